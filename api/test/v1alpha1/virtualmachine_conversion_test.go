@@ -401,6 +401,26 @@ func TestVirtualMachineConversion(t *testing.T) {
 		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
 	})
 
+	t.Run("VirtualMachine hub-spoke-hub with spec.profileName", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ProfileName: "my-profile",
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
+	t.Run("VirtualMachine hub-spoke-hub with spec.driftPolicy", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				DriftPolicy: vmopv1.VirtualMachineDriftPolicyReportOnly,
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
 	t.Run("VirtualMachine hub-spoke-hub with CloudInit", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -453,6 +453,93 @@ func TestVirtualMachineConversion(t *testing.T) {
 		hubSpokeHub(g, &hub, &vmopv1.VirtualMachine{}, &vmopv1a2.VirtualMachine{})
 	})
 
+	t.Run("VirtualMachine hub-spoke-hub with spec.network.interfaces.ipPoolName", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name:       "eth0",
+							IPPoolName: "my-pool",
+						},
+						{
+							Name: "eth1",
+						},
+					},
+				},
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1.VirtualMachine{}, &vmopv1a2.VirtualMachine{})
+	})
+
+	t.Run("VirtualMachine hub-spoke-hub with spec.profileName", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ProfileName: "my-profile",
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1.VirtualMachine{}, &vmopv1a2.VirtualMachine{})
+	})
+
+	t.Run("VirtualMachine hub-spoke-hub with spec.driftPolicy", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				DriftPolicy: vmopv1.VirtualMachineDriftPolicyAdopt,
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1.VirtualMachine{}, &vmopv1a2.VirtualMachine{})
+	})
+
+	t.Run("VirtualMachine hub-spoke-hub with spec.network bonds, vlans, and bridges", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name: "eth0",
+						},
+						{
+							Name: "eth1",
+						},
+					},
+					Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+						{
+							Name:       "bond0",
+							Interfaces: []string{"eth0", "eth1"},
+							Mode:       vmopv1.VirtualMachineNetworkBondMode8023AD,
+							LACPRate:   vmopv1.VirtualMachineNetworkBondLACPRateFast,
+						},
+					},
+					VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+						{
+							Name: "bond0.100",
+							ID:   100,
+							Link: "bond0",
+							VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+								Addresses: []string{"192.168.100.10/24"},
+								Gateway4:  "192.168.100.1",
+							},
+						},
+					},
+					Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+						{
+							Name:       "br0",
+							Interfaces: []string{"bond0"},
+							VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+								DHCP4: true,
+							},
+						},
+					},
+				},
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1.VirtualMachine{}, &vmopv1a2.VirtualMachine{})
+	})
+
 	t.Run("VirtualMachine status.storage", func(t *testing.T) {
 		t.Run("hub-spoke-hub", func(t *testing.T) {
 			g := NewWithT(t)
@@ -381,6 +381,85 @@ func TestVirtualMachineConversion(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "spec.network.interfaces.ipPoolName",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						Network: &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:       "eth0",
+									IPPoolName: "my-pool",
+								},
+								{
+									Name: "eth1",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "spec.profileName",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						ProfileName: "my-profile",
+					},
+				},
+			},
+			{
+				name: "spec.driftPolicy",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						DriftPolicy: vmopv1.VirtualMachineDriftPolicyAutoCorrect,
+					},
+				},
+			},
+			{
+				name: "spec.network.bonds, vlans, and bridges",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						Network: &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+								{
+									Name: "eth1",
+								},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0", "eth1"},
+									Mode:       vmopv1.VirtualMachineNetworkBondMode8023AD,
+									LACPRate:   vmopv1.VirtualMachineNetworkBondLACPRateFast,
+								},
+							},
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{
+									Name: "bond0.100",
+									ID:   100,
+									Link: "bond0",
+									VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+										Addresses: []string{"192.168.100.10/24"},
+										Gateway4:  "192.168.100.1",
+									},
+								},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"bond0"},
+									VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+										DHCP4: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				name: "spec.groupName",
 				hub: &vmopv1.VirtualMachine{
@@ -49,6 +49,46 @@ func TestVirtualMachinePublishRequestConversion(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "oci target and artifact",
+				hub: &vmopv1.VirtualMachinePublishRequest{
+					Spec: vmopv1.VirtualMachinePublishRequestSpec{
+						BackoffLimit: 3,
+						Target: vmopv1.VirtualMachinePublishRequestTarget{
+							OCI: &vmopv1.VirtualMachinePublishRequestOCITarget{
+								Reference:      "registry.example.com/images/my-vm:v1",
+								PushSecretName: "my-secret",
+								Insecure:       true,
+							},
+						},
+					},
+					Status: vmopv1.VirtualMachinePublishRequestStatus{
+						TargetRef: &vmopv1.VirtualMachinePublishRequestTarget{
+							OCI: &vmopv1.VirtualMachinePublishRequestOCITarget{
+								Reference: "registry.example.com/images/my-vm:v1",
+							},
+						},
+						Artifact: &vmopv1.VirtualMachinePublishRequestArtifactStatus{
+							Reference: "registry.example.com/images/my-vm@sha256:abc",
+							Digest:    "sha256:abc",
+							Size:      1024,
+						},
+					},
+				},
+			},
+			{
+				name: "http target",
+				hub: &vmopv1.VirtualMachinePublishRequest{
+					Spec: vmopv1.VirtualMachinePublishRequestSpec{
+						BackoffLimit: 3,
+						Target: vmopv1.VirtualMachinePublishRequestTarget{
+							HTTP: &vmopv1.VirtualMachinePublishRequestHTTPTarget{
+								URLSecretName: "my-secret",
+							},
+						},
+					},
+				},
+			},
 		}
 
 		for i := range testCases {
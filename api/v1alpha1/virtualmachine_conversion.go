@@ -819,6 +819,14 @@ func Convert_v1alpha5_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(
 	return nil
 }
 
+func restore_v1alpha5_VirtualMachineDriftPolicy(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.DriftPolicy = src.Spec.DriftPolicy
+}
+
+func restore_v1alpha5_VirtualMachineProfileName(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.ProfileName = src.Spec.ProfileName
+}
+
 func restore_v1alpha5_VirtualMachineGroupName(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GroupName = src.Spec.GroupName
 }
@@ -1306,6 +1314,8 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha5_VirtualMachineVolumes(dst, restored)
 	restore_v1alpha5_VirtualMachineHardware(dst, restored)
 	restore_v1alpha5_VirtualMachinePolicies(dst, restored)
+	restore_v1alpha5_VirtualMachineDriftPolicy(dst, restored)
+	restore_v1alpha5_VirtualMachineProfileName(dst, restored)
 
 	// END RESTORE
 
@@ -25,6 +25,7 @@ func (src *VirtualMachinePublishRequest) ConvertTo(dstRaw ctrlconversion.Hub) er
 	}
 
 	dst.Spec.BackoffLimit = restored.Spec.BackoffLimit
+	restore_v1alpha5_VirtualMachinePublishRequestArtifactTarget(dst, restored)
 
 	return nil
 }
@@ -60,3 +61,27 @@ func Convert_v1alpha5_VirtualMachinePublishRequestSpec_To_v1alpha1_VirtualMachin
 
 	return nil
 }
+
+func Convert_v1alpha5_VirtualMachinePublishRequestTarget_To_v1alpha1_VirtualMachinePublishRequestTarget(
+	in *vmopv1.VirtualMachinePublishRequestTarget, out *VirtualMachinePublishRequestTarget, s conversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachinePublishRequestTarget_To_v1alpha1_VirtualMachinePublishRequestTarget(in, out, s)
+}
+
+func Convert_v1alpha5_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(
+	in *vmopv1.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in, out, s)
+}
+
+func restore_v1alpha5_VirtualMachinePublishRequestArtifactTarget(
+	dst, src *vmopv1.VirtualMachinePublishRequest) {
+
+	dst.Spec.Target.OCI = src.Spec.Target.OCI
+	dst.Spec.Target.HTTP = src.Spec.Target.HTTP
+	if dst.Status.TargetRef != nil && src.Status.TargetRef != nil {
+		dst.Status.TargetRef.OCI = src.Status.TargetRef.OCI
+		dst.Status.TargetRef.HTTP = src.Status.TargetRef.HTTP
+	}
+	dst.Status.Artifact = src.Status.Artifact
+}
@@ -15,6 +15,12 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 )
 
+func Convert_v1alpha5_AffinitySpec_To_v1alpha2_AffinitySpec(
+	in *vmopv1.AffinitySpec, out *AffinitySpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_AffinitySpec_To_v1alpha2_AffinitySpec(in, out, s)
+}
+
 func Convert_v1alpha5_VirtualMachineVolume_To_v1alpha2_VirtualMachineVolume(
 	in *vmopv1.VirtualMachineVolume, out *VirtualMachineVolume, s apiconversion.Scope) error {
 
@@ -359,6 +365,52 @@ func restore_v1alpha5_VirtualMachineBootOptions(dst, src *vmopv1.VirtualMachine)
 	dst.Spec.BootOptions = src.Spec.BootOptions
 }
 
+func Convert_v1alpha5_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(
+	in *vmopv1.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in, out, s)
+}
+
+func Convert_v1alpha5_VirtualMachineNetworkConfigStatus_To_v1alpha2_VirtualMachineNetworkConfigStatus(
+	in *vmopv1.VirtualMachineNetworkConfigStatus, out *VirtualMachineNetworkConfigStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineNetworkConfigStatus_To_v1alpha2_VirtualMachineNetworkConfigStatus(in, out, s)
+}
+
+func restore_v1alpha5_VirtualMachineDriftPolicy(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.DriftPolicy = src.Spec.DriftPolicy
+}
+
+func restore_v1alpha5_VirtualMachineProfileName(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.ProfileName = src.Spec.ProfileName
+}
+
+func restore_v1alpha5_VirtualMachineNetworkGuestDevices(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+
+	dst.Spec.Network.Bonds = src.Spec.Network.Bonds
+	dst.Spec.Network.VLANs = src.Spec.Network.VLANs
+	dst.Spec.Network.Bridges = src.Spec.Network.Bridges
+}
+
+func restore_v1alpha5_VirtualMachineNetworkInterfaceIPPoolName(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for _, srcIface := range src.Spec.Network.Interfaces {
+			if srcIface.Name == dstIface.Name {
+				dstIface.IPPoolName = srcIface.IPPoolName
+				break
+			}
+		}
+	}
+}
+
 func restore_v1alpha5_VirtualMachineVolumes(dst, src *vmopv1.VirtualMachine) {
 	srcVolMap := map[string]*vmopv1.VirtualMachineVolume{}
 	for i := range src.Spec.Volumes {
@@ -431,6 +483,10 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha5_VirtualMachinePromoteDisksMode(dst, restored)
 	restore_v1alpha5_VirtualMachineBootOptions(dst, restored)
 	restore_v1alpha5_VirtualMachineVolumes(dst, restored)
+	restore_v1alpha5_VirtualMachineNetworkInterfaceIPPoolName(dst, restored)
+	restore_v1alpha5_VirtualMachineNetworkGuestDevices(dst, restored)
+	restore_v1alpha5_VirtualMachineDriftPolicy(dst, restored)
+	restore_v1alpha5_VirtualMachineProfileName(dst, restored)
 	restore_v1alpha5_VirtualMachineHardware(dst, restored)
 	restore_v1alpha5_VirtualMachinePolicies(dst, restored)
 	restore_v1alpha5_VirtualMachineCryptoVTPM(dst, restored)
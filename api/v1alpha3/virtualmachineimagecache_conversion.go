@@ -5,12 +5,46 @@
 package v1alpha3
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 )
 
+func Convert_v1alpha5_VirtualMachineImageCacheLocationSpec_To_v1alpha3_VirtualMachineImageCacheLocationSpec(
+	in *vmopv1.VirtualMachineImageCacheLocationSpec, out *VirtualMachineImageCacheLocationSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineImageCacheLocationSpec_To_v1alpha3_VirtualMachineImageCacheLocationSpec(in, out, s)
+}
+
+func Convert_v1alpha5_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(
+	in *vmopv1.VirtualMachineImageCacheLocationStatus, out *VirtualMachineImageCacheLocationStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(in, out, s)
+}
+
+func Convert_v1alpha5_VirtualMachineImageCacheStatus_To_v1alpha3_VirtualMachineImageCacheStatus(
+	in *vmopv1.VirtualMachineImageCacheStatus, out *VirtualMachineImageCacheStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineImageCacheStatus_To_v1alpha3_VirtualMachineImageCacheStatus(in, out, s)
+}
+
+func restore_v1alpha5_VirtualMachineImageCacheLocationsLastUsedTime(dst, src *vmopv1.VirtualMachineImageCache) {
+	for i := range dst.Spec.Locations {
+		d := &dst.Spec.Locations[i]
+		for _, s := range src.Spec.Locations {
+			if d.DatacenterID == s.DatacenterID &&
+				d.DatastoreID == s.DatastoreID &&
+				d.ProfileID == s.ProfileID {
+
+				d.LastUsedTime = s.LastUsedTime
+				break
+			}
+		}
+	}
+}
+
 // ConvertTo converts this VirtualMachineImageCache to the Hub version.
 func (src *VirtualMachineImageCache) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineImageCache)
@@ -24,6 +58,7 @@ func (src *VirtualMachineImageCache) ConvertTo(dstRaw ctrlconversion.Hub) error
 		return err
 	}
 
+	restore_v1alpha5_VirtualMachineImageCacheLocationsLastUsedTime(dst, restored)
 	dst.Status = restored.Status
 
 	return nil
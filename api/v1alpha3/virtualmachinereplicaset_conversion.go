@@ -5,12 +5,19 @@
 package v1alpha3
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 )
 
+func Convert_v1alpha5_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(
+	in *vmopv1.VirtualMachineReplicaSetSpec, out *VirtualMachineReplicaSetSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha5_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(in, out, s)
+}
+
 // ConvertTo converts this VirtualMachineReplicaSet to the Hub version.
 func (src *VirtualMachineReplicaSet) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineReplicaSet)
@@ -24,6 +31,7 @@ func (src *VirtualMachineReplicaSet) ConvertTo(dstRaw ctrlconversion.Hub) error
 		return err
 	}
 
+	dst.Spec.ImageStreamUpdatePolicy = restored.Spec.ImageStreamUpdatePolicy
 	dst.Status = restored.Status
 
 	return nil
@@ -99,6 +99,104 @@ type VMAntiAffinitySpec struct {
 	PreferredDuringSchedulingPreferredDuringExecution []VMAffinityTerm `json:"preferredDuringSchedulingPreferredDuringExecution,omitempty"`
 }
 
+// ZoneSelectorTerm defines a term that selects zones.
+type ZoneSelectorTerm struct {
+	// +optional
+
+	// LabelSelector is a label query over the zones available to the VM's
+	// namespace, i.e. the Zone resources in the namespace, or the
+	// AvailabilityZone resources when the namespace does not have Zones.
+	// When omitted, this term matches with no zones.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// ZoneAffinitySpec defines the affinity requirements for scheduling rules
+// related to zones.
+type ZoneAffinitySpec struct {
+	// +optional
+	// +listType=atomic
+
+	// RequiredDuringSchedulingIgnoredDuringExecution describes the zones in
+	// which the VM must be placed. The VM will not be scheduled if no zone
+	// satisfies the requirements.
+	//
+	// When there are multiple elements, the lists of zones corresponding to
+	// each term are intersected, i.e. all terms must be satisfied.
+	RequiredDuringSchedulingIgnoredDuringExecution []ZoneSelectorTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// PreferredDuringSchedulingIgnoredDuringExecution describes the zones in
+	// which the VM should be placed. The scheduler prefers the zones that
+	// satisfy the most terms, but the VM may still be scheduled in a zone
+	// that satisfies none of them.
+	PreferredDuringSchedulingIgnoredDuringExecution []ZoneSelectorTerm `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// HostSelectorOperator is the relationship between a host's tags and the tags
+// in a HostSelectorTerm.
+//
+// +kubebuilder:validation:Enum=In;NotIn
+type HostSelectorOperator string
+
+const (
+	// HostSelectorOpIn selects the hosts that have any of the tags.
+	HostSelectorOpIn HostSelectorOperator = "In"
+
+	// HostSelectorOpNotIn selects the hosts that have none of the tags.
+	HostSelectorOpNotIn HostSelectorOperator = "NotIn"
+)
+
+// HostSelectorTerm defines a term that selects hosts by the vSphere tags
+// attached to the hosts or to their clusters.
+type HostSelectorTerm struct {
+	// Category is the name of the vSphere tag category of the tags.
+	Category string `json:"category"`
+
+	// +optional
+	// +kubebuilder:default=In
+
+	// Operator describes the relationship between the host's tags and the
+	// tags in this term.
+	//
+	// Defaults to In.
+	Operator HostSelectorOperator `json:"operator,omitempty"`
+
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+
+	// Tags is the list of names of the vSphere tags in the category.
+	//
+	// A tag is considered attached to a host if it is attached to either the
+	// host or the cluster that contains the host.
+	Tags []string `json:"tags"`
+}
+
+// HostAffinitySpec defines the affinity requirements for scheduling rules
+// related to hosts.
+type HostAffinitySpec struct {
+	// +optional
+	// +listType=atomic
+
+	// RequiredDuringSchedulingIgnoredDuringExecution describes the hosts on
+	// which the VM must be placed. The VM will not be scheduled if no host
+	// satisfies the requirements.
+	//
+	// When there are multiple elements, the lists of hosts corresponding to
+	// each term are intersected, i.e. all terms must be satisfied.
+	RequiredDuringSchedulingIgnoredDuringExecution []HostSelectorTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// PreferredDuringSchedulingIgnoredDuringExecution describes the hosts on
+	// which the VM should be placed. The scheduler prefers the hosts that
+	// satisfy the most terms, but the VM may still be scheduled on a host
+	// that satisfies none of them.
+	PreferredDuringSchedulingIgnoredDuringExecution []HostSelectorTerm `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
 // AffinitySpec defines the group of affinity scheduling rules.
 type AffinitySpec struct {
 	// +optional
@@ -111,4 +209,19 @@ type AffinitySpec struct {
 	// VMAntiAffinity describes anti-affinity scheduling rules related to other
 	// VMs.
 	VMAntiAffinity *VMAntiAffinitySpec `json:"vmAntiAffinity,omitempty"`
+
+	// +optional
+
+	// ZoneAffinity describes scheduling rules related to the labels of zones.
+	//
+	// Please note, these rules are only considered when the VM is placed in a
+	// zone, i.e. they are ignored if the VM's zone label is set when the VM is
+	// created.
+	ZoneAffinity *ZoneAffinitySpec `json:"zoneAffinity,omitempty"`
+
+	// +optional
+
+	// HostAffinity describes scheduling rules related to the vSphere tags of
+	// hosts and clusters. When set, placement also selects the VM's host.
+	HostAffinity *HostAffinitySpec `json:"hostAffinity,omitempty"`
 }
@@ -0,0 +1,90 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+// +kubebuilder:validation:Enum=ReportOnly;AutoCorrect;Adopt
+
+// VirtualMachineDriftPolicy describes what happens when the configuration of
+// the underlying vSphere VM is changed out of band, ex. directly in vCenter,
+// and no longer matches the configuration derived from the VM's spec and
+// class.
+type VirtualMachineDriftPolicy string
+
+const (
+	// VirtualMachineDriftPolicyReportOnly indicates the drift is reported and
+	// the diverged properties are not changed on the vSphere VM.
+	VirtualMachineDriftPolicyReportOnly VirtualMachineDriftPolicy = "ReportOnly"
+
+	// VirtualMachineDriftPolicyAutoCorrect indicates the drift is reported
+	// and the diverged properties are reverted to the values derived from the
+	// VM's spec and class.
+	VirtualMachineDriftPolicyAutoCorrect VirtualMachineDriftPolicy = "AutoCorrect"
+
+	// VirtualMachineDriftPolicyAdopt indicates the values of the diverged
+	// properties on the vSphere VM are adopted as the VM's desired values.
+	// The adopted values are reported once, and are then neither reported nor
+	// reverted until they or the values derived from the VM's spec and class
+	// change.
+	VirtualMachineDriftPolicyAdopt VirtualMachineDriftPolicy = "Adopt"
+)
+
+// +kubebuilder:validation:Enum=Reported;Corrected;Adopted
+
+// VirtualMachineDriftAction describes what happened to a diverged property.
+type VirtualMachineDriftAction string
+
+const (
+	// VirtualMachineDriftActionReported indicates the diverged property was
+	// not changed on the vSphere VM.
+	VirtualMachineDriftActionReported VirtualMachineDriftAction = "Reported"
+
+	// VirtualMachineDriftActionCorrected indicates the diverged property was
+	// reverted to its desired value on the vSphere VM.
+	VirtualMachineDriftActionCorrected VirtualMachineDriftAction = "Corrected"
+
+	// VirtualMachineDriftActionAdopted indicates the value of the diverged
+	// property on the vSphere VM was adopted as its desired value.
+	VirtualMachineDriftActionAdopted VirtualMachineDriftAction = "Adopted"
+)
+
+// VirtualMachineDriftStatus describes a property of the vSphere VM whose value
+// diverged from the value derived from the VM's spec and class.
+type VirtualMachineDriftStatus struct {
+	// Property is the path of the diverged property on the vSphere VM, ex.
+	// config.hardware.numCPU or config.extraConfig["key"].
+	Property string `json:"property"`
+
+	// +optional
+
+	// Desired is the value derived from the VM's spec and class.
+	Desired string `json:"desired,omitempty"`
+
+	// +optional
+
+	// Actual is the value observed on the vSphere VM.
+	Actual string `json:"actual,omitempty"`
+
+	// Action describes what happened to the diverged property.
+	Action VirtualMachineDriftAction `json:"action"`
+}
+
+const (
+	// VirtualMachineDriftDetectedCondition exposes whether the configuration
+	// of the vSphere VM diverged from the configuration derived from the VM's
+	// spec and class. It is only set when the VM has a drift policy.
+	VirtualMachineDriftDetectedCondition = "DriftDetected"
+
+	// VirtualMachineDriftReportedReason documents that the diverged properties
+	// were not changed on the vSphere VM.
+	VirtualMachineDriftReportedReason = "Reported"
+
+	// VirtualMachineDriftCorrectedReason documents that one or more of the
+	// diverged properties were reverted on the vSphere VM.
+	VirtualMachineDriftCorrectedReason = "Corrected"
+
+	// VirtualMachineDriftAdoptedReason documents that the values of the
+	// diverged properties on the vSphere VM were adopted.
+	VirtualMachineDriftAdoptedReason = "Adopted"
+)
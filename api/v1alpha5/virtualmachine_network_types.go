@@ -87,6 +87,20 @@ type VirtualMachineNetworkInterfaceSpec struct {
 
 	// +optional
 
+	// IPPoolName is the name of a VirtualMachineIPPool in the VM's namespace
+	// from which this interface is allocated a static address, prefix, and
+	// gateway when the VM is created. The address is released when the VM is
+	// deleted.
+	//
+	// Please note this field is only supported when the network provider is
+	// NAMED or VSPHERE_NETWORK.
+	//
+	// Please note this field is mutually exclusive with the Addresses, DHCP4,
+	// and DHCP6 fields.
+	IPPoolName string `json:"ipPoolName,omitempty"`
+
+	// +optional
+
 	// DHCP4 indicates whether or not this interface uses DHCP for IP4
 	// networking.
 	//
@@ -181,6 +195,232 @@ type VirtualMachineNetworkInterfaceSpec struct {
 	SearchDomains []string `json:"searchDomains,omitempty"`
 }
 
+// VirtualMachineNetworkGuestDeviceIPSpec describes the IP configuration of a
+// guest network device that is composed of the VM's network interfaces, such
+// as a bond, VLAN, or bridge.
+type VirtualMachineNetworkGuestDeviceIPSpec struct {
+	// +optional
+
+	// Addresses is an optional list of IP4 or IP6 addresses to assign to this
+	// device.
+	//
+	// Please note IP4 and IP6 addresses must include the network prefix length,
+	// ex. 192.168.0.10/24 or 2001:db8:101::a/64.
+	//
+	// Please note this field may not contain IP4 addresses if DHCP4 is set
+	// to true or IP6 addresses if DHCP6 is set to true.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// +optional
+
+	// DHCP4 indicates whether or not this device uses DHCP for IP4
+	// networking.
+	DHCP4 bool `json:"dhcp4,omitempty"`
+
+	// +optional
+
+	// DHCP6 indicates whether or not this device uses DHCP for IP6
+	// networking.
+	DHCP6 bool `json:"dhcp6,omitempty"`
+
+	// +optional
+
+	// Gateway4 is the default, IP4 gateway for this device.
+	//
+	// Please note this field is mutually exclusive with DHCP4.
+	Gateway4 string `json:"gateway4,omitempty"`
+
+	// +optional
+
+	// Gateway6 is the primary IP6 gateway for this device.
+	//
+	// Please note this field is mutually exclusive with DHCP6.
+	Gateway6 string `json:"gateway6,omitempty"`
+
+	// +optional
+
+	// MTU is the Maximum Transmission Unit size in bytes.
+	MTU *int64 `json:"mtu,omitempty"`
+
+	// +optional
+
+	// Nameservers is a list of IP4 and/or IP6 addresses used as DNS
+	// nameservers.
+	//
+	// When UseGlobalNameserversAsDefault is either unset or true, if
+	// nameservers is not provided, the global nameservers will be used
+	// instead.
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +optional
+
+	// Routes is a list of optional, static routes.
+	Routes []VirtualMachineNetworkRouteSpec `json:"routes,omitempty"`
+
+	// +optional
+
+	// SearchDomains is a list of search domains used when resolving IP
+	// addresses with DNS.
+	//
+	// When UseGlobalSearchDomainsAsDefault is either unset or true, if search
+	// domains is not provided, the global search domains will be used instead.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=balance-rr;active-backup;balance-xor;broadcast;"802.3ad";balance-tlb;balance-alb
+
+// VirtualMachineNetworkBondMode describes the bonding policy of a bond.
+type VirtualMachineNetworkBondMode string
+
+const (
+	VirtualMachineNetworkBondModeBalanceRR    VirtualMachineNetworkBondMode = "balance-rr"
+	VirtualMachineNetworkBondModeActiveBackup VirtualMachineNetworkBondMode = "active-backup"
+	VirtualMachineNetworkBondModeBalanceXOR   VirtualMachineNetworkBondMode = "balance-xor"
+	VirtualMachineNetworkBondModeBroadcast    VirtualMachineNetworkBondMode = "broadcast"
+	VirtualMachineNetworkBondMode8023AD       VirtualMachineNetworkBondMode = "802.3ad"
+	VirtualMachineNetworkBondModeBalanceTLB   VirtualMachineNetworkBondMode = "balance-tlb"
+	VirtualMachineNetworkBondModeBalanceALB   VirtualMachineNetworkBondMode = "balance-alb"
+)
+
+// +kubebuilder:validation:Enum=slow;fast
+
+// VirtualMachineNetworkBondLACPRate describes the rate at which a bond
+// transmits LACPDUs.
+type VirtualMachineNetworkBondLACPRate string
+
+const (
+	VirtualMachineNetworkBondLACPRateSlow VirtualMachineNetworkBondLACPRate = "slow"
+	VirtualMachineNetworkBondLACPRateFast VirtualMachineNetworkBondLACPRate = "fast"
+)
+
+// +kubebuilder:validation:Enum=layer2;"layer3+4";"layer2+3";"encap2+3";"encap3+4"
+
+// VirtualMachineNetworkBondTransmitHashPolicy describes how a bond selects the
+// interface used to transmit a packet.
+type VirtualMachineNetworkBondTransmitHashPolicy string
+
+const (
+	VirtualMachineNetworkBondTransmitHashPolicyLayer2  VirtualMachineNetworkBondTransmitHashPolicy = "layer2"
+	VirtualMachineNetworkBondTransmitHashPolicyLayer34 VirtualMachineNetworkBondTransmitHashPolicy = "layer3+4"
+	VirtualMachineNetworkBondTransmitHashPolicyLayer23 VirtualMachineNetworkBondTransmitHashPolicy = "layer2+3"
+	VirtualMachineNetworkBondTransmitHashPolicyEncap23 VirtualMachineNetworkBondTransmitHashPolicy = "encap2+3"
+	VirtualMachineNetworkBondTransmitHashPolicyEncap34 VirtualMachineNetworkBondTransmitHashPolicy = "encap3+4"
+)
+
+// VirtualMachineNetworkBondSpec describes a bond inside the guest that
+// aggregates two or more of the VM's network interfaces.
+type VirtualMachineNetworkBondSpec struct {
+	// +kubebuilder:validation:Pattern="^[a-z0-9]{2,}$"
+	// +kubebuilder:validation:MaxLength=15
+
+	// Name describes the name of the bond device inside the guest.
+	//
+	// Please note the name must be unique across the network interfaces,
+	// bonds, VLANs, and bridges.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Interfaces is the list of the names of the network interfaces from
+	// spec.network.interfaces that are aggregated by this bond.
+	//
+	// Please note an interface may be a member of only one bond or bridge, and
+	// the IP configuration of a member interface is not used.
+	Interfaces []string `json:"interfaces"`
+
+	// Mode describes the bonding policy, ex. active-backup or 802.3ad for
+	// LACP.
+	Mode VirtualMachineNetworkBondMode `json:"mode"`
+
+	// +optional
+
+	// LACPRate describes the rate at which LACPDUs are transmitted.
+	//
+	// Please note this field is only supported when Mode is 802.3ad.
+	LACPRate VirtualMachineNetworkBondLACPRate `json:"lacpRate,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// MIIMonitorInterval describes how often, in milliseconds, the link state
+	// of the bond's interfaces is inspected.
+	MIIMonitorInterval *int32 `json:"miiMonitorInterval,omitempty"`
+
+	// +optional
+
+	// TransmitHashPolicy describes how the interface used to transmit a packet
+	// is selected.
+	//
+	// Please note this field is only supported when Mode is balance-xor,
+	// 802.3ad, or balance-tlb.
+	TransmitHashPolicy VirtualMachineNetworkBondTransmitHashPolicy `json:"transmitHashPolicy,omitempty"`
+
+	// +optional
+
+	// Primary is the name of the interface that is used while it is available.
+	//
+	// Please note this field is only supported when Mode is active-backup,
+	// balance-alb, or balance-tlb, and must be one of the bond's interfaces.
+	Primary string `json:"primary,omitempty"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkVLANSpec describes a tagged VLAN sub-interface inside
+// the guest.
+type VirtualMachineNetworkVLANSpec struct {
+	// +kubebuilder:validation:Pattern="^[a-z0-9.]{2,}$"
+	// +kubebuilder:validation:MaxLength=15
+
+	// Name describes the name of the VLAN device inside the guest, ex.
+	// eth0.100.
+	//
+	// Please note the name must be unique across the network interfaces,
+	// bonds, VLANs, and bridges.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+
+	// ID is the VLAN ID.
+	ID int32 `json:"id"`
+
+	// Link is the name of the network interface from spec.network.interfaces or
+	// the bond from spec.network.bonds on which the VLAN is created.
+	Link string `json:"link"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkBridgeSpec describes a bridge inside the guest.
+type VirtualMachineNetworkBridgeSpec struct {
+	// +kubebuilder:validation:Pattern="^[a-z0-9]{2,}$"
+	// +kubebuilder:validation:MaxLength=15
+
+	// Name describes the name of the bridge device inside the guest.
+	//
+	// Please note the name must be unique across the network interfaces,
+	// bonds, VLANs, and bridges.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Interfaces is the list of the names of the network interfaces, bonds, or
+	// VLANs that are connected to this bridge.
+	//
+	// Please note a network interface or bond may be a member of only one bond
+	// or bridge, and the IP configuration of a member is not used.
+	Interfaces []string `json:"interfaces"`
+
+	// +optional
+
+	// STP describes whether or not the bridge uses the Spanning Tree Protocol.
+	// Defaults to true.
+	STP *bool `json:"stp,omitempty"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
+}
+
 // VirtualMachineNetworkSpec defines a VM's desired network configuration.
 type VirtualMachineNetworkSpec struct {
 	// +optional
@@ -292,6 +532,40 @@ type VirtualMachineNetworkSpec struct {
 	// The maximum number of network interface allowed is 10 because a vSphere
 	// virtual machine may not have more than 10 virtual ethernet card devices.
 	Interfaces []VirtualMachineNetworkInterfaceSpec `json:"interfaces,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+
+	// Bonds is the list of bonds inside the guest that aggregate the VM's
+	// network interfaces, ex. for active-backup or LACP link aggregation.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bonds []VirtualMachineNetworkBondSpec `json:"bonds,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+
+	// VLANs is the list of tagged VLAN sub-interfaces inside the guest.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	VLANs []VirtualMachineNetworkVLANSpec `json:"vlans,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+
+	// Bridges is the list of bridges inside the guest.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bridges []VirtualMachineNetworkBridgeSpec `json:"bridges,omitempty"`
 }
 
 // VirtualMachineNetworkDNSStatus describes the observed state of the guest's
@@ -631,6 +905,43 @@ type VirtualMachineNetworkConfigInterfaceStatus struct {
 	DNS *VirtualMachineNetworkConfigDNSStatus `json:"dns,omitempty"`
 }
 
+// VirtualMachineNetworkGuestDeviceType describes the type of a guest network
+// device that is composed of the VM's network interfaces.
+type VirtualMachineNetworkGuestDeviceType string
+
+const (
+	VirtualMachineNetworkGuestDeviceTypeBond   VirtualMachineNetworkGuestDeviceType = "Bond"
+	VirtualMachineNetworkGuestDeviceTypeVLAN   VirtualMachineNetworkGuestDeviceType = "VLAN"
+	VirtualMachineNetworkGuestDeviceTypeBridge VirtualMachineNetworkGuestDeviceType = "Bridge"
+)
+
+// VirtualMachineNetworkConfigDeviceStatus describes the configured state of a
+// guest network device, such as a bond, VLAN, or bridge.
+type VirtualMachineNetworkConfigDeviceStatus struct {
+	// Name describes the corresponding bond, VLAN, or bridge with the same name
+	// in the VM's desired network configuration.
+	Name string `json:"name"`
+
+	// Type describes the type of the device.
+	Type VirtualMachineNetworkGuestDeviceType `json:"type"`
+
+	// +optional
+
+	// Interfaces describes the members of a bond or bridge, or the link of a
+	// VLAN.
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// +optional
+
+	// IP describes the device's configured IP information.
+	IP *VirtualMachineNetworkConfigInterfaceIPStatus `json:"ip,omitempty"`
+
+	// +optional
+
+	// DNS describes the device's configured DNS information.
+	DNS *VirtualMachineNetworkConfigDNSStatus `json:"dns,omitempty"`
+}
+
 // VirtualMachineNetworkIPStackStatus describes the observed state of a
 // VM's IP stack.
 type VirtualMachineNetworkIPStackStatus struct {
@@ -736,6 +1047,12 @@ type VirtualMachineNetworkConfigStatus struct {
 
 	// +optional
 
+	// Devices describes the configured state of the bonds, VLANs, and bridges
+	// composed of the network interfaces.
+	Devices []VirtualMachineNetworkConfigDeviceStatus `json:"devices,omitempty"`
+
+	// +optional
+
 	// DNS describes the configured state of client-side DNS.
 	DNS *VirtualMachineNetworkConfigDNSStatus `json:"dns,omitempty"`
 }
@@ -91,6 +91,16 @@ const (
 	VirtualMachineGuestNetworkConfigSynced = "VirtualMachineGuestNetworkConfigSynced"
 )
 
+const (
+	// VirtualMachinePlacementNoZoneAffinityMatchReason indicates that none of
+	// the VM's candidate zones satisfy its required zone affinity.
+	VirtualMachinePlacementNoZoneAffinityMatchReason = "NoZoneAffinityMatch"
+
+	// VirtualMachinePlacementNoHostAffinityMatchReason indicates that none of
+	// the VM's candidate hosts satisfy its required host affinity.
+	VirtualMachinePlacementNoHostAffinityMatchReason = "NoHostAffinityMatch"
+)
+
 const (
 	// VirtualMachineSnapshotRevertSucceeded indicates that the VM
 	// has been reverted to a snapshot.
@@ -144,6 +154,18 @@ const (
 	GuestIDReconfiguredCondition = "GuestIDReconfigured"
 )
 
+const (
+	// GuestPropertiesCondition exposes whether all of the application metadata
+	// published by the guest is reported in status.guest.properties. It is
+	// only set when some of the metadata is not reported.
+	GuestPropertiesCondition = "GuestProperties"
+
+	// GuestPropertiesLimitExceededReason documents that some of the
+	// application metadata published by the guest is not reported because it
+	// exceeds the size or key limits.
+	GuestPropertiesLimitExceededReason = "LimitExceeded"
+)
+
 const (
 	// GuestCustomizationCondition exposes the status of guest customization
 	// from within the guest OS, when available.
@@ -300,6 +322,76 @@ const (
 	VirtualMachineBackupFailedReason = "VirtualMachineBackupFailed"
 )
 
+const (
+	// VirtualMachinePlacementOptimalCondition exposes whether the VM's
+	// current host satisfies its preferred affinity and anti-affinity rules
+	// across hosts. It is only set when the rebalancer is enabled.
+	VirtualMachinePlacementOptimalCondition = "PlacementOptimal"
+
+	// VirtualMachinePlacementPreferredRulesViolatedReason documents that some
+	// of the VM's preferred rules are violated on its current host, and the
+	// VM is waiting to be moved to a host on which fewer of them are.
+	VirtualMachinePlacementPreferredRulesViolatedReason = "PreferredRulesViolated"
+
+	// VirtualMachinePlacementNoBetterHostReason documents that some of the
+	// VM's preferred rules are violated on its current host, but there is no
+	// eligible host on which fewer of them are.
+	VirtualMachinePlacementNoBetterHostReason = "NoBetterHost"
+
+	// VirtualMachinePlacementDisruptionBudgetExceededReason documents that the
+	// VM was not moved because doing so would exceed a
+	// VirtualMachineDisruptionBudget that selects the VM.
+	VirtualMachinePlacementDisruptionBudgetExceededReason = "DisruptionBudgetExceeded"
+
+	// VirtualMachinePlacementRelocateFailedReason documents that the VM could
+	// not be moved to a host on which fewer of its preferred rules are
+	// violated.
+	VirtualMachinePlacementRelocateFailedReason = "RelocateFailed"
+)
+
+const (
+	// VirtualMachineDNSRecordsSyncedCondition exposes whether the VM's host
+	// name and primary IP addresses are registered in DNS. It is only set when
+	// dynamic DNS is enabled.
+	VirtualMachineDNSRecordsSyncedCondition = "DNSRecordsSynced"
+
+	// VirtualMachineDNSRecordsWaitingForAddressReason documents that the VM
+	// is not registered in DNS because it does not have a primary IP address.
+	VirtualMachineDNSRecordsWaitingForAddressReason = "WaitingForAddress"
+
+	// VirtualMachineDNSRecordsNoDomainNameReason documents that the VM is not
+	// registered in DNS because it does not have a domain name.
+	VirtualMachineDNSRecordsNoDomainNameReason = "NoDomainName"
+
+	// VirtualMachineDNSRecordsZoneNotFoundReason documents that the VM is not
+	// registered in DNS because its domain is not in any of the configured
+	// zones.
+	VirtualMachineDNSRecordsZoneNotFoundReason = "ZoneNotFound"
+
+	// VirtualMachineDNSRecordsUpdateFailedReason documents that the VM's DNS
+	// records could not be updated.
+	VirtualMachineDNSRecordsUpdateFailedReason = "UpdateFailed"
+
+	// VirtualMachineDNSRecordsNameInUseReason documents that the VM is not
+	// registered in DNS because one of its names already has records that
+	// were not registered for the VM.
+	VirtualMachineDNSRecordsNameInUseReason = "NameInUse"
+)
+
+const (
+	// VirtualMachineZoneDrainingCondition exposes that the VM's zone is being
+	// deleted or is cordoned, and the VM should be moved to another zone.
+	VirtualMachineZoneDrainingCondition = "ZoneDraining"
+
+	// VirtualMachineZoneDeletingReason documents that the VM's zone is being
+	// deleted.
+	VirtualMachineZoneDeletingReason = "ZoneDeleting"
+
+	// VirtualMachineZoneCordonedReason documents that the VM's zone is
+	// cordoned.
+	VirtualMachineZoneCordonedReason = "ZoneCordoned"
+)
+
 const (
 	// ForceEnableBackupAnnotation is an annotation that instructs VM operator to
 	// ignore all exclusion rules and persist the configuration of the resource in
@@ -413,6 +505,10 @@ const (
 type VirtualMachineImageRef struct {
 	// Kind describes the type of image, either a namespace-scoped
 	// VirtualMachineImage or cluster-scoped ClusterVirtualMachineImage.
+	//
+	// When a VM is created, the kind may also be a namespace-scoped
+	// VirtualMachineImageStream, in which case the reference is replaced with
+	// the stream's current image.
 	Kind string `json:"kind"`
 
 	// Name refers to the name of a VirtualMachineImage resource in the same
@@ -1122,6 +1218,37 @@ type VirtualMachineSpec struct {
 	//
 	// Valid policy types are: ComputePolicy.
 	Policies []PolicySpec `json:"policies,omitempty"`
+
+	// +optional
+
+	// DriftPolicy describes what happens when the configuration of the
+	// underlying vSphere VM is changed out of band and diverges from the
+	// configuration derived from this VM's spec and class.
+	//
+	// The following properties are compared:
+	//
+	// - config.hardware.numCPU   - The number of CPUs from the VM's class.
+	// - config.hardware.memoryMB - The memory from the VM's class.
+	// - config.hardware.device   - The number of network interfaces from
+	//                              spec.network.interfaces.
+	// - config.extraConfig[key]  - The ExtraConfig values set by VM Operator.
+	//                              A value is only compared with the value
+	//                              VM Operator last applied to the vSphere
+	//                              VM, so changes to the VM's spec and class
+	//                              are still applied.
+	//
+	// When omitted, drift is not detected.
+	DriftPolicy VirtualMachineDriftPolicy `json:"driftPolicy,omitempty"`
+
+	// +optional
+
+	// ProfileName is the name of a VirtualMachineProfile in the VM's namespace
+	// whose values are set on the fields of this VM that are not already set.
+	// The profile takes precedence over the profiles that select this VM by
+	// its labels.
+	//
+	// Please refer to VirtualMachineProfile for more information.
+	ProfileName string `json:"profileName,omitempty"`
 }
 
 // VirtualMachineReservedSpec describes a set of VM configuration options
@@ -1218,6 +1345,22 @@ type VirtualMachineGuestStatus struct {
 
 	// GuestFullName describes the full name of the observed operating system.
 	GuestFullName string `json:"guestFullName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxProperties=64
+
+	// Properties describes the application metadata published by the guest
+	// in the guestinfo keys that begin with "guestinfo.vmservice.status.".
+	// Each key is reported without the prefix, ex. the value of the guestinfo
+	// key "guestinfo.vmservice.status.app.version" is reported as the
+	// property "app.version".
+	//
+	// At most 64 properties are reported, and their keys and values may not
+	// exceed 16KiB in total. Keys must be at most 128 characters and consist
+	// of alphanumeric characters, '-', '_', and '.'. Values must be at most
+	// 1KiB. The properties that do not meet these limits are not reported,
+	// and the GuestProperties condition is false.
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 // VirtualMachineStatus defines the observed state of a VirtualMachine instance.
@@ -1343,6 +1486,24 @@ type VirtualMachineStatus struct {
 
 	// Policies describes the observed policies applied to this VM.
 	Policies []PolicyStatus `json:"policies,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=property
+
+	// Drift describes the properties of the underlying vSphere VM whose values
+	// diverged from the values derived from this VM's spec and class.
+	//
+	// Please refer to VirtualMachineSpec.DriftPolicy for more information.
+	Drift []VirtualMachineDriftStatus `json:"drift,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// AppliedProfiles describes the VirtualMachineProfiles whose values were
+	// set on this VM, and the fields that were set from each profile.
+	AppliedProfiles []VirtualMachineAppliedProfile `json:"appliedProfiles,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,201 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineAdmissionPolicyMode describes how a
+// VirtualMachineAdmissionPolicy is evaluated.
+//
+// +kubebuilder:validation:Enum=Validate;Mutate
+type VirtualMachineAdmissionPolicyMode string
+
+const (
+	// VirtualMachineAdmissionPolicyModeValidate indicates the policy's
+	// validations are evaluated when a VM is validated.
+	VirtualMachineAdmissionPolicyModeValidate VirtualMachineAdmissionPolicyMode = "Validate"
+
+	// VirtualMachineAdmissionPolicyModeMutate indicates the policy's defaults
+	// are evaluated when a VM is mutated.
+	VirtualMachineAdmissionPolicyModeMutate VirtualMachineAdmissionPolicyMode = "Mutate"
+)
+
+// VirtualMachineAdmissionPolicyAction describes what happens when a
+// VirtualMachineAdmissionPolicy fires.
+//
+// +kubebuilder:validation:Enum=Deny;Warn;Audit
+type VirtualMachineAdmissionPolicyAction string
+
+const (
+	// VirtualMachineAdmissionPolicyActionDeny indicates a failed validation
+	// denies the request. In Mutate mode, the defaults are applied.
+	VirtualMachineAdmissionPolicyActionDeny VirtualMachineAdmissionPolicyAction = "Deny"
+
+	// VirtualMachineAdmissionPolicyActionWarn indicates a failed validation
+	// returns a warning to the client. In Mutate mode, the defaults are
+	// applied and a warning describing them is returned to the client.
+	VirtualMachineAdmissionPolicyActionWarn VirtualMachineAdmissionPolicyAction = "Warn"
+
+	// VirtualMachineAdmissionPolicyActionAudit indicates a failed validation,
+	// or the defaults that would have been applied in Mutate mode, are only
+	// recorded in the audit annotations of the request.
+	VirtualMachineAdmissionPolicyActionAudit VirtualMachineAdmissionPolicyAction = "Audit"
+)
+
+// VirtualMachineAdmissionPolicyOperation is an operation on a VM to which a
+// VirtualMachineAdmissionPolicy applies.
+//
+// +kubebuilder:validation:Enum=Create;Update
+type VirtualMachineAdmissionPolicyOperation string
+
+const (
+	// VirtualMachineAdmissionPolicyOperationCreate indicates the policy
+	// applies when a VM is created.
+	VirtualMachineAdmissionPolicyOperationCreate VirtualMachineAdmissionPolicyOperation = "Create"
+
+	// VirtualMachineAdmissionPolicyOperationUpdate indicates the policy
+	// applies when a VM is updated.
+	VirtualMachineAdmissionPolicyOperationUpdate VirtualMachineAdmissionPolicyOperation = "Update"
+)
+
+// VirtualMachineAdmissionPolicyValidation is a CEL expression that must
+// evaluate to true for a VM to be admitted.
+type VirtualMachineAdmissionPolicyValidation struct {
+	// Expression is a CEL expression that evaluates to a bool. The
+	// validation fails when the expression evaluates to false.
+	//
+	// The following variables are available to the expression:
+	//
+	// - object          - The VM.
+	// - oldObject       - The existing VM on update, otherwise null.
+	// - class           - The VM's VirtualMachineClass, or null if it does
+	//                     not exist.
+	// - image           - The VM's VirtualMachineImage or
+	//                     ClusterVirtualMachineImage, or null if it does not
+	//                     exist.
+	// - namespaceObject - The VM's namespace.
+	// - request         - The operation and the name and groups of the user
+	//                     that made the request.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+
+	// +optional
+
+	// Message is the message returned to the client when the validation
+	// fails.
+	//
+	// Defaults to a message that includes the expression.
+	Message string `json:"message,omitempty"`
+}
+
+// VirtualMachineAdmissionPolicyDefault is a CEL expression whose value is set
+// on a field of a VM when the field is unset.
+type VirtualMachineAdmissionPolicyDefault struct {
+	// Path is the dot-separated path of the field to set, ex.
+	// spec.crypto.encryptionClassName. The path must be in the VM's spec.
+	//
+	// +kubebuilder:validation:Pattern=`^spec(\.[a-zA-Z][a-zA-Z0-9]*)+$`
+	Path string `json:"path"`
+
+	// Expression is a CEL expression whose value is set on the field. The
+	// same variables that are available to validation expressions are
+	// available to this expression.
+	//
+	// The field is not set if the expression evaluates to null or to an
+	// empty optional, ex. optional.none().
+	//
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+}
+
+// VirtualMachineAdmissionPolicySpec defines the desired state of
+// VirtualMachineAdmissionPolicy.
+type VirtualMachineAdmissionPolicySpec struct {
+	// +optional
+
+	// NamespaceSelector selects the namespaces of the VMs to which the policy
+	// applies.
+	//
+	// Defaults to all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +optional
+	// +listType=set
+
+	// Operations are the operations on a VM to which the policy applies.
+	//
+	// Defaults to both Create and Update when the policy's mode is Validate,
+	// and to Create when the policy's mode is Mutate, since a default set when
+	// a VM is updated may change a field that is immutable once the VM is
+	// created.
+	Operations []VirtualMachineAdmissionPolicyOperation `json:"operations,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Validate
+
+	// Mode describes whether the policy's validations are evaluated when a VM
+	// is validated, or the policy's defaults are evaluated when a VM is
+	// mutated.
+	//
+	// Defaults to Validate.
+	Mode VirtualMachineAdmissionPolicyMode `json:"mode,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Deny
+
+	// Action describes what happens when the policy fires.
+	//
+	// Defaults to Deny.
+	Action VirtualMachineAdmissionPolicyAction `json:"action,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// Validations are the expressions evaluated in Validate mode.
+	Validations []VirtualMachineAdmissionPolicyValidation `json:"validations,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// Defaults are the expressions evaluated in Mutate mode.
+	Defaults []VirtualMachineAdmissionPolicyDefault `json:"defaults,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=vmadmpol
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineAdmissionPolicy is a set of CEL expressions that are
+// evaluated when VMs are admitted, either to validate the VMs or to set
+// default values on them.
+type VirtualMachineAdmissionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineAdmissionPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineAdmissionPolicyList contains a list of
+// VirtualMachineAdmissionPolicy resources.
+type VirtualMachineAdmissionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineAdmissionPolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineAdmissionPolicy{},
+		&VirtualMachineAdmissionPolicyList{},
+	)
+}
@@ -0,0 +1,69 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineDisruptionBudgetSpec defines the desired state of a
+// VirtualMachineDisruptionBudget.
+type VirtualMachineDisruptionBudgetSpec struct {
+	// Selector is a label query over the VMs in the namespace to which the
+	// budget applies.
+	//
+	// An empty selector matches all of the VMs in the namespace.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+
+	// MaxUnavailable is the maximum number of the selected VMs that may be
+	// unavailable when one of them is voluntarily disrupted, ex. relocated by
+	// the rebalancer.
+	//
+	// A VM is unavailable when it is not powered on, or when it has a
+	// readiness probe and its Ready condition is not true. The VM being
+	// disrupted is counted as unavailable.
+	//
+	// Setting this field to zero prevents the selected VMs from being
+	// voluntarily disrupted.
+	//
+	// Defaults to 1.
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmdb
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Max-Unavailable",type="integer",JSONPath=".spec.maxUnavailable"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineDisruptionBudget limits the number of VMs that may be
+// unavailable at the same time due to voluntary disruptions.
+type VirtualMachineDisruptionBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineDisruptionBudgetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineDisruptionBudgetList contains a list of
+// VirtualMachineDisruptionBudget resources.
+type VirtualMachineDisruptionBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineDisruptionBudget `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineDisruptionBudget{},
+		&VirtualMachineDisruptionBudgetList{},
+	)
+}
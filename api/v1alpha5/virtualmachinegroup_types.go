@@ -21,6 +21,10 @@ const (
 	// VirtualMachineGroupMemberConditionPlacementReady indicates that the
 	// member has a placement decision ready.
 	VirtualMachineGroupMemberConditionPlacementReady = "PlacementReady"
+
+	// VirtualMachineGroupMemberPlacementNotReadyReason is the reason of the
+	// PlacementReady condition when the member could not be placed.
+	VirtualMachineGroupMemberPlacementNotReadyReason = "NotReady"
 )
 
 // GroupMember describes a member of a VirtualMachineGroup.
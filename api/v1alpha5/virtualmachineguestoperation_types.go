@@ -0,0 +1,349 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+)
+
+const (
+	// VirtualMachineGuestOperationCredentialsUsernameKey is the key in a
+	// guest operation's credentials Secret whose value is the name of the
+	// guest OS user.
+	VirtualMachineGuestOperationCredentialsUsernameKey = "username"
+
+	// VirtualMachineGuestOperationCredentialsPasswordKey is the key in a
+	// guest operation's credentials Secret whose value is the password of the
+	// guest OS user.
+	VirtualMachineGuestOperationCredentialsPasswordKey = "password"
+)
+
+const (
+	// VirtualMachineGuestOperationConditionComplete is the Type for a
+	// VirtualMachineGuestOperation resource's status condition.
+	//
+	// The condition's status is set to true only when the operation
+	// succeeded. When the operation failed, the condition's status is set to
+	// false and the completion time is set.
+	VirtualMachineGuestOperationConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineGuestOperation.
+const (
+	// VirtualMachineGuestOperationInProgressReason documents that the
+	// operation is being performed in the guest.
+	VirtualMachineGuestOperationInProgressReason = "InProgress"
+
+	// VirtualMachineGuestOperationVMNotFoundReason documents that the
+	// operation's VM does not exist.
+	VirtualMachineGuestOperationVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineGuestOperationCredentialsInvalidReason documents that the
+	// operation's credentials Secret does not exist or does not have the
+	// username and password keys.
+	VirtualMachineGuestOperationCredentialsInvalidReason = "CredentialsInvalid"
+
+	// VirtualMachineGuestOperationSourceInvalidReason documents that the
+	// Secret or ConfigMap that is copied into the guest does not exist or
+	// does not have the key.
+	VirtualMachineGuestOperationSourceInvalidReason = "SourceInvalid"
+
+	// VirtualMachineGuestOperationTargetInvalidReason documents that the
+	// Secret into which a guest file is copied already exists and was not
+	// created by the operation.
+	VirtualMachineGuestOperationTargetInvalidReason = "TargetInvalid"
+
+	// VirtualMachineGuestOperationFileTooLargeReason documents that the guest
+	// file that is copied out of the guest is larger than the limit.
+	VirtualMachineGuestOperationFileTooLargeReason = "FileTooLarge"
+
+	// VirtualMachineGuestOperationTimedOutReason documents that the program
+	// did not exit before its timeout and was terminated.
+	VirtualMachineGuestOperationTimedOutReason = "TimedOut"
+
+	// VirtualMachineGuestOperationExitCodeNonZeroReason documents that the
+	// program exited with a non-zero exit code.
+	VirtualMachineGuestOperationExitCodeNonZeroReason = "ExitCodeNonZero"
+
+	// VirtualMachineGuestOperationFailedReason documents that the operation
+	// could not be performed in the guest, ex. because VMware Tools is not
+	// running or the credentials were rejected by the guest.
+	VirtualMachineGuestOperationFailedReason = "Failed"
+)
+
+// ConfigMapKeySelector references data from a ConfigMap resource by a
+// specific key.
+type ConfigMapKeySelector struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the key in the ConfigMap that specifies the requested data.
+	Key string `json:"key"`
+}
+
+// VirtualMachineGuestOperationFileSource describes the data that is copied
+// into a guest file. Exactly one of its fields must be set.
+type VirtualMachineGuestOperationFileSource struct {
+	// +optional
+
+	// Secret references the data of a key in a Secret in the same namespace.
+	Secret *vmopv1common.SecretKeySelector `json:"secret,omitempty"`
+
+	// +optional
+
+	// ConfigMap references the data of a key in a ConfigMap in the same
+	// namespace. The key may be in either the ConfigMap's data or binaryData.
+	ConfigMap *ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// VirtualMachineGuestOperationCopyIn describes a file that is copied into
+// the guest.
+type VirtualMachineGuestOperationCopyIn struct {
+	// Source is the data that is copied into the guest file.
+	Source VirtualMachineGuestOperationFileSource `json:"source"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// GuestPath is the absolute path of the file in the guest, ex.
+	// /etc/app/config.yaml or C:\app\config.yaml. The file's directory must
+	// exist.
+	GuestPath string `json:"guestPath"`
+
+	// +optional
+
+	// Overwrite may be set to true to overwrite the file if it already
+	// exists. Otherwise the operation fails if the file exists.
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// VirtualMachineGuestOperationCopyOut describes a file that is copied out of
+// the guest into a Secret.
+type VirtualMachineGuestOperationCopyOut struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// GuestPath is the absolute path of the file in the guest.
+	GuestPath string `json:"guestPath"`
+
+	// SecretName is the name of the Secret, in the same namespace, into which
+	// the file is copied. The Secret is created by the operation, and is not
+	// deleted when the operation is deleted.
+	//
+	// The operation fails if a Secret with this name already exists.
+	SecretName string `json:"secretName"`
+
+	// +optional
+
+	// Key is the key in the Secret whose value is the file's contents.
+	//
+	// Defaults to the file's base name.
+	Key string `json:"key,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=1048576
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1048576
+
+	// MaxSizeBytes is the maximum size of the file. The operation fails if
+	// the file is larger, and the Secret is not created.
+	//
+	// Defaults to, and may not be larger than, 1MiB, the maximum size of a
+	// Secret.
+	MaxSizeBytes int64 `json:"maxSizeBytes,omitempty"`
+}
+
+// VirtualMachineGuestOperationRunProgram describes a program that is run in
+// the guest.
+type VirtualMachineGuestOperationRunProgram struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Path is the path of the program in the guest, ex. /usr/bin/systemctl.
+	// The program is run with the guest's shell, /bin/sh on Linux and
+	// cmd.exe on Windows, so its output may be captured.
+	Path string `json:"path"`
+
+	// +optional
+
+	// Args are the arguments passed to the program.
+	Args []string `json:"args,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Env are the environment variables set for the program, in addition to
+	// those of the guest user.
+	Env []vmopv1common.NameValuePair `json:"env,omitempty"`
+
+	// +optional
+
+	// WorkingDirectory is the absolute path of the program's working
+	// directory in the guest.
+	//
+	// Defaults to the guest user's home directory.
+	WorkingDirectory string `json:"workingDirectory,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+
+	// TimeoutSeconds is the number of seconds after which the program is
+	// terminated if it has not exited.
+	//
+	// Defaults to 300.
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// VirtualMachineGuestOperationSpec defines the desired state of a
+// VirtualMachineGuestOperation.
+//
+// Exactly one of CopyIn, CopyOut, or RunProgram must be set. The spec may not
+// be changed once the operation is created, with the exception of
+// TTLSecondsAfterFinished.
+type VirtualMachineGuestOperationSpec struct {
+	// VMName is the name of the VirtualMachine, in the same namespace, in
+	// whose guest the operation is performed. The VM must be powered on and
+	// running VMware Tools.
+	VMName string `json:"vmName"`
+
+	// CredentialsSecretName is the name of a Secret, in the same namespace,
+	// with the username and password keys of the guest OS user as whom the
+	// operation is performed.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// +optional
+
+	// CopyIn describes a file that is copied into the guest.
+	CopyIn *VirtualMachineGuestOperationCopyIn `json:"copyIn,omitempty"`
+
+	// +optional
+
+	// CopyOut describes a file that is copied out of the guest into a
+	// Secret.
+	CopyOut *VirtualMachineGuestOperationCopyOut `json:"copyOut,omitempty"`
+
+	// +optional
+
+	// RunProgram describes a program that is run in the guest.
+	RunProgram *VirtualMachineGuestOperationRunProgram `json:"runProgram,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// TTLSecondsAfterFinished is the time-to-live duration for how long this
+	// resource will be allowed to exist once the operation completes. After
+	// the TTL expires, the resource will be automatically deleted without the
+	// user having to take any direct action.
+	//
+	// If this field is unset then the resource will not be automatically
+	// deleted. If this field is set to zero then the resource is eligible for
+	// deletion immediately after it finishes.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// VirtualMachineGuestOperationStatus defines the observed state of a
+// VirtualMachineGuestOperation.
+type VirtualMachineGuestOperationStatus struct {
+	// +optional
+
+	// StartTime represents when the operation was started by the controller.
+	// It is represented in RFC3339 form and is in UTC.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents when the operation was completed, whether or
+	// not it succeeded. It is represented in RFC3339 form and is in UTC.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Succeeded is set to true only when the operation completed
+	// successfully.
+	Succeeded bool `json:"succeeded,omitempty"`
+
+	// +optional
+
+	// FileSizeBytes is the size of the file that was copied into or out of
+	// the guest.
+	FileSizeBytes int64 `json:"fileSizeBytes,omitempty"`
+
+	// +optional
+
+	// ExitCode is the exit code of the program. It is not set if the program
+	// did not exit, ex. because it timed out.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// +optional
+
+	// Stdout is the standard output of the program. Only the first 16KiB of
+	// the output is kept.
+	Stdout string `json:"stdout,omitempty"`
+
+	// +optional
+
+	// Stderr is the standard error of the program. Only the first 16KiB of
+	// the output is kept.
+	Stderr string `json:"stderr,omitempty"`
+
+	// +optional
+
+	// OutputTruncated is set to true when either Stdout or Stderr was
+	// truncated.
+	OutputTruncated bool `json:"outputTruncated,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// operation's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmguestop
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VM",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Succeeded",type="boolean",JSONPath=".status.succeeded"
+// +kubebuilder:printcolumn:name="Exit-Code",type="integer",JSONPath=".status.exitCode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineGuestOperation copies a file into or out of a VM's guest, or
+// runs a program in the guest, through VMware Tools. The operation does not
+// require network access to the guest.
+type VirtualMachineGuestOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineGuestOperationSpec   `json:"spec,omitempty"`
+	Status VirtualMachineGuestOperationStatus `json:"status,omitempty"`
+}
+
+func (o *VirtualMachineGuestOperation) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *VirtualMachineGuestOperation) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineGuestOperationList contains a list of
+// VirtualMachineGuestOperation resources.
+type VirtualMachineGuestOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineGuestOperation `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineGuestOperation{},
+		&VirtualMachineGuestOperationList{},
+	)
+}
@@ -49,6 +49,24 @@ const (
 	// VirtualMachineImageV1Alpha1CompatibleCondition denotes that an image was prepared by
 	// VMware specifically for compatibility with VMService.
 	VirtualMachineImageV1Alpha1CompatibleCondition = "VirtualMachineImageV1Alpha1Compatible"
+
+	// VirtualMachineImageSignatureVerifiedCondition denotes that the signature
+	// of the image's provider item was verified against the trust roots
+	// configured for VM Operator. When the condition is true, its reason is
+	// the method with which the signature was verified, and its message
+	// identifies the signer.
+	//
+	// This condition is only present when image signature verification is
+	// enabled.
+	VirtualMachineImageSignatureVerifiedCondition = "VirtualMachineImageSignatureVerified"
+)
+
+const (
+	// RequireVerifiedImagesLabelKey is a label that may be applied to a
+	// Namespace. When its value is "true", VirtualMachine resources in that
+	// namespace may only be created from images whose
+	// VirtualMachineImageSignatureVerified condition is true.
+	RequireVerifiedImagesLabelKey = GroupName + "/require-verified-images"
 )
 
 // Condition reasons for VirtualMachineImages.
@@ -64,6 +82,24 @@ const (
 	// VirtualMachineImageProviderSecurityNotCompliantReason documents that the
 	// VirtualMachineImage provider doesn't meet security compliance requirements.
 	VirtualMachineImageProviderSecurityNotCompliantReason = "VirtualMachineImageProviderSecurityNotCompliant"
+
+	// VirtualMachineImageSignatureNotFoundReason documents that the image's
+	// provider item does not have a signed manifest.
+	VirtualMachineImageSignatureNotFoundReason = "SignatureNotFound"
+
+	// VirtualMachineImageSignatureUntrustedReason documents that the image's
+	// provider item is signed, but not by any of the trust roots.
+	VirtualMachineImageSignatureUntrustedReason = "SignatureUntrusted"
+
+	// VirtualMachineImageSignatureInvalidReason documents that the signature
+	// of the image's provider item is malformed, or does not match its
+	// contents.
+	VirtualMachineImageSignatureInvalidReason = "SignatureInvalid"
+
+	// VirtualMachineImageSignatureVerificationFailedReason documents that the
+	// signature of the image's provider item could not be verified because of
+	// an error, ex. the item's files could not be downloaded.
+	VirtualMachineImageSignatureVerificationFailedReason = "SignatureVerificationFailed"
 )
 
 // VirtualMachineImageProductInfo describes product information for an image.
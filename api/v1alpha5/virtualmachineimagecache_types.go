@@ -5,6 +5,9 @@
 package v1alpha5
 
 import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,6 +49,15 @@ type VirtualMachineImageCacheLocationSpec struct {
 	// DatastoreID describes the ID of the datastore to which the image should
 	// be cached.
 	DatastoreID string `json:"datastoreID"`
+
+	// +optional
+
+	// LastUsedTime describes when a VM was last deployed from the files cached
+	// in this location.
+	// Cached files that have not been used recently may be evicted when the
+	// capacity used by cached files on a datastore exceeds the configured
+	// limits.
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
 }
 
 // VirtualMachineImageCacheSpec defines the desired state of
@@ -102,6 +114,38 @@ func (i *VirtualMachineImageCache) AddLocation(
 		})
 }
 
+// MarkLocationUsed sets the lastUsedTime of the location in the image cache
+// object's spec.locations list that matches the provided datacenterID,
+// datastoreID, and profileID to now. The time is only updated if it is unset
+// or at least the provided resolution older than now in order to avoid
+// updating the object every time the location is used.
+//
+// Returns true if the lastUsedTime was updated.
+func (i *VirtualMachineImageCache) MarkLocationUsed(
+	datacenterID,
+	datastoreID,
+	profileID string,
+	now metav1.Time,
+	resolution time.Duration) bool {
+
+	for idx := range i.Spec.Locations {
+		l := &i.Spec.Locations[idx]
+		if l.DatacenterID == datacenterID &&
+			l.DatastoreID == datastoreID &&
+			l.ProfileID == profileID {
+
+			if l.LastUsedTime != nil &&
+				now.Sub(l.LastUsedTime.Time) < resolution {
+
+				return false
+			}
+			l.LastUsedTime = &now
+			return true
+		}
+	}
+	return false
+}
+
 // +kubebuilder:validation:Enum=Disk;Other
 
 // VirtualMachineImageCacheFileType describes the types of files that may be
@@ -163,6 +207,11 @@ type VirtualMachineImageCacheLocationStatus struct {
 
 	// +optional
 
+	// Size describes the capacity used by the files cached in this location.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// +optional
+
 	// Conditions describes any conditions associated with this cache location.
 	//
 	// Generally this should just include the ReadyType condition.
@@ -194,6 +243,42 @@ type VirtualMachineImageCacheOVFStatus struct {
 	ProviderVersion string `json:"providerVersion,omitempty"`
 }
 
+// VirtualMachineImageCacheEvictionStatus describes cached files that were
+// removed from a location.
+type VirtualMachineImageCacheEvictionStatus struct {
+
+	// DatacenterID describes the ID of the datacenter from which the files were
+	// evicted.
+	DatacenterID string `json:"datacenterID"`
+
+	// DatastoreID describes the ID of the datastore from which the files were
+	// evicted.
+	DatastoreID string `json:"datastoreID"`
+
+	// ProfileID describes the ID of the storage profile used to cache the
+	// evicted files.
+	ProfileID string `json:"profileID"`
+
+	// +optional
+
+	// Size describes the capacity that was used by the evicted files.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// +optional
+
+	// LastUsedTime describes when a VM was last deployed from the evicted
+	// files.
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+
+	// EvictionTime describes when the files were evicted.
+	EvictionTime metav1.Time `json:"evictionTime"`
+
+	// +optional
+
+	// Reason describes why the files were evicted.
+	Reason string `json:"reason,omitempty"`
+}
+
 // VirtualMachineImageCacheStatus defines the observed state of
 // VirtualMachineImageCache.
 type VirtualMachineImageCacheStatus struct {
@@ -209,6 +294,19 @@ type VirtualMachineImageCacheStatus struct {
 
 	// +optional
 
+	// Size describes the total capacity used by the image's files cached in
+	// all of the observed locations.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+
+	// Evictions describes the most recent evictions of the image's cached
+	// files, ordered from oldest to newest.
+	Evictions []VirtualMachineImageCacheEvictionStatus `json:"evictions,omitempty"`
+
+	// +optional
+
 	// OVF describes the observed status of the cached OVF content.
 	OVF *VirtualMachineImageCacheOVFStatus `json:"ovf,omitempty"`
 
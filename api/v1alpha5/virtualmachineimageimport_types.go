@@ -0,0 +1,309 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageImportConditionTargetValid is the Type for a
+	// VirtualMachineImageImport resource's status condition.
+	//
+	// The condition's status is set to true only when the target content
+	// library exists, is writable and ready, and does not already have an
+	// item with the target name.
+	VirtualMachineImageImportConditionTargetValid = "TargetValid"
+
+	// VirtualMachineImageImportConditionUploaded is the Type for a
+	// VirtualMachineImageImport resource's status condition.
+	//
+	// The condition's status is set to true only when the image has been
+	// downloaded from its source, verified, and uploaded to the target
+	// content library.
+	VirtualMachineImageImportConditionUploaded = "Uploaded"
+
+	// VirtualMachineImageImportConditionImageAvailable is the Type for a
+	// VirtualMachineImageImport resource's status condition.
+	//
+	// The condition's status is set to true only when the
+	// VirtualMachineImage resource for the uploaded content library item is
+	// ready.
+	VirtualMachineImageImportConditionImageAvailable = "ImageAvailable"
+
+	// VirtualMachineImageImportConditionComplete is the Type for a
+	// VirtualMachineImageImport resource's status condition.
+	//
+	// The condition's status is set to true only when all other conditions
+	// present on the resource have a truthy status.
+	VirtualMachineImageImportConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineImageImport.
+const (
+	// VirtualMachineImageImportUploadInProgressReason documents that the
+	// image is being downloaded from its source and uploaded to the target
+	// content library.
+	VirtualMachineImageImportUploadInProgressReason = "UploadInProgress"
+
+	// VirtualMachineImageImportUploadFailedReason documents that the image
+	// could not be downloaded from its source or uploaded to the target
+	// content library.
+	VirtualMachineImageImportUploadFailedReason = "UploadFailed"
+
+	// VirtualMachineImageImportChecksumMismatchReason documents that the
+	// checksum of the downloaded image does not match the expected checksum.
+	VirtualMachineImageImportChecksumMismatchReason = "ChecksumMismatch"
+
+	// VirtualMachineImageImportSourceInvalidReason documents that the image's
+	// source could not be resolved, ex. the OCI artifact does not have any
+	// files, or the source's pull secret is invalid.
+	VirtualMachineImageImportSourceInvalidReason = "SourceInvalid"
+)
+
+// VirtualMachineImageImportChecksumAlgorithm is the algorithm of a checksum.
+//
+// +kubebuilder:validation:Enum=SHA256;SHA512
+type VirtualMachineImageImportChecksumAlgorithm string
+
+const (
+	// VirtualMachineImageImportChecksumAlgorithmSHA256 is the SHA-256
+	// algorithm.
+	VirtualMachineImageImportChecksumAlgorithmSHA256 VirtualMachineImageImportChecksumAlgorithm = "SHA256"
+
+	// VirtualMachineImageImportChecksumAlgorithmSHA512 is the SHA-512
+	// algorithm.
+	VirtualMachineImageImportChecksumAlgorithmSHA512 VirtualMachineImageImportChecksumAlgorithm = "SHA512"
+)
+
+// VirtualMachineImageImportChecksum is the expected checksum of a downloaded
+// file.
+type VirtualMachineImageImportChecksum struct {
+	// Algorithm is the algorithm used to compute the checksum.
+	Algorithm VirtualMachineImageImportChecksumAlgorithm `json:"algorithm"`
+
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]+$`
+
+	// Value is the hex encoded checksum.
+	Value string `json:"value"`
+}
+
+// VirtualMachineImageImportHTTPSource describes an image that is downloaded
+// from an HTTP(S) URL.
+type VirtualMachineImageImportHTTPSource struct {
+	// +kubebuilder:validation:Pattern=`^https?://.+`
+
+	// URL is the HTTP(S) URL of an OVA, OVF or ISO file.
+	//
+	// The type of the image is determined by the extension of the URL's path,
+	// which must be one of .ova, .ovf or .iso. The files referenced by an OVF
+	// descriptor, ex. its VMDK files, are downloaded from the same location
+	// as the descriptor.
+	URL string `json:"url"`
+
+	// +optional
+
+	// Checksum is the expected checksum of the file at URL.
+	//
+	// The import fails when the checksum of the downloaded file does not
+	// match this value.
+	Checksum *VirtualMachineImageImportChecksum `json:"checksum,omitempty"`
+}
+
+// VirtualMachineImageImportOCISource describes an image that is pulled from
+// an OCI registry.
+type VirtualMachineImageImportOCISource struct {
+	// Reference is a reference to an OCI artifact, ex.
+	// registry.example.com/images/photon:5.0, or
+	// registry.example.com/images/photon@sha256:....
+	//
+	// Each layer of the artifact's manifest is a file of the image, and is
+	// named with the layer's org.opencontainers.image.title annotation. The
+	// layers must be an OVA file, an OVF descriptor and the files it
+	// references, or an ISO file. The digest of each layer is verified.
+	Reference string `json:"reference"`
+
+	// +optional
+
+	// PullSecretName is the name of a Secret of type
+	// kubernetes.io/dockerconfigjson, in the same namespace, with the
+	// credentials for the registry.
+	PullSecretName string `json:"pullSecretName,omitempty"`
+
+	// +optional
+
+	// Insecure allows the registry to be accessed over plain HTTP, or over
+	// HTTPS without verifying its certificate.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// VirtualMachineImageImportSource describes where an image is imported from.
+// Exactly one of its fields must be set.
+type VirtualMachineImageImportSource struct {
+	// +optional
+
+	// HTTP describes an image that is downloaded from an HTTP(S) URL.
+	HTTP *VirtualMachineImageImportHTTPSource `json:"http,omitempty"`
+
+	// +optional
+
+	// OCI describes an image that is pulled from an OCI registry.
+	OCI *VirtualMachineImageImportOCISource `json:"oci,omitempty"`
+}
+
+// VirtualMachineImageImportTarget describes the content library item into
+// which an image is imported.
+type VirtualMachineImageImportTarget struct {
+	// LibraryName is the name of the ContentLibrary resource, in the same
+	// namespace, to which the image is uploaded.
+	LibraryName string `json:"libraryName"`
+
+	// +optional
+
+	// ItemName is the name of the content library item that is created.
+	//
+	// This is the name that shows up in vCenter Content Library, not the name
+	// of a resource in the namespace. If omitted, the name of the
+	// VirtualMachineImageImport resource is used.
+	ItemName string `json:"itemName,omitempty"`
+
+	// +optional
+
+	// ItemDescription is the description of the content library item that is
+	// created.
+	ItemDescription string `json:"itemDescription,omitempty"`
+}
+
+// VirtualMachineImageImportSpec defines the desired state of a
+// VirtualMachineImageImport.
+type VirtualMachineImageImportSpec struct {
+	// Source is where the image is imported from.
+	Source VirtualMachineImageImportSource `json:"source"`
+
+	// Target is the content library item into which the image is imported.
+	Target VirtualMachineImageImportTarget `json:"target"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// TTLSecondsAfterFinished is the time-to-live duration for how long this
+	// resource will be allowed to exist once the import completes. After the
+	// TTL expires, the resource will be automatically deleted without the
+	// user having to take any direct action.
+	//
+	// If this field is unset then the resource will not be automatically
+	// deleted. If this field is set to zero then the resource is eligible for
+	// deletion immediately after it finishes.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// VirtualMachineImageImportStatus defines the observed state of a
+// VirtualMachineImageImport.
+type VirtualMachineImageImportStatus struct {
+	// +optional
+
+	// StartTime represents when the import was started by the controller. It
+	// is represented in RFC3339 form and is in UTC.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents when the import was completed. It is
+	// represented in RFC3339 form and is in UTC.
+	//
+	// The value of this field should be equal to the value of the
+	// LastTransitionTime for the status condition Type=Complete.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// TotalBytes is the total number of bytes that are downloaded from the
+	// source. It is zero while the total is not known.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// +optional
+
+	// BytesTransferred is the number of bytes that have been downloaded from
+	// the source and uploaded to the content library.
+	BytesTransferred int64 `json:"bytesTransferred,omitempty"`
+
+	// +optional
+
+	// Progress is the percentage of TotalBytes that have been transferred.
+	Progress int32 `json:"progress,omitempty"`
+
+	// +optional
+
+	// ItemID is the ID of the content library item into which the image was
+	// uploaded.
+	ItemID string `json:"itemID,omitempty"`
+
+	// +optional
+
+	// ImageName is the name of the VirtualMachineImage resource for the
+	// content library item.
+	//
+	// This field will not be set until the VirtualMachineImage resource is
+	// ready.
+	ImageName string `json:"imageName,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the image has been imported and the
+	// VirtualMachineImage resource is ready.
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// import's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmimport
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Library",type="string",JSONPath=".spec.target.libraryName"
+// +kubebuilder:printcolumn:name="Progress",type="integer",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.imageName"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageImport imports an image from an HTTP(S) URL or an OCI
+// registry into a content library, which makes it available as a
+// VirtualMachineImage.
+type VirtualMachineImageImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageImportSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageImportStatus `json:"status,omitempty"`
+}
+
+func (r *VirtualMachineImageImport) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachineImageImport) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageImportList contains a list of VirtualMachineImageImport
+// resources.
+type VirtualMachineImageImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageImport `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageImport{},
+		&VirtualMachineImageImportList{},
+	)
+}
@@ -0,0 +1,204 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageStreamKind is the kind of a VirtualMachineImageStream
+	// resource when it is referenced by a VM's spec.image field.
+	VirtualMachineImageStreamKind = "VirtualMachineImageStream"
+
+	// VirtualMachineImageStreamAnnotation is the annotation on a VM that was
+	// created from a VirtualMachineImageStream. Its value is the name of the
+	// stream.
+	//
+	// When a VM that refers to a stream in spec.image is created, spec.image
+	// is set to the stream's current image and this annotation records the
+	// stream.
+	VirtualMachineImageStreamAnnotation = GroupName + "/image-stream"
+)
+
+const (
+	// VirtualMachineImageStreamConditionImageResolved is the Type for a
+	// VirtualMachineImageStream resource's status condition.
+	//
+	// The condition's status is set to true only when the stream's selectors
+	// match at least one ready image.
+	VirtualMachineImageStreamConditionImageResolved = "ImageResolved"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineImageStream.
+const (
+	// VirtualMachineImageStreamNoMatchingImagesReason documents that none of
+	// the ready images match the stream's selectors.
+	VirtualMachineImageStreamNoMatchingImagesReason = "NoMatchingImages"
+
+	// VirtualMachineImageStreamInvalidSelectorReason documents that one of
+	// the stream's version constraints or its label selector is invalid.
+	VirtualMachineImageStreamInvalidSelectorReason = "InvalidSelector"
+)
+
+// VirtualMachineImageStreamProductSelector selects images by the product
+// information in their status.
+type VirtualMachineImageStreamProductSelector struct {
+	// +optional
+
+	// Product matches the image's status.productInfo.product field.
+	Product string `json:"product,omitempty"`
+
+	// +optional
+
+	// Vendor matches the image's status.productInfo.vendor field.
+	Vendor string `json:"vendor,omitempty"`
+
+	// +optional
+
+	// Version is a semantic version range that the image's
+	// status.productInfo.version field must satisfy, ex. ">=5.0.0 <6.0.0".
+	//
+	// Versions that are not valid semantic versions are parsed tolerantly,
+	// ex. "5.0" is treated as "5.0.0". Images whose version cannot be parsed
+	// do not match a range.
+	Version string `json:"version,omitempty"`
+}
+
+// VirtualMachineImageStreamOSSelector selects images by the operating system
+// information in their status.
+type VirtualMachineImageStreamOSSelector struct {
+	// +optional
+
+	// ID matches the image's status.osInfo.id field.
+	ID string `json:"id,omitempty"`
+
+	// +optional
+
+	// Type matches the image's status.osInfo.type field.
+	Type string `json:"type,omitempty"`
+
+	// +optional
+
+	// Version is a semantic version range that the image's
+	// status.osInfo.version field must satisfy, ex. ">=22.4.0".
+	Version string `json:"version,omitempty"`
+}
+
+// VirtualMachineImageStreamSpec defines the desired state of a
+// VirtualMachineImageStream.
+type VirtualMachineImageStreamSpec struct {
+	// +optional
+
+	// Product selects images by their product information.
+	Product *VirtualMachineImageStreamProductSelector `json:"product,omitempty"`
+
+	// +optional
+
+	// OS selects images by their operating system information.
+	OS *VirtualMachineImageStreamOSSelector `json:"os,omitempty"`
+
+	// +optional
+
+	// Selector selects images by their labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+
+	// IncludeClusterImages indicates whether ClusterVirtualMachineImage
+	// resources are also considered, in addition to the VirtualMachineImage
+	// resources in the stream's namespace.
+	IncludeClusterImages bool `json:"includeClusterImages,omitempty"`
+}
+
+// VirtualMachineImageStreamStatus defines the observed state of a
+// VirtualMachineImageStream.
+type VirtualMachineImageStreamStatus struct {
+	// +optional
+
+	// Image is the stream's current image, i.e. the ready image that matches
+	// the stream's selectors with the highest product version. Ties are
+	// broken by the highest operating system version, and then by the most
+	// recently created image.
+	Image *VirtualMachineImageRef `json:"image,omitempty"`
+
+	// +optional
+
+	// ImageName is the display name of the current image.
+	ImageName string `json:"imageName,omitempty"`
+
+	// +optional
+
+	// ProductVersion is the product version of the current image.
+	ProductVersion string `json:"productVersion,omitempty"`
+
+	// +optional
+
+	// OSVersion is the operating system version of the current image.
+	OSVersion string `json:"osVersion,omitempty"`
+
+	// +optional
+
+	// LastUpdateTime is when the stream last moved to a different image.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// +optional
+
+	// ObservedGeneration describes the value of the metadata.generation field
+	// the last time this object was reconciled by its primary controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// stream's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmistream
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image.name"
+// +kubebuilder:printcolumn:name="Image-Name",type="string",JSONPath=".status.imageName"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.productVersion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageStream resolves the latest image that matches a set of
+// product, operating system, and label selectors. VMs and
+// VirtualMachineReplicaSet templates may refer to a stream instead of a
+// specific image.
+type VirtualMachineImageStream struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageStreamSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageStreamStatus `json:"status,omitempty"`
+}
+
+func (s *VirtualMachineImageStream) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachineImageStream) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageStreamList contains a list of VirtualMachineImageStream
+// resources.
+type VirtualMachineImageStreamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageStream `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageStream{},
+		&VirtualMachineImageStreamList{},
+	)
+}
@@ -0,0 +1,110 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// VirtualMachineIPPoolSpec defines the desired state of a
+// VirtualMachineIPPool.
+type VirtualMachineIPPoolSpec struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// Addresses is the list of addresses that may be allocated from the pool.
+	// Each entry may be one of the following:
+	//
+	//   * A single IP address, ex. 192.168.0.10.
+	//   * An inclusive range of IP addresses, ex.
+	//     192.168.0.10-192.168.0.50.
+	//   * A network in CIDR notation, ex. 192.168.0.0/24. The first address of
+	//     the network, and for IP4 networks the last address of the network,
+	//     are not allocated.
+	//
+	// Please note all of the addresses must be of the same IP family.
+	Addresses []string `json:"addresses"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+
+	// Prefix is the network prefix length of the addresses allocated from the
+	// pool, ex. 24.
+	Prefix int32 `json:"prefix"`
+
+	// +optional
+
+	// Gateway is the default gateway of the interfaces that are allocated an
+	// address from the pool. The gateway is never allocated.
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// VirtualMachineIPPoolAllocation describes an address allocated to a VM's
+// network interface.
+type VirtualMachineIPPoolAllocation struct {
+	// Address is the allocated IP address.
+	Address string `json:"address"`
+
+	// VirtualMachineName is the name of the VM to which the address is
+	// allocated.
+	VirtualMachineName string `json:"virtualMachineName"`
+
+	// VirtualMachineUID is the UID of the VM to which the address is
+	// allocated.
+	VirtualMachineUID types.UID `json:"virtualMachineUID"`
+
+	// InterfaceName is the name of the VM's network interface to which the
+	// address is allocated.
+	InterfaceName string `json:"interfaceName"`
+}
+
+// VirtualMachineIPPoolStatus defines the observed state of a
+// VirtualMachineIPPool.
+type VirtualMachineIPPoolStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=address
+
+	// Allocations is the list of addresses allocated from the pool.
+	//
+	// Addresses are allocated when a VM that has a network interface that
+	// refers to the pool is created, and released when the VM is deleted.
+	Allocations []VirtualMachineIPPoolAllocation `json:"allocations,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmippool
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Prefix",type="integer",JSONPath=".spec.prefix"
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".spec.gateway"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineIPPool is a pool of IP addresses from which the network
+// interfaces of VMs may be allocated static addresses. This is useful for
+// networks on which the network provider does not manage IP addresses.
+type VirtualMachineIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineIPPoolSpec   `json:"spec,omitempty"`
+	Status VirtualMachineIPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineIPPoolList contains a list of VirtualMachineIPPool resources.
+type VirtualMachineIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineIPPool `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineIPPool{},
+		&VirtualMachineIPPoolList{},
+	)
+}
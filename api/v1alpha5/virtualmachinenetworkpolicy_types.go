@@ -0,0 +1,239 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// VirtualMachineNetworkPolicyConditionApplied is the Type for a
+	// VirtualMachineNetworkPolicy resource's status condition.
+	//
+	// The condition's status is set to true only when the policy has been
+	// translated into the network provider's security constructs and the
+	// provider reports they are realized.
+	VirtualMachineNetworkPolicyConditionApplied = "Applied"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineNetworkPolicy.
+const (
+	// VirtualMachineNetworkPolicyProviderNotSupportedReason documents that
+	// the active network provider cannot enforce network policies.
+	VirtualMachineNetworkPolicyProviderNotSupportedReason = "ProviderNotSupported"
+
+	// VirtualMachineNetworkPolicyApplyFailedReason documents that the policy
+	// could not be translated into the network provider's security
+	// constructs.
+	VirtualMachineNetworkPolicyApplyFailedReason = "ApplyFailed"
+
+	// VirtualMachineNetworkPolicyNotRealizedReason documents that the network
+	// provider has not yet realized the policy's security constructs.
+	VirtualMachineNetworkPolicyNotRealizedReason = "NotRealized"
+)
+
+// VirtualMachineNetworkPolicyType is the direction of the traffic to which a
+// VirtualMachineNetworkPolicy applies.
+//
+// +kubebuilder:validation:Enum=Ingress;Egress
+type VirtualMachineNetworkPolicyType string
+
+const (
+	// VirtualMachineNetworkPolicyTypeIngress is the type of a policy that
+	// applies to the traffic to the selected VMs.
+	VirtualMachineNetworkPolicyTypeIngress VirtualMachineNetworkPolicyType = "Ingress"
+
+	// VirtualMachineNetworkPolicyTypeEgress is the type of a policy that
+	// applies to the traffic from the selected VMs.
+	VirtualMachineNetworkPolicyTypeEgress VirtualMachineNetworkPolicyType = "Egress"
+)
+
+// VirtualMachineNetworkPolicyIPBlock describes a range of IP addresses.
+type VirtualMachineNetworkPolicyIPBlock struct {
+	// CIDR is the range of IP addresses in CIDR notation, ex. 10.0.0.0/24 or
+	// 2001:db8::/64.
+	CIDR string `json:"cidr"`
+}
+
+// VirtualMachineNetworkPolicyPeer describes the VMs or IP addresses with
+// which the selected VMs may communicate. Either IPBlock, or one or both of
+// VMSelector and NamespaceSelector, must be specified.
+type VirtualMachineNetworkPolicyPeer struct {
+	// +optional
+
+	// VMSelector selects VMs by their labels.
+	//
+	// If NamespaceSelector is also specified, the VMs are selected from the
+	// namespaces that match the NamespaceSelector. Otherwise the VMs are
+	// selected from the policy's namespace.
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+
+	// +optional
+
+	// NamespaceSelector selects namespaces by their labels.
+	//
+	// If VMSelector is not specified, all of the VMs in the selected
+	// namespaces are selected.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +optional
+
+	// IPBlock selects a range of IP addresses.
+	IPBlock *VirtualMachineNetworkPolicyIPBlock `json:"ipBlock,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyPort describes the protocol and port of the
+// traffic that is allowed.
+type VirtualMachineNetworkPolicyPort struct {
+	// +optional
+	// +kubebuilder:default=TCP
+	// +kubebuilder:validation:Enum=TCP;UDP
+
+	// Protocol is the protocol of the traffic, either TCP or UDP. Defaults to
+	// TCP.
+	Protocol *corev1.Protocol `json:"protocol,omitempty"`
+
+	// +optional
+
+	// Port is the number or name of the port. When omitted, all ports are
+	// matched.
+	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// +optional
+
+	// EndPort is the last port of the range of ports that starts at Port.
+	// EndPort may only be specified if Port is a number, and must not be less
+	// than Port.
+	EndPort *int32 `json:"endPort,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyIngressRule describes the traffic to the
+// selected VMs that is allowed.
+type VirtualMachineNetworkPolicyIngressRule struct {
+	// +optional
+
+	// From is the list of sources from which the traffic is allowed. When
+	// empty, the traffic from all sources is allowed.
+	From []VirtualMachineNetworkPolicyPeer `json:"from,omitempty"`
+
+	// +optional
+
+	// Ports is the list of ports on which the traffic is allowed. When empty,
+	// the traffic on all ports is allowed.
+	Ports []VirtualMachineNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyEgressRule describes the traffic from the
+// selected VMs that is allowed.
+type VirtualMachineNetworkPolicyEgressRule struct {
+	// +optional
+
+	// To is the list of destinations to which the traffic is allowed. When
+	// empty, the traffic to all destinations is allowed.
+	To []VirtualMachineNetworkPolicyPeer `json:"to,omitempty"`
+
+	// +optional
+
+	// Ports is the list of ports on which the traffic is allowed. When empty,
+	// the traffic on all ports is allowed.
+	Ports []VirtualMachineNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// VirtualMachineNetworkPolicySpec defines the desired state of a
+// VirtualMachineNetworkPolicy.
+type VirtualMachineNetworkPolicySpec struct {
+	// VMSelector selects the VMs in the policy's namespace to which the policy
+	// applies. An empty selector selects all of the VMs in the namespace.
+	VMSelector metav1.LabelSelector `json:"vmSelector"`
+
+	// +optional
+	// +listType=set
+
+	// PolicyTypes is the list of directions of the traffic to which the policy
+	// applies. The traffic to or from the selected VMs in these directions is
+	// denied unless a rule allows it.
+	//
+	// When omitted, the policy applies to ingress traffic, and also to egress
+	// traffic if there are egress rules.
+	PolicyTypes []VirtualMachineNetworkPolicyType `json:"policyTypes,omitempty"`
+
+	// +optional
+
+	// Ingress is the list of rules that allow traffic to the selected VMs.
+	Ingress []VirtualMachineNetworkPolicyIngressRule `json:"ingress,omitempty"`
+
+	// +optional
+
+	// Egress is the list of rules that allow traffic from the selected VMs.
+	Egress []VirtualMachineNetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyStatus defines the observed state of a
+// VirtualMachineNetworkPolicy.
+type VirtualMachineNetworkPolicyStatus struct {
+	// +optional
+
+	// Provider is the network provider that enforces the policy, ex.
+	// NSXT_VPC.
+	Provider string `json:"provider,omitempty"`
+
+	// +optional
+
+	// ObservedGeneration describes the value of the metadata.generation field
+	// the last time this object was reconciled by its primary controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// policy's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmnetpol
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".status.provider"
+// +kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(.type=='Applied')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineNetworkPolicy describes the network traffic that is allowed
+// to and from a set of VMs, similar to a Kubernetes NetworkPolicy. The policy
+// is translated into the security constructs of the active network provider.
+type VirtualMachineNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineNetworkPolicySpec   `json:"spec,omitempty"`
+	Status VirtualMachineNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *VirtualMachineNetworkPolicy) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+func (p *VirtualMachineNetworkPolicy) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineNetworkPolicyList contains a list of
+// VirtualMachineNetworkPolicy resources.
+type VirtualMachineNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineNetworkPolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineNetworkPolicy{},
+		&VirtualMachineNetworkPolicyList{},
+	)
+}
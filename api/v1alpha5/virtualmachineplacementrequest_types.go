@@ -0,0 +1,183 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachinePlacementRequestConditionComplete is the Type for a
+	// VirtualMachinePlacementRequest resource's status condition.
+	//
+	// The condition's status is set to true once placement has been
+	// determined for all of the request's VMs, whether or not it succeeded.
+	VirtualMachinePlacementRequestConditionComplete = "Complete"
+
+	// VirtualMachinePlacementRequestConditionPlaced is the Type for a
+	// VirtualMachinePlacementRequest resource's status condition.
+	//
+	// The condition's status is set to true when all of the request's VMs
+	// could be placed, and false when at least one of them could not be.
+	VirtualMachinePlacementRequestConditionPlaced = "Placed"
+
+	// VirtualMachinePlacementRequestConditionReasonPlacementFailed indicates
+	// that at least one of the request's VMs could not be placed.
+	VirtualMachinePlacementRequestConditionReasonPlacementFailed = "PlacementFailed"
+)
+
+// VirtualMachinePlacementRequestVM describes a VM, or a number of identical
+// VMs, to place.
+type VirtualMachinePlacementRequestVM struct {
+	// Name is the name of the VM.
+	//
+	// When Replicas is greater than one, the VMs are named by appending a
+	// hyphen and their index to this name, ex. my-vm-0, my-vm-1, etc.
+	Name string `json:"name"`
+
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+
+	// Replicas is the number of VMs to place with this template.
+	//
+	// Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Template describes the VM to place.
+	Template VirtualMachineTemplateSpec `json:"template"`
+}
+
+// VirtualMachinePlacementRequestSpec defines the desired state of a
+// VirtualMachinePlacementRequest.
+type VirtualMachinePlacementRequestSpec struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// VirtualMachines is the list of VMs to place.
+	//
+	// When GroupName is empty, each of these VMs is placed the same way as a
+	// VM that is created by itself, i.e. independently of the other VMs in
+	// this list.
+	VirtualMachines []VirtualMachinePlacementRequestVM `json:"virtualMachines,omitempty"`
+
+	// +optional
+
+	// GroupName is the name of a VirtualMachineGroup in the same namespace.
+	//
+	// When set, the VMs that are members of the group, either directly or
+	// indirectly via a nested group, are placed together with the VMs from
+	// VirtualMachines, the same way as the VMs of a group are placed. The
+	// VMs from VirtualMachines are placed as if they were members of the
+	// group.
+	GroupName string `json:"groupName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// TTLSecondsAfterFinished is the time-to-live duration for how long this
+	// resource will be allowed to exist once the placement is complete. After
+	// the TTL expires, the resource will be automatically deleted without the
+	// user having to take any direct action.
+	//
+	// If this field is unset then the request resource will not be
+	// automatically deleted. If this field is set to zero then the request
+	// resource is eligible for deletion immediately after it finishes.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// VirtualMachinePlacementRequestResult describes the placement of a VM.
+type VirtualMachinePlacementRequestResult struct {
+	// Name is the name of the VM.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Placement describes where the VM would be placed.
+	//
+	// This field is not set if the VM could not be placed.
+	Placement *VirtualMachinePlacementStatus `json:"placement,omitempty"`
+
+	// +optional
+
+	// Error describes why the VM could not be placed.
+	Error string `json:"error,omitempty"`
+}
+
+// VirtualMachinePlacementRequestStatus defines the observed state of a
+// VirtualMachinePlacementRequest.
+type VirtualMachinePlacementRequestStatus struct {
+	// +optional
+
+	// StartTime represents when the request was acknowledged by the
+	// controller. It is represented in RFC3339 form and is in UTC.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents when the request was completed. It is
+	// represented in RFC3339 form and is in UTC.
+	//
+	// The value of this field should be equal to the value of the
+	// LastTransitionTime for the status condition Type=Complete.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Results describes the placement of each of the request's VMs.
+	Results []VirtualMachinePlacementRequestResult `json:"results,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// request's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmplacement
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type=='Complete')].status"
+// +kubebuilder:printcolumn:name="Placed",type="string",JSONPath=".status.conditions[?(@.type=='Placed')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachinePlacementRequest determines where VMs would be placed, and
+// whether they can be placed at all, without creating them.
+type VirtualMachinePlacementRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachinePlacementRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachinePlacementRequestStatus `json:"status,omitempty"`
+}
+
+func (r *VirtualMachinePlacementRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachinePlacementRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachinePlacementRequestList contains a list of
+// VirtualMachinePlacementRequest resources.
+type VirtualMachinePlacementRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachinePlacementRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachinePlacementRequest{},
+		&VirtualMachinePlacementRequestList{},
+	)
+}
@@ -0,0 +1,302 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachinePowerScheduleConditionReady is the Type for a
+	// VirtualMachinePowerSchedule resource's status condition.
+	//
+	// The condition's status is set to true only when the schedule is valid
+	// and its target exists.
+	VirtualMachinePowerScheduleConditionReady = "Ready"
+)
+
+// Condition.Reason for Conditions related to VirtualMachinePowerSchedule.
+const (
+	// VirtualMachinePowerScheduleInvalidReason documents that the schedule of
+	// one of the windows or the time zone is invalid.
+	VirtualMachinePowerScheduleInvalidReason = "Invalid"
+
+	// VirtualMachinePowerScheduleGroupNotFoundReason documents that the
+	// VirtualMachineGroup targeted by the schedule does not exist.
+	VirtualMachinePowerScheduleGroupNotFoundReason = "GroupNotFound"
+
+	// VirtualMachinePowerSchedulePausedReason documents that the schedule is
+	// paused.
+	VirtualMachinePowerSchedulePausedReason = "Paused"
+)
+
+// VirtualMachinePowerScheduleAction is the power action applied to the VMs
+// targeted by a VirtualMachinePowerSchedule.
+//
+// +kubebuilder:validation:Enum=PowerOn;PowerOff;Suspend
+type VirtualMachinePowerScheduleAction string
+
+const (
+	// VirtualMachinePowerScheduleActionPowerOn sets the VMs' power state to
+	// PoweredOn.
+	VirtualMachinePowerScheduleActionPowerOn VirtualMachinePowerScheduleAction = "PowerOn"
+
+	// VirtualMachinePowerScheduleActionPowerOff sets the VMs' power state to
+	// PoweredOff.
+	VirtualMachinePowerScheduleActionPowerOff VirtualMachinePowerScheduleAction = "PowerOff"
+
+	// VirtualMachinePowerScheduleActionSuspend sets the VMs' power state to
+	// Suspended.
+	VirtualMachinePowerScheduleActionSuspend VirtualMachinePowerScheduleAction = "Suspend"
+)
+
+// PowerState returns the VM power state that corresponds to the action.
+func (a VirtualMachinePowerScheduleAction) PowerState() VirtualMachinePowerState {
+	switch a {
+	case VirtualMachinePowerScheduleActionPowerOn:
+		return VirtualMachinePowerStateOn
+	case VirtualMachinePowerScheduleActionPowerOff:
+		return VirtualMachinePowerStateOff
+	case VirtualMachinePowerScheduleActionSuspend:
+		return VirtualMachinePowerStateSuspended
+	}
+	return ""
+}
+
+// VirtualMachinePowerScheduleWindow describes when a power action is applied
+// to the targeted VMs.
+type VirtualMachinePowerScheduleWindow struct {
+	// Name is the name of the window.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// Schedule is a standard, five-field cron expression that describes when
+	// the action is applied, ex. "0 19 * * MON-FRI" for 7pm on weekdays. The
+	// expression is evaluated in the schedule's time zone.
+	Schedule string `json:"schedule"`
+
+	// Action is the power action applied to the targeted VMs.
+	Action VirtualMachinePowerScheduleAction `json:"action"`
+
+	// +optional
+
+	// PowerOpMode describes how the VMs are powered off or suspended. It is
+	// set as the VMs' spec.powerOffMode or spec.suspendMode, and is ignored
+	// when the action is PowerOn.
+	//
+	// When omitted, the VMs' existing power off or suspend mode is used.
+	PowerOpMode VirtualMachinePowerOpMode `json:"powerOpMode,omitempty"`
+}
+
+// VirtualMachinePowerScheduleExclusion describes a range of dates on which
+// the windows of a VirtualMachinePowerSchedule are not applied, ex. a
+// holiday.
+type VirtualMachinePowerScheduleExclusion struct {
+	// Name is the name of the exclusion, ex. New Year's Day.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}$"
+
+	// Date is the first date of the exclusion in the format YYYY-MM-DD. The
+	// date is in the schedule's time zone.
+	Date string `json:"date"`
+
+	// +optional
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}$"
+
+	// EndDate is the last date of the exclusion in the format YYYY-MM-DD.
+	//
+	// When omitted, the exclusion is the single day described by Date.
+	EndDate string `json:"endDate,omitempty"`
+}
+
+// VirtualMachinePowerScheduleSpec defines the desired state of
+// VirtualMachinePowerSchedule.
+type VirtualMachinePowerScheduleSpec struct {
+	// +optional
+
+	// Selector selects the VMs in the schedule's namespace by their labels.
+	// An empty selector selects all of the VMs in the namespace.
+	//
+	// Please note this field and GroupName are mutually exclusive.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+
+	// GroupName is the name of a VirtualMachineGroup in the schedule's
+	// namespace. The action is applied to the group's power state, so the
+	// group's boot order is honored when its members are powered on.
+	//
+	// Please note this field and Selector are mutually exclusive.
+	GroupName string `json:"groupName,omitempty"`
+
+	// +optional
+
+	// TimeZone is the name of the IANA time zone in which the windows'
+	// schedules and the exclusions' dates are evaluated, ex.
+	// America/Los_Angeles.
+	//
+	// Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+
+	// Windows describes when the power actions are applied to the VMs.
+	Windows []VirtualMachinePowerScheduleWindow `json:"windows"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Exclusions describes the dates on which the windows are not applied.
+	Exclusions []VirtualMachinePowerScheduleExclusion `json:"exclusions,omitempty"`
+
+	// +optional
+
+	// Paused may be set to true to stop applying the windows. The windows
+	// whose times pass while the schedule is paused are not applied when the
+	// schedule is resumed.
+	Paused bool `json:"paused,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// StartingDeadlineSeconds is the number of seconds after a window's
+	// scheduled time during which the window may still be applied, ex. if VM
+	// Operator was not running at the scheduled time. Windows whose deadline
+	// has passed are skipped.
+	//
+	// When omitted, the most recently missed window is always applied.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+
+	// HistoryLimit is the number of applied actions kept in the history of
+	// each VM.
+	//
+	// Defaults to 10.
+	HistoryLimit int32 `json:"historyLimit,omitempty"`
+}
+
+// VirtualMachinePowerScheduleHistoryEntry describes a power action that was
+// applied to a VM.
+type VirtualMachinePowerScheduleHistoryEntry struct {
+	// Window is the name of the window that was applied.
+	Window string `json:"window"`
+
+	// Action is the power action that was applied.
+	Action VirtualMachinePowerScheduleAction `json:"action"`
+
+	// ScheduleTime is the time at which the window was scheduled.
+	ScheduleTime metav1.Time `json:"scheduleTime"`
+
+	// +optional
+
+	// Error describes why the action could not be applied to the VM. It is
+	// empty when the action was applied.
+	Error string `json:"error,omitempty"`
+}
+
+// VirtualMachinePowerScheduleVMStatus describes the power actions a
+// VirtualMachinePowerSchedule applied to a VM.
+type VirtualMachinePowerScheduleVMStatus struct {
+	// Name is the name of the VM.
+	Name string `json:"name"`
+
+	// +optional
+
+	// History is the list of the most recently applied actions, from the
+	// oldest to the newest.
+	History []VirtualMachinePowerScheduleHistoryEntry `json:"history,omitempty"`
+}
+
+// VirtualMachinePowerScheduleStatus defines the observed state of
+// VirtualMachinePowerSchedule.
+type VirtualMachinePowerScheduleStatus struct {
+	// +optional
+
+	// LastScheduleTime is the time at which a window was last scheduled,
+	// whether or not it was applied.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// +optional
+
+	// NextScheduleTime is the time at which the next window is scheduled.
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// VMs describes the power actions that were applied to each VM.
+	VMs []VirtualMachinePowerScheduleVMStatus `json:"vms,omitempty"`
+
+	// +optional
+
+	// ObservedGeneration describes the value of the metadata.generation field
+	// the last time this object was reconciled by its primary controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// schedule's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmpowersched
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.groupName"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused"
+// +kubebuilder:printcolumn:name="Last-Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Next-Schedule",type="date",JSONPath=".status.nextScheduleTime"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachinePowerSchedule applies power actions to a set of VMs, or to a
+// VirtualMachineGroup, on a cron schedule, ex. to power off the VMs in a dev
+// namespace overnight. The actions are applied by setting the VMs'
+// spec.powerState, so the VMs are powered on, powered off, or suspended the
+// same way as when a user changes their power state.
+type VirtualMachinePowerSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachinePowerScheduleSpec   `json:"spec,omitempty"`
+	Status VirtualMachinePowerScheduleStatus `json:"status,omitempty"`
+}
+
+func (s *VirtualMachinePowerSchedule) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachinePowerSchedule) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachinePowerScheduleList contains a list of
+// VirtualMachinePowerSchedule resources.
+type VirtualMachinePowerScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachinePowerSchedule `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachinePowerSchedule{},
+		&VirtualMachinePowerScheduleList{},
+	)
+}
@@ -0,0 +1,128 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha5
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineProfileNetworkSpec describes the network settings a
+// VirtualMachineProfile sets on VMs.
+type VirtualMachineProfileNetworkSpec struct {
+	// +optional
+
+	// Nameservers is a list of IP4 and/or IP6 addresses used as DNS
+	// nameservers. It is set as the VM's spec.network.nameservers.
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +optional
+
+	// SearchDomains is a list of search domains used when resolving IP
+	// addresses with DNS. It is set as the VM's spec.network.searchDomains.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// VirtualMachineProfileSpec defines the desired state of
+// VirtualMachineProfile.
+type VirtualMachineProfileSpec struct {
+	// +optional
+
+	// Selector selects the VMs in the profile's namespace to which the profile
+	// applies by their labels. An empty selector selects all of the VMs in
+	// the namespace.
+	//
+	// When omitted, the profile only applies to the VMs that select it by
+	// name with spec.profileName.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+
+	// Priority describes the precedence of this profile over the other
+	// profiles that select a VM by its labels. The values of a profile with a
+	// higher priority take precedence over the values of a profile with a
+	// lower priority. Profiles with the same priority take precedence in order
+	// by name.
+	//
+	// The profile a VM selects by name always takes precedence over the
+	// profiles that select the VM by its labels.
+	//
+	// Defaults to 0.
+	Priority int32 `json:"priority,omitempty"`
+
+	// +optional
+
+	// StorageClass is set as the VM's spec.storageClass.
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +optional
+
+	// Bootstrap is set as the VM's spec.bootstrap. If the VM already specifies
+	// cloud-init with an inline cloud-config that does not have any users,
+	// the users from this field's cloud-config are set instead.
+	Bootstrap *VirtualMachineBootstrapSpec `json:"bootstrap,omitempty"`
+
+	// +optional
+
+	// Network describes the network settings set on the VM.
+	Network *VirtualMachineProfileNetworkSpec `json:"network,omitempty"`
+
+	// +optional
+
+	// ReadinessProbe is set as the VM's spec.readinessProbe.
+	ReadinessProbe *VirtualMachineReadinessProbeSpec `json:"readinessProbe,omitempty"`
+
+	// +optional
+
+	// Crypto is set as the VM's spec.crypto.
+	Crypto *VirtualMachineCryptoSpec `json:"crypto,omitempty"`
+}
+
+// VirtualMachineAppliedProfile describes the values a VirtualMachineProfile
+// set on a VM.
+type VirtualMachineAppliedProfile struct {
+	// Name is the name of the VirtualMachineProfile.
+	Name string `json:"name"`
+
+	// +optional
+	// +listType=set
+
+	// Fields are the paths of the VM's fields that were set from the profile,
+	// ex. spec.storageClass.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmprofile
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineProfile is a set of default values for the VMs in a
+// namespace. VMs select a profile by name with spec.profileName, or a profile
+// selects VMs by their labels. The profile's values are only set on the VM's
+// fields that are not already set, and only when the VM is created.
+type VirtualMachineProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineProfileList contains a list of VirtualMachineProfile
+// resources.
+type VirtualMachineProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineProfile `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineProfile{},
+		&VirtualMachineProfileList{},
+	)
+}
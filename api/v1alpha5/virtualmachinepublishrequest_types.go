@@ -62,6 +62,11 @@ const (
 	// and unencrypted disks.
 	SourceVirtualMachineUnsupportedEncryptionReason = "SourceVirtualMachineUnsupportedEncryption"
 
+	// SourceVirtualMachineNotPoweredOffReason documents that the source VM of
+	// the VirtualMachinePublishRequest must be powered off before it can be
+	// exported to an OCI or HTTP target.
+	SourceVirtualMachineNotPoweredOffReason = "SourceVirtualMachineNotPoweredOff"
+
 	// TargetContentLibraryNotExistReason documents that the target content
 	// library of the VirtualMachinePublishRequest doesn't exist.
 	TargetContentLibraryNotExistReason = "TargetContentLibraryNotExist"
@@ -84,6 +89,11 @@ const (
 	// class which is incompatible with the encryption status of the source VM.
 	TargetContentLibraryIncompatibleStorageClassReason = "TargetContentLibraryIncompatibleStorageClass"
 
+	// TargetSecretInvalidReason documents that the Secret referenced by the
+	// VirtualMachinePublishRequest's OCI or HTTP target does not exist or
+	// does not contain the expected data.
+	TargetSecretInvalidReason = "TargetSecretInvalid"
+
 	// TargetItemAlreadyExistsReason documents that an item with the same name
 	// as the VirtualMachinePublishRequest's target item name exists in
 	// the target content library.
@@ -135,6 +145,10 @@ const (
 	// VirtualMachinePublishRequestUUIDExtraConfigKey is the ExtraConfig key to store UUID of the
 	// VirtualMachinePublishRequest that clones a source virtual machine, as a virtual machine template.
 	VirtualMachinePublishRequestUUIDExtraConfigKey = "vmservice.vmpub.uuid"
+
+	// VirtualMachinePublishRequestHTTPTargetURLSecretKey is the key of the
+	// Secret referenced by an HTTP target that contains the pre-signed URL.
+	VirtualMachinePublishRequestHTTPTargetURLSecretKey = "url"
 )
 
 // VirtualMachinePublishRequestSource is the source of a publication request,
@@ -212,6 +226,51 @@ type VirtualMachinePublishRequestTargetLocation struct {
 	Kind string `json:"kind,omitempty"`
 }
 
+// VirtualMachinePublishRequestOCITarget describes an OCI registry to which a
+// VM is published as an OCI artifact.
+type VirtualMachinePublishRequestOCITarget struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Reference is the reference of the OCI artifact to push, ex.
+	// registry.example.com/images/my-vm:v1.
+	//
+	// The artifact has one layer for each of the exported files. The OVF
+	// descriptor is the first layer, and each layer is named with the
+	// org.opencontainers.image.title annotation. This is the same layout
+	// that is supported by VirtualMachineImageImport.
+	Reference string `json:"reference"`
+
+	// +optional
+
+	// PushSecretName is the name of a Secret of type
+	// kubernetes.io/dockerconfigjson in the same namespace as the
+	// VirtualMachinePublishRequest that contains the credentials used to
+	// push the artifact.
+	//
+	// If omitted the artifact is pushed anonymously.
+	PushSecretName string `json:"pushSecretName,omitempty"`
+
+	// +optional
+
+	// Insecure allows the registry to be accessed using plain HTTP or using
+	// HTTPS without verifying its certificate.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// VirtualMachinePublishRequestHTTPTarget describes a pre-signed HTTP URL to
+// which a VM is uploaded as an OVA.
+type VirtualMachinePublishRequestHTTPTarget struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// URLSecretName is the name of a Secret in the same namespace as the
+	// VirtualMachinePublishRequest whose "url" key contains the pre-signed
+	// URL to which the OVA is uploaded with an HTTP PUT request.
+	//
+	// The URL is stored in a Secret since pre-signed URLs typically include
+	// credentials in their query parameters.
+	URLSecretName string `json:"urlSecretName"`
+}
+
 // VirtualMachinePublishRequestTarget is the target of a publication request,
 // typically a ContentLibrary resource.
 type VirtualMachinePublishRequestTarget struct {
@@ -230,6 +289,50 @@ type VirtualMachinePublishRequestTarget struct {
 	// Location contains information about the location to which to publish
 	// the VM.
 	Location VirtualMachinePublishRequestTargetLocation `json:"location,omitempty"`
+
+	// +optional
+
+	// OCI describes an OCI registry to which the VM is exported and pushed
+	// as an OCI artifact instead of being published to a content library.
+	//
+	// This field is mutually exclusive with spec.target.location.name and
+	// spec.target.http.
+	OCI *VirtualMachinePublishRequestOCITarget `json:"oci,omitempty"`
+
+	// +optional
+
+	// HTTP describes a pre-signed URL to which the VM is exported and
+	// uploaded as an OVA instead of being published to a content library.
+	//
+	// This field is mutually exclusive with spec.target.location.name and
+	// spec.target.oci.
+	HTTP *VirtualMachinePublishRequestHTTPTarget `json:"http,omitempty"`
+}
+
+// VirtualMachinePublishRequestArtifactStatus describes the artifact that was
+// published to an OCI or HTTP target.
+type VirtualMachinePublishRequestArtifactStatus struct {
+	// +optional
+
+	// Reference is the digest reference of the OCI artifact that was pushed,
+	// ex. registry.example.com/images/my-vm@sha256:abc.
+	Reference string `json:"reference,omitempty"`
+
+	// +optional
+
+	// URL is the URL to which the OVA was uploaded, without its query.
+	URL string `json:"url,omitempty"`
+
+	// +optional
+
+	// Digest is the digest of the OCI artifact's manifest, or the SHA-256
+	// digest of the uploaded OVA, ex. sha256:abc.
+	Digest string `json:"digest,omitempty"`
+
+	// +optional
+
+	// Size is the number of bytes that were uploaded.
+	Size int64 `json:"size,omitempty"`
 }
 
 // VirtualMachinePublishRequestSpec defines the desired state of a
@@ -348,6 +451,14 @@ type VirtualMachinePublishRequestStatus struct {
 
 	// +optional
 
+	// Artifact describes the artifact that was published when the target is
+	// an OCI registry or a pre-signed HTTP URL.
+	//
+	// This field will not be set until the artifact has been uploaded.
+	Artifact *VirtualMachinePublishRequestArtifactStatus `json:"artifact,omitempty"`
+
+	// +optional
+
 	// Ready is set to true only when the VM has been published successfully
 	// and the new VirtualMachineImage resource is ready.
 	//
@@ -360,6 +471,9 @@ type VirtualMachinePublishRequestStatus struct {
 	//   * Uploaded
 	//   * ImageAvailable
 	//   * Complete
+	//
+	// The ImageAvailable condition is not present when the target is an OCI
+	// registry or a pre-signed HTTP URL.
 	Ready bool `json:"ready,omitempty"`
 
 	// +optional
@@ -64,6 +64,25 @@ type VirtualMachineTemplateSpec struct {
 	Spec VirtualMachineSpec `json:"spec,omitempty"`
 }
 
+// VirtualMachineReplicaSetImageStreamUpdatePolicy describes what happens to
+// the replicas of a VirtualMachineReplicaSet when the
+// VirtualMachineImageStream referenced by its template moves to a new image.
+//
+// +kubebuilder:validation:Enum=Manual;Rolling
+type VirtualMachineReplicaSetImageStreamUpdatePolicy string
+
+const (
+	// VirtualMachineReplicaSetImageStreamUpdatePolicyManual indicates the
+	// existing replicas are not replaced when the stream moves. Only new
+	// replicas are created from the stream's new image.
+	VirtualMachineReplicaSetImageStreamUpdatePolicyManual VirtualMachineReplicaSetImageStreamUpdatePolicy = "Manual"
+
+	// VirtualMachineReplicaSetImageStreamUpdatePolicyRolling indicates the
+	// replicas that were not created from the stream's current image are
+	// replaced one at a time.
+	VirtualMachineReplicaSetImageStreamUpdatePolicyRolling VirtualMachineReplicaSetImageStreamUpdatePolicy = "Rolling"
+)
+
 // VirtualMachineReplicaSetSpec is the specification of a VirtualMachineReplicaSet.
 type VirtualMachineReplicaSetSpec struct {
 	// +optional
@@ -96,6 +115,15 @@ type VirtualMachineReplicaSetSpec struct {
 	// Template is the object that describes the virtual machine that will be
 	// created if insufficient replicas are detected.
 	Template VirtualMachineTemplateSpec `json:"template,omitempty"`
+
+	// +optional
+	//
+	// ImageStreamUpdatePolicy describes what happens to the existing replicas
+	// when the template's spec.image refers to a VirtualMachineImageStream and
+	// the stream moves to a new image.
+	//
+	// Defaults to Manual.
+	ImageStreamUpdatePolicy VirtualMachineReplicaSetImageStreamUpdatePolicy `json:"imageStreamUpdatePolicy,omitempty"`
 }
 
 // VirtualMachineReplicaSetStatus represents the observed state of a
@@ -7,6 +7,8 @@ package v1alpha5
 import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
 )
 
 const (
@@ -60,6 +62,30 @@ type VirtualMachineSnapshotSpec struct {
 	// VMName represents the name of the virtual machine for which the
 	// snapshot is requested.
 	VMName string `json:"vmName,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// PreSnapshotHooks are run in order inside the guest before the snapshot
+	// is taken, ex. to flush and freeze an application's writes so the
+	// snapshot is application-consistent.
+	//
+	// Hooks are only run if the VM is powered on.
+	PreSnapshotHooks []VirtualMachineSnapshotHook `json:"preSnapshotHooks,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// PostSnapshotHooks are run in order inside the guest after the snapshot
+	// is taken, ex. to thaw the application frozen by the pre-snapshot hooks.
+	//
+	// Post-snapshot hooks are always run after the pre-snapshot hooks, even
+	// if a pre-snapshot hook or the snapshot fails or times out.
+	//
+	// Hooks are only run if the VM is powered on.
+	PostSnapshotHooks []VirtualMachineSnapshotHook `json:"postSnapshotHooks,omitempty"`
 }
 
 // QuiesceSpec represents specifications that will be used to quiesce
@@ -74,6 +100,146 @@ type QuiesceSpec struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Abort;Continue
+
+// VirtualMachineSnapshotHookFailurePolicy describes what happens when a
+// snapshot hook fails.
+type VirtualMachineSnapshotHookFailurePolicy string
+
+const (
+	// VirtualMachineSnapshotHookFailurePolicyAbort stops running the
+	// remaining hooks when a hook fails. A failed pre-snapshot hook also
+	// aborts the snapshot.
+	VirtualMachineSnapshotHookFailurePolicyAbort VirtualMachineSnapshotHookFailurePolicy = "Abort"
+
+	// VirtualMachineSnapshotHookFailurePolicyContinue ignores the failure and
+	// continues with the next hook.
+	VirtualMachineSnapshotHookFailurePolicyContinue VirtualMachineSnapshotHookFailurePolicy = "Continue"
+)
+
+// VirtualMachineSnapshotHook describes an action run inside the guest before
+// or after the snapshot is taken. Exactly one of Command or HTTP must be
+// specified.
+type VirtualMachineSnapshotHook struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the hook and must be unique within the list of
+	// hooks.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Command describes a program run inside the guest using VMware Tools.
+	// The hook fails if the program exits with a non-zero code.
+	Command *VirtualMachineSnapshotHookCommand `json:"command,omitempty"`
+
+	// +optional
+
+	// HTTP describes a request sent to an endpoint in the guest. The hook
+	// fails if the response status code is not 2xx.
+	HTTP *VirtualMachineSnapshotHookHTTP `json:"http,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:format:=duration
+
+	// Timeout is the maximum amount of time the hook may run. The timeout may
+	// not be more than 10 minutes.
+	//
+	// Defaults to 1 minute.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Abort
+
+	// OnFailure describes what happens when the hook fails or times out.
+	// A failed post-snapshot hook never affects the snapshot, which has
+	// already been taken.
+	//
+	// Defaults to Abort.
+	OnFailure VirtualMachineSnapshotHookFailurePolicy `json:"onFailure,omitempty"`
+}
+
+// VirtualMachineSnapshotHookCommand describes a program run inside the guest
+// using VMware Tools guest operations.
+type VirtualMachineSnapshotHookCommand struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Path is the path of the program in the guest, ex. /usr/bin/fsfreeze.
+	Path string `json:"path"`
+
+	// +optional
+
+	// Args are the arguments passed to the program.
+	Args []string `json:"args,omitempty"`
+
+	// +optional
+
+	// Env are the environment variables set for the program, in addition to
+	// those of the guest user.
+	Env []vmopv1common.NameValuePair `json:"env,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// CredentialsSecretName is the name of the Secret in the snapshot's
+	// namespace with the username and password keys used to authenticate with
+	// the guest.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+
+// VirtualMachineSnapshotHookHTTPScheme is the scheme used by an HTTP snapshot
+// hook.
+type VirtualMachineSnapshotHookHTTPScheme string
+
+const (
+	// VirtualMachineSnapshotHookHTTPSchemeHTTP connects to the endpoint
+	// using plain HTTP.
+	VirtualMachineSnapshotHookHTTPSchemeHTTP VirtualMachineSnapshotHookHTTPScheme = "HTTP"
+
+	// VirtualMachineSnapshotHookHTTPSchemeHTTPS connects to the endpoint
+	// using HTTPS.
+	VirtualMachineSnapshotHookHTTPSchemeHTTPS VirtualMachineSnapshotHookHTTPScheme = "HTTPS"
+)
+
+// VirtualMachineSnapshotHookHTTP describes a request sent to an endpoint in
+// the guest. The request is sent to the VM's primary IP address.
+type VirtualMachineSnapshotHookHTTP struct {
+	// +optional
+	// +kubebuilder:default=HTTP
+
+	// Scheme is the scheme used to connect to the endpoint.
+	//
+	// Defaults to HTTP.
+	Scheme VirtualMachineSnapshotHookHTTPScheme `json:"scheme,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port is the port of the endpoint.
+	Port int32 `json:"port"`
+
+	// +optional
+
+	// Path is the path of the endpoint, ex. /hooks/freeze.
+	Path string `json:"path,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=POST
+	// +kubebuilder:validation:Enum=GET;POST;PUT
+
+	// Method is the HTTP method of the request.
+	//
+	// Defaults to POST.
+	Method string `json:"method,omitempty"`
+
+	// +optional
+
+	// InsecureSkipTLSVerify disables verification of the endpoint's
+	// certificate when the scheme is HTTPS.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
 const (
 	// VirtualMachineSnapshotReadyCondition represents the condition
 	// that the virtual machine snapshot is ready.
@@ -102,6 +268,33 @@ const (
 	// VirtualMachineSnapshotCreationFailedReason documents that the virtual machine
 	// snapshot creation has failed.
 	VirtualMachineSnapshotCreationFailedReason = "VirtualMachineSnapshotCreationFailed"
+
+	// VirtualMachineSnapshotCreationAbortedReason documents that the virtual
+	// machine snapshot was not created because a pre-snapshot hook failed.
+	VirtualMachineSnapshotCreationAbortedReason = "VirtualMachineSnapshotCreationAborted"
+)
+
+const (
+	// VirtualMachineSnapshotPreSnapshotHooksCondition exposes the result of
+	// running the snapshot's pre-snapshot hooks. The snapshot is not taken
+	// until this condition is present.
+	VirtualMachineSnapshotPreSnapshotHooksCondition = "VirtualMachineSnapshotPreSnapshotHooks"
+
+	// VirtualMachineSnapshotPostSnapshotHooksCondition exposes the result of
+	// running the snapshot's post-snapshot hooks.
+	VirtualMachineSnapshotPostSnapshotHooksCondition = "VirtualMachineSnapshotPostSnapshotHooks"
+
+	// VirtualMachineSnapshotHookFailedReason documents that a snapshot hook
+	// failed.
+	VirtualMachineSnapshotHookFailedReason = "VirtualMachineSnapshotHookFailed"
+
+	// VirtualMachineSnapshotHookTimedOutReason documents that a snapshot hook
+	// did not complete within its timeout.
+	VirtualMachineSnapshotHookTimedOutReason = "VirtualMachineSnapshotHookTimedOut"
+
+	// VirtualMachineSnapshotHooksSkippedReason documents that the snapshot
+	// hooks were not run because the VM is not powered on.
+	VirtualMachineSnapshotHooksSkippedReason = "VirtualMachineSnapshotHooksSkipped"
 )
 
 const (
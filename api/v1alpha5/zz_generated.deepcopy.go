@@ -13,8 +13,10 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/sysprep"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -30,6 +32,16 @@ func (in *AffinitySpec) DeepCopyInto(out *AffinitySpec) {
 		*out = new(VMAntiAffinitySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ZoneAffinity != nil {
+		in, out := &in.ZoneAffinity, &out.ZoneAffinity
+		*out = new(ZoneAffinitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(HostAffinitySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AffinitySpec.
@@ -101,6 +113,21 @@ func (in *ClusterVirtualMachineImageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicDirectPathIODevice) DeepCopyInto(out *DynamicDirectPathIODevice) {
 	*out = *in
@@ -161,6 +188,55 @@ func (in *GuestInfoAction) DeepCopy() *GuestInfoAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostAffinitySpec) DeepCopyInto(out *HostAffinitySpec) {
+	*out = *in
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = make([]HostSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]HostSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostAffinitySpec.
+func (in *HostAffinitySpec) DeepCopy() *HostAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSelectorTerm) DeepCopyInto(out *HostSelectorTerm) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelectorTerm.
+func (in *HostSelectorTerm) DeepCopy() *HostSelectorTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSelectorTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IDEControllerSpec) DeepCopyInto(out *IDEControllerSpec) {
 	*out = *in
@@ -692,6 +768,129 @@ func (in *VirtualMachine) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAdmissionPolicy) DeepCopyInto(out *VirtualMachineAdmissionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdmissionPolicy.
+func (in *VirtualMachineAdmissionPolicy) DeepCopy() *VirtualMachineAdmissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAdmissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineAdmissionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAdmissionPolicyDefault) DeepCopyInto(out *VirtualMachineAdmissionPolicyDefault) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdmissionPolicyDefault.
+func (in *VirtualMachineAdmissionPolicyDefault) DeepCopy() *VirtualMachineAdmissionPolicyDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAdmissionPolicyDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAdmissionPolicyList) DeepCopyInto(out *VirtualMachineAdmissionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineAdmissionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdmissionPolicyList.
+func (in *VirtualMachineAdmissionPolicyList) DeepCopy() *VirtualMachineAdmissionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAdmissionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineAdmissionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAdmissionPolicySpec) DeepCopyInto(out *VirtualMachineAdmissionPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]VirtualMachineAdmissionPolicyOperation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]VirtualMachineAdmissionPolicyValidation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make([]VirtualMachineAdmissionPolicyDefault, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdmissionPolicySpec.
+func (in *VirtualMachineAdmissionPolicySpec) DeepCopy() *VirtualMachineAdmissionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAdmissionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAdmissionPolicyValidation) DeepCopyInto(out *VirtualMachineAdmissionPolicyValidation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdmissionPolicyValidation.
+func (in *VirtualMachineAdmissionPolicyValidation) DeepCopy() *VirtualMachineAdmissionPolicyValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAdmissionPolicyValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineAdvancedSpec) DeepCopyInto(out *VirtualMachineAdvancedSpec) {
 	*out = *in
@@ -717,6 +916,26 @@ func (in *VirtualMachineAdvancedSpec) DeepCopy() *VirtualMachineAdvancedSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAppliedProfile) DeepCopyInto(out *VirtualMachineAppliedProfile) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAppliedProfile.
+func (in *VirtualMachineAppliedProfile) DeepCopy() *VirtualMachineAppliedProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAppliedProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineBootOptions) DeepCopyInto(out *VirtualMachineBootOptions) {
 	*out = *in
@@ -1262,6 +1481,104 @@ func (in *VirtualMachineCryptoStatus) DeepCopy() *VirtualMachineCryptoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDisruptionBudget) DeepCopyInto(out *VirtualMachineDisruptionBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDisruptionBudget.
+func (in *VirtualMachineDisruptionBudget) DeepCopy() *VirtualMachineDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineDisruptionBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDisruptionBudgetList) DeepCopyInto(out *VirtualMachineDisruptionBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineDisruptionBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDisruptionBudgetList.
+func (in *VirtualMachineDisruptionBudgetList) DeepCopy() *VirtualMachineDisruptionBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDisruptionBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineDisruptionBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDisruptionBudgetSpec) DeepCopyInto(out *VirtualMachineDisruptionBudgetSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDisruptionBudgetSpec.
+func (in *VirtualMachineDisruptionBudgetSpec) DeepCopy() *VirtualMachineDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDriftStatus) DeepCopyInto(out *VirtualMachineDriftStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDriftStatus.
+func (in *VirtualMachineDriftStatus) DeepCopy() *VirtualMachineDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineGroup) DeepCopyInto(out *VirtualMachineGroup) {
 	*out = *in
@@ -1596,32 +1913,243 @@ func (in *VirtualMachineGroupStatus) DeepCopy() *VirtualMachineGroupStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineGuestStatus) DeepCopyInto(out *VirtualMachineGuestStatus) {
+func (in *VirtualMachineGuestOperation) DeepCopyInto(out *VirtualMachineGuestOperation) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestStatus.
-func (in *VirtualMachineGuestStatus) DeepCopy() *VirtualMachineGuestStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperation.
+func (in *VirtualMachineGuestOperation) DeepCopy() *VirtualMachineGuestOperation {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineGuestStatus)
+	out := new(VirtualMachineGuestOperation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineGuestOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineHardwareSpec) DeepCopyInto(out *VirtualMachineHardwareSpec) {
+func (in *VirtualMachineGuestOperationCopyIn) DeepCopyInto(out *VirtualMachineGuestOperationCopyIn) {
 	*out = *in
-	if in.Cdrom != nil {
-		in, out := &in.Cdrom, &out.Cdrom
-		*out = make([]VirtualMachineCdromSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationCopyIn.
+func (in *VirtualMachineGuestOperationCopyIn) DeepCopy() *VirtualMachineGuestOperationCopyIn {
+	if in == nil {
+		return nil
 	}
-	if in.IDEControllers != nil {
-		in, out := &in.IDEControllers, &out.IDEControllers
+	out := new(VirtualMachineGuestOperationCopyIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationCopyOut) DeepCopyInto(out *VirtualMachineGuestOperationCopyOut) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationCopyOut.
+func (in *VirtualMachineGuestOperationCopyOut) DeepCopy() *VirtualMachineGuestOperationCopyOut {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationCopyOut)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationFileSource) DeepCopyInto(out *VirtualMachineGuestOperationFileSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(common.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationFileSource.
+func (in *VirtualMachineGuestOperationFileSource) DeepCopy() *VirtualMachineGuestOperationFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationList) DeepCopyInto(out *VirtualMachineGuestOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineGuestOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationList.
+func (in *VirtualMachineGuestOperationList) DeepCopy() *VirtualMachineGuestOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineGuestOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationRunProgram) DeepCopyInto(out *VirtualMachineGuestOperationRunProgram) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]common.NameValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationRunProgram.
+func (in *VirtualMachineGuestOperationRunProgram) DeepCopy() *VirtualMachineGuestOperationRunProgram {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationRunProgram)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationSpec) DeepCopyInto(out *VirtualMachineGuestOperationSpec) {
+	*out = *in
+	if in.CopyIn != nil {
+		in, out := &in.CopyIn, &out.CopyIn
+		*out = new(VirtualMachineGuestOperationCopyIn)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CopyOut != nil {
+		in, out := &in.CopyOut, &out.CopyOut
+		*out = new(VirtualMachineGuestOperationCopyOut)
+		**out = **in
+	}
+	if in.RunProgram != nil {
+		in, out := &in.RunProgram, &out.RunProgram
+		*out = new(VirtualMachineGuestOperationRunProgram)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationSpec.
+func (in *VirtualMachineGuestOperationSpec) DeepCopy() *VirtualMachineGuestOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestOperationStatus) DeepCopyInto(out *VirtualMachineGuestOperationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestOperationStatus.
+func (in *VirtualMachineGuestOperationStatus) DeepCopy() *VirtualMachineGuestOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestStatus) DeepCopyInto(out *VirtualMachineGuestStatus) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestStatus.
+func (in *VirtualMachineGuestStatus) DeepCopy() *VirtualMachineGuestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineHardwareSpec) DeepCopyInto(out *VirtualMachineHardwareSpec) {
+	*out = *in
+	if in.Cdrom != nil {
+		in, out := &in.Cdrom, &out.Cdrom
+		*out = make([]VirtualMachineCdromSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IDEControllers != nil {
+		in, out := &in.IDEControllers, &out.IDEControllers
 		*out = make([]IDEControllerSpec, len(*in))
 		copy(*out, *in)
 	}
@@ -1705,34 +2233,7 @@ func (in *VirtualMachineHardwareVGPUStatus) DeepCopy() *VirtualMachineHardwareVG
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImage) DeepCopyInto(out *VirtualMachineImage) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImage.
-func (in *VirtualMachineImage) DeepCopy() *VirtualMachineImage {
-	if in == nil {
-		return nil
-	}
-	out := new(VirtualMachineImage)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCache) DeepCopyInto(out *VirtualMachineImageCache) {
+func (in *VirtualMachineIPPool) DeepCopyInto(out *VirtualMachineIPPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1740,18 +2241,18 @@ func (in *VirtualMachineImageCache) DeepCopyInto(out *VirtualMachineImageCache)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCache.
-func (in *VirtualMachineImageCache) DeepCopy() *VirtualMachineImageCache {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIPPool.
+func (in *VirtualMachineIPPool) DeepCopy() *VirtualMachineIPPool {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCache)
+	out := new(VirtualMachineIPPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImageCache) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineIPPool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1759,46 +2260,46 @@ func (in *VirtualMachineImageCache) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheFileStatus) DeepCopyInto(out *VirtualMachineImageCacheFileStatus) {
+func (in *VirtualMachineIPPoolAllocation) DeepCopyInto(out *VirtualMachineIPPoolAllocation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheFileStatus.
-func (in *VirtualMachineImageCacheFileStatus) DeepCopy() *VirtualMachineImageCacheFileStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIPPoolAllocation.
+func (in *VirtualMachineIPPoolAllocation) DeepCopy() *VirtualMachineIPPoolAllocation {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheFileStatus)
+	out := new(VirtualMachineIPPoolAllocation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheList) DeepCopyInto(out *VirtualMachineImageCacheList) {
+func (in *VirtualMachineIPPoolList) DeepCopyInto(out *VirtualMachineIPPoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachineImageCache, len(*in))
+		*out = make([]VirtualMachineIPPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheList.
-func (in *VirtualMachineImageCacheList) DeepCopy() *VirtualMachineImageCacheList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIPPoolList.
+func (in *VirtualMachineIPPoolList) DeepCopy() *VirtualMachineIPPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheList)
+	out := new(VirtualMachineIPPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImageCacheList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineIPPoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1806,177 +2307,165 @@ func (in *VirtualMachineImageCacheList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheLocationSpec) DeepCopyInto(out *VirtualMachineImageCacheLocationSpec) {
+func (in *VirtualMachineIPPoolSpec) DeepCopyInto(out *VirtualMachineIPPoolSpec) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheLocationSpec.
-func (in *VirtualMachineImageCacheLocationSpec) DeepCopy() *VirtualMachineImageCacheLocationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIPPoolSpec.
+func (in *VirtualMachineIPPoolSpec) DeepCopy() *VirtualMachineIPPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheLocationSpec)
+	out := new(VirtualMachineIPPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheLocationStatus) DeepCopyInto(out *VirtualMachineImageCacheLocationStatus) {
+func (in *VirtualMachineIPPoolStatus) DeepCopyInto(out *VirtualMachineIPPoolStatus) {
 	*out = *in
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
-		*out = make([]VirtualMachineImageCacheFileStatus, len(*in))
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]VirtualMachineIPPoolAllocation, len(*in))
 		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheLocationStatus.
-func (in *VirtualMachineImageCacheLocationStatus) DeepCopy() *VirtualMachineImageCacheLocationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIPPoolStatus.
+func (in *VirtualMachineIPPoolStatus) DeepCopy() *VirtualMachineIPPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheLocationStatus)
+	out := new(VirtualMachineIPPoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheOVFStatus) DeepCopyInto(out *VirtualMachineImageCacheOVFStatus) {
+func (in *VirtualMachineImage) DeepCopyInto(out *VirtualMachineImage) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheOVFStatus.
-func (in *VirtualMachineImageCacheOVFStatus) DeepCopy() *VirtualMachineImageCacheOVFStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImage.
+func (in *VirtualMachineImage) DeepCopy() *VirtualMachineImage {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheOVFStatus)
+	out := new(VirtualMachineImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheSpec) DeepCopyInto(out *VirtualMachineImageCacheSpec) {
+func (in *VirtualMachineImageCache) DeepCopyInto(out *VirtualMachineImageCache) {
 	*out = *in
-	if in.Locations != nil {
-		in, out := &in.Locations, &out.Locations
-		*out = make([]VirtualMachineImageCacheLocationSpec, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheSpec.
-func (in *VirtualMachineImageCacheSpec) DeepCopy() *VirtualMachineImageCacheSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCache.
+func (in *VirtualMachineImageCache) DeepCopy() *VirtualMachineImageCache {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheSpec)
+	out := new(VirtualMachineImageCache)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheStatus) DeepCopyInto(out *VirtualMachineImageCacheStatus) {
-	*out = *in
-	if in.Locations != nil {
-		in, out := &in.Locations, &out.Locations
-		*out = make([]VirtualMachineImageCacheLocationStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageCache) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.OVF != nil {
-		in, out := &in.OVF, &out.OVF
-		*out = new(VirtualMachineImageCacheOVFStatus)
-		**out = **in
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageCacheEvictionStatus) DeepCopyInto(out *VirtualMachineImageCacheEvictionStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
 	}
+	in.EvictionTime.DeepCopyInto(&out.EvictionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheStatus.
-func (in *VirtualMachineImageCacheStatus) DeepCopy() *VirtualMachineImageCacheStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheEvictionStatus.
+func (in *VirtualMachineImageCacheEvictionStatus) DeepCopy() *VirtualMachineImageCacheEvictionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheStatus)
+	out := new(VirtualMachineImageCacheEvictionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageDiskInfo) DeepCopyInto(out *VirtualMachineImageDiskInfo) {
+func (in *VirtualMachineImageCacheFileStatus) DeepCopyInto(out *VirtualMachineImageCacheFileStatus) {
 	*out = *in
-	if in.Limit != nil {
-		in, out := &in.Limit, &out.Limit
-		x := (*in).DeepCopy()
-		*out = &x
-	}
-	if in.Requested != nil {
-		in, out := &in.Requested, &out.Requested
-		x := (*in).DeepCopy()
-		*out = &x
-	}
-	if in.ControllerBusNumber != nil {
-		in, out := &in.ControllerBusNumber, &out.ControllerBusNumber
-		*out = new(int32)
-		**out = **in
-	}
-	if in.UnitNumber != nil {
-		in, out := &in.UnitNumber, &out.UnitNumber
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageDiskInfo.
-func (in *VirtualMachineImageDiskInfo) DeepCopy() *VirtualMachineImageDiskInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheFileStatus.
+func (in *VirtualMachineImageCacheFileStatus) DeepCopy() *VirtualMachineImageCacheFileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageDiskInfo)
+	out := new(VirtualMachineImageCacheFileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
+func (in *VirtualMachineImageCacheList) DeepCopyInto(out *VirtualMachineImageCacheList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachineImage, len(*in))
+		*out = make([]VirtualMachineImageCache, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
-func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheList.
+func (in *VirtualMachineImageCacheList) DeepCopy() *VirtualMachineImageCacheList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageList)
+	out := new(VirtualMachineImageCacheList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineImageCacheList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1984,104 +2473,120 @@ func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
+func (in *VirtualMachineImageCacheLocationSpec) DeepCopyInto(out *VirtualMachineImageCacheLocationSpec) {
 	*out = *in
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
-func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheLocationSpec.
+func (in *VirtualMachineImageCacheLocationSpec) DeepCopy() *VirtualMachineImageCacheLocationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageOSInfo)
+	out := new(VirtualMachineImageCacheLocationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+func (in *VirtualMachineImageCacheLocationStatus) DeepCopyInto(out *VirtualMachineImageCacheLocationStatus) {
 	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]VirtualMachineImageCacheFileStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
-func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheLocationStatus.
+func (in *VirtualMachineImageCacheLocationStatus) DeepCopy() *VirtualMachineImageCacheLocationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageProductInfo)
+	out := new(VirtualMachineImageCacheLocationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageRef) DeepCopyInto(out *VirtualMachineImageRef) {
+func (in *VirtualMachineImageCacheOVFStatus) DeepCopyInto(out *VirtualMachineImageCacheOVFStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageRef.
-func (in *VirtualMachineImageRef) DeepCopy() *VirtualMachineImageRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheOVFStatus.
+func (in *VirtualMachineImageCacheOVFStatus) DeepCopy() *VirtualMachineImageCacheOVFStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageRef)
+	out := new(VirtualMachineImageCacheOVFStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+func (in *VirtualMachineImageCacheSpec) DeepCopyInto(out *VirtualMachineImageCacheSpec) {
 	*out = *in
-	if in.ProviderRef != nil {
-		in, out := &in.ProviderRef, &out.ProviderRef
-		*out = new(common.LocalObjectRef)
-		**out = **in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]VirtualMachineImageCacheLocationSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
-func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheSpec.
+func (in *VirtualMachineImageCacheSpec) DeepCopy() *VirtualMachineImageCacheSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageSpec)
+	out := new(VirtualMachineImageCacheSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+func (in *VirtualMachineImageCacheStatus) DeepCopyInto(out *VirtualMachineImageCacheStatus) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.HardwareVersion != nil {
-		in, out := &in.HardwareVersion, &out.HardwareVersion
-		*out = new(int32)
-		**out = **in
-	}
-	out.OSInfo = in.OSInfo
-	if in.OVFProperties != nil {
-		in, out := &in.OVFProperties, &out.OVFProperties
-		*out = make([]OVFProperty, len(*in))
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]VirtualMachineImageCacheLocationStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.VMwareSystemProperties != nil {
-		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	out.ProductInfo = in.ProductInfo
-	if in.Disks != nil {
-		in, out := &in.Disks, &out.Disks
-		*out = make([]VirtualMachineImageDiskInfo, len(*in))
+	if in.Evictions != nil {
+		in, out := &in.Evictions, &out.Evictions
+		*out = make([]VirtualMachineImageCacheEvictionStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OVF != nil {
+		in, out := &in.OVF, &out.OVF
+		*out = new(VirtualMachineImageCacheOVFStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2091,115 +2596,1403 @@ func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
-func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheStatus.
+func (in *VirtualMachineImageCacheStatus) DeepCopy() *VirtualMachineImageCacheStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageStatus)
+	out := new(VirtualMachineImageCacheStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
+func (in *VirtualMachineImageDiskInfo) DeepCopyInto(out *VirtualMachineImageDiskInfo) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachine, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ControllerBusNumber != nil {
+		in, out := &in.ControllerBusNumber, &out.ControllerBusNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnitNumber != nil {
+		in, out := &in.UnitNumber, &out.UnitNumber
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineList.
-func (in *VirtualMachineList) DeepCopy() *VirtualMachineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageDiskInfo.
+func (in *VirtualMachineImageDiskInfo) DeepCopy() *VirtualMachineImageDiskInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineList)
+	out := new(VirtualMachineImageDiskInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineMemoryAllocationStatus) DeepCopyInto(out *VirtualMachineMemoryAllocationStatus) {
+func (in *VirtualMachineImageImport) DeepCopyInto(out *VirtualMachineImageImport) {
 	*out = *in
-	if in.Total != nil {
-		in, out := &in.Total, &out.Total
-		x := (*in).DeepCopy()
-		*out = &x
-	}
-	if in.Reservation != nil {
-		in, out := &in.Reservation, &out.Reservation
-		x := (*in).DeepCopy()
-		*out = &x
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineMemoryAllocationStatus.
-func (in *VirtualMachineMemoryAllocationStatus) DeepCopy() *VirtualMachineMemoryAllocationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImport.
+func (in *VirtualMachineImageImport) DeepCopy() *VirtualMachineImageImport {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineMemoryAllocationStatus)
+	out := new(VirtualMachineImageImport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageImport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPOptionsStatus) {
+func (in *VirtualMachineImageImportChecksum) DeepCopyInto(out *VirtualMachineImageImportChecksum) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDHCPOptionsStatus.
-func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkConfigDHCPOptionsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportChecksum.
+func (in *VirtualMachineImageImportChecksum) DeepCopy() *VirtualMachineImageImportChecksum {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigDHCPOptionsStatus)
+	out := new(VirtualMachineImageImportChecksum)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPStatus) {
+func (in *VirtualMachineImageImportHTTPSource) DeepCopyInto(out *VirtualMachineImageImportHTTPSource) {
 	*out = *in
-	if in.IP4 != nil {
-		in, out := &in.IP4, &out.IP4
-		*out = new(VirtualMachineNetworkConfigDHCPOptionsStatus)
-		**out = **in
-	}
-	if in.IP6 != nil {
-		in, out := &in.IP6, &out.IP6
-		*out = new(VirtualMachineNetworkConfigDHCPOptionsStatus)
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(VirtualMachineImageImportChecksum)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDHCPStatus.
-func (in *VirtualMachineNetworkConfigDHCPStatus) DeepCopy() *VirtualMachineNetworkConfigDHCPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportHTTPSource.
+func (in *VirtualMachineImageImportHTTPSource) DeepCopy() *VirtualMachineImageImportHTTPSource {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigDHCPStatus)
+	out := new(VirtualMachineImageImportHTTPSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigDNSStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDNSStatus) {
+func (in *VirtualMachineImageImportList) DeepCopyInto(out *VirtualMachineImageImportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImageImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportList.
+func (in *VirtualMachineImageImportList) DeepCopy() *VirtualMachineImageImportList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageImportOCISource) DeepCopyInto(out *VirtualMachineImageImportOCISource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportOCISource.
+func (in *VirtualMachineImageImportOCISource) DeepCopy() *VirtualMachineImageImportOCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportOCISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageImportSource) DeepCopyInto(out *VirtualMachineImageImportSource) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(VirtualMachineImageImportHTTPSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(VirtualMachineImageImportOCISource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportSource.
+func (in *VirtualMachineImageImportSource) DeepCopy() *VirtualMachineImageImportSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageImportSpec) DeepCopyInto(out *VirtualMachineImageImportSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	out.Target = in.Target
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportSpec.
+func (in *VirtualMachineImageImportSpec) DeepCopy() *VirtualMachineImageImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageImportStatus) DeepCopyInto(out *VirtualMachineImageImportStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportStatus.
+func (in *VirtualMachineImageImportStatus) DeepCopy() *VirtualMachineImageImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageImportTarget) DeepCopyInto(out *VirtualMachineImageImportTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageImportTarget.
+func (in *VirtualMachineImageImportTarget) DeepCopy() *VirtualMachineImageImportTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageImportTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
+func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
+func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageOSInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
+func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageProductInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageRef) DeepCopyInto(out *VirtualMachineImageRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageRef.
+func (in *VirtualMachineImageRef) DeepCopy() *VirtualMachineImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+	*out = *in
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
+func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HardwareVersion != nil {
+		in, out := &in.HardwareVersion, &out.HardwareVersion
+		*out = new(int32)
+		**out = **in
+	}
+	out.OSInfo = in.OSInfo
+	if in.OVFProperties != nil {
+		in, out := &in.OVFProperties, &out.OVFProperties
+		*out = make([]OVFProperty, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VMwareSystemProperties != nil {
+		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+	out.ProductInfo = in.ProductInfo
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]VirtualMachineImageDiskInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
+func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStream) DeepCopyInto(out *VirtualMachineImageStream) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStream.
+func (in *VirtualMachineImageStream) DeepCopy() *VirtualMachineImageStream {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageStream) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStreamList) DeepCopyInto(out *VirtualMachineImageStreamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImageStream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStreamList.
+func (in *VirtualMachineImageStreamList) DeepCopy() *VirtualMachineImageStreamList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStreamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageStreamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStreamOSSelector) DeepCopyInto(out *VirtualMachineImageStreamOSSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStreamOSSelector.
+func (in *VirtualMachineImageStreamOSSelector) DeepCopy() *VirtualMachineImageStreamOSSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStreamOSSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStreamProductSelector) DeepCopyInto(out *VirtualMachineImageStreamProductSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStreamProductSelector.
+func (in *VirtualMachineImageStreamProductSelector) DeepCopy() *VirtualMachineImageStreamProductSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStreamProductSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStreamSpec) DeepCopyInto(out *VirtualMachineImageStreamSpec) {
+	*out = *in
+	if in.Product != nil {
+		in, out := &in.Product, &out.Product
+		*out = new(VirtualMachineImageStreamProductSelector)
+		**out = **in
+	}
+	if in.OS != nil {
+		in, out := &in.OS, &out.OS
+		*out = new(VirtualMachineImageStreamOSSelector)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStreamSpec.
+func (in *VirtualMachineImageStreamSpec) DeepCopy() *VirtualMachineImageStreamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStreamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStreamStatus) DeepCopyInto(out *VirtualMachineImageStreamStatus) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(VirtualMachineImageRef)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStreamStatus.
+func (in *VirtualMachineImageStreamStatus) DeepCopy() *VirtualMachineImageStreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineList.
+func (in *VirtualMachineList) DeepCopy() *VirtualMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineMemoryAllocationStatus) DeepCopyInto(out *VirtualMachineMemoryAllocationStatus) {
+	*out = *in
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Reservation != nil {
+		in, out := &in.Reservation, &out.Reservation
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineMemoryAllocationStatus.
+func (in *VirtualMachineMemoryAllocationStatus) DeepCopy() *VirtualMachineMemoryAllocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineMemoryAllocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkBondSpec) DeepCopyInto(out *VirtualMachineNetworkBondSpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MIIMonitorInterval != nil {
+		in, out := &in.MIIMonitorInterval, &out.MIIMonitorInterval
+		*out = new(int32)
+		**out = **in
+	}
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkBondSpec.
+func (in *VirtualMachineNetworkBondSpec) DeepCopy() *VirtualMachineNetworkBondSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkBondSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkBridgeSpec) DeepCopyInto(out *VirtualMachineNetworkBridgeSpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.STP != nil {
+		in, out := &in.STP, &out.STP
+		*out = new(bool)
+		**out = **in
+	}
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkBridgeSpec.
+func (in *VirtualMachineNetworkBridgeSpec) DeepCopy() *VirtualMachineNetworkBridgeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkBridgeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPOptionsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDHCPOptionsStatus.
+func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkConfigDHCPOptionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigDHCPOptionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPStatus) {
+	*out = *in
+	if in.IP4 != nil {
+		in, out := &in.IP4, &out.IP4
+		*out = new(VirtualMachineNetworkConfigDHCPOptionsStatus)
+		**out = **in
+	}
+	if in.IP6 != nil {
+		in, out := &in.IP6, &out.IP6
+		*out = new(VirtualMachineNetworkConfigDHCPOptionsStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDHCPStatus.
+func (in *VirtualMachineNetworkConfigDHCPStatus) DeepCopy() *VirtualMachineNetworkConfigDHCPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigDHCPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigDNSStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDNSStatus) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDNSStatus.
+func (in *VirtualMachineNetworkConfigDNSStatus) DeepCopy() *VirtualMachineNetworkConfigDNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigDNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigDeviceStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDeviceStatus) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IP != nil {
+		in, out := &in.IP, &out.IP
+		*out = new(VirtualMachineNetworkConfigInterfaceIPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VirtualMachineNetworkConfigDNSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDeviceStatus.
+func (in *VirtualMachineNetworkConfigDeviceStatus) DeepCopy() *VirtualMachineNetworkConfigDeviceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigDeviceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkConfigInterfaceIPStatus) {
+	*out = *in
+	if in.DHCP != nil {
+		in, out := &in.DHCP, &out.DHCP
+		*out = new(VirtualMachineNetworkConfigDHCPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigInterfaceIPStatus.
+func (in *VirtualMachineNetworkConfigInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkConfigInterfaceIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigInterfaceIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkConfigInterfaceStatus) {
+	*out = *in
+	if in.IP != nil {
+		in, out := &in.IP, &out.IP
+		*out = new(VirtualMachineNetworkConfigInterfaceIPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VirtualMachineNetworkConfigDNSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigInterfaceStatus.
+func (in *VirtualMachineNetworkConfigInterfaceStatus) DeepCopy() *VirtualMachineNetworkConfigInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkConfigStatus) DeepCopyInto(out *VirtualMachineNetworkConfigStatus) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkConfigInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]VirtualMachineNetworkConfigDeviceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VirtualMachineNetworkConfigDNSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigStatus.
+func (in *VirtualMachineNetworkConfigStatus) DeepCopy() *VirtualMachineNetworkConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPOptionsStatus) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPOptionsStatus.
+func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkDHCPOptionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDHCPOptionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPStatus) {
+	*out = *in
+	in.IP4.DeepCopyInto(&out.IP4)
+	in.IP6.DeepCopyInto(&out.IP6)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPStatus.
+func (in *VirtualMachineNetworkDHCPStatus) DeepCopy() *VirtualMachineNetworkDHCPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDHCPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDNSStatus) DeepCopyInto(out *VirtualMachineNetworkDNSStatus) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDNSStatus.
+func (in *VirtualMachineNetworkDNSStatus) DeepCopy() *VirtualMachineNetworkDNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkGuestDeviceIPSpec) DeepCopyInto(out *VirtualMachineNetworkGuestDeviceIPSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkGuestDeviceIPSpec.
+func (in *VirtualMachineNetworkGuestDeviceIPSpec) DeepCopy() *VirtualMachineNetworkGuestDeviceIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkGuestDeviceIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteGatewayStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteGatewayStatus.
+func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopy() *VirtualMachineNetworkIPRouteGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPRouteGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPRouteStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteStatus) {
+	*out = *in
+	out.Gateway = in.Gateway
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteStatus.
+func (in *VirtualMachineNetworkIPRouteStatus) DeepCopy() *VirtualMachineNetworkIPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPStackStatus) DeepCopyInto(out *VirtualMachineNetworkIPStackStatus) {
+	*out = *in
+	if in.DHCP != nil {
+		in, out := &in.DHCP, &out.DHCP
+		*out = new(VirtualMachineNetworkDHCPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VirtualMachineNetworkDNSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPRoutes != nil {
+		in, out := &in.IPRoutes, &out.IPRoutes
+		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelConfig != nil {
+		in, out := &in.KernelConfig, &out.KernelConfig
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPStackStatus.
+func (in *VirtualMachineNetworkIPStackStatus) DeepCopy() *VirtualMachineNetworkIPStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPAddrStatus) {
+	*out = *in
+	in.Lifetime.DeepCopyInto(&out.Lifetime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPAddrStatus.
+func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPAddrStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceIPAddrStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPStatus) {
+	*out = *in
+	if in.AutoConfigurationEnabled != nil {
+		in, out := &in.AutoConfigurationEnabled, &out.AutoConfigurationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DHCP != nil {
+		in, out := &in.DHCP, &out.DHCP
+		*out = new(VirtualMachineNetworkDHCPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]VirtualMachineNetworkInterfaceIPAddrStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPStatus.
+func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSpec) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(common.PartialObjectRef)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSpec.
+func (in *VirtualMachineNetworkInterfaceSpec) DeepCopy() *VirtualMachineNetworkInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceStatus) {
+	*out = *in
+	if in.IP != nil {
+		in, out := &in.IP, &out.IP
+		*out = new(VirtualMachineNetworkInterfaceIPStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VirtualMachineNetworkDNSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceStatus.
+func (in *VirtualMachineNetworkInterfaceStatus) DeepCopy() *VirtualMachineNetworkInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicy) DeepCopyInto(out *VirtualMachineNetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicy.
+func (in *VirtualMachineNetworkPolicy) DeepCopy() *VirtualMachineNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineNetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyEgressRule) DeepCopyInto(out *VirtualMachineNetworkPolicyEgressRule) {
+	*out = *in
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]VirtualMachineNetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachineNetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyEgressRule.
+func (in *VirtualMachineNetworkPolicyEgressRule) DeepCopy() *VirtualMachineNetworkPolicyEgressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyEgressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyIPBlock) DeepCopyInto(out *VirtualMachineNetworkPolicyIPBlock) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyIPBlock.
+func (in *VirtualMachineNetworkPolicyIPBlock) DeepCopy() *VirtualMachineNetworkPolicyIPBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyIPBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyIngressRule) DeepCopyInto(out *VirtualMachineNetworkPolicyIngressRule) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]VirtualMachineNetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachineNetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyIngressRule.
+func (in *VirtualMachineNetworkPolicyIngressRule) DeepCopy() *VirtualMachineNetworkPolicyIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyList) DeepCopyInto(out *VirtualMachineNetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyList.
+func (in *VirtualMachineNetworkPolicyList) DeepCopy() *VirtualMachineNetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineNetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyPeer) DeepCopyInto(out *VirtualMachineNetworkPolicyPeer) {
+	*out = *in
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPBlock != nil {
+		in, out := &in.IPBlock, &out.IPBlock
+		*out = new(VirtualMachineNetworkPolicyIPBlock)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyPeer.
+func (in *VirtualMachineNetworkPolicyPeer) DeepCopy() *VirtualMachineNetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyPort) DeepCopyInto(out *VirtualMachineNetworkPolicyPort) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(corev1.Protocol)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyPort.
+func (in *VirtualMachineNetworkPolicyPort) DeepCopy() *VirtualMachineNetworkPolicyPort {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicySpec) DeepCopyInto(out *VirtualMachineNetworkPolicySpec) {
+	*out = *in
+	in.VMSelector.DeepCopyInto(&out.VMSelector)
+	if in.PolicyTypes != nil {
+		in, out := &in.PolicyTypes, &out.PolicyTypes
+		*out = make([]VirtualMachineNetworkPolicyType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]VirtualMachineNetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]VirtualMachineNetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicySpec.
+func (in *VirtualMachineNetworkPolicySpec) DeepCopy() *VirtualMachineNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkPolicyStatus) DeepCopyInto(out *VirtualMachineNetworkPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkPolicyStatus.
+func (in *VirtualMachineNetworkPolicyStatus) DeepCopy() *VirtualMachineNetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkRouteSpec) DeepCopyInto(out *VirtualMachineNetworkRouteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteSpec.
+func (in *VirtualMachineNetworkRouteSpec) DeepCopy() *VirtualMachineNetworkRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkRouteStatus) DeepCopyInto(out *VirtualMachineNetworkRouteStatus) {
+	*out = *in
+	if in.IPRoutes != nil {
+		in, out := &in.IPRoutes, &out.IPRoutes
+		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteStatus.
+func (in *VirtualMachineNetworkRouteStatus) DeepCopy() *VirtualMachineNetworkRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkSpec) DeepCopyInto(out *VirtualMachineNetworkSpec) {
 	*out = *in
 	if in.Nameservers != nil {
 		in, out := &in.Nameservers, &out.Nameservers
@@ -2211,460 +4004,594 @@ func (in *VirtualMachineNetworkConfigDNSStatus) DeepCopyInto(out *VirtualMachine
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]VirtualMachineNetworkBondSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VLANs != nil {
+		in, out := &in.VLANs, &out.VLANs
+		*out = make([]VirtualMachineNetworkVLANSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bridges != nil {
+		in, out := &in.Bridges, &out.Bridges
+		*out = make([]VirtualMachineNetworkBridgeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigDNSStatus.
-func (in *VirtualMachineNetworkConfigDNSStatus) DeepCopy() *VirtualMachineNetworkConfigDNSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkSpec.
+func (in *VirtualMachineNetworkSpec) DeepCopy() *VirtualMachineNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkStatus) DeepCopyInto(out *VirtualMachineNetworkStatus) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(VirtualMachineNetworkConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPStacks != nil {
+		in, out := &in.IPStacks, &out.IPStacks
+		*out = make([]VirtualMachineNetworkIPStackStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkStatus.
+func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkVLANSpec) DeepCopyInto(out *VirtualMachineNetworkVLANSpec) {
+	*out = *in
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkVLANSpec.
+func (in *VirtualMachineNetworkVLANSpec) DeepCopy() *VirtualMachineNetworkVLANSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkVLANSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequest) DeepCopyInto(out *VirtualMachinePlacementRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequest.
+func (in *VirtualMachinePlacementRequest) DeepCopy() *VirtualMachinePlacementRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePlacementRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequestList) DeepCopyInto(out *VirtualMachinePlacementRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePlacementRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestList.
+func (in *VirtualMachinePlacementRequestList) DeepCopy() *VirtualMachinePlacementRequestList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigDNSStatus)
+	out := new(VirtualMachinePlacementRequestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePlacementRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkConfigInterfaceIPStatus) {
+func (in *VirtualMachinePlacementRequestResult) DeepCopyInto(out *VirtualMachinePlacementRequestResult) {
 	*out = *in
-	if in.DHCP != nil {
-		in, out := &in.DHCP, &out.DHCP
-		*out = new(VirtualMachineNetworkConfigDHCPStatus)
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(VirtualMachinePlacementStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigInterfaceIPStatus.
-func (in *VirtualMachineNetworkConfigInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkConfigInterfaceIPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestResult.
+func (in *VirtualMachinePlacementRequestResult) DeepCopy() *VirtualMachinePlacementRequestResult {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigInterfaceIPStatus)
+	out := new(VirtualMachinePlacementRequestResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkConfigInterfaceStatus) {
+func (in *VirtualMachinePlacementRequestSpec) DeepCopyInto(out *VirtualMachinePlacementRequestSpec) {
 	*out = *in
-	if in.IP != nil {
-		in, out := &in.IP, &out.IP
-		*out = new(VirtualMachineNetworkConfigInterfaceIPStatus)
-		(*in).DeepCopyInto(*out)
+	if in.VirtualMachines != nil {
+		in, out := &in.VirtualMachines, &out.VirtualMachines
+		*out = make([]VirtualMachinePlacementRequestVM, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DNS != nil {
-		in, out := &in.DNS, &out.DNS
-		*out = new(VirtualMachineNetworkConfigDNSStatus)
-		(*in).DeepCopyInto(*out)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigInterfaceStatus.
-func (in *VirtualMachineNetworkConfigInterfaceStatus) DeepCopy() *VirtualMachineNetworkConfigInterfaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestSpec.
+func (in *VirtualMachinePlacementRequestSpec) DeepCopy() *VirtualMachinePlacementRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigInterfaceStatus)
+	out := new(VirtualMachinePlacementRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkConfigStatus) DeepCopyInto(out *VirtualMachineNetworkConfigStatus) {
+func (in *VirtualMachinePlacementRequestStatus) DeepCopyInto(out *VirtualMachinePlacementRequestStatus) {
 	*out = *in
-	if in.Interfaces != nil {
-		in, out := &in.Interfaces, &out.Interfaces
-		*out = make([]VirtualMachineNetworkConfigInterfaceStatus, len(*in))
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]VirtualMachinePlacementRequestResult, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.DNS != nil {
-		in, out := &in.DNS, &out.DNS
-		*out = new(VirtualMachineNetworkConfigDNSStatus)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigStatus.
-func (in *VirtualMachineNetworkConfigStatus) DeepCopy() *VirtualMachineNetworkConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestStatus.
+func (in *VirtualMachinePlacementRequestStatus) DeepCopy() *VirtualMachinePlacementRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkConfigStatus)
+	out := new(VirtualMachinePlacementRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPOptionsStatus) {
+func (in *VirtualMachinePlacementRequestVM) DeepCopyInto(out *VirtualMachinePlacementRequestVM) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPOptionsStatus.
-func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkDHCPOptionsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestVM.
+func (in *VirtualMachinePlacementRequestVM) DeepCopy() *VirtualMachinePlacementRequestVM {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDHCPOptionsStatus)
+	out := new(VirtualMachinePlacementRequestVM)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPStatus) {
+func (in *VirtualMachinePlacementStatus) DeepCopyInto(out *VirtualMachinePlacementStatus) {
 	*out = *in
-	in.IP4.DeepCopyInto(&out.IP4)
-	in.IP6.DeepCopyInto(&out.IP6)
+	if in.Datastores != nil {
+		in, out := &in.Datastores, &out.Datastores
+		*out = make([]VirtualMachineGroupPlacementDatastoreStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPStatus.
-func (in *VirtualMachineNetworkDHCPStatus) DeepCopy() *VirtualMachineNetworkDHCPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementStatus.
+func (in *VirtualMachinePlacementStatus) DeepCopy() *VirtualMachinePlacementStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDHCPStatus)
+	out := new(VirtualMachinePlacementStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDNSStatus) DeepCopyInto(out *VirtualMachineNetworkDNSStatus) {
+func (in *VirtualMachinePowerSchedule) DeepCopyInto(out *VirtualMachinePowerSchedule) {
 	*out = *in
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SearchDomains != nil {
-		in, out := &in.SearchDomains, &out.SearchDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDNSStatus.
-func (in *VirtualMachineNetworkDNSStatus) DeepCopy() *VirtualMachineNetworkDNSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerSchedule.
+func (in *VirtualMachinePowerSchedule) DeepCopy() *VirtualMachinePowerSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDNSStatus)
+	out := new(VirtualMachinePowerSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePowerSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteGatewayStatus) {
+func (in *VirtualMachinePowerScheduleExclusion) DeepCopyInto(out *VirtualMachinePowerScheduleExclusion) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteGatewayStatus.
-func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopy() *VirtualMachineNetworkIPRouteGatewayStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleExclusion.
+func (in *VirtualMachinePowerScheduleExclusion) DeepCopy() *VirtualMachinePowerScheduleExclusion {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPRouteGatewayStatus)
+	out := new(VirtualMachinePowerScheduleExclusion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPRouteStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteStatus) {
+func (in *VirtualMachinePowerScheduleHistoryEntry) DeepCopyInto(out *VirtualMachinePowerScheduleHistoryEntry) {
 	*out = *in
-	out.Gateway = in.Gateway
+	in.ScheduleTime.DeepCopyInto(&out.ScheduleTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteStatus.
-func (in *VirtualMachineNetworkIPRouteStatus) DeepCopy() *VirtualMachineNetworkIPRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleHistoryEntry.
+func (in *VirtualMachinePowerScheduleHistoryEntry) DeepCopy() *VirtualMachinePowerScheduleHistoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPRouteStatus)
+	out := new(VirtualMachinePowerScheduleHistoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPStackStatus) DeepCopyInto(out *VirtualMachineNetworkIPStackStatus) {
+func (in *VirtualMachinePowerScheduleList) DeepCopyInto(out *VirtualMachinePowerScheduleList) {
 	*out = *in
-	if in.DHCP != nil {
-		in, out := &in.DHCP, &out.DHCP
-		*out = new(VirtualMachineNetworkDHCPStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DNS != nil {
-		in, out := &in.DNS, &out.DNS
-		*out = new(VirtualMachineNetworkDNSStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.IPRoutes != nil {
-		in, out := &in.IPRoutes, &out.IPRoutes
-		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.KernelConfig != nil {
-		in, out := &in.KernelConfig, &out.KernelConfig
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePowerSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPStackStatus.
-func (in *VirtualMachineNetworkIPStackStatus) DeepCopy() *VirtualMachineNetworkIPStackStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleList.
+func (in *VirtualMachinePowerScheduleList) DeepCopy() *VirtualMachinePowerScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPStackStatus)
+	out := new(VirtualMachinePowerScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPAddrStatus) {
-	*out = *in
-	in.Lifetime.DeepCopyInto(&out.Lifetime)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPAddrStatus.
-func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPAddrStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePowerScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(VirtualMachineNetworkInterfaceIPAddrStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPStatus) {
+func (in *VirtualMachinePowerScheduleSpec) DeepCopyInto(out *VirtualMachinePowerScheduleSpec) {
 	*out = *in
-	if in.AutoConfigurationEnabled != nil {
-		in, out := &in.AutoConfigurationEnabled, &out.AutoConfigurationEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DHCP != nil {
-		in, out := &in.DHCP, &out.DHCP
-		*out = new(VirtualMachineNetworkDHCPStatus)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]VirtualMachineNetworkInterfaceIPAddrStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]VirtualMachinePowerScheduleWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = make([]VirtualMachinePowerScheduleExclusion, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPStatus.
-func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleSpec.
+func (in *VirtualMachinePowerScheduleSpec) DeepCopy() *VirtualMachinePowerScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceIPStatus)
+	out := new(VirtualMachinePowerScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSpec) {
+func (in *VirtualMachinePowerScheduleStatus) DeepCopyInto(out *VirtualMachinePowerScheduleStatus) {
 	*out = *in
-	if in.Network != nil {
-		in, out := &in.Network, &out.Network
-		*out = new(common.PartialObjectRef)
-		**out = **in
-	}
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.MTU != nil {
-		in, out := &in.MTU, &out.MTU
-		*out = new(int64)
-		**out = **in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Routes != nil {
-		in, out := &in.Routes, &out.Routes
-		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
-		copy(*out, *in)
+	if in.VMs != nil {
+		in, out := &in.VMs, &out.VMs
+		*out = make([]VirtualMachinePowerScheduleVMStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.SearchDomains != nil {
-		in, out := &in.SearchDomains, &out.SearchDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSpec.
-func (in *VirtualMachineNetworkInterfaceSpec) DeepCopy() *VirtualMachineNetworkInterfaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleStatus.
+func (in *VirtualMachinePowerScheduleStatus) DeepCopy() *VirtualMachinePowerScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceSpec)
+	out := new(VirtualMachinePowerScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceStatus) {
+func (in *VirtualMachinePowerScheduleVMStatus) DeepCopyInto(out *VirtualMachinePowerScheduleVMStatus) {
 	*out = *in
-	if in.IP != nil {
-		in, out := &in.IP, &out.IP
-		*out = new(VirtualMachineNetworkInterfaceIPStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DNS != nil {
-		in, out := &in.DNS, &out.DNS
-		*out = new(VirtualMachineNetworkDNSStatus)
-		(*in).DeepCopyInto(*out)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]VirtualMachinePowerScheduleHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceStatus.
-func (in *VirtualMachineNetworkInterfaceStatus) DeepCopy() *VirtualMachineNetworkInterfaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleVMStatus.
+func (in *VirtualMachinePowerScheduleVMStatus) DeepCopy() *VirtualMachinePowerScheduleVMStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceStatus)
+	out := new(VirtualMachinePowerScheduleVMStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkRouteSpec) DeepCopyInto(out *VirtualMachineNetworkRouteSpec) {
+func (in *VirtualMachinePowerScheduleWindow) DeepCopyInto(out *VirtualMachinePowerScheduleWindow) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteSpec.
-func (in *VirtualMachineNetworkRouteSpec) DeepCopy() *VirtualMachineNetworkRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleWindow.
+func (in *VirtualMachinePowerScheduleWindow) DeepCopy() *VirtualMachinePowerScheduleWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkRouteSpec)
+	out := new(VirtualMachinePowerScheduleWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkRouteStatus) DeepCopyInto(out *VirtualMachineNetworkRouteStatus) {
+func (in *VirtualMachineProfile) DeepCopyInto(out *VirtualMachineProfile) {
 	*out = *in
-	if in.IPRoutes != nil {
-		in, out := &in.IPRoutes, &out.IPRoutes
-		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteStatus.
-func (in *VirtualMachineNetworkRouteStatus) DeepCopy() *VirtualMachineNetworkRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineProfile.
+func (in *VirtualMachineProfile) DeepCopy() *VirtualMachineProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkRouteStatus)
+	out := new(VirtualMachineProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkSpec) DeepCopyInto(out *VirtualMachineNetworkSpec) {
+func (in *VirtualMachineProfileList) DeepCopyInto(out *VirtualMachineProfileList) {
 	*out = *in
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SearchDomains != nil {
-		in, out := &in.SearchDomains, &out.SearchDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Interfaces != nil {
-		in, out := &in.Interfaces, &out.Interfaces
-		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineProfile, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkSpec.
-func (in *VirtualMachineNetworkSpec) DeepCopy() *VirtualMachineNetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineProfileList.
+func (in *VirtualMachineProfileList) DeepCopy() *VirtualMachineProfileList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkSpec)
+	out := new(VirtualMachineProfileList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkStatus) DeepCopyInto(out *VirtualMachineNetworkStatus) {
+func (in *VirtualMachineProfileNetworkSpec) DeepCopyInto(out *VirtualMachineProfileNetworkSpec) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(VirtualMachineNetworkConfigStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Interfaces != nil {
-		in, out := &in.Interfaces, &out.Interfaces
-		*out = make([]VirtualMachineNetworkInterfaceStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.IPStacks != nil {
-		in, out := &in.IPStacks, &out.IPStacks
-		*out = make([]VirtualMachineNetworkIPStackStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkStatus.
-func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineProfileNetworkSpec.
+func (in *VirtualMachineProfileNetworkSpec) DeepCopy() *VirtualMachineProfileNetworkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkStatus)
+	out := new(VirtualMachineProfileNetworkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePlacementStatus) DeepCopyInto(out *VirtualMachinePlacementStatus) {
+func (in *VirtualMachineProfileSpec) DeepCopyInto(out *VirtualMachineProfileSpec) {
 	*out = *in
-	if in.Datastores != nil {
-		in, out := &in.Datastores, &out.Datastores
-		*out = make([]VirtualMachineGroupPlacementDatastoreStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(VirtualMachineBootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(VirtualMachineProfileNetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(VirtualMachineReadinessProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Crypto != nil {
+		in, out := &in.Crypto, &out.Crypto
+		*out = new(VirtualMachineCryptoSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementStatus.
-func (in *VirtualMachinePlacementStatus) DeepCopy() *VirtualMachinePlacementStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineProfileSpec.
+func (in *VirtualMachineProfileSpec) DeepCopy() *VirtualMachineProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePlacementStatus)
+	out := new(VirtualMachineProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2696,6 +4623,36 @@ func (in *VirtualMachinePublishRequest) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestArtifactStatus) DeepCopyInto(out *VirtualMachinePublishRequestArtifactStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestArtifactStatus.
+func (in *VirtualMachinePublishRequestArtifactStatus) DeepCopy() *VirtualMachinePublishRequestArtifactStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestArtifactStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestHTTPTarget) DeepCopyInto(out *VirtualMachinePublishRequestHTTPTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestHTTPTarget.
+func (in *VirtualMachinePublishRequestHTTPTarget) DeepCopy() *VirtualMachinePublishRequestHTTPTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestHTTPTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachinePublishRequestList) DeepCopyInto(out *VirtualMachinePublishRequestList) {
 	*out = *in
@@ -2728,6 +4685,21 @@ func (in *VirtualMachinePublishRequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestOCITarget) DeepCopyInto(out *VirtualMachinePublishRequestOCITarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestOCITarget.
+func (in *VirtualMachinePublishRequestOCITarget) DeepCopy() *VirtualMachinePublishRequestOCITarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestOCITarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachinePublishRequestSource) DeepCopyInto(out *VirtualMachinePublishRequestSource) {
 	*out = *in
@@ -2747,7 +4719,7 @@ func (in *VirtualMachinePublishRequestSource) DeepCopy() *VirtualMachinePublishR
 func (in *VirtualMachinePublishRequestSpec) DeepCopyInto(out *VirtualMachinePublishRequestSpec) {
 	*out = *in
 	out.Source = in.Source
-	out.Target = in.Target
+	in.Target.DeepCopyInto(&out.Target)
 	if in.TTLSecondsAfterFinished != nil {
 		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
 		*out = new(int64)
@@ -2776,11 +4748,16 @@ func (in *VirtualMachinePublishRequestStatus) DeepCopyInto(out *VirtualMachinePu
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(VirtualMachinePublishRequestTarget)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(VirtualMachinePublishRequestArtifactStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2805,6 +4782,16 @@ func (in *VirtualMachinePublishRequestTarget) DeepCopyInto(out *VirtualMachinePu
 	*out = *in
 	out.Item = in.Item
 	out.Location = in.Location
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(VirtualMachinePublishRequestOCITarget)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(VirtualMachinePublishRequestHTTPTarget)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTarget.
@@ -3270,6 +5257,76 @@ func (in *VirtualMachineSnapshot) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotHook) DeepCopyInto(out *VirtualMachineSnapshotHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = new(VirtualMachineSnapshotHookCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(VirtualMachineSnapshotHookHTTP)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotHook.
+func (in *VirtualMachineSnapshotHook) DeepCopy() *VirtualMachineSnapshotHook {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotHookCommand) DeepCopyInto(out *VirtualMachineSnapshotHookCommand) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]common.NameValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotHookCommand.
+func (in *VirtualMachineSnapshotHookCommand) DeepCopy() *VirtualMachineSnapshotHookCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotHookCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotHookHTTP) DeepCopyInto(out *VirtualMachineSnapshotHookHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotHookHTTP.
+func (in *VirtualMachineSnapshotHookHTTP) DeepCopy() *VirtualMachineSnapshotHookHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotHookHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSnapshotList) DeepCopyInto(out *VirtualMachineSnapshotList) {
 	*out = *in
@@ -3325,6 +5382,20 @@ func (in *VirtualMachineSnapshotSpec) DeepCopyInto(out *VirtualMachineSnapshotSp
 		*out = new(QuiesceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreSnapshotHooks != nil {
+		in, out := &in.PreSnapshotHooks, &out.PreSnapshotHooks
+		*out = make([]VirtualMachineSnapshotHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostSnapshotHooks != nil {
+		in, out := &in.PostSnapshotHooks, &out.PostSnapshotHooks
+		*out = make([]VirtualMachineSnapshotHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotSpec.
@@ -3557,7 +5628,7 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 	if in.Guest != nil {
 		in, out := &in.Guest, &out.Guest
 		*out = new(VirtualMachineGuestStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Hardware != nil {
 		in, out := &in.Hardware, &out.Hardware
@@ -3569,6 +5640,18 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = make([]PolicyStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]VirtualMachineDriftStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedProfiles != nil {
+		in, out := &in.AppliedProfiles, &out.AppliedProfiles
+		*out = make([]VirtualMachineAppliedProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStatus.
@@ -3919,3 +6002,52 @@ func (in *VirtualMachineWebConsoleRequestStatus) DeepCopy() *VirtualMachineWebCo
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAffinitySpec) DeepCopyInto(out *ZoneAffinitySpec) {
+	*out = *in
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = make([]ZoneSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]ZoneSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAffinitySpec.
+func (in *ZoneAffinitySpec) DeepCopy() *ZoneAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSelectorTerm) DeepCopyInto(out *ZoneSelectorTerm) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSelectorTerm.
+func (in *ZoneSelectorTerm) DeepCopy() *ZoneSelectorTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSelectorTerm)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,181 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"path"
+	goruntime "runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+)
+
+const (
+	// consoleKeySize is the size of the key pair used to encrypt the ticket.
+	consoleKeySize = 2048
+
+	// defaultConsolePort is the port of the web console proxy when the
+	// proxy address does not include one.
+	defaultConsolePort = "443"
+)
+
+func newConsoleCommand(o *options) *cobra.Command {
+	var (
+		urlOnly bool
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "console NAME",
+		Short: "Open a web console to a VirtualMachine",
+		Long: "Requests a web console ticket for a VirtualMachine and opens the " +
+			"web console in a browser. The URL expires if it is not used " +
+			"within two minutes.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			consoleURL, err := o.requestConsole(cmd.Context(), args[0], timeout)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), consoleURL); err != nil {
+				return err
+			}
+			if urlOnly {
+				return nil
+			}
+			return openURL(consoleURL)
+		},
+	}
+
+	cmd.Flags().BoolVar(&urlOnly, "url-only", false,
+		"Print the URL to the web console without opening it.")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute,
+		"How long to wait for the web console ticket.")
+
+	return cmd
+}
+
+// requestConsole creates a VirtualMachineWebConsoleRequest for the named VM and
+// returns the URL to the web console.
+func (o *options) requestConsole(
+	ctx context.Context,
+	name string,
+	timeout time.Duration) (string, error) {
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, consoleKeySize)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	publicKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey),
+	})
+
+	wcr := &vmopv1.VirtualMachineWebConsoleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    o.namespace,
+		},
+		Spec: vmopv1.VirtualMachineWebConsoleRequestSpec{
+			Name:      name,
+			PublicKey: string(publicKey),
+		},
+	}
+	if err := o.client.Create(ctx, wcr); err != nil {
+		return "", fmt.Errorf("failed to create web console request: %w", err)
+	}
+
+	if err := wait.PollUntilContextTimeout(
+		ctx,
+		time.Second,
+		timeout,
+		true,
+		func(ctx context.Context) (bool, error) {
+			if err := o.client.Get(
+				ctx,
+				ctrlclient.ObjectKeyFromObject(wcr),
+				wcr); err != nil {
+
+				return false, err
+			}
+			return wcr.Status.Response != "" && wcr.Status.ProxyAddr != "", nil
+		}); err != nil {
+
+		return "", fmt.Errorf("failed to wait for web console ticket: %w", err)
+	}
+
+	ticketURL, err := virtualmachine.DecryptWebMKS(privateKey, wcr.Status.Response)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt web console ticket: %w", err)
+	}
+
+	return consoleURL(
+		wcr.Status.ProxyAddr,
+		ticketURL,
+		string(wcr.UID),
+		wcr.Namespace)
+}
+
+// consoleURL returns the URL to the web console for the decrypted ticket URL,
+// which has the form wss://<host>:<port>/ticket/<ticket>. The uuid and
+// namespace are used by the proxy to validate the request.
+func consoleURL(proxyAddr, ticketURL, uuid, namespace string) (string, error) {
+	u, err := url.Parse(ticketURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse web console ticket: %w", err)
+	}
+
+	host, port, err := net.SplitHostPort(proxyAddr)
+	if err != nil {
+		host, port = proxyAddr, defaultConsolePort
+	}
+
+	query := url.Values{}
+	query.Set("host", host)
+	query.Set("port", port)
+	query.Set("ticket", path.Base(u.Path))
+	query.Set("uuid", uuid)
+	query.Set("namespace", namespace)
+
+	return (&url.URL{
+		Scheme:   "https",
+		Host:     net.JoinHostPort(host, port),
+		Path:     "/vm/web-console",
+		RawQuery: query.Encode(),
+	}).String(), nil
+}
+
+// openURL opens the URL in the default browser.
+func openURL(u string) error {
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open web console: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+func newDescribeCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Show the details of a VirtualMachine",
+		Long: "Shows a summary of a VirtualMachine's spec and status, along " +
+			"with its conditions, network interfaces, and volumes.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vm, err := o.getVM(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return describeVM(cmd.OutOrStdout(), vm)
+		},
+	}
+
+	return cmd
+}
+
+func describeVM(out io.Writer, vm *vmopv1.VirtualMachine) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", vm.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", vm.Namespace)
+	fmt.Fprintf(w, "Image:\t%s\n", vm.Spec.ImageName)
+	fmt.Fprintf(w, "Class:\t%s\n", vm.Spec.ClassName)
+	fmt.Fprintf(w, "Storage Class:\t%s\n", vm.Spec.StorageClass)
+	fmt.Fprintf(w, "Power State:\t%s (desired %s)\n",
+		vm.Status.PowerState, vm.Spec.PowerState)
+	fmt.Fprintf(w, "Zone:\t%s\n", vm.Status.Zone)
+	fmt.Fprintf(w, "Host:\t%s\n", vm.Status.NodeName)
+	fmt.Fprintf(w, "Hardware Version:\tvmx-%d\n", vm.Status.HardwareVersion)
+	fmt.Fprintf(w, "Instance UUID:\t%s\n", vm.Status.InstanceUUID)
+	fmt.Fprintf(w, "Managed Object ID:\t%s\n", vm.Status.UniqueID)
+	if vm.Status.CurrentSnapshot != nil {
+		fmt.Fprintf(w, "Current Snapshot:\t%s\n", vm.Status.CurrentSnapshot.Name)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "\nConditions:")
+	w = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+	for _, c := range vm.Status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			c.Type, c.Status, c.Reason, age(c.LastTransitionTime), c.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "\nNetwork:")
+	w = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if n := vm.Status.Network; n != nil {
+		fmt.Fprintf(w, "  Host Name:\t%s\n", n.HostName)
+		fmt.Fprintf(w, "  Primary IPv4:\t%s\n", n.PrimaryIP4)
+		fmt.Fprintf(w, "  Primary IPv6:\t%s\n", n.PrimaryIP6)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		w = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  INTERFACE\tMAC\tADDRESSES")
+		for _, nic := range n.Interfaces {
+			var (
+				mac   string
+				addrs []string
+			)
+			if nic.IP != nil {
+				mac = nic.IP.MACAddr
+				for _, a := range nic.IP.Addresses {
+					addrs = append(addrs, a.Address)
+				}
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n",
+				nic.Name, mac, strings.Join(addrs, ","))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// The claim name is only in the spec, so index it by volume name.
+	claims := map[string]string{}
+	for _, v := range vm.Spec.Volumes {
+		if pvc := v.PersistentVolumeClaim; pvc != nil {
+			claims[v.Name] = pvc.ClaimName
+		}
+	}
+
+	fmt.Fprintln(out, "\nVolumes:")
+	w = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTYPE\tCLAIM\tATTACHED\tDISK UUID\tERROR")
+	for _, v := range vm.Status.Volumes {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%t\t%s\t%s\n",
+			v.Name, v.Type, claims[v.Name], v.Attached, v.DiskUUID, v.Error)
+	}
+	return w.Flush()
+}
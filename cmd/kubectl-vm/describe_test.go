@@ -0,0 +1,98 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+var _ = Describe("describe", func() {
+	It("should show the VM's spec and status", func() {
+		vm := newVM("my-vm")
+		vm.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+			{
+				Name: "data",
+				VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+					PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "my-claim",
+						},
+					},
+				},
+			},
+		}
+		vm.Status = vmopv1.VirtualMachineStatus{
+			PowerState:      vmopv1.VirtualMachinePowerStateOff,
+			Zone:            "zone-a",
+			NodeName:        "host-1",
+			HardwareVersion: 21,
+			InstanceUUID:    "instance-uuid",
+			UniqueID:        "vm-42",
+			CurrentSnapshot: &vmopv1.VirtualMachineSnapshotReference{
+				Type: vmopv1.VirtualMachineSnapshotReferenceTypeManaged,
+				Name: "my-snap",
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:    vmopv1.VirtualMachineConditionCreated,
+					Status:  metav1.ConditionTrue,
+					Reason:  "True",
+					Message: "created",
+				},
+			},
+			Network: &vmopv1.VirtualMachineNetworkStatus{
+				HostName:   "my-host",
+				PrimaryIP4: "192.168.0.10",
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceStatus{
+					{
+						Name: "eth0",
+						IP: &vmopv1.VirtualMachineNetworkInterfaceIPStatus{
+							MACAddr: "00:50:56:00:00:01",
+							Addresses: []vmopv1.VirtualMachineNetworkInterfaceIPAddrStatus{
+								{Address: "192.168.0.10/24"},
+								{Address: "fd00::10/64"},
+							},
+						},
+					},
+				},
+			},
+			Volumes: []vmopv1.VirtualMachineVolumeStatus{
+				{
+					Name:     "data",
+					Type:     vmopv1.VolumeTypeManaged,
+					Attached: true,
+					DiskUUID: "disk-uuid",
+				},
+			},
+		}
+
+		out, err := runCommand(newFakeClient(vm), "describe", "my-vm")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchRegexp(`Name:\s+my-vm\n`))
+		Expect(out).To(MatchRegexp(`Namespace:\s+my-namespace\n`))
+		Expect(out).To(MatchRegexp(`Image:\s+my-image\n`))
+		Expect(out).To(MatchRegexp(`Power State:\s+PoweredOff \(desired PoweredOn\)\n`))
+		Expect(out).To(MatchRegexp(`Zone:\s+zone-a\n`))
+		Expect(out).To(MatchRegexp(`Host:\s+host-1\n`))
+		Expect(out).To(MatchRegexp(`Hardware Version:\s+vmx-21\n`))
+		Expect(out).To(MatchRegexp(`Current Snapshot:\s+my-snap\n`))
+		Expect(out).To(MatchRegexp(`VirtualMachineCreated\s+True\s+True\s+\S+\s+created\n`))
+		Expect(out).To(MatchRegexp(`Primary IPv4:\s+192.168.0.10\n`))
+		Expect(out).To(MatchRegexp(`eth0\s+00:50:56:00:00:01\s+192.168.0.10/24,fd00::10/64\n`))
+		Expect(out).To(MatchRegexp(`data\s+Managed\s+my-claim\s+true\s+disk-uuid\s`))
+	})
+
+	It("should return an error if the VM does not exist", func() {
+		_, err := runCommand(newFakeClient(), "describe", "my-vm")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to get VirtualMachine my-namespace/my-vm"))
+	})
+})
@@ -0,0 +1,148 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// kubectl-vm is a kubectl plug-in for day-2 operations on VM Operator
+// VirtualMachines. Install it anywhere in the PATH and run it as "kubectl vm".
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// options are the options common to all of the commands.
+type options struct {
+	kubeconfig string
+	context    string
+	namespace  string
+
+	client ctrlclient.Client
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	return newRootCommandWithOptions(&options{})
+}
+
+// newRootCommandWithOptions returns the root command. If o already has a
+// client, the kubeconfig is not loaded.
+func newRootCommandWithOptions(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "kubectl-vm",
+		Short:        "Manage VM Operator VirtualMachines",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if o.client != nil {
+				return nil
+			}
+			return o.complete()
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use.")
+	flags.StringVar(&o.context, "context", "",
+		"The name of the kubeconfig context to use.")
+	flags.StringVarP(&o.namespace, "namespace", "n", "",
+		"The namespace of the VirtualMachine. Defaults to the namespace of the kubeconfig context.")
+
+	cmd.AddCommand(
+		newPowerCommand(o),
+		newConsoleCommand(o),
+		newSnapshotCommand(o),
+		newPublishCommand(o),
+		newDescribeCommand(o))
+
+	return cmd
+}
+
+// complete creates the client and defaults the namespace from the kubeconfig.
+func (o *options) complete() error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: o.context})
+
+	if o.namespace == "" {
+		ns, _, err := clientConfig.Namespace()
+		if err != nil {
+			return fmt.Errorf("failed to get namespace from kubeconfig: %w", err)
+		}
+		o.namespace = ns
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = vmopv1.AddToScheme(scheme)
+
+	if o.client, err = ctrlclient.New(
+		restConfig,
+		ctrlclient.Options{Scheme: scheme}); err != nil {
+
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return nil
+}
+
+// getVM returns the named VirtualMachine.
+func (o *options) getVM(
+	ctx context.Context,
+	name string) (*vmopv1.VirtualMachine, error) {
+
+	vm := &vmopv1.VirtualMachine{}
+	if err := o.client.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: o.namespace, Name: name},
+		vm); err != nil {
+
+		return nil, fmt.Errorf("failed to get VirtualMachine %s/%s: %w",
+			o.namespace, name, err)
+	}
+	return vm, nil
+}
+
+// patchVM gets the named VirtualMachine, applies mutateFn, and patches the
+// VirtualMachine with the result.
+func (o *options) patchVM(
+	ctx context.Context,
+	name string,
+	mutateFn func(vm *vmopv1.VirtualMachine)) error {
+
+	vm, err := o.getVM(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	patch := ctrlclient.MergeFrom(vm.DeepCopy())
+	mutateFn(vm)
+
+	if err := o.client.Patch(ctx, vm, patch); err != nil {
+		return fmt.Errorf("failed to patch VirtualMachine %s/%s: %w",
+			o.namespace, name, err)
+	}
+	return nil
+}
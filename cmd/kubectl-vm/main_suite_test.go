@@ -0,0 +1,81 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+const testNamespace = "my-namespace"
+
+func TestKubectlVM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "kubectl-vm Test Suite")
+}
+
+// newFakeClient returns a fake client with the provided objects.
+func newFakeClient(objs ...ctrlclient.Object) ctrlclient.Client {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(vmopv1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&vmopv1.VirtualMachine{}).
+		Build()
+}
+
+// runCommand runs the root command with the provided client and arguments,
+// and returns its output.
+func runCommand(
+	client ctrlclient.Client,
+	args ...string) (string, error) {
+
+	var out bytes.Buffer
+	cmd := newRootCommandWithOptions(&options{client: client})
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append(args, "--namespace", testNamespace))
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func newVM(name string) *vmopv1.VirtualMachine {
+	return &vmopv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: vmopv1.VirtualMachineSpec{
+			ImageName:    "my-image",
+			ClassName:    "my-class",
+			StorageClass: "my-storage-class",
+			PowerState:   vmopv1.VirtualMachinePowerStateOn,
+		},
+	}
+}
+
+// getVM returns the named VM from the client.
+func getVM(client ctrlclient.Client, name string) *vmopv1.VirtualMachine {
+	vm := &vmopv1.VirtualMachine{}
+	ExpectWithOffset(1, client.Get(
+		context.Background(),
+		ctrlclient.ObjectKey{Namespace: testNamespace, Name: name},
+		vm)).To(Succeed())
+	return vm
+}
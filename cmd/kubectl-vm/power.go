@@ -0,0 +1,107 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// restartNow is the value of spec.nextRestartTime that causes a VM to be
+// restarted.
+const restartNow = "now"
+
+func newPowerCommand(o *options) *cobra.Command {
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "power",
+		Short: "Change the power state of a VirtualMachine",
+	}
+	cmd.PersistentFlags().StringVar(&mode, "mode", "",
+		"The power operation mode: Hard, Soft, or TrySoft. Defaults to the mode in the VirtualMachine's spec.")
+
+	newPowerSubCommand := func(
+		use, short string,
+		mutateFn func(vm *vmopv1.VirtualMachine, mode vmopv1.VirtualMachinePowerOpMode)) *cobra.Command {
+
+		return &cobra.Command{
+			Use:   use + " NAME",
+			Short: short,
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				opMode, err := parsePowerOpMode(mode)
+				if err != nil {
+					return err
+				}
+				if err := o.patchVM(
+					cmd.Context(),
+					args[0],
+					func(vm *vmopv1.VirtualMachine) {
+						mutateFn(vm, opMode)
+					}); err != nil {
+
+					return err
+				}
+				_, err = fmt.Fprintf(cmd.OutOrStdout(),
+					"virtualmachine/%s %s\n", args[0], use)
+				return err
+			},
+		}
+	}
+
+	cmd.AddCommand(
+		newPowerSubCommand(
+			"on",
+			"Power on a VirtualMachine",
+			func(vm *vmopv1.VirtualMachine, _ vmopv1.VirtualMachinePowerOpMode) {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+			}),
+		newPowerSubCommand(
+			"off",
+			"Power off a VirtualMachine",
+			func(vm *vmopv1.VirtualMachine, mode vmopv1.VirtualMachinePowerOpMode) {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+				if mode != "" {
+					vm.Spec.PowerOffMode = mode
+				}
+			}),
+		newPowerSubCommand(
+			"suspend",
+			"Suspend a VirtualMachine",
+			func(vm *vmopv1.VirtualMachine, mode vmopv1.VirtualMachinePowerOpMode) {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateSuspended
+				if mode != "" {
+					vm.Spec.SuspendMode = mode
+				}
+			}),
+		newPowerSubCommand(
+			"restart",
+			"Restart a VirtualMachine",
+			func(vm *vmopv1.VirtualMachine, mode vmopv1.VirtualMachinePowerOpMode) {
+				vm.Spec.NextRestartTime = restartNow
+				if mode != "" {
+					vm.Spec.RestartMode = mode
+				}
+			}),
+	)
+
+	return cmd
+}
+
+func parsePowerOpMode(s string) (vmopv1.VirtualMachinePowerOpMode, error) {
+	switch m := vmopv1.VirtualMachinePowerOpMode(s); m {
+	case "",
+		vmopv1.VirtualMachinePowerOpModeHard,
+		vmopv1.VirtualMachinePowerOpModeSoft,
+		vmopv1.VirtualMachinePowerOpModeTrySoft:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid power operation mode %q", s)
+	}
+}
@@ -0,0 +1,90 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+var _ = Describe("power", func() {
+	var (
+		client ctrlclient.Client
+		vm     *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = newVM("my-vm")
+	})
+
+	JustBeforeEach(func() {
+		client = newFakeClient(vm)
+	})
+
+	When("powering on the VM", func() {
+		BeforeEach(func() {
+			vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+		})
+		It("should set the desired power state", func() {
+			out, err := runCommand(client, "power", "on", "my-vm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("virtualmachine/my-vm on\n"))
+			Expect(getVM(client, "my-vm").Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+		})
+	})
+
+	When("powering off the VM with a mode", func() {
+		It("should set the desired power state and mode", func() {
+			_, err := runCommand(client, "power", "off", "my-vm", "--mode", "Soft")
+			Expect(err).ToNot(HaveOccurred())
+			obj := getVM(client, "my-vm")
+			Expect(obj.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+			Expect(obj.Spec.PowerOffMode).To(Equal(vmopv1.VirtualMachinePowerOpModeSoft))
+		})
+	})
+
+	When("suspending the VM without a mode", func() {
+		BeforeEach(func() {
+			vm.Spec.SuspendMode = vmopv1.VirtualMachinePowerOpModeHard
+		})
+		It("should keep the mode from the spec", func() {
+			_, err := runCommand(client, "power", "suspend", "my-vm")
+			Expect(err).ToNot(HaveOccurred())
+			obj := getVM(client, "my-vm")
+			Expect(obj.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateSuspended))
+			Expect(obj.Spec.SuspendMode).To(Equal(vmopv1.VirtualMachinePowerOpModeHard))
+		})
+	})
+
+	When("restarting the VM", func() {
+		It("should request a restart", func() {
+			_, err := runCommand(client, "power", "restart", "my-vm", "--mode", "TrySoft")
+			Expect(err).ToNot(HaveOccurred())
+			obj := getVM(client, "my-vm")
+			Expect(obj.Spec.NextRestartTime).To(Equal(restartNow))
+			Expect(obj.Spec.RestartMode).To(Equal(vmopv1.VirtualMachinePowerOpModeTrySoft))
+		})
+	})
+
+	When("the mode is invalid", func() {
+		It("should return an error and not change the VM", func() {
+			_, err := runCommand(client, "power", "off", "my-vm", "--mode", "Gentle")
+			Expect(err).To(MatchError(`invalid power operation mode "Gentle"`))
+			Expect(getVM(client, "my-vm").Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+		})
+	})
+
+	When("the VM does not exist", func() {
+		It("should return an error", func() {
+			_, err := runCommand(client, "power", "on", "other-vm")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get VirtualMachine my-namespace/other-vm"))
+		})
+	})
+})
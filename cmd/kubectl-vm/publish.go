@@ -0,0 +1,79 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+func newPublishCommand(o *options) *cobra.Command {
+	var (
+		itemName        string
+		itemDescription string
+		location        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "publish VM",
+		Short: "Publish a VirtualMachine as an image",
+		Long: "Creates a VirtualMachinePublishRequest that publishes the " +
+			"VirtualMachine to a Content Library. The item name defaults to " +
+			"<VM>-image, and the location defaults to the namespace's " +
+			"default publication target.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vmName := args[0]
+
+			// Ensure the VM exists, which is more helpful than the error
+			// reported in the publish request's conditions.
+			if _, err := o.getVM(cmd.Context(), vmName); err != nil {
+				return err
+			}
+
+			pub := &vmopv1.VirtualMachinePublishRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: vmName + "-",
+					Namespace:    o.namespace,
+				},
+				Spec: vmopv1.VirtualMachinePublishRequestSpec{
+					Source: vmopv1.VirtualMachinePublishRequestSource{
+						Name: vmName,
+					},
+					Target: vmopv1.VirtualMachinePublishRequestTarget{
+						Item: vmopv1.VirtualMachinePublishRequestTargetItem{
+							Name:        itemName,
+							Description: itemDescription,
+						},
+						Location: vmopv1.VirtualMachinePublishRequestTargetLocation{
+							Name: location,
+						},
+					},
+				},
+			}
+
+			if err := o.client.Create(cmd.Context(), pub); err != nil {
+				return fmt.Errorf("failed to create publish request: %w", err)
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(),
+				"virtualmachinepublishrequest/%s created\n", pub.Name)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&itemName, "item-name", "",
+		"The name of the published image.")
+	cmd.Flags().StringVar(&itemDescription, "item-description", "",
+		"The description of the published image.")
+	cmd.Flags().StringVar(&location, "location", "",
+		"The name of the ContentLibrary to which the image is published.")
+
+	return cmd
+}
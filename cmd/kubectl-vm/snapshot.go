@@ -0,0 +1,181 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+func newSnapshotCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Aliases: []string{"snap"},
+		Short:   "Manage the snapshots of a VirtualMachine",
+	}
+
+	cmd.AddCommand(
+		newSnapshotCreateCommand(o),
+		newSnapshotListCommand(o),
+		newSnapshotRevertCommand(o))
+
+	return cmd
+}
+
+func newSnapshotCreateCommand(o *options) *cobra.Command {
+	var (
+		memory         bool
+		description    string
+		quiesceTimeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create VM NAME",
+		Short: "Create a snapshot of a VirtualMachine",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshot := &vmopv1.VirtualMachineSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      args[1],
+					Namespace: o.namespace,
+				},
+				Spec: vmopv1.VirtualMachineSnapshotSpec{
+					VMName:      args[0],
+					Memory:      memory,
+					Description: description,
+				},
+			}
+			if quiesceTimeout > 0 {
+				snapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+					Timeout: &metav1.Duration{Duration: quiesceTimeout},
+				}
+			}
+
+			if err := o.client.Create(cmd.Context(), snapshot); err != nil {
+				return fmt.Errorf("failed to create snapshot: %w", err)
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(),
+				"virtualmachinesnapshot/%s created\n", snapshot.Name)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&memory, "memory", false,
+		"Include the VM's memory in the snapshot.")
+	cmd.Flags().StringVar(&description, "description", "",
+		"A description of the snapshot.")
+	cmd.Flags().DurationVar(&quiesceTimeout, "quiesce-timeout", 0,
+		"Quiesce the guest file system before taking the snapshot, waiting at most this long.")
+
+	return cmd
+}
+
+func newSnapshotListCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list [VM]",
+		Aliases: []string{"ls"},
+		Short:   "List the snapshots, optionally only those of a VirtualMachine",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var list vmopv1.VirtualMachineSnapshotList
+			if err := o.client.List(
+				cmd.Context(),
+				&list,
+				ctrlclient.InNamespace(o.namespace)); err != nil {
+
+				return fmt.Errorf("failed to list snapshots: %w", err)
+			}
+
+			// The name of the current snapshot of each VM.
+			current := map[string]string{}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 3, ' ', 0)
+			fmt.Fprintln(w, "NAME\tVM\tREADY\tCURRENT\tPOWERSTATE\tAGE")
+
+			for i := range list.Items {
+				s := &list.Items[i]
+				if len(args) == 1 && s.Spec.VMName != args[0] {
+					continue
+				}
+
+				if _, ok := current[s.Spec.VMName]; !ok {
+					current[s.Spec.VMName] = ""
+					if vm, err := o.getVM(cmd.Context(), s.Spec.VMName); err == nil &&
+						vm.Status.CurrentSnapshot != nil {
+
+						current[s.Spec.VMName] = vm.Status.CurrentSnapshot.Name
+					}
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n",
+					s.Name,
+					s.Spec.VMName,
+					conditionStatus(
+						s.Status.Conditions,
+						vmopv1.VirtualMachineSnapshotReadyCondition),
+					current[s.Spec.VMName] == s.Name,
+					s.Status.PowerState,
+					age(s.CreationTimestamp))
+			}
+
+			return w.Flush()
+		},
+	}
+
+	return cmd
+}
+
+func newSnapshotRevertCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revert VM NAME",
+		Short: "Revert a VirtualMachine to a snapshot",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.patchVM(
+				cmd.Context(),
+				args[0],
+				func(vm *vmopv1.VirtualMachine) {
+					vm.Spec.CurrentSnapshotName = args[1]
+				}); err != nil {
+
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(),
+				"virtualmachine/%s reverting to snapshot %s\n", args[0], args[1])
+			return err
+		},
+	}
+
+	return cmd
+}
+
+// conditionStatus returns the status of the condition, or "Unknown" if the
+// condition does not exist.
+func conditionStatus(conditions []metav1.Condition, conditionType string) string {
+	if c := meta.FindStatusCondition(conditions, conditionType); c != nil {
+		return string(c.Status)
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// age returns the time since the provided timestamp in the same format as
+// kubectl.
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t.Time))
+}
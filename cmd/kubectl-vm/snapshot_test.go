@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+var _ = Describe("snapshot", func() {
+	var (
+		client  ctrlclient.Client
+		objects []ctrlclient.Object
+	)
+
+	newSnapshot := func(name, vmName string, ready bool) *vmopv1.VirtualMachineSnapshot {
+		s := &vmopv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: vmopv1.VirtualMachineSnapshotSpec{
+				VMName: vmName,
+			},
+		}
+		if ready {
+			s.Status.Conditions = []metav1.Condition{
+				{
+					Type:   vmopv1.VirtualMachineSnapshotReadyCondition,
+					Status: metav1.ConditionTrue,
+				},
+			}
+		}
+		return s
+	}
+
+	BeforeEach(func() {
+		objects = []ctrlclient.Object{newVM("my-vm")}
+	})
+
+	JustBeforeEach(func() {
+		client = newFakeClient(objects...)
+	})
+
+	Context("create", func() {
+		It("should create the snapshot", func() {
+			out, err := runCommand(client,
+				"snapshot", "create", "my-vm", "my-snap",
+				"--memory",
+				"--description", "before upgrade",
+				"--quiesce-timeout", "5m")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("virtualmachinesnapshot/my-snap created\n"))
+
+			var s vmopv1.VirtualMachineSnapshot
+			Expect(client.Get(
+				context.Background(),
+				ctrlclient.ObjectKey{Namespace: testNamespace, Name: "my-snap"},
+				&s)).To(Succeed())
+			Expect(s.Spec.VMName).To(Equal("my-vm"))
+			Expect(s.Spec.Memory).To(BeTrue())
+			Expect(s.Spec.Description).To(Equal("before upgrade"))
+			Expect(s.Spec.Quiesce).ToNot(BeNil())
+			Expect(s.Spec.Quiesce.Timeout.Duration).To(Equal(5 * time.Minute))
+		})
+
+		When("the snapshot already exists", func() {
+			BeforeEach(func() {
+				objects = append(objects, newSnapshot("my-snap", "my-vm", false))
+			})
+			It("should return an error", func() {
+				_, err := runCommand(client, "snapshot", "create", "my-vm", "my-snap")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to create snapshot"))
+			})
+		})
+	})
+
+	Context("list", func() {
+		BeforeEach(func() {
+			vm := newVM("other-vm")
+			vm.Status.CurrentSnapshot = &vmopv1.VirtualMachineSnapshotReference{
+				Type: vmopv1.VirtualMachineSnapshotReferenceTypeManaged,
+				Name: "snap-2",
+			}
+			objects = append(objects,
+				vm,
+				newSnapshot("snap-1", "my-vm", true),
+				newSnapshot("snap-2", "other-vm", false))
+		})
+
+		It("should list the snapshots of all VMs", func() {
+			out, err := runCommand(client, "snapshot", "list")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(MatchRegexp(`NAME\s+VM\s+READY\s+CURRENT\s+POWERSTATE\s+AGE\n`))
+			Expect(out).To(MatchRegexp(`snap-1\s+my-vm\s+True\s+false\s`))
+			Expect(out).To(MatchRegexp(`snap-2\s+other-vm\s+Unknown\s+true\s`))
+		})
+
+		It("should only list the snapshots of the named VM", func() {
+			out, err := runCommand(client, "snapshot", "list", "my-vm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("snap-1"))
+			Expect(out).ToNot(ContainSubstring("snap-2"))
+		})
+	})
+
+	Context("revert", func() {
+		It("should set the VM's current snapshot name", func() {
+			out, err := runCommand(client, "snapshot", "revert", "my-vm", "my-snap")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("virtualmachine/my-vm reverting to snapshot my-snap\n"))
+			Expect(getVM(client, "my-vm").Spec.CurrentSnapshotName).To(Equal("my-snap"))
+		})
+	})
+})
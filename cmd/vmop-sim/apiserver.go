@@ -0,0 +1,93 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+const (
+	// adminUserName is the name of the user written to the kubeconfig.
+	adminUserName = "vmop-sim-admin"
+
+	// webhookManifestsPath is the path, relative to the root of this
+	// project, of the webhook manifests.
+	webhookManifestsPath = "config/webhook/manifests.yaml"
+)
+
+// apiServer is a local Kubernetes API server.
+type apiServer struct {
+	env    envtest.Environment
+	config *rest.Config
+}
+
+// startAPIServer starts a local etcd and kube-apiserver from the binaries in
+// assetsDir and writes a kubeconfig for an administrator to kubeconfigPath.
+//
+// The webhooks from config/webhook are installed and point to a webhook server
+// on the local host. The CRDs are not installed, as that is done the same way
+// as VM Operator does at startup.
+func startAPIServer(
+	rootDir, assetsDir, kubeconfigPath string) (_ *apiServer, retErr error) {
+
+	s := &apiServer{
+		env: envtest.Environment{
+			BinaryAssetsDirectory: assetsDir,
+			WebhookInstallOptions: envtest.WebhookInstallOptions{
+				Paths: []string{
+					filepath.Join(rootDir, webhookManifestsPath),
+				},
+			},
+		},
+	}
+
+	config, err := s.env.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start api server: %w", err)
+	}
+	s.config = config
+
+	defer func() {
+		if retErr != nil {
+			_ = s.stop()
+		}
+	}()
+
+	user, err := s.env.AddUser(
+		envtest.User{
+			Name:   adminUserName,
+			Groups: []string{"system:masters"},
+		},
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add api server user: %w", err)
+	}
+
+	kubeconfig, err := user.KubeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	if err := os.WriteFile(kubeconfigPath, kubeconfig, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	return s, nil
+}
+
+// stop stops the local API server.
+func (s *apiServer) stop() error {
+	return s.env.Stop()
+}
+
+// webhookOptions returns the options for the local webhook server.
+func (s *apiServer) webhookOptions() envtest.WebhookInstallOptions {
+	return s.env.WebhookInstallOptions
+}
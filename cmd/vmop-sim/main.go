@@ -0,0 +1,400 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// vmop-sim runs VM Operator against a simulated vCenter and a local
+// Kubernetes API server. It is intended for demos and for developing
+// integrations with VM Operator without access to vSphere.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/component-base/logs"
+	logsv1 "k8s.io/component-base/logs/api/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlsig "sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/vmware-tanzu/vm-operator/controllers"
+	"github.com/vmware-tanzu/vm-operator/pkg"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgcrd "github.com/vmware-tanzu/vm-operator/pkg/crd"
+	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
+	pkgmgrinit "github.com/vmware-tanzu/vm-operator/pkg/manager/init"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/watcher"
+	"github.com/vmware-tanzu/vm-operator/services"
+	"github.com/vmware-tanzu/vm-operator/webhooks"
+)
+
+// defaultImagePath is the path, relative to the root of this project, of the
+// image uploaded to the Content Library by default.
+const defaultImagePath = "test/builder/testdata/images/ttylinux-pc_i486-16.1.ova"
+
+var (
+	rootDir        string
+	assetsDir      string
+	kubeconfigPath string
+	vcsimAddr      string
+	namespace      string
+	numZones       int
+	numNetworks    int
+	imagePaths     string
+	metricsAddr    string
+	healthAddr     string
+	logOptions     = logs.NewOptions()
+	setupLog       = klog.Background().WithName("setup")
+)
+
+func main() {
+	initFlags()
+	initLogging()
+
+	if err := run(ctrlsig.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "Problem running simulator")
+		os.Exit(1)
+	}
+}
+
+func initFlags() {
+	flag.StringVar(
+		&rootDir,
+		"root-dir",
+		".",
+		"The root directory of the VM Operator project.")
+	flag.StringVar(
+		&assetsDir,
+		"assets-dir",
+		"",
+		"The directory with the etcd and kube-apiserver binaries. Defaults to $KUBEBUILDER_ASSETS, or hack/tools/bin/<os>_<arch> in the root directory.")
+	flag.StringVar(
+		&kubeconfigPath,
+		"kubeconfig-out",
+		"vmop-sim.kubeconfig",
+		"The path to which the kubeconfig for the local API server is written.")
+	flag.StringVar(
+		&vcsimAddr,
+		"vcsim-addr",
+		"127.0.0.1:8989",
+		"The address on which the simulated vCenter listens.")
+	flag.StringVar(
+		&namespace,
+		"namespace",
+		"my-namespace",
+		"The name of the workload namespace in which to deploy VMs.")
+	flag.IntVar(
+		&numZones,
+		"zones",
+		3,
+		"The number of zones.")
+	flag.IntVar(
+		&numNetworks,
+		"networks",
+		1,
+		"The number of networks.")
+	flag.StringVar(
+		&imagePaths,
+		"images",
+		defaultImagePath,
+		"A comma-separated list of OVA and ISO files uploaded to the Content Library. Relative paths are relative to the root directory.")
+	flag.StringVar(
+		&metricsAddr,
+		"metrics-addr",
+		"0",
+		"The address the metric endpoint binds to.")
+	flag.StringVar(
+		&healthAddr,
+		"health-addr",
+		"0",
+		"The address the health probe endpoint binds to.")
+
+	logsv1.AddGoFlags(logOptions, flag.CommandLine)
+
+	// Set log level 2 as default.
+	if err := flag.Set("v", "2"); err != nil {
+		setupLog.Error(err, "Failed to set default log level")
+		os.Exit(1)
+	}
+
+	flag.Parse()
+}
+
+func initLogging() {
+	if err := logsv1.ValidateAndApply(logOptions, nil); err != nil {
+		setupLog.Error(err, "Failed to validate logging configuration")
+		os.Exit(1)
+	}
+
+	// klog.Background will automatically use the right logger.
+	ctrl.SetLogger(klog.Background())
+}
+
+// initContext returns the context for the simulator. The configuration is read
+// from the environment the same way as VM Operator, with the exception of the
+// settings required to run against vC Sim.
+func initContext(parent context.Context) context.Context {
+	cfg := pkgcfg.FromEnv()
+
+	// There is no network operator, so the networks are referenced by name.
+	cfg.NetworkProviderType = pkgcfg.NetworkProviderTypeNamed
+	cfg.Features.VMSnapshots = true
+
+	ctx := pkgcfg.WithContext(parent, cfg)
+	ctx = cource.WithContext(ctx)
+	ctx = watcher.WithContext(ctx)
+	ctx = ovfcache.WithContext(ctx)
+	return ctx
+}
+
+func run(parentCtx context.Context) error {
+	setupLog.Info("Starting VM Operator simulator",
+		"version", pkg.BuildVersion,
+		"commit", pkg.BuildCommit)
+
+	ctx := initContext(parentCtx)
+
+	if assetsDir == "" {
+		assetsDir = os.Getenv("KUBEBUILDER_ASSETS")
+	}
+	if assetsDir == "" {
+		assetsDir = filepath.Join(
+			rootDir, "hack", "tools", "bin",
+			goruntime.GOOS+"_"+goruntime.GOARCH)
+	}
+
+	tracingShutdown, err := pkgtracing.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = tracingShutdown(shutdownCtx)
+	}()
+
+	setupLog.Info("Starting vC Sim", "addr", vcsimAddr)
+	sim, err := startVCSim(ctx, vcSimOptions{
+		ListenAddr:  vcsimAddr,
+		NumZones:    numZones,
+		NumNetworks: numNetworks,
+		ImagePaths:  getImagePaths(),
+	})
+	if err != nil {
+		return err
+	}
+	defer sim.stop()
+
+	setupLog.Info("Starting local API server", "assetsDir", assetsDir)
+	apiServer, err := startAPIServer(rootDir, assetsDir, kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := apiServer.stop(); err != nil {
+			setupLog.Error(err, "Failed to stop local API server")
+		}
+	}()
+
+	if err := installCRDs(ctx, apiServer); err != nil {
+		return err
+	}
+
+	mgr, err := newManager(ctx, apiServer)
+	if err != nil {
+		return err
+	}
+
+	client, err := ctrlclient.New(
+		apiServer.config,
+		ctrlclient.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	s := seeder{
+		client:       client,
+		sim:          sim,
+		podNamespace: mgr.GetContext().Namespace,
+		namespace:    namespace,
+	}
+
+	setupLog.Info("Seeding infrastructure")
+	if err := s.seedInfrastructure(ctx); err != nil {
+		return err
+	}
+
+	mgrCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	mgrErr := make(chan error, 1)
+	go func() {
+		setupLog.Info("Starting controller manager")
+		mgrErr <- mgr.Start(mgrCtx)
+	}()
+
+	setupLog.Info("Waiting for webhook server")
+	if err := waitForWebhookServer(ctx, mgr); err != nil {
+		return err
+	}
+
+	setupLog.Info("Seeding workloads")
+	if err := s.seedWorkloads(ctx); err != nil {
+		return err
+	}
+
+	setupLog.Info("Simulator is ready",
+		"kubeconfig", kubeconfigPath,
+		"vcsim", sim.server.URL.String(),
+		"namespace", namespace)
+
+	if err := <-mgrErr; err != nil {
+		return fmt.Errorf("problem running controller manager: %w", err)
+	}
+	return nil
+}
+
+// getImagePaths returns the paths of the images to upload to the Content
+// Library. The default image is skipped if it has not been built.
+func getImagePaths() []string {
+	var paths []string
+	for _, p := range strings.Split(imagePaths, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(rootDir, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			setupLog.Error(err, "Skipping image")
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// installCRDs installs the CRDs the same way as VM Operator does at startup,
+// and waits for them to be established.
+func installCRDs(ctx context.Context, apiServer *apiServer) error {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	c, err := ctrlclient.New(apiServer.config, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client for installing CRDs: %w", err)
+	}
+
+	if err := pkgcrd.Install(ctx, c, nil); err != nil {
+		return fmt.Errorf("failed to install CRDs: %w", err)
+	}
+
+	return wait.PollUntilContextTimeout(
+		ctx,
+		time.Second,
+		time.Minute,
+		true,
+		func(ctx context.Context) (bool, error) {
+			var list apiextensionsv1.CustomResourceDefinitionList
+			if err := c.List(ctx, &list); err != nil {
+				return false, err
+			}
+			for i := range list.Items {
+				if !isEstablished(list.Items[i]) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+}
+
+func isEstablished(crd apiextensionsv1.CustomResourceDefinition) bool {
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apiextensionsv1.Established {
+			return c.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newManager returns a controller manager with the same controllers, services,
+// and webhooks as VM Operator.
+func newManager(
+	ctx context.Context,
+	apiServer *apiServer) (pkgmgr.Manager, error) {
+
+	addToManager := func(
+		ctx *pkgctx.ControllerManagerContext,
+		mgr ctrlmgr.Manager) error {
+
+		if err := controllers.AddToManager(ctx, mgr); err != nil {
+			return err
+		}
+		if err := services.AddToManager(ctx, mgr); err != nil {
+			return err
+		}
+		return webhooks.AddToManager(ctx, mgr)
+	}
+
+	cfg := pkgcfg.FromContext(ctx)
+	webhookOpts := apiServer.webhookOptions()
+
+	mgr, err := pkgmgr.New(ctx, pkgmgr.Options{
+		KubeConfig:                   apiServer.config,
+		MetricsAddr:                  metricsAddr,
+		HealthProbeBindAddress:       healthAddr,
+		PodNamespace:                 cfg.PodNamespace,
+		PodName:                      cfg.PodName,
+		PodServiceAccountName:        cfg.PodServiceAccountName,
+		SyncPeriod:                   cfg.SyncPeriod,
+		MaxConcurrentReconciles:      cfg.MaxConcurrentReconciles,
+		WebhookServiceContainerPort:  webhookOpts.LocalServingPort,
+		WebhookSecretVolumeMountPath: webhookOpts.LocalServingCertDir,
+		UsePriorityQueue:             true,
+		Logger:                       ptr.To(klog.Background()),
+		InitializeProviders:          pkgmgrinit.InitializeProviders,
+		AddToManager:                 addToManager,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create controller manager: %w", err)
+	}
+
+	srv, ok := mgr.GetWebhookServer().(*webhook.DefaultServer)
+	if !ok {
+		return nil, errors.New("unexpected webhook server type")
+	}
+	srv.Options.Host = webhookOpts.LocalServingHost
+
+	return mgr, nil
+}
+
+// waitForWebhookServer waits for the manager's webhook server to start.
+func waitForWebhookServer(ctx context.Context, mgr pkgmgr.Manager) error {
+	checker := mgr.GetWebhookServer().StartedChecker()
+	return wait.PollUntilContextTimeout(
+		ctx,
+		time.Second,
+		time.Minute,
+		true,
+		func(context.Context) (bool, error) {
+			return checker(nil) == nil, nil
+		})
+}
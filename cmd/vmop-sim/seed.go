@@ -0,0 +1,363 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/simulator"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	spqv1 "github.com/vmware-tanzu/vm-operator/external/storage-policy-quota/api/v1alpha2"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/credentials"
+)
+
+// storageClassProvisioner is the provisioner of the seeded StorageClasses.
+const storageClassProvisioner = "csi.vsphere.vmware.com"
+
+// vmClass describes a seeded VirtualMachineClass.
+type vmClass struct {
+	Name   string
+	CPUs   int64
+	Memory string
+}
+
+// vmClasses are the VirtualMachineClasses seeded in the workload namespace.
+var vmClasses = []vmClass{
+	{Name: "best-effort-xsmall", CPUs: 2, Memory: "2Gi"},
+	{Name: "best-effort-small", CPUs: 2, Memory: "4Gi"},
+	{Name: "best-effort-medium", CPUs: 2, Memory: "8Gi"},
+	{Name: "best-effort-large", CPUs: 4, Memory: "16Gi"},
+}
+
+// seeder creates the Kubernetes resources that describe the simulated vCenter
+// to VM Operator, and a workload namespace in which to deploy VMs.
+type seeder struct {
+	client       ctrlclient.Client
+	sim          *vcSim
+	podNamespace string
+	namespace    string
+}
+
+// seedInfrastructure creates the resources VM Operator reads at startup or
+// that are otherwise provided by the Supervisor, i.e. resources that are not
+// subject to VM Operator's webhooks.
+func (s seeder) seedInfrastructure(ctx context.Context) error {
+	for _, fn := range []func(context.Context) error{
+		s.createPodNamespace,
+		s.createProviderConfig,
+		s.createStorageClasses,
+		s.createAvailabilityZones,
+		s.createWorkloadNamespace,
+		s.createImages,
+		s.createContentLibrary,
+	} {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedWorkloads creates the resources that are subject to VM Operator's
+// webhooks, and thus may only be created once the webhook server is running.
+func (s seeder) seedWorkloads(ctx context.Context) error {
+	return s.createVMClasses(ctx)
+}
+
+func (s seeder) createPodNamespace(ctx context.Context) error {
+	return s.create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: s.podNamespace,
+		},
+	})
+}
+
+func (s seeder) createProviderConfig(ctx context.Context) error {
+	password, _ := simulator.DefaultLogin.Password()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pkgcfg.FromContext(ctx).VCCredsSecretName,
+			Namespace: s.podNamespace,
+		},
+		Data: map[string][]byte{
+			credentials.UsernameKey: []byte(simulator.DefaultLogin.Username()),
+			credentials.PasswordKey: []byte(password),
+		},
+	}
+	if err := s.create(ctx, secret); err != nil {
+		return err
+	}
+
+	providerConfig := &config.VSphereVMProviderConfig{
+		VcPNID:               s.sim.server.URL.Hostname(),
+		VcPort:               s.sim.server.URL.Port(),
+		Datacenter:           s.sim.datacenter.Reference().Value,
+		StorageClassRequired: true,
+		CAFilePath:           s.sim.caFilePath,
+	}
+	if len(s.sim.networkNames) > 0 {
+		providerConfig.Network = s.sim.networkNames[0]
+	}
+	if err := s.create(
+		ctx,
+		config.ProviderConfigToConfigMap(s.podNamespace, providerConfig)); err != nil {
+
+		return err
+	}
+
+	return s.create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.NetworkConfigMapName,
+			Namespace: s.podNamespace,
+		},
+		Data: map[string]string{
+			config.NameserversKey: "1.1.1.1 1.0.0.1",
+		},
+	})
+}
+
+func (s seeder) createStorageClasses(ctx context.Context) error {
+	for _, p := range s.sim.storagePolicies {
+		if err := s.create(ctx, &storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: dnsName(p.Name),
+			},
+			Provisioner: storageClassProvisioner,
+			Parameters: map[string]string{
+				"storagePolicyID": p.ID,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s seeder) createAvailabilityZones(ctx context.Context) error {
+	for _, name := range s.sim.zoneNames {
+		az := &topologyv1.AvailabilityZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		for _, ccr := range s.sim.zones[name] {
+			az.Spec.ClusterComputeResourceMoIDs = append(
+				az.Spec.ClusterComputeResourceMoIDs,
+				ccr.Reference().Value)
+		}
+		if err := s.create(ctx, az); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s seeder) createWorkloadNamespace(ctx context.Context) error {
+	if err := s.create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: s.namespace,
+		},
+	}); err != nil {
+		return err
+	}
+
+	nsFolder, err := s.sim.vmFolder.CreateFolder(ctx, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to create namespace folder: %w", err)
+	}
+
+	for _, zoneName := range s.sim.zoneNames {
+		nsInfo := topologyv1.NamespaceInfo{
+			FolderMoId: nsFolder.Reference().Value,
+		}
+
+		for _, ccr := range s.sim.zones[zoneName] {
+			rp, err := ccr.ResourcePool(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get cluster resource pool: %w", err)
+			}
+			nsRP, err := rp.Create(
+				ctx, s.namespace, vimtypes.DefaultResourceConfigSpec())
+			if err != nil {
+				return fmt.Errorf("failed to create namespace resource pool: %w", err)
+			}
+			nsInfo.PoolMoIDs = append(nsInfo.PoolMoIDs, nsRP.Reference().Value)
+		}
+
+		// When the WorkloadDomainIsolation capability is disabled,
+		// AvailabilityZone stores namespace info. Otherwise the namespaced
+		// Zone does.
+		if !pkgcfg.FromContext(ctx).Features.WorkloadDomainIsolation {
+			az := &topologyv1.AvailabilityZone{}
+			if err := s.client.Get(
+				ctx, ctrlclient.ObjectKey{Name: zoneName}, az); err != nil {
+
+				return fmt.Errorf("failed to get zone %q: %w", zoneName, err)
+			}
+			if az.Spec.Namespaces == nil {
+				az.Spec.Namespaces = map[string]topologyv1.NamespaceInfo{}
+			}
+			az.Spec.Namespaces[s.namespace] = nsInfo
+			if err := s.client.Update(ctx, az); err != nil {
+				return fmt.Errorf("failed to update zone %q: %w", zoneName, err)
+			}
+		} else if err := s.create(ctx, &topologyv1.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: s.namespace,
+			},
+			Spec: topologyv1.ZoneSpec{
+				ManagedVMs: topologyv1.VSphereEntityInfo{
+					FolderMoID: nsInfo.FolderMoId,
+					PoolMoIDs:  nsInfo.PoolMoIDs,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.PodVMOnStretchedSupervisor {
+		for _, p := range s.sim.storagePolicies {
+			if err := s.create(ctx, &spqv1.StoragePolicyQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dnsName(p.Name) + "-storagepolicyquota",
+					Namespace: s.namespace,
+				},
+				Spec: spqv1.StoragePolicyQuotaSpec{
+					StoragePolicyId: p.ID,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage",
+			Namespace: s.namespace,
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{},
+		},
+	}
+	for _, p := range s.sim.storagePolicies {
+		name := corev1.ResourceName(
+			dnsName(p.Name) + ".storageclass.storage.k8s.io/persistentvolumeclaims")
+		quota.Spec.Hard[name] = resource.MustParse("100")
+	}
+	return s.create(ctx, quota)
+}
+
+func (s seeder) createImages(ctx context.Context) error {
+	for _, item := range s.sim.libraryItems {
+		image := &vmopv1.ClusterVirtualMachineImage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: item.Name,
+			},
+			Spec: vmopv1.VirtualMachineImageSpec{
+				ProviderRef: &common.LocalObjectRef{
+					Kind: "ClusterContentLibraryItem",
+				},
+			},
+		}
+		if err := s.create(ctx, image); err != nil {
+			return err
+		}
+
+		image.Status = vmopv1.VirtualMachineImageStatus{
+			Name:                   item.Name,
+			ProviderItemID:         item.ID,
+			ProviderContentVersion: item.Version,
+			Type:                   item.Type,
+		}
+		conditions.MarkTrue(image, vmopv1.ReadyConditionType)
+		if err := s.client.Status().Update(ctx, image); err != nil {
+			return fmt.Errorf("failed to update image %q status: %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s seeder) createContentLibrary(ctx context.Context) error {
+	cl := &imgregv1a1.ContentLibrary{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      contentLibraryName,
+			Namespace: s.namespace,
+		},
+		Spec: imgregv1a1.ContentLibrarySpec{
+			BaseContentLibrarySpec: imgregv1a1.BaseContentLibrarySpec{
+				UUID: types.UID(s.sim.libraryID),
+			},
+			Writable: true,
+		},
+	}
+	if err := s.create(ctx, cl); err != nil {
+		return err
+	}
+
+	cl.Status = imgregv1a1.ContentLibraryStatus{
+		Name: contentLibraryName,
+		Type: imgregv1a1.ContentLibraryTypeLocal,
+		Conditions: imgregv1a1.Conditions{
+			{
+				Type:               imgregv1a1.ReadyCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			},
+		},
+	}
+	if err := s.client.Status().Update(ctx, cl); err != nil {
+		return fmt.Errorf("failed to update content library status: %w", err)
+	}
+	return nil
+}
+
+func (s seeder) createVMClasses(ctx context.Context) error {
+	for _, c := range vmClasses {
+		if err := s.create(ctx, &vmopv1.VirtualMachineClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.Name,
+				Namespace: s.namespace,
+			},
+			Spec: vmopv1.VirtualMachineClassSpec{
+				Hardware: vmopv1.VirtualMachineClassHardware{
+					Cpus:   c.CPUs,
+					Memory: resource.MustParse(c.Memory),
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s seeder) create(ctx context.Context, obj ctrlclient.Object) error {
+	if err := s.client.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to create %T %s: %w",
+			obj, ctrlclient.ObjectKeyFromObject(obj), err)
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	// Register the simulator endpoints used by VM Operator.
+	_ "github.com/vmware/govmomi/lookup/simulator"
+	_ "github.com/vmware/govmomi/pbm/simulator"
+	_ "github.com/vmware/govmomi/sts/simulator"
+	_ "github.com/vmware/govmomi/vapi/cluster/simulator"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// clustersPerZone is the number of clusters in each zone.
+	clustersPerZone = 1
+
+	// hostsPerCluster is the number of hosts in each cluster.
+	hostsPerCluster = 2
+
+	// hostDomainName is the domain name of the simulated hosts. Instance
+	// storage requires the hosts' FQDN to be populated.
+	hostDomainName = "vmop-sim.local"
+
+	// contentLibraryName is the name of the seeded Content Library.
+	contentLibraryName = "vmop-sim"
+)
+
+// storagePolicy is a storage policy from the simulated vCenter.
+type storagePolicy struct {
+	ID   string
+	Name string
+}
+
+// libraryItem is an item in the seeded Content Library.
+type libraryItem struct {
+	ID      string
+	Name    string
+	Type    string
+	Version string
+}
+
+// vcSim is a running vC Sim instance and the inventory with which it has been
+// seeded.
+type vcSim struct {
+	model  *simulator.Model
+	server *simulator.Server
+
+	client     *govmomi.Client
+	restClient *rest.Client
+	finder     *find.Finder
+
+	caFilePath string
+
+	datacenter *object.Datacenter
+	datastore  *object.Datastore
+	vmFolder   *object.Folder
+
+	// zones maps the name of each zone to its clusters.
+	zones     map[string][]*object.ClusterComputeResource
+	zoneNames []string
+
+	networkNames    []string
+	storagePolicies []storagePolicy
+
+	libraryID    string
+	libraryItems []libraryItem
+}
+
+// vcSimOptions describes the inventory with which vC Sim is seeded.
+type vcSimOptions struct {
+	// ListenAddr is the address on which vC Sim listens.
+	ListenAddr string
+
+	// NumZones is the number of zones.
+	NumZones int
+
+	// NumNetworks is the number of distributed port groups.
+	NumNetworks int
+
+	// ImagePaths are the paths to the OVA and ISO files uploaded to the
+	// Content Library.
+	ImagePaths []string
+}
+
+// startVCSim starts vC Sim and seeds its inventory.
+func startVCSim(ctx context.Context, opts vcSimOptions) (_ *vcSim, retErr error) {
+	model := simulator.VPX()
+
+	// Only create hosts in clusters so each cluster has a single
+	// ResourcePool.
+	model.Host = 0
+	model.Cluster = opts.NumZones * clustersPerZone
+	model.ClusterHost = hostsPerCluster
+	model.Portgroup = opts.NumNetworks
+
+	if err := model.Create(); err != nil {
+		return nil, fmt.Errorf("failed to create vcsim model: %w", err)
+	}
+
+	model.Service.RegisterEndpoints = true
+	model.Service.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	model.Service.Listen = &url.URL{
+		Host: opts.ListenAddr,
+	}
+
+	s := &vcSim{
+		model:  model,
+		server: model.Service.NewServer(),
+		zones:  map[string][]*object.ClusterComputeResource{},
+	}
+
+	defer func() {
+		if retErr != nil {
+			s.stop()
+		}
+	}()
+
+	caFilePath, err := s.server.CertificateFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write vcsim certificate: %w", err)
+	}
+	s.caFilePath = caFilePath
+
+	if s.client, err = govmomi.NewClient(ctx, s.server.URL, true); err != nil {
+		return nil, fmt.Errorf("failed to create vcsim client: %w", err)
+	}
+
+	s.restClient = rest.NewClient(s.client.Client)
+	if err := s.restClient.Login(ctx, simulator.DefaultLogin); err != nil {
+		return nil, fmt.Errorf("failed to login to vcsim rest api: %w", err)
+	}
+
+	if err := s.setupInventory(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	if err := s.setupStoragePolicies(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.setupContentLibrary(ctx, opts.ImagePaths); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// stop stops vC Sim.
+func (s *vcSim) stop() {
+	if s.caFilePath != "" {
+		_ = os.Remove(s.caFilePath)
+	}
+	s.server.Close()
+	s.model.Remove()
+}
+
+func (s *vcSim) setupInventory(ctx context.Context, opts vcSimOptions) error {
+	s.finder = find.NewFinder(s.client.Client)
+
+	dc, err := s.finder.DefaultDatacenter(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find datacenter: %w", err)
+	}
+	s.datacenter = dc
+	s.finder.SetDatacenter(dc)
+
+	if s.vmFolder, err = s.finder.DefaultFolder(ctx); err != nil {
+		return fmt.Errorf("failed to find vm folder: %w", err)
+	}
+
+	if s.datastore, err = s.finder.DefaultDatastore(ctx); err != nil {
+		return fmt.Errorf("failed to find datastore: %w", err)
+	}
+
+	for _, ref := range s.model.Map().AllReference("HostNetworkSystem") {
+		if hns, ok := ref.(*simulator.HostNetworkSystem); ok && hns.Host != nil {
+			hns.DnsConfig = &vimtypes.HostDnsConfig{
+				HostName:   hns.Host.Reference().Value,
+				DomainName: hostDomainName,
+			}
+		}
+	}
+
+	ccrs, err := s.finder.ClusterComputeResourceList(ctx, "*")
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	if len(ccrs) != opts.NumZones*clustersPerZone {
+		return fmt.Errorf("expected %d clusters, found %d",
+			opts.NumZones*clustersPerZone, len(ccrs))
+	}
+	for i := range opts.NumZones {
+		name := fmt.Sprintf("zone-%d", i)
+		s.zones[name] = ccrs[i*clustersPerZone : (i+1)*clustersPerZone]
+		s.zoneNames = append(s.zoneNames, name)
+	}
+
+	for i := range opts.NumNetworks {
+		name := fmt.Sprintf("DC0_DVPG%d", i)
+		if _, err := s.finder.Network(ctx, name); err != nil {
+			return fmt.Errorf("failed to find network %q: %w", name, err)
+		}
+		s.networkNames = append(s.networkNames, name)
+	}
+
+	return nil
+}
+
+func (s *vcSim) setupStoragePolicies(ctx context.Context) error {
+	c, err := pbm.NewClient(ctx, s.client.Client)
+	if err != nil {
+		return fmt.Errorf("failed to create pbm client: %w", err)
+	}
+
+	ids, err := c.QueryProfile(
+		ctx,
+		pbmtypes.PbmProfileResourceType{
+			ResourceType: string(pbmtypes.PbmProfileResourceTypeEnumSTORAGE),
+		},
+		string(pbmtypes.PbmProfileCategoryEnumREQUIREMENT))
+	if err != nil {
+		return fmt.Errorf("failed to query storage policies: %w", err)
+	}
+
+	profiles, err := c.RetrieveContent(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve storage policies: %w", err)
+	}
+
+	for _, p := range profiles {
+		profile := p.GetPbmProfile()
+		s.storagePolicies = append(s.storagePolicies, storagePolicy{
+			ID:   profile.ProfileId.UniqueId,
+			Name: profile.Name,
+		})
+	}
+
+	return nil
+}
+
+func (s *vcSim) setupContentLibrary(
+	ctx context.Context,
+	imagePaths []string) error {
+
+	libMgr := library.NewManager(s.restClient)
+
+	libID, err := libMgr.CreateLibrary(ctx, library.Library{
+		Name: contentLibraryName,
+		Type: "LOCAL",
+		Storage: []library.StorageBacking{
+			{
+				DatastoreID: s.datastore.Reference().Value,
+				Type:        "DATASTORE",
+			},
+		},
+		Publication: &library.Publication{
+			Published: ptr.To(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create content library: %w", err)
+	}
+	s.libraryID = libID
+
+	for _, p := range imagePaths {
+		itemType := library.ItemTypeOVF
+		if strings.EqualFold(filepath.Ext(p), ".iso") {
+			itemType = library.ItemTypeISO
+		}
+
+		item := library.Item{
+			Name:      imageName(p),
+			Type:      itemType,
+			LibraryID: libID,
+		}
+
+		itemID, err := createLibraryItem(ctx, libMgr, item, p)
+		if err != nil {
+			return fmt.Errorf("failed to create library item for %q: %w", p, err)
+		}
+
+		li, err := libMgr.GetLibraryItem(ctx, itemID)
+		if err != nil {
+			return fmt.Errorf("failed to get library item %q: %w", itemID, err)
+		}
+
+		s.libraryItems = append(s.libraryItems, libraryItem{
+			ID:      li.ID,
+			Name:    li.Name,
+			Type:    strings.ToUpper(li.Type),
+			Version: li.ContentVersion,
+		})
+	}
+
+	return nil
+}
+
+// createLibraryItem creates a library item and uploads the file at the
+// provided path to it.
+func createLibraryItem(
+	ctx context.Context,
+	libMgr *library.Manager,
+	item library.Item,
+	filePath string) (string, error) {
+
+	itemID, err := libMgr.CreateLibraryItem(ctx, item)
+	if err != nil {
+		return "", err
+	}
+
+	sessionID, err := libMgr.CreateLibraryItemUpdateSession(
+		ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	info := library.UpdateFile{
+		Name:       filepath.Base(filePath),
+		SourceType: "PUSH",
+		Size:       fi.Size(),
+	}
+
+	update, err := libMgr.AddLibraryItemFile(ctx, sessionID, info)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(update.UploadEndpoint.URI)
+	if err != nil {
+		return "", err
+	}
+
+	p := soap.DefaultUpload
+	p.ContentLength = info.Size
+
+	if err := libMgr.Client.Upload(ctx, f, u, &p); err != nil {
+		return "", err
+	}
+
+	if err := libMgr.CompleteLibraryItemUpdateSession(ctx, sessionID); err != nil {
+		return "", err
+	}
+
+	return itemID, nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// imageName returns the name of the image for the file at the provided path.
+func imageName(filePath string) string {
+	return dnsName(strings.TrimSuffix(
+		filepath.Base(filePath), filepath.Ext(filePath)))
+}
+
+// dnsName returns the provided value as a valid DNS-1123 label.
+func dnsName(s string) string {
+	s = invalidNameChars.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-")
+}
@@ -29,4 +29,9 @@ const (
 	// This label prefix is used only when Features.TKGMultipleCL is enabled,
 	// otherwise TKGServiceTypeLabelKeyPrefix is used.
 	MultipleCLServiceTypeLabelKeyPrefix = "services.supervisor.vmware.com/"
+
+	// SignatureVerifiedForAnnotationKey is an annotation on an image that
+	// records the content version of its library item and the digest of the
+	// trust roots when the item's signature was last verified.
+	SignatureVerifiedForAnnotationKey = "vmoperator.vmware.com/signature-verified-for"
 )
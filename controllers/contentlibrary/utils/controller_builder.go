@@ -33,6 +33,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	imgutil "github.com/vmware-tanzu/vm-operator/pkg/util/image"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
@@ -87,7 +88,20 @@ func AddToManager(
 			))
 	}
 
-	return builder.Complete(r)
+	if pkgcfg.FromContext(ctx).ImageTrustRootsName != "" {
+		var err error
+		builder, r.TrustRoots, err = watchTrustRoots(ctx, mgr, builder, func() client.ObjectList {
+			if controlledItemTypeName == ContentLibraryItemKind {
+				return &imgregv1a1.ContentLibraryItemList{}
+			}
+			return &imgregv1a1.ClusterContentLibraryItemList{}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return builder.Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconciler(
@@ -106,6 +120,7 @@ func NewReconciler(
 		VMProvider: vmProvider,
 		Metrics:    metrics.NewContentLibraryItemMetrics(),
 		Kind:       kind,
+		TrustRoots: client,
 	}
 }
 
@@ -119,6 +134,10 @@ type Reconciler struct {
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.ContentLibraryItemMetrics
 	Kind       string
+
+	// TrustRoots reads the ConfigMap and Secret that contain the trust roots
+	// used to verify the signatures of library items.
+	TrustRoots client.Reader
 }
 
 func (r *Reconciler) Reconcile(
@@ -268,6 +287,7 @@ func (r *Reconciler) ReconcileNormal(
 	var (
 		didSync     bool
 		syncErr     error
+		sigErr      error
 		savedStatus *vmopv1.VirtualMachineImageStatus
 	)
 
@@ -337,7 +357,16 @@ func (r *Reconciler) ReconcileNormal(
 
 			didSync = true
 
-			// Do not return syncErr here as we still want to patch the updated
+			sigErr = reconcileSignature(
+				ctx,
+				r.TrustRoots,
+				r.VMProvider,
+				string(cliSpec.UUID),
+				cliStatus.ContentVersion,
+				vmiObj,
+				vmiStatus)
+
+			// Do not return syncErr or sigErr here as we still want to patch the updated
 			// fields we get above.
 			return nil
 		})
@@ -379,6 +408,11 @@ func (r *Reconciler) ReconcileNormal(
 		return syncErr
 	}
 
+	if sigErr != nil {
+		logger.Error(sigErr, "Failed to verify image signature")
+		return sigErr
+	}
+
 	logger.Info(
 		"Successfully reconciled library item",
 		"contentVersion", savedStatus.ProviderContentVersion)
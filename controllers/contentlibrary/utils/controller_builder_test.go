@@ -6,8 +6,16 @@ package utils_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -446,6 +454,189 @@ var _ = Describe("Reconcile",
 					})
 				})
 
+				When("image signature verification is enabled", func() {
+					var (
+						trustRoots *corev1.ConfigMap
+						itemFiles  map[string][]byte
+						itemDisks  map[string][]byte
+						filesErr   error
+						filesCalls int
+					)
+
+					BeforeEach(func() {
+						pub, priv, err := ed25519.GenerateKey(rand.Reader)
+						Expect(err).ToNot(HaveOccurred())
+						der, err := x509.MarshalPKIXPublicKey(pub)
+						Expect(err).ToNot(HaveOccurred())
+
+						trustRoots = &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "image-trust-roots",
+								Namespace: "vmop-system",
+							},
+							Data: map[string]string{
+								"key.pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+							},
+						}
+						Expect(ctx.Client.Create(ctx, trustRoots)).To(Succeed())
+
+						itemDisks = map[string][]byte{
+							"photon-disk1.vmdk": []byte("disk contents"),
+						}
+						diskDigest := sha256.Sum256(itemDisks["photon-disk1.vmdk"])
+						manifest := fmt.Appendf(nil, "SHA256(photon-disk1.vmdk)= %s\n", hex.EncodeToString(diskDigest[:]))
+						itemFiles = map[string][]byte{
+							"photon.mf":     manifest,
+							"photon.mf.sig": []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, manifest))),
+						}
+						filesErr = nil
+						filesCalls = 0
+
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.PodNamespace = trustRoots.Namespace
+							config.ImageTrustRootsName = trustRoots.Name
+						})
+
+						fakeVMProvider.GetContentLibraryItemFilesFn = func(
+							_ context.Context,
+							itemID string,
+							match func(string) bool) (map[string][]byte, error) {
+
+							filesCalls++
+							Expect(itemID).To(BeEquivalentTo(cliSpec.UUID))
+							Expect(match("photon.mf")).To(BeTrue())
+							return itemFiles, filesErr
+						}
+						fakeVMProvider.ListContentLibraryItemFilesFn = func(
+							_ context.Context,
+							itemID string) ([]string, error) {
+
+							Expect(itemID).To(BeEquivalentTo(cliSpec.UUID))
+							var names []string
+							for name := range itemFiles {
+								names = append(names, name)
+							}
+							for name := range itemDisks {
+								names = append(names, name)
+							}
+							return names, nil
+						}
+						fakeVMProvider.CopyContentLibraryItemFileFn = func(
+							_ context.Context,
+							itemID, name string,
+							w io.Writer) error {
+
+							Expect(itemID).To(BeEquivalentTo(cliSpec.UUID))
+							_, err := w.Write(itemDisks[name])
+							return err
+						}
+					})
+
+					It("should mark the image as verified when the item is signed with a trusted key", func() {
+						_, err := reconciler.Reconcile(context.Background(), req)
+						Expect(err).ToNot(HaveOccurred())
+
+						vmiObj, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+						c := pkgcnd.Get(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)
+						Expect(c).ToNot(BeNil())
+						Expect(c.Status).To(Equal(metav1.ConditionTrue))
+						Expect(c.Reason).To(Equal("Signature"))
+						Expect(c.Message).To(HavePrefix("SHA256:"))
+						Expect(vmiObj.GetAnnotations()).To(HaveKey(utils.SignatureVerifiedForAnnotationKey))
+						Expect(pkgcnd.IsTrue(vmiStatus, vmopv1.ReadyConditionType)).To(BeTrue())
+
+						By("not verifying the item again until the trust roots change", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(filesCalls).To(Equal(1))
+
+							trustRoots.Data["other.pem"] = ""
+							Expect(ctx.Client.Update(ctx, trustRoots)).To(Succeed())
+							_, err = reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(filesCalls).To(Equal(2))
+						})
+					})
+
+					When("the item is not signed", func() {
+						BeforeEach(func() {
+							delete(itemFiles, "photon.mf.sig")
+						})
+
+						It("should mark the image as not verified", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							c := pkgcnd.Get(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)
+							Expect(c).ToNot(BeNil())
+							Expect(c.Status).To(Equal(metav1.ConditionFalse))
+							Expect(c.Reason).To(Equal(vmopv1.VirtualMachineImageSignatureNotFoundReason))
+						})
+					})
+
+					When("the item is signed with an untrusted key", func() {
+						BeforeEach(func() {
+							trustRoots.Data = nil
+							Expect(ctx.Client.Update(ctx, trustRoots)).To(Succeed())
+						})
+
+						It("should mark the image as not verified", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							Expect(pkgcnd.GetReason(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)).
+								To(Equal(vmopv1.VirtualMachineImageSignatureUntrustedReason))
+						})
+					})
+
+					When("a disk of the item does not match the signed manifest", func() {
+						BeforeEach(func() {
+							itemDisks["photon-disk1.vmdk"] = []byte("tampered")
+						})
+
+						It("should mark the image as not verified", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							Expect(pkgcnd.GetReason(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)).
+								To(Equal(vmopv1.VirtualMachineImageSignatureInvalidReason))
+						})
+					})
+
+					When("the item has a disk that is not in the signed manifest", func() {
+						BeforeEach(func() {
+							itemDisks["photon-disk2.vmdk"] = []byte("unsigned")
+						})
+
+						It("should mark the image as not verified", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							Expect(pkgcnd.GetReason(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)).
+								To(Equal(vmopv1.VirtualMachineImageSignatureInvalidReason))
+						})
+					})
+
+					When("the item's files cannot be retrieved", func() {
+						BeforeEach(func() {
+							filesErr = errors.New("fake files error")
+						})
+
+						It("should return the error", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).To(MatchError("fake files error"))
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							Expect(pkgcnd.GetReason(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)).
+								To(Equal(vmopv1.VirtualMachineImageSignatureVerificationFailedReason))
+						})
+					})
+				})
+
 				When("Image resource is created and already up-to-date and Status.Disk is not empty", func() {
 
 					JustBeforeEach(func() {
@@ -33,6 +33,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	imgutil "github.com/vmware-tanzu/vm-operator/pkg/util/image"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
@@ -81,7 +82,20 @@ func AddToManagerV1A2(
 			))
 	}
 
-	return builder.Complete(r)
+	if pkgcfg.FromContext(ctx).ImageTrustRootsName != "" {
+		var err error
+		builder, r.TrustRoots, err = watchTrustRoots(ctx, mgr, builder, func() client.ObjectList {
+			if controlledItemTypeName == ContentLibraryItemKind {
+				return &imgregv1.ContentLibraryItemList{}
+			}
+			return &imgregv1.ClusterContentLibraryItemList{}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return builder.Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconcilerV1A2(
@@ -100,6 +114,7 @@ func NewReconcilerV1A2(
 		VMProvider: vmProvider,
 		Metrics:    metrics.NewContentLibraryItemMetrics(),
 		Kind:       kind,
+		TrustRoots: client,
 	}
 }
 
@@ -113,6 +128,10 @@ type ReconcilerV1A2 struct {
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.ContentLibraryItemMetrics
 	Kind       string
+
+	// TrustRoots reads the ConfigMap and Secret that contain the trust roots
+	// used to verify the signatures of library items.
+	TrustRoots client.Reader
 }
 
 func (r *ReconcilerV1A2) Reconcile(
@@ -262,6 +281,7 @@ func (r *ReconcilerV1A2) ReconcileNormal(
 	var (
 		didSync     bool
 		syncErr     error
+		sigErr      error
 		savedStatus *vmopv1.VirtualMachineImageStatus
 	)
 
@@ -331,7 +351,16 @@ func (r *ReconcilerV1A2) ReconcileNormal(
 
 			didSync = true
 
-			// Do not return syncErr here as we still want to patch the updated
+			sigErr = reconcileSignature(
+				ctx,
+				r.TrustRoots,
+				r.VMProvider,
+				cliSpec.ID,
+				cliStatus.ContentVersion,
+				vmiObj,
+				vmiStatus)
+
+			// Do not return syncErr or sigErr here as we still want to patch the updated
 			// fields we get above.
 			return nil
 		})
@@ -372,6 +401,11 @@ func (r *ReconcilerV1A2) ReconcileNormal(
 		return syncErr
 	}
 
+	if sigErr != nil {
+		logger.Error(sigErr, "Failed to verify image signature")
+		return sigErr
+	}
+
 	logger.Info(
 		"Successfully reconciled library item",
 		"contentVersion", savedStatus.ProviderContentVersion)
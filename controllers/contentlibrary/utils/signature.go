@@ -0,0 +1,274 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/imagesignature"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+)
+
+// trustRootsReader reads ConfigMaps and Secrets from separate readers, since
+// each is served by its own namespaced cache.
+type trustRootsReader struct {
+	configMaps client.Reader
+	secrets    client.Reader
+}
+
+func (r trustRootsReader) Get(
+	ctx context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	opts ...client.GetOption) error {
+
+	if _, ok := obj.(*corev1.Secret); ok {
+		return r.secrets.Get(ctx, key, obj, opts...)
+	}
+	return r.configMaps.Get(ctx, key, obj, opts...)
+}
+
+func (r trustRootsReader) List(
+	ctx context.Context,
+	list client.ObjectList,
+	opts ...client.ListOption) error {
+
+	if _, ok := list.(*corev1.SecretList); ok {
+		return r.secrets.List(ctx, list, opts...)
+	}
+	return r.configMaps.List(ctx, list, opts...)
+}
+
+// watchTrustRoots watches the ConfigMap and Secret that contain the image
+// trust roots so every item of the controlled type is reconciled when they
+// change. The returned reader reads the trust roots from the watches' caches.
+func watchTrustRoots(
+	ctx *pkgctx.ControllerManagerContext,
+	mgr manager.Manager,
+	b *builder.Builder,
+	newItemList func() client.ObjectList) (*builder.Builder, client.Reader, error) {
+
+	var (
+		cfg    = pkgcfg.FromContext(ctx)
+		reader trustRootsReader
+	)
+
+	// Enqueue every item when the trust roots change so the items are
+	// verified against the new trust roots.
+	mapFn := func(ctx context.Context, _ client.Object) []reconcile.Request {
+		list := newItemList()
+		if err := mgr.GetClient().List(ctx, list); err != nil {
+			pkglog.FromContextOrDefault(ctx).Error(err, "Failed to list library items for trust roots")
+			return nil
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for i := range items {
+			if obj, ok := items[i].(client.Object); ok {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(obj),
+				})
+			}
+		}
+		return requests
+	}
+
+	isTrustRoots := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == cfg.ImageTrustRootsName
+	})
+
+	for _, obj := range []client.Object{&corev1.ConfigMap{}, &corev1.Secret{}} {
+		cache, err := pkgmgr.NewNamespacedCacheForObject(
+			mgr,
+			&ctx.SyncPeriod,
+			obj,
+			cfg.PodNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := obj.(*corev1.Secret); ok {
+			reader.secrets = cache
+		} else {
+			reader.configMaps = cache
+		}
+
+		b = b.WatchesRawSource(source.Kind(
+			cache,
+			obj,
+			handler.EnqueueRequestsFromMapFunc(mapFn),
+			isTrustRoots))
+	}
+
+	return b, reader, nil
+}
+
+// getTrustRoots returns the trust roots from the ConfigMap and Secret with
+// the configured name in the pod's namespace. Either may be absent.
+func getTrustRoots(ctx context.Context, reader client.Reader) (imagesignature.TrustRoots, error) {
+	var (
+		cfg  = pkgcfg.FromContext(ctx)
+		key  = client.ObjectKey{Namespace: cfg.PodNamespace, Name: cfg.ImageTrustRootsName}
+		data = map[string][]byte{}
+	)
+
+	var cm corev1.ConfigMap
+	if err := reader.Get(ctx, key, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return imagesignature.TrustRoots{}, fmt.Errorf("failed to get trust roots ConfigMap: %w", err)
+		}
+	}
+	for k, v := range cm.Data {
+		data["configmap/"+k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		data["configmap/"+k] = v
+	}
+
+	var secret corev1.Secret
+	if err := reader.Get(ctx, key, &secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return imagesignature.TrustRoots{}, fmt.Errorf("failed to get trust roots Secret: %w", err)
+		}
+	}
+	for k, v := range secret.Data {
+		data["secret/"+k] = v
+	}
+
+	return imagesignature.ParseTrustRoots(data)
+}
+
+// reconcileSignature verifies the signature of the library item and records
+// the result in the image's VirtualMachineImageSignatureVerified condition.
+// The item is only verified again once its content version or the trust roots
+// change. The condition is removed when signature verification is disabled.
+func reconcileSignature(
+	ctx context.Context,
+	reader client.Reader,
+	vmProvider providers.VirtualMachineProviderInterface,
+	itemID string,
+	contentVersion string,
+	vmiObj client.Object,
+	vmiStatus *vmopv1.VirtualMachineImageStatus) error {
+
+	annotations := vmiObj.GetAnnotations()
+
+	if pkgcfg.FromContext(ctx).ImageTrustRootsName == "" {
+		pkgcnd.Delete(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition)
+		if _, ok := annotations[SignatureVerifiedForAnnotationKey]; ok {
+			delete(annotations, SignatureVerifiedForAnnotationKey)
+			vmiObj.SetAnnotations(annotations)
+		}
+		return nil
+	}
+
+	roots, err := getTrustRoots(ctx, reader)
+	if err != nil {
+		pkgcnd.MarkError(
+			vmiStatus,
+			vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+			vmopv1.VirtualMachineImageSignatureVerificationFailedReason,
+			err)
+		return err
+	}
+
+	verifiedFor := contentVersion + ":" + roots.Digest
+	if annotations[SignatureVerifiedForAnnotationKey] == verifiedFor &&
+		pkgcnd.Has(vmiStatus, vmopv1.VirtualMachineImageSignatureVerifiedCondition) {
+
+		return nil
+	}
+
+	names, err := vmProvider.ListContentLibraryItemFiles(ctx, itemID)
+	if err != nil {
+		pkgcnd.MarkError(
+			vmiStatus,
+			vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+			vmopv1.VirtualMachineImageSignatureVerificationFailedReason,
+			err)
+		return err
+	}
+
+	files, err := vmProvider.GetContentLibraryItemFiles(ctx, itemID, imagesignature.IsSignatureFile)
+	if err != nil {
+		pkgcnd.MarkError(
+			vmiStatus,
+			vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+			vmopv1.VirtualMachineImageSignatureVerificationFailedReason,
+			err)
+		return err
+	}
+
+	// The disks are only read to verify their digests once the manifest's
+	// signature is verified.
+	result, err := imagesignature.Verify(imagesignature.Item{
+		Names: names,
+		Files: files,
+		Copy: func(name string, w io.Writer) error {
+			return vmProvider.CopyContentLibraryItemFile(ctx, itemID, name, w)
+		},
+	}, roots)
+	if err == nil {
+		pkgcnd.Set(vmiStatus, &metav1.Condition{
+			Type:    vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(result.Method),
+			Message: result.Signer,
+		})
+	} else {
+		var reason string
+		switch {
+		case errors.Is(err, imagesignature.ErrNotSigned):
+			reason = vmopv1.VirtualMachineImageSignatureNotFoundReason
+		case errors.Is(err, imagesignature.ErrUntrusted):
+			reason = vmopv1.VirtualMachineImageSignatureUntrustedReason
+		case errors.Is(err, imagesignature.ErrInvalid):
+			reason = vmopv1.VirtualMachineImageSignatureInvalidReason
+		default:
+			pkgcnd.MarkError(
+				vmiStatus,
+				vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+				vmopv1.VirtualMachineImageSignatureVerificationFailedReason,
+				err)
+			return err
+		}
+		pkgcnd.MarkError(
+			vmiStatus,
+			vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+			reason,
+			err)
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[SignatureVerifiedForAnnotationKey] = verifiedFor
+	vmiObj.SetAnnotations(annotations)
+
+	return nil
+}
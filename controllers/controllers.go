@@ -13,11 +13,18 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/infra"
 	"github.com/vmware-tanzu/vm-operator/controllers/storageclass"
 	spq "github.com/vmware-tanzu/vm-operator/controllers/storagepolicyquota"
+	"github.com/vmware-tanzu/vm-operator/controllers/storageversionmigration"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinegroup"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinegrouppublishrequest"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestoperation"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagecache"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageimport"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagestream"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinenetworkpolicy"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineplacementrequest"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepowerschedule"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice"
@@ -55,6 +62,24 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	if err := virtualmachinewebconsolerequest.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachineWebConsoleRequest controller: %w", err)
 	}
+	if err := virtualmachineplacementrequest.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachinePlacementRequest controller: %w", err)
+	}
+	if err := virtualmachineguestoperation.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineGuestOperation controller: %w", err)
+	}
+	if err := virtualmachineimageimport.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageImport controller: %w", err)
+	}
+	if err := virtualmachineimagestream.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageStream controller: %w", err)
+	}
+	if err := virtualmachinenetworkpolicy.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineNetworkPolicy controller: %w", err)
+	}
+	if err := virtualmachinepowerschedule.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachinePowerSchedule controller: %w", err)
+	}
 	if err := virtualmachinepublishrequest.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachinePublishRequest controller: %w", err)
 	}
@@ -92,6 +117,12 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		}
 	}
 
+	if pkgcfg.FromContext(ctx).StorageVersionMigrationEnabled {
+		if err := storageversionmigration.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize StorageVersionMigration controller: %w", err)
+		}
+	}
+
 	if pkgcfg.FromContext(ctx).Features.VSpherePolicies {
 		if err := vspherepolicy.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize vSphere Policy controllers: %w", err)
@@ -27,6 +27,7 @@ import (
 	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 )
 
@@ -59,7 +60,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	// This controller is also run on the non-leaders (webhooks) pods too
 	// so capabilities updates are reflected there.
 	c, err := controller.New(controllerName, mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              pkgtracing.Reconciler(controllerNameShort, r),
 		MaxConcurrentReconciles: 1,
 		LogConstructor:          pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
 	})
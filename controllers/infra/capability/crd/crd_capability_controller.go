@@ -23,6 +23,7 @@ import (
 	pkgexit "github.com/vmware-tanzu/vm-operator/pkg/exit"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 )
 
 // AddToManager adds this package's controller to the provided manager.
@@ -59,7 +60,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			},
 		}).
 		WithEventFilter(predicate.ResourceVersionChangedPredicate{}).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconciler(
@@ -25,6 +25,7 @@ import (
 	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 )
 
@@ -47,7 +48,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	)
 
 	c, err := controller.New(controllerName, mgr, controller.Options{
-		Reconciler:     r,
+		Reconciler:     pkgtracing.Reconciler(controllerNameShort, r),
 		LogConstructor: pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
 	})
 	if err != nil {
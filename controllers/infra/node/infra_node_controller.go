@@ -21,6 +21,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 )
 
 type provider interface {
@@ -68,7 +69,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			},
 		).
 		WithLogConstructor(pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme())).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconciler(
@@ -25,6 +25,8 @@ import (
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/watcher"
 )
 
@@ -57,7 +59,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			SkipNameValidation: SkipNameValidation,
 			LogConstructor:     pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
 		}).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconciler(
@@ -125,6 +127,16 @@ func (r *Reconciler) ReconcileDelete(
 	ctx context.Context,
 	obj *topologyv1.Zone) (ctrl.Result, error) {
 
+	// The finalizer is not removed until the VMs that can be recreated in
+	// another zone have been drained from this one.
+	drained, err := r.reconcileDrain(ctx, obj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !drained {
+		return ctrl.Result{RequeueAfter: DrainRequeueAfter}, nil
+	}
+
 	if val := obj.Spec.ManagedVMs.FolderMoID; val != "" {
 		if err := watcher.Remove(
 			ctx,
@@ -172,5 +184,17 @@ func (r *Reconciler) ReconcileNormal(
 		}
 	}
 
+	if !topology.IsZoneDraining(*obj) {
+		return ctrl.Result{}, r.reconcileUndrain(ctx, obj)
+	}
+
+	drained, err := r.reconcileDrain(ctx, obj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !drained {
+		return ctrl.Result{RequeueAfter: DrainRequeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
@@ -0,0 +1,305 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package zone
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	// DrainRequeueAfter is how long to wait before checking on the progress
+	// of a zone drain.
+	DrainRequeueAfter = 10 * time.Second
+
+	// drainingReason is the reason of the event emitted on a VM when its zone
+	// starts draining.
+	drainingReason = "ZoneDraining"
+
+	// evictedReason is the reason of the events emitted when a VM is deleted
+	// so it is recreated in another zone.
+	evictedReason = "ZoneEvicted"
+
+	replicaSetKind = "VirtualMachineReplicaSet"
+)
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=get;list;watch
+
+// drainedVM is a VM in a draining zone that is owned by a
+// VirtualMachineReplicaSet that can recreate it in another zone.
+type drainedVM struct {
+	vm *vmopv1.VirtualMachine
+	rs *vmopv1.VirtualMachineReplicaSet
+}
+
+// reconcileDrain drains the VMs from a zone that is being deleted or is
+// cordoned. Every VM in the zone is marked with the ZoneDraining condition.
+// The VMs owned by a VirtualMachineReplicaSet are deleted one at a time, once
+// all the replicas of their replica set are available, so they are recreated
+// in the remaining zones. The other VMs must be moved by their owners.
+//
+// Returns true once there are no VMs left in the zone that may be drained.
+func (r *Reconciler) reconcileDrain(
+	ctx context.Context,
+	obj *topologyv1.Zone) (bool, error) {
+
+	logger := pkglog.FromContextOrDefault(ctx)
+
+	reason := vmopv1.VirtualMachineZoneCordonedReason
+	if !obj.DeletionTimestamp.IsZero() {
+		reason = vmopv1.VirtualMachineZoneDeletingReason
+	}
+
+	var vms vmopv1.VirtualMachineList
+	if err := r.List(
+		ctx,
+		&vms,
+		client.InNamespace(obj.Namespace),
+		client.MatchingLabels{corev1.LabelTopologyZone: obj.Name}); err != nil {
+
+		return false, fmt.Errorf("failed to list VMs in zone %s: %w", obj.Name, err)
+	}
+
+	var (
+		drainable   []drainedVM
+		inProgress  bool
+		replicaSets = map[string]*vmopv1.VirtualMachineReplicaSet{}
+	)
+
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+
+		rs, err := r.getReplicaSet(ctx, vm, replicaSets)
+		if err != nil {
+			return false, err
+		}
+
+		if !vm.DeletionTimestamp.IsZero() {
+			if rs != nil {
+				inProgress = true
+			}
+			continue
+		}
+
+		var msg string
+		switch {
+		case rs == nil:
+			msg = fmt.Sprintf(
+				"Zone %s is being drained. The VM must be recreated in another zone.",
+				obj.Name)
+		case rs.Spec.Template.Labels[corev1.LabelTopologyZone] == obj.Name:
+			msg = fmt.Sprintf(
+				"Zone %s is being drained. The template of VirtualMachineReplicaSet %s "+
+					"requires the zone, so the VM cannot be recreated in another zone.",
+				obj.Name, rs.Name)
+		default:
+			msg = fmt.Sprintf(
+				"Zone %s is being drained. The VM will be recreated in another zone "+
+					"by VirtualMachineReplicaSet %s.",
+				obj.Name, rs.Name)
+			drainable = append(drainable, drainedVM{vm: vm, rs: rs})
+		}
+
+		if err := r.markZoneDraining(ctx, vm, reason, msg); err != nil {
+			return false, err
+		}
+	}
+
+	if inProgress {
+		logger.V(4).Info("Waiting for the deletion of a drained VM")
+		return false, nil
+	}
+	if len(drainable) == 0 {
+		return true, nil
+	}
+
+	// The next VM is only deleted once all the replicas of the affected
+	// replica sets are available, which includes the replacement of the
+	// previously deleted VM.
+	for _, rs := range replicaSets {
+		if rs == nil {
+			continue
+		}
+		ok, err := r.isReplicaSetAvailable(ctx, rs)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			logger.V(4).Info("Waiting for VirtualMachineReplicaSet to be available",
+				"replicaSet", rs.Name)
+			return false, nil
+		}
+	}
+
+	next := drainable[0]
+	if err := r.Delete(ctx, next.vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to delete VM %s: %w", next.vm.Name, err)
+	}
+
+	r.Recorder.Eventf(obj, evictedReason,
+		"Deleted VM %s so VirtualMachineReplicaSet %s recreates it in another zone",
+		next.vm.Name, next.rs.Name)
+	r.Recorder.Eventf(next.vm, evictedReason,
+		"Deleted so VirtualMachineReplicaSet %s recreates the VM outside of zone %s",
+		next.rs.Name, obj.Name)
+
+	return false, nil
+}
+
+// reconcileUndrain removes the ZoneDraining condition from the VMs in a zone
+// that is no longer cordoned.
+func (r *Reconciler) reconcileUndrain(
+	ctx context.Context,
+	obj *topologyv1.Zone) error {
+
+	var vms vmopv1.VirtualMachineList
+	if err := r.List(
+		ctx,
+		&vms,
+		client.InNamespace(obj.Namespace),
+		client.MatchingLabels{corev1.LabelTopologyZone: obj.Name}); err != nil {
+
+		return fmt.Errorf("failed to list VMs in zone %s: %w", obj.Name, err)
+	}
+
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		if conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition) == nil {
+			continue
+		}
+		patchHelper, err := patch.NewHelper(vm, r.Client)
+		if err != nil {
+			return err
+		}
+		conditions.Delete(vm, vmopv1.VirtualMachineZoneDrainingCondition)
+		if err := patchHelper.Patch(ctx, vm); err != nil {
+			return fmt.Errorf("failed to patch VM %s: %w", vm.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// markZoneDraining sets the ZoneDraining condition on the VM, and emits an
+// event when the condition is added or changed.
+func (r *Reconciler) markZoneDraining(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	reason, msg string) error {
+
+	if c := conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition); c != nil &&
+		c.Status == metav1.ConditionTrue && c.Reason == reason && c.Message == msg {
+
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, r.Client)
+	if err != nil {
+		return err
+	}
+	conditions.Set(vm, &metav1.Condition{
+		Type:    vmopv1.VirtualMachineZoneDrainingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	if err := patchHelper.Patch(ctx, vm); err != nil {
+		return fmt.Errorf("failed to patch VM %s: %w", vm.Name, err)
+	}
+
+	r.Recorder.Warn(vm, drainingReason, msg)
+
+	return nil
+}
+
+// getReplicaSet returns the VirtualMachineReplicaSet that controls the VM, or
+// nil if the VM is not controlled by a replica set. The replica sets are
+// cached by name.
+func (r *Reconciler) getReplicaSet(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	cache map[string]*vmopv1.VirtualMachineReplicaSet) (*vmopv1.VirtualMachineReplicaSet, error) {
+
+	ref := metav1.GetControllerOfNoCopy(vm)
+	if ref == nil || ref.Kind != replicaSetKind {
+		return nil, nil
+	}
+	if rs, ok := cache[ref.Name]; ok {
+		return rs, nil
+	}
+
+	rs := &vmopv1.VirtualMachineReplicaSet{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: ref.Name}, rs); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get VirtualMachineReplicaSet %s: %w", ref.Name, err)
+		}
+		// The VM is orphaned and will be garbage collected.
+		rs = nil
+	} else if rs.UID != ref.UID {
+		rs = nil
+	}
+
+	cache[ref.Name] = rs
+	return rs, nil
+}
+
+// isReplicaSetAvailable returns true if the replica set has its desired number
+// of replicas, and all of them are available.
+func (r *Reconciler) isReplicaSetAvailable(
+	ctx context.Context,
+	rs *vmopv1.VirtualMachineReplicaSet) (bool, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector for VirtualMachineReplicaSet %s: %w", rs.Name, err)
+	}
+
+	var vms vmopv1.VirtualMachineList
+	if err := r.List(
+		ctx,
+		&vms,
+		client.InNamespace(rs.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+
+		return false, fmt.Errorf("failed to list VMs of VirtualMachineReplicaSet %s: %w", rs.Name, err)
+	}
+
+	var available int32
+	for i := range vms.Items {
+		vm := vms.Items[i]
+		if ref := metav1.GetControllerOfNoCopy(&vm); ref == nil || ref.UID != rs.UID {
+			continue
+		}
+		if !vm.DeletionTimestamp.IsZero() || !vmopv1util.IsVirtualMachineAvailable(vm) {
+			return false, nil
+		}
+		available++
+	}
+
+	replicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+
+	return available >= replicas, nil
+}
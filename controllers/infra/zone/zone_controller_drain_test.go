@@ -0,0 +1,301 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package zone_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/infra/zone"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe(
+	"Drain",
+	Label(
+		testlabels.Controller,
+	),
+	func() {
+		const (
+			ns       = "my-namespace"
+			zoneName = "zone-a"
+		)
+
+		var (
+			ctx         *builder.UnitTestContext
+			reconciler  *zone.Reconciler
+			events      chan string
+			initObjects []ctrlclient.Object
+			obj         *topologyv1.Zone
+			rs          *vmopv1.VirtualMachineReplicaSet
+		)
+
+		newVM := func(name, zoneName string, owner *vmopv1.VirtualMachineReplicaSet) *vmopv1.VirtualMachine {
+			vm := &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns,
+					Labels: map[string]string{
+						"app":                    "db",
+						corev1.LabelTopologyZone: zoneName,
+					},
+				},
+			}
+			if owner != nil {
+				vm.OwnerReferences = []metav1.OwnerReference{
+					{
+						APIVersion: vmopv1.GroupVersion.String(),
+						Kind:       "VirtualMachineReplicaSet",
+						Name:       owner.Name,
+						UID:        owner.UID,
+						Controller: ptr.To(true),
+					},
+				}
+			}
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			return vm
+		}
+
+		getVM := func(name string) (*vmopv1.VirtualMachine, error) {
+			vm := &vmopv1.VirtualMachine{}
+			err := ctx.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: ns, Name: name}, vm)
+			return vm, err
+		}
+
+		BeforeEach(func() {
+			obj = &topologyv1.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       zoneName,
+					Namespace:  ns,
+					Finalizers: []string{zone.Finalizer},
+				},
+			}
+			rs = &vmopv1.VirtualMachineReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-rs",
+					Namespace: ns,
+					UID:       types.UID("my-rs-uid"),
+				},
+				Spec: vmopv1.VirtualMachineReplicaSetSpec{
+					Replicas: ptr.To[int32](2),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "db"},
+					},
+				},
+			}
+			initObjects = []ctrlclient.Object{rs}
+		})
+
+		JustBeforeEach(func() {
+			ctx = builder.NewUnitTestContext(initObjects...)
+
+			var recorder record.Recorder
+			recorder, events = builder.NewFakeRecorder()
+			reconciler = zone.NewReconciler(ctx, ctx.Client, logr.Discard(), recorder)
+		})
+
+		AfterEach(func() {
+			ctx = nil
+			initObjects = nil
+		})
+
+		reconcileDelete := func() ctrl.Result {
+			obj.DeletionTimestamp = ptr.To(metav1.Now())
+			result, err := reconciler.ReconcileDelete(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+			return result
+		}
+
+		Context("ReconcileDelete", func() {
+			When("there are no VMs in the zone", func() {
+				It("removes the finalizer", func() {
+					Expect(reconcileDelete().RequeueAfter).To(BeZero())
+					Expect(obj.Finalizers).To(BeEmpty())
+				})
+			})
+
+			When("there is a standalone VM in the zone", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, newVM("vm-0", zoneName, nil))
+				})
+
+				It("marks the VM and removes the finalizer", func() {
+					Expect(reconcileDelete().RequeueAfter).To(BeZero())
+					Expect(obj.Finalizers).To(BeEmpty())
+
+					vm, err := getVM("vm-0")
+					Expect(err).ToNot(HaveOccurred())
+					c := conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionTrue))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineZoneDeletingReason))
+					Expect(c.Message).To(ContainSubstring("must be recreated in another zone"))
+					Expect(events).To(Receive(ContainSubstring("ZoneDraining")))
+				})
+
+				It("emits the event once", func() {
+					reconcileDelete()
+					Expect(events).To(Receive(ContainSubstring("ZoneDraining")))
+					reconcileDelete()
+					Expect(events).ToNot(Receive())
+				})
+			})
+
+			When("there are replica-managed VMs in the zone", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects,
+						newVM("vm-0", zoneName, rs),
+						newVM("vm-1", "zone-b", rs))
+				})
+
+				It("deletes the VM and keeps the finalizer", func() {
+					Expect(reconcileDelete().RequeueAfter).To(Equal(zone.DrainRequeueAfter))
+					Expect(obj.Finalizers).To(ConsistOf(zone.Finalizer))
+
+					_, err := getVM("vm-0")
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					_, err = getVM("vm-1")
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(events).To(Receive(ContainSubstring("ZoneDraining")))
+					Expect(events).To(Receive(ContainSubstring("ZoneEvicted")))
+					Expect(events).To(Receive(ContainSubstring("ZoneEvicted")))
+				})
+
+				When("another replica is not available", func() {
+					BeforeEach(func() {
+						vm := newVM("vm-2", "zone-b", rs)
+						vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+						initObjects = append(initObjects, vm)
+						rs.Spec.Replicas = ptr.To[int32](3)
+					})
+
+					It("waits to delete the VM", func() {
+						Expect(reconcileDelete().RequeueAfter).To(Equal(zone.DrainRequeueAfter))
+						Expect(obj.Finalizers).To(ConsistOf(zone.Finalizer))
+
+						vm, err := getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineZoneDrainingCondition)).To(BeTrue())
+					})
+				})
+
+				When("the replica set does not have all its replicas", func() {
+					BeforeEach(func() {
+						rs.Spec.Replicas = ptr.To[int32](3)
+					})
+
+					It("waits to delete the VM", func() {
+						Expect(reconcileDelete().RequeueAfter).To(Equal(zone.DrainRequeueAfter))
+						_, err := getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
+
+				When("a drained VM is still being deleted", func() {
+					BeforeEach(func() {
+						vm := newVM("vm-2", zoneName, rs)
+						vm.Finalizers = []string{"test"}
+						vm.DeletionTimestamp = ptr.To(metav1.Now())
+						initObjects = append(initObjects, vm)
+					})
+
+					It("waits to delete the next VM", func() {
+						Expect(reconcileDelete().RequeueAfter).To(Equal(zone.DrainRequeueAfter))
+						_, err := getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
+
+				When("the replica set template requires the zone", func() {
+					BeforeEach(func() {
+						rs.Spec.Template.Labels = map[string]string{
+							corev1.LabelTopologyZone: zoneName,
+						}
+					})
+
+					It("marks the VM and removes the finalizer", func() {
+						Expect(reconcileDelete().RequeueAfter).To(BeZero())
+						Expect(obj.Finalizers).To(BeEmpty())
+
+						vm, err := getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+						c := conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition)
+						Expect(c).ToNot(BeNil())
+						Expect(c.Message).To(ContainSubstring("cannot be recreated in another zone"))
+					})
+				})
+			})
+		})
+
+		Context("ReconcileNormal", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects,
+					newVM("vm-0", zoneName, rs),
+					newVM("vm-1", "zone-b", rs))
+			})
+
+			reconcileNormal := func() ctrl.Result {
+				result, err := reconciler.ReconcileNormal(ctx, obj)
+				Expect(err).ToNot(HaveOccurred())
+				return result
+			}
+
+			It("does not drain the zone", func() {
+				Expect(reconcileNormal().RequeueAfter).To(BeZero())
+				vm, err := getVM("vm-0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition)).To(BeNil())
+			})
+
+			When("the zone is cordoned", func() {
+				BeforeEach(func() {
+					obj.Annotations = map[string]string{topology.ZoneCordonedAnnotation: ""}
+				})
+
+				It("drains the zone", func() {
+					Expect(reconcileNormal().RequeueAfter).To(Equal(zone.DrainRequeueAfter))
+					_, err := getVM("vm-0")
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+
+				When("the zone is uncordoned", func() {
+					BeforeEach(func() {
+						rs.Spec.Replicas = ptr.To[int32](3)
+					})
+
+					It("removes the condition from the VMs", func() {
+						reconcileNormal()
+						vm, err := getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+						c := conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition)
+						Expect(c).ToNot(BeNil())
+						Expect(c.Reason).To(Equal(vmopv1.VirtualMachineZoneCordonedReason))
+
+						obj.Annotations = nil
+						Expect(reconcileNormal().RequeueAfter).To(BeZero())
+						vm, err = getVM("vm-0")
+						Expect(err).ToNot(HaveOccurred())
+						Expect(conditions.Get(vm, vmopv1.VirtualMachineZoneDrainingCondition)).To(BeNil())
+					})
+				})
+			})
+		})
+	})
@@ -0,0 +1,508 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package storageversionmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/metrics"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+const (
+	// listPageSize is the maximum number of objects read from the API server
+	// in a single list call while migrating a resource.
+	listPageSize = 250
+
+	// ReasonMigrationSucceeded is the reason of the event emitted on a CRD
+	// once all of its objects have been rewritten at the storage version.
+	ReasonMigrationSucceeded = "StorageVersionMigrationSucceeded"
+
+	// ReasonMigrationFailed is the reason of the event emitted on a CRD when
+	// one or more of its objects could not be rewritten.
+	ReasonMigrationFailed = "StorageVersionMigrationFailed"
+
+	// ReasonConversionFailed is the reason of the event emitted on an object
+	// that could not be rewritten at the storage version.
+	ReasonConversionFailed = "ConversionFailed"
+
+	// ReasonConversionLossy is the reason of the event emitted on an object
+	// that cannot be converted to one of the previously stored versions and
+	// back without losing data.
+	ReasonConversionLossy = "ConversionLossy"
+)
+
+// SkipNameValidation is used for testing to allow multiple controllers with the
+// same name since Controller-Runtime has a global singleton registry to
+// prevent controllers with the same name, even if attached to different
+// managers.
+var SkipNameValidation *bool
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType      = &apiextensionsv1.CustomResourceDefinition{}
+		controllerName      = "storageversionmigration"
+		controllerNameShort = fmt.Sprintf("%s-controller", controllerName)
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		ctrl.Log.WithName("controllers").WithName(controllerName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+			SkipNameValidation:      SkipNameValidation,
+			LogConstructor:          pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
+		}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(o ctrlclient.Object) bool {
+			return strings.HasSuffix(o.GetName(), "."+vmopv1.GroupName)
+		})).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client ctrlclient.Client,
+	scheme *runtime.Scheme,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Scheme:   scheme,
+		Logger:   logger,
+		Recorder: recorder,
+		Metrics:  metrics.NewStorageVersionMigrationMetrics(),
+	}
+}
+
+// Reconciler rewrites the objects of the VM Operator CRDs at their current
+// storage version and then prunes the CRD's status.storedVersions so the
+// older API versions may eventually be retired.
+type Reconciler struct {
+	Context  context.Context
+	Client   ctrlclient.Client
+	Scheme   *runtime.Scheme
+	Logger   logr.Logger
+	Recorder record.Recorder
+	Metrics  *metrics.StorageVersionMigrationMetrics
+}
+
+// Result describes the outcome of migrating the objects for a single CRD.
+type Result struct {
+	// Total is the number of objects that were processed.
+	Total int
+
+	// Expected is the estimated number of objects to process, as reported
+	// by the API server when the objects were listed.
+	Expected int
+
+	// Migrated is the number of objects rewritten at the storage version.
+	Migrated int
+
+	// Failed is the number of objects that could not be rewritten.
+	Failed int
+
+	// Lossy is the number of objects that cannot round-trip through one of
+	// the previously stored versions without losing data.
+	Lossy int
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages;virtualmachineclasses;virtualmachineclassinstances;virtualmachinegroups;virtualmachineimagecaches;virtualmachineimages;virtualmachinepublishrequests;virtualmachinereplicasets;virtualmachines;virtualmachineservices;virtualmachinesetresourcepolicies;virtualmachinewebconsolerequests,verbs=get;list;update
+
+func (r *Reconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request) (ctrl.Result, error) {
+
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	var obj apiextensionsv1.CustomResourceDefinition
+	if err := r.Client.Get(ctx, req.NamespacedName, &obj); err != nil {
+		return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
+	}
+
+	if !obj.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(ctx, &obj)
+}
+
+func (r *Reconciler) ReconcileNormal(
+	ctx context.Context,
+	obj *apiextensionsv1.CustomResourceDefinition) error {
+
+	logger := pkglog.FromContextOrDefault(ctx)
+
+	storageVersion := StorageVersion(*obj)
+	if storageVersion == "" {
+		logger.Info("Skipping CRD without a storage version")
+		return nil
+	}
+
+	staleVersions := StaleStoredVersions(*obj)
+	if len(staleVersions) == 0 {
+		logger.V(4).Info("CRD objects are already at the storage version",
+			"storageVersion", storageVersion)
+		return nil
+	}
+
+	logger.Info("Migrating objects to the storage version",
+		"storageVersion", storageVersion,
+		"staleVersions", staleVersions)
+
+	result, err := r.migrate(ctx, obj, storageVersion, staleVersions)
+	r.reportProgress(logger, obj.Spec.Names.Kind, result)
+	if err != nil {
+		r.Recorder.Warn(obj, ReasonMigrationFailed, err.Error())
+		return err
+	}
+
+	if result.Failed > 0 {
+		err := fmt.Errorf(
+			"failed to migrate %d of %d %s objects to %s",
+			result.Failed, result.Total, obj.Spec.Names.Kind, storageVersion)
+		r.Recorder.Warn(obj, ReasonMigrationFailed, err.Error())
+		return err
+	}
+
+	// All of the objects are now stored at the storage version, so the older
+	// versions may be removed from the CRD's list of stored versions.
+	obj.Status.StoredVersions = []string{storageVersion}
+	if err := r.Client.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to update stored versions: %w", err)
+	}
+
+	r.Recorder.Eventf(
+		obj,
+		ReasonMigrationSucceeded,
+		"Migrated %d objects to %s, removed stored versions %s, %d lossy",
+		result.Migrated,
+		storageVersion,
+		strings.Join(staleVersions, ","),
+		result.Lossy)
+
+	logger.Info("Migrated objects to the storage version",
+		"storageVersion", storageVersion,
+		"total", result.Total,
+		"lossy", result.Lossy)
+
+	return nil
+}
+
+func (r *Reconciler) migrate(
+	ctx context.Context,
+	obj *apiextensionsv1.CustomResourceDefinition,
+	storageVersion string,
+	staleVersions []string) (Result, error) {
+
+	var (
+		result Result
+		gvk    = schema.GroupVersionKind{
+			Group:   obj.Spec.Group,
+			Version: storageVersion,
+			Kind:    obj.Spec.Names.ListKind,
+		}
+	)
+
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(gvk)
+
+	for {
+		if err := r.Client.List(
+			ctx,
+			&list,
+			ctrlclient.Limit(listPageSize),
+			ctrlclient.Continue(list.GetContinue())); err != nil {
+
+			return result, fmt.Errorf("failed to list %s: %w", gvk, err)
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			result.Total++
+
+			paths, err := r.lossyFieldPaths(item, staleVersions)
+			if err != nil {
+				r.Recorder.Warn(item, ReasonConversionFailed, err.Error())
+			}
+			if len(paths) > 0 {
+				result.Lossy++
+				r.Recorder.Warnf(
+					item,
+					ReasonConversionLossy,
+					"Fields not preserved by conversion to %s: %s",
+					strings.Join(staleVersions, ","),
+					strings.Join(paths, ","))
+			}
+
+			if err := r.rewrite(ctx, item); err != nil {
+				result.Failed++
+				r.Recorder.Warn(item, ReasonConversionFailed, err.Error())
+				continue
+			}
+
+			result.Migrated++
+		}
+
+		// The number of objects left to list is an estimate, so the expected
+		// total is recomputed after each page.
+		result.Expected = result.Total
+		if remaining := list.GetRemainingItemCount(); remaining != nil {
+			result.Expected += int(*remaining)
+		}
+
+		if list.GetContinue() == "" {
+			break
+		}
+
+		r.reportProgress(pkglog.FromContextOrDefault(ctx), obj.Spec.Names.Kind, result)
+	}
+
+	return result, nil
+}
+
+// reportProgress records how many of a kind's objects have been migrated so
+// far.
+func (r *Reconciler) reportProgress(
+	logger logr.Logger,
+	kind string,
+	result Result) {
+
+	r.Metrics.RegisterStorageVersionMigration(
+		logger,
+		kind,
+		result.Expected,
+		result.Total,
+		result.Migrated,
+		result.Failed,
+		result.Lossy)
+
+	logger.Info("Storage version migration progress",
+		"kind", kind,
+		"migrated", result.Migrated,
+		"expected", result.Expected,
+		"failed", result.Failed)
+}
+
+// rewrite issues a no-op update for the object, which causes the API server
+// to persist it at the current storage version.
+func (r *Reconciler) rewrite(
+	ctx context.Context,
+	obj *unstructured.Unstructured) error {
+
+	if err := r.Client.Update(ctx, obj); err != nil {
+		switch {
+		case apierrors.IsNotFound(err):
+			// The object was deleted after it was listed.
+			return nil
+		case apierrors.IsConflict(err):
+			// The object was written after it was listed, and thus it is
+			// already stored at the storage version.
+			return nil
+		}
+		return fmt.Errorf(
+			"failed to rewrite %s at storage version: %w",
+			obj.GetName(), err)
+	}
+	return nil
+}
+
+// lossyFieldPaths returns the paths of the fields that do not survive a
+// round-trip from the storage version through each of the provided versions
+// and back. A nil value is returned if the conversion is lossless or if the
+// round-trip cannot be performed locally.
+func (r *Reconciler) lossyFieldPaths(
+	obj *unstructured.Unstructured,
+	versions []string) ([]string, error) {
+
+	if r.Scheme == nil {
+		return nil, nil
+	}
+
+	hubObj, err := r.Scheme.New(obj.GroupVersionKind())
+	if err != nil {
+		return nil, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(
+		obj.Object, hubObj); err != nil {
+
+		return nil, fmt.Errorf(
+			"failed to decode %s: %w", obj.GroupVersionKind(), err)
+	}
+	hub, ok := hubObj.(ctrlconversion.Hub)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		paths []string
+		errs  []error
+	)
+	for _, v := range versions {
+		gvk := obj.GroupVersionKind()
+		gvk.Version = v
+
+		spokeObj, err := r.Scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		spoke, ok := spokeObj.(ctrlconversion.Convertible)
+		if !ok {
+			continue
+		}
+
+		dst, err := roundTrip(hub, spoke)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"failed to convert to %s: %w", v, err))
+			continue
+		}
+
+		paths = append(paths, diffFieldPaths(hub, dst)...)
+	}
+
+	slices.Sort(paths)
+	return slices.Compact(paths), errors.Join(errs...)
+}
+
+// roundTrip converts the hub to the spoke and back to a new hub object.
+func roundTrip(
+	hub ctrlconversion.Hub,
+	spoke ctrlconversion.Convertible) (_ ctrlconversion.Hub, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("conversion panicked: %v", r)
+		}
+	}()
+
+	// The conversion functions share the ObjectMeta maps between the source
+	// and destination, so use a copy of the hub.
+	if err := spoke.ConvertFrom(hub.DeepCopyObject().(ctrlconversion.Hub)); err != nil {
+		return nil, err
+	}
+	dst := hub.DeepCopyObject().(ctrlconversion.Hub)
+	if err := spoke.ConvertTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// diffFieldPaths returns the paths of the spec and status fields that differ
+// between the two objects.
+func diffFieldPaths(a, b runtime.Object) []string {
+	ua, err := runtime.DefaultUnstructuredConverter.ToUnstructured(a)
+	if err != nil {
+		return nil
+	}
+	ub, err := runtime.DefaultUnstructuredConverter.ToUnstructured(b)
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, f := range []string{"spec", "status"} {
+		var r fieldPathReporter
+		cmp.Equal(ua[f], ub[f], cmp.Reporter(&r))
+		for _, p := range r.paths {
+			paths = append(paths, f+p)
+		}
+	}
+	return paths
+}
+
+// fieldPathReporter is a cmp.Reporter that records the paths of the values
+// that differ.
+type fieldPathReporter struct {
+	path  cmp.Path
+	paths []string
+}
+
+func (r *fieldPathReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *fieldPathReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	var sb strings.Builder
+	for _, ps := range r.path {
+		switch tps := ps.(type) {
+		case cmp.MapIndex:
+			fmt.Fprintf(&sb, ".%v", tps.Key())
+		case cmp.SliceIndex:
+			if k := tps.Key(); k >= 0 {
+				fmt.Fprintf(&sb, "[%d]", k)
+			}
+		}
+	}
+	r.paths = append(r.paths, sb.String())
+}
+
+func (r *fieldPathReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// StorageVersion returns the name of the version used to persist the CRD's
+// objects.
+func StorageVersion(obj apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range obj.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// StaleStoredVersions returns the sorted list of versions in the CRD's
+// status.storedVersions that are not the current storage version.
+func StaleStoredVersions(obj apiextensionsv1.CustomResourceDefinition) []string {
+	storageVersion := StorageVersion(obj)
+	var stale []string
+	for _, v := range obj.Status.StoredVersions {
+		if v != storageVersion {
+			stale = append(stale, v)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
@@ -0,0 +1,243 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package storageversionmigration_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/vm-operator/controllers/storageversionmigration"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsReconcile,
+	)
+	Describe(
+		"StaleStoredVersions",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsStaleStoredVersions,
+	)
+}
+
+func newCRD(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "virtualmachinepublishrequests.vmoperator.vmware.com",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "vmoperator.vmware.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "VirtualMachinePublishRequest",
+				ListKind: "VirtualMachinePublishRequestList",
+				Plural:   "virtualmachinepublishrequests",
+				Singular: "virtualmachinepublishrequest",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1alpha1",
+					Served: true,
+				},
+				{
+					Name:    "v1alpha5",
+					Served:  true,
+					Storage: true,
+				},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+		},
+	}
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		funcs       interceptor.Funcs
+		ctx         *builder.UnitTestContextForController
+		reconciler  *storageversionmigration.Reconciler
+		crd         *apiextensionsv1.CustomResourceDefinition
+		updated     int
+		err         error
+	)
+
+	BeforeEach(func() {
+		updated = 0
+		funcs = interceptor.Funcs{
+			Update: func(
+				ctx context.Context,
+				client client.WithWatch,
+				obj client.Object,
+				opts ...client.UpdateOption) error {
+
+				updated++
+				return client.Update(ctx, obj, opts...)
+			},
+		}
+		crd = newCRD("v1alpha1", "v1alpha5")
+		initObjects = []client.Object{
+			crd,
+			builder.DummyVirtualMachinePublishRequest("pub-1", "ns-1", "vm-1", "item-1", "cl-1"),
+			builder.DummyVirtualMachinePublishRequest("pub-2", "ns-2", "vm-2", "item-2", "cl-2"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForControllerWithFuncs(funcs, initObjects...)
+		reconciler = storageversionmigration.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Client.Scheme(),
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(crd),
+		})
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	getStoredVersions := func() []string {
+		var obj apiextensionsv1.CustomResourceDefinition
+		Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(crd), &obj)).To(Succeed())
+		return obj.Status.StoredVersions
+	}
+
+	When("the crd has stale stored versions", func() {
+		It("should rewrite the objects and prune the stored versions", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(Equal(2))
+			Expect(getStoredVersions()).To(Equal([]string{"v1alpha5"}))
+			Expect(ctx.Events).To(Receive(ContainSubstring(
+				storageversionmigration.ReasonMigrationSucceeded)))
+		})
+
+		It("should report the migration progress", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gatherGauge(
+				"vmservice_storage_version_migration_progress_ratio",
+				map[string]string{"kind": "VirtualMachinePublishRequest"})).To(Equal(1.0))
+			Expect(gatherGauge(
+				"vmservice_storage_version_migration_objects",
+				map[string]string{"kind": "VirtualMachinePublishRequest", "state": "expected"})).To(Equal(2.0))
+		})
+
+		It("should not report the lossless objects", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Consistently(ctx.Events).ShouldNot(Receive(ContainSubstring(
+				storageversionmigration.ReasonConversionLossy)))
+		})
+	})
+
+	When("the crd only has the storage version", func() {
+		BeforeEach(func() {
+			crd.Status.StoredVersions = []string{"v1alpha5"}
+		})
+		It("should not rewrite the objects", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeZero())
+			Expect(getStoredVersions()).To(Equal([]string{"v1alpha5"}))
+		})
+	})
+
+	When("an object cannot be rewritten", func() {
+		BeforeEach(func() {
+			funcs.Update = func(
+				ctx context.Context,
+				client client.WithWatch,
+				obj client.Object,
+				opts ...client.UpdateOption) error {
+
+				if obj.GetName() == "pub-2" {
+					return errors.New("conversion webhook failed")
+				}
+				return client.Update(ctx, obj, opts...)
+			}
+		})
+		It("should report the failure and keep the stored versions", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to migrate 1 of 2"))
+			Expect(gatherGauge(
+				"vmservice_storage_version_migration_progress_ratio",
+				map[string]string{"kind": "VirtualMachinePublishRequest"})).To(Equal(0.5))
+			Expect(getStoredVersions()).To(Equal([]string{"v1alpha1", "v1alpha5"}))
+			Expect(ctx.Events).To(Receive(And(
+				ContainSubstring(storageversionmigration.ReasonConversionFailed),
+				ContainSubstring("conversion webhook failed"))))
+		})
+	})
+
+	When("the crd does not exist", func() {
+		BeforeEach(func() {
+			initObjects = nil
+		})
+		It("should not return an error", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeZero())
+		})
+	})
+}
+
+// gatherGauge returns the value of the gauge with the given name and labels
+// from the controller-runtime metrics registry.
+func gatherGauge(name string, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metricLoop:
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+					continue metricLoop
+				}
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+	Fail("gauge " + name + " not found")
+	return 0
+}
+
+func unitTestsStaleStoredVersions() {
+	It("should return the stored versions other than the storage version", func() {
+		crd := newCRD("v1alpha5", "v1alpha2", "v1alpha1")
+		Expect(storageversionmigration.StorageVersion(*crd)).To(Equal("v1alpha5"))
+		Expect(storageversionmigration.StaleStoredVersions(*crd)).To(Equal([]string{"v1alpha1", "v1alpha2"}))
+	})
+	It("should return nil when only the storage version is stored", func() {
+		crd := newCRD("v1alpha5")
+		Expect(storageversionmigration.StaleStoredVersions(*crd)).To(BeEmpty())
+	})
+}
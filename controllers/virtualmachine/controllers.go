@@ -9,6 +9,8 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/dynamicdns"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/rebalancer"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/storagepolicyusage"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/volume"
@@ -25,6 +27,16 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	if err := storagepolicyusage.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize virtualmachine storagepolicyusage controller: %w", err)
 	}
+	if pkgcfg.FromContext(ctx).Rebalancer.Enabled {
+		if err := rebalancer.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize virtualmachine rebalancer controller: %w", err)
+		}
+	}
+	if pkgcfg.FromContext(ctx).DynamicDNS.Server != "" {
+		if err := dynamicdns.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize virtualmachine dynamicdns controller: %w", err)
+		}
+	}
 
 	if pkgcfg.FromContext(ctx).Features.VMSharedDisks {
 		if err := volumebatch.AddToManager(ctx, mgr); err != nil {
@@ -0,0 +1,578 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgdns "github.com/vmware-tanzu/vm-operator/pkg/dynamicdns"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+const (
+	// Finalizer is the finalizer that is added to the VMs that are registered
+	// in DNS so their records are removed when they are deleted.
+	Finalizer = "vmoperator.vmware.com/dynamic-dns"
+
+	// dnsUpdateFailedReason is the reason of the event emitted when a VM's
+	// DNS records could not be updated.
+	dnsUpdateFailedReason = "DNSUpdateFailed"
+
+	// ownerPrefix is the prefix of the text of the TXT record that is
+	// registered at each of a VM's names along with its other records. The
+	// prefix is followed by the VM's UID.
+	ownerPrefix = "vmoperator.vmware.com/owner="
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controllerName      = "dynamicdns"
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controllerName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&vmopv1.VirtualMachine{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				&vmopv1.VirtualMachine{},
+				mgr.GetScheme()),
+		})
+
+	var secretReader client.Reader = mgr.GetClient()
+
+	if cfg := pkgcfg.FromContext(ctx); cfg.DynamicDNS.TSIGSecretName != "" {
+		// The manager's client does not cache Secrets, so the TSIG key
+		// Secret is read from a cache of the Secrets in the pod's namespace.
+		cache, err := pkgmgr.NewNamespacedCacheForObject(
+			mgr,
+			&ctx.SyncPeriod,
+			&corev1.Secret{},
+			cfg.PodNamespace)
+		if err != nil {
+			return err
+		}
+		secretReader = cache
+
+		// Retry the VMs whose records could not be updated when the key
+		// changes.
+		mapFn := func(ctx context.Context, _ client.Object) []reconcile.Request {
+			var list vmopv1.VirtualMachineList
+			if err := mgr.GetClient().List(ctx, &list); err != nil {
+				pkglog.FromContextOrDefault(ctx).Error(err, "Failed to list VMs for TSIG key Secret")
+				return nil
+			}
+			var requests []reconcile.Request
+			for i := range list.Items {
+				vm := &list.Items[i]
+				if conditions.IsFalse(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(vm),
+					})
+				}
+			}
+			return requests
+		}
+
+		builder = builder.WatchesRawSource(source.Kind[client.Object](
+			cache,
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(mapFn),
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == cfg.DynamicDNS.TSIGSecretName
+			})))
+	}
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		secretReader,
+		ctrl.Log.WithName("controllers").WithName(controllerName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+
+	return builder.Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	secretReader client.Reader,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:      ctx,
+		Client:       client,
+		secretReader: secretReader,
+		Logger:       logger,
+		Recorder:     recorder,
+	}
+}
+
+// Reconciler registers the VMs' host names and primary IP addresses in DNS
+// with dynamic updates.
+type Reconciler struct {
+	client.Client
+	Context      context.Context
+	secretReader client.Reader
+	Logger       logr.Logger
+	Recorder     record.Recorder
+}
+
+// registration is the host name and addresses with which a VM is registered
+// in DNS.
+type registration struct {
+	Name string `json:"name,omitempty"`
+	IP4  string `json:"ip4,omitempty"`
+	IP6  string `json:"ip6,omitempty"`
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vm := &vmopv1.VirtualMachine{}
+	if err := r.Get(ctx, req.NamespacedName, vm); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmCtx := &pkgctx.VirtualMachineContext{
+		Context: ctx,
+		Logger:  pkglog.FromContextOrDefault(ctx),
+		VM:      vm,
+	}
+
+	patchHelper, err := patch.NewHelper(vm, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vm.NamespacedName(), err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vm); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vm.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(vmCtx)
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(vmCtx)
+}
+
+// ReconcileDelete removes the records of a deleted VM from DNS.
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineContext) error {
+	vm := ctx.VM
+
+	if !controllerutil.ContainsFinalizer(vm, Finalizer) {
+		return nil
+	}
+
+	if cur := getRegistration(vm); cur.Name != "" {
+		dnsClient, err := r.newDNSClient(ctx)
+		if err != nil {
+			return err
+		}
+		if err := r.unregister(ctx, dnsClient, cur, registration{}); err != nil {
+			r.Recorder.Warnf(vm, dnsUpdateFailedReason, "Failed to remove DNS records: %v", err)
+			return err
+		}
+		ctx.Logger.Info("Removed DNS records", "name", cur.Name, "ip4", cur.IP4, "ip6", cur.IP6)
+	}
+
+	controllerutil.RemoveFinalizer(vm, Finalizer)
+	return nil
+}
+
+// ReconcileNormal registers the VM's host name and primary IP addresses in
+// DNS once the VM has an address, and updates its records when its host name
+// or addresses change.
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) error {
+	vm := ctx.VM
+
+	if _, ok := vm.Annotations[vmopv1.PauseAnnotation]; ok {
+		ctx.Logger.V(4).Info("Skipping paused VM")
+		return nil
+	}
+
+	cfg := pkgcfg.FromContext(ctx).DynamicDNS
+	zones := pkgcfg.StringToSlice(cfg.Zones)
+
+	hostName, domainName := vm.Name, cfg.Domain
+	if vm.Spec.Network != nil {
+		if vm.Spec.Network.HostName != "" {
+			hostName = vm.Spec.Network.HostName
+		}
+		if vm.Spec.Network.DomainName != "" {
+			domainName = vm.Spec.Network.DomainName
+		}
+	}
+
+	if domainName == "" {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineDNSRecordsSyncedCondition,
+			vmopv1.VirtualMachineDNSRecordsNoDomainNameReason,
+			"The VM does not have a domain name")
+		return nil
+	}
+
+	des := registration{
+		Name: pkgdns.Fqdn(strings.ToLower(hostName + "." + domainName)),
+	}
+	if pkgdns.FindZone(des.Name, zones) == "" {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineDNSRecordsSyncedCondition,
+			vmopv1.VirtualMachineDNSRecordsZoneNotFoundReason,
+			"The name %s is not in any of the zones %s",
+			des.Name,
+			cfg.Zones)
+		return nil
+	}
+
+	if vm.Status.Network != nil {
+		des.IP4 = vm.Status.Network.PrimaryIP4
+		des.IP6 = vm.Status.Network.PrimaryIP6
+	}
+
+	cur := getRegistration(vm)
+
+	if des.IP4 == "" && des.IP6 == "" {
+		// The records of a VM that is already registered are kept while it
+		// does not have an address, ex. while it is powered off.
+		if cur.Name == "" {
+			conditions.MarkFalse(
+				vm,
+				vmopv1.VirtualMachineDNSRecordsSyncedCondition,
+				vmopv1.VirtualMachineDNSRecordsWaitingForAddressReason,
+				"The VM does not have a primary IP address")
+		}
+		return nil
+	}
+
+	if cur == des && conditions.IsTrue(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition) {
+		return nil
+	}
+
+	// Add the finalizer before any records are registered, and return so the
+	// VM is patched immediately.
+	if controllerutil.AddFinalizer(vm, Finalizer) {
+		return nil
+	}
+
+	if err := r.register(ctx, cur, des); err != nil {
+		r.Recorder.Warnf(vm, dnsUpdateFailedReason, "Failed to update DNS records: %v", err)
+		reason := vmopv1.VirtualMachineDNSRecordsUpdateFailedReason
+		if errors.Is(err, errNameInUse) {
+			reason = vmopv1.VirtualMachineDNSRecordsNameInUseReason
+		}
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineDNSRecordsSyncedCondition,
+			reason,
+			"%v",
+			err)
+		return err
+	}
+
+	if cur != des {
+		ctx.Logger.Info("Updated DNS records", "name", des.Name, "ip4", des.IP4, "ip6", des.IP6)
+	}
+
+	if err := setRegistration(vm, des); err != nil {
+		return err
+	}
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition)
+
+	return nil
+}
+
+// register replaces the VM's current records with its desired records.
+func (r *Reconciler) register(
+	ctx *pkgctx.VirtualMachineContext,
+	cur, des registration) error {
+
+	dnsClient, err := r.newDNSClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.unregister(ctx, dnsClient, cur, des); err != nil {
+		return err
+	}
+
+	zones := pkgcfg.StringToSlice(pkgcfg.FromContext(ctx).DynamicDNS.Zones)
+
+	var adds []pkgdns.Record
+	if des.IP4 != "" {
+		adds = append(adds, pkgdns.Record{Name: des.Name, Type: dns.TypeA, Data: des.IP4})
+	}
+	if des.IP6 != "" {
+		adds = append(adds, pkgdns.Record{Name: des.Name, Type: dns.TypeAAAA, Data: des.IP6})
+	}
+	if err := replace(
+		ctx,
+		dnsClient,
+		pkgdns.FindZone(des.Name, zones),
+		newOwnerRecord(ctx.VM, des.Name),
+		[]uint16{dns.TypeA, dns.TypeAAAA},
+		adds); err != nil {
+
+		return fmt.Errorf("failed to update the A and AAAA records of %s: %w", des.Name, err)
+	}
+
+	for _, ip := range []string{des.IP4, des.IP6} {
+		if ip == "" {
+			continue
+		}
+		ptrName, err := pkgdns.ReverseName(ip)
+		if err != nil {
+			return err
+		}
+		zone := pkgdns.FindZone(ptrName, zones)
+		if zone == "" {
+			ctx.Logger.V(4).Info("Skipping PTR record that is not in any zone", "name", ptrName)
+			continue
+		}
+		if err := replace(
+			ctx,
+			dnsClient,
+			zone,
+			newOwnerRecord(ctx.VM, ptrName),
+			[]uint16{dns.TypePTR},
+			[]pkgdns.Record{{Name: ptrName, Type: dns.TypePTR, Data: des.Name}}); err != nil {
+
+			return fmt.Errorf("failed to update the PTR record of %s: %w", ip, err)
+		}
+	}
+
+	return nil
+}
+
+// unregister removes the current records that are not replaced by the
+// desired records.
+func (r *Reconciler) unregister(
+	ctx *pkgctx.VirtualMachineContext,
+	dnsClient pkgdns.Client,
+	cur, des registration) error {
+
+	zones := pkgcfg.StringToSlice(pkgcfg.FromContext(ctx).DynamicDNS.Zones)
+
+	if cur.Name != "" && cur.Name != des.Name {
+		if zone := pkgdns.FindZone(cur.Name, zones); zone != "" {
+			if err := remove(
+				ctx,
+				dnsClient,
+				zone,
+				newOwnerRecord(ctx.VM, cur.Name),
+				[]uint16{dns.TypeA, dns.TypeAAAA}); err != nil {
+
+				return fmt.Errorf("failed to remove the A and AAAA records of %s: %w", cur.Name, err)
+			}
+		}
+	}
+
+	for _, ip := range []string{cur.IP4, cur.IP6} {
+		if ip == "" || ip == des.IP4 || ip == des.IP6 {
+			continue
+		}
+		ptrName, err := pkgdns.ReverseName(ip)
+		if err != nil {
+			continue
+		}
+		zone := pkgdns.FindZone(ptrName, zones)
+		if zone == "" {
+			continue
+		}
+		if err := remove(
+			ctx,
+			dnsClient,
+			zone,
+			newOwnerRecord(ctx.VM, ptrName),
+			[]uint16{dns.TypePTR}); err != nil {
+
+			return fmt.Errorf("failed to remove the PTR record of %s: %w", ip, err)
+		}
+	}
+
+	return nil
+}
+
+// errNameInUse is returned when a name already has records that were not
+// registered for the VM.
+var errNameInUse = errors.New("name is in use")
+
+// newOwnerRecord returns the TXT record that marks the records of the name as
+// registered for the VM. The records of a name are only replaced or removed
+// while the name has this record, so a VM cannot take over the records of
+// another VM or of any other client of the DNS server.
+func newOwnerRecord(vm *vmopv1.VirtualMachine, name string) pkgdns.Record {
+	return pkgdns.Record{
+		Name: name,
+		Type: dns.TypeTXT,
+		Data: ownerPrefix + string(vm.UID),
+	}
+}
+
+// replace replaces the RRsets of the provided types at the owner record's name
+// with the provided records if the VM owns the name, or claims the name and
+// adds the records if the name does not have any of the RRsets or a TXT
+// record. Otherwise errNameInUse is returned.
+func replace(
+	ctx context.Context,
+	dnsClient pkgdns.Client,
+	zone string,
+	owner pkgdns.Record,
+	types []uint16,
+	adds []pkgdns.Record) error {
+
+	rrsets := make([]pkgdns.Record, 0, len(types))
+	for _, t := range types {
+		rrsets = append(rrsets, pkgdns.Record{Name: owner.Name, Type: t})
+	}
+
+	err := dnsClient.Update(ctx, pkgdns.Update{
+		Zone:         zone,
+		Exists:       []pkgdns.Record{owner},
+		DeleteRRsets: rrsets,
+		Adds:         adds,
+	})
+	if !pkgdns.IsRCode(err, dns.RcodeNXRrset) {
+		return err
+	}
+
+	// The VM does not own the name, so only claim it if it is not in use.
+	err = dnsClient.Update(ctx, pkgdns.Update{
+		Zone:      zone,
+		NotExists: append(rrsets, pkgdns.Record{Name: owner.Name, Type: dns.TypeTXT}),
+		Adds:      append(slices.Clone(adds), owner),
+	})
+	if pkgdns.IsRCode(err, dns.RcodeYXRrset) {
+		return fmt.Errorf("%w: %s has records that were not registered for the VM", errNameInUse, owner.Name)
+	}
+	return err
+}
+
+// remove removes the RRsets of the provided types and the owner record at the
+// owner record's name if the VM owns the name. The records of a name the VM
+// does not own are left as they are.
+func remove(
+	ctx context.Context,
+	dnsClient pkgdns.Client,
+	zone string,
+	owner pkgdns.Record,
+	types []uint16) error {
+
+	rrsets := make([]pkgdns.Record, 0, len(types))
+	for _, t := range types {
+		rrsets = append(rrsets, pkgdns.Record{Name: owner.Name, Type: t})
+	}
+
+	err := dnsClient.Update(ctx, pkgdns.Update{
+		Zone:         zone,
+		Exists:       []pkgdns.Record{owner},
+		DeleteRRsets: rrsets,
+		Deletes:      []pkgdns.Record{owner},
+	})
+	if pkgdns.IsRCode(err, dns.RcodeNXRrset) {
+		pkglog.FromContextOrDefault(ctx).V(4).Info(
+			"Skipping removal of records that were not registered for the VM", "name", owner.Name)
+		return nil
+	}
+	return err
+}
+
+// newDNSClient returns a client for the configured DNS server that signs the
+// updates with the TSIG key from the configured Secret.
+func (r *Reconciler) newDNSClient(ctx context.Context) (pkgdns.Client, error) {
+	cfg := pkgcfg.FromContext(ctx)
+
+	dnsClient := pkgdns.Client{
+		Server: cfg.DynamicDNS.Server,
+		TTL:    cfg.DynamicDNS.TTL,
+	}
+
+	if cfg.DynamicDNS.TSIGSecretName == "" {
+		return dnsClient, nil
+	}
+
+	var (
+		secret corev1.Secret
+		key    = client.ObjectKey{Namespace: cfg.PodNamespace, Name: cfg.DynamicDNS.TSIGSecretName}
+	)
+	if err := r.secretReader.Get(ctx, key, &secret); err != nil {
+		return pkgdns.Client{}, fmt.Errorf("failed to get TSIG key Secret %s: %w", key, err)
+	}
+
+	tsigKey, err := pkgdns.ParseTSIGKey(
+		string(secret.Data["name"]),
+		string(secret.Data["algorithm"]),
+		string(secret.Data["secret"]))
+	if err != nil {
+		return pkgdns.Client{}, fmt.Errorf("invalid TSIG key Secret %s: %w", key, err)
+	}
+	dnsClient.Key = &tsigKey
+
+	return dnsClient, nil
+}
+
+func getRegistration(vm *vmopv1.VirtualMachine) registration {
+	var reg registration
+	if v := vm.Annotations[pkgconst.DynamicDNSRecordsAnnotation]; v != "" {
+		// An invalid annotation is treated as if the VM is not registered.
+		_ = json.Unmarshal([]byte(v), &reg)
+	}
+	return reg
+}
+
+func setRegistration(vm *vmopv1.VirtualMachine, reg registration) error {
+	data, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[pkgconst.DynamicDNSRecordsAnnotation] = string(data)
+	return nil
+}
@@ -0,0 +1,97 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/miekg/dns"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+		vm  *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: builder.DummyClassName,
+				ImageName: builder.DummyVMIName,
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					DomainName: "example.com",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("registers the VM once it has an address and removes it when the VM is deleted", func() {
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachine{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			c := conditions.Get(obj, vmopv1.VirtualMachineDNSRecordsSyncedCondition)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Reason).To(Equal(vmopv1.VirtualMachineDNSRecordsWaitingForAddressReason))
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachine{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			obj.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+				PrimaryIP4: "192.168.1.10",
+			}
+			g.Expect(ctx.Client.Status().Update(ctx, obj)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachine{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineDNSRecordsSyncedCondition)).To(BeTrue())
+			g.Expect(intgDNSServer.Lookup("dummy-vm.example.com", dns.TypeA)).To(ConsistOf("192.168.1.10"))
+		}).Should(Succeed())
+
+		Expect(ctx.Client.Delete(ctx, vm)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), &vmopv1.VirtualMachine{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			g.Expect(intgDNSServer.Lookup("dummy-vm.example.com", dns.TypeA)).To(BeEmpty())
+		}).Should(Succeed())
+	})
+}
@@ -0,0 +1,49 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/dynamicdns"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/dynamicdns/dnstest"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var intgDNSServer *dnstest.Server
+
+var suite = builder.NewTestSuiteForControllerWithContext(
+	pkgcfg.NewContextWithDefaultConfig(),
+	dynamicdns.AddToManager,
+	func(ctx *pkgctx.ControllerManagerContext, _ ctrlmgr.Manager) error {
+		var err error
+		if intgDNSServer, err = dnstest.NewServer(nil, "example.com"); err != nil {
+			return err
+		}
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.DynamicDNS.Server = intgDNSServer.Addr
+			config.DynamicDNS.Zones = "example.com"
+		})
+		return nil
+	})
+
+func TestDynamicDNS(t *testing.T) {
+	suite.Register(t, "Dynamic DNS controller suite", intgTests, unitTests)
+}
+
+var _ = BeforeSuite(suite.BeforeSuite)
+
+var _ = AfterSuite(func() {
+	suite.AfterSuite()
+	if intgDNSServer != nil {
+		intgDNSServer.Close()
+	}
+})
@@ -0,0 +1,480 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns_test
+
+import (
+	"encoding/base64"
+	"slices"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/dynamicdns"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgdns "github.com/vmware-tanzu/vm-operator/pkg/dynamicdns"
+	"github.com/vmware-tanzu/vm-operator/pkg/dynamicdns/dnstest"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		podNamespace   = "vmop-system"
+		tsigSecretName = "dns-tsig-key"
+		zones          = "example.com,168.192.in-addr.arpa,8.b.d.0.1.0.0.2.ip6.arpa"
+
+		vmName   = "my-vm.example.com."
+		ptr4Name = "10.1.168.192.in-addr.arpa."
+		ptr6Name = "0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."
+	)
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *dynamicdns.Reconciler
+		server      *dnstest.Server
+		tsigKey     pkgdns.TSIGKey
+		tsigSecret  *corev1.Secret
+		vm          *vmopv1.VirtualMachine
+		vmCtx       *pkgctx.VirtualMachineContext
+
+		// existingRecords are the records the server has before the VM is
+		// reconciled.
+		existingRecords []pkgdns.Record
+	)
+
+	BeforeEach(func() {
+		secret := base64.StdEncoding.EncodeToString([]byte("my-tsig-key-secret"))
+
+		var err error
+		tsigKey, err = pkgdns.ParseTSIGKey("vmop-key", "hmac-sha512", secret)
+		Expect(err).ToNot(HaveOccurred())
+
+		tsigSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tsigSecretName,
+				Namespace: podNamespace,
+			},
+			Data: map[string][]byte{
+				"name":      []byte("vmop-key"),
+				"algorithm": []byte("hmac-sha512"),
+				"secret":    []byte(secret),
+			},
+		}
+		initObjects = []client.Object{tsigSecret}
+
+		vm = builder.DummyVirtualMachine()
+		vm.Name = "my-vm"
+		vm.UID = "my-vm-uid"
+		vm.Namespace = builder.DummyNamespaceName
+		vm.Spec.Network.DomainName = "example.com"
+		vm.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+			PrimaryIP4: "192.168.1.10",
+			PrimaryIP6: "2001:db8::10",
+		}
+		controllerutil.AddFinalizer(vm, dynamicdns.Finalizer)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		server, err = dnstest.NewServer(&tsigKey, pkgcfg.StringToSlice(zones)...)
+		Expect(err).ToNot(HaveOccurred())
+		server.AddRecords(existingRecords...)
+
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.PodNamespace = podNamespace
+			config.DynamicDNS.Server = server.Addr
+			config.DynamicDNS.Zones = zones
+			config.DynamicDNS.TSIGSecretName = tsigSecretName
+			config.DynamicDNS.TTL = time.Minute
+		})
+		reconciler = dynamicdns.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		vmCtx = &pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  ctx.Logger.WithName(vm.Name),
+			VM:      vm,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		existingRecords = nil
+		reconciler = nil
+		vm = nil
+		vmCtx = nil
+	})
+
+	// expectRecords expects the server to have the provided records, and an
+	// owner record at the name of each of them.
+	expectRecords := func(records ...pkgdns.Record) {
+		var owners []pkgdns.Record
+		for _, r := range records {
+			owner := pkgdns.Record{Name: r.Name, Type: dns.TypeTXT, Data: "vmoperator.vmware.com/owner=my-vm-uid"}
+			if !slices.Contains(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+		if len(records) == 0 {
+			ExpectWithOffset(1, server.Records()).To(BeEmpty())
+		} else {
+			ExpectWithOffset(1, server.Records()).To(ConsistOf(append(records, owners...)))
+		}
+	}
+
+	allRecords := []pkgdns.Record{
+		{Name: vmName, Type: dns.TypeA, Data: "192.168.1.10"},
+		{Name: vmName, Type: dns.TypeAAAA, Data: "2001:db8::10"},
+		{Name: ptr4Name, Type: dns.TypePTR, Data: vmName},
+		{Name: ptr6Name, Type: dns.TypePTR, Data: vmName},
+	}
+
+	expectCondition := func(reason string) {
+		c := conditions.Get(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition)
+		ExpectWithOffset(1, c).ToNot(BeNil())
+		ExpectWithOffset(1, c.Status).To(Equal(metav1.ConditionFalse))
+		ExpectWithOffset(1, c.Reason).To(Equal(reason))
+	}
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			vm.Finalizers = nil
+			initObjects = append(initObjects, vm)
+		})
+
+		reconcileVM := func() *vmopv1.VirtualMachine {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			obj := &vmopv1.VirtualMachine{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			return obj
+		}
+
+		It("adds the finalizer and then registers the VM", func() {
+			obj := reconcileVM()
+			Expect(obj.Finalizers).To(ContainElement(dynamicdns.Finalizer))
+			Expect(server.Updates()).To(BeZero())
+
+			obj = reconcileVM()
+			Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineDNSRecordsSyncedCondition)).To(BeTrue())
+			Expect(obj.Annotations).To(HaveKeyWithValue(
+				pkgconst.DynamicDNSRecordsAnnotation,
+				`{"name":"my-vm.example.com.","ip4":"192.168.1.10","ip6":"2001:db8::10"}`))
+			expectRecords(allRecords...)
+
+			// The records are not updated again when nothing has changed.
+			updates := server.Updates()
+			reconcileVM()
+			Expect(server.Updates()).To(Equal(updates))
+		})
+	})
+
+	Context("ReconcileNormal", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = reconciler.ReconcileNormal(vmCtx)
+		})
+
+		It("registers the VM's A, AAAA, and PTR records", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition)).To(BeTrue())
+			expectRecords(allRecords...)
+		})
+
+		When("the VM's addresses change", func() {
+			It("replaces the VM's records", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				vm.Status.Network.PrimaryIP4 = "192.168.1.11"
+				vm.Status.Network.PrimaryIP6 = ""
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+
+				expectRecords(
+					pkgdns.Record{Name: vmName, Type: dns.TypeA, Data: "192.168.1.11"},
+					pkgdns.Record{Name: "11.1.168.192.in-addr.arpa.", Type: dns.TypePTR, Data: vmName},
+				)
+			})
+		})
+
+		When("the VM's host name changes", func() {
+			It("removes the records of the old name", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				vm.Spec.Network.HostName = "new-name"
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+
+				expectRecords(
+					pkgdns.Record{Name: "new-name.example.com.", Type: dns.TypeA, Data: "192.168.1.10"},
+					pkgdns.Record{Name: "new-name.example.com.", Type: dns.TypeAAAA, Data: "2001:db8::10"},
+					pkgdns.Record{Name: ptr4Name, Type: dns.TypePTR, Data: "new-name.example.com."},
+					pkgdns.Record{Name: ptr6Name, Type: dns.TypePTR, Data: "new-name.example.com."},
+				)
+			})
+		})
+
+		When("the VM no longer has an address", func() {
+			It("keeps the VM's records", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				vm.Status.Network = nil
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+
+				Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineDNSRecordsSyncedCondition)).To(BeTrue())
+				expectRecords(allRecords...)
+			})
+		})
+
+		When("an address is not in a reverse zone", func() {
+			BeforeEach(func() {
+				vm.Status.Network.PrimaryIP4 = "10.0.0.10"
+			})
+
+			It("does not register its PTR record", func() {
+				Expect(err).ToNot(HaveOccurred())
+				expectRecords(
+					pkgdns.Record{Name: vmName, Type: dns.TypeA, Data: "10.0.0.10"},
+					pkgdns.Record{Name: vmName, Type: dns.TypeAAAA, Data: "2001:db8::10"},
+					pkgdns.Record{Name: ptr6Name, Type: dns.TypePTR, Data: vmName},
+				)
+			})
+		})
+
+		When("the VM's name has the records of another VM", func() {
+			BeforeEach(func() {
+				existingRecords = []pkgdns.Record{
+					{Name: vmName, Type: dns.TypeA, Data: "192.168.1.20"},
+					{Name: vmName, Type: dns.TypeTXT, Data: "vmoperator.vmware.com/owner=other-vm-uid"},
+				}
+			})
+
+			It("does not replace the records", func() {
+				Expect(err).To(MatchError(ContainSubstring("my-vm.example.com. has records that were not registered for the VM")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsNameInUseReason)
+				Expect(server.Records()).To(ConsistOf(existingRecords))
+			})
+		})
+
+		When("the VM's name has records that were not registered for a VM", func() {
+			BeforeEach(func() {
+				existingRecords = []pkgdns.Record{
+					{Name: vmName, Type: dns.TypeAAAA, Data: "2001:db8::20"},
+				}
+			})
+
+			It("does not replace the records", func() {
+				Expect(err).To(MatchError(ContainSubstring("name is in use")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsNameInUseReason)
+				Expect(server.Records()).To(ConsistOf(existingRecords))
+			})
+		})
+
+		When("the VM's reverse name has the records of another VM", func() {
+			BeforeEach(func() {
+				existingRecords = []pkgdns.Record{
+					{Name: ptr4Name, Type: dns.TypePTR, Data: "other-vm.example.com."},
+					{Name: ptr4Name, Type: dns.TypeTXT, Data: "vmoperator.vmware.com/owner=other-vm-uid"},
+				}
+			})
+
+			It("does not replace the records", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to update the PTR record of 192.168.1.10")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsNameInUseReason)
+				Expect(server.Lookup(ptr4Name, dns.TypePTR)).To(ConsistOf("other-vm.example.com."))
+			})
+		})
+
+		When("the VM does not have the finalizer", func() {
+			BeforeEach(func() {
+				vm.Finalizers = nil
+			})
+
+			It("adds the finalizer before registering the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Finalizers).To(ContainElement(dynamicdns.Finalizer))
+				expectRecords()
+			})
+		})
+
+		When("the VM does not have an address", func() {
+			BeforeEach(func() {
+				vm.Status.Network = nil
+			})
+
+			It("waits for an address", func() {
+				Expect(err).ToNot(HaveOccurred())
+				expectCondition(vmopv1.VirtualMachineDNSRecordsWaitingForAddressReason)
+				expectRecords()
+			})
+		})
+
+		When("the VM does not have a domain name", func() {
+			BeforeEach(func() {
+				vm.Spec.Network.DomainName = ""
+			})
+
+			It("does not register the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				expectCondition(vmopv1.VirtualMachineDNSRecordsNoDomainNameReason)
+				expectRecords()
+			})
+
+			When("there is a default domain", func() {
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.DynamicDNS.Domain = "example.com"
+					})
+					err = reconciler.ReconcileNormal(vmCtx)
+				})
+
+				It("registers the VM in the default domain", func() {
+					Expect(err).ToNot(HaveOccurred())
+					expectRecords(allRecords...)
+				})
+			})
+		})
+
+		When("the VM's domain is not in a zone", func() {
+			BeforeEach(func() {
+				vm.Spec.Network.DomainName = "example.org"
+			})
+
+			It("does not register the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				expectCondition(vmopv1.VirtualMachineDNSRecordsZoneNotFoundReason)
+				expectRecords()
+			})
+		})
+
+		When("the DNS server refuses the update", func() {
+			JustBeforeEach(func() {
+				server.SetRCode(dns.RcodeRefused)
+				vm.Status.Network.PrimaryIP4 = "192.168.1.11"
+				err = reconciler.ReconcileNormal(vmCtx)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("DNS server refused the update: REFUSED")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsUpdateFailedReason)
+				Expect(vm.Annotations).To(HaveKeyWithValue(
+					pkgconst.DynamicDNSRecordsAnnotation,
+					`{"name":"my-vm.example.com.","ip4":"192.168.1.10","ip6":"2001:db8::10"}`))
+			})
+		})
+
+		When("the TSIG key Secret does not exist", func() {
+			BeforeEach(func() {
+				initObjects = nil
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to get TSIG key Secret")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsUpdateFailedReason)
+				expectRecords()
+			})
+		})
+
+		When("the TSIG key does not match the DNS server's key", func() {
+			BeforeEach(func() {
+				tsigSecret.Data["name"] = []byte("other-key")
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("DNS server refused the update: NOTAUTH")))
+				expectCondition(vmopv1.VirtualMachineDNSRecordsUpdateFailedReason)
+				expectRecords()
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		var (
+			err   error
+			rcode int
+		)
+
+		BeforeEach(func() {
+			rcode = dns.RcodeSuccess
+		})
+
+		JustBeforeEach(func() {
+			Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+			expectRecords(allRecords...)
+
+			server.SetRCode(rcode)
+			err = reconciler.ReconcileDelete(vmCtx)
+		})
+
+		It("removes the VM's records and the finalizer", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Finalizers).ToNot(ContainElement(dynamicdns.Finalizer))
+			expectRecords()
+		})
+
+		It("does not remove the records of another VM", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			// The VM's annotation cannot be used to remove records that were
+			// not registered for the VM.
+			otherRecords := []pkgdns.Record{
+				{Name: "other-vm.example.com.", Type: dns.TypeA, Data: "192.168.1.20"},
+				{Name: "other-vm.example.com.", Type: dns.TypeTXT, Data: "vmoperator.vmware.com/owner=other-vm-uid"},
+			}
+			server.AddRecords(otherRecords...)
+			vm.Annotations[pkgconst.DynamicDNSRecordsAnnotation] = `{"name":"other-vm.example.com.","ip4":"192.168.1.20"}`
+			controllerutil.AddFinalizer(vm, dynamicdns.Finalizer)
+
+			Expect(reconciler.ReconcileDelete(vmCtx)).To(Succeed())
+			Expect(vm.Finalizers).ToNot(ContainElement(dynamicdns.Finalizer))
+			Expect(server.Records()).To(ConsistOf(otherRecords))
+		})
+
+		When("the DNS server refuses the update", func() {
+			BeforeEach(func() {
+				rcode = dns.RcodeServerFailure
+			})
+
+			It("keeps the records and the finalizer", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(vm.Finalizers).To(ContainElement(dynamicdns.Finalizer))
+				expectRecords(allRecords...)
+			})
+		})
+	})
+}
@@ -0,0 +1,271 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package rebalancer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	// relocatedReason is the reason of the event emitted when a VM is moved
+	// to another host.
+	relocatedReason = "Relocated"
+
+	// relocateFailedReason is the reason of the event emitted when a VM
+	// could not be moved to another host.
+	relocateFailedReason = "RelocateFailed"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controllerName      = "rebalancer"
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controllerName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controllerName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&vmopv1.VirtualMachine{}).
+		// The VMs are re-evaluated periodically, so there is no need to
+		// reconcile them each time their status changes.
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(controller.Options{
+			// The VMs are reconciled one at a time so the moves are throttled
+			// and each move is visible to the disruption budgets of the next.
+			MaxConcurrentReconciles: 1,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				&vmopv1.VirtualMachine{},
+				mgr.GetScheme()),
+		}).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler periodically re-evaluates the VMs that have preferred rules
+// across hosts, and moves them to hosts on which fewer of the rules are
+// violated.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+
+	movesMu sync.Mutex
+	moves   []time.Time
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedisruptionbudgets,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vm := &vmopv1.VirtualMachine{}
+	if err := r.Get(ctx, req.NamespacedName, vm); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmCtx := &pkgctx.VirtualMachineContext{
+		Context: ctx,
+		Logger:  pkglog.FromContextOrDefault(ctx),
+		VM:      vm,
+	}
+
+	patchHelper, err := patch.NewHelper(vm, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vm.NamespacedName(), err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vm); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vm.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if !placement.HasRebalanceRules(vm) {
+		conditions.Delete(vm, vmopv1.VirtualMachinePlacementOptimalCondition)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.ReconcileNormal(vmCtx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: pkgcfg.FromContext(ctx).Rebalancer.Interval}, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) error {
+	vm := ctx.VM
+
+	if _, ok := vm.Annotations[vmopv1.PauseAnnotation]; ok {
+		ctx.Logger.V(4).Info("Skipping paused VM")
+		return nil
+	}
+	if vm.Status.UniqueID == "" || vm.Status.NodeName == "" {
+		// The VM has not been placed yet.
+		return nil
+	}
+
+	result, err := r.VMProvider.EvaluateVirtualMachineHostPlacement(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate VM host placement: %w", err)
+	}
+
+	if len(result.Violations) == 0 {
+		conditions.MarkTrue(vm, vmopv1.VirtualMachinePlacementOptimalCondition)
+		return nil
+	}
+
+	violations := strings.Join(result.Violations, "; ")
+
+	if result.TargetHost == "" {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachinePlacementOptimalCondition,
+			vmopv1.VirtualMachinePlacementNoBetterHostReason,
+			"%s",
+			violations)
+		return nil
+	}
+
+	budgetName, err := vmopv1util.GetExceededDisruptionBudget(ctx, r.Client, vm)
+	if err != nil {
+		return err
+	}
+	if budgetName != "" {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachinePlacementOptimalCondition,
+			vmopv1.VirtualMachinePlacementDisruptionBudgetExceededReason,
+			"Moving to host %s would exceed VirtualMachineDisruptionBudget %s: %s",
+			result.TargetHost,
+			budgetName,
+			violations)
+		return nil
+	}
+
+	if !r.reserveMove(ctx) {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachinePlacementOptimalCondition,
+			vmopv1.VirtualMachinePlacementPreferredRulesViolatedReason,
+			"Waiting to move to host %s: %s",
+			result.TargetHost,
+			violations)
+		return nil
+	}
+
+	fromHost := vm.Status.NodeName
+
+	if err := r.VMProvider.RelocateVirtualMachineToHost(ctx, vm, result.TargetHostMoID, result.TargetPoolMoID); err != nil {
+		r.Recorder.Warnf(vm, relocateFailedReason,
+			"Failed to move from host %s to host %s: %v", fromHost, result.TargetHost, err)
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachinePlacementOptimalCondition,
+			vmopv1.VirtualMachinePlacementRelocateFailedReason,
+			"Failed to move to host %s: %v",
+			result.TargetHost,
+			err)
+		return nil
+	}
+
+	r.Recorder.Eventf(vm, relocatedReason,
+		"Moved from host %s to host %s to reduce the violated preferred rules from %d to %d",
+		fromHost, result.TargetHost, len(result.Violations), len(result.TargetViolations))
+
+	// The VM controller updates the status.nodeName field once it observes
+	// the move.
+	if len(result.TargetViolations) == 0 {
+		conditions.MarkTrue(vm, vmopv1.VirtualMachinePlacementOptimalCondition)
+	} else {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachinePlacementOptimalCondition,
+			vmopv1.VirtualMachinePlacementNoBetterHostReason,
+			"%s",
+			strings.Join(result.TargetViolations, "; "))
+	}
+
+	return nil
+}
+
+// reserveMove returns true if a VM may be moved without exceeding the
+// maximum number of moves per interval, and records the move.
+func (r *Reconciler) reserveMove(ctx *pkgctx.VirtualMachineContext) bool {
+	cfg := pkgcfg.FromContext(ctx).Rebalancer
+
+	r.movesMu.Lock()
+	defer r.movesMu.Unlock()
+
+	now := time.Now()
+	for len(r.moves) > 0 && now.Sub(r.moves[0]) >= cfg.Interval {
+		r.moves = r.moves[1:]
+	}
+	if len(r.moves) >= cfg.MaxMovesPerInterval {
+		ctx.Logger.V(4).Info("Throttling VM move",
+			"movesInInterval", len(r.moves), "maxMovesPerInterval", cfg.MaxMovesPerInterval)
+		return false
+	}
+
+	r.moves = append(r.moves, now)
+	return true
+}
@@ -0,0 +1,103 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package rebalancer_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+		vm  *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		intgFakeVMProvider.Lock()
+		intgFakeVMProvider.EvaluateVirtualMachineHostPlacementFn = func(
+			_ context.Context,
+			vm *vmopv1.VirtualMachine) (providers.VMHostPlacementEvaluation, error) {
+
+			if vm.Status.NodeName == "host-2" {
+				return providers.VMHostPlacementEvaluation{}, nil
+			}
+			return providers.VMHostPlacementEvaluation{
+				Violations:     []string{"fake violation"},
+				TargetHost:     "host-2",
+				TargetHostMoID: "host-moid-2",
+			}, nil
+		}
+		intgFakeVMProvider.Unlock()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+				Labels:    map[string]string{"app": "db"},
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: builder.DummyClassName,
+				ImageName: builder.DummyVMIName,
+				Affinity: &vmopv1.AffinitySpec{
+					VMAntiAffinity: &vmopv1.VMAntiAffinitySpec{
+						PreferredDuringSchedulingPreferredDuringExecution: []vmopv1.VMAffinityTerm{
+							{
+								TopologyKey: corev1.LabelHostname,
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"app": "db"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	It("moves the VM once it has been placed", func() {
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+
+		vm.Status.UniqueID = "vm-42"
+		vm.Status.NodeName = "host-1"
+		Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachine{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeTrue())
+		}).Should(Succeed())
+	})
+}
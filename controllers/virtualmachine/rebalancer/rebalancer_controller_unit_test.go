@@ -0,0 +1,314 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package rebalancer_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/rebalancer"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		curHost        = "host-1"
+		targetHost     = "host-2"
+		targetMoID     = "host-moid-2"
+		targetPoolMoID = "resgroup-2"
+	)
+
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		reconciler     *rebalancer.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+		vm             *vmopv1.VirtualMachine
+		vmCtx          *pkgctx.VirtualMachineContext
+
+		evaluation     providers.VMHostPlacementEvaluation
+		relocateErr    error
+		relocatedMoIDs []string
+		relocatedPools []string
+		evaluateCalled bool
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyVirtualMachine()
+		vm.Name = "dummy-vm"
+		vm.Namespace = builder.DummyNamespaceName
+		vm.Labels = map[string]string{"app": "db"}
+		vm.Spec.Affinity = &vmopv1.AffinitySpec{
+			VMAntiAffinity: &vmopv1.VMAntiAffinitySpec{
+				PreferredDuringSchedulingPreferredDuringExecution: []vmopv1.VMAffinityTerm{
+					{
+						TopologyKey: corev1.LabelHostname,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "db"},
+						},
+					},
+				},
+			},
+		}
+		vm.Status.UniqueID = "vm-42"
+		vm.Status.NodeName = curHost
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+
+		evaluation = providers.VMHostPlacementEvaluation{
+			Violations:     []string{"anti-affinity term 0 matches 1 VMs on the host"},
+			TargetHost:     targetHost,
+			TargetHostMoID: targetMoID,
+			TargetPoolMoID: targetPoolMoID,
+		}
+		relocateErr = nil
+		relocatedMoIDs = nil
+		relocatedPools = nil
+		evaluateCalled = false
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.Rebalancer.Enabled = true
+			config.Rebalancer.Interval = 10 * time.Minute
+			config.Rebalancer.MaxMovesPerInterval = 5
+		})
+		reconciler = rebalancer.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.EvaluateVirtualMachineHostPlacementFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachine) (providers.VMHostPlacementEvaluation, error) {
+
+			evaluateCalled = true
+			return evaluation, nil
+		}
+		fakeVMProvider.RelocateVirtualMachineToHostFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachine,
+			hostMoID, poolMoID string) error {
+
+			relocatedMoIDs = append(relocatedMoIDs, hostMoID)
+			relocatedPools = append(relocatedPools, poolMoID)
+			return relocateErr
+		}
+		vmCtx = &pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  ctx.Logger.WithName(vm.Name),
+			VM:      vm,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider = nil
+		vm = nil
+		vmCtx = nil
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, vm)
+		})
+
+		reconcileVM := func() (reconcile.Result, *vmopv1.VirtualMachine) {
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			obj := &vmopv1.VirtualMachine{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			return result, obj
+		}
+
+		It("requeues the VM after the interval", func() {
+			result, obj := reconcileVM()
+			Expect(result.RequeueAfter).To(Equal(pkgcfg.FromContext(ctx).Rebalancer.Interval))
+			Expect(conditions.IsTrue(obj, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeTrue())
+		})
+
+		When("the VM does not have preferred rules across hosts", func() {
+			BeforeEach(func() {
+				vm.Spec.Affinity = nil
+				conditions.MarkTrue(vm, vmopv1.VirtualMachinePlacementOptimalCondition)
+			})
+
+			It("removes the condition and does not requeue", func() {
+				result, obj := reconcileVM()
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(conditions.Get(obj, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeNil())
+				Expect(evaluateCalled).To(BeFalse())
+			})
+		})
+	})
+
+	Context("ReconcileNormal", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = reconciler.ReconcileNormal(vmCtx)
+		})
+
+		expectCondition := func(reason string) {
+			c := conditions.Get(vm, vmopv1.VirtualMachinePlacementOptimalCondition)
+			Expect(c).ToNot(BeNil())
+			Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			Expect(c.Reason).To(Equal(reason))
+		}
+
+		When("the VM has not been placed", func() {
+			BeforeEach(func() {
+				vm.Status.NodeName = ""
+			})
+
+			It("does not evaluate the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evaluateCalled).To(BeFalse())
+				Expect(conditions.Get(vm, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeNil())
+			})
+		})
+
+		When("the VM is paused", func() {
+			BeforeEach(func() {
+				vm.Annotations = map[string]string{vmopv1.PauseAnnotation: ""}
+			})
+
+			It("does not evaluate the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evaluateCalled).To(BeFalse())
+			})
+		})
+
+		When("no preferred rules are violated", func() {
+			BeforeEach(func() {
+				evaluation = providers.VMHostPlacementEvaluation{}
+			})
+
+			It("marks the condition true", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.IsTrue(vm, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeTrue())
+				Expect(relocatedMoIDs).To(BeEmpty())
+			})
+		})
+
+		When("there is no better host", func() {
+			BeforeEach(func() {
+				evaluation.TargetHost = ""
+				evaluation.TargetHostMoID = ""
+			})
+
+			It("marks the condition false", func() {
+				Expect(err).ToNot(HaveOccurred())
+				expectCondition(vmopv1.VirtualMachinePlacementNoBetterHostReason)
+				Expect(relocatedMoIDs).To(BeEmpty())
+			})
+		})
+
+		When("there is a better host", func() {
+			It("moves the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(relocatedMoIDs).To(Equal([]string{targetMoID}))
+				Expect(relocatedPools).To(Equal([]string{targetPoolMoID}))
+				Expect(conditions.IsTrue(vm, vmopv1.VirtualMachinePlacementOptimalCondition)).To(BeTrue())
+				Expect(ctx.Events).To(Receive(ContainSubstring("Relocated")))
+			})
+
+			When("the move fails", func() {
+				BeforeEach(func() {
+					relocateErr = errors.New("fake")
+				})
+
+				It("marks the condition false and emits a warning", func() {
+					Expect(err).ToNot(HaveOccurred())
+					expectCondition(vmopv1.VirtualMachinePlacementRelocateFailedReason)
+					Expect(ctx.Events).To(Receive(ContainSubstring("RelocateFailed")))
+				})
+			})
+
+			When("the moves are throttled", func() {
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.Rebalancer.MaxMovesPerInterval = 1
+					})
+
+					vm2 := vm.DeepCopy()
+					vm2.Name = "another-vm"
+					vm2.Status.Conditions = nil
+					Expect(reconciler.ReconcileNormal(&pkgctx.VirtualMachineContext{
+						Context: ctx,
+						Logger:  ctx.Logger,
+						VM:      vm2,
+					})).To(Succeed())
+
+					c := conditions.Get(vm2, vmopv1.VirtualMachinePlacementOptimalCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachinePlacementPreferredRulesViolatedReason))
+				})
+
+				It("does not move the next VM", func() {
+					Expect(relocatedMoIDs).To(HaveLen(1))
+				})
+			})
+
+			When("the move would exceed a disruption budget", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &vmopv1.VirtualMachineDisruptionBudget{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "my-budget",
+							Namespace: vm.Namespace,
+						},
+						Spec: vmopv1.VirtualMachineDisruptionBudgetSpec{
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "db"},
+							},
+							MaxUnavailable: ptr.To[int32](0),
+						},
+					})
+				})
+
+				It("does not move the VM", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(relocatedMoIDs).To(BeEmpty())
+					expectCondition(vmopv1.VirtualMachinePlacementDisruptionBudgetExceededReason)
+				})
+			})
+		})
+	})
+}
@@ -28,6 +28,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
 )
 
@@ -60,7 +61,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	)
 
 	c, err := controller.New(controllerName, mgr, controller.Options{
-		Reconciler:     r,
+		Reconciler:     pkgtracing.Reconciler(controllerNameShort, r),
 		LogConstructor: pkglog.ControllerLogConstructor(controllerNameShort, &spqv1.StoragePolicyUsage{}, mgr.GetScheme()),
 	})
 	if err != nil {
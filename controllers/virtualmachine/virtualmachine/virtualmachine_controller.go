@@ -40,6 +40,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
@@ -185,7 +186,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		)
 	}
 
-	return builder.Complete(r)
+	return builder.Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 // classToVMMapperFn returns a mapper function that can be used to queue reconcile request
@@ -525,6 +526,10 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 
 	ctx.Logger.Info("Reconciling VirtualMachine")
 
+	// The mutation webhook records the profiles applied to the VM in an
+	// annotation since it cannot update the VM's status.
+	ctx.VM.Status.AppliedProfiles = vmopv1util.AppliedProfiles(*ctx.VM)
+
 	defer func(beforeVMStatus *vmopv1.VirtualMachineStatus) {
 		if pkgerr.IsNoRequeueError(reterr) {
 			ctx.Logger.V(4).Info(
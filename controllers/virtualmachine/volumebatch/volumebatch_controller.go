@@ -29,6 +29,7 @@ import (
 
 	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/api/v1alpha1"
 	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
@@ -88,7 +89,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	)
 
 	c, err := controller.New(controllerName, mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              pkgtracing.Reconciler(controllerNameShort, r),
 		MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
 		LogConstructor:          pkglog.ControllerLogConstructor(controllerNameShort, &vmopv1.VirtualMachine{}, mgr.GetScheme()),
 	})
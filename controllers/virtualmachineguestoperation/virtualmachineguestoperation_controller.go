@@ -0,0 +1,643 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+)
+
+const (
+	delTTLMsg = "%s vm guest operation due to TTL expired"
+
+	// MaxOutputBytes is the number of bytes of a program's standard output
+	// and standard error that are kept in the status.
+	MaxOutputBytes = 16 * 1024
+
+	// GuestOperationFailedReason is the reason of the warning event that is
+	// emitted when a guest operation fails.
+	GuestOperationFailedReason = "GuestOperationFailed"
+
+	// GuestOperationSucceededReason is the reason of the event that is
+	// emitted when a guest operation succeeds.
+	GuestOperationSucceededReason = "GuestOperationSucceeded"
+
+	defaultMaxSizeBytes   = 1024 * 1024
+	defaultTimeoutSeconds = 300
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineGuestOperation{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		}).
+		WatchesRawSource(source.Channel(
+			cource.FromContextWithBuffer(ctx, controlledTypeName, 100),
+			&handler.EnqueueRequestForObject{})).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// operation is a guest operation that is being performed in the background.
+type operation struct {
+	// data and result may only be read once the operation is done.
+	data   []byte
+	result providers.GuestProgramResult
+}
+
+// reconcileOnOperationDone reconciles a guest operation once the operation is
+// done.
+var reconcileOnOperationDone = asyncop.ReconcileOnDone(
+	"VirtualMachineGuestOperation",
+	func() client.Object { return &vmopv1.VirtualMachineGuestOperation{} })
+
+// Reconciler reconciles a VirtualMachineGuestOperation object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+
+	operations *asyncop.Tracker[*operation]
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+		operations: asyncop.NewTracker[*operation](reconcileOnOperationDone),
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile reconciles a VirtualMachineGuestOperation object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+	ctx = cource.JoinContext(ctx, r.Context)
+
+	guestOp := &vmopv1.VirtualMachineGuestOperation{}
+	if err := r.Get(ctx, req.NamespacedName, guestOp); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.operations.Cancel(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	guestOpCtx := &pkgctx.VirtualMachineGuestOperationContext{
+		Context:        ctx,
+		Logger:         pkglog.FromContextOrDefault(ctx),
+		GuestOperation: guestOp,
+	}
+	patchHelper, err := patch.NewHelper(guestOp, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"failed to init patch helper for %s/%s: %w",
+			guestOp.Namespace,
+			guestOp.Name,
+			err)
+	}
+
+	// The patch is skipped when the status is updated when the operation is
+	// started.
+	defer func() {
+		if guestOpCtx.SkipPatch {
+			return
+		}
+
+		if err := patchHelper.Patch(ctx, guestOp); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			guestOpCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !guestOp.DeletionTimestamp.IsZero() {
+		// A program that is running in the guest is terminated by the
+		// provider when the operation is canceled.
+		r.operations.Cancel(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	return pkgerr.ResultFromError(r.ReconcileNormal(guestOpCtx))
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineGuestOperationContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineGuestOperation")
+	guestOp := ctx.GuestOperation
+
+	if !guestOp.Status.CompletionTime.IsZero() {
+		return r.reconcileSpecTTL(ctx)
+	}
+
+	if guestOp.Status.StartTime.IsZero() {
+		guestOp.Status.StartTime = metav1.NewTime(time.Now())
+	}
+
+	key := client.ObjectKeyFromObject(guestOp)
+
+	inProgress := conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete) ==
+		vmopv1.VirtualMachineGuestOperationInProgressReason
+
+	if r.operations.IsLost(key, inProgress) {
+		// The operation was lost, ex. because the pod restarted. It is not
+		// retried since it may have already had side effects in the guest.
+		r.markFailed(ctx,
+			vmopv1.VirtualMachineGuestOperationFailedReason,
+			errors.New("the operation was interrupted"))
+		return nil
+	}
+
+	op := r.operations.Get(key)
+	if op == nil {
+		return r.startOperation(ctx)
+	}
+	if !op.IsDone() {
+		// The operation is reconciled again once it is done.
+		return nil
+	}
+
+	if err := r.processResult(ctx, op.Value(), op.Err()); err != nil {
+		return err
+	}
+
+	r.operations.Forget(key)
+	return nil
+}
+
+// startOperation gets the VM and the data the operation needs, and starts the
+// operation in the background.
+func (r *Reconciler) startOperation(ctx *pkgctx.VirtualMachineGuestOperationContext) error {
+	guestOp := ctx.GuestOperation
+	spec := guestOp.Spec
+
+	vm := &vmopv1.VirtualMachine{}
+	vmKey := client.ObjectKey{Namespace: guestOp.Namespace, Name: spec.VMName}
+	if err := r.Get(ctx, vmKey, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(guestOp,
+				vmopv1.VirtualMachineGuestOperationConditionComplete,
+				vmopv1.VirtualMachineGuestOperationVMNotFoundReason,
+				err)
+		}
+		return fmt.Errorf("failed to get VirtualMachine %v: %w", vmKey, err)
+	}
+
+	creds, err := r.getCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	var fn func(context.Context, *operation) error
+
+	switch {
+	case spec.CopyIn != nil:
+		data, err := r.getCopyInData(ctx)
+		if err != nil {
+			return err
+		}
+		copyIn := *spec.CopyIn
+		fn = func(opCtx context.Context, op *operation) error {
+			op.data = data
+			return r.VMProvider.CopyFileToVirtualMachineGuest(
+				opCtx, vm, creds, copyIn.GuestPath, data, copyIn.Overwrite)
+		}
+
+	case spec.CopyOut != nil:
+		if err := r.checkCopyOutTarget(ctx); err != nil {
+			return err
+		}
+		copyOut := *spec.CopyOut
+		maxSize := copyOut.MaxSizeBytes
+		if maxSize == 0 {
+			maxSize = defaultMaxSizeBytes
+		}
+		fn = func(opCtx context.Context, op *operation) error {
+			var err error
+			op.data, err = r.VMProvider.CopyFileFromVirtualMachineGuest(
+				opCtx, vm, creds, copyOut.GuestPath, maxSize)
+			return err
+		}
+
+	case spec.RunProgram != nil:
+		programSpec := programSpecFrom(*spec.RunProgram)
+		timeout := spec.RunProgram.TimeoutSeconds
+		if timeout == 0 {
+			timeout = defaultTimeoutSeconds
+		}
+		fn = func(opCtx context.Context, op *operation) error {
+			opCtx, cancel := context.WithTimeout(opCtx, time.Duration(timeout)*time.Second)
+			defer cancel()
+			var err error
+			op.result, err = r.VMProvider.RunProgramInVirtualMachineGuest(
+				opCtx, vm, creds, programSpec)
+			return err
+		}
+
+	default:
+		// The validation webhook prevents this.
+		r.markFailed(ctx,
+			vmopv1.VirtualMachineGuestOperationFailedReason,
+			errors.New("one of copyIn, copyOut, or runProgram is required"))
+		return nil
+	}
+
+	conditions.MarkFalse(guestOp,
+		vmopv1.VirtualMachineGuestOperationConditionComplete,
+		vmopv1.VirtualMachineGuestOperationInProgressReason,
+		"operation started")
+
+	// Update the status instead of patching it, and before the operation is
+	// started, so the operation is known to be lost, rather than started
+	// again, if the pod restarts while it is running. The update is rejected
+	// if the object is stale, so the operation is never started twice.
+	ctx.SkipPatch = true
+	if err := r.Client.Status().Update(ctx, guestOp); err != nil {
+		ctx.Logger.Error(err, "update VirtualMachineGuestOperation status failed")
+		return err
+	}
+
+	r.runOperation(ctx, fn)
+	ctx.Logger.Info("Started guest operation", "vmName", spec.VMName)
+
+	return nil
+}
+
+// runOperation runs fn in the background and reconciles the guest operation
+// once it returns.
+func (r *Reconciler) runOperation(
+	ctx *pkgctx.VirtualMachineGuestOperationContext,
+	fn asyncop.Func[*operation]) {
+
+	logger := ctx.Logger
+
+	// The operation outlives this reconcile, so its context is only canceled
+	// when the guest operation is deleted.
+	r.operations.Start(ctx, client.ObjectKeyFromObject(ctx.GuestOperation), &operation{},
+		func(opCtx context.Context, op *operation) error {
+			err := fn(opCtx, op)
+			if err != nil {
+				logger.Error(err, "Guest operation failed")
+			}
+			return err
+		})
+}
+
+// processResult updates the status with the result of the operation, and
+// creates the Secret for a file that was copied out of the guest.
+func (r *Reconciler) processResult(
+	ctx *pkgctx.VirtualMachineGuestOperationContext,
+	op *operation,
+	opErr error) error {
+
+	guestOp := ctx.GuestOperation
+	spec := guestOp.Spec
+
+	switch {
+	case spec.CopyIn != nil:
+		if opErr != nil {
+			r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationFailedReason, opErr)
+			return nil
+		}
+		guestOp.Status.FileSizeBytes = int64(len(op.data))
+
+	case spec.CopyOut != nil:
+		if opErr != nil {
+			reason := vmopv1.VirtualMachineGuestOperationFailedReason
+			if errors.Is(opErr, providers.ErrGuestFileTooLarge) {
+				reason = vmopv1.VirtualMachineGuestOperationFileTooLargeReason
+			}
+			r.markFailed(ctx, reason, opErr)
+			return nil
+		}
+		if err := r.createCopyOutSecret(ctx, op.data); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationTargetInvalidReason,
+					fmt.Errorf("secret %s already exists", spec.CopyOut.SecretName))
+				return nil
+			}
+			return err
+		}
+		guestOp.Status.FileSizeBytes = int64(len(op.data))
+
+	case spec.RunProgram != nil:
+		if opErr != nil {
+			if errors.Is(opErr, context.DeadlineExceeded) {
+				r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationTimedOutReason,
+					fmt.Errorf("program did not exit within %d seconds", timeoutSeconds(spec.RunProgram)))
+				return nil
+			}
+			r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationFailedReason, opErr)
+			return nil
+		}
+
+		exitCode := op.result.ExitCode
+		guestOp.Status.ExitCode = &exitCode
+		guestOp.Status.Stdout = string(op.result.Stdout)
+		guestOp.Status.Stderr = string(op.result.Stderr)
+		guestOp.Status.OutputTruncated = op.result.OutputTruncated
+
+		if exitCode != 0 {
+			r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationExitCodeNonZeroReason,
+				fmt.Errorf("program exited with code %d", exitCode))
+			return nil
+		}
+	}
+
+	r.markSucceeded(ctx)
+	return nil
+}
+
+// getCredentials returns the guest credentials from the operation's
+// credentials Secret.
+func (r *Reconciler) getCredentials(
+	ctx *pkgctx.VirtualMachineGuestOperationContext) (providers.GuestCredentials, error) {
+
+	guestOp := ctx.GuestOperation
+
+	secret := &corev1.Secret{}
+	objKey := client.ObjectKey{Namespace: guestOp.Namespace, Name: guestOp.Spec.CredentialsSecretName}
+	if err := r.Get(ctx, objKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(guestOp,
+				vmopv1.VirtualMachineGuestOperationConditionComplete,
+				vmopv1.VirtualMachineGuestOperationCredentialsInvalidReason,
+				err)
+		}
+		return providers.GuestCredentials{}, fmt.Errorf("failed to get credentials secret %v: %w", objKey, err)
+	}
+
+	creds := providers.GuestCredentials{
+		Username: string(secret.Data[vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey]),
+		Password: string(secret.Data[vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey]),
+	}
+	if creds.Username == "" || creds.Password == "" {
+		err := fmt.Errorf("credentials secret %s must have the %s and %s keys",
+			objKey.Name,
+			vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey,
+			vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey)
+		r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationCredentialsInvalidReason, err)
+		return providers.GuestCredentials{}, pkgerr.NoRequeueError{Message: err.Error()}
+	}
+
+	return creds, nil
+}
+
+// getCopyInData returns the data of the Secret or ConfigMap key that is
+// copied into the guest.
+func (r *Reconciler) getCopyInData(ctx *pkgctx.VirtualMachineGuestOperationContext) ([]byte, error) {
+	var (
+		guestOp = ctx.GuestOperation
+		src     = guestOp.Spec.CopyIn.Source
+		obj     client.Object
+		objKey  = client.ObjectKey{Namespace: guestOp.Namespace}
+		key     string
+	)
+
+	switch {
+	case src.Secret != nil:
+		obj = &corev1.Secret{}
+		objKey.Name, key = src.Secret.Name, src.Secret.Key
+	case src.ConfigMap != nil:
+		obj = &corev1.ConfigMap{}
+		objKey.Name, key = src.ConfigMap.Name, src.ConfigMap.Key
+	default:
+		// The validation webhook prevents this.
+		err := errors.New("one of secret or configMap is required")
+		r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationSourceInvalidReason, err)
+		return nil, pkgerr.NoRequeueError{Message: err.Error()}
+	}
+
+	kind := reflect.TypeOf(obj).Elem().Name()
+
+	if err := r.Get(ctx, objKey, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(guestOp,
+				vmopv1.VirtualMachineGuestOperationConditionComplete,
+				vmopv1.VirtualMachineGuestOperationSourceInvalidReason,
+				err)
+		}
+		return nil, fmt.Errorf("failed to get %s %v: %w", kind, objKey, err)
+	}
+
+	var (
+		data []byte
+		ok   bool
+	)
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		data, ok = o.Data[key]
+	case *corev1.ConfigMap:
+		if s, found := o.Data[key]; found {
+			data, ok = []byte(s), true
+		} else {
+			data, ok = o.BinaryData[key]
+		}
+	}
+	if !ok {
+		err := fmt.Errorf("%s %s does not have the key %s", kind, objKey.Name, key)
+		r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationSourceInvalidReason, err)
+		return nil, pkgerr.NoRequeueError{Message: err.Error()}
+	}
+
+	return data, nil
+}
+
+// checkCopyOutTarget fails the operation if the Secret into which the guest
+// file is copied already exists.
+func (r *Reconciler) checkCopyOutTarget(ctx *pkgctx.VirtualMachineGuestOperationContext) error {
+	guestOp := ctx.GuestOperation
+
+	objKey := client.ObjectKey{Namespace: guestOp.Namespace, Name: guestOp.Spec.CopyOut.SecretName}
+	if err := r.Get(ctx, objKey, &corev1.Secret{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get secret %v: %w", objKey, err)
+	}
+
+	err := fmt.Errorf("secret %s already exists", objKey.Name)
+	r.markFailed(ctx, vmopv1.VirtualMachineGuestOperationTargetInvalidReason, err)
+	return pkgerr.NoRequeueError{Message: err.Error()}
+}
+
+// createCopyOutSecret creates the Secret with the contents of the file that
+// was copied out of the guest.
+func (r *Reconciler) createCopyOutSecret(
+	ctx *pkgctx.VirtualMachineGuestOperationContext,
+	data []byte) error {
+
+	guestOp := ctx.GuestOperation
+	copyOut := guestOp.Spec.CopyOut
+
+	key := copyOut.Key
+	if key == "" {
+		key = guestFileBaseName(copyOut.GuestPath)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: guestOp.Namespace,
+			Name:      copyOut.SecretName,
+		},
+		Data: map[string][]byte{
+			key: data,
+		},
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		return fmt.Errorf("failed to create secret %s: %w", copyOut.SecretName, err)
+	}
+
+	return nil
+}
+
+// guestFileBaseName returns the last element of a Linux or Windows guest
+// path.
+func guestFileBaseName(guestPath string) string {
+	return path.Base(strings.ReplaceAll(guestPath, `\`, "/"))
+}
+
+func programSpecFrom(runProgram vmopv1.VirtualMachineGuestOperationRunProgram) providers.GuestProgramSpec {
+	spec := providers.GuestProgramSpec{
+		Path:             runProgram.Path,
+		Args:             runProgram.Args,
+		WorkingDirectory: runProgram.WorkingDirectory,
+		MaxOutputBytes:   MaxOutputBytes,
+	}
+	for _, e := range runProgram.Env {
+		spec.Env = append(spec.Env, e.Name+"="+e.Value)
+	}
+	return spec
+}
+
+func timeoutSeconds(runProgram *vmopv1.VirtualMachineGuestOperationRunProgram) int64 {
+	if runProgram.TimeoutSeconds == 0 {
+		return defaultTimeoutSeconds
+	}
+	return runProgram.TimeoutSeconds
+}
+
+// markSucceeded marks the operation as complete and succeeded.
+func (r *Reconciler) markSucceeded(ctx *pkgctx.VirtualMachineGuestOperationContext) {
+	guestOp := ctx.GuestOperation
+
+	conditions.MarkTrue(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)
+	guestOp.Status.Succeeded = true
+	guestOp.Status.CompletionTime = metav1.Now()
+	r.Recorder.Eventf(guestOp, GuestOperationSucceededReason,
+		"Guest operation in VirtualMachine %s succeeded", guestOp.Spec.VMName)
+	ctx.Logger.Info("VirtualMachineGuestOperation succeeded")
+}
+
+// markFailed marks the operation as complete, but not succeeded.
+func (r *Reconciler) markFailed(
+	ctx *pkgctx.VirtualMachineGuestOperationContext,
+	reason string,
+	err error) {
+
+	guestOp := ctx.GuestOperation
+
+	conditions.MarkError(guestOp,
+		vmopv1.VirtualMachineGuestOperationConditionComplete,
+		reason,
+		err)
+	guestOp.Status.Succeeded = false
+	guestOp.Status.CompletionTime = metav1.Now()
+	r.Recorder.Warn(guestOp, GuestOperationFailedReason, err.Error())
+	ctx.Logger.Info("VirtualMachineGuestOperation failed", "reason", reason, "error", err.Error())
+}
+
+// reconcileSpecTTL deletes the guest operation once the TTL expires.
+func (r *Reconciler) reconcileSpecTTL(ctx *pkgctx.VirtualMachineGuestOperationContext) error {
+	guestOp := ctx.GuestOperation
+
+	// Skip deletion when ttl is nil.
+	if guestOp.Spec.TTLSecondsAfterFinished == nil {
+		return nil
+	}
+
+	// When ttl is zero the expiration time is the same as the completion time
+	// which triggers immediate deletion.
+	expirationTime := guestOp.Status.CompletionTime.Time.Add(
+		time.Duration(*guestOp.Spec.TTLSecondsAfterFinished) * time.Second)
+	if time.Now().Before(expirationTime) {
+		return pkgerr.RequeueError{After: time.Until(expirationTime)}
+	}
+
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleting"))
+	if err := r.Delete(ctx, guestOp); client.IgnoreNotFound(err) != nil {
+		ctx.Logger.Error(err, fmt.Sprintf(delTTLMsg, "failed to delete"))
+		return err
+	}
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleted"))
+	return nil
+}
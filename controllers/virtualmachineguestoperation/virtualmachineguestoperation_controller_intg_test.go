@@ -0,0 +1,114 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx         *builder.IntegrationTestContext
+		vm          *vmopv1.VirtualMachine
+		credsSecret *corev1.Secret
+		guestOp     *vmopv1.VirtualMachineGuestOperation
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		intgFakeVMProvider.Lock()
+		intgFakeVMProvider.RunProgramInVirtualMachineGuestFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachine,
+			_ providers.GuestCredentials,
+			_ providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+			return providers.GuestProgramResult{Stdout: []byte("hello")}, nil
+		}
+		intgFakeVMProvider.Unlock()
+
+		vm = builder.DummyBasicVirtualMachine("my-vm", ctx.Namespace)
+		credsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-creds",
+				Namespace: ctx.Namespace,
+			},
+			StringData: map[string]string{
+				vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey: "root",
+				vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey: "secret",
+			},
+		}
+		guestOp = &vmopv1.VirtualMachineGuestOperation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-op",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineGuestOperationSpec{
+				VMName:                vm.Name,
+				CredentialsSecretName: credsSecret.Name,
+				RunProgram: &vmopv1.VirtualMachineGuestOperationRunProgram{
+					Path: "/bin/echo",
+					Args: []string{"hello"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	getGuestOperation := func() *vmopv1.VirtualMachineGuestOperation {
+		obj := &vmopv1.VirtualMachineGuestOperation{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(guestOp), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	It("runs the program and reports its output", func() {
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, credsSecret)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, guestOp)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := getGuestOperation()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineGuestOperationConditionComplete)).To(BeTrue())
+			g.Expect(obj.Status.Succeeded).To(BeTrue())
+			g.Expect(obj.Status.ExitCode).ToNot(BeNil())
+			g.Expect(*obj.Status.ExitCode).To(BeZero())
+			g.Expect(obj.Status.Stdout).To(Equal("hello"))
+			g.Expect(obj.Status.CompletionTime.IsZero()).To(BeFalse())
+		}).Should(Succeed())
+	})
+}
@@ -0,0 +1,549 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestoperation"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		vmName          = "my-vm"
+		credsSecretName = "my-creds"
+	)
+
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		reconciler     *virtualmachineguestoperation.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+		vm             *vmopv1.VirtualMachine
+		credsSecret    *corev1.Secret
+		guestOp        *vmopv1.VirtualMachineGuestOperation
+		guestOpCtx     *pkgctx.VirtualMachineGuestOperationContext
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachine(vmName, builder.DummyNamespaceName)
+		credsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      credsSecretName,
+				Namespace: builder.DummyNamespaceName,
+			},
+			Data: map[string][]byte{
+				vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey: []byte("root"),
+				vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey: []byte("secret"),
+			},
+		}
+		guestOp = &vmopv1.VirtualMachineGuestOperation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-op",
+				Namespace: builder.DummyNamespaceName,
+			},
+			Spec: vmopv1.VirtualMachineGuestOperationSpec{
+				VMName:                vmName,
+				CredentialsSecretName: credsSecretName,
+				RunProgram: &vmopv1.VirtualMachineGuestOperationRunProgram{
+					Path: "/usr/bin/hostname",
+					Args: []string{"-f"},
+					Env: []vmopv1common.NameValuePair{
+						{Name: "LANG", Value: "C"},
+					},
+					TimeoutSeconds: 60,
+				},
+			},
+		}
+		initObjects = []client.Object{vm, credsSecret}
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, guestOp)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineguestoperation.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		guestOpCtx = &pkgctx.VirtualMachineGuestOperationContext{
+			Context:        cource.WithContext(ctx),
+			Logger:         ctx.Logger.WithName(guestOp.Name),
+			GuestOperation: guestOp,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider = nil
+		vm = nil
+		credsSecret = nil
+		guestOp = nil
+		guestOpCtx = nil
+	})
+
+	// reconcileUntilComplete reconciles the guest operation until it is
+	// complete.
+	reconcileUntilComplete := func() {
+		Eventually(func(g Gomega) {
+			g.Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+			g.Expect(guestOp.Status.CompletionTime.IsZero()).To(BeFalse())
+		}).Should(Succeed())
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{credsSecret}
+			})
+
+			It("returns an error and sets Complete to false", func() {
+				err := reconciler.ReconcileNormal(guestOpCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationVMNotFoundReason))
+				Expect(guestOp.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(guestOp.Status.CompletionTime.IsZero()).To(BeTrue())
+			})
+		})
+
+		When("the credentials secret does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{vm}
+			})
+
+			It("returns an error and sets Complete to false", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(MatchError(ContainSubstring(credsSecretName)))
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationCredentialsInvalidReason))
+				Expect(guestOp.Status.CompletionTime.IsZero()).To(BeTrue())
+			})
+		})
+
+		When("the credentials secret does not have a password", func() {
+			BeforeEach(func() {
+				delete(credsSecret.Data, vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey)
+			})
+
+			It("fails the operation", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(BeAssignableToTypeOf(pkgerr.NoRequeueError{}))
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationCredentialsInvalidReason))
+				Expect(guestOp.Status.CompletionTime.IsZero()).To(BeFalse())
+				Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineguestoperation.GuestOperationFailedReason)))
+			})
+		})
+
+		When("a program is run", func() {
+			var (
+				release   chan struct{}
+				specs     chan providers.GuestProgramSpec
+				result    providers.GuestProgramResult
+				resultErr error
+			)
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+				specs = make(chan providers.GuestProgramSpec, 1)
+				result = providers.GuestProgramResult{
+					Stdout: []byte("my-vm.local\n"),
+				}
+				resultErr = nil
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.RunProgramInVirtualMachineGuestFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					creds providers.GuestCredentials,
+					spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+					defer GinkgoRecover()
+					Expect(creds).To(Equal(providers.GuestCredentials{Username: "root", Password: "secret"}))
+					specs <- spec
+					<-release
+					return result, resultErr
+				}
+			})
+
+			It("reports the exit code and output", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+				Expect(guestOp.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationInProgressReason))
+
+				Eventually(specs).Should(Receive(Equal(providers.GuestProgramSpec{
+					Path:           "/usr/bin/hostname",
+					Args:           []string{"-f"},
+					Env:            []string{"LANG=C"},
+					MaxOutputBytes: virtualmachineguestoperation.MaxOutputBytes,
+				})))
+
+				By("reconciling while the program is running", func() {
+					Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationInProgressReason))
+				})
+
+				close(release)
+
+				By("reconciling once the program exited", func() {
+					reconcileUntilComplete()
+					Expect(conditions.IsTrue(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).To(BeTrue())
+					Expect(guestOp.Status.Succeeded).To(BeTrue())
+					Expect(guestOp.Status.ExitCode).To(Equal(ptr.To[int32](0)))
+					Expect(guestOp.Status.Stdout).To(Equal("my-vm.local\n"))
+					Expect(guestOp.Status.Stderr).To(BeEmpty())
+					Expect(guestOp.Status.OutputTruncated).To(BeFalse())
+					Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineguestoperation.GuestOperationSucceededReason)))
+				})
+			})
+
+			It("persists the status before the program is run", func() {
+				fakeVMProvider.RunProgramInVirtualMachineGuestFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ providers.GuestCredentials,
+					spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+					defer GinkgoRecover()
+					obj := &vmopv1.VirtualMachineGuestOperation{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(guestOp), obj)).To(Succeed())
+					Expect(obj.Status.StartTime.IsZero()).To(BeFalse())
+					Expect(conditions.GetReason(obj, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationInProgressReason))
+					specs <- spec
+					return result, nil
+				}
+
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+				Expect(guestOpCtx.SkipPatch).To(BeTrue())
+				Eventually(specs).Should(Receive())
+				reconcileUntilComplete()
+				Expect(guestOp.Status.Succeeded).To(BeTrue())
+			})
+
+			When("the status cannot be updated", func() {
+				JustBeforeEach(func() {
+					guestOp.ResourceVersion = "1"
+				})
+
+				It("does not run the program", func() {
+					err := reconciler.ReconcileNormal(guestOpCtx)
+					Expect(apierrors.IsConflict(err)).To(BeTrue())
+					Consistently(specs).ShouldNot(Receive())
+				})
+			})
+
+			When("the program exits with a non-zero code", func() {
+				BeforeEach(func() {
+					result = providers.GuestProgramResult{
+						ExitCode:        2,
+						Stderr:          []byte("invalid option"),
+						OutputTruncated: true,
+					}
+				})
+
+				It("fails the operation", func() {
+					close(release)
+					reconcileUntilComplete()
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationExitCodeNonZeroReason))
+					Expect(guestOp.Status.Succeeded).To(BeFalse())
+					Expect(guestOp.Status.ExitCode).To(Equal(ptr.To[int32](2)))
+					Expect(guestOp.Status.Stderr).To(Equal("invalid option"))
+					Expect(guestOp.Status.OutputTruncated).To(BeTrue())
+					Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineguestoperation.GuestOperationFailedReason)))
+				})
+			})
+
+			When("the program times out", func() {
+				BeforeEach(func() {
+					resultErr = fmt.Errorf("waiting for program: %w", context.DeadlineExceeded)
+				})
+
+				It("fails the operation", func() {
+					close(release)
+					reconcileUntilComplete()
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationTimedOutReason))
+					Expect(conditions.GetMessage(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(ContainSubstring("60 seconds"))
+					Expect(guestOp.Status.ExitCode).To(BeNil())
+				})
+			})
+
+			When("the program fails to start", func() {
+				BeforeEach(func() {
+					resultErr = errors.New("file not found")
+				})
+
+				It("fails the operation", func() {
+					close(release)
+					reconcileUntilComplete()
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationFailedReason))
+
+					By("not running the program again", func() {
+						Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+						Expect(specs).To(HaveLen(1))
+					})
+				})
+			})
+		})
+
+		When("a file is copied into the guest", func() {
+			var copied chan string
+
+			BeforeEach(func() {
+				copied = make(chan string, 1)
+				guestOp.Spec.RunProgram = nil
+				guestOp.Spec.CopyIn = &vmopv1.VirtualMachineGuestOperationCopyIn{
+					Source: vmopv1.VirtualMachineGuestOperationFileSource{
+						ConfigMap: &vmopv1.ConfigMapKeySelector{
+							Name: "my-config",
+							Key:  "app.conf",
+						},
+					},
+					GuestPath: "/etc/app.conf",
+					Overwrite: true,
+				}
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.CopyFileToVirtualMachineGuestFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ providers.GuestCredentials,
+					guestPath string,
+					data []byte,
+					overwrite bool) error {
+
+					copied <- fmt.Sprintf("%s:%s:%t", guestPath, data, overwrite)
+					return nil
+				}
+			})
+
+			It("returns an error when the source does not exist", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(MatchError(ContainSubstring("my-config")))
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationSourceInvalidReason))
+				Expect(guestOp.Status.CompletionTime.IsZero()).To(BeTrue())
+			})
+
+			When("the source exists", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "my-config",
+							Namespace: builder.DummyNamespaceName,
+						},
+						BinaryData: map[string][]byte{
+							"app.conf": []byte("debug=true"),
+						},
+					})
+				})
+
+				It("copies the file", func() {
+					reconcileUntilComplete()
+					Expect(copied).To(Receive(Equal("/etc/app.conf:debug=true:true")))
+					Expect(guestOp.Status.Succeeded).To(BeTrue())
+					Expect(guestOp.Status.FileSizeBytes).To(BeEquivalentTo(len("debug=true")))
+				})
+
+				When("the source does not have the key", func() {
+					BeforeEach(func() {
+						guestOp.Spec.CopyIn.Source.ConfigMap.Key = "other.conf"
+					})
+
+					It("fails the operation", func() {
+						Expect(reconciler.ReconcileNormal(guestOpCtx)).To(BeAssignableToTypeOf(pkgerr.NoRequeueError{}))
+						Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+							To(Equal(vmopv1.VirtualMachineGuestOperationSourceInvalidReason))
+						Expect(guestOp.Status.CompletionTime.IsZero()).To(BeFalse())
+					})
+				})
+			})
+		})
+
+		When("a file is copied out of the guest", func() {
+			var copyErr error
+
+			BeforeEach(func() {
+				copyErr = nil
+				guestOp.Spec.RunProgram = nil
+				guestOp.Spec.CopyOut = &vmopv1.VirtualMachineGuestOperationCopyOut{
+					GuestPath:    `C:\ProgramData\app\app.log`,
+					SecretName:   "my-log",
+					MaxSizeBytes: 1024,
+				}
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.CopyFileFromVirtualMachineGuestFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ providers.GuestCredentials,
+					_ string,
+					maxSize int64) ([]byte, error) {
+
+					defer GinkgoRecover()
+					Expect(maxSize).To(BeEquivalentTo(1024))
+					return []byte("started"), copyErr
+				}
+			})
+
+			It("creates the secret with the file", func() {
+				reconcileUntilComplete()
+				Expect(guestOp.Status.Succeeded).To(BeTrue())
+				Expect(guestOp.Status.FileSizeBytes).To(BeEquivalentTo(len("started")))
+
+				secret := &corev1.Secret{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: guestOp.Namespace, Name: "my-log"}, secret)).
+					To(Succeed())
+				Expect(secret.Data).To(Equal(map[string][]byte{"app.log": []byte("started")}))
+			})
+
+			When("the key is specified", func() {
+				BeforeEach(func() {
+					guestOp.Spec.CopyOut.Key = "log"
+				})
+
+				It("creates the secret with the key", func() {
+					reconcileUntilComplete()
+					secret := &corev1.Secret{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: guestOp.Namespace, Name: "my-log"}, secret)).
+						To(Succeed())
+					Expect(secret.Data).To(HaveKey("log"))
+				})
+			})
+
+			When("the file is too large", func() {
+				BeforeEach(func() {
+					copyErr = fmt.Errorf("%w: app.log", providers.ErrGuestFileTooLarge)
+				})
+
+				It("fails the operation", func() {
+					reconcileUntilComplete()
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationFileTooLargeReason))
+					err := ctx.Client.Get(ctx, client.ObjectKey{Namespace: guestOp.Namespace, Name: "my-log"}, &corev1.Secret{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the secret already exists", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "my-log",
+							Namespace: builder.DummyNamespaceName,
+						},
+					})
+				})
+
+				It("fails the operation", func() {
+					Expect(reconciler.ReconcileNormal(guestOpCtx)).To(BeAssignableToTypeOf(pkgerr.NoRequeueError{}))
+					Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineGuestOperationTargetInvalidReason))
+					Expect(guestOp.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+		})
+
+		When("the operation was interrupted", func() {
+			BeforeEach(func() {
+				conditions.MarkFalse(guestOp,
+					vmopv1.VirtualMachineGuestOperationConditionComplete,
+					vmopv1.VirtualMachineGuestOperationInProgressReason,
+					"operation started")
+			})
+
+			It("fails the operation", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+				Expect(conditions.GetReason(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineGuestOperationFailedReason))
+				Expect(conditions.GetMessage(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)).
+					To(ContainSubstring("interrupted"))
+			})
+		})
+
+		When("the operation is complete", func() {
+			BeforeEach(func() {
+				conditions.MarkTrue(guestOp, vmopv1.VirtualMachineGuestOperationConditionComplete)
+				guestOp.Status.CompletionTime = metav1.Now()
+			})
+
+			It("does not requeue when there is no TTL", func() {
+				Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+			})
+
+			When("the TTL has not expired", func() {
+				BeforeEach(func() {
+					guestOp.Spec.TTLSecondsAfterFinished = ptr.To[int64](60)
+				})
+
+				It("requeues until the TTL expires", func() {
+					err := reconciler.ReconcileNormal(guestOpCtx)
+					requeueErr := pkgerr.RequeueError{}
+					Expect(errors.As(err, &requeueErr)).To(BeTrue())
+					Expect(requeueErr.After).To(BeNumerically("~", time.Minute, time.Second))
+				})
+			})
+
+			When("the TTL has expired", func() {
+				BeforeEach(func() {
+					guestOp.Spec.TTLSecondsAfterFinished = ptr.To[int64](0)
+				})
+
+				It("deletes the operation", func() {
+					Expect(reconciler.ReconcileNormal(guestOpCtx)).To(Succeed())
+					err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(guestOp), &vmopv1.VirtualMachineGuestOperation{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+	})
+}
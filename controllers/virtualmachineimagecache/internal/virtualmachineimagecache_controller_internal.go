@@ -12,4 +12,7 @@ const (
 
 	// NewContentLibraryProviderContextKey is used for testing.
 	NewContentLibraryProviderContextKey
+
+	// NewDeleteCachedFilesClientContextKey is used for testing.
+	NewDeleteCachedFilesClientContextKey
 )
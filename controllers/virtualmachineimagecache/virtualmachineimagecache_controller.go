@@ -22,6 +22,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +44,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	clprov "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
@@ -74,6 +76,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 
 		newCLSProvdrFn: newContentLibraryProviderOrDefault(ctx),
 		newSRIClientFn: newCacheStorageURIsClientOrDefault(ctx),
+		newDCFClientFn: newDeleteCachedFilesClientOrDefault(ctx),
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -85,7 +88,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		WatchesRawSource(source.Channel(
 			cource.FromContextWithBuffer(ctx, "VirtualMachineImageCache", 100),
 			&handler.EnqueueRequestForObject{})).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 // reconciler reconciles a VirtualMachineImageCache object.
@@ -98,6 +101,7 @@ type reconciler struct {
 
 	newCLSProvdrFn newContentLibraryProviderFn
 	newSRIClientFn newCacheStorageURIsClientFn
+	newDCFClientFn newDeleteCachedFilesClientFn
 }
 
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagecaches,verbs=get;list;watch;create;update;patch;delete
@@ -130,7 +134,15 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
-	return pkgerr.ResultFromError(r.ReconcileNormal(ctx, &obj))
+	result, err := pkgerr.ResultFromError(r.ReconcileNormal(ctx, &obj))
+	if err == nil && result.IsZero() &&
+		len(obj.Spec.Locations) > 0 && isEvictionEnabled(ctx) {
+
+		// Requeue the object so its cached files are periodically considered
+		// for eviction.
+		result.RequeueAfter = pkgcfg.FromContext(ctx).ImageCache.EvictionInterval
+	}
+	return result, err
 }
 
 const conditionReasonFailed = "Failed"
@@ -250,8 +262,16 @@ func (r *reconciler) ReconcileNormal(
 				getters,
 				pkgcond.WithStepCounter())
 		}
+
+		// Evict the least recently used cached files if the capacity limits
+		// are exceeded.
+		if err := r.reconcileEviction(ctx, c.VimClient(), obj); err != nil {
+			logger.Error(err, "Failed to evict cached files")
+		}
 	}
 
+	updateSize(obj)
+
 	// Create the object's Ready condition based on its other conditions.
 	pkgcond.SetSummary(obj, pkgcond.WithStepCounter())
 
@@ -395,6 +415,7 @@ func (r *reconciler) reconcileLocations(
 				err)
 		} else {
 			status.Files = cachedFiles
+			status.Size = getSourceFilesSize(srcFiles)
 			conditions = conditions.MarkTrue(vmopv1.ReadyConditionType)
 		}
 
@@ -402,6 +423,19 @@ func (r *reconciler) reconcileLocations(
 	}
 }
 
+// getSourceFilesSize returns the total size of the source files, or nil if the
+// size of any of the files is unknown.
+func getSourceFilesSize(srcFiles []clsutil.SourceFile) *resource.Quantity {
+	var size int64
+	for i := range srcFiles {
+		if srcFiles[i].Size <= 0 {
+			return nil
+		}
+		size += srcFiles[i].Size
+	}
+	return resource.NewQuantity(size, resource.BinarySI)
+}
+
 func (r *reconciler) cacheFiles(
 	ctx context.Context,
 	vimClient *vim25.Client,
@@ -656,6 +690,7 @@ func getSourceFilePathsForOVF(
 			if includeItemFile(s) {
 				srcFiles = append(srcFiles, clsutil.SourceFile{
 					Path: s,
+					Size: is.Size,
 				})
 			}
 		}
@@ -703,6 +738,7 @@ func getSourceFilePathsForVM(
 			if disk.VDiskId != nil {
 				sf.VDiskID = disk.VDiskId.Id
 			}
+			sf.Size = disk.CapacityInBytes
 
 			switch tb := disk.Backing.(type) {
 			case *vimtypes.VirtualDiskFlatVer1BackingInfo:
@@ -729,6 +765,7 @@ func getSourceFilePathsForVM(
 		if strings.EqualFold(path.Ext(f.Name), ".nvram") {
 			srcFiles = append(srcFiles, clsutil.SourceFile{
 				Path: f.Name,
+				Size: f.Size,
 			})
 		}
 	}
@@ -837,6 +874,7 @@ func getDatastores(
 
 type newContentLibraryProviderFn = func(context.Context, *rest.Client) clprov.Provider
 type newCacheStorageURIsClientFn = func(*vim25.Client) clsutil.CacheStorageURIsClient
+type newDeleteCachedFilesClientFn = func(*vim25.Client) clsutil.DeleteCachedFilesClient
 
 func newContentLibraryProviderOrDefault(
 	ctx context.Context) newContentLibraryProviderFn {
@@ -870,6 +908,29 @@ func newCacheStorageURIsClientOrDefault(
 	return out
 }
 
+func newDeleteCachedFilesClientOrDefault(
+	ctx context.Context) newDeleteCachedFilesClientFn {
+
+	out := newDeleteCachedFilesClient
+	obj := ctx.Value(internal.NewDeleteCachedFilesClientContextKey)
+	if fn, ok := obj.(newDeleteCachedFilesClientFn); ok {
+		out = func(c *vim25.Client) clsutil.DeleteCachedFilesClient {
+			if p := fn(c); p != nil {
+				return p
+			}
+			return newDeleteCachedFilesClient(c)
+		}
+	}
+	return out
+}
+
+func newDeleteCachedFilesClient(c *vim25.Client) clsutil.DeleteCachedFilesClient {
+	return &cacheStorageURIsClient{
+		FileManager:        object.NewFileManager(c),
+		VirtualDiskManager: object.NewVirtualDiskManager(c),
+	}
+}
+
 func newCacheStorageURIsClient(c *vim25.Client) clsutil.CacheStorageURIsClient {
 	return &cacheStorageURIsClient{
 		FileManager:        object.NewFileManager(c),
@@ -7,7 +7,9 @@ package virtualmachineimagecache_test
 import (
 	"context"
 	"errors"
+	"io"
 	"path"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +36,7 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	clprov "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
@@ -241,6 +244,10 @@ var _ = Describe(
 					ctx,
 					internal.NewCacheStorageURIsClientContextKey,
 					faker.newCacheStorageURIsClientFn)
+				ctx = context.WithValue(
+					ctx,
+					internal.NewDeleteCachedFilesClientContextKey,
+					faker.newDeleteCachedFilesClientFn)
 
 				provider = providerfake.NewVMProvider()
 
@@ -686,6 +693,157 @@ var _ = Describe(
 				),
 			)
 
+			Context("Eviction", func() {
+				var (
+					deletedMu sync.Mutex
+					deleted   []string
+				)
+
+				BeforeEach(func() {
+					deletedMu.Lock()
+					deleted = nil
+					deletedMu.Unlock()
+
+					recordDelete := func(name string) {
+						deletedMu.Lock()
+						defer deletedMu.Unlock()
+						deleted = append(deleted, name)
+					}
+
+					faker.Lock()
+					faker.fakeDCFClient = true
+					faker.deleteVirtualDiskFn = func(
+						_ context.Context,
+						name string,
+						_ *object.Datacenter) (*object.Task, error) {
+
+						recordDelete(name)
+						return nil, nil
+					}
+					faker.deleteDatastoreFileFn = func(
+						_ context.Context,
+						name string,
+						_ *object.Datacenter) (*object.Task, error) {
+
+						recordDelete(name)
+						return nil, nil
+					}
+					faker.Unlock()
+				})
+
+				AfterEach(func() {
+					pkgcfg.SetContext(vcSimCtx, func(config *pkgcfg.Config) {
+						config.ImageCache = pkgcfg.Default().ImageCache
+					})
+				})
+
+				getDeleted := func() []string {
+					deletedMu.Lock()
+					defer deletedMu.Unlock()
+					return slices.Clone(deleted)
+				}
+
+				createCachedObj := func() ctrlclient.ObjectKey {
+					obj := getVMICacheObj(
+						nsInfo.Namespace,
+						itemIDVM,
+						itemVersionVM,
+						vmopv1.VirtualMachineImageCacheLocationSpec{
+							DatacenterID: vcSimCtx.Datacenter.Reference().Value,
+							DatastoreID:  vcSimCtx.Datastore.Reference().Value,
+							ProfileID:    vcSimCtx.StorageProfileID,
+						})
+					Expect(vcSimCtx.Client.Create(ctx, &obj)).To(Succeed())
+					key := ctrlclient.ObjectKey{
+						Namespace: obj.Namespace,
+						Name:      obj.Name,
+					}
+
+					// Wait for the files to be cached before enabling
+					// eviction.
+					Eventually(func(g Gomega) {
+						var obj vmopv1.VirtualMachineImageCache
+						g.Expect(vcSimCtx.Client.Get(ctx, key, &obj)).To(Succeed())
+						assertCondTrue(g, obj, cndFilReady)
+						g.Expect(obj.Status.Locations).To(HaveLen(1))
+						g.Expect(obj.Status.Locations[0].Size).ToNot(BeNil())
+					}, 5*time.Second, 1*time.Second).Should(Succeed())
+
+					return key
+				}
+
+				enableEviction := func(key ctrlclient.ObjectKey) {
+					pkgcfg.SetContext(vcSimCtx, func(config *pkgcfg.Config) {
+						config.ImageCache.DatastoreCapacityLimit = 1
+						config.ImageCache.EvictionGracePeriod = 0
+					})
+
+					// Update the object to cause it to be reconciled again.
+					var obj vmopv1.VirtualMachineImageCache
+					Expect(vcSimCtx.Client.Get(ctx, key, &obj)).To(Succeed())
+					if obj.Annotations == nil {
+						obj.Annotations = map[string]string{}
+					}
+					obj.Annotations["evict"] = "true"
+					Expect(vcSimCtx.Client.Update(ctx, &obj)).To(Succeed())
+				}
+
+				When("the datastore capacity limit is exceeded", func() {
+					It("should evict the cached files", func() {
+						key := createCachedObj()
+						Expect(getDeleted()).To(BeEmpty())
+
+						enableEviction(key)
+
+						Eventually(func(g Gomega) {
+							var obj vmopv1.VirtualMachineImageCache
+							g.Expect(vcSimCtx.Client.Get(ctx, key, &obj)).To(Succeed())
+							g.Expect(obj.Spec.Locations).To(BeEmpty())
+							g.Expect(obj.Status.Locations).To(BeEmpty())
+							g.Expect(obj.Status.Evictions).To(HaveLen(1))
+
+							e := obj.Status.Evictions[0]
+							g.Expect(e.DatacenterID).To(Equal(vcSimCtx.Datacenter.Reference().Value))
+							g.Expect(e.DatastoreID).To(Equal(vcSimCtx.Datastore.Reference().Value))
+							g.Expect(e.ProfileID).To(Equal(vcSimCtx.StorageProfileID))
+							g.Expect(e.Reason).To(Equal("CapacityLimitExceeded"))
+							g.Expect(e.Size).ToNot(BeNil())
+							g.Expect(e.EvictionTime.IsZero()).To(BeFalse())
+						}, 5*time.Second, 1*time.Second).Should(Succeed())
+
+						Expect(getDeleted()).ToNot(BeEmpty())
+					})
+				})
+
+				When("the cached files cannot be deleted", func() {
+					BeforeEach(func() {
+						faker.Lock()
+						faker.deleteVirtualDiskFn = func(
+							_ context.Context,
+							_ string,
+							_ *object.Datacenter) (*object.Task, error) {
+
+							return nil, errors.New("delete disk error")
+						}
+						faker.Unlock()
+					})
+
+					It("should not remove the location", func() {
+						key := createCachedObj()
+
+						enableEviction(key)
+
+						Consistently(func(g Gomega) {
+							var obj vmopv1.VirtualMachineImageCache
+							g.Expect(vcSimCtx.Client.Get(ctx, key, &obj)).To(Succeed())
+							g.Expect(obj.Spec.Locations).To(HaveLen(1))
+							g.Expect(obj.Status.Locations).To(HaveLen(1))
+							g.Expect(obj.Status.Evictions).To(BeEmpty())
+						}, 3*time.Second, 1*time.Second).Should(Succeed())
+					})
+				})
+			})
+
 			Context("Cleanup Empty ProfileIDs", func() {
 				It("should remove spec locations with empty profileID and requeue", func() {
 					obj := getVMICacheObj(
@@ -939,6 +1097,7 @@ type fakeClient struct {
 
 	fakeCLSProvdr bool
 	fakeSRIClient bool
+	fakeDCFClient bool
 
 	datastoreFileExistsFn func(
 		ctx context.Context,
@@ -966,6 +1125,16 @@ type fakeClient struct {
 	waitForTaskFn func(
 		ctx context.Context, task *object.Task) error
 
+	deleteVirtualDiskFn func(
+		ctx context.Context,
+		name string,
+		datacenter *object.Datacenter) (*object.Task, error)
+
+	deleteDatastoreFileFn func(
+		ctx context.Context,
+		name string,
+		datacenter *object.Datacenter) (*object.Task, error)
+
 	getLibraryItemsFn func(
 		ctx context.Context,
 		libraryID string) ([]library.Item, error)
@@ -1024,12 +1193,15 @@ func (m *fakeClient) reset() {
 
 	m.fakeCLSProvdr = false
 	m.fakeSRIClient = false
+	m.fakeDCFClient = false
 
 	m.datastoreFileExistsFn = nil
 	m.copyVirtualDiskFn = nil
 	m.copyDatastoreFileFn = nil
 	m.makeDirectoryFn = nil
 	m.waitForTaskFn = nil
+	m.deleteVirtualDiskFn = nil
+	m.deleteDatastoreFileFn = nil
 	m.getLibraryItemsFn = nil
 	m.getLibraryItemFn = nil
 	m.getLibraryItemIDFn = nil
@@ -1068,6 +1240,18 @@ func (m *fakeClient) newCacheStorageURIsClientFn(
 	return nil
 }
 
+func (m *fakeClient) newDeleteCachedFilesClientFn(
+	c *vim25.Client) clsutil.DeleteCachedFilesClient {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.fakeDCFClient {
+		return m
+	}
+	return nil
+}
+
 func (m *fakeClient) DatastoreFileExists(
 	ctx context.Context,
 	name string,
@@ -1127,6 +1311,34 @@ func (m *fakeClient) MakeDirectory(
 	return nil
 }
 
+func (m *fakeClient) DeleteVirtualDisk(
+	ctx context.Context,
+	name string,
+	datacenter *object.Datacenter) (*object.Task, error) {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	if fn := m.deleteVirtualDiskFn; fn != nil {
+		return fn(ctx, name, datacenter)
+	}
+	return nil, nil
+}
+
+func (m *fakeClient) DeleteDatastoreFile(
+	ctx context.Context,
+	name string,
+	datacenter *object.Datacenter) (*object.Task, error) {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	if fn := m.deleteDatastoreFileFn; fn != nil {
+		return fn(ctx, name, datacenter)
+	}
+	return nil, nil
+}
+
 func (m *fakeClient) WaitForTask(
 	ctx context.Context, task *object.Task) error {
 
@@ -1289,6 +1501,44 @@ func (m *fakeClient) CreateLibraryItem(
 	return nil
 }
 
+func (m *fakeClient) RetrieveLibraryItemFiles(
+	_ context.Context,
+	_ string,
+	_ func(name string) bool) (map[string][]byte, error) {
+
+	return nil, nil
+}
+
+func (m *fakeClient) ImportLibraryItem(
+	_ context.Context,
+	_ library.Item,
+	_ imageimport.Source) (string, error) {
+
+	return "", nil
+}
+
+func (m *fakeClient) ListLibraryItemFileNames(
+	_ context.Context,
+	_ string) ([]string, error) {
+
+	return nil, nil
+}
+
+func (m *fakeClient) CopyLibraryItemFile(
+	_ context.Context,
+	_, _ string,
+	_ io.Writer) error {
+
+	return nil
+}
+
+func (m *fakeClient) DeleteLibraryItem(
+	_ context.Context,
+	_ string) error {
+
+	return nil
+}
+
 const ovfEnvelopeYAML = `
 diskSection:
   disk:
@@ -0,0 +1,354 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagecache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcond "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
+)
+
+const (
+	// maxEvictions is the maximum number of evictions recorded in an object's
+	// status.
+	maxEvictions = 10
+
+	// evictionReasonCapacityLimitExceeded is the reason recorded for files
+	// evicted because the capacity used by cached files exceeded a limit.
+	evictionReasonCapacityLimitExceeded = "CapacityLimitExceeded"
+
+	// eventReasonEvicted is the reason of the event emitted when cached files
+	// are evicted.
+	eventReasonEvicted = "Evicted"
+)
+
+// isEvictionEnabled returns true if either of the image cache capacity limits
+// is set.
+func isEvictionEnabled(ctx context.Context) bool {
+	c := pkgcfg.FromContext(ctx).ImageCache
+	return c.DatastoreCapacityLimit > 0 || c.ProfileCapacityLimit > 0
+}
+
+// reconcileEviction evicts the object's least recently used cached files when
+// the capacity used by all of the cached files on a datastore, or by a storage
+// profile on a datastore, exceeds the configured limits.
+//
+// The locations to evict are planned using the cached locations of all the
+// objects in the namespace, but only the locations that belong to this object
+// are evicted. The locations of the other objects are evicted when those
+// objects are reconciled.
+func (r *reconciler) reconcileEviction(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	obj *vmopv1.VirtualMachineImageCache) error {
+
+	if !isEvictionEnabled(ctx) {
+		return nil
+	}
+
+	var list vmopv1.VirtualMachineImageCacheList
+	if err := r.Client.List(
+		ctx,
+		&list,
+		ctrlclient.InNamespace(obj.Namespace)); err != nil {
+
+		return fmt.Errorf("failed to list image caches: %w", err)
+	}
+
+	var locations []clsutil.CachedLocation
+	for i := range list.Items {
+		o := &list.Items[i]
+		if o.Name == obj.Name {
+			// Use the in-memory object as it may be more recent.
+			o = obj
+		}
+		locations = append(locations, getCachedLocations(o)...)
+	}
+
+	var (
+		cfg    = pkgcfg.FromContext(ctx).ImageCache
+		now    = time.Now()
+		logger = pkglog.FromContextOrDefault(ctx)
+	)
+
+	toEvict := clsutil.GetCachedLocationsToEvict(
+		now,
+		clsutil.CacheLimits{
+			DatastoreCapacity: cfg.DatastoreCapacityLimit,
+			ProfileCapacity:   cfg.ProfileCapacityLimit,
+			GracePeriod:       cfg.EvictionGracePeriod,
+		},
+		locations...)
+
+	for _, l := range toEvict {
+		if l.ItemName != obj.Name {
+			continue
+		}
+
+		logger := logger.WithValues(
+			"datacenterID", l.DatacenterID,
+			"datastoreID", l.DatastoreID,
+			"profileID", l.ProfileID,
+			"lastUsed", l.LastUsed)
+
+		status := getLocationStatus(obj, l.DatacenterID, l.DatastoreID, l.ProfileID)
+		if status == nil {
+			continue
+		}
+		filePaths := getCachedFilePaths(status.Files)
+
+		// VMs deployed as linked clones continue to use the cached files for
+		// as long as they exist.
+		inUse, err := isInUse(ctx, vimClient, l.DatastoreID, filePaths)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			logger.Info("Not evicting cached files that are used by a VM")
+			obj.MarkLocationUsed(
+				l.DatacenterID,
+				l.DatastoreID,
+				l.ProfileID,
+				metav1.NewTime(now),
+				0)
+			continue
+		}
+
+		logger.Info("Evicting cached files", "files", filePaths)
+
+		dc := object.NewDatacenter(vimClient, vimtypes.ManagedObjectReference{
+			Type:  "Datacenter",
+			Value: l.DatacenterID,
+		})
+		if err := clsutil.DeleteCachedFiles(
+			ctx,
+			r.newDCFClientFn(vimClient),
+			dc,
+			filePaths...); err != nil {
+
+			r.Recorder.Warnf(obj, "EvictionFailed",
+				"Failed to evict cached files from datastore %s: %v",
+				l.DatastoreID, err)
+			return fmt.Errorf("failed to evict cached files: %w", err)
+		}
+
+		removeLocation(obj, l.DatacenterID, l.DatastoreID, l.ProfileID)
+
+		var lastUsed *metav1.Time
+		if !l.LastUsed.IsZero() {
+			lastUsed = &metav1.Time{Time: l.LastUsed}
+		}
+		obj.Status.Evictions = append(obj.Status.Evictions,
+			vmopv1.VirtualMachineImageCacheEvictionStatus{
+				DatacenterID: l.DatacenterID,
+				DatastoreID:  l.DatastoreID,
+				ProfileID:    l.ProfileID,
+				Size:         resource.NewQuantity(l.Size, resource.BinarySI),
+				LastUsedTime: lastUsed,
+				EvictionTime: metav1.NewTime(now),
+				Reason:       evictionReasonCapacityLimitExceeded,
+			})
+		if n := len(obj.Status.Evictions); n > maxEvictions {
+			obj.Status.Evictions = obj.Status.Evictions[n-maxEvictions:]
+		}
+
+		r.Recorder.Eventf(obj, eventReasonEvicted,
+			"Evicted %s of cached files from datastore %s with storage profile %s",
+			resource.NewQuantity(l.Size, resource.BinarySI),
+			l.DatastoreID,
+			l.ProfileID)
+	}
+
+	return nil
+}
+
+// getCachedLocations returns the object's cached locations that have a known
+// size.
+func getCachedLocations(
+	obj *vmopv1.VirtualMachineImageCache) []clsutil.CachedLocation {
+
+	var out []clsutil.CachedLocation
+	for _, status := range obj.Status.Locations {
+		if status.Size == nil {
+			continue
+		}
+		out = append(out, clsutil.CachedLocation{
+			ItemName:     obj.Name,
+			DatacenterID: status.DatacenterID,
+			DatastoreID:  status.DatastoreID,
+			ProfileID:    status.ProfileID,
+			Size:         status.Size.Value(),
+			LastUsed:     getLastUsedTime(obj, status),
+		})
+	}
+	return out
+}
+
+// getLastUsedTime returns the last time the location was used. Locations that
+// have never been used by a VM are considered to have been last used when
+// their files were cached.
+func getLastUsedTime(
+	obj *vmopv1.VirtualMachineImageCache,
+	status vmopv1.VirtualMachineImageCacheLocationStatus) time.Time {
+
+	for _, spec := range obj.Spec.Locations {
+		if spec.DatacenterID == status.DatacenterID &&
+			spec.DatastoreID == status.DatastoreID &&
+			spec.ProfileID == status.ProfileID &&
+			spec.LastUsedTime != nil {
+
+			return spec.LastUsedTime.Time
+		}
+	}
+	if c := pkgcond.Get(status, vmopv1.ReadyConditionType); c != nil {
+		return c.LastTransitionTime.Time
+	}
+	return obj.CreationTimestamp.Time
+}
+
+func getLocationStatus(
+	obj *vmopv1.VirtualMachineImageCache,
+	datacenterID, datastoreID, profileID string) *vmopv1.VirtualMachineImageCacheLocationStatus {
+
+	for i, s := range obj.Status.Locations {
+		if s.DatacenterID == datacenterID &&
+			s.DatastoreID == datastoreID &&
+			s.ProfileID == profileID {
+
+			return &obj.Status.Locations[i]
+		}
+	}
+	return nil
+}
+
+func removeLocation(
+	obj *vmopv1.VirtualMachineImageCache,
+	datacenterID, datastoreID, profileID string) {
+
+	obj.Spec.Locations = slices.DeleteFunc(
+		obj.Spec.Locations,
+		func(e vmopv1.VirtualMachineImageCacheLocationSpec) bool {
+			return e.DatacenterID == datacenterID &&
+				e.DatastoreID == datastoreID &&
+				e.ProfileID == profileID
+		})
+	obj.Status.Locations = slices.DeleteFunc(
+		obj.Status.Locations,
+		func(e vmopv1.VirtualMachineImageCacheLocationStatus) bool {
+			return e.DatacenterID == datacenterID &&
+				e.DatastoreID == datastoreID &&
+				e.ProfileID == profileID
+		})
+}
+
+// getCachedFilePaths returns the datastore paths of the cached files. Managed
+// disks are not included since they are not located by a datastore path.
+func getCachedFilePaths(
+	files []vmopv1.VirtualMachineImageCacheFileStatus) []string {
+
+	var out []string
+	for _, f := range files {
+		if f.Type == vmopv1.VirtualMachineImageCacheFileTypeDisk &&
+			f.DiskType == vmopv1.VolumeTypeManaged {
+
+			continue
+		}
+		out = append(out, f.ID)
+	}
+	return out
+}
+
+// isInUse returns true if any of the VMs on the datastore use any of the
+// provided files.
+func isInUse(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	datastoreID string,
+	filePaths []string) (bool, error) {
+
+	if len(filePaths) == 0 {
+		return false, nil
+	}
+
+	var (
+		moDS mo.Datastore
+		pc   = property.DefaultCollector(vimClient)
+	)
+
+	if err := pc.RetrieveOne(
+		ctx,
+		vimtypes.ManagedObjectReference{
+			Type:  "Datastore",
+			Value: datastoreID,
+		},
+		[]string{"vm"},
+		&moDS); err != nil {
+
+		return false, fmt.Errorf("failed to get datastore vms: %w", err)
+	}
+
+	if len(moDS.Vm) == 0 {
+		return false, nil
+	}
+
+	var moVMs []mo.VirtualMachine
+	if err := pc.Retrieve(
+		ctx,
+		moDS.Vm,
+		[]string{"layoutEx.file"},
+		&moVMs); err != nil {
+
+		return false, fmt.Errorf("failed to get vm files: %w", err)
+	}
+
+	for i := range moVMs {
+		if moVMs[i].LayoutEx == nil {
+			continue
+		}
+		for _, f := range moVMs[i].LayoutEx.File {
+			if slices.Contains(filePaths, f.Name) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// updateSize sets the object's total size to the sum of the sizes of its
+// cached locations.
+func updateSize(obj *vmopv1.VirtualMachineImageCache) {
+	var (
+		size  int64
+		known bool
+	)
+	for _, l := range obj.Status.Locations {
+		if l.Size != nil {
+			size += l.Size.Value()
+			known = true
+		}
+	}
+	if known {
+		obj.Status.Size = resource.NewQuantity(size, resource.BinarySI)
+	} else {
+		obj.Status.Size = nil
+	}
+}
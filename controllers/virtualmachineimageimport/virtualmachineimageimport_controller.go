@@ -0,0 +1,602 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	pkgnil "github.com/vmware-tanzu/vm-operator/pkg/util/nil"
+)
+
+const (
+	delTTLMsg = "%s vm image import due to TTL expired"
+
+	// ProgressRequeueAfter is how often the status of an import is updated
+	// with its progress while the image is being transferred.
+	ProgressRequeueAfter = 5 * time.Second
+
+	// TransferFailedReason is the reason of the warning event that is
+	// emitted when an image cannot be transferred.
+	TransferFailedReason = "TransferFailed"
+
+	// TransferSucceededReason is the reason of the event that is emitted
+	// when an image has been transferred.
+	TransferSucceededReason = "TransferSucceeded"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImageImport{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		}).
+		Watches(&vmopv1.VirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(vmiToImageImportMapperFn(ctx, r.Client))).
+		WatchesRawSource(source.Channel(
+			cource.FromContextWithBuffer(ctx, controlledTypeName, 100),
+			&handler.EnqueueRequestForObject{})).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// vmiToImageImportMapperFn returns a mapper function that queues a reconcile
+// request for the VirtualMachineImageImports that uploaded the content library
+// item of a VirtualMachineImage.
+func vmiToImageImportMapperFn(
+	ctx *pkgctx.ControllerManagerContext,
+	c client.Client) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vmi := o.(*vmopv1.VirtualMachineImage)
+		if vmi.Status.ProviderItemID == "" {
+			return nil
+		}
+
+		list := &vmopv1.VirtualMachineImageImportList{}
+		if err := c.List(ctx, list, client.InNamespace(vmi.Namespace)); err != nil {
+			ctx.Logger.Error(err, "Failed to list VirtualMachineImageImports for VirtualMachineImage watch",
+				"name", vmi.Name, "namespace", vmi.Namespace)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, imageImport := range list.Items {
+			if imageImport.Status.ItemID == vmi.Status.ProviderItemID {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&imageImport),
+				})
+			}
+		}
+		return requests
+	}
+}
+
+// transfer is an image that is being transferred from its source to a content
+// library in the background.
+type transfer struct {
+	progress imageimport.Progress
+
+	// itemID may only be read once the transfer is done.
+	itemID string
+}
+
+// reconcileOnTransferDone reconciles an import once its transfer is done.
+var reconcileOnTransferDone = asyncop.ReconcileOnDone(
+	"VirtualMachineImageImport",
+	func() client.Object { return &vmopv1.VirtualMachineImageImport{} })
+
+// Reconciler reconciles a VirtualMachineImageImport object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+
+	transfers *asyncop.Tracker[*transfer]
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+		transfers:  asyncop.NewTracker[*transfer](reconcileOnTransferDone),
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageimports,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageimports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile reconciles a VirtualMachineImageImport object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+	ctx = cource.JoinContext(ctx, r.Context)
+
+	imageImport := &vmopv1.VirtualMachineImageImport{}
+	if err := r.Get(ctx, req.NamespacedName, imageImport); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.transfers.Cancel(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	imageImportCtx := &pkgctx.VirtualMachineImageImportContext{
+		Context:     ctx,
+		Logger:      pkglog.FromContextOrDefault(ctx),
+		ImageImport: imageImport,
+	}
+	patchHelper, err := patch.NewHelper(imageImport, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"failed to init patch helper for %s/%s: %w",
+			imageImport.Namespace,
+			imageImport.Name,
+			err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, imageImport); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			imageImportCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !imageImport.DeletionTimestamp.IsZero() {
+		// The content library item that was being uploaded is deleted by the
+		// provider when the transfer is canceled.
+		r.transfers.Cancel(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	return pkgerr.ResultFromError(r.ReconcileNormal(imageImportCtx))
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineImageImport")
+	imageImport := ctx.ImageImport
+
+	if !imageImport.Status.CompletionTime.IsZero() {
+		return r.reconcileSpecTTL(ctx)
+	}
+
+	if imageImport.Status.StartTime.IsZero() {
+		imageImport.Status.StartTime = metav1.NewTime(time.Now())
+	}
+
+	if !conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded) {
+		if err := r.reconcileUpload(ctx); err != nil {
+			return err
+		}
+		if !conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded) {
+			return nil
+		}
+	}
+
+	if err := r.reconcileImageAvailable(ctx); err != nil {
+		return err
+	}
+
+	if conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionImageAvailable) {
+		r.markComplete(ctx)
+	}
+
+	return nil
+}
+
+// reconcileUpload starts transferring the image in the background, and
+// updates the status with the progress of the transfer until it is done.
+func (r *Reconciler) reconcileUpload(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	imageImport := ctx.ImageImport
+	key := client.ObjectKeyFromObject(imageImport)
+
+	inProgress := conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded) ==
+		vmopv1.VirtualMachineImageImportUploadInProgressReason
+
+	if r.transfers.IsLost(key, inProgress) {
+		// The transfer was lost, ex. because the pod restarted, so the library
+		// item it created was not deleted by the provider. Delete it so the
+		// import may be retried with the same item name.
+		if err := r.deleteOrphanedItem(ctx); err != nil {
+			return err
+		}
+		r.markFailed(ctx,
+			vmopv1.VirtualMachineImageImportUploadFailedReason,
+			errors.New("the transfer was interrupted"))
+		return nil
+	}
+
+	op := r.transfers.Get(key)
+	if op == nil {
+		return r.startTransfer(ctx)
+	}
+
+	t := op.Value()
+	if !op.IsDone() {
+		r.updateProgress(ctx, t)
+		conditions.MarkFalse(imageImport,
+			vmopv1.VirtualMachineImageImportConditionUploaded,
+			vmopv1.VirtualMachineImageImportUploadInProgressReason,
+			"transferred %d of %d bytes", t.progress.Transferred(), t.progress.Total())
+		return pkgerr.RequeueError{After: ProgressRequeueAfter}
+	}
+
+	r.transfers.Forget(key)
+	r.updateProgress(ctx, t)
+
+	if err := op.Err(); err != nil {
+		reason := vmopv1.VirtualMachineImageImportUploadFailedReason
+		switch {
+		case errors.Is(err, imageimport.ErrChecksumMismatch):
+			reason = vmopv1.VirtualMachineImageImportChecksumMismatchReason
+		case errors.Is(err, imageimport.ErrInvalidSource):
+			reason = vmopv1.VirtualMachineImageImportSourceInvalidReason
+		}
+		r.markFailed(ctx, reason, err)
+		return nil
+	}
+
+	imageImport.Status.ItemID = t.itemID
+	imageImport.Status.Progress = 100
+	conditions.MarkTrue(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)
+	r.Recorder.Eventf(imageImport, TransferSucceededReason,
+		"Transferred %d bytes to content library item %s", imageImport.Status.BytesTransferred, t.itemID)
+	ctx.Logger.Info("Image transferred", "itemID", t.itemID)
+
+	return nil
+}
+
+// startTransfer validates the target of the import, and starts transferring
+// the image to it in the background.
+func (r *Reconciler) startTransfer(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	imageImport := ctx.ImageImport
+
+	contentLibrary, err := r.checkIsTargetValid(ctx)
+	if err != nil || contentLibrary == nil {
+		return err
+	}
+
+	opts, err := r.getSourceOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	itemName := targetItemName(imageImport)
+
+	t := &transfer{}
+	opts.Progress = &t.progress
+
+	source := *imageImport.Spec.Source.DeepCopy()
+	libraryUUID := string(contentLibrary.Spec.UUID)
+	itemDescription := imageImport.Spec.Target.ItemDescription
+	logger := ctx.Logger
+
+	// The transfer outlives this reconcile, so its context is only canceled
+	// when the import is deleted.
+	r.transfers.Start(ctx, client.ObjectKeyFromObject(imageImport), t,
+		func(transferCtx context.Context, t *transfer) error {
+			src, err := imageimport.NewSource(transferCtx, source, opts)
+			if err != nil {
+				return err
+			}
+			t.itemID, err = r.VMProvider.ImportContentLibraryItem(
+				transferCtx, libraryUUID, itemName, itemDescription, src)
+			if err != nil {
+				logger.Error(err, "Failed to transfer image")
+			}
+			return err
+		})
+
+	conditions.MarkFalse(imageImport,
+		vmopv1.VirtualMachineImageImportConditionUploaded,
+		vmopv1.VirtualMachineImageImportUploadInProgressReason,
+		"transfer started")
+	ctx.Logger.Info("Started image transfer", "itemName", itemName)
+
+	return pkgerr.RequeueError{After: ProgressRequeueAfter}
+}
+
+// checkIsTargetValid returns the target content library when it exists, is
+// writable and ready, and does not have an item with the target name.
+// Otherwise nil is returned, and the TargetValid condition is set to false.
+func (r *Reconciler) checkIsTargetValid(
+	ctx *pkgctx.VirtualMachineImageImportContext) (*imgregv1a1.ContentLibrary, error) {
+
+	imageImport := ctx.ImageImport
+	objKey := client.ObjectKey{Namespace: imageImport.Namespace, Name: imageImport.Spec.Target.LibraryName}
+
+	contentLibrary := &imgregv1a1.ContentLibrary{}
+	if err := r.Get(ctx, objKey, contentLibrary); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(imageImport,
+				vmopv1.VirtualMachineImageImportConditionTargetValid,
+				vmopv1.TargetContentLibraryNotExistReason,
+				err)
+		}
+		return nil, fmt.Errorf("failed to get ContentLibrary %v: %w", objKey, err)
+	}
+
+	if !contentLibrary.Spec.Writable {
+		err := fmt.Errorf("target location %s is not writable", objKey.Name)
+		conditions.MarkError(imageImport,
+			vmopv1.VirtualMachineImageImportConditionTargetValid,
+			vmopv1.TargetContentLibraryNotWritableReason,
+			err)
+		return nil, err
+	}
+
+	isReady := false
+	for _, condition := range contentLibrary.Status.Conditions {
+		if condition.Type == imgregv1a1.ReadyCondition {
+			isReady = condition.Status == corev1.ConditionTrue
+			break
+		}
+	}
+	if !isReady {
+		err := fmt.Errorf("target location %s is not ready", objKey.Name)
+		conditions.MarkError(imageImport,
+			vmopv1.VirtualMachineImageImportConditionTargetValid,
+			vmopv1.TargetContentLibraryNotReadyReason,
+			err)
+		return nil, err
+	}
+
+	itemName := targetItemName(imageImport)
+	item, err := r.VMProvider.GetItemFromLibraryByName(ctx, string(contentLibrary.Spec.UUID), itemName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item %q from library: %w", itemName, err)
+	}
+	if !pkgnil.IsNil(item) {
+		// An item is never replaced, so give up at this point.
+		conditions.MarkFalse(imageImport,
+			vmopv1.VirtualMachineImageImportConditionTargetValid,
+			vmopv1.TargetItemAlreadyExistsReason,
+			"item with name %s already exists in the content library %s", itemName, objKey.Name)
+		r.markFailed(ctx,
+			vmopv1.TargetItemAlreadyExistsReason,
+			fmt.Errorf("item with name %s already exists in the content library %s", itemName, objKey.Name))
+		return nil, nil
+	}
+
+	conditions.MarkTrue(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)
+	return contentLibrary, nil
+}
+
+// deleteOrphanedItem deletes the content library item that was created by the
+// import's transfer if the transfer was lost. The item is only deleted if it
+// was created after the import started, since the target was validated to not
+// have an item with the same name before the transfer was started.
+func (r *Reconciler) deleteOrphanedItem(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	imageImport := ctx.ImageImport
+
+	contentLibrary := &imgregv1a1.ContentLibrary{}
+	objKey := client.ObjectKey{Namespace: imageImport.Namespace, Name: imageImport.Spec.Target.LibraryName}
+	if err := r.Get(ctx, objKey, contentLibrary); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get ContentLibrary %v: %w", objKey, err)
+	}
+
+	itemName := targetItemName(imageImport)
+	item, err := r.VMProvider.GetItemFromLibraryByName(ctx, string(contentLibrary.Spec.UUID), itemName)
+	if err != nil {
+		return fmt.Errorf("failed to get item %q from library: %w", itemName, err)
+	}
+	if pkgnil.IsNil(item) {
+		return nil
+	}
+
+	if item.CreationTime == nil || item.CreationTime.Before(imageImport.Status.StartTime.Time) {
+		ctx.Logger.Info("Not deleting library item that was not created by the import",
+			"itemName", itemName, "itemID", item.ID)
+		return nil
+	}
+
+	if err := r.VMProvider.DeleteContentLibraryItem(ctx, item.ID); err != nil {
+		return fmt.Errorf("failed to delete orphaned item %q from library: %w", itemName, err)
+	}
+	ctx.Logger.Info("Deleted orphaned library item", "itemName", itemName, "itemID", item.ID)
+
+	return nil
+}
+
+// targetItemName returns the name of the content library item the image is
+// imported to.
+func targetItemName(imageImport *vmopv1.VirtualMachineImageImport) string {
+	if imageImport.Spec.Target.ItemName != "" {
+		return imageImport.Spec.Target.ItemName
+	}
+	return imageImport.Name
+}
+
+// getSourceOptions returns the options used to access the import's source.
+func (r *Reconciler) getSourceOptions(ctx *pkgctx.VirtualMachineImageImportContext) (imageimport.Options, error) {
+	imageImport := ctx.ImageImport
+	oci := imageImport.Spec.Source.OCI
+	if oci == nil || oci.PullSecretName == "" {
+		return imageimport.Options{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	objKey := client.ObjectKey{Namespace: imageImport.Namespace, Name: oci.PullSecretName}
+	if err := r.Get(ctx, objKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(imageImport,
+				vmopv1.VirtualMachineImageImportConditionUploaded,
+				vmopv1.VirtualMachineImageImportSourceInvalidReason,
+				err)
+		}
+		return imageimport.Options{}, fmt.Errorf("failed to get pull secret %v: %w", objKey, err)
+	}
+
+	auth, err := imageimport.AuthFromDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey], oci.Reference)
+	if err != nil {
+		r.markFailed(ctx, vmopv1.VirtualMachineImageImportSourceInvalidReason, err)
+		return imageimport.Options{}, pkgerr.NoRequeueError{Message: err.Error()}
+	}
+
+	return imageimport.Options{Auth: auth}, nil
+}
+
+// reconcileImageAvailable sets the name of the VirtualMachineImage for the
+// uploaded content library item once the image is ready.
+func (r *Reconciler) reconcileImageAvailable(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	imageImport := ctx.ImageImport
+
+	vmiList := &vmopv1.VirtualMachineImageList{}
+	if err := r.List(ctx, vmiList, client.InNamespace(imageImport.Namespace)); err != nil {
+		return fmt.Errorf("failed to list VirtualMachineImages: %w", err)
+	}
+
+	for _, vmi := range vmiList.Items {
+		if vmi.Status.ProviderItemID != imageImport.Status.ItemID {
+			continue
+		}
+		if !conditions.IsTrue(&vmi, vmopv1.ReadyConditionType) {
+			conditions.MarkFalse(imageImport,
+				vmopv1.VirtualMachineImageImportConditionImageAvailable,
+				vmopv1.ImageUnavailableReason,
+				"VirtualMachineImage %s is not ready", vmi.Name)
+			return nil
+		}
+		imageImport.Status.ImageName = vmi.Name
+		conditions.MarkTrue(imageImport, vmopv1.VirtualMachineImageImportConditionImageAvailable)
+		return nil
+	}
+
+	conditions.MarkFalse(imageImport,
+		vmopv1.VirtualMachineImageImportConditionImageAvailable,
+		vmopv1.TargetVirtualMachineImageNotFoundReason,
+		"VirtualMachineImage not found")
+	return nil
+}
+
+// markComplete marks the import as complete and ready.
+func (r *Reconciler) markComplete(ctx *pkgctx.VirtualMachineImageImportContext) {
+	imageImport := ctx.ImageImport
+
+	conditions.MarkTrue(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)
+	imageImport.Status.Ready = true
+	imageImport.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VirtualMachineImageImport completed", "imageName", imageImport.Status.ImageName)
+}
+
+// markFailed marks the import as complete, but not ready, because the image
+// cannot be imported.
+func (r *Reconciler) markFailed(ctx *pkgctx.VirtualMachineImageImportContext, reason string, err error) {
+	imageImport := ctx.ImageImport
+
+	if reason != vmopv1.TargetItemAlreadyExistsReason {
+		conditions.MarkError(imageImport,
+			vmopv1.VirtualMachineImageImportConditionUploaded,
+			reason,
+			err)
+	}
+	conditions.MarkError(imageImport,
+		vmopv1.VirtualMachineImageImportConditionComplete,
+		reason,
+		err)
+	imageImport.Status.Ready = false
+	imageImport.Status.CompletionTime = metav1.Now()
+	r.Recorder.Warn(imageImport, TransferFailedReason, err.Error())
+	ctx.Logger.Info("VirtualMachineImageImport failed", "reason", reason, "error", err.Error())
+}
+
+func (r *Reconciler) updateProgress(ctx *pkgctx.VirtualMachineImageImportContext, t *transfer) {
+	imageImport := ctx.ImageImport
+	imageImport.Status.TotalBytes = t.progress.Total()
+	imageImport.Status.BytesTransferred = t.progress.Transferred()
+	imageImport.Status.Progress = t.progress.Percent()
+}
+
+// reconcileSpecTTL deletes the import once the TTL expires.
+func (r *Reconciler) reconcileSpecTTL(ctx *pkgctx.VirtualMachineImageImportContext) error {
+	imageImport := ctx.ImageImport
+
+	// Skip deletion when ttl is nil.
+	if imageImport.Spec.TTLSecondsAfterFinished == nil {
+		return nil
+	}
+
+	// When ttl is zero the expiration time is the same as the completion time
+	// which triggers immediate deletion.
+	expirationTime := imageImport.Status.CompletionTime.Time.Add(
+		time.Duration(*imageImport.Spec.TTLSecondsAfterFinished) * time.Second)
+	if time.Now().Before(expirationTime) {
+		return pkgerr.RequeueError{After: time.Until(expirationTime)}
+	}
+
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleting"))
+	if err := r.Delete(ctx, imageImport); client.IgnoreNotFound(err) != nil {
+		ctx.Logger.Error(err, fmt.Sprintf(delTTLMsg, "failed to delete"))
+		return err
+	}
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleted"))
+	return nil
+}
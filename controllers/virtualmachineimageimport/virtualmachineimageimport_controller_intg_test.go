@@ -0,0 +1,124 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimport_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	const itemID = "dummy-item-id"
+
+	var (
+		ctx         *builder.IntegrationTestContext
+		cl          *imgregv1a1.ContentLibrary
+		imageImport *vmopv1.VirtualMachineImageImport
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		intgFakeVMProvider.Lock()
+		intgFakeVMProvider.ImportContentLibraryItemFn = func(
+			_ context.Context, _, _, _ string, _ imageimport.Source) (string, error) {
+
+			return itemID, nil
+		}
+		intgFakeVMProvider.Unlock()
+
+		cl = builder.DummyContentLibrary("dummy-cl", ctx.Namespace, "dummy-cl-uuid")
+		imageImport = &vmopv1.VirtualMachineImageImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "photon",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineImageImportSpec{
+				Source: vmopv1.VirtualMachineImageImportSource{
+					HTTP: &vmopv1.VirtualMachineImageImportHTTPSource{
+						URL: "https://images.example.com/photon.iso",
+					},
+				},
+				Target: vmopv1.VirtualMachineImageImportTarget{
+					LibraryName: cl.Name,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	getImageImport := func() *vmopv1.VirtualMachineImageImport {
+		obj := &vmopv1.VirtualMachineImageImport{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(imageImport), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	It("imports the image and reports the VirtualMachineImage", func() {
+		clStatus := cl.Status.DeepCopy()
+		Expect(ctx.Client.Create(ctx, cl)).To(Succeed())
+		cl.Status = *clStatus
+		Expect(ctx.Client.Status().Update(ctx, cl)).To(Succeed())
+
+		Expect(ctx.Client.Create(ctx, imageImport)).To(Succeed())
+
+		By("uploading the image", func() {
+			Eventually(func(g Gomega) {
+				obj := getImageImport()
+				g.Expect(obj).ToNot(BeNil())
+				g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineImageImportConditionUploaded)).To(BeTrue())
+				g.Expect(obj.Status.ItemID).To(Equal(itemID))
+				g.Expect(obj.Status.Ready).To(BeFalse())
+			}).Should(Succeed())
+		})
+
+		vmi := builder.DummyVirtualMachineImage("vmi-photon")
+		vmi.Namespace = ctx.Namespace
+		Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+		vmi.Status.ProviderItemID = itemID
+		conditions.MarkTrue(vmi, vmopv1.ReadyConditionType)
+		Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+
+		By("reporting the image once it is ready", func() {
+			Eventually(func(g Gomega) {
+				obj := getImageImport()
+				g.Expect(obj).ToNot(BeNil())
+				g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineImageImportConditionComplete)).To(BeTrue())
+				g.Expect(obj.Status.ImageName).To(Equal(vmi.Name))
+				g.Expect(obj.Status.Ready).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+}
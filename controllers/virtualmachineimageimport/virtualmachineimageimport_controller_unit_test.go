@@ -0,0 +1,466 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimport_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vapi/library"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageimport"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		libraryName = "dummy-cl"
+		libraryUUID = "dummy-cl-uuid"
+		itemID      = "dummy-item-id"
+	)
+
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		reconciler     *virtualmachineimageimport.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+		cl             *imgregv1a1.ContentLibrary
+		imageImport    *vmopv1.VirtualMachineImageImport
+		importCtx      *pkgctx.VirtualMachineImageImportContext
+	)
+
+	BeforeEach(func() {
+		cl = builder.DummyContentLibrary(libraryName, builder.DummyNamespaceName, libraryUUID)
+		imageImport = &vmopv1.VirtualMachineImageImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "photon",
+				Namespace: builder.DummyNamespaceName,
+			},
+			Spec: vmopv1.VirtualMachineImageImportSpec{
+				Source: vmopv1.VirtualMachineImageImportSource{
+					HTTP: &vmopv1.VirtualMachineImageImportHTTPSource{
+						URL: "https://images.example.com/photon.iso",
+					},
+				},
+				Target: vmopv1.VirtualMachineImageImportTarget{
+					LibraryName: libraryName,
+				},
+			},
+		}
+		initObjects = []client.Object{cl}
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, imageImport)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineimageimport.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		importCtx = &pkgctx.VirtualMachineImageImportContext{
+			Context:     cource.WithContext(ctx),
+			Logger:      ctx.Logger.WithName(imageImport.Name),
+			ImageImport: imageImport,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider = nil
+		imageImport = nil
+		importCtx = nil
+	})
+
+	// reconcileUntilUploaded reconciles the import until its transfer is
+	// done.
+	reconcileUntilUploaded := func() {
+		Eventually(func(g Gomega) {
+			err := reconciler.ReconcileNormal(importCtx)
+			g.Expect(err).ToNot(BeAssignableToTypeOf(pkgerr.RequeueError{}))
+			g.Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+				ToNot(Equal(vmopv1.VirtualMachineImageImportUploadInProgressReason))
+		}).Should(Succeed())
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the content library does not exist", func() {
+			BeforeEach(func() {
+				initObjects = nil
+			})
+
+			It("returns an error and sets TargetValid to false", func() {
+				err := reconciler.ReconcileNormal(importCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)).
+					To(Equal(vmopv1.TargetContentLibraryNotExistReason))
+				Expect(imageImport.Status.CompletionTime.IsZero()).To(BeTrue())
+			})
+		})
+
+		When("the content library is not writable", func() {
+			BeforeEach(func() {
+				cl.Spec.Writable = false
+			})
+
+			It("returns an error and sets TargetValid to false", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(MatchError(ContainSubstring("not writable")))
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)).
+					To(Equal(vmopv1.TargetContentLibraryNotWritableReason))
+			})
+		})
+
+		When("the content library is not ready", func() {
+			BeforeEach(func() {
+				cl.Status.Conditions = nil
+			})
+
+			It("returns an error and sets TargetValid to false", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(MatchError(ContainSubstring("not ready")))
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)).
+					To(Equal(vmopv1.TargetContentLibraryNotReadyReason))
+			})
+		})
+
+		When("the item already exists in the content library", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.GetItemFromLibraryByNameFn = func(
+					_ context.Context, _, itemName string) (*library.Item, error) {
+
+					return &library.Item{Name: itemName}, nil
+				}
+			})
+
+			It("fails the import", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)).
+					To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+					To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+				Expect(imageImport.Status.Ready).To(BeFalse())
+				Expect(imageImport.Status.CompletionTime.IsZero()).To(BeFalse())
+			})
+		})
+
+		When("the pull secret does not exist", func() {
+			BeforeEach(func() {
+				imageImport.Spec.Source = vmopv1.VirtualMachineImageImportSource{
+					OCI: &vmopv1.VirtualMachineImageImportOCISource{
+						Reference:      "registry.example.com/images/photon:5.0",
+						PullSecretName: "my-secret",
+					},
+				}
+			})
+
+			It("returns an error and sets Uploaded to false", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(MatchError(ContainSubstring("my-secret")))
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+					To(Equal(vmopv1.VirtualMachineImageImportSourceInvalidReason))
+			})
+
+			When("the pull secret is invalid", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "my-secret",
+							Namespace: imageImport.Namespace,
+						},
+						Type: corev1.SecretTypeDockerConfigJson,
+						Data: map[string][]byte{
+							corev1.DockerConfigJsonKey: []byte("{"),
+						},
+					})
+				})
+
+				It("fails the import", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(BeAssignableToTypeOf(pkgerr.NoRequeueError{}))
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineImageImportSourceInvalidReason))
+					Expect(imageImport.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+		})
+
+		When("the image is imported", func() {
+			var (
+				release    chan struct{}
+				importArgs chan []string
+			)
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+				importArgs = make(chan []string, 1)
+				imageImport.Spec.Target.ItemDescription = "Photon OS"
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.ImportContentLibraryItemFn = func(
+					_ context.Context,
+					libraryUUID, itemName, itemDescription string,
+					src imageimport.Source) (string, error) {
+
+					importArgs <- []string{libraryUUID, itemName, itemDescription, src.ItemType()}
+					<-release
+					return itemID, nil
+				}
+			})
+
+			It("reports the progress and then the image", func() {
+				err := reconciler.ReconcileNormal(importCtx)
+				Expect(err).To(Equal(pkgerr.RequeueError{After: virtualmachineimageimport.ProgressRequeueAfter}))
+				Expect(imageImport.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionTargetValid)).To(BeTrue())
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+					To(Equal(vmopv1.VirtualMachineImageImportUploadInProgressReason))
+
+				Eventually(importArgs).Should(Receive(Equal([]string{
+					libraryUUID, imageImport.Name, "Photon OS", library.ItemTypeISO,
+				})))
+
+				By("reconciling while the transfer is in progress", func() {
+					err := reconciler.ReconcileNormal(importCtx)
+					Expect(err).To(Equal(pkgerr.RequeueError{After: virtualmachineimageimport.ProgressRequeueAfter}))
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+						To(Equal(vmopv1.VirtualMachineImageImportUploadInProgressReason))
+				})
+
+				close(release)
+
+				By("reconciling once the transfer is done", func() {
+					reconcileUntilUploaded()
+					Expect(conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).To(BeTrue())
+					Expect(imageImport.Status.ItemID).To(Equal(itemID))
+					Expect(imageImport.Status.Progress).To(BeEquivalentTo(100))
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionImageAvailable)).
+						To(Equal(vmopv1.TargetVirtualMachineImageNotFoundReason))
+					Expect(imageImport.Status.Ready).To(BeFalse())
+					Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineimageimport.TransferSucceededReason)))
+				})
+
+				vmi := builder.DummyVirtualMachineImage("vmi-photon")
+				vmi.Namespace = imageImport.Namespace
+				vmi.Status.ProviderItemID = itemID
+				Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+
+				By("reconciling once the image exists but is not ready", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionImageAvailable)).
+						To(Equal(vmopv1.ImageUnavailableReason))
+				})
+
+				conditions.MarkTrue(vmi, vmopv1.ReadyConditionType)
+				Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+
+				By("reconciling once the image is ready", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+					Expect(conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionImageAvailable)).To(BeTrue())
+					Expect(conditions.IsTrue(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).To(BeTrue())
+					Expect(imageImport.Status.ImageName).To(Equal(vmi.Name))
+					Expect(imageImport.Status.Ready).To(BeTrue())
+					Expect(imageImport.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+
+			When("the item name is specified", func() {
+				BeforeEach(func() {
+					imageImport.Spec.Target.ItemName = "photon-5.0"
+				})
+
+				It("imports the item with the name", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(HaveOccurred())
+					Eventually(importArgs).Should(Receive(ContainElement("photon-5.0")))
+					close(release)
+				})
+			})
+		})
+
+		DescribeTable("the transfer fails",
+			func(transferErr error, expectedReason string) {
+				fakeVMProvider.ImportContentLibraryItemFn = func(
+					_ context.Context, _, _, _ string, _ imageimport.Source) (string, error) {
+
+					return "", transferErr
+				}
+
+				reconcileUntilUploaded()
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+					To(Equal(expectedReason))
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+					To(Equal(expectedReason))
+				Expect(imageImport.Status.Ready).To(BeFalse())
+				Expect(imageImport.Status.CompletionTime.IsZero()).To(BeFalse())
+				Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineimageimport.TransferFailedReason)))
+
+				By("not starting another transfer", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionUploaded)).
+						To(Equal(expectedReason))
+				})
+			},
+			Entry("checksum mismatch",
+				fmt.Errorf("photon.iso: %w", imageimport.ErrChecksumMismatch),
+				vmopv1.VirtualMachineImageImportChecksumMismatchReason),
+			Entry("invalid source",
+				fmt.Errorf("photon.iso: %w", imageimport.ErrInvalidSource),
+				vmopv1.VirtualMachineImageImportSourceInvalidReason),
+			Entry("other error",
+				errors.New("connection reset"),
+				vmopv1.VirtualMachineImageImportUploadFailedReason),
+		)
+
+		When("the transfer was interrupted", func() {
+			BeforeEach(func() {
+				conditions.MarkFalse(imageImport,
+					vmopv1.VirtualMachineImageImportConditionUploaded,
+					vmopv1.VirtualMachineImageImportUploadInProgressReason,
+					"transfer started")
+			})
+
+			It("fails the import", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+				Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+					To(Equal(vmopv1.VirtualMachineImageImportUploadFailedReason))
+				Expect(conditions.GetMessage(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+					To(ContainSubstring("interrupted"))
+			})
+
+			When("the transfer left a library item behind", func() {
+				var (
+					startTime    time.Time
+					creationTime time.Time
+					deletedIDs   []string
+				)
+
+				BeforeEach(func() {
+					startTime = time.Now().Add(-time.Hour).Truncate(time.Second)
+					creationTime = startTime.Add(time.Minute)
+					deletedIDs = nil
+					imageImport.Status.StartTime = metav1.NewTime(startTime)
+				})
+
+				JustBeforeEach(func() {
+					fakeVMProvider.GetItemFromLibraryByNameFn = func(
+						_ context.Context, libUUID, itemName string) (*library.Item, error) {
+
+						Expect(libUUID).To(Equal(libraryUUID))
+						Expect(itemName).To(Equal(imageImport.Name))
+						return &library.Item{ID: itemID, Name: itemName, CreationTime: &creationTime}, nil
+					}
+					fakeVMProvider.DeleteContentLibraryItemFn = func(_ context.Context, id string) error {
+						deletedIDs = append(deletedIDs, id)
+						return nil
+					}
+				})
+
+				It("deletes the item and fails the import", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+					Expect(deletedIDs).To(ConsistOf(itemID))
+					Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+						To(Equal(vmopv1.VirtualMachineImageImportUploadFailedReason))
+				})
+
+				When("the item was created before the import started", func() {
+					BeforeEach(func() {
+						creationTime = startTime.Add(-time.Minute)
+					})
+
+					It("does not delete the item", func() {
+						Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+						Expect(deletedIDs).To(BeEmpty())
+						Expect(conditions.GetReason(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)).
+							To(Equal(vmopv1.VirtualMachineImageImportUploadFailedReason))
+					})
+				})
+
+				When("the item cannot be deleted", func() {
+					JustBeforeEach(func() {
+						fakeVMProvider.DeleteContentLibraryItemFn = func(_ context.Context, _ string) error {
+							return errors.New("fake delete error")
+						}
+					})
+
+					It("returns an error and does not fail the import yet", func() {
+						Expect(reconciler.ReconcileNormal(importCtx)).To(MatchError(ContainSubstring("fake delete error")))
+						Expect(imageImport.Status.CompletionTime.IsZero()).To(BeTrue())
+					})
+				})
+			})
+		})
+
+		When("the import is complete", func() {
+			BeforeEach(func() {
+				conditions.MarkTrue(imageImport, vmopv1.VirtualMachineImageImportConditionComplete)
+				imageImport.Status.CompletionTime = metav1.Now()
+			})
+
+			It("does not requeue when there is no TTL", func() {
+				Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+			})
+
+			When("the TTL has not expired", func() {
+				BeforeEach(func() {
+					imageImport.Spec.TTLSecondsAfterFinished = ptr.To[int64](60)
+				})
+
+				It("requeues until the TTL expires", func() {
+					err := reconciler.ReconcileNormal(importCtx)
+					requeueErr := pkgerr.RequeueError{}
+					Expect(errors.As(err, &requeueErr)).To(BeTrue())
+					Expect(requeueErr.After).To(BeNumerically("~", time.Minute, time.Second))
+				})
+			})
+
+			When("the TTL has expired", func() {
+				BeforeEach(func() {
+					imageImport.Spec.TTLSecondsAfterFinished = ptr.To[int64](0)
+				})
+
+				It("deletes the import", func() {
+					Expect(reconciler.ReconcileNormal(importCtx)).To(Succeed())
+					err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(imageImport), &vmopv1.VirtualMachineImageImport{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+	})
+}
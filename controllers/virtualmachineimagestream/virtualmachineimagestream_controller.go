@@ -0,0 +1,281 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagestream
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	vmiKind  = "VirtualMachineImage"
+	cvmiKind = "Cluster" + vmiKind
+
+	// ImageUpdatedReason is the reason of the event that is emitted when a
+	// stream moves to a different image.
+	ImageUpdatedReason = "ImageUpdated"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImageStream{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(&vmopv1.VirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(r.imageToStreams),
+		).
+		Watches(&vmopv1.ClusterVirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(r.imageToStreams),
+		).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		}).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// imageToStreams returns a request for each stream that may select the image.
+// A namespace-scoped image may be selected by the streams in its namespace,
+// and a cluster-scoped image by the streams that include cluster images.
+func (r *Reconciler) imageToStreams(ctx context.Context, o client.Object) []reconcile.Request {
+	var opts []client.ListOption
+	if ns := o.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+
+	var list vmopv1.VirtualMachineImageStreamList
+	if err := r.Client.List(ctx, &list, opts...); err != nil {
+		pkglog.FromContextOrDefault(ctx).Error(err, "Failed to list VirtualMachineImageStreams")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		s := &list.Items[i]
+		if o.GetNamespace() == "" && !s.Spec.IncludeClusterImages {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(s),
+		})
+	}
+	return requests
+}
+
+// Reconciler reconciles a VirtualMachineImageStream object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagestreams,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagestreams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
+
+// Reconcile reconciles a VirtualMachineImageStream object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	stream := &vmopv1.VirtualMachineImageStream{}
+	if err := r.Get(ctx, req.NamespacedName, stream); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	streamCtx := &pkgctx.VirtualMachineImageStreamContext{
+		Context:     ctx,
+		Logger:      pkglog.FromContextOrDefault(ctx),
+		ImageStream: stream,
+	}
+
+	patchHelper, err := patch.NewHelper(stream, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", streamCtx, err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, stream); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			streamCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !stream.DeletionTimestamp.IsZero() {
+		// There are no resources to clean up.
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(streamCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImageStreamContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineImageStream")
+	stream := ctx.ImageStream
+
+	m, err := newImageMatcher(stream.Spec)
+	if err != nil {
+		conditions.MarkFalse(
+			stream,
+			vmopv1.VirtualMachineImageStreamConditionImageResolved,
+			vmopv1.VirtualMachineImageStreamInvalidSelectorReason,
+			"%v", err)
+		stream.Status.ObservedGeneration = stream.Generation
+		return nil
+	}
+
+	candidates, err := r.getCandidates(ctx, stream, m)
+	if err != nil {
+		return err
+	}
+
+	var latest *candidate
+	for i := range candidates {
+		if latest == nil || compareCandidates(candidates[i], *latest) > 0 {
+			latest = &candidates[i]
+		}
+	}
+
+	r.updateStatus(ctx, latest)
+
+	return nil
+}
+
+// getCandidates returns the ready images that the stream may select and that
+// match its selectors.
+func (r *Reconciler) getCandidates(
+	ctx *pkgctx.VirtualMachineImageStreamContext,
+	stream *vmopv1.VirtualMachineImageStream,
+	m imageMatcher) ([]candidate, error) {
+
+	var images []vmopv1.VirtualMachineImage
+	var kinds []string
+
+	var vmiList vmopv1.VirtualMachineImageList
+	if err := r.Client.List(ctx, &vmiList, client.InNamespace(stream.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineImages: %w", err)
+	}
+	for i := range vmiList.Items {
+		images = append(images, vmiList.Items[i])
+		kinds = append(kinds, vmiKind)
+	}
+
+	if stream.Spec.IncludeClusterImages {
+		var cvmiList vmopv1.ClusterVirtualMachineImageList
+		if err := r.Client.List(ctx, &cvmiList); err != nil {
+			return nil, fmt.Errorf("failed to list ClusterVirtualMachineImages: %w", err)
+		}
+		for i := range cvmiList.Items {
+			images = append(images, vmopv1.VirtualMachineImage(cvmiList.Items[i]))
+			kinds = append(kinds, cvmiKind)
+		}
+	}
+
+	var candidates []candidate
+	for i := range images {
+		if vmopv1util.IsImageReady(images[i]) != nil {
+			continue
+		}
+		if c, ok := m.match(images[i], kinds[i]); ok {
+			candidates = append(candidates, c)
+		}
+	}
+
+	return candidates, nil
+}
+
+// updateStatus records the latest image in the stream's status, or clears
+// the current image when no image matches.
+func (r *Reconciler) updateStatus(
+	ctx *pkgctx.VirtualMachineImageStreamContext,
+	latest *candidate) {
+
+	stream := ctx.ImageStream
+	stream.Status.ObservedGeneration = stream.Generation
+
+	if latest == nil {
+		stream.Status.Image = nil
+		stream.Status.ImageName = ""
+		stream.Status.ProductVersion = ""
+		stream.Status.OSVersion = ""
+		conditions.MarkFalse(
+			stream,
+			vmopv1.VirtualMachineImageStreamConditionImageResolved,
+			vmopv1.VirtualMachineImageStreamNoMatchingImagesReason,
+			"No ready images match the stream's selectors")
+		return
+	}
+
+	if stream.Status.Image == nil || *stream.Status.Image != latest.ref {
+		ctx.Logger.Info("Image stream moved to a new image",
+			"oldImage", stream.Status.Image, "newImage", latest.ref)
+		r.Recorder.Eventf(stream, ImageUpdatedReason,
+			"Moved to %s %q (%s)", latest.ref.Kind, latest.ref.Name, latest.image.Status.Name)
+		stream.Status.LastUpdateTime = metav1.NewTime(time.Now())
+	}
+
+	stream.Status.Image = &vmopv1.VirtualMachineImageRef{
+		Kind: latest.ref.Kind,
+		Name: latest.ref.Name,
+	}
+	stream.Status.ImageName = latest.image.Status.Name
+	stream.Status.ProductVersion = latest.image.Status.ProductInfo.Version
+	stream.Status.OSVersion = latest.image.Status.OSInfo.Version
+	conditions.MarkTrue(stream, vmopv1.VirtualMachineImageStreamConditionImageResolved)
+}
@@ -0,0 +1,120 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagestream_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		stream *vmopv1.VirtualMachineImageStream
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		stream = &vmopv1.VirtualMachineImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "photon",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineImageStreamSpec{
+				Product: &vmopv1.VirtualMachineImageStreamProductSelector{
+					Product: "photon",
+					Version: ">=5.0.0 <6.0.0",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getStream := func() *vmopv1.VirtualMachineImageStream {
+		obj := &vmopv1.VirtualMachineImageStream{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(stream), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	createImage := func(name, productVersion string) {
+		img := readyImage(name, productVersion)
+		img.Namespace = ctx.Namespace
+		status := img.Status.DeepCopy()
+		Expect(ctx.Client.Create(ctx, img)).To(Succeed())
+		img.Status = *status
+		Expect(ctx.Client.Status().Update(ctx, img)).To(Succeed())
+	}
+
+	It("moves to newer images as they become available", func() {
+		Expect(ctx.Client.Create(ctx, stream)).To(Succeed())
+
+		By("no image matches", func() {
+			Eventually(func(g Gomega) {
+				s := getStream()
+				g.Expect(s).ToNot(BeNil())
+				g.Expect(conditions.GetReason(s, vmopv1.VirtualMachineImageStreamConditionImageResolved)).
+					To(Equal(vmopv1.VirtualMachineImageStreamNoMatchingImagesReason))
+			}).Should(Succeed())
+		})
+
+		By("an image is added", func() {
+			createImage("vmi-1", "5.0.1")
+			Eventually(func(g Gomega) {
+				s := getStream()
+				g.Expect(s).ToNot(BeNil())
+				g.Expect(s.Status.Image).ToNot(BeNil())
+				g.Expect(s.Status.Image.Name).To(Equal("vmi-1"))
+				g.Expect(conditions.IsTrue(s, vmopv1.VirtualMachineImageStreamConditionImageResolved)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		By("a newer image is added", func() {
+			createImage("vmi-2", "5.1.0")
+			Eventually(func(g Gomega) {
+				s := getStream()
+				g.Expect(s).ToNot(BeNil())
+				g.Expect(s.Status.Image).ToNot(BeNil())
+				g.Expect(s.Status.Image.Name).To(Equal("vmi-2"))
+				g.Expect(s.Status.ProductVersion).To(Equal("5.1.0"))
+			}).Should(Succeed())
+		})
+
+		By("an image outside of the range is added", func() {
+			createImage("vmi-3", "6.0.0")
+			Consistently(func(g Gomega) {
+				s := getStream()
+				g.Expect(s).ToNot(BeNil())
+				g.Expect(s.Status.Image).ToNot(BeNil())
+				g.Expect(s.Status.Image.Name).To(Equal("vmi-2"))
+			}, "1s").Should(Succeed())
+		})
+	})
+}
@@ -0,0 +1,256 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagestream_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagestream"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+// readyImage returns a ready image with the provided product version.
+func readyImage(name, productVersion string) *vmopv1.VirtualMachineImage {
+	img := builder.DummyVirtualMachineImage(name)
+	img.Namespace = builder.DummyNamespaceName
+	img.Spec.ProviderRef = &vmopv1common.LocalObjectRef{
+		Kind: "ContentLibraryItem",
+		Name: name,
+	}
+	img.Status.ProductInfo = vmopv1.VirtualMachineImageProductInfo{
+		Product: "photon",
+		Vendor:  "vmware",
+		Version: productVersion,
+	}
+	img.Status.OSInfo = vmopv1.VirtualMachineImageOSInfo{
+		ID:      "photon",
+		Version: "5.0",
+	}
+	conditions.MarkTrue(img, vmopv1.ReadyConditionType)
+	return img
+}
+
+// readyClusterImage returns a ready cluster image with the provided product
+// version.
+func readyClusterImage(name, productVersion string) *vmopv1.ClusterVirtualMachineImage {
+	img := readyImage(name, productVersion)
+	img.Namespace = ""
+	return (*vmopv1.ClusterVirtualMachineImage)(img)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *virtualmachineimagestream.Reconciler
+		stream      *vmopv1.VirtualMachineImageStream
+		streamCtx   *pkgctx.VirtualMachineImageStreamContext
+	)
+
+	BeforeEach(func() {
+		stream = &vmopv1.VirtualMachineImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "photon",
+				Namespace: builder.DummyNamespaceName,
+			},
+			Spec: vmopv1.VirtualMachineImageStreamSpec{
+				Product: &vmopv1.VirtualMachineImageStreamProductSelector{
+					Product: "photon",
+					Version: ">=5.0.0 <6.0.0",
+				},
+			},
+		}
+		initObjects = []client.Object{
+			readyImage("vmi-1", "5.0.1"),
+			readyImage("vmi-2", "5.2.0"),
+			readyImage("vmi-3", "6.0.0"),
+			readyImage("vmi-4", "4.9.9"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, stream)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineimagestream.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		streamCtx = &pkgctx.VirtualMachineImageStreamContext{
+			Context:     ctx,
+			Logger:      ctx.Logger.WithName(stream.Name),
+			ImageStream: stream,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		stream = nil
+		streamCtx = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		It("selects the image with the highest version in the range", func() {
+			Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+			Expect(stream.Status.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+				Kind: "VirtualMachineImage",
+				Name: "vmi-2",
+			}))
+			Expect(stream.Status.ImageName).To(Equal("vmi-2"))
+			Expect(stream.Status.ProductVersion).To(Equal("5.2.0"))
+			Expect(stream.Status.OSVersion).To(Equal("5.0"))
+			Expect(stream.Status.LastUpdateTime.IsZero()).To(BeFalse())
+			Expect(conditions.IsTrue(stream, vmopv1.VirtualMachineImageStreamConditionImageResolved)).To(BeTrue())
+			Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachineimagestream.ImageUpdatedReason)))
+		})
+
+		When("the stream already points to the latest image", func() {
+			var lastUpdateTime metav1.Time
+
+			BeforeEach(func() {
+				lastUpdateTime = metav1.NewTime(metav1.Now().Add(-time.Hour))
+				stream.Status.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-2",
+				}
+				stream.Status.LastUpdateTime = lastUpdateTime
+			})
+
+			It("does not update the last update time or emit an event", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image.Name).To(Equal("vmi-2"))
+				Expect(stream.Status.LastUpdateTime).To(Equal(lastUpdateTime))
+				Expect(ctx.Events).ToNot(Receive())
+			})
+		})
+
+		When("the image with the highest version is not ready", func() {
+			BeforeEach(func() {
+				img := readyImage("vmi-5", "5.9.0")
+				conditions.MarkFalse(img, vmopv1.ReadyConditionType, "NotReady", "")
+				initObjects = append(initObjects, img)
+			})
+
+			It("selects the highest version of the ready images", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image.Name).To(Equal("vmi-2"))
+			})
+		})
+
+		When("there are matching cluster images", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, readyClusterImage("cvmi-1", "5.3.0"))
+			})
+
+			It("ignores the cluster images", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image.Name).To(Equal("vmi-2"))
+			})
+
+			When("the stream includes cluster images", func() {
+				BeforeEach(func() {
+					stream.Spec.IncludeClusterImages = true
+				})
+
+				It("selects the cluster image", func() {
+					Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+					Expect(stream.Status.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+						Kind: "ClusterVirtualMachineImage",
+						Name: "cvmi-1",
+					}))
+				})
+			})
+		})
+
+		When("the stream has a label selector", func() {
+			BeforeEach(func() {
+				img := readyImage("vmi-5", "5.1.0")
+				img.Labels = map[string]string{"channel": "stable"}
+				initObjects = append(initObjects, img)
+
+				stream.Spec.Selector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"channel": "stable"},
+				}
+			})
+
+			It("only selects images with matching labels", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image.Name).To(Equal("vmi-5"))
+			})
+		})
+
+		When("no image matches", func() {
+			BeforeEach(func() {
+				stream.Spec.Product.Version = ">=7.0.0"
+				stream.Status.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-2",
+				}
+				stream.Status.ImageName = "vmi-2"
+			})
+
+			It("clears the image and sets ImageResolved to false", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image).To(BeNil())
+				Expect(stream.Status.ImageName).To(BeEmpty())
+				Expect(conditions.GetReason(stream, vmopv1.VirtualMachineImageStreamConditionImageResolved)).
+					To(Equal(vmopv1.VirtualMachineImageStreamNoMatchingImagesReason))
+			})
+		})
+
+		When("the version range is invalid", func() {
+			BeforeEach(func() {
+				stream.Spec.Product.Version = "not-a-range"
+			})
+
+			It("sets ImageResolved to false", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image).To(BeNil())
+				Expect(conditions.GetReason(stream, vmopv1.VirtualMachineImageStreamConditionImageResolved)).
+					To(Equal(vmopv1.VirtualMachineImageStreamInvalidSelectorReason))
+			})
+		})
+
+		When("the images have the same product version", func() {
+			BeforeEach(func() {
+				img := readyImage("vmi-5", "5.2.0")
+				img.Status.OSInfo.Version = "5.1"
+				initObjects = append(initObjects, img)
+			})
+
+			It("selects the image with the highest OS version", func() {
+				Expect(reconciler.ReconcileNormal(streamCtx)).To(Succeed())
+				Expect(stream.Status.Image.Name).To(Equal("vmi-5"))
+				Expect(stream.Status.OSVersion).To(Equal("5.1"))
+			})
+		})
+	})
+}
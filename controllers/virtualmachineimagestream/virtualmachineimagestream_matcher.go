@@ -0,0 +1,152 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagestream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// candidate is an image that matches a stream's selectors.
+type candidate struct {
+	ref            vmopv1.VirtualMachineImageRef
+	image          vmopv1.VirtualMachineImage
+	productVersion *semver.Version
+	osVersion      *semver.Version
+}
+
+// imageMatcher matches images against a stream's selectors.
+type imageMatcher struct {
+	product      vmopv1.VirtualMachineImageStreamProductSelector
+	os           vmopv1.VirtualMachineImageStreamOSSelector
+	productRange semver.Range
+	osRange      semver.Range
+	selector     labels.Selector
+}
+
+func newImageMatcher(spec vmopv1.VirtualMachineImageStreamSpec) (imageMatcher, error) {
+	m := imageMatcher{
+		selector: labels.Everything(),
+	}
+
+	if spec.Product != nil {
+		m.product = *spec.Product
+	}
+	if spec.OS != nil {
+		m.os = *spec.OS
+	}
+
+	var err error
+	if m.product.Version != "" {
+		if m.productRange, err = semver.ParseRange(m.product.Version); err != nil {
+			return imageMatcher{}, fmt.Errorf("invalid product version range %q: %w", m.product.Version, err)
+		}
+	}
+	if m.os.Version != "" {
+		if m.osRange, err = semver.ParseRange(m.os.Version); err != nil {
+			return imageMatcher{}, fmt.Errorf("invalid OS version range %q: %w", m.os.Version, err)
+		}
+	}
+	if spec.Selector != nil {
+		if m.selector, err = metav1.LabelSelectorAsSelector(spec.Selector); err != nil {
+			return imageMatcher{}, fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// match returns the image as a candidate if it matches the selectors.
+func (m imageMatcher) match(img vmopv1.VirtualMachineImage, kind string) (candidate, bool) {
+	var (
+		productInfo = img.Status.ProductInfo
+		osInfo      = img.Status.OSInfo
+	)
+
+	if m.product.Product != "" && m.product.Product != productInfo.Product {
+		return candidate{}, false
+	}
+	if m.product.Vendor != "" && m.product.Vendor != productInfo.Vendor {
+		return candidate{}, false
+	}
+	if m.os.ID != "" && m.os.ID != osInfo.ID {
+		return candidate{}, false
+	}
+	if m.os.Type != "" && m.os.Type != osInfo.Type {
+		return candidate{}, false
+	}
+	if !m.selector.Matches(labels.Set(img.Labels)) {
+		return candidate{}, false
+	}
+
+	c := candidate{
+		ref: vmopv1.VirtualMachineImageRef{
+			Kind: kind,
+			Name: img.Name,
+		},
+		image:          img,
+		productVersion: parseVersion(productInfo.Version, productInfo.FullVersion),
+		osVersion:      parseVersion(osInfo.Version),
+	}
+
+	if m.productRange != nil && (c.productVersion == nil || !m.productRange(*c.productVersion)) {
+		return candidate{}, false
+	}
+	if m.osRange != nil && (c.osVersion == nil || !m.osRange(*c.osVersion)) {
+		return candidate{}, false
+	}
+
+	return c, true
+}
+
+// parseVersion returns the first of the values that can be parsed as a
+// semantic version, or nil if none can.
+func parseVersion(values ...string) *semver.Version {
+	for _, s := range values {
+		if s == "" {
+			continue
+		}
+		if v, err := semver.ParseTolerant(s); err == nil {
+			return &v
+		}
+	}
+	return nil
+}
+
+// compareVersions compares two versions, where a nil version is lower than
+// any other version.
+func compareVersions(a, b *semver.Version) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
+
+// compareCandidates orders candidates by their product version, then by
+// their OS version, then by when the image was created. The names of the
+// images make the order deterministic when all else is equal.
+func compareCandidates(a, b candidate) int {
+	if c := compareVersions(a.productVersion, b.productVersion); c != 0 {
+		return c
+	}
+	if c := compareVersions(a.osVersion, b.osVersion); c != 0 {
+		return c
+	}
+	if c := a.image.CreationTimestamp.Compare(b.image.CreationTimestamp.Time); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ref.Name, b.ref.Name)
+}
@@ -0,0 +1,194 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/networkpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+const (
+	// ApplyFailedReason is the reason of the event that is emitted when a
+	// policy cannot be applied.
+	ApplyFailedReason = "ApplyFailed"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineNetworkPolicy{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	// When the network provider does not support network policies, the
+	// controller still reconciles the policies to report that in their
+	// status.
+	provider, err := networkpolicy.NewProvider(
+		pkgcfg.FromContext(ctx).NetworkProviderType,
+		mgr.GetClient())
+	if err != nil && !errors.Is(err, networkpolicy.ErrProviderNotSupported) {
+		return err
+	}
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		provider,
+	)
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		})
+
+	if provider != nil && provider.ObjectType() != nil {
+		// Reconcile the policy when the provider realizes its constructs.
+		builder = builder.Owns(provider.ObjectType())
+	}
+
+	return builder.Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// Reconciler reconciles a VirtualMachineNetworkPolicy object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+
+	// Provider applies the policies. It is nil when the network provider does
+	// not support network policies.
+	Provider networkpolicy.Provider
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	provider networkpolicy.Provider) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+		Provider: provider,
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=crd.nsx.vmware.com,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nsx.vmware.com,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile reconciles a VirtualMachineNetworkPolicy object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	policy := &vmopv1.VirtualMachineNetworkPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	policyCtx := &pkgctx.VirtualMachineNetworkPolicyContext{
+		Context:       ctx,
+		Logger:        pkglog.FromContextOrDefault(ctx),
+		NetworkPolicy: policy,
+	}
+
+	patchHelper, err := patch.NewHelper(policy, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", policyCtx, err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, policy); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			policyCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !policy.DeletionTimestamp.IsZero() {
+		// The provider's constructs are owned by the policy and are garbage
+		// collected.
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(policyCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineNetworkPolicyContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineNetworkPolicy")
+	policy := ctx.NetworkPolicy
+	policy.Status.ObservedGeneration = policy.Generation
+
+	if r.Provider == nil {
+		policy.Status.Provider = ""
+		conditions.MarkFalse(
+			policy,
+			vmopv1.VirtualMachineNetworkPolicyConditionApplied,
+			vmopv1.VirtualMachineNetworkPolicyProviderNotSupportedReason,
+			"The network provider %q does not support VirtualMachineNetworkPolicies",
+			pkgcfg.FromContext(ctx).NetworkProviderType)
+		return nil
+	}
+
+	policy.Status.Provider = r.Provider.Name()
+
+	status, err := r.Provider.Apply(ctx, policy)
+	if err != nil {
+		r.Recorder.Warnf(policy, ApplyFailedReason, "Failed to apply policy: %v", err)
+		conditions.MarkFalse(
+			policy,
+			vmopv1.VirtualMachineNetworkPolicyConditionApplied,
+			vmopv1.VirtualMachineNetworkPolicyApplyFailedReason,
+			"%v", err)
+		return err
+	}
+
+	if !status.Realized {
+		conditions.MarkFalse(
+			policy,
+			vmopv1.VirtualMachineNetworkPolicyConditionApplied,
+			vmopv1.VirtualMachineNetworkPolicyNotRealizedReason,
+			"%s", status.Message)
+		return nil
+	}
+
+	conditions.MarkTrue(policy, vmopv1.VirtualMachineNetworkPolicyConditionApplied)
+	return nil
+}
@@ -0,0 +1,73 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		policy *vmopv1.VirtualMachineNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		policy = &vmopv1.VirtualMachineNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+				VMSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "db"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	// The suite's network provider is NAMED, which does not support network
+	// policies.
+	It("reports the network provider is not supported", func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachineNetworkPolicy{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(policy), obj)).To(Succeed())
+			c := conditions.Get(obj, vmopv1.VirtualMachineNetworkPolicyConditionApplied)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicyProviderNotSupportedReason))
+		}).Should(Succeed())
+	})
+}
@@ -0,0 +1,206 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinenetworkpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/networkpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/networkpolicy/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		provider    *fake.Provider
+		reconciler  *virtualmachinenetworkpolicy.Reconciler
+		policy      *vmopv1.VirtualMachineNetworkPolicy
+		policyCtx   *pkgctx.VirtualMachineNetworkPolicyContext
+	)
+
+	BeforeEach(func() {
+		provider = fake.NewProvider()
+		policy = &vmopv1.VirtualMachineNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "db",
+				Namespace:  builder.DummyNamespaceName,
+				Generation: 2,
+			},
+			Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+				VMSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "db"},
+				},
+				Ingress: []vmopv1.VirtualMachineNetworkPolicyIngressRule{
+					{
+						From: []vmopv1.VirtualMachineNetworkPolicyPeer{
+							{
+								VMSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"tier": "app"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinenetworkpolicy.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			provider,
+		)
+		policyCtx = &pkgctx.VirtualMachineNetworkPolicyContext{
+			Context:       ctx,
+			Logger:        ctx.Logger.WithName(policy.Name),
+			NetworkPolicy: policy,
+		}
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		initObjects = nil
+		provider = nil
+		reconciler = nil
+		policy = nil
+		policyCtx = nil
+	})
+
+	Context("ReconcileNormal", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = reconciler.ReconcileNormal(policyCtx)
+		})
+
+		It("applies the policy", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			spec, ok := provider.Applied(client.ObjectKeyFromObject(policy))
+			Expect(ok).To(BeTrue())
+			Expect(spec).To(Equal(policy.Spec))
+
+			Expect(policy.Status.Provider).To(Equal("FAKE"))
+			Expect(policy.Status.ObservedGeneration).To(Equal(int64(2)))
+			Expect(conditions.IsTrue(policy, vmopv1.VirtualMachineNetworkPolicyConditionApplied)).To(BeTrue())
+		})
+
+		When("the provider has not realized the policy", func() {
+			BeforeEach(func() {
+				provider.ApplyFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineNetworkPolicy) (networkpolicy.Status, error) {
+
+					return networkpolicy.Status{Message: "Waiting for NSX"}, nil
+				}
+			})
+
+			It("reports the policy is not realized", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				c := conditions.Get(policy, vmopv1.VirtualMachineNetworkPolicyConditionApplied)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicyNotRealizedReason))
+				Expect(c.Message).To(Equal("Waiting for NSX"))
+			})
+		})
+
+		When("the provider fails to apply the policy", func() {
+			BeforeEach(func() {
+				provider.ApplyFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineNetworkPolicy) (networkpolicy.Status, error) {
+
+					return networkpolicy.Status{}, errors.New("fake error")
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError("fake error"))
+
+				c := conditions.Get(policy, vmopv1.VirtualMachineNetworkPolicyConditionApplied)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicyApplyFailedReason))
+				Expect(c.Message).To(Equal("fake error"))
+			})
+		})
+
+		When("the network provider does not support network policies", func() {
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+				})
+				reconciler.Provider = nil
+				err = reconciler.ReconcileNormal(policyCtx)
+			})
+
+			It("reports the provider is not supported", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policy.Status.Provider).To(BeEmpty())
+
+				c := conditions.Get(policy, vmopv1.VirtualMachineNetworkPolicyConditionApplied)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicyProviderNotSupportedReason))
+				Expect(c.Message).To(Equal(
+					`The network provider "VSPHERE_NETWORK" does not support VirtualMachineNetworkPolicies`))
+			})
+		})
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, policy)
+		})
+
+		It("updates the policy's status", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: policy.Namespace,
+					Name:      policy.Name,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			obj := &vmopv1.VirtualMachineNetworkPolicy{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(policy), obj)).To(Succeed())
+			Expect(obj.Status.Provider).To(Equal("FAKE"))
+			Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineNetworkPolicyConditionApplied)).To(BeTrue())
+		})
+	})
+}
@@ -0,0 +1,419 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineplacementrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	invalidSpecErrorMsg = "webhooks failed to mutate/validate spec. please delete and create again."
+	delTTLMsg           = "%s vm placement request due to TTL expired"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachinePlacementRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		}).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// Reconciler reconciles a VirtualMachinePlacementRequest object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinegroups,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
+
+// Reconcile reconciles a VirtualMachinePlacementRequest object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	placementReq := &vmopv1.VirtualMachinePlacementRequest{}
+	if err := r.Get(ctx, req.NamespacedName, placementReq); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	placementReqCtx := &pkgctx.VirtualMachinePlacementRequestContext{
+		Context:          ctx,
+		Logger:           pkglog.FromContextOrDefault(ctx),
+		PlacementRequest: placementReq,
+	}
+	patchHelper, err := patch.NewHelper(placementReq, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"failed to init patch helper for %s/%s: %w",
+			placementReq.Namespace,
+			placementReq.Name,
+			err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, placementReq); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			placementReqCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !placementReq.DeletionTimestamp.IsZero() {
+		// There are no resources to clean up.
+		return ctrl.Result{}, nil
+	}
+
+	return pkgerr.ResultFromError(r.ReconcileNormal(placementReqCtx))
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePlacementRequestContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachinePlacementRequest")
+	placementReq := ctx.PlacementRequest
+
+	if conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete) {
+		return r.reconcileSpecTTL(ctx)
+	}
+
+	if err := r.verifySpec(ctx); err != nil {
+		return err
+	}
+
+	if placementReq.Status.StartTime.IsZero() {
+		placementReq.Status.StartTime = metav1.NewTime(time.Now())
+	}
+
+	var (
+		results map[string]vmopv1.VirtualMachinePlacementRequestResult
+		err     error
+	)
+	if placementReq.Spec.GroupName != "" {
+		results, err = r.placeGroup(ctx)
+	} else {
+		results, err = r.placeVMs(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.reconcileStatusResults(ctx, results)
+
+	return nil
+}
+
+// placeVMs places each of the request's VMs by itself, the same way as a VM
+// that is not a member of a group is placed when it is created. The VMs are
+// placed in order so the capacity reserved by each VM is not available to the
+// VMs placed after it.
+func (r *Reconciler) placeVMs(
+	ctx *pkgctx.VirtualMachinePlacementRequestContext) (map[string]vmopv1.VirtualMachinePlacementRequestResult, error) {
+
+	vms := r.getRequestVMs(ctx)
+	placementResults := r.VMProvider.PlaceVirtualMachines(ctx, vms)
+	if len(placementResults) != len(vms) {
+		return nil, fmt.Errorf("expected %d placement results but got %d",
+			len(vms), len(placementResults))
+	}
+
+	results := map[string]vmopv1.VirtualMachinePlacementRequestResult{}
+	for i, vm := range vms {
+		result := vmopv1.VirtualMachinePlacementRequestResult{
+			Name: vm.Name,
+		}
+
+		if err := placementResults[i].Err; err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Placement = placementResults[i].Placement
+		}
+
+		results[vm.Name] = result
+	}
+
+	return results, nil
+}
+
+// placeGroup places the request's VMs together with the VMs that are members
+// of the request's group, the same way as the VMs of a group are placed.
+// Members that already exist on the underlying infrastructure are not placed
+// again.
+func (r *Reconciler) placeGroup(
+	ctx *pkgctx.VirtualMachinePlacementRequestContext) (map[string]vmopv1.VirtualMachinePlacementRequestResult, error) {
+
+	placementReq := ctx.PlacementRequest
+	results := map[string]vmopv1.VirtualMachinePlacementRequestResult{}
+
+	vmGroup := &vmopv1.VirtualMachineGroup{}
+	groupKey := client.ObjectKey{Namespace: placementReq.Namespace, Name: placementReq.Spec.GroupName}
+	if err := r.Get(ctx, groupKey, vmGroup); err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachineGroup %q: %w", groupKey.Name, err)
+	}
+
+	memberNames, err := vmopv1util.RetrieveVMGroupMembers(ctx, r.Client, groupKey, &sets.Set[string]{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachineGroup %q members: %w", groupKey.Name, err)
+	}
+
+	var vms []*vmopv1.VirtualMachine
+	for _, name := range sets.List(memberNames) {
+		vm := &vmopv1.VirtualMachine{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: placementReq.Namespace, Name: name}, vm); err != nil {
+			return nil, fmt.Errorf("failed to get group member VM %q: %w", name, err)
+		}
+		if vm.Status.UniqueID != "" {
+			// The VM already exists so it is not placed with the group.
+			continue
+		}
+		vms = append(vms, vm)
+	}
+
+	for _, vm := range r.getRequestVMs(ctx) {
+		if memberNames.Has(vm.Name) {
+			results[vm.Name] = vmopv1.VirtualMachinePlacementRequestResult{
+				Name:  vm.Name,
+				Error: fmt.Sprintf("VM %q is already a member of group %q", vm.Name, groupKey.Name),
+			}
+			continue
+		}
+		vm.Spec.GroupName = vmGroup.Name
+		vms = append(vms, vm)
+	}
+
+	if len(vms) == 0 {
+		return results, nil
+	}
+
+	// The provider records the placement of each VM in the group's member
+	// status, so place with a copy of the group that has no member status.
+	groupCopy := vmGroup.DeepCopy()
+	groupCopy.Status.Members = nil
+
+	placementErr := r.VMProvider.PlaceVirtualMachineGroup(
+		ctx,
+		groupCopy,
+		[]providers.VMGroupPlacement{
+			{
+				VMGroup:   groupCopy,
+				VMMembers: vms,
+			},
+		})
+
+	for _, vm := range vms {
+		result := vmopv1.VirtualMachinePlacementRequestResult{
+			Name: vm.Name,
+		}
+
+		var memberStatus *vmopv1.VirtualMachineGroupMemberStatus
+		for i := range groupCopy.Status.Members {
+			if m := &groupCopy.Status.Members[i]; m.Kind == "VirtualMachine" && m.Name == vm.Name {
+				memberStatus = m
+				break
+			}
+		}
+
+		switch {
+		case memberStatus != nil && memberStatus.Placement != nil &&
+			conditions.IsTrue(memberStatus, vmopv1.VirtualMachineGroupMemberConditionPlacementReady):
+			result.Placement = memberStatus.Placement
+		case memberStatus != nil &&
+			conditions.GetReason(memberStatus, vmopv1.VirtualMachineGroupMemberConditionPlacementReady) ==
+				vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason:
+			result.Error = conditions.GetMessage(memberStatus, vmopv1.VirtualMachineGroupMemberConditionPlacementReady)
+		case placementErr != nil:
+			result.Error = placementErr.Error()
+		default:
+			result.Error = "no placement result for VM"
+		}
+
+		results[vm.Name] = result
+	}
+
+	return results, nil
+}
+
+// getRequestVMs returns the VMs described by the request's spec. The VMs are
+// never created.
+func (r *Reconciler) getRequestVMs(ctx *pkgctx.VirtualMachinePlacementRequestContext) []*vmopv1.VirtualMachine {
+	var vms []*vmopv1.VirtualMachine
+
+	for _, reqVM := range ctx.PlacementRequest.Spec.VirtualMachines {
+		for _, name := range vmopv1util.PlacementRequestVMNames(reqVM) {
+			vms = append(vms, &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   ctx.PlacementRequest.Namespace,
+					Name:        name,
+					Labels:      reqVM.Template.Labels,
+					Annotations: reqVM.Template.Annotations,
+				},
+				Spec: *reqVM.Template.Spec.DeepCopy(),
+			})
+		}
+	}
+
+	return vms
+}
+
+// reconcileStatusResults updates the status with a sorted list of the
+// placement results, and marks the request as complete.
+func (r *Reconciler) reconcileStatusResults(
+	ctx *pkgctx.VirtualMachinePlacementRequestContext,
+	results map[string]vmopv1.VirtualMachinePlacementRequestResult) {
+
+	placementReq := ctx.PlacementRequest
+
+	placementReq.Status.Results = slices.SortedFunc(maps.Values(results),
+		func(a, b vmopv1.VirtualMachinePlacementRequestResult) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+	var failed []string
+	for _, result := range placementReq.Status.Results {
+		if result.Error != "" {
+			failed = append(failed, result.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		conditions.MarkFalse(
+			placementReq,
+			vmopv1.VirtualMachinePlacementRequestConditionPlaced,
+			vmopv1.VirtualMachinePlacementRequestConditionReasonPlacementFailed,
+			"failed to place %d of %d VMs: %s",
+			len(failed),
+			len(placementReq.Status.Results),
+			strings.Join(failed, ", "))
+		r.Recorder.Warn(placementReq, "PlacementFailed",
+			fmt.Sprintf("Failed to place %d of %d VMs", len(failed), len(placementReq.Status.Results)))
+	} else {
+		conditions.MarkTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionPlaced)
+	}
+
+	conditions.MarkTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)
+	placementReq.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VirtualMachinePlacementRequest completed", "failed", len(failed))
+}
+
+// verifySpec ensures the spec was validated by the webhooks. It sets the
+// completed condition to false with a message asking the user to re-create
+// the request.
+func (r *Reconciler) verifySpec(ctx *pkgctx.VirtualMachinePlacementRequestContext) error {
+	placementReq := ctx.PlacementRequest
+
+	var err error
+	switch {
+	case len(placementReq.Spec.VirtualMachines) == 0 && placementReq.Spec.GroupName == "":
+		err = errors.New("one of spec.virtualMachines or spec.groupName must be set")
+	case placementReq.Spec.GroupName != "" && !pkgcfg.FromContext(ctx).Features.VMGroups:
+		err = errors.New("spec.groupName requires the VM groups feature")
+	}
+
+	if err != nil {
+		// In the rare case that webhooks were down when the request was
+		// created set condition to failure.
+		conditions.MarkError(placementReq,
+			vmopv1.VirtualMachinePlacementRequestConditionComplete,
+			invalidSpecErrorMsg,
+			err)
+
+		return pkgerr.NoRequeueError{Message: invalidSpecErrorMsg}
+	}
+
+	return nil
+}
+
+// reconcileSpecTTL deletes the placement request once the TTL expires.
+func (r *Reconciler) reconcileSpecTTL(ctx *pkgctx.VirtualMachinePlacementRequestContext) error {
+	placementReq := ctx.PlacementRequest
+
+	// Skip deletion when ttl is nil.
+	if placementReq.Spec.TTLSecondsAfterFinished == nil {
+		return nil
+	}
+
+	// When ttl is zero the expiration time is the same as the completion time
+	// which triggers immediate deletion.
+	expirationTime := placementReq.Status.CompletionTime.Time.Add(
+		time.Duration(*placementReq.Spec.TTLSecondsAfterFinished) * time.Second)
+	if time.Now().Before(expirationTime) {
+		return pkgerr.RequeueError{After: time.Until(expirationTime)}
+	}
+
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleting"))
+	if err := r.Delete(ctx, placementReq); client.IgnoreNotFound(err) != nil {
+		ctx.Logger.Error(err, fmt.Sprintf(delTTLMsg, "failed to delete"))
+		return err
+	}
+	ctx.Logger.Info(fmt.Sprintf(delTTLMsg, "deleted"))
+	return nil
+}
@@ -0,0 +1,122 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineplacementrequest_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx          *builder.IntegrationTestContext
+		placementReq *vmopv1.VirtualMachinePlacementRequest
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		intgFakeVMProvider.Lock()
+		intgFakeVMProvider.PlaceVirtualMachinesFn = func(
+			_ context.Context,
+			vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+
+			results := make([]providers.VMPlacementResult, len(vms))
+			for i, vm := range vms {
+				results[i].Placement = &vmopv1.VirtualMachinePlacementStatus{
+					Zone: "zone-a",
+					Node: "host-" + vm.Name,
+				}
+			}
+			return results
+		}
+		intgFakeVMProvider.Unlock()
+
+		placementReq = &vmopv1.VirtualMachinePlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-placement-request",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachinePlacementRequestSpec{
+				VirtualMachines: []vmopv1.VirtualMachinePlacementRequestVM{
+					{
+						Name:     "web",
+						Replicas: ptr.To[int32](2),
+						Template: vmopv1.VirtualMachineTemplateSpec{
+							Spec: vmopv1.VirtualMachineSpec{
+								ClassName: builder.DummyClassName,
+								ImageName: builder.DummyVMIName,
+							},
+						},
+					},
+				},
+				TTLSecondsAfterFinished: ptr.To[int64](0),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		placementReq = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+		BeforeEach(func() {
+			// Keep the request around long enough to observe its status.
+			placementReq.Spec.TTLSecondsAfterFinished = ptr.To[int64](3600)
+		})
+
+		It("places the VMs and completes the request", func() {
+			Expect(ctx.Client.Create(ctx, placementReq)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				req := &vmopv1.VirtualMachinePlacementRequest{}
+				g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(placementReq), req)).To(Succeed())
+				g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachinePlacementRequestConditionComplete)).To(BeTrue())
+				g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachinePlacementRequestConditionPlaced)).To(BeTrue())
+				g.Expect(req.Status.Results).To(HaveLen(2))
+				g.Expect(req.Status.Results[0].Name).To(Equal("web-0"))
+				g.Expect(req.Status.Results[0].Placement).ToNot(BeNil())
+				g.Expect(req.Status.Results[0].Placement.Node).To(Equal("host-web-0"))
+			}).Should(Succeed())
+		})
+	})
+
+	Context("TTL", func() {
+		It("deletes the request once it is complete", func() {
+			Expect(ctx.Client.Create(ctx, placementReq)).To(Succeed())
+
+			Eventually(func() bool {
+				err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(placementReq), &vmopv1.VirtualMachinePlacementRequest{})
+				return err != nil
+			}).Should(BeTrue())
+		})
+	})
+}
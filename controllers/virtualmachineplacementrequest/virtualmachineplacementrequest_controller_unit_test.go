@@ -0,0 +1,413 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineplacementrequest_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineplacementrequest"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		groupName = "my-group"
+	)
+
+	var (
+		initObjects     []client.Object
+		ctx             *builder.UnitTestContextForController
+		reconciler      *virtualmachineplacementrequest.Reconciler
+		fakeVMProvider  *providerfake.VMProvider
+		placementReq    *vmopv1.VirtualMachinePlacementRequest
+		placementReqCtx *pkgctx.VirtualMachinePlacementRequestContext
+	)
+
+	BeforeEach(func() {
+		placementReq = &vmopv1.VirtualMachinePlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-placement-request",
+				Namespace: builder.DummyNamespaceName,
+			},
+			Spec: vmopv1.VirtualMachinePlacementRequestSpec{
+				VirtualMachines: []vmopv1.VirtualMachinePlacementRequestVM{
+					{
+						Name:     "web",
+						Replicas: ptr.To[int32](2),
+						Template: vmopv1.VirtualMachineTemplateSpec{
+							Spec: vmopv1.VirtualMachineSpec{
+								ClassName: builder.DummyClassName,
+								ImageName: builder.DummyVMIName,
+							},
+						},
+					},
+					{
+						Name: "db",
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineplacementrequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		placementReqCtx = &pkgctx.VirtualMachinePlacementRequestContext{
+			Context:          ctx,
+			Logger:           ctx.Logger.WithName(placementReq.Name),
+			PlacementRequest: placementReq,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider = nil
+		placementReq = nil
+		placementReqCtx = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("spec has neither VMs nor a group", func() {
+			BeforeEach(func() {
+				placementReq.Spec.VirtualMachines = nil
+			})
+
+			It("should set complete condition false", func() {
+				err := reconciler.ReconcileNormal(placementReqCtx)
+				Expect(err).To(Equal(pkgerr.NoRequeueError{
+					Message: "webhooks failed to mutate/validate spec. please delete and create again.",
+				}))
+				c := conditions.Get(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Message).To(ContainSubstring("one of spec.virtualMachines or spec.groupName must be set"))
+			})
+		})
+
+		When("all VMs can be placed", func() {
+			var placedVMs []*vmopv1.VirtualMachine
+
+			JustBeforeEach(func() {
+				placedVMs = nil
+				fakeVMProvider.PlaceVirtualMachinesFn = func(
+					_ context.Context,
+					vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+
+					placedVMs = vms
+					results := make([]providers.VMPlacementResult, len(vms))
+					for i, vm := range vms {
+						results[i].Placement = &vmopv1.VirtualMachinePlacementStatus{
+							Zone: "zone-a",
+							Node: "host-" + vm.Name,
+							Pool: "resgroup-1",
+						}
+					}
+					return results
+				}
+			})
+
+			It("should report the placement of each VM", func() {
+				Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+
+				Expect(placedVMs).To(HaveLen(3))
+				for _, vm := range placedVMs {
+					Expect(vm.Namespace).To(Equal(placementReq.Namespace))
+					Expect(vm.Spec.GroupName).To(BeEmpty())
+				}
+				Expect(placedVMs[0].Spec.ClassName).To(Equal(builder.DummyClassName))
+
+				Expect(placementReq.Status.StartTime).ToNot(Equal(metav1.Time{}))
+				Expect(placementReq.Status.CompletionTime).ToNot(Equal(metav1.Time{}))
+				Expect(placementReq.Status.Results).To(HaveLen(3))
+				Expect(placementReq.Status.Results[0].Name).To(Equal("db"))
+				Expect(placementReq.Status.Results[1].Name).To(Equal("web-0"))
+				Expect(placementReq.Status.Results[2].Name).To(Equal("web-1"))
+				for _, r := range placementReq.Status.Results {
+					Expect(r.Error).To(BeEmpty())
+					Expect(r.Placement).ToNot(BeNil())
+					Expect(r.Placement.Zone).To(Equal("zone-a"))
+					Expect(r.Placement.Node).To(Equal("host-" + r.Name))
+				}
+
+				Expect(conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)).To(BeTrue())
+				Expect(conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionPlaced)).To(BeTrue())
+			})
+		})
+
+		When("a VM cannot be placed", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.PlaceVirtualMachinesFn = func(
+					_ context.Context,
+					vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+
+					results := make([]providers.VMPlacementResult, len(vms))
+					for i, vm := range vms {
+						if vm.Name == "db" {
+							results[i].Err = errors.New("no placement candidates available")
+						} else {
+							results[i].Placement = &vmopv1.VirtualMachinePlacementStatus{Zone: "zone-a"}
+						}
+					}
+					return results
+				}
+			})
+
+			It("should report the fault", func() {
+				Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+
+				Expect(placementReq.Status.Results).To(HaveLen(3))
+				Expect(placementReq.Status.Results[0].Placement).To(BeNil())
+				Expect(placementReq.Status.Results[0].Error).To(Equal("no placement candidates available"))
+				Expect(placementReq.Status.Results[1].Placement).ToNot(BeNil())
+
+				Expect(conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)).To(BeTrue())
+				c := conditions.Get(placementReq, vmopv1.VirtualMachinePlacementRequestConditionPlaced)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachinePlacementRequestConditionReasonPlacementFailed))
+				Expect(c.Message).To(ContainSubstring("db"))
+			})
+		})
+
+		When("spec has a group", func() {
+			var vmGroup *vmopv1.VirtualMachineGroup
+
+			BeforeEach(func() {
+				placementReq.Spec.GroupName = groupName
+				placementReq.Spec.VirtualMachines = placementReq.Spec.VirtualMachines[:1]
+
+				vmGroup = &vmopv1.VirtualMachineGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      groupName,
+						Namespace: placementReq.Namespace,
+					},
+				}
+
+				newMember := builder.DummyBasicVirtualMachine("member-new", placementReq.Namespace)
+				newMember.Spec.GroupName = groupName
+				createdMember := builder.DummyBasicVirtualMachine("member-created", placementReq.Namespace)
+				createdMember.Spec.GroupName = groupName
+				createdMember.Status.UniqueID = "vm-42"
+
+				for _, vm := range []*vmopv1.VirtualMachine{newMember, createdMember} {
+					ms := vmopv1.VirtualMachineGroupMemberStatus{
+						Name: vm.Name,
+						Kind: "VirtualMachine",
+					}
+					conditions.MarkTrue(&ms, vmopv1.VirtualMachineGroupMemberConditionGroupLinked)
+					vmGroup.Status.Members = append(vmGroup.Status.Members, ms)
+				}
+
+				initObjects = append(initObjects, vmGroup, newMember, createdMember)
+			})
+
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.Features.VMGroups = true
+				})
+			})
+
+			When("VM groups feature is disabled", func() {
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.Features.VMGroups = false
+					})
+				})
+
+				It("should set complete condition false", func() {
+					Expect(reconciler.ReconcileNormal(placementReqCtx)).ToNot(Succeed())
+					c := conditions.Get(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				})
+			})
+
+			When("the group does not exist", func() {
+				BeforeEach(func() {
+					placementReq.Spec.GroupName = "does-not-exist"
+				})
+
+				It("should return an error", func() {
+					err := reconciler.ReconcileNormal(placementReqCtx)
+					Expect(err).To(MatchError(ContainSubstring("failed to get VirtualMachineGroup")))
+					Expect(conditions.Get(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)).To(BeNil())
+				})
+			})
+
+			It("should place the request's VMs with the group's members", func() {
+				var placedVMs []string
+
+				fakeVMProvider.PlaceVirtualMachineGroupFn = func(
+					_ context.Context,
+					group *vmopv1.VirtualMachineGroup,
+					groupPlacements []providers.VMGroupPlacement) error {
+
+					Expect(group.Name).To(Equal(groupName))
+					Expect(group.Status.Members).To(BeEmpty())
+					Expect(groupPlacements).To(HaveLen(1))
+
+					for _, vm := range groupPlacements[0].VMMembers {
+						Expect(vm.Spec.GroupName).To(Equal(groupName))
+						placedVMs = append(placedVMs, vm.Name)
+
+						ms := vmopv1.VirtualMachineGroupMemberStatus{
+							Name: vm.Name,
+							Kind: "VirtualMachine",
+						}
+						if vm.Name == "web-1" {
+							conditions.MarkError(&ms,
+								vmopv1.VirtualMachineGroupMemberConditionPlacementReady,
+								vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason,
+								errors.New("failed to get VM placement prereqs"))
+						} else {
+							ms.Placement = &vmopv1.VirtualMachinePlacementStatus{Zone: "zone-b"}
+							conditions.MarkTrue(&ms, vmopv1.VirtualMachineGroupMemberConditionPlacementReady)
+						}
+						group.Status.Members = append(group.Status.Members, ms)
+					}
+
+					return errors.New("failed to get placement ConfigSpecs")
+				}
+
+				Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+				Expect(placedVMs).To(ConsistOf("member-new", "web-0", "web-1"))
+
+				Expect(placementReq.Status.Results).To(HaveLen(3))
+				Expect(placementReq.Status.Results[0].Name).To(Equal("member-new"))
+				Expect(placementReq.Status.Results[0].Placement).ToNot(BeNil())
+				Expect(placementReq.Status.Results[0].Placement.Zone).To(Equal("zone-b"))
+				Expect(placementReq.Status.Results[1].Name).To(Equal("web-0"))
+				Expect(placementReq.Status.Results[1].Placement).ToNot(BeNil())
+				Expect(placementReq.Status.Results[2].Name).To(Equal("web-1"))
+				Expect(placementReq.Status.Results[2].Placement).To(BeNil())
+				Expect(placementReq.Status.Results[2].Error).To(ContainSubstring("failed to get VM placement prereqs"))
+
+				Expect(conditions.IsFalse(placementReq, vmopv1.VirtualMachinePlacementRequestConditionPlaced)).To(BeTrue())
+
+				// The group itself is never updated.
+				group := &vmopv1.VirtualMachineGroup{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmGroup), group)).To(Succeed())
+				Expect(group.Status.Members).To(HaveLen(2))
+				for _, ms := range group.Status.Members {
+					Expect(ms.Placement).To(BeNil())
+				}
+			})
+
+			It("should report the group placement fault for each VM", func() {
+				fakeVMProvider.PlaceVirtualMachineGroupFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineGroup,
+					_ []providers.VMGroupPlacement) error {
+
+					return errors.New("no placement candidates available")
+				}
+
+				Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+				Expect(placementReq.Status.Results).To(HaveLen(3))
+				for _, r := range placementReq.Status.Results {
+					Expect(r.Error).To(Equal("no placement candidates available"))
+				}
+			})
+
+			When("a request VM has the same name as a group member", func() {
+				BeforeEach(func() {
+					placementReq.Spec.VirtualMachines[0].Name = "member-created"
+					placementReq.Spec.VirtualMachines[0].Replicas = nil
+				})
+
+				It("should report an error for the request VM", func() {
+					Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+					Expect(placementReq.Status.Results).To(HaveLen(2))
+					Expect(placementReq.Status.Results[0].Name).To(Equal("member-created"))
+					Expect(placementReq.Status.Results[0].Error).To(ContainSubstring("already a member of group"))
+				})
+			})
+		})
+
+		When("request is complete", func() {
+			BeforeEach(func() {
+				conditions.MarkTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionComplete)
+				placementReq.Status.CompletionTime = metav1.Now()
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.PlaceVirtualMachinesFn = func(
+					_ context.Context,
+					_ []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+
+					Fail("placement should not be done again")
+					return nil
+				}
+			})
+
+			It("should return nil when ttl is nil", func() {
+				Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+			})
+
+			When("ttl has not expired", func() {
+				BeforeEach(func() {
+					placementReq.Spec.TTLSecondsAfterFinished = ptr.To[int64](3600)
+				})
+
+				It("should requeue", func() {
+					err := reconciler.ReconcileNormal(placementReqCtx)
+					var requeueErr pkgerr.RequeueError
+					Expect(errors.As(err, &requeueErr)).To(BeTrue())
+					Expect(requeueErr.After).To(BeNumerically(">", 59*time.Minute))
+				})
+			})
+
+			When("ttl has expired", func() {
+				BeforeEach(func() {
+					placementReq.Spec.TTLSecondsAfterFinished = ptr.To[int64](0)
+					initObjects = append(initObjects, placementReq)
+				})
+
+				It("should delete the request", func() {
+					Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+					err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(placementReq), &vmopv1.VirtualMachinePlacementRequest{})
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,583 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepowerschedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+)
+
+const (
+	// PowerActionAppliedReason is the reason of the event that is emitted
+	// when a window's power action is applied.
+	PowerActionAppliedReason = "PowerActionApplied"
+
+	// PowerActionFailedReason is the reason of the event that is emitted
+	// when a window's power action cannot be applied to a VM or group.
+	PowerActionFailedReason = "PowerActionFailed"
+
+	// WindowExcludedReason is the reason of the event that is emitted when a
+	// window is not applied because its date is excluded.
+	WindowExcludedReason = "WindowExcluded"
+
+	// WindowMissedReason is the reason of the event that is emitted when a
+	// window is not applied because its starting deadline passed.
+	WindowMissedReason = "WindowMissed"
+
+	// defaultHistoryLimit is the number of history entries kept for each VM
+	// when spec.historyLimit is not set.
+	defaultHistoryLimit = 10
+
+	// maxMissedWindowsAge is how far back the missed windows are searched.
+	maxMissedWindowsAge = 7 * 24 * time.Hour
+
+	// nowSyncTime is the value of a VirtualMachineGroup's
+	// spec.nextForcePowerStateSyncTime that forces the group's power state
+	// to be synced to its members.
+	nowSyncTime = "now"
+
+	dateLayout = "2006-01-02"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachinePowerSchedule{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+			LogConstructor: pkglog.ControllerLogConstructor(
+				controllerNameShort,
+				controlledType,
+				mgr.GetScheme()),
+		}).
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
+}
+
+// Reconciler reconciles a VirtualMachinePowerSchedule object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+
+	// Now returns the current time. It may be replaced in tests.
+	Now func() time.Time
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+		Now:      time.Now,
+	}
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepowerschedules,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepowerschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinegroups,verbs=get;list;watch;patch
+
+// Reconcile reconciles a VirtualMachinePowerSchedule object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	sched := &vmopv1.VirtualMachinePowerSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, sched); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	schedCtx := &pkgctx.VirtualMachinePowerScheduleContext{
+		Context:       ctx,
+		Logger:        pkglog.FromContextOrDefault(ctx),
+		PowerSchedule: sched,
+	}
+
+	patchHelper, err := patch.NewHelper(sched, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", schedCtx, err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, sched); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			schedCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !sched.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	requeueAfter, err := r.ReconcileNormal(schedCtx)
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
+}
+
+// window is a parsed VirtualMachinePowerScheduleWindow.
+type window struct {
+	vmopv1.VirtualMachinePowerScheduleWindow
+	schedule cron.Schedule
+}
+
+// ReconcileNormal applies the window that was most recently scheduled, if it
+// was not already applied, and returns the duration until the next window is
+// scheduled.
+func (r *Reconciler) ReconcileNormal(
+	ctx *pkgctx.VirtualMachinePowerScheduleContext) (time.Duration, error) {
+
+	ctx.Logger.V(4).Info("Reconciling VirtualMachinePowerSchedule")
+	sched := ctx.PowerSchedule
+	sched.Status.ObservedGeneration = sched.Generation
+
+	loc, windows, err := parseSchedule(sched.Spec)
+	if err != nil {
+		sched.Status.NextScheduleTime = nil
+		conditions.MarkFalse(
+			sched,
+			vmopv1.VirtualMachinePowerScheduleConditionReady,
+			vmopv1.VirtualMachinePowerScheduleInvalidReason,
+			"%v", err)
+		return 0, nil
+	}
+
+	now := r.Now().In(loc)
+
+	// The window is not applied if it was scheduled at or before the last
+	// schedule time, or, for a new schedule, before the schedule was
+	// created.
+	since := sched.CreationTimestamp.Time
+	if sched.Status.LastScheduleTime != nil {
+		since = sched.Status.LastScheduleTime.Time
+	}
+	if minSince := now.Add(-maxMissedWindowsAge); since.Before(minSince) {
+		since = minSince
+	}
+
+	if w, t, ok := mostRecentWindow(windows, since.In(loc), now); ok {
+		switch {
+		case sched.Spec.Paused:
+			ctx.Logger.V(4).Info("Skipping window since schedule is paused",
+				"window", w.Name, "scheduleTime", t)
+		case isExcluded(sched.Spec.Exclusions, t):
+			r.Recorder.Eventf(sched, WindowExcludedReason,
+				"Window %q scheduled at %s is excluded", w.Name, t.Format(time.RFC3339))
+		case isMissed(sched.Spec.StartingDeadlineSeconds, t, now):
+			r.Recorder.Warnf(sched, WindowMissedReason,
+				"Window %q scheduled at %s missed its starting deadline", w.Name, t.Format(time.RFC3339))
+		default:
+			if err := r.applyWindow(ctx, w, t); err != nil {
+				return 0, err
+			}
+		}
+		sched.Status.LastScheduleTime = &metav1.Time{Time: t}
+	}
+
+	var next time.Time
+	for _, w := range windows {
+		if t := w.schedule.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	if next.IsZero() {
+		sched.Status.NextScheduleTime = nil
+	} else {
+		sched.Status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+
+	if err := r.updateReadyCondition(ctx); err != nil {
+		return 0, err
+	}
+
+	if next.IsZero() {
+		return 0, nil
+	}
+	return next.Sub(now), nil
+}
+
+func (r *Reconciler) updateReadyCondition(ctx *pkgctx.VirtualMachinePowerScheduleContext) error {
+	sched := ctx.PowerSchedule
+
+	if sched.Spec.Paused {
+		conditions.MarkFalse(
+			sched,
+			vmopv1.VirtualMachinePowerScheduleConditionReady,
+			vmopv1.VirtualMachinePowerSchedulePausedReason,
+			"The schedule is paused")
+		return nil
+	}
+
+	if sched.Spec.GroupName != "" {
+		if err := r.Get(ctx, client.ObjectKey{
+			Namespace: sched.Namespace,
+			Name:      sched.Spec.GroupName,
+		}, &vmopv1.VirtualMachineGroup{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			conditions.MarkFalse(
+				sched,
+				vmopv1.VirtualMachinePowerScheduleConditionReady,
+				vmopv1.VirtualMachinePowerScheduleGroupNotFoundReason,
+				"VirtualMachineGroup %q not found", sched.Spec.GroupName)
+			return nil
+		}
+	}
+
+	conditions.MarkTrue(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)
+	return nil
+}
+
+// parseSchedule returns the location and the parsed windows of the schedule.
+func parseSchedule(
+	spec vmopv1.VirtualMachinePowerScheduleSpec) (*time.Location, []window, error) {
+
+	tz := spec.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone %q: %w", tz, err)
+	}
+
+	windows := make([]window, len(spec.Windows))
+	for i := range spec.Windows {
+		s, err := cron.Parse(spec.Windows[i].Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"invalid schedule for window %q: %w", spec.Windows[i].Name, err)
+		}
+		windows[i] = window{
+			VirtualMachinePowerScheduleWindow: spec.Windows[i],
+			schedule:                          s,
+		}
+	}
+
+	return loc, windows, nil
+}
+
+// mostRecentWindow returns the window that was most recently scheduled after
+// since and at or before now. When more than one window is scheduled at the
+// same time, the last of them in the schedule's list of windows is returned.
+func mostRecentWindow(
+	windows []window,
+	since, now time.Time) (window, time.Time, bool) {
+
+	var (
+		latestWindow window
+		latestTime   time.Time
+	)
+
+	for _, w := range windows {
+		var last time.Time
+		for t := w.schedule.Next(since); !t.IsZero() && !t.After(now); t = w.schedule.Next(t) {
+			last = t
+		}
+		if !last.IsZero() && !last.Before(latestTime) {
+			latestWindow, latestTime = w, last
+		}
+	}
+
+	return latestWindow, latestTime, !latestTime.IsZero()
+}
+
+// isExcluded returns true if the date of t is in one of the exclusions. The
+// exclusions' dates are in t's location.
+func isExcluded(
+	exclusions []vmopv1.VirtualMachinePowerScheduleExclusion,
+	t time.Time) bool {
+
+	date := t.Format(dateLayout)
+	for _, e := range exclusions {
+		end := e.EndDate
+		if end == "" {
+			end = e.Date
+		}
+		// The dates are compared as strings since they have the same,
+		// zero-padded layout.
+		if date >= e.Date && date <= end {
+			return true
+		}
+	}
+	return false
+}
+
+func isMissed(startingDeadlineSeconds *int64, t, now time.Time) bool {
+	if startingDeadlineSeconds == nil {
+		return false
+	}
+	return now.Sub(t) > time.Duration(*startingDeadlineSeconds)*time.Second
+}
+
+// applyWindow applies the window's power action to the schedule's target and
+// records it in the history of the targeted VMs.
+func (r *Reconciler) applyWindow(
+	ctx *pkgctx.VirtualMachinePowerScheduleContext,
+	w window,
+	t time.Time) error {
+
+	sched := ctx.PowerSchedule
+	entry := vmopv1.VirtualMachinePowerScheduleHistoryEntry{
+		Window:       w.Name,
+		Action:       w.Action,
+		ScheduleTime: metav1.Time{Time: t},
+	}
+
+	var (
+		results map[string]error
+		err     error
+	)
+	if sched.Spec.GroupName != "" {
+		results, err = r.applyToGroup(ctx, w)
+	} else {
+		results, err = r.applyToVMs(ctx, w)
+	}
+	if err != nil {
+		return err
+	}
+	if results == nil {
+		// The target does not exist.
+		return nil
+	}
+
+	var failed int
+	history := make(map[string][]vmopv1.VirtualMachinePowerScheduleHistoryEntry, len(results))
+	for _, s := range sched.Status.VMs {
+		if _, ok := results[s.Name]; ok {
+			history[s.Name] = s.History
+		}
+	}
+
+	limit := int(sched.Spec.HistoryLimit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	vms := make([]vmopv1.VirtualMachinePowerScheduleVMStatus, 0, len(results))
+	for name, result := range results {
+		e := entry
+		if result != nil {
+			e.Error = result.Error()
+			failed++
+		}
+		h := append(history[name], e)
+		if len(h) > limit {
+			h = h[len(h)-limit:]
+		}
+		vms = append(vms, vmopv1.VirtualMachinePowerScheduleVMStatus{
+			Name:    name,
+			History: h,
+		})
+	}
+	slices.SortFunc(vms, func(a, b vmopv1.VirtualMachinePowerScheduleVMStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	sched.Status.VMs = vms
+
+	if failed > 0 {
+		r.Recorder.Warnf(sched, PowerActionFailedReason,
+			"Failed to apply %s from window %q to %d of %d VMs",
+			w.Action, w.Name, failed, len(results))
+	} else {
+		r.Recorder.Eventf(sched, PowerActionAppliedReason,
+			"Applied %s from window %q to %d VMs",
+			w.Action, w.Name, len(results))
+	}
+
+	return nil
+}
+
+// applyToVMs sets the power state of the VMs selected by the schedule, and
+// returns the result for each VM.
+func (r *Reconciler) applyToVMs(
+	ctx *pkgctx.VirtualMachinePowerScheduleContext,
+	w window) (map[string]error, error) {
+
+	sched := ctx.PowerSchedule
+
+	selector, err := metav1.LabelSelectorAsSelector(sched.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var list vmopv1.VirtualMachineList
+	if err := r.List(
+		ctx,
+		&list,
+		client.InNamespace(sched.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+
+		return nil, fmt.Errorf("failed to list VirtualMachines: %w", err)
+	}
+
+	results := make(map[string]error, len(list.Items))
+	for i := range list.Items {
+		vm := &list.Items[i]
+		if !vm.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		vmPatch := client.MergeFrom(vm.DeepCopy())
+		vm.Spec.PowerState = w.Action.PowerState()
+		if w.PowerOpMode != "" {
+			switch w.Action {
+			case vmopv1.VirtualMachinePowerScheduleActionPowerOff:
+				vm.Spec.PowerOffMode = w.PowerOpMode
+			case vmopv1.VirtualMachinePowerScheduleActionSuspend:
+				vm.Spec.SuspendMode = w.PowerOpMode
+			}
+		}
+
+		results[vm.Name] = r.Patch(ctx, vm, vmPatch)
+		if results[vm.Name] != nil {
+			ctx.Logger.Error(results[vm.Name], "Failed to apply power action to VM",
+				"vm", vm.Name, "action", w.Action)
+		}
+	}
+
+	return results, nil
+}
+
+// applyToGroup sets the power state of the schedule's VirtualMachineGroup, and
+// returns the result for each VM in the group. The group controller powers on
+// the group's members in the group's boot order.
+func (r *Reconciler) applyToGroup(
+	ctx *pkgctx.VirtualMachinePowerScheduleContext,
+	w window) (map[string]error, error) {
+
+	sched := ctx.PowerSchedule
+
+	group := &vmopv1.VirtualMachineGroup{}
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: sched.Namespace,
+		Name:      sched.Spec.GroupName,
+	}, group); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Warnf(sched, PowerActionFailedReason,
+				"Failed to apply %s from window %q: VirtualMachineGroup %q not found",
+				w.Action, w.Name, sched.Spec.GroupName)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	groupPatch := client.MergeFrom(group.DeepCopy())
+	group.Spec.PowerState = w.Action.PowerState()
+	// Force the power state to be synced to the group's members even if the
+	// group's power state did not change, ex. when a member was powered on
+	// by a user after the group was powered off.
+	group.Spec.NextForcePowerStateSyncTime = nowSyncTime
+	if w.PowerOpMode != "" {
+		switch w.Action {
+		case vmopv1.VirtualMachinePowerScheduleActionPowerOff:
+			group.Spec.PowerOffMode = w.PowerOpMode
+		case vmopv1.VirtualMachinePowerScheduleActionSuspend:
+			group.Spec.SuspendMode = w.PowerOpMode
+		}
+	}
+	patchErr := r.Patch(ctx, group, groupPatch)
+	if patchErr != nil {
+		ctx.Logger.Error(patchErr, "Failed to apply power action to group",
+			"group", group.Name, "action", w.Action)
+	}
+
+	vmNames, err := r.groupVMNames(ctx, group, map[string]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]error, len(vmNames))
+	for _, name := range vmNames {
+		results[name] = patchErr
+	}
+
+	return results, nil
+}
+
+// groupVMNames returns the names of the VMs in the group and the groups
+// nested in it.
+func (r *Reconciler) groupVMNames(
+	ctx *pkgctx.VirtualMachinePowerScheduleContext,
+	group *vmopv1.VirtualMachineGroup,
+	visited map[string]struct{}) ([]string, error) {
+
+	if _, ok := visited[group.Name]; ok {
+		return nil, nil
+	}
+	visited[group.Name] = struct{}{}
+
+	var names []string
+	for _, bo := range group.Spec.BootOrder {
+		for _, m := range bo.Members {
+			if m.Kind != "VirtualMachineGroup" {
+				names = append(names, m.Name)
+				continue
+			}
+
+			nested := &vmopv1.VirtualMachineGroup{}
+			if err := r.Get(ctx, client.ObjectKey{
+				Namespace: group.Namespace,
+				Name:      m.Name,
+			}, nested); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			nestedNames, err := r.groupVMNames(ctx, nested, visited)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, nestedNames...)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,76 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepowerschedule_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx   *builder.IntegrationTestContext
+		sched *vmopv1.VirtualMachinePowerSchedule
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		sched = &vmopv1.VirtualMachinePowerSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "overnight",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachinePowerScheduleSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"env": "dev"},
+				},
+				Windows: []vmopv1.VirtualMachinePowerScheduleWindow{
+					{
+						Name:     "evening",
+						Schedule: "0 19 * * *",
+						Action:   vmopv1.VirtualMachinePowerScheduleActionPowerOff,
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("reports the next schedule time", func() {
+		Expect(ctx.Client.Create(ctx, sched)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachinePowerSchedule{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(sched), obj)).To(Succeed())
+			g.Expect(obj.Status.NextScheduleTime).ToNot(BeNil())
+			g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachinePowerScheduleConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+	})
+}
@@ -0,0 +1,400 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepowerschedule_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepowerschedule"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *virtualmachinepowerschedule.Reconciler
+		sched       *vmopv1.VirtualMachinePowerSchedule
+		schedCtx    *pkgctx.VirtualMachinePowerScheduleContext
+		now         time.Time
+		vm1, vm2    *vmopv1.VirtualMachine
+	)
+
+	newVM := func(name string, labels map[string]string) *vmopv1.VirtualMachine {
+		vm := builder.DummyBasicVirtualMachine(name, builder.DummyNamespaceName)
+		vm.Labels = labels
+		vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+		return vm
+	}
+
+	getVM := func(name string) *vmopv1.VirtualMachine {
+		vm := &vmopv1.VirtualMachine{}
+		Expect(ctx.Client.Get(ctx, client.ObjectKey{
+			Namespace: builder.DummyNamespaceName,
+			Name:      name,
+		}, vm)).To(Succeed())
+		return vm
+	}
+
+	BeforeEach(func() {
+		// 2026-01-05 is a Monday.
+		now = time.Date(2026, 1, 5, 19, 0, 30, 0, time.UTC)
+
+		vm1 = newVM("vm-1", map[string]string{"env": "dev"})
+		vm2 = newVM("vm-2", map[string]string{"env": "prod"})
+
+		sched = &vmopv1.VirtualMachinePowerSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "overnight",
+				Namespace:         builder.DummyNamespaceName,
+				Generation:        2,
+				CreationTimestamp: metav1.NewTime(now.Add(-24 * time.Hour)),
+			},
+			Spec: vmopv1.VirtualMachinePowerScheduleSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"env": "dev"},
+				},
+				Windows: []vmopv1.VirtualMachinePowerScheduleWindow{
+					{
+						Name:        "evening",
+						Schedule:    "0 19 * * MON-FRI",
+						Action:      vmopv1.VirtualMachinePowerScheduleActionPowerOff,
+						PowerOpMode: vmopv1.VirtualMachinePowerOpModeTrySoft,
+					},
+					{
+						Name:     "morning",
+						Schedule: "0 7 * * MON-FRI",
+						Action:   vmopv1.VirtualMachinePowerScheduleActionPowerOn,
+					},
+				},
+			},
+		}
+		sched.Status.LastScheduleTime = &metav1.Time{Time: time.Date(2026, 1, 5, 7, 0, 0, 0, time.UTC)}
+
+		initObjects = []client.Object{vm1, vm2}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinepowerschedule.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		reconciler.Now = func() time.Time { return now }
+		schedCtx = &pkgctx.VirtualMachinePowerScheduleContext{
+			Context:       ctx,
+			Logger:        ctx.Logger.WithName(sched.Name),
+			PowerSchedule: sched,
+		}
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		sched = nil
+		schedCtx = nil
+		vm1 = nil
+		vm2 = nil
+	})
+
+	Context("ReconcileNormal", func() {
+		var (
+			requeueAfter time.Duration
+			err          error
+		)
+
+		JustBeforeEach(func() {
+			requeueAfter, err = reconciler.ReconcileNormal(schedCtx)
+		})
+
+		It("applies the most recent window to the selected VMs", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			vm := getVM(vm1.Name)
+			Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+			Expect(vm.Spec.PowerOffMode).To(Equal(vmopv1.VirtualMachinePowerOpModeTrySoft))
+			Expect(getVM(vm2.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+			scheduleTime := time.Date(2026, 1, 5, 19, 0, 0, 0, time.UTC)
+			Expect(sched.Status.LastScheduleTime.Time).To(Equal(scheduleTime))
+			Expect(sched.Status.NextScheduleTime.Time).To(Equal(time.Date(2026, 1, 6, 7, 0, 0, 0, time.UTC)))
+			Expect(requeueAfter).To(Equal(sched.Status.NextScheduleTime.Sub(now)))
+			Expect(sched.Status.ObservedGeneration).To(Equal(int64(2)))
+
+			Expect(sched.Status.VMs).To(Equal([]vmopv1.VirtualMachinePowerScheduleVMStatus{
+				{
+					Name: vm1.Name,
+					History: []vmopv1.VirtualMachinePowerScheduleHistoryEntry{
+						{
+							Window:       "evening",
+							Action:       vmopv1.VirtualMachinePowerScheduleActionPowerOff,
+							ScheduleTime: metav1.Time{Time: scheduleTime},
+						},
+					},
+				},
+			}))
+			Expect(conditions.IsTrue(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)).To(BeTrue())
+			Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachinepowerschedule.PowerActionAppliedReason)))
+		})
+
+		When("the window was already applied", func() {
+			BeforeEach(func() {
+				sched.Status.LastScheduleTime = &metav1.Time{Time: time.Date(2026, 1, 5, 19, 0, 0, 0, time.UTC)}
+			})
+
+			It("does not apply the window again", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				Expect(sched.Status.VMs).To(BeEmpty())
+			})
+		})
+
+		When("the history limit is reached", func() {
+			BeforeEach(func() {
+				sched.Spec.HistoryLimit = 2
+				sched.Status.VMs = []vmopv1.VirtualMachinePowerScheduleVMStatus{
+					{
+						Name: vm1.Name,
+						History: []vmopv1.VirtualMachinePowerScheduleHistoryEntry{
+							{Window: "evening", Action: vmopv1.VirtualMachinePowerScheduleActionPowerOff},
+							{Window: "morning", Action: vmopv1.VirtualMachinePowerScheduleActionPowerOn},
+						},
+					},
+					{
+						Name: "deleted-vm",
+					},
+				}
+			})
+
+			It("keeps the most recent entries of the targeted VMs", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sched.Status.VMs).To(HaveLen(1))
+				h := sched.Status.VMs[0].History
+				Expect(h).To(HaveLen(2))
+				Expect(h[0].Window).To(Equal("morning"))
+				Expect(h[1].Window).To(Equal("evening"))
+				Expect(h[1].ScheduleTime.Time).To(Equal(time.Date(2026, 1, 5, 19, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		When("the date is excluded", func() {
+			BeforeEach(func() {
+				sched.Spec.Exclusions = []vmopv1.VirtualMachinePowerScheduleExclusion{
+					{
+						Name:    "holidays",
+						Date:    "2026-01-01",
+						EndDate: "2026-01-05",
+					},
+				}
+			})
+
+			It("does not apply the window", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				Expect(sched.Status.LastScheduleTime.Time).To(Equal(time.Date(2026, 1, 5, 19, 0, 0, 0, time.UTC)))
+				Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachinepowerschedule.WindowExcludedReason)))
+			})
+		})
+
+		When("the date is excluded in the schedule's time zone", func() {
+			BeforeEach(func() {
+				// 2026-01-06 04:00 in Tokyo is 2026-01-05 19:00 in UTC.
+				sched.Spec.TimeZone = "Asia/Tokyo"
+				sched.Spec.Windows[0].Schedule = "0 4 * * *"
+				sched.Spec.Exclusions = []vmopv1.VirtualMachinePowerScheduleExclusion{
+					{
+						Name: "holiday",
+						Date: "2026-01-06",
+					},
+				}
+			})
+
+			It("does not apply the window", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachinepowerschedule.WindowExcludedReason)))
+			})
+		})
+
+		When("the window's starting deadline passed", func() {
+			BeforeEach(func() {
+				sched.Spec.StartingDeadlineSeconds = ptr.To(int64(10))
+			})
+
+			It("does not apply the window", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				Expect(ctx.Events).To(Receive(ContainSubstring(virtualmachinepowerschedule.WindowMissedReason)))
+			})
+		})
+
+		When("the schedule is paused", func() {
+			BeforeEach(func() {
+				sched.Spec.Paused = true
+			})
+
+			It("does not apply the window", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				Expect(sched.Status.LastScheduleTime.Time).To(Equal(time.Date(2026, 1, 5, 19, 0, 0, 0, time.UTC)))
+
+				c := conditions.Get(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachinePowerSchedulePausedReason))
+			})
+		})
+
+		When("a window's schedule is invalid", func() {
+			BeforeEach(func() {
+				sched.Spec.Windows[1].Schedule = "0 7 * *"
+			})
+
+			It("reports the schedule is invalid", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+				c := conditions.Get(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachinePowerScheduleInvalidReason))
+				Expect(c.Message).To(ContainSubstring(`invalid schedule for window "morning"`))
+			})
+		})
+
+		When("the schedule targets a group", func() {
+			var (
+				group *vmopv1.VirtualMachineGroup
+			)
+
+			BeforeEach(func() {
+				nested := &vmopv1.VirtualMachineGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "nested",
+						Namespace: builder.DummyNamespaceName,
+					},
+					Spec: vmopv1.VirtualMachineGroupSpec{
+						BootOrder: []vmopv1.VirtualMachineGroupBootOrderGroup{
+							{
+								Members: []vmopv1.GroupMember{
+									{Name: vm2.Name, Kind: "VirtualMachine"},
+								},
+							},
+						},
+					},
+				}
+				group = &vmopv1.VirtualMachineGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app",
+						Namespace: builder.DummyNamespaceName,
+					},
+					Spec: vmopv1.VirtualMachineGroupSpec{
+						PowerState: vmopv1.VirtualMachinePowerStateOn,
+						BootOrder: []vmopv1.VirtualMachineGroupBootOrderGroup{
+							{
+								Members: []vmopv1.GroupMember{
+									{Name: vm1.Name, Kind: "VirtualMachine"},
+								},
+							},
+							{
+								Members: []vmopv1.GroupMember{
+									{Name: nested.Name, Kind: "VirtualMachineGroup"},
+								},
+							},
+						},
+					},
+				}
+				initObjects = append(initObjects, group, nested)
+
+				sched.Spec.Selector = nil
+				sched.Spec.GroupName = group.Name
+			})
+
+			It("sets the group's power state", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				obj := &vmopv1.VirtualMachineGroup{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(group), obj)).To(Succeed())
+				Expect(obj.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+				Expect(obj.Spec.PowerOffMode).To(Equal(vmopv1.VirtualMachinePowerOpModeTrySoft))
+				Expect(obj.Spec.NextForcePowerStateSyncTime).To(Equal("now"))
+
+				// The group controller sets the members' power states.
+				Expect(getVM(vm1.Name).Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+				Expect(sched.Status.VMs).To(HaveLen(2))
+				Expect(sched.Status.VMs[0].Name).To(Equal(vm1.Name))
+				Expect(sched.Status.VMs[1].Name).To(Equal(vm2.Name))
+				Expect(conditions.IsTrue(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)).To(BeTrue())
+			})
+
+			When("the group does not exist", func() {
+				BeforeEach(func() {
+					sched.Spec.GroupName = "missing"
+				})
+
+				It("reports the group is not found", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sched.Status.VMs).To(BeEmpty())
+
+					c := conditions.Get(sched, vmopv1.VirtualMachinePowerScheduleConditionReady)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachinePowerScheduleGroupNotFoundReason))
+				})
+			})
+		})
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, sched)
+		})
+
+		It("updates the schedule's status", func() {
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: sched.Namespace,
+					Name:      sched.Name,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).ToNot(BeZero())
+
+			obj := &vmopv1.VirtualMachinePowerSchedule{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(sched), obj)).To(Succeed())
+			Expect(obj.Status.LastScheduleTime).ToNot(BeNil())
+			Expect(obj.Status.VMs).To(HaveLen(1))
+			Expect(conditions.IsTrue(obj, vmopv1.VirtualMachinePowerScheduleConditionReady)).To(BeTrue())
+		})
+	})
+}
@@ -0,0 +1,312 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+	"github.com/vmware-tanzu/vm-operator/pkg/imagepublish"
+)
+
+// artifactRequeueAfter is how often a request is reconciled while its VM is
+// exported and uploaded to an OCI or HTTP target.
+const artifactRequeueAfter = 10 * time.Second
+
+// artifactExport is a VM that is being exported and uploaded to an OCI or HTTP
+// target in the background.
+type artifactExport struct {
+	// result may only be read once the export is done.
+	result imagepublish.Result
+}
+
+// isArtifactTarget returns true if the request publishes the VM to an OCI or
+// HTTP target instead of a content library.
+func isArtifactTarget(vmPub *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPub.Spec.Target.OCI != nil || vmPub.Spec.Target.HTTP != nil
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// reconcileArtifact exports the source VM and uploads it to the request's OCI
+// or HTTP target. The export runs in the background, and the request is
+// requeued until it is done.
+func (r *Reconciler) reconcileArtifact(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (ctrl.Result, error) {
+
+	vmPub := ctx.VMPublishRequest
+	if conditions.IsTrue(vmPub, vmopv1.VirtualMachinePublishRequestConditionUploaded) {
+		return ctrl.Result{}, nil
+	}
+
+	key := client.ObjectKeyFromObject(vmPub)
+	uploading := conditions.GetReason(vmPub, vmopv1.VirtualMachinePublishRequestConditionUploaded) ==
+		vmopv1.UploadingReason
+
+	if r.exports.IsLost(key, uploading) {
+		// The export was lost, ex. because the pod restarted, so it is
+		// attempted again.
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			errors.New("the export was interrupted"))
+	}
+
+	op := r.exports.Get(key)
+	if op == nil {
+		return r.startExport(ctx)
+	}
+	if !op.IsDone() {
+		return ctrl.Result{RequeueAfter: artifactRequeueAfter}, nil
+	}
+
+	r.exports.Forget(key)
+
+	if err := op.Err(); err != nil {
+		ctx.Logger.Error(err, "Failed to publish VM as artifact")
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			err)
+		r.Recorder.EmitEvent(vmPub, "Publish", err, false)
+		return ctrl.Result{RequeueAfter: artifactRequeueAfter}, nil
+	}
+
+	e := op.Value()
+
+	vmPub.Status.Artifact = &vmopv1.VirtualMachinePublishRequestArtifactStatus{
+		Reference: e.result.Reference,
+		URL:       e.result.URL,
+		Digest:    e.result.Digest,
+		Size:      e.result.Size,
+	}
+	conditions.MarkTrue(vmPub, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+	r.Recorder.EmitEvent(vmPub, "Publish", nil, false)
+	ctx.Logger.Info("Published VM as artifact", "digest", e.result.Digest)
+
+	return ctrl.Result{}, nil
+}
+
+// startExport validates the source and target of the request, and starts
+// exporting and uploading the VM in the background.
+func (r *Reconciler) startExport(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (ctrl.Result, error) {
+
+	vmPub := ctx.VMPublishRequest
+
+	if err := r.checkIsSourceValid(ctx); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check if source is valid: %w", err)
+	}
+	if err := r.checkIsSourceExportable(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	opts, url, err := r.checkIsArtifactTargetValid(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check if target is valid: %w", err)
+	}
+
+	if vmPub.Spec.BackoffLimit != 0 && vmPub.Status.Attempts >= vmPub.Spec.BackoffLimit {
+		err := pkgerr.NoRequeueError{
+			Message: fmt.Sprintf("publish attempts limit has been reached: %d", vmPub.Status.Attempts),
+		}
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionComplete,
+			vmopv1.FatalReason,
+			err)
+		return ctrl.Result{}, err
+	}
+
+	// The exported files are staged on a volume since the upload to a
+	// registry or URL requires the size and digest of each file. The pod's
+	// ephemeral storage is not used as it is too small for the disks of most
+	// VMs.
+	stagingDir := pkgcfg.FromContext(ctx).PublishStagingDir
+	if stagingDir == "" {
+		err := pkgerr.NoRequeueError{
+			Message: "no staging volume is configured for exporting VMs",
+		}
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			err)
+		return ctrl.Result{}, err
+	}
+	release, err := r.staging.reserve(stagingDir, getExportSize(ctx.VM))
+	if err != nil {
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			err)
+		return ctrl.Result{}, pkgerr.RequeueError{After: artifactRequeueAfter, Message: err.Error()}
+	}
+
+	vmPub.Status.Attempts++
+	vmPub.Status.LastAttemptTime = metav1.Now()
+	conditions.MarkFalse(vmPub,
+		vmopv1.VirtualMachinePublishRequestConditionUploaded,
+		vmopv1.UploadingReason,
+		"exporting and uploading the VM")
+
+	// Update the status instead of patching it so the export is not started
+	// more than once for the same attempt. Please see publishVirtualMachine
+	// for more information.
+	ctx.SkipPatch = true
+	if err := r.Client.Status().Update(ctx, vmPub); err != nil {
+		release()
+		ctx.Logger.Error(err, "update VirtualMachinePublishRequest status failed")
+		return ctrl.Result{}, err
+	}
+
+	var (
+		vm     = ctx.VM.DeepCopy()
+		target = *vmPub.Spec.Target.DeepCopy()
+		name   = vmPub.Status.TargetRef.Item.Name
+		logger = ctx.Logger
+	)
+
+	// The export outlives this reconcile, so its context is only canceled
+	// when the request is deleted.
+	r.exports.Start(ctx, client.ObjectKeyFromObject(vmPub), &artifactExport{},
+		func(exportCtx context.Context, e *artifactExport) error {
+			defer release()
+
+			dir, err := os.MkdirTemp(stagingDir, "vmpub-")
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := os.RemoveAll(dir); err != nil {
+					logger.Error(err, "Failed to remove exported files", "dir", dir)
+				}
+			}()
+
+			paths, err := r.VMProvider.ExportVirtualMachine(exportCtx, vm, name, dir)
+			if err != nil {
+				return fmt.Errorf("failed to export VM: %w", err)
+			}
+
+			switch {
+			case target.OCI != nil:
+				e.result, err = imagepublish.PushOCI(exportCtx, *target.OCI, paths, opts)
+			case target.HTTP != nil:
+				e.result, err = imagepublish.UploadHTTP(exportCtx, url, paths, opts)
+			}
+			return err
+		})
+
+	ctx.Logger.Info("Started exporting VM", "name", name, "attempts", vmPub.Status.Attempts)
+
+	return ctrl.Result{RequeueAfter: artifactRequeueAfter}, nil
+}
+
+// checkIsSourceExportable checks that the source VM may be exported with an
+// export lease, which requires the VM to be powered off and not encrypted.
+func (r *Reconciler) checkIsSourceExportable(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
+	vmPub := ctx.VMPublishRequest
+
+	if ctx.VM.Status.Crypto != nil && len(ctx.VM.Status.Crypto.Encrypted) > 0 {
+		err := pkgerr.NoRequeueError{
+			Message: "source VM is encrypted and cannot be exported",
+		}
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+			vmopv1.SourceVirtualMachineUnsupportedEncryptionReason,
+			err)
+		return err
+	}
+
+	if ctx.VM.Status.PowerState != vmopv1.VirtualMachinePowerStateOff {
+		err := errors.New("source VM must be powered off to be exported")
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotPoweredOffReason,
+			err)
+		return pkgerr.RequeueError{After: artifactRequeueAfter, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// checkIsArtifactTargetValid returns the options used to upload to the
+// request's OCI or HTTP target, and the URL of an HTTP target, from the
+// Secrets referenced by the target.
+func (r *Reconciler) checkIsArtifactTargetValid(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (imagepublish.Options, string, error) {
+
+	var (
+		vmPub  = ctx.VMPublishRequest
+		target = vmPub.Spec.Target
+		opts   imagepublish.Options
+		url    string
+	)
+
+	markInvalid := func(err error) error {
+		conditions.MarkError(vmPub,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetSecretInvalidReason,
+			err)
+		return err
+	}
+
+	switch {
+	case target.OCI != nil:
+		if target.OCI.PushSecretName != "" {
+			secret, err := r.getTargetSecret(ctx, target.OCI.PushSecretName)
+			if err != nil {
+				return opts, "", markInvalid(err)
+			}
+			auth, err := imageimport.AuthFromDockerConfigJSON(
+				secret.Data[corev1.DockerConfigJsonKey], target.OCI.Reference)
+			if err != nil {
+				return opts, "", markInvalid(err)
+			}
+			opts.Auth = auth
+		}
+	case target.HTTP != nil:
+		secret, err := r.getTargetSecret(ctx, target.HTTP.URLSecretName)
+		if err != nil {
+			return opts, "", markInvalid(err)
+		}
+		url = string(secret.Data[vmopv1.VirtualMachinePublishRequestHTTPTargetURLSecretKey])
+		if url == "" {
+			return opts, "", markInvalid(fmt.Errorf("secret %s does not have the key %q",
+				target.HTTP.URLSecretName, vmopv1.VirtualMachinePublishRequestHTTPTargetURLSecretKey))
+		}
+	}
+
+	conditions.MarkTrue(vmPub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+	return opts, url, nil
+}
+
+func (r *Reconciler) getTargetSecret(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	name string) (*corev1.Secret, error) {
+
+	secret := &corev1.Secret{}
+	objKey := client.ObjectKey{Namespace: ctx.VMPublishRequest.Namespace, Name: name}
+	if err := r.Get(ctx, objKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("secret %s does not exist", name)
+		}
+		return nil, fmt.Errorf("failed to get secret %v: %w", objKey, err)
+	}
+	return secret, nil
+}
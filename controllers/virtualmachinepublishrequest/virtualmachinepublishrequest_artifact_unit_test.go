@@ -0,0 +1,385 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+// fakeExport writes the files of a VM that is exported by the fake provider.
+// The exports of a spec may outlive it, so each spec has its own fakeExport.
+type fakeExport struct {
+	mu   sync.Mutex
+	err  error
+	name string
+}
+
+func (e *fakeExport) getName() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.name
+}
+
+func (e *fakeExport) exportVirtualMachine(
+	_ context.Context,
+	_ *vmopv1.VirtualMachine,
+	name, dir string) ([]string, error) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.name = name
+	if e.err != nil {
+		return nil, e.err
+	}
+	var paths []string
+	for _, f := range []string{name + ".ovf", name + "-disk-0.vmdk"} {
+		p := filepath.Join(dir, f)
+		if err := os.WriteFile(p, []byte(f), 0600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func unitTestsReconcileArtifact() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachinepublishrequest.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vm       *vmopv1.VirtualMachine
+		vmpub    *vmopv1.VirtualMachinePublishRequest
+		vmpubCtx *pkgctx.VirtualMachinePublishRequestContext
+
+		registryServer *httptest.Server
+		httpServer     *httptest.Server
+		uploaded       *atomic.Int64
+		export         *fakeExport
+		stagingDir     string
+	)
+
+	BeforeEach(func() {
+		registryServer = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		uploaded = &atomic.Int64{}
+		uploaded := uploaded
+		httpServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Query().Get("signature") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			n, _ := io.Copy(io.Discard, r.Body)
+			uploaded.Store(n)
+		}))
+		export = &fakeExport{}
+		stagingDir = GinkgoT().TempDir()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				UniqueID:   "dummy-id",
+				PowerState: vmopv1.VirtualMachinePowerStateOff,
+			},
+		}
+
+		vmpub = builder.DummyVirtualMachinePublishRequest("dummy-vmpub", vm.Namespace, vm.Name, "dummy-item", "")
+		vmpub.Spec.Target.OCI = &vmopv1.VirtualMachinePublishRequestOCITarget{
+			Reference: strings.TrimPrefix(registryServer.URL, "http://") + "/images/dummy-vm:v1",
+			Insecure:  true,
+		}
+
+		initObjects = append(initObjects, vm, vmpub)
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.PublishStagingDir = stagingDir
+		})
+		reconciler = virtualmachinepublishrequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+		fakeVMProvider.ExportVirtualMachineFn = export.exportVirtualMachine
+
+		vmpubCtx = &pkgctx.VirtualMachinePublishRequestContext{
+			Context:          ctx,
+			Logger:           ctx.Logger.WithName(vmpub.Name),
+			VMPublishRequest: vmpub,
+			VM:               vm,
+		}
+	})
+
+	AfterEach(func() {
+		registryServer.Close()
+		httpServer.Close()
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	reconcileUntilUploaded := func() {
+		GinkgoHelper()
+		Eventually(func(g Gomega) {
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).ToNot(BeNil())
+			g.Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				ToNot(Equal(vmopv1.UploadingReason))
+		}).Should(Succeed())
+	}
+
+	When("the target is an OCI registry", func() {
+		It("exports the VM and pushes it to the registry", func() {
+			reconcileUntilUploaded()
+
+			Expect(export.getName()).To(Equal("dummy-item"))
+			Expect(vmpub.Status.Attempts).To(Equal(int64(1)))
+			Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionSourceValid)).To(BeTrue())
+			Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(BeTrue())
+			Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+			Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+			Expect(conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionImageAvailable)).To(BeNil())
+			Expect(vmpub.Status.Ready).To(BeTrue())
+			Expect(vmpub.Status.TargetRef.OCI).To(Equal(vmpub.Spec.Target.OCI))
+
+			Expect(vmpub.Status.Artifact).ToNot(BeNil())
+			Expect(vmpub.Status.Artifact.Digest).To(HavePrefix("sha256:"))
+			Expect(vmpub.Status.Artifact.Reference).To(Equal(
+				strings.TrimPrefix(registryServer.URL, "http://") + "/images/dummy-vm@" + vmpub.Status.Artifact.Digest))
+		})
+
+		When("the push secret does not exist", func() {
+			BeforeEach(func() {
+				vmpub.Spec.Target.OCI.PushSecretName = "my-secret"
+			})
+
+			It("marks the target as invalid", func() {
+				_, err := reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).To(MatchError(ContainSubstring("secret my-secret does not exist")))
+				Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)).
+					To(Equal(vmopv1.TargetSecretInvalidReason))
+				Expect(vmpub.Status.Attempts).To(BeZero())
+			})
+		})
+
+		When("the push secret exists", func() {
+			BeforeEach(func() {
+				vmpub.Spec.Target.OCI.PushSecretName = "my-secret"
+				initObjects = append(initObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vm.Namespace,
+						Name:      "my-secret",
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+					},
+				})
+			})
+
+			It("pushes the artifact", func() {
+				reconcileUntilUploaded()
+				Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+			})
+		})
+	})
+
+	When("the target is an HTTP URL", func() {
+		BeforeEach(func() {
+			vmpub.Spec.Target.OCI = nil
+			vmpub.Spec.Target.HTTP = &vmopv1.VirtualMachinePublishRequestHTTPTarget{
+				URLSecretName: "my-secret",
+			}
+		})
+
+		When("the URL secret exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vm.Namespace,
+						Name:      "my-secret",
+					},
+					Data: map[string][]byte{
+						"url": []byte(httpServer.URL + "/images/dummy-vm.ova?signature=secret"),
+					},
+				})
+			})
+
+			It("exports the VM and uploads it as an OVA", func() {
+				reconcileUntilUploaded()
+
+				Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+				Expect(vmpub.Status.Artifact).ToNot(BeNil())
+				Expect(vmpub.Status.Artifact.URL).To(Equal(httpServer.URL + "/images/dummy-vm.ova"))
+				Expect(vmpub.Status.Artifact.Digest).To(HavePrefix("sha256:"))
+				Expect(vmpub.Status.Artifact.Size).To(Equal(uploaded.Load()))
+			})
+		})
+
+		When("the URL secret does not have a url", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vm.Namespace,
+						Name:      "my-secret",
+					},
+				})
+			})
+
+			It("marks the target as invalid", func() {
+				_, err := reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).To(MatchError(ContainSubstring(`does not have the key "url"`)))
+				Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)).
+					To(Equal(vmopv1.TargetSecretInvalidReason))
+			})
+		})
+	})
+
+	When("the source VM is powered on", func() {
+		BeforeEach(func() {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+		})
+
+		It("marks the source as invalid", func() {
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionSourceValid)).
+				To(Equal(vmopv1.SourceVirtualMachineNotPoweredOffReason))
+			Expect(vmpub.Status.Attempts).To(BeZero())
+		})
+	})
+
+	When("no staging volume is configured", func() {
+		BeforeEach(func() {
+			stagingDir = ""
+		})
+
+		It("marks the upload as failed", func() {
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).To(MatchError(ContainSubstring("no staging volume is configured")))
+			Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				To(Equal(vmopv1.UploadFailureReason))
+			Expect(vmpub.Status.Attempts).To(BeZero())
+			Expect(export.getName()).To(BeEmpty())
+		})
+	})
+
+	When("the staging volume does not have enough free space", func() {
+		BeforeEach(func() {
+			vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+				{
+					Name: "disk-0",
+					Used: resource.NewQuantity(1<<62, resource.BinarySI),
+				},
+			}
+		})
+
+		It("does not start the export", func() {
+			result, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).ToNot(BeZero())
+			Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				To(Equal(vmopv1.UploadFailureReason))
+			Expect(conditions.GetMessage(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				To(ContainSubstring("staging volume does not have enough free space"))
+			Expect(vmpub.Status.Attempts).To(BeZero())
+			Expect(export.getName()).To(BeEmpty())
+		})
+	})
+
+	When("the export fails", func() {
+		BeforeEach(func() {
+			export.err = errors.New("fubar")
+		})
+
+		It("marks the upload as failed and tries again", func() {
+			reconcileUntilUploaded()
+
+			Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				To(Equal(vmopv1.UploadFailureReason))
+			Expect(conditions.GetMessage(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)).
+				To(ContainSubstring("fubar"))
+			Expect(vmpub.Status.Artifact).To(BeNil())
+
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmpub.Status.Attempts).To(Equal(int64(2)))
+		})
+	})
+
+	When("the export was interrupted", func() {
+		BeforeEach(func() {
+			vmpub.Status.Attempts = 1
+			conditions.MarkFalse(vmpub,
+				vmopv1.VirtualMachinePublishRequestConditionUploaded,
+				vmopv1.UploadingReason,
+				"exporting and uploading the VM")
+		})
+
+		It("starts the export again", func() {
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmpub.Status.Attempts).To(Equal(int64(2)))
+
+			reconcileUntilUploaded()
+			Expect(conditions.IsTrue(vmpub, vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+		})
+	})
+
+	When("the backoff limit has been reached", func() {
+		BeforeEach(func() {
+			vmpub.Status.Attempts = vmpub.Spec.BackoffLimit
+		})
+
+		It("marks the request as failed", func() {
+			_, err := reconciler.ReconcileNormal(vmpubCtx)
+			Expect(err).To(MatchError(ContainSubstring("publish attempts limit has been reached: 3")))
+			Expect(conditions.GetReason(vmpub, vmopv1.VirtualMachinePublishRequestConditionComplete)).
+				To(Equal(vmopv1.FatalReason))
+		})
+	})
+}
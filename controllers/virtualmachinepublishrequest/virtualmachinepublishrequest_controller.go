@@ -46,6 +46,8 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	pkgnil "github.com/vmware-tanzu/vm-operator/pkg/util/nil"
 )
@@ -103,7 +105,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		}).
 		Watches(&vmopv1.VirtualMachineImage{},
 			handler.EnqueueRequestsFromMapFunc(vmiToVMPubMapperFn(ctx, r.Client))).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 // vmiToVMPubMapperFn returns a mapper function that can be used to queue a
@@ -160,6 +162,7 @@ func NewReconciler(
 		Recorder:   recorder,
 		VMProvider: vmProvider,
 		Metrics:    metrics.NewVMPublishMetrics(),
+		exports:    asyncop.NewTracker[*artifactExport](nil),
 	}
 }
 
@@ -172,6 +175,9 @@ type Reconciler struct {
 	Recorder   record.Recorder
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.VMPublishMetrics
+
+	exports *asyncop.Tracker[*artifactExport]
+	staging stagingVolume
 }
 
 func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result {
@@ -284,6 +290,8 @@ func (r *Reconciler) updateSourceAndTargetRef(ctx *pkgctx.VirtualMachinePublishR
 				Description: vmPubReq.Spec.Target.Item.Description,
 			},
 			Location: vmPubReq.Spec.Target.Location,
+			OCI:      vmPubReq.Spec.Target.OCI,
+			HTTP:     vmPubReq.Spec.Target.HTTP,
 		}
 	}
 }
@@ -721,7 +729,8 @@ func (r *Reconciler) checkIsComplete(ctx *pkgctx.VirtualMachinePublishRequestCon
 		return false
 	}
 
-	if !conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
+	if !isArtifactTarget(ctx.VMPublishRequest) &&
+		!conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
 		conditions.MarkFalse(ctx.VMPublishRequest,
 			vmopv1.VirtualMachinePublishRequestConditionComplete,
 			vmopv1.ImageUnavailableReason,
@@ -1155,6 +1164,21 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePublishRequestCon
 
 	r.updateSourceAndTargetRef(ctx)
 
+	// Requests with an OCI or HTTP target export the VM themselves instead of
+	// publishing it to a content library, and do not result in an image.
+	if isArtifactTarget(vmPublishReq) {
+		res, err := r.reconcileArtifact(ctx)
+		if err != nil {
+			return pkgerr.ResultFromError(err)
+		}
+		if isComplete = r.checkIsComplete(ctx); isComplete {
+			requeueAfter, deleted, err := r.removeVMPubResourceFromCluster(ctx)
+			isDeleted = deleted
+			return ctrl.Result{RequeueAfter: requeueAfter}, err
+		}
+		return res, nil
+	}
+
 	// There are two types of target Content Libraries that we can publish to. The full processing
 	// of the request is dependent on the type of Content Library. So, we fetch the Content Library
 	// here, instead of waiting until later to do so during target validation.
@@ -1202,6 +1226,8 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePublishRequestCon
 }
 
 func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachinePublishRequestContext) (ctrl.Result, error) {
+	r.exports.Cancel(client.ObjectKeyFromObject(ctx.VMPublishRequest))
+
 	if controllerutil.ContainsFinalizer(ctx.VMPublishRequest, finalizerName) ||
 		controllerutil.ContainsFinalizer(ctx.VMPublishRequest, deprecatedFinalizerName) {
 		r.Metrics.DeleteMetrics(ctx.Logger, ctx.VMPublishRequest.Name, ctx.VMPublishRequest.Namespace)
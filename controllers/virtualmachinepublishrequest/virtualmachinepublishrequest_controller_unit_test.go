@@ -51,6 +51,14 @@ func unitTests() {
 		),
 		unitTestsReconcile,
 	)
+	Describe(
+		"Reconcile artifact targets",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsReconcileArtifact,
+	)
 }
 
 func unitTestsReconcile() {
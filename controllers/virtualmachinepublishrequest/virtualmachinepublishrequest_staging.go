@@ -0,0 +1,79 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// stagingVolume tracks the space on the staging volume that is reserved by the
+// exports that are in progress, so concurrent exports do not together exceed
+// the free space of the volume.
+type stagingVolume struct {
+	mu       sync.Mutex
+	reserved int64
+}
+
+// reserve reserves size bytes on the volume of dir. An error is returned if
+// the volume does not have enough free space that is not already reserved.
+// The returned function releases the reservation.
+func (s *stagingVolume) reserve(dir string, size int64) (func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	free, err := getFreeSpace(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get free space of staging volume: %w", err)
+	}
+	if available := free - s.reserved; size > available {
+		return nil, fmt.Errorf(
+			"staging volume does not have enough free space to export the VM: "+
+				"requires %s, available %s",
+			resource.NewQuantity(size, resource.BinarySI),
+			resource.NewQuantity(max(available, 0), resource.BinarySI))
+	}
+
+	s.reserved += size
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.reserved -= size
+		})
+	}, nil
+}
+
+// getFreeSpace returns the number of bytes that are free on the volume of dir.
+func getFreeSpace(dir string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail) * int64(st.Bsize), nil //nolint:gosec // disable G115
+}
+
+// getExportSize returns the estimated number of bytes staged when the VM is
+// exported, which is the observed size on disk of each of its volumes, or the
+// capacity of the volumes whose size is unknown.
+func getExportSize(vm *vmopv1.VirtualMachine) int64 {
+	var size int64
+	for _, v := range vm.Status.Volumes {
+		switch {
+		case v.Used != nil:
+			size += v.Used.Value()
+		case v.Limit != nil:
+			size += v.Limit.Value()
+		}
+	}
+	return size
+}
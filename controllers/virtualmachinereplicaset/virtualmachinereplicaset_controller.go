@@ -50,6 +50,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 )
 
@@ -93,11 +94,14 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		Watches(&vmopv1.VirtualMachine{},
 			handler.EnqueueRequestsFromMapFunc(r.VMToReplicaSets(ctx)),
 		).
+		Watches(&vmopv1.VirtualMachineImageStream{},
+			handler.EnqueueRequestsFromMapFunc(r.imageStreamToReplicaSets),
+		).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
 			LogConstructor:          pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
 		}).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 // VMToReplicaSets is a mapper function to be used to enqueue requests for
@@ -391,6 +395,16 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineReplicaSetContext
 		return ctrl.Result{}, fmt.Errorf("failed to sync VirtualMachineReplicaSet replicas: %w", syncErr)
 	}
 
+	// Replace the replicas that are not using the current image of the image
+	// stream the template refers to, one at a time.
+	rolled, err := r.rollImageStream(ctx, ctx.ReplicaSet, filteredVMs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if rolled {
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
 	var replicas int32
 	if ctx.ReplicaSet.Spec.Replicas != nil {
 		replicas = *ctx.ReplicaSet.Spec.Replicas
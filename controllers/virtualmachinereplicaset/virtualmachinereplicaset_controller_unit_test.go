@@ -0,0 +1,220 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		), unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const streamName = "photon"
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *virtualmachinereplicaset.Reconciler
+		rs          *vmopv1.VirtualMachineReplicaSet
+		rsCtx       *pkgctx.VirtualMachineReplicaSetContext
+		stream      *vmopv1.VirtualMachineImageStream
+		vm1, vm2    *vmopv1.VirtualMachine
+		currentImg  = vmopv1.VirtualMachineImageRef{Kind: "VirtualMachineImage", Name: "vmi-2"}
+		outdatedImg = vmopv1.VirtualMachineImageRef{Kind: "VirtualMachineImage", Name: "vmi-1"}
+	)
+
+	newVM := func(name string, created time.Time, img vmopv1.VirtualMachineImageRef) *vmopv1.VirtualMachine {
+		vm := &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         rs.Namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					"appname":                                "db",
+					vmopv1.VirtualMachineReplicaSetNameLabel: rs.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(rs, vmopv1.GroupVersion.WithKind("VirtualMachineReplicaSet")),
+				},
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Image: &img,
+			},
+		}
+		conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+		return vm
+	}
+
+	BeforeEach(func() {
+		rs = &vmopv1.VirtualMachineReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "dummy-replicaset",
+				Namespace:  builder.DummyNamespaceName,
+				UID:        "dummy-replicaset-uid",
+				Finalizers: []string{finalizerName},
+			},
+			Spec: vmopv1.VirtualMachineReplicaSetSpec{
+				Replicas: ptrTo(int32(2)),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"appname": "db",
+					},
+				},
+				Template: vmopv1.VirtualMachineTemplateSpec{
+					ObjectMeta: vmopv1common.ObjectMeta{
+						Labels: map[string]string{
+							"appname": "db",
+						},
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						Image: &vmopv1.VirtualMachineImageRef{
+							Kind: vmopv1.VirtualMachineImageStreamKind,
+							Name: streamName,
+						},
+					},
+				},
+				ImageStreamUpdatePolicy: vmopv1.VirtualMachineReplicaSetImageStreamUpdatePolicyRolling,
+			},
+		}
+		stream = &vmopv1.VirtualMachineImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      streamName,
+				Namespace: rs.Namespace,
+			},
+			Status: vmopv1.VirtualMachineImageStreamStatus{
+				Image: &currentImg,
+			},
+		}
+
+		now := time.Now()
+		vm1 = newVM("vm-1", now.Add(-2*time.Hour), outdatedImg)
+		vm2 = newVM("vm-2", now.Add(-1*time.Hour), outdatedImg)
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, rs, stream, vm1, vm2)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinereplicaset.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		rsCtx = &pkgctx.VirtualMachineReplicaSetContext{
+			Context:    ctx,
+			Logger:     ctx.Logger.WithName(rs.Name),
+			ReplicaSet: rs,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		rs = nil
+		rsCtx = nil
+		stream = nil
+	})
+
+	vmExists := func(vm *vmopv1.VirtualMachine) bool {
+		err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), &vmopv1.VirtualMachine{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the image stream moved to a new image", func() {
+			It("replaces the oldest outdated VM", func() {
+				result, err := reconciler.ReconcileNormal(rsCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).ToNot(BeZero())
+				Expect(vmExists(vm1)).To(BeFalse())
+				Expect(vmExists(vm2)).To(BeTrue())
+				Expect(ctx.Events).To(Receive(ContainSubstring("ImageStreamRollout")))
+			})
+
+			When("the update policy is Manual", func() {
+				BeforeEach(func() {
+					rs.Spec.ImageStreamUpdatePolicy = vmopv1.VirtualMachineReplicaSetImageStreamUpdatePolicyManual
+				})
+
+				It("does not replace any VM", func() {
+					_, err := reconciler.ReconcileNormal(rsCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmExists(vm1)).To(BeTrue())
+					Expect(vmExists(vm2)).To(BeTrue())
+				})
+			})
+
+			When("a VM has not been created yet", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vm2, vmopv1.VirtualMachineConditionCreated, "NotCreated", "")
+				})
+
+				It("does not replace any VM", func() {
+					_, err := reconciler.ReconcileNormal(rsCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmExists(vm1)).To(BeTrue())
+					Expect(vmExists(vm2)).To(BeTrue())
+				})
+			})
+		})
+
+		When("only some VMs are outdated", func() {
+			BeforeEach(func() {
+				vm1.Spec.Image = &currentImg
+			})
+
+			It("replaces the outdated VM", func() {
+				_, err := reconciler.ReconcileNormal(rsCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmExists(vm1)).To(BeTrue())
+				Expect(vmExists(vm2)).To(BeFalse())
+			})
+		})
+
+		When("all VMs use the stream's current image", func() {
+			BeforeEach(func() {
+				vm1.Spec.Image = &currentImg
+				vm2.Spec.Image = &currentImg
+			})
+
+			It("does not replace any VM", func() {
+				_, err := reconciler.ReconcileNormal(rsCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmExists(vm1)).To(BeTrue())
+				Expect(vmExists(vm2)).To(BeTrue())
+			})
+		})
+	})
+}
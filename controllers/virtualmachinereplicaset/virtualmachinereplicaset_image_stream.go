@@ -0,0 +1,134 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagestreams,verbs=get;list;watch
+
+// templateImageStream returns the name of the VirtualMachineImageStream the
+// replica set's template refers to, or an empty string if the template refers
+// to an image.
+func templateImageStream(rs *vmopv1.VirtualMachineReplicaSet) string {
+	img := rs.Spec.Template.Spec.Image
+	if img == nil || img.Kind != vmopv1.VirtualMachineImageStreamKind {
+		return ""
+	}
+	return img.Name
+}
+
+// imageStreamToReplicaSets returns a request for each replica set in the
+// stream's namespace whose template refers to the stream.
+func (r *Reconciler) imageStreamToReplicaSets(ctx context.Context, o client.Object) []reconcile.Request {
+	var list vmopv1.VirtualMachineReplicaSetList
+	if err := r.Client.List(ctx, &list, client.InNamespace(o.GetNamespace())); err != nil {
+		pkglog.FromContextOrDefault(ctx).Error(err, "Failed to list VirtualMachineReplicaSets")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if templateImageStream(rs) != o.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(rs),
+		})
+	}
+	return requests
+}
+
+// rollImageStream replaces one replica that was not created from the current
+// image of the stream the template refers to. Replicas are only replaced when
+// the replica set has the desired number of replicas and all of them have been
+// created, so at most one replica is unavailable at a time.
+//
+// Returns true if a replica was deleted.
+func (r *Reconciler) rollImageStream(
+	ctx *pkgctx.VirtualMachineReplicaSetContext,
+	rs *vmopv1.VirtualMachineReplicaSet,
+	vms []*vmopv1.VirtualMachine) (bool, error) {
+
+	if rs.Spec.ImageStreamUpdatePolicy != vmopv1.VirtualMachineReplicaSetImageStreamUpdatePolicyRolling {
+		return false, nil
+	}
+
+	streamName := templateImageStream(rs)
+	if streamName == "" {
+		return false, nil
+	}
+
+	if rs.Spec.Replicas == nil || len(vms) != int(*rs.Spec.Replicas) {
+		return false, nil
+	}
+	for _, vm := range vms {
+		if !vm.DeletionTimestamp.IsZero() ||
+			!conditions.IsTrue(vm, vmopv1.VirtualMachineConditionCreated) {
+
+			return false, nil
+		}
+	}
+
+	var stream vmopv1.VirtualMachineImageStream
+	if err := r.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: rs.Namespace, Name: streamName},
+		&stream); err != nil {
+
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get VirtualMachineImageStream %q: %w", streamName, err)
+	}
+
+	if stream.Status.Image == nil {
+		return false, nil
+	}
+
+	var outdated []*vmopv1.VirtualMachine
+	for _, vm := range vms {
+		if vm.Spec.Image == nil || *vm.Spec.Image != *stream.Status.Image {
+			outdated = append(outdated, vm)
+		}
+	}
+	if len(outdated) == 0 {
+		return false, nil
+	}
+
+	sort.SliceStable(outdated, func(i, j int) bool {
+		return outdated[i].CreationTimestamp.Before(&outdated[j].CreationTimestamp)
+	})
+	vm := outdated[0]
+
+	ctx.Logger.Info("Replacing VM that is not using the image stream's current image",
+		"vm", vm.Name,
+		"imageStream", stream.Name,
+		"image", stream.Status.Image,
+		"outdatedReplicas", len(outdated))
+
+	if err := r.Client.Delete(ctx, vm); err != nil {
+		r.Recorder.Warnf(rs, "FailedDelete", "Failed to delete VM %q: %v", vm.Name, err)
+		return false, fmt.Errorf("failed to delete VM %q: %w", vm.Name, err)
+	}
+	r.Recorder.Eventf(rs, "ImageStreamRollout",
+		"Deleted VM %q to replace it with image %q from stream %q",
+		vm.Name, stream.Status.Image.Name, stream.Name)
+
+	return true, nil
+}
@@ -24,6 +24,7 @@ import (
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 )
 
 const (
@@ -51,7 +52,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			&topologyv1.Zone{},
 			handler.EnqueueRequestsFromMapFunc(zoneToNamespaceVMSRP(mgr.GetClient()))).
 		WithLogConstructor(pkglog.ControllerLogConstructor(controlledTypeName, controlledType, mgr.GetScheme())).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controlledTypeName, r))
 }
 
 func NewReconciler(
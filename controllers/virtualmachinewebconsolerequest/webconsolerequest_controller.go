@@ -25,6 +25,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/proxyaddr"
 )
 
@@ -57,7 +58,7 @@ func addToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			MaxConcurrentReconciles: 1,
 			LogConstructor:          pkglog.ControllerLogConstructor(controllerNameShort, controlledType, mgr.GetScheme()),
 		}).
-		Complete(r)
+		Complete(pkgtracing.Reconciler(controllerNameShort, r))
 }
 
 func NewReconciler(
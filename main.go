@@ -41,6 +41,7 @@ import (
 	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
 	pkgmgrinit "github.com/vmware-tanzu/vm-operator/pkg/manager/init"
 	"github.com/vmware-tanzu/vm-operator/pkg/mem"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/watcher"
@@ -64,6 +65,7 @@ var (
 	defaultConfig    = pkgcfg.FromEnv()
 	logOptions       = logs.NewOptions()
 	setupLog         = klog.Background().WithName("setup")
+	tracingShutdown  pkgtracing.ShutdownFunc
 )
 
 // main is the entrypoint for the application. Please note, unless otherwise
@@ -84,6 +86,8 @@ func main() {
 
 	initMemStats()
 
+	initTracing()
+
 	initFeatures()
 
 	initCRDs()
@@ -100,7 +104,11 @@ func main() {
 
 	setupLog.Info("Starting controller manager")
 	sigHandler := ctrlsig.SetupSignalHandler()
-	if err := mgr.Start(sigHandler); err != nil {
+	err := mgr.Start(sigHandler)
+
+	shutdownTracing()
+
+	if err != nil {
 		setupLog.Error(err, "Problem running controller manager")
 		os.Exit(1)
 	}
@@ -153,6 +161,36 @@ func initMemStats() {
 		metrics.Registry.MustRegister)
 }
 
+func initTracing() {
+	cfg := pkgcfg.FromContext(ctx).Tracing
+	if cfg.OTLPEndpoint == "" {
+		setupLog.Info("Tracing is disabled")
+	} else {
+		setupLog.Info("Initializing tracing",
+			"endpoint", cfg.OTLPEndpoint,
+			"insecure", cfg.OTLPInsecure,
+			"samplingRatio", cfg.SamplingRatio)
+	}
+
+	shutdown, err := pkgtracing.Init(ctx)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize tracing")
+		os.Exit(1)
+	}
+	tracingShutdown = shutdown
+}
+
+func shutdownTracing() {
+	if tracingShutdown == nil {
+		return
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracingShutdown(shutdownCtx); err != nil {
+		setupLog.Error(err, "Failed to shutdown tracing")
+	}
+}
+
 func initContext() {
 	ctx = pkgcfg.WithConfig(defaultConfig)
 	ctx = cource.WithContext(ctx)
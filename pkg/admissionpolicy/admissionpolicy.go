@@ -0,0 +1,578 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package admissionpolicy evaluates the CEL expressions of the
+// VirtualMachineAdmissionPolicy resources when VMs are admitted.
+package admissionpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+const (
+	// costLimit is the maximum cost of evaluating a single expression.
+	costLimit = 1000000
+
+	varObject          = "object"
+	varOldObject       = "oldObject"
+	varClass           = "class"
+	varImage           = "image"
+	varNamespaceObject = "namespaceObject"
+	varRequest         = "request"
+)
+
+// Request is a VM admission request that is evaluated against the
+// VirtualMachineAdmissionPolicy resources.
+type Request struct {
+	// Operation is the operation of the request.
+	Operation admissionv1.Operation
+
+	// UserInfo is the user that made the request.
+	UserInfo authv1.UserInfo
+
+	// VM is the VM being admitted. The VM is updated in place when defaults
+	// are applied.
+	VM *vmopv1.VirtualMachine
+
+	// OldVM is the existing VM on update, otherwise nil.
+	OldVM *vmopv1.VirtualMachine
+}
+
+// Result is the outcome of evaluating a request against the
+// VirtualMachineAdmissionPolicy resources.
+type Result struct {
+	// Denials are the messages of the policies that denied the request.
+	Denials []string
+
+	// Warnings are the messages of the policies that warned about the
+	// request.
+	Warnings []string
+
+	// AuditAnnotations describes, by policy name, each of the policies that
+	// fired.
+	AuditAnnotations map[string]string
+
+	// Mutated is true if defaults were applied to the VM.
+	Mutated bool
+}
+
+// Evaluator evaluates requests against the VirtualMachineAdmissionPolicy
+// resources.
+type Evaluator struct {
+	client ctrlclient.Client
+
+	// programs caches the compiled policies by their UID and generation.
+	programs sync.Map
+}
+
+// NewEvaluator returns a new Evaluator.
+func NewEvaluator(client ctrlclient.Client) *Evaluator {
+	return &Evaluator{client: client}
+}
+
+// Validate evaluates the request against the policies in Validate mode.
+func (e *Evaluator) Validate(ctx context.Context, req Request) (Result, error) {
+	return e.evaluate(ctx, vmopv1.VirtualMachineAdmissionPolicyModeValidate, req)
+}
+
+// Mutate evaluates the request against the policies in Mutate mode, applying
+// their defaults to req.VM.
+func (e *Evaluator) Mutate(ctx context.Context, req Request) (Result, error) {
+	return e.evaluate(ctx, vmopv1.VirtualMachineAdmissionPolicyModeMutate, req)
+}
+
+// ValidateExpressions returns an error for each expression in the policy that
+// does not compile.
+func ValidateExpressions(policy *vmopv1.VirtualMachineAdmissionPolicy) field.ErrorList {
+	_, errs := compile(policy)
+	return errs
+}
+
+func (e *Evaluator) evaluate(
+	ctx context.Context,
+	mode vmopv1.VirtualMachineAdmissionPolicyMode,
+	req Request) (Result, error) {
+
+	var result Result
+
+	var list vmopv1.VirtualMachineAdmissionPolicyList
+	if err := e.client.List(ctx, &list); err != nil {
+		return result, fmt.Errorf("failed to list admission policies: %w", err)
+	}
+	e.evict(list.Items)
+
+	policies := make([]*vmopv1.VirtualMachineAdmissionPolicy, 0, len(list.Items))
+	for i := range list.Items {
+		p := &list.Items[i]
+		if policyMode(p) == mode && appliesToOperation(p, req.Operation) {
+			policies = append(policies, p)
+		}
+	}
+	if len(policies) == 0 {
+		return result, nil
+	}
+	slices.SortFunc(policies, func(a, b *vmopv1.VirtualMachineAdmissionPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	vars, ns, err := e.resolve(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	for _, p := range policies {
+		if ok, err := appliesToNamespace(p, ns); err != nil {
+			result.fire(p, []string{err.Error()})
+			continue
+		} else if !ok {
+			continue
+		}
+
+		c, errs := e.getProgram(p)
+		if len(errs) > 0 {
+			result.fire(p, []string{errs.ToAggregate().Error()})
+			continue
+		}
+
+		switch mode {
+		case vmopv1.VirtualMachineAdmissionPolicyModeValidate:
+			if msgs := c.validate(ctx, vars); len(msgs) > 0 {
+				result.fire(p, msgs)
+			}
+		case vmopv1.VirtualMachineAdmissionPolicyModeMutate:
+			obj := vars[varObject].(map[string]any)
+			paths, msgs := c.mutate(ctx, vars, obj, policyAction(p) != vmopv1.VirtualMachineAdmissionPolicyActionAudit)
+			if len(msgs) > 0 {
+				result.fire(p, msgs)
+			}
+			if len(paths) > 0 {
+				result.applied(p, paths)
+			}
+		}
+	}
+
+	if result.Mutated {
+		data, err := json.Marshal(vars[varObject])
+		if err != nil {
+			return result, err
+		}
+		var vm vmopv1.VirtualMachine
+		if err := json.Unmarshal(data, &vm); err != nil {
+			return result, fmt.Errorf("failed to apply admission policy defaults: %w", err)
+		}
+		*req.VM = vm
+	}
+
+	return result, nil
+}
+
+// fire records that the policy fired with the provided messages.
+func (r *Result) fire(p *vmopv1.VirtualMachineAdmissionPolicy, msgs []string) {
+	msg := strings.Join(msgs, "; ")
+	action := policyAction(p)
+
+	switch action {
+	case vmopv1.VirtualMachineAdmissionPolicyActionDeny:
+		r.Denials = append(r.Denials,
+			fmt.Sprintf("VirtualMachineAdmissionPolicy %q denied the request: %s", p.Name, msg))
+	case vmopv1.VirtualMachineAdmissionPolicyActionWarn:
+		r.Warnings = append(r.Warnings,
+			fmt.Sprintf("VirtualMachineAdmissionPolicy %q: %s", p.Name, msg))
+	}
+
+	r.annotate(p, fmt.Sprintf("%s: %s", strings.ToLower(string(action)), msg))
+}
+
+// applied records that the policy set, or would have set, the fields at the
+// provided paths.
+func (r *Result) applied(p *vmopv1.VirtualMachineAdmissionPolicy, paths []string) {
+	fields := strings.Join(paths, ", ")
+	action := policyAction(p)
+
+	switch action {
+	case vmopv1.VirtualMachineAdmissionPolicyActionAudit:
+		r.annotate(p, "audit: would set "+fields)
+		return
+	case vmopv1.VirtualMachineAdmissionPolicyActionWarn:
+		r.Warnings = append(r.Warnings,
+			fmt.Sprintf("VirtualMachineAdmissionPolicy %q set %s", p.Name, fields))
+	}
+
+	r.Mutated = true
+	r.annotate(p, fmt.Sprintf("%s: set %s", strings.ToLower(string(action)), fields))
+}
+
+func (r *Result) annotate(p *vmopv1.VirtualMachineAdmissionPolicy, value string) {
+	if r.AuditAnnotations == nil {
+		r.AuditAnnotations = map[string]string{}
+	}
+	if v, ok := r.AuditAnnotations[p.Name]; ok {
+		value = v + "; " + value
+	}
+	r.AuditAnnotations[p.Name] = value
+}
+
+// resolve returns the variables available to the expressions and the VM's
+// namespace.
+func (e *Evaluator) resolve(
+	ctx context.Context,
+	req Request) (map[string]any, *corev1.Namespace, error) {
+
+	vm := req.VM
+
+	vars := map[string]any{
+		varOldObject:       nil,
+		varClass:           nil,
+		varImage:           nil,
+		varNamespaceObject: nil,
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vm)
+	if err != nil {
+		return nil, nil, err
+	}
+	vars[varObject] = obj
+
+	if req.OldVM != nil {
+		if vars[varOldObject], err = runtime.DefaultUnstructuredConverter.ToUnstructured(req.OldVM); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	groups := make([]any, len(req.UserInfo.Groups))
+	for i := range req.UserInfo.Groups {
+		groups[i] = req.UserInfo.Groups[i]
+	}
+	vars[varRequest] = map[string]any{
+		"operation": string(req.Operation),
+		"userInfo": map[string]any{
+			"username": req.UserInfo.Username,
+			"groups":   groups,
+		},
+	}
+
+	ns := &corev1.Namespace{}
+	if err := e.get(ctx, apitypes.NamespacedName{Name: vm.Namespace}, ns, vars, varNamespaceObject); err != nil {
+		return nil, nil, err
+	}
+
+	if vm.Spec.ClassName != "" {
+		key := apitypes.NamespacedName{Namespace: vm.Namespace, Name: vm.Spec.ClassName}
+		if err := e.get(ctx, key, &vmopv1.VirtualMachineClass{}, vars, varClass); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if ref := vm.Spec.Image; ref != nil && ref.Name != "" {
+		switch ref.Kind {
+		case "VirtualMachineImage":
+			key := apitypes.NamespacedName{Namespace: vm.Namespace, Name: ref.Name}
+			if err := e.get(ctx, key, &vmopv1.VirtualMachineImage{}, vars, varImage); err != nil {
+				return nil, nil, err
+			}
+		case "ClusterVirtualMachineImage":
+			key := apitypes.NamespacedName{Name: ref.Name}
+			if err := e.get(ctx, key, &vmopv1.ClusterVirtualMachineImage{}, vars, varImage); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return vars, ns, nil
+}
+
+// get gets the object and sets it as the named variable. The variable is left
+// null if the object does not exist.
+func (e *Evaluator) get(
+	ctx context.Context,
+	key ctrlclient.ObjectKey,
+	obj ctrlclient.Object,
+	vars map[string]any,
+	name string) error {
+
+	if err := e.client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	vars[name] = u
+
+	return nil
+}
+
+type programKey struct {
+	uid        apitypes.UID
+	generation int64
+}
+
+type cachedProgram struct {
+	program *compiledPolicy
+	errs    field.ErrorList
+}
+
+func (e *Evaluator) getProgram(p *vmopv1.VirtualMachineAdmissionPolicy) (*compiledPolicy, field.ErrorList) {
+	key := programKey{uid: p.UID, generation: p.Generation}
+	if v, ok := e.programs.Load(key); ok {
+		c := v.(cachedProgram)
+		return c.program, c.errs
+	}
+
+	program, errs := compile(p)
+	if p.UID != "" {
+		e.programs.Store(key, cachedProgram{
+			program: program,
+			errs:    errs,
+		})
+	}
+
+	return program, errs
+}
+
+// evict removes the programs of the policies that were deleted or changed
+// since they were compiled, given the current policies.
+func (e *Evaluator) evict(policies []vmopv1.VirtualMachineAdmissionPolicy) {
+	cur := make(map[programKey]struct{}, len(policies))
+	for i := range policies {
+		cur[programKey{uid: policies[i].UID, generation: policies[i].Generation}] = struct{}{}
+	}
+	e.programs.Range(func(k, _ any) bool {
+		if _, ok := cur[k.(programKey)]; !ok {
+			e.programs.Delete(k)
+		}
+		return true
+	})
+}
+
+type compiledValidation struct {
+	program cel.Program
+	message string
+}
+
+type compiledDefault struct {
+	program cel.Program
+	path    []string
+}
+
+type compiledPolicy struct {
+	validations []compiledValidation
+	defaults    []compiledDefault
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(varObject, cel.DynType),
+		cel.Variable(varOldObject, cel.DynType),
+		cel.Variable(varClass, cel.DynType),
+		cel.Variable(varImage, cel.DynType),
+		cel.Variable(varNamespaceObject, cel.DynType),
+		cel.Variable(varRequest, cel.DynType),
+		cel.OptionalTypes(),
+		ext.Strings(),
+		ext.Sets(),
+	)
+}
+
+func compile(p *vmopv1.VirtualMachineAdmissionPolicy) (*compiledPolicy, field.ErrorList) {
+	var allErrs field.ErrorList
+
+	env, err := newEnv()
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	program := func(fieldPath *field.Path, expr string, wantBool bool) cel.Program {
+		ast, iss := env.Compile(expr)
+		if iss.Err() != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath, expr, iss.Err().Error()))
+			return nil
+		}
+		if wantBool {
+			if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+				allErrs = append(allErrs, field.Invalid(fieldPath, expr,
+					fmt.Sprintf("must evaluate to bool, not %s", t)))
+				return nil
+			}
+		}
+		prg, err := env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath, expr, err.Error()))
+			return nil
+		}
+		return prg
+	}
+
+	c := &compiledPolicy{}
+
+	validationsPath := field.NewPath("spec", "validations")
+	for i, v := range p.Spec.Validations {
+		prg := program(validationsPath.Index(i).Child("expression"), v.Expression, true)
+		msg := v.Message
+		if msg == "" {
+			msg = fmt.Sprintf("failed expression: %s", v.Expression)
+		}
+		c.validations = append(c.validations, compiledValidation{program: prg, message: msg})
+	}
+
+	defaultsPath := field.NewPath("spec", "defaults")
+	for i, d := range p.Spec.Defaults {
+		path := strings.Split(d.Path, ".")
+		if len(path) < 2 || path[0] != "spec" || slices.Contains(path, "") {
+			allErrs = append(allErrs, field.Invalid(defaultsPath.Index(i).Child("path"), d.Path,
+				"must be a dot-separated path of a field in spec"))
+		}
+		prg := program(defaultsPath.Index(i).Child("expression"), d.Expression, false)
+		c.defaults = append(c.defaults, compiledDefault{program: prg, path: path})
+	}
+
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+
+	return c, nil
+}
+
+// validate returns the messages of the validations that failed.
+func (c *compiledPolicy) validate(ctx context.Context, vars map[string]any) []string {
+	var msgs []string
+
+	for _, v := range c.validations {
+		out, _, err := v.program.ContextEval(ctx, vars)
+		switch {
+		case err != nil:
+			msgs = append(msgs, fmt.Sprintf("%s: %v", v.message, err))
+		case out != types.True:
+			msgs = append(msgs, v.message)
+		}
+	}
+
+	return msgs
+}
+
+// mutate evaluates the defaults for the fields of obj that are unset, and
+// sets them if apply is true. The paths of the fields that were, or would
+// have been, set are returned along with the messages of the defaults that
+// failed to evaluate.
+func (c *compiledPolicy) mutate(
+	ctx context.Context,
+	vars map[string]any,
+	obj map[string]any,
+	apply bool) ([]string, []string) {
+
+	var paths, msgs []string
+
+	for _, d := range c.defaults {
+		path := strings.Join(d.path, ".")
+
+		if v, ok, _ := unstructured.NestedFieldNoCopy(obj, d.path...); ok && v != nil {
+			continue
+		}
+
+		out, _, err := d.program.ContextEval(ctx, vars)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to evaluate default for %s: %v", path, err))
+			continue
+		}
+		if o, ok := out.(*types.Optional); ok {
+			if !o.HasValue() {
+				continue
+			}
+			out = o.GetValue()
+		}
+		if out == types.NullValue {
+			continue
+		}
+
+		val, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to convert default for %s: %v", path, err))
+			continue
+		}
+
+		if apply {
+			if err := unstructured.SetNestedField(obj, val.(*structpb.Value).AsInterface(), d.path...); err != nil {
+				msgs = append(msgs, fmt.Sprintf("failed to set default for %s: %v", path, err))
+				continue
+			}
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, msgs
+}
+
+func policyMode(p *vmopv1.VirtualMachineAdmissionPolicy) vmopv1.VirtualMachineAdmissionPolicyMode {
+	if p.Spec.Mode == "" {
+		return vmopv1.VirtualMachineAdmissionPolicyModeValidate
+	}
+	return p.Spec.Mode
+}
+
+func policyAction(p *vmopv1.VirtualMachineAdmissionPolicy) vmopv1.VirtualMachineAdmissionPolicyAction {
+	if p.Spec.Action == "" {
+		return vmopv1.VirtualMachineAdmissionPolicyActionDeny
+	}
+	return p.Spec.Action
+}
+
+// appliesToOperation returns true if the policy applies to the operation. A
+// policy in Mutate mode only applies to Update when it is listed explicitly,
+// since setting a field on update may change a field that is immutable once
+// the VM is created.
+func appliesToOperation(p *vmopv1.VirtualMachineAdmissionPolicy, op admissionv1.Operation) bool {
+	if op != admissionv1.Create && op != admissionv1.Update {
+		return false
+	}
+	if len(p.Spec.Operations) == 0 {
+		return op == admissionv1.Create ||
+			policyMode(p) != vmopv1.VirtualMachineAdmissionPolicyModeMutate
+	}
+	for _, o := range p.Spec.Operations {
+		if strings.EqualFold(string(o), string(op)) {
+			return true
+		}
+	}
+	return false
+}
+
+func appliesToNamespace(p *vmopv1.VirtualMachineAdmissionPolicy, ns *corev1.Namespace) (bool, error) {
+	if p.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,99 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package admissionpolicy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("Evaluator programs", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		policy    *vmopv1.VirtualMachineAdmissionPolicy
+		evaluator *Evaluator
+		req       Request
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ns"},
+		}
+		policy = &vmopv1.VirtualMachineAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "my-policy",
+				UID:        "uid-my-policy",
+				Generation: 1,
+			},
+			Spec: vmopv1.VirtualMachineAdmissionPolicySpec{
+				Mode:   vmopv1.VirtualMachineAdmissionPolicyModeValidate,
+				Action: vmopv1.VirtualMachineAdmissionPolicyActionDeny,
+				Validations: []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "object.spec.className != ''",
+						Message:    "VMs must have a class",
+					},
+				},
+			},
+		}
+		k8sClient = builder.NewFakeClient(namespace, policy)
+		evaluator = NewEvaluator(k8sClient)
+		req = Request{
+			Operation: admissionv1.Create,
+			VM: &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-vm",
+					Namespace: namespace.Name,
+				},
+			},
+		}
+	})
+
+	getKeys := func() []programKey {
+		var keys []programKey
+		evaluator.programs.Range(func(k, _ any) bool {
+			keys = append(keys, k.(programKey))
+			return true
+		})
+		return keys
+	}
+
+	It("caches the program of each generation of a policy", func() {
+		_, err := evaluator.Validate(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getKeys()).To(ConsistOf(programKey{uid: "uid-my-policy", generation: 1}))
+
+		policy.Generation = 2
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		_, err = evaluator.Validate(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getKeys()).To(ConsistOf(programKey{uid: "uid-my-policy", generation: 2}))
+	})
+
+	It("evicts the program of a deleted policy", func() {
+		_, err := evaluator.Validate(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getKeys()).To(HaveLen(1))
+
+		Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+
+		_, err = evaluator.Validate(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getKeys()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,470 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package admissionpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/admissionpolicy"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func newPolicy(
+	name string,
+	mode vmopv1.VirtualMachineAdmissionPolicyMode,
+	action vmopv1.VirtualMachineAdmissionPolicyAction) *vmopv1.VirtualMachineAdmissionPolicy {
+
+	return &vmopv1.VirtualMachineAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        types.UID("uid-" + name),
+			Generation: 1,
+		},
+		Spec: vmopv1.VirtualMachineAdmissionPolicySpec{
+			Mode:   mode,
+			Action: action,
+		},
+	}
+}
+
+var _ = Describe("Evaluator", func() {
+	var (
+		ctx       context.Context
+		objs      []client.Object
+		namespace *corev1.Namespace
+		vm        *vmopv1.VirtualMachine
+		req       admissionpolicy.Request
+		evaluator *admissionpolicy.Evaluator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-ns",
+				Labels: map[string]string{"env": "prod"},
+			},
+		}
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vm",
+				Namespace: namespace.Name,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "my-class",
+				Image: &vmopv1.VirtualMachineImageRef{
+					Kind: "ClusterVirtualMachineImage",
+					Name: "vmi-123",
+				},
+			},
+		}
+		objs = []client.Object{namespace}
+		req = admissionpolicy.Request{
+			Operation: admissionv1.Create,
+			UserInfo: authv1.UserInfo{
+				Username: "jdoe",
+				Groups:   []string{"devs"},
+			},
+			VM: vm,
+		}
+	})
+
+	JustBeforeEach(func() {
+		evaluator = admissionpolicy.NewEvaluator(builder.NewFakeClient(objs...))
+	})
+
+	Context("Validate", func() {
+		var policy *vmopv1.VirtualMachineAdmissionPolicy
+
+		BeforeEach(func() {
+			policy = newPolicy("prod-encryption",
+				vmopv1.VirtualMachineAdmissionPolicyModeValidate,
+				vmopv1.VirtualMachineAdmissionPolicyActionDeny)
+			policy.Spec.NamespaceSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			}
+			policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+				{
+					Expression: "object.spec.?crypto.encryptionClassName.orValue('') != ''",
+					Message:    "VMs in prod must use encryption",
+				},
+			}
+			objs = append(objs, policy)
+		})
+
+		When("there are no policies", func() {
+			BeforeEach(func() {
+				objs = []client.Object{namespace}
+			})
+
+			It("should not fire", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(admissionpolicy.Result{}))
+			})
+		})
+
+		When("the validation fails", func() {
+			It("should deny the request", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(ConsistOf(
+					`VirtualMachineAdmissionPolicy "prod-encryption" denied the request: VMs in prod must use encryption`))
+				Expect(result.Warnings).To(BeEmpty())
+				Expect(result.AuditAnnotations).To(HaveKeyWithValue(
+					"prod-encryption", "deny: VMs in prod must use encryption"))
+			})
+		})
+
+		When("the validation succeeds", func() {
+			BeforeEach(func() {
+				vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{
+					EncryptionClassName: "my-encryption-class",
+				}
+			})
+
+			It("should not fire", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+				Expect(result.AuditAnnotations).To(BeEmpty())
+			})
+		})
+
+		When("the namespace is not selected", func() {
+			BeforeEach(func() {
+				namespace.Labels["env"] = "dev"
+			})
+
+			It("should not fire", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+			})
+		})
+
+		When("the operation is not selected", func() {
+			BeforeEach(func() {
+				policy.Spec.Operations = []vmopv1.VirtualMachineAdmissionPolicyOperation{
+					vmopv1.VirtualMachineAdmissionPolicyOperationUpdate,
+				}
+			})
+
+			It("should not fire", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+			})
+		})
+
+		When("the action is Warn", func() {
+			BeforeEach(func() {
+				policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionWarn
+			})
+
+			It("should warn", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+				Expect(result.Warnings).To(ConsistOf(
+					`VirtualMachineAdmissionPolicy "prod-encryption": VMs in prod must use encryption`))
+				Expect(result.AuditAnnotations).To(HaveKeyWithValue(
+					"prod-encryption", "warn: VMs in prod must use encryption"))
+			})
+		})
+
+		When("the action is Audit", func() {
+			BeforeEach(func() {
+				policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionAudit
+			})
+
+			It("should only record the policy in the audit annotations", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+				Expect(result.Warnings).To(BeEmpty())
+				Expect(result.AuditAnnotations).To(HaveKeyWithValue(
+					"prod-encryption", "audit: VMs in prod must use encryption"))
+			})
+		})
+
+		When("the validation uses the class", func() {
+			BeforeEach(func() {
+				policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "class == null || class.spec.hardware.devices.?vgpuDevices.orValue([]).size() <= 2",
+						Message:    "no more than 2 vGPUs",
+					},
+				}
+				class := builder.DummyVirtualMachineClass("my-class")
+				class.Namespace = namespace.Name
+				class.Spec.Hardware.Devices.VGPUDevices = []vmopv1.VGPUDevice{
+					{ProfileName: "a"}, {ProfileName: "b"}, {ProfileName: "c"},
+				}
+				objs = append(objs, class)
+			})
+
+			It("should evaluate the VM's class", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(ConsistOf(ContainSubstring("no more than 2 vGPUs")))
+			})
+		})
+
+		When("the validation uses the image", func() {
+			BeforeEach(func() {
+				policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "image != null && image.spec.providerRef.name == 'library-x'",
+						Message:    "only images from library-x",
+					},
+				}
+			})
+
+			When("the image does not exist", func() {
+				It("should deny the request", func() {
+					result, err := evaluator.Validate(ctx, req)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.Denials).To(ConsistOf(ContainSubstring("only images from library-x")))
+				})
+			})
+
+			When("the image is from the library", func() {
+				BeforeEach(func() {
+					image := builder.DummyClusterVirtualMachineImage("vmi-123")
+					image.Spec.ProviderRef = &vmopv1common.LocalObjectRef{
+						Kind: "ContentLibraryItem",
+						Name: "library-x",
+					}
+					objs = append(objs, image)
+				})
+
+				It("should not fire", func() {
+					result, err := evaluator.Validate(ctx, req)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.Denials).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the validation uses the request", func() {
+			BeforeEach(func() {
+				policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "request.operation == 'CREATE' && 'devs' in request.userInfo.groups && " +
+							"namespaceObject.metadata.labels.env == 'prod' && oldObject == null",
+					},
+				}
+			})
+
+			It("should not fire", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(BeEmpty())
+			})
+		})
+
+		When("the validation fails to evaluate", func() {
+			BeforeEach(func() {
+				policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "object.spec.crypto.encryptionClassName != ''",
+					},
+				}
+			})
+
+			It("should deny the request", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Denials).To(ConsistOf(ContainSubstring(
+					"failed expression: object.spec.crypto.encryptionClassName != '': no such key: crypto")))
+			})
+		})
+	})
+
+	Context("Mutate", func() {
+		var policy *vmopv1.VirtualMachineAdmissionPolicy
+
+		BeforeEach(func() {
+			policy = newPolicy("defaults",
+				vmopv1.VirtualMachineAdmissionPolicyModeMutate,
+				vmopv1.VirtualMachineAdmissionPolicyActionDeny)
+			policy.Spec.Defaults = []vmopv1.VirtualMachineAdmissionPolicyDefault{
+				{
+					Path:       "spec.storageClass",
+					Expression: "'gold'",
+				},
+				{
+					Path:       "spec.crypto.encryptionClassName",
+					Expression: "namespaceObject.metadata.labels.env == 'prod' ? optional.of('prod-keys') : optional.none()",
+				},
+			}
+			objs = append(objs, policy)
+		})
+
+		It("should set the unset fields", func() {
+			result, err := evaluator.Mutate(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Mutated).To(BeTrue())
+			Expect(result.Warnings).To(BeEmpty())
+			Expect(result.AuditAnnotations).To(HaveKeyWithValue(
+				"defaults", "deny: set spec.storageClass, spec.crypto.encryptionClassName"))
+			Expect(vm.Spec.StorageClass).To(Equal("gold"))
+			Expect(vm.Spec.Crypto).ToNot(BeNil())
+			Expect(vm.Spec.Crypto.EncryptionClassName).To(Equal("prod-keys"))
+			Expect(vm.Spec.ClassName).To(Equal("my-class"))
+		})
+
+		When("a field is already set", func() {
+			BeforeEach(func() {
+				vm.Spec.StorageClass = "silver"
+			})
+
+			It("should not change the field", func() {
+				result, err := evaluator.Mutate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Mutated).To(BeTrue())
+				Expect(vm.Spec.StorageClass).To(Equal("silver"))
+			})
+		})
+
+		When("a default evaluates to an empty optional", func() {
+			BeforeEach(func() {
+				namespace.Labels["env"] = "dev"
+			})
+
+			It("should not set the field", func() {
+				_, err := evaluator.Mutate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Spec.StorageClass).To(Equal("gold"))
+				Expect(vm.Spec.Crypto).To(BeNil())
+			})
+		})
+
+		When("the action is Warn", func() {
+			BeforeEach(func() {
+				policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionWarn
+			})
+
+			It("should set the fields and warn", func() {
+				result, err := evaluator.Mutate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Mutated).To(BeTrue())
+				Expect(result.Warnings).To(ConsistOf(
+					`VirtualMachineAdmissionPolicy "defaults" set spec.storageClass, spec.crypto.encryptionClassName`))
+				Expect(vm.Spec.StorageClass).To(Equal("gold"))
+			})
+		})
+
+		When("the action is Audit", func() {
+			BeforeEach(func() {
+				policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionAudit
+			})
+
+			It("should only record the fields that would have been set", func() {
+				result, err := evaluator.Mutate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Mutated).To(BeFalse())
+				Expect(result.AuditAnnotations).To(HaveKeyWithValue(
+					"defaults", "audit: would set spec.storageClass, spec.crypto.encryptionClassName"))
+				Expect(vm.Spec.StorageClass).To(BeEmpty())
+			})
+		})
+
+		When("the VM is updated", func() {
+			BeforeEach(func() {
+				req.Operation = admissionv1.Update
+			})
+
+			It("should not set the fields", func() {
+				result, err := evaluator.Mutate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(admissionpolicy.Result{}))
+				Expect(vm.Spec.StorageClass).To(BeEmpty())
+				Expect(vm.Spec.Crypto).To(BeNil())
+			})
+
+			When("the policy selects the Update operation", func() {
+				BeforeEach(func() {
+					policy.Spec.Operations = []vmopv1.VirtualMachineAdmissionPolicyOperation{
+						vmopv1.VirtualMachineAdmissionPolicyOperationUpdate,
+					}
+				})
+
+				It("should set the unset fields", func() {
+					result, err := evaluator.Mutate(ctx, req)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.Mutated).To(BeTrue())
+					Expect(vm.Spec.StorageClass).To(Equal("gold"))
+				})
+			})
+		})
+
+		When("the VM is validated", func() {
+			It("should not evaluate the mutating policy", func() {
+				result, err := evaluator.Validate(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(admissionpolicy.Result{}))
+			})
+		})
+	})
+})
+
+var _ = Describe("ValidateExpressions", func() {
+	var policy *vmopv1.VirtualMachineAdmissionPolicy
+
+	BeforeEach(func() {
+		policy = newPolicy("my-policy", "", "")
+	})
+
+	It("should allow valid expressions", func() {
+		policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+			{Expression: "object.metadata.name.startsWith('vm-')"},
+		}
+		policy.Spec.Defaults = []vmopv1.VirtualMachineAdmissionPolicyDefault{
+			{Path: "spec.storageClass", Expression: "'gold'"},
+		}
+		Expect(admissionpolicy.ValidateExpressions(policy)).To(BeEmpty())
+	})
+
+	It("should return an error for an expression that does not compile", func() {
+		policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+			{Expression: "object.metadata.name =="},
+		}
+		errs := admissionpolicy.ValidateExpressions(policy)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.validations[0].expression"))
+	})
+
+	It("should return an error for a validation that does not evaluate to a bool", func() {
+		policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+			{Expression: "'hello'"},
+		}
+		errs := admissionpolicy.ValidateExpressions(policy)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Detail).To(Equal("must evaluate to bool, not string"))
+	})
+
+	It("should return an error for a path that is not in spec", func() {
+		policy.Spec.Defaults = []vmopv1.VirtualMachineAdmissionPolicyDefault{
+			{Path: "metadata.name", Expression: "'my-vm'"},
+		}
+		errs := admissionpolicy.ValidateExpressions(policy)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.defaults[0].path"))
+	})
+})
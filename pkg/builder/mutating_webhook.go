@@ -103,7 +103,10 @@ type mutatingWebhookHandler struct {
 	Mutator
 }
 
-func (h *mutatingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+func (h *mutatingWebhookHandler) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	webhookCtx, span := startWebhookSpan(ctx, h.WebhookContext, h.Name+".Mutate", req)
+	defer func() { endWebhookSpan(span, resp) }()
+
 	if h.EnableWebhookClientVerification {
 		ctx = pkgcfg.JoinContext(ctx, h.WebhookContext)
 		if err := VerifyWebhookRequest(ctx); err != nil {
@@ -162,7 +165,7 @@ func (h *mutatingWebhookHandler) Handle(ctx context.Context, req admission.Reque
 	}
 
 	webhookRequestContext := &pkgctx.WebhookRequestContext{
-		WebhookContext:      h.WebhookContext,
+		WebhookContext:      webhookCtx,
 		Op:                  req.Operation,
 		Obj:                 obj,
 		RawObj:              req.Object.Raw,
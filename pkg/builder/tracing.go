@@ -0,0 +1,59 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+const (
+	attrWebhookOperation = attribute.Key("vmoperator.webhook.operation")
+	attrWebhookKind      = attribute.Key("vmoperator.webhook.kind")
+	attrWebhookAllowed   = attribute.Key("vmoperator.webhook.allowed")
+)
+
+// startWebhookSpan starts a span for an admission request and returns a copy
+// of the webhook context whose embedded context contains the span.
+func startWebhookSpan(
+	ctx context.Context,
+	webhookCtx *pkgctx.WebhookContext,
+	spanName string,
+	req admission.Request) (*pkgctx.WebhookContext, trace.Span) {
+
+	_, span := pkgtracing.Start(
+		ctx,
+		spanName,
+		attrWebhookOperation.String(string(req.Operation)),
+		attrWebhookKind.String(req.Kind.Kind),
+		pkgtracing.AttrNamespace.String(req.Namespace),
+		pkgtracing.AttrName.String(req.Name))
+
+	spanWebhookCtx := *webhookCtx
+	spanWebhookCtx.Context = trace.ContextWithSpan(webhookCtx.Context, span)
+
+	return &spanWebhookCtx, span
+}
+
+// endWebhookSpan records the result of an admission request and ends the span.
+func endWebhookSpan(span trace.Span, resp admission.Response) {
+	span.SetAttributes(attrWebhookAllowed.Bool(resp.Allowed))
+	if !resp.Allowed {
+		msg := "denied"
+		if resp.Result != nil {
+			msg = fmt.Sprintf("%s: %s", msg, resp.Result.Message)
+		}
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
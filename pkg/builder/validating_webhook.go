@@ -111,7 +111,10 @@ type validatingWebhookHandler struct {
 	Validator
 }
 
-func (h *validatingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+func (h *validatingWebhookHandler) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	webhookCtx, span := startWebhookSpan(ctx, h.WebhookContext, h.Name+".Validate", req)
+	defer func() { endWebhookSpan(span, resp) }()
+
 	if h.EnableWebhookClientVerification {
 		ctx = pkgcfg.JoinContext(ctx, h.WebhookContext)
 		if err := VerifyWebhookRequest(ctx); err != nil {
@@ -159,7 +162,7 @@ func (h *validatingWebhookHandler) Handle(ctx context.Context, req admission.Req
 
 	// Create the webhook request pkgctx.
 	webhookRequestContext := &pkgctx.WebhookRequestContext{
-		WebhookContext:      h.WebhookContext,
+		WebhookContext:      webhookCtx,
 		Op:                  req.Operation,
 		Obj:                 obj,
 		OldObj:              oldObj,
@@ -161,6 +161,50 @@ type Config struct {
 	// Please note, this field has no effect if a CRD is being installed for the
 	// first time.
 	CRDCleanupEnabled bool
+
+	// StorageVersionMigrationEnabled may be set to true to enable the
+	// controller that rewrites the objects of the VM Operator CRDs at their
+	// current storage version and then removes the older versions from each
+	// CRD's status.storedVersions.
+	//
+	// Defaults to false.
+	StorageVersionMigrationEnabled bool
+
+	// Tracing contains configuration details related to exporting
+	// OpenTelemetry traces.
+	Tracing Tracing
+
+	// Rebalancer contains configuration details related to the controller
+	// that moves VMs to repair violations of their preferred placement rules.
+	Rebalancer Rebalancer
+
+	// ImageTrustRootsName is the name of the ConfigMap and/or Secret in the
+	// pod's namespace that contains the PEM encoded certificates and public
+	// keys used to verify the signatures of images.
+	//
+	// Image signatures are not verified when this is empty.
+	//
+	// Defaults to empty.
+	ImageTrustRootsName string
+
+	// ImageCache contains configuration details related to the eviction of
+	// the files cached on datastores by the VirtualMachineImageCache
+	// controller.
+	ImageCache ImageCache
+
+	// DynamicDNS contains configuration details related to the controller
+	// that registers the VMs' host names and addresses in DNS.
+	DynamicDNS DynamicDNS
+
+	// PublishStagingDir is the directory, on a volume mounted in the pod, in
+	// which the files of the VMs exported by VirtualMachinePublishRequests are
+	// staged before they are uploaded to an OCI registry or HTTP URL.
+	//
+	// VMs cannot be published to an OCI registry or HTTP URL when this is
+	// empty.
+	//
+	// Defaults to empty.
+	PublishStagingDir string
 }
 
 // GetMaxDeployThreadsOnProvider returns MaxDeployThreadsOnProvider if it is >0
@@ -227,6 +271,82 @@ type InstanceStorage struct {
 	SeedRequeueDuration time.Duration
 }
 
+type Tracing struct {
+	// OTLPEndpoint is the host:port of the OTLP/gRPC collector to which spans
+	// are exported.
+	//
+	// Tracing is disabled when this value is empty.
+	//
+	// Defaults to "".
+	OTLPEndpoint string
+
+	// OTLPInsecure may be set to true to disable TLS when connecting to the
+	// OTLP collector.
+	//
+	// Defaults to false.
+	OTLPInsecure bool
+
+	// SamplingRatio is the ratio of root spans that are sampled, from 0.0 to
+	// 1.0. Child spans always follow the sampling decision of their parent.
+	//
+	// Defaults to 1.0.
+	SamplingRatio float64
+}
+
+type Rebalancer struct {
+	// Enabled may be set to true to enable the controller that periodically
+	// re-evaluates the VMs that have preferred affinity or anti-affinity
+	// rules across hosts, and relocates them to hosts on which fewer of those
+	// rules are violated.
+	//
+	// Defaults to false.
+	Enabled bool
+
+	// Interval is how often each VM is re-evaluated.
+	//
+	// Defaults to 10m.
+	Interval time.Duration
+
+	// MaxMovesPerInterval is the maximum number of VMs that may be relocated
+	// during each Interval.
+	//
+	// Defaults to 5.
+	MaxMovesPerInterval int
+}
+
+type ImageCache struct {
+	// DatastoreCapacityLimit is the maximum number of bytes that may be used
+	// by cached image files on a single datastore. The least recently used
+	// cached files are evicted when this limit is exceeded.
+	//
+	// There is no limit when this value is zero.
+	//
+	// Defaults to 0.
+	DatastoreCapacityLimit int64
+
+	// ProfileCapacityLimit is the maximum number of bytes that may be used by
+	// cached image files with a single storage profile on a single datastore.
+	// The least recently used cached files are evicted when this limit is
+	// exceeded.
+	//
+	// There is no limit when this value is zero.
+	//
+	// Defaults to 0.
+	ProfileCapacityLimit int64
+
+	// EvictionGracePeriod is how long cached files must go unused before they
+	// may be evicted.
+	//
+	// Defaults to 24h.
+	EvictionGracePeriod time.Duration
+
+	// EvictionInterval is how often the cached files are checked for eviction
+	// when either capacity limit is set.
+	//
+	// Defaults to 10m.
+	EvictionInterval time.Duration
+}
+
 type NetworkProviderType string
 
 const (
@@ -235,3 +355,47 @@ const (
 	NetworkProviderTypeVDS   NetworkProviderType = "VSPHERE_NETWORK"
 	NetworkProviderTypeVPC   NetworkProviderType = "NSXT_VPC"
 )
+
+type DynamicDNS struct {
+	// Server is the host:port of the DNS server to which RFC 2136 dynamic
+	// updates are sent over TCP.
+	//
+	// VMs are not registered in DNS when this is empty.
+	//
+	// Defaults to empty.
+	Server string
+
+	// Zones is a comma-separated list of the forward and reverse zones that
+	// are updated, ex. "example.com,1.168.192.in-addr.arpa". Each record is
+	// updated in the longest zone that contains it. A VM's A and AAAA records
+	// are not registered when its domain is not in a zone, and its PTR records
+	// are not registered when its addresses are not in a reverse zone.
+	//
+	// Defaults to empty.
+	Zones string
+
+	// Domain is the domain name of the VMs that do not specify
+	// spec.network.domainName.
+	//
+	// VMs that do not specify a domain name are not registered when this is
+	// empty.
+	//
+	// Defaults to empty.
+	Domain string
+
+	// TSIGSecretName is the name of the Secret in the pod's namespace that
+	// contains the TSIG key used to sign the updates. The Secret's "name"
+	// and "secret" keys contain the name and base64 encoded secret of the
+	// key, and its optional "algorithm" key contains the name of the HMAC
+	// algorithm, ex. "hmac-sha256".
+	//
+	// The updates are not signed when this is empty.
+	//
+	// Defaults to empty.
+	TSIGSecretName string
+
+	// TTL is the TTL of the registered records.
+	//
+	// Defaults to 5m.
+	TTL time.Duration
+}
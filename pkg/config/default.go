@@ -34,34 +34,49 @@ func Default() Config {
 			PVPlacementFailedTTL: 5 * time.Minute,
 			SeedRequeueDuration:  10 * time.Second,
 		},
-		LeaderElectionID:             defaultPrefix + "controller-manager-runtime",
-		MaxCreateVMsOnProvider:       80,
-		MaxConcurrentReconciles:      1,
-		AsyncSignalEnabled:           true,
-		AsyncCreateEnabled:           true,
-		MemStatsPeriod:               10 * time.Minute,
-		FastDeployMode:               pkgconst.FastDeployModeLinked,
-		VCCredsSecretName:            pkgconst.VCCredsSecretName,
-		CreateVMRequeueDelay:         10 * time.Second,
-		PoweredOnVMHasIPRequeueDelay: 10 * time.Second,
-		SyncImageRequeueDelay:        10 * time.Second,
-		NetworkProviderType:          NetworkProviderTypeNamed,
-		SIGUSR2RestartEnabled:        false,
-		DeploymentName:               defaultPrefix + "controller-manager",
-		PodName:                      defaultPrefix + "controller-manager",
-		PodNamespace:                 defaultPrefix + "system",
-		PodServiceAccountName:        defaultPrefix + "service-account",
-		ProfilerAddr:                 ":8073",
-		RateLimitBurst:               1000,
-		RateLimitQPS:                 500,
-		SyncPeriod:                   30 * time.Minute,
-		WatchNamespace:               "",
-		WebhookServiceContainerPort:  9878,
-		WebhookServiceName:           defaultPrefix + "webhook-service",
-		WebhookServiceNamespace:      defaultPrefix + "system",
-		WebhookSecretName:            defaultPrefix + "webhook-server-cert",
-		WebhookSecretNamespace:       defaultPrefix + "system",
-		WebhookSecretVolumeMountPath: "/etc/vmware/wcp/webhook-certs",
-		CRDCleanupEnabled:            false,
+		LeaderElectionID:               defaultPrefix + "controller-manager-runtime",
+		MaxCreateVMsOnProvider:         80,
+		MaxConcurrentReconciles:        1,
+		AsyncSignalEnabled:             true,
+		AsyncCreateEnabled:             true,
+		MemStatsPeriod:                 10 * time.Minute,
+		FastDeployMode:                 pkgconst.FastDeployModeLinked,
+		VCCredsSecretName:              pkgconst.VCCredsSecretName,
+		CreateVMRequeueDelay:           10 * time.Second,
+		PoweredOnVMHasIPRequeueDelay:   10 * time.Second,
+		SyncImageRequeueDelay:          10 * time.Second,
+		NetworkProviderType:            NetworkProviderTypeNamed,
+		SIGUSR2RestartEnabled:          false,
+		DeploymentName:                 defaultPrefix + "controller-manager",
+		PodName:                        defaultPrefix + "controller-manager",
+		PodNamespace:                   defaultPrefix + "system",
+		PodServiceAccountName:          defaultPrefix + "service-account",
+		ProfilerAddr:                   ":8073",
+		RateLimitBurst:                 1000,
+		RateLimitQPS:                   500,
+		SyncPeriod:                     30 * time.Minute,
+		WatchNamespace:                 "",
+		WebhookServiceContainerPort:    9878,
+		WebhookServiceName:             defaultPrefix + "webhook-service",
+		WebhookServiceNamespace:        defaultPrefix + "system",
+		WebhookSecretName:              defaultPrefix + "webhook-server-cert",
+		WebhookSecretNamespace:         defaultPrefix + "system",
+		WebhookSecretVolumeMountPath:   "/etc/vmware/wcp/webhook-certs",
+		CRDCleanupEnabled:              false,
+		StorageVersionMigrationEnabled: false,
+		Tracing: Tracing{
+			SamplingRatio: 1.0,
+		},
+		Rebalancer: Rebalancer{
+			Interval:            10 * time.Minute,
+			MaxMovesPerInterval: 5,
+		},
+		ImageCache: ImageCache{
+			EvictionGracePeriod: 24 * time.Hour,
+			EvictionInterval:    10 * time.Minute,
+		},
+		DynamicDNS: DynamicDNS{
+			TTL: 5 * time.Minute,
+		},
 	}
 }
@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/vmware-tanzu/vm-operator/pkg/config/env"
 )
 
@@ -34,6 +36,24 @@ func FromEnv() Config {
 	setString(env.FastDeployMode, &config.FastDeployMode)
 	setString(env.VCCredsSecretName, &config.VCCredsSecretName)
 	setBool(env.CRDCleanupEnabled, &config.CRDCleanupEnabled)
+	setBool(env.StorageVersionMigrationEnabled, &config.StorageVersionMigrationEnabled)
+	setString(env.TracingOTLPEndpoint, &config.Tracing.OTLPEndpoint)
+	setBool(env.TracingOTLPInsecure, &config.Tracing.OTLPInsecure)
+	setFloat64(env.TracingSamplingRatio, &config.Tracing.SamplingRatio)
+	setBool(env.RebalancerEnabled, &config.Rebalancer.Enabled)
+	setDuration(env.RebalancerInterval, &config.Rebalancer.Interval)
+	setInt(env.RebalancerMaxMovesPerInterval, &config.Rebalancer.MaxMovesPerInterval)
+	setString(env.ImageTrustRootsName, &config.ImageTrustRootsName)
+	setQuantity(env.ImageCacheDatastoreCapacityLimit, &config.ImageCache.DatastoreCapacityLimit)
+	setQuantity(env.ImageCacheProfileCapacityLimit, &config.ImageCache.ProfileCapacityLimit)
+	setDuration(env.ImageCacheEvictionGracePeriod, &config.ImageCache.EvictionGracePeriod)
+	setDuration(env.ImageCacheEvictionInterval, &config.ImageCache.EvictionInterval)
+	setString(env.DynamicDNSServer, &config.DynamicDNS.Server)
+	setStringSlice(env.DynamicDNSZones, &config.DynamicDNS.Zones)
+	setString(env.DynamicDNSDomain, &config.DynamicDNS.Domain)
+	setString(env.DynamicDNSTSIGSecretName, &config.DynamicDNS.TSIGSecretName)
+	setDuration(env.DynamicDNSTTL, &config.DynamicDNS.TTL)
+	setString(env.PublishStagingDir, &config.PublishStagingDir)
 
 	setDuration(env.InstanceStoragePVPlacementFailedTTL, &config.InstanceStorage.PVPlacementFailedTTL)
 	setFloat64(env.InstanceStorageJitterMaxFactor, &config.InstanceStorage.JitterMaxFactor)
@@ -117,6 +137,14 @@ func setNetworkProviderType(n env.VarName, p *NetworkProviderType) {
 	}
 }
 
+func setQuantity(n env.VarName, p *int64) {
+	if v := os.Getenv(n.String()); v != "" {
+		if v, err := resource.ParseQuantity(v); err == nil {
+			*p = v.Value()
+		}
+	}
+}
+
 func setString(n env.VarName, p *string) {
 	if v := os.Getenv(n.String()); v != "" {
 		*p = v
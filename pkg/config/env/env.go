@@ -51,6 +51,24 @@ const (
 	WebhookSecretName
 	WebhookSecretNamespace
 	CRDCleanupEnabled
+	StorageVersionMigrationEnabled
+	TracingOTLPEndpoint
+	TracingOTLPInsecure
+	TracingSamplingRatio
+	RebalancerEnabled
+	RebalancerInterval
+	RebalancerMaxMovesPerInterval
+	ImageTrustRootsName
+	ImageCacheDatastoreCapacityLimit
+	ImageCacheProfileCapacityLimit
+	ImageCacheEvictionGracePeriod
+	ImageCacheEvictionInterval
+	DynamicDNSServer
+	DynamicDNSZones
+	DynamicDNSDomain
+	DynamicDNSTSIGSecretName
+	DynamicDNSTTL
+	PublishStagingDir
 	FSSInstanceStorage
 	FSSK8sWorkloadMgmtAPI
 	FSSPodVMOnStretchedSupervisor
@@ -169,6 +187,42 @@ func (n VarName) String() string {
 		return "WEBHOOK_SECRET_NAMESPACE"
 	case CRDCleanupEnabled:
 		return "CRD_CLEANUP_ENABLED"
+	case StorageVersionMigrationEnabled:
+		return "STORAGE_VERSION_MIGRATION_ENABLED"
+	case TracingOTLPEndpoint:
+		return "TRACING_OTLP_ENDPOINT"
+	case TracingOTLPInsecure:
+		return "TRACING_OTLP_INSECURE"
+	case TracingSamplingRatio:
+		return "TRACING_SAMPLING_RATIO"
+	case RebalancerEnabled:
+		return "REBALANCER_ENABLED"
+	case RebalancerInterval:
+		return "REBALANCER_INTERVAL"
+	case RebalancerMaxMovesPerInterval:
+		return "REBALANCER_MAX_MOVES_PER_INTERVAL"
+	case ImageTrustRootsName:
+		return "IMAGE_TRUST_ROOTS_NAME"
+	case ImageCacheDatastoreCapacityLimit:
+		return "IMAGE_CACHE_DATASTORE_CAPACITY_LIMIT"
+	case ImageCacheProfileCapacityLimit:
+		return "IMAGE_CACHE_PROFILE_CAPACITY_LIMIT"
+	case ImageCacheEvictionGracePeriod:
+		return "IMAGE_CACHE_EVICTION_GRACE_PERIOD"
+	case ImageCacheEvictionInterval:
+		return "IMAGE_CACHE_EVICTION_INTERVAL"
+	case DynamicDNSServer:
+		return "DYNAMIC_DNS_SERVER"
+	case DynamicDNSZones:
+		return "DYNAMIC_DNS_ZONES"
+	case DynamicDNSDomain:
+		return "DYNAMIC_DNS_DOMAIN"
+	case DynamicDNSTSIGSecretName:
+		return "DYNAMIC_DNS_TSIG_SECRET_NAME"
+	case DynamicDNSTTL:
+		return "DYNAMIC_DNS_TTL"
+	case PublishStagingDir:
+		return "PUBLISH_STAGING_DIR"
 
 	//
 	// Features/Capabilities
@@ -111,6 +111,24 @@ var _ = Describe(
 					Expect(os.Setenv("DEPLOYMENT_NAME", "129")).To(Succeed())
 					Expect(os.Setenv("SIGUSR2_RESTART_ENABLED", "true")).To(Succeed())
 					Expect(os.Setenv("CRD_CLEANUP_ENABLED", "true")).To(Succeed())
+					Expect(os.Setenv("STORAGE_VERSION_MIGRATION_ENABLED", "true")).To(Succeed())
+					Expect(os.Setenv("TRACING_OTLP_ENDPOINT", "130")).To(Succeed())
+					Expect(os.Setenv("TRACING_OTLP_INSECURE", "true")).To(Succeed())
+					Expect(os.Setenv("TRACING_SAMPLING_RATIO", "0.131")).To(Succeed())
+					Expect(os.Setenv("REBALANCER_ENABLED", "true")).To(Succeed())
+					Expect(os.Setenv("REBALANCER_INTERVAL", "132h")).To(Succeed())
+					Expect(os.Setenv("REBALANCER_MAX_MOVES_PER_INTERVAL", "133")).To(Succeed())
+					Expect(os.Setenv("IMAGE_TRUST_ROOTS_NAME", "134")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_DATASTORE_CAPACITY_LIMIT", "135Gi")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_PROFILE_CAPACITY_LIMIT", "136")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_EVICTION_GRACE_PERIOD", "137h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_EVICTION_INTERVAL", "138h")).To(Succeed())
+					Expect(os.Setenv("DYNAMIC_DNS_SERVER", "139")).To(Succeed())
+					Expect(os.Setenv("DYNAMIC_DNS_ZONES", "140,141")).To(Succeed())
+					Expect(os.Setenv("DYNAMIC_DNS_DOMAIN", "142")).To(Succeed())
+					Expect(os.Setenv("DYNAMIC_DNS_TSIG_SECRET_NAME", "143")).To(Succeed())
+					Expect(os.Setenv("DYNAMIC_DNS_TTL", "144h")).To(Succeed())
+					Expect(os.Setenv("PUBLISH_STAGING_DIR", "145")).To(Succeed())
 				})
 				It("Should return a default config overridden by the environment", func() {
 					Expect(config).To(BeComparableTo(pkgcfg.Config{
@@ -127,28 +145,54 @@ var _ = Describe(
 							JitterMaxFactor:      108.0,
 							SeedRequeueDuration:  109 * time.Hour,
 						},
-						ContainerNode:                true,
-						ProfilerAddr:                 "110",
-						RateLimitQPS:                 111,
-						RateLimitBurst:               112,
-						SyncPeriod:                   113 * time.Hour,
-						MaxConcurrentReconciles:      114,
-						AsyncSignalEnabled:           false,
-						AsyncCreateEnabled:           false,
-						FastDeployMode:               pkgconst.FastDeployModeDirect,
-						VCCredsSecretName:            pkgconst.VCCredsSecretName,
-						LeaderElectionID:             "115",
-						PodName:                      "116",
-						PodNamespace:                 "117",
-						PodServiceAccountName:        "118",
-						WatchNamespace:               "119",
-						WebhookServiceContainerPort:  120,
-						WebhookServiceName:           "121",
-						WebhookServiceNamespace:      "122",
-						WebhookSecretName:            "123",
-						WebhookSecretNamespace:       "124",
-						WebhookSecretVolumeMountPath: pkgcfg.Default().WebhookSecretVolumeMountPath,
-						CRDCleanupEnabled:            true,
+						ContainerNode:                  true,
+						ProfilerAddr:                   "110",
+						RateLimitQPS:                   111,
+						RateLimitBurst:                 112,
+						SyncPeriod:                     113 * time.Hour,
+						MaxConcurrentReconciles:        114,
+						AsyncSignalEnabled:             false,
+						AsyncCreateEnabled:             false,
+						FastDeployMode:                 pkgconst.FastDeployModeDirect,
+						VCCredsSecretName:              pkgconst.VCCredsSecretName,
+						LeaderElectionID:               "115",
+						PodName:                        "116",
+						PodNamespace:                   "117",
+						PodServiceAccountName:          "118",
+						WatchNamespace:                 "119",
+						WebhookServiceContainerPort:    120,
+						WebhookServiceName:             "121",
+						WebhookServiceNamespace:        "122",
+						WebhookSecretName:              "123",
+						WebhookSecretNamespace:         "124",
+						WebhookSecretVolumeMountPath:   pkgcfg.Default().WebhookSecretVolumeMountPath,
+						CRDCleanupEnabled:              true,
+						StorageVersionMigrationEnabled: true,
+						Tracing: pkgcfg.Tracing{
+							OTLPEndpoint:  "130",
+							OTLPInsecure:  true,
+							SamplingRatio: 0.131,
+						},
+						Rebalancer: pkgcfg.Rebalancer{
+							Enabled:             true,
+							Interval:            132 * time.Hour,
+							MaxMovesPerInterval: 133,
+						},
+						ImageTrustRootsName: "134",
+						ImageCache: pkgcfg.ImageCache{
+							DatastoreCapacityLimit: 135 * 1024 * 1024 * 1024,
+							ProfileCapacityLimit:   136,
+							EvictionGracePeriod:    137 * time.Hour,
+							EvictionInterval:       138 * time.Hour,
+						},
+						DynamicDNS: pkgcfg.DynamicDNS{
+							Server:         "139",
+							Zones:          "140,141",
+							Domain:         "142",
+							TSIGSecretName: "143",
+							TTL:            144 * time.Hour,
+						},
+						PublishStagingDir: "145",
 						Features: pkgcfg.FeatureStates{
 							InstanceStorage:           false,
 							K8sWorkloadMgmtAPI:        true,
@@ -163,6 +163,10 @@ const (
 	// scheduled from its parent group.
 	ApplyPowerStateTimeAnnotation = "vmoperator.vmware.com.protected/apply-power-state-time"
 
+	// DynamicDNSRecordsAnnotation is the annotation key for the host name and
+	// addresses with which a VM is registered in DNS.
+	DynamicDNSRecordsAnnotation = "vmoperator.vmware.com.protected/dynamic-dns-records"
+
 	// VirtualMachineClassHashAnnotationKey is the annotation key for the VM Class hash
 	// used to generate VirtualMachineClassInstances.
 	VirtualMachineClassHashAnnotationKey = "vmoperator.vmware.com/vmclass-hash"
@@ -0,0 +1,33 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// VirtualMachineGuestOperationContext is the context used for the
+// VirtualMachineGuestOperation controller.
+type VirtualMachineGuestOperationContext struct {
+	context.Context
+	Logger         logr.Logger
+	GuestOperation *vmopv1.VirtualMachineGuestOperation
+	// SkipPatch indicates whether we should skip patching the object after
+	// reconcile because its status was already updated when the operation
+	// was started.
+	SkipPatch bool
+}
+
+func (v VirtualMachineGuestOperationContext) String() string {
+	return fmt.Sprintf("%s %s/%s",
+		v.GuestOperation.GroupVersionKind(),
+		v.GuestOperation.Namespace,
+		v.GuestOperation.Name)
+}
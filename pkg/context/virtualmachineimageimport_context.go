@@ -0,0 +1,29 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// VirtualMachineImageImportContext is the context used for the
+// VirtualMachineImageImport controller.
+type VirtualMachineImageImportContext struct {
+	context.Context
+	Logger      logr.Logger
+	ImageImport *vmopv1.VirtualMachineImageImport
+}
+
+func (v VirtualMachineImageImportContext) String() string {
+	return fmt.Sprintf("%s %s/%s",
+		v.ImageImport.GroupVersionKind(),
+		v.ImageImport.Namespace,
+		v.ImageImport.Name)
+}
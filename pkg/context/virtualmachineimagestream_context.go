@@ -0,0 +1,29 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// VirtualMachineImageStreamContext is the context used for the
+// VirtualMachineImageStream controller.
+type VirtualMachineImageStreamContext struct {
+	context.Context
+	Logger      logr.Logger
+	ImageStream *vmopv1.VirtualMachineImageStream
+}
+
+func (v VirtualMachineImageStreamContext) String() string {
+	return fmt.Sprintf("%s %s/%s",
+		v.ImageStream.GroupVersionKind(),
+		v.ImageStream.Namespace,
+		v.ImageStream.Name)
+}
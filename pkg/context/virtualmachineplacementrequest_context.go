@@ -0,0 +1,29 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// VirtualMachinePlacementRequestContext is the context used for the
+// VirtualMachinePlacementRequest controller.
+type VirtualMachinePlacementRequestContext struct {
+	context.Context
+	Logger           logr.Logger
+	PlacementRequest *vmopv1.VirtualMachinePlacementRequest
+}
+
+func (v VirtualMachinePlacementRequestContext) String() string {
+	return fmt.Sprintf("%s %s/%s",
+		v.PlacementRequest.GroupVersionKind(),
+		v.PlacementRequest.Namespace,
+		v.PlacementRequest.Name)
+}
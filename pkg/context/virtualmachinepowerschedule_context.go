@@ -0,0 +1,29 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// VirtualMachinePowerScheduleContext is the context used for the
+// VirtualMachinePowerSchedule controller.
+type VirtualMachinePowerScheduleContext struct {
+	context.Context
+	Logger        logr.Logger
+	PowerSchedule *vmopv1.VirtualMachinePowerSchedule
+}
+
+func (v VirtualMachinePowerScheduleContext) String() string {
+	return fmt.Sprintf("%s %s/%s",
+		v.PowerSchedule.GroupVersionKind(),
+		v.PowerSchedule.Namespace,
+		v.PowerSchedule.Name)
+}
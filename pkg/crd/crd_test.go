@@ -29,9 +29,19 @@ var (
 		"contentlibraryproviders.vmoperator.vmware.com",
 		"contentsourcebindings.vmoperator.vmware.com",
 		"contentsources.vmoperator.vmware.com",
+		"virtualmachineadmissionpolicies.vmoperator.vmware.com",
 		"virtualmachineclassbindings.vmoperator.vmware.com",
 		"virtualmachineclasses.vmoperator.vmware.com",
+		"virtualmachinedisruptionbudgets.vmoperator.vmware.com",
+		"virtualmachineguestoperations.vmoperator.vmware.com",
+		"virtualmachineimageimports.vmoperator.vmware.com",
 		"virtualmachineimages.vmoperator.vmware.com",
+		"virtualmachineimagestreams.vmoperator.vmware.com",
+		"virtualmachineippools.vmoperator.vmware.com",
+		"virtualmachinenetworkpolicies.vmoperator.vmware.com",
+		"virtualmachineplacementrequests.vmoperator.vmware.com",
+		"virtualmachinepowerschedules.vmoperator.vmware.com",
+		"virtualmachineprofiles.vmoperator.vmware.com",
 		"virtualmachinepublishrequests.vmoperator.vmware.com",
 		"virtualmachinereplicasets.vmoperator.vmware.com",
 		"virtualmachines.vmoperator.vmware.com",
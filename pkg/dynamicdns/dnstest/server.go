@@ -0,0 +1,342 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dnstest provides an in-process DNS server that applies RFC 2136
+// dynamic updates, for use in tests.
+package dnstest
+
+import (
+	"encoding/base64"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/dynamicdns"
+)
+
+// Server is a DNS server that applies dynamic updates to the records of its
+// zones. It is only as strict as the tests require.
+type Server struct {
+	// Addr is the host:port on which the server listens.
+	Addr string
+
+	key    *dynamicdns.TSIGKey
+	zones  []string
+	server *dns.Server
+
+	mu      sync.Mutex
+	records map[string]map[uint16][]string
+	rcode   int
+	updates int
+}
+
+// NewServer starts a server on the loopback interface that is authoritative
+// for the provided zones. The updates must be signed with the provided key if
+// it is not nil.
+func NewServer(key *dynamicdns.TSIGKey, zones ...string) (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		Addr:    ln.Addr().String(),
+		key:     key,
+		records: map[string]map[uint16][]string{},
+	}
+	for _, z := range zones {
+		s.zones = append(s.zones, strings.ToLower(dynamicdns.Fqdn(z)))
+	}
+
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          ln,
+		Net:               "tcp",
+		Handler:           dns.HandlerFunc(s.handle),
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		NotifyStartedFunc: func() { close(started) },
+		// The default function rejects updates.
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction {
+			return dns.MsgAccept
+		},
+	}
+	if key != nil {
+		s.server.TsigSecret = map[string]string{
+			key.Name: base64.StdEncoding.EncodeToString(key.Secret),
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.server.ActivateAndServe()
+	}()
+
+	select {
+	case <-started:
+		return s, nil
+	case err := <-errCh:
+		return nil, err
+	}
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	_ = s.server.Shutdown()
+}
+
+// SetRCode causes the server to refuse the subsequent updates with the
+// provided response code. The updates are applied again once the code is
+// dns.RcodeSuccess.
+func (s *Server) SetRCode(rcode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rcode = rcode
+}
+
+// Updates returns the number of updates the server has applied.
+func (s *Server) Updates() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.updates
+}
+
+// Lookup returns the data of the records with the provided name and type.
+func (s *Server) Lookup(name string, typ uint16) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records[strings.ToLower(dynamicdns.Fqdn(name))][typ])
+}
+
+// Records returns all of the server's records, sorted by name, type, and
+// data.
+func (s *Server) Records() []dynamicdns.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []dynamicdns.Record
+	for name, types := range s.records {
+		for typ, data := range types {
+			for _, d := range data {
+				records = append(records, dynamicdns.Record{Name: name, Type: typ, Data: d})
+			}
+		}
+	}
+	slices.SortFunc(records, func(a, b dynamicdns.Record) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if a.Type != b.Type {
+			return int(a.Type) - int(b.Type)
+		}
+		return strings.Compare(a.Data, b.Data)
+	})
+	return records
+}
+
+// AddRecords adds records to the server, ex. to simulate records that were
+// not registered by the client under test.
+func (s *Server) AddRecords(records ...dynamicdns.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		name := strings.ToLower(dynamicdns.Fqdn(r.Name))
+		if s.records[name] == nil {
+			s.records[name] = map[uint16][]string{}
+		}
+		s.records[name][r.Type] = append(s.records[name][r.Type], r.Data)
+	}
+}
+
+func (s *Server) handle(w dns.ResponseWriter, req *dns.Msg) {
+	resp := &dns.Msg{}
+	resp.SetReply(req)
+
+	if s.key != nil && (req.IsTsig() == nil || w.TsigStatus() != nil) {
+		// A response to an update that could not be authenticated is not
+		// signed.
+		resp.Rcode = dns.RcodeNotAuth
+		_ = w.WriteMsg(resp)
+		return
+	}
+
+	if req.Opcode != dns.OpcodeUpdate {
+		resp.Rcode = dns.RcodeNotImplemented
+	} else {
+		resp.Rcode = s.update(req)
+	}
+
+	if s.key != nil {
+		// The response is signed when it is written.
+		resp.SetTsig(s.key.Name, s.key.Algorithm, 300, time.Now().Unix())
+	}
+	_ = w.WriteMsg(resp)
+}
+
+// update applies the update section of the message if all of it is valid and
+// all of the prerequisites are met.
+func (s *Server) update(req *dns.Msg) int {
+	if len(req.Question) != 1 || req.Question[0].Qtype != dns.TypeSOA {
+		return dns.RcodeFormatError
+	}
+	zone := strings.ToLower(req.Question[0].Name)
+	if !slices.Contains(s.zones, zone) {
+		return dns.RcodeNotAuth
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rcode != dns.RcodeSuccess {
+		return s.rcode
+	}
+
+	if rcode := s.checkPrerequisites(zone, req.Answer); rcode != dns.RcodeSuccess {
+		return rcode
+	}
+
+	records := map[string]map[uint16][]string{}
+	for name, types := range s.records {
+		records[name] = map[uint16][]string{}
+		for typ, data := range types {
+			records[name][typ] = slices.Clone(data)
+		}
+	}
+
+	for _, rr := range req.Ns {
+		h := rr.Header()
+		name := strings.ToLower(h.Name)
+		if dynamicdns.FindZone(name, []string{zone}) == "" {
+			return dns.RcodeNotZone
+		}
+
+		if h.Class == dns.ClassANY {
+			if h.Rrtype == dns.TypeANY {
+				delete(records, name)
+			} else if records[name] != nil {
+				delete(records[name], h.Rrtype)
+			}
+			continue
+		}
+
+		data, ok := getData(rr)
+		if !ok {
+			return dns.RcodeNotImplemented
+		}
+
+		switch h.Class {
+		case dns.ClassINET:
+			if records[name] == nil {
+				records[name] = map[uint16][]string{}
+			}
+			if !slices.Contains(records[name][h.Rrtype], data) {
+				records[name][h.Rrtype] = append(records[name][h.Rrtype], data)
+			}
+		case dns.ClassNONE:
+			if records[name] != nil {
+				records[name][h.Rrtype] = slices.DeleteFunc(records[name][h.Rrtype], func(d string) bool {
+					return d == data
+				})
+			}
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+
+	for name, types := range records {
+		for typ, data := range types {
+			if len(data) == 0 {
+				delete(types, typ)
+			}
+		}
+		if len(types) == 0 {
+			delete(records, name)
+		}
+	}
+
+	s.records = records
+	s.updates++
+
+	return dns.RcodeSuccess
+}
+
+// checkPrerequisites checks the prerequisites of an update as described by
+// RFC 2136, section 3.2.
+func (s *Server) checkPrerequisites(zone string, prereqs []dns.RR) int {
+	type rrset struct {
+		name string
+		typ  uint16
+	}
+	exists := map[rrset][]string{}
+
+	for _, rr := range prereqs {
+		h := rr.Header()
+		name := strings.ToLower(h.Name)
+		if dynamicdns.FindZone(name, []string{zone}) == "" {
+			return dns.RcodeNotZone
+		}
+
+		switch h.Class {
+		case dns.ClassANY:
+			if h.Rrtype == dns.TypeANY {
+				if len(s.records[name]) == 0 {
+					return dns.RcodeNameError
+				}
+			} else if len(s.records[name][h.Rrtype]) == 0 {
+				return dns.RcodeNXRrset
+			}
+		case dns.ClassNONE:
+			if h.Rrtype == dns.TypeANY {
+				if len(s.records[name]) > 0 {
+					return dns.RcodeYXDomain
+				}
+			} else if len(s.records[name][h.Rrtype]) > 0 {
+				return dns.RcodeYXRrset
+			}
+		case dns.ClassINET:
+			data, ok := getData(rr)
+			if !ok {
+				return dns.RcodeNotImplemented
+			}
+			k := rrset{name: name, typ: h.Rrtype}
+			exists[k] = append(exists[k], data)
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+
+	// The RRsets must exactly match the value dependent prerequisites.
+	for k, data := range exists {
+		cur := s.records[k.name][k.typ]
+		if len(cur) != len(data) {
+			return dns.RcodeNXRrset
+		}
+		for _, d := range data {
+			if !slices.Contains(cur, d) {
+				return dns.RcodeNXRrset
+			}
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+func getData(rr dns.RR) (string, bool) {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String(), true
+	case *dns.AAAA:
+		return rr.AAAA.String(), true
+	case *dns.PTR:
+		return strings.ToLower(rr.Ptr), true
+	case *dns.TXT:
+		return strings.Join(rr.Txt, ""), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,336 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dynamicdns registers DNS records with RFC 2136 dynamic updates that
+// are authenticated with RFC 8945 transaction signatures (TSIG).
+package dynamicdns
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	defaultTTL     = 5 * time.Minute
+)
+
+// Record is a DNS resource record.
+type Record struct {
+	// Name is the fully qualified name of the record.
+	Name string
+
+	// Type is the type of the record, ex. dns.TypeA. Only A, AAAA, PTR, and
+	// TXT records are supported.
+	Type uint16
+
+	// Data is the IP address of an A or AAAA record, the fully qualified name
+	// to which a PTR record points, or the text of a TXT record.
+	Data string
+}
+
+func (r Record) String() string {
+	return fmt.Sprintf("%s %s %s", r.Name, dns.Type(r.Type), r.Data)
+}
+
+// Update is an RFC 2136 dynamic update of the records in a zone. The update
+// is only applied if all of its prerequisites are met, in which case all of
+// its changes are applied atomically.
+type Update struct {
+	// Zone is the zone that contains the records.
+	Zone string
+
+	// Exists are the records that must exist for the update to be applied.
+	// The RRset with the name and type of each record must contain exactly
+	// the provided records.
+	Exists []Record
+
+	// NotExists are the names and types of the RRsets that must not exist for
+	// the update to be applied. The Data of the records is ignored.
+	NotExists []Record
+
+	// DeleteRRsets are the names and types of the RRsets that are deleted.
+	// The Data of the records is ignored.
+	DeleteRRsets []Record
+
+	// Deletes are the records that are deleted.
+	Deletes []Record
+
+	// Adds are the records that are added.
+	Adds []Record
+}
+
+// Client sends dynamic updates to a DNS server over TCP.
+type Client struct {
+	// Server is the host:port of the DNS server.
+	Server string
+
+	// Key is the TSIG key used to sign the updates. The updates are not
+	// signed when this is nil.
+	Key *TSIGKey
+
+	// TTL is the TTL of the added records. Defaults to 5m.
+	TTL time.Duration
+
+	// Timeout is the maximum amount of time to wait for the server to apply
+	// an update. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// Update sends the update to the server. An UpdateError is returned if the
+// server refuses the update, ex. because one of its prerequisites is not met.
+func (c Client) Update(ctx context.Context, u Update) error {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	msg, err := c.newUpdateMessage(u)
+	if err != nil {
+		return err
+	}
+
+	dnsClient := &dns.Client{
+		Net:     "tcp",
+		Timeout: timeout,
+	}
+	if c.Key != nil {
+		dnsClient.TsigSecret = map[string]string{
+			c.Key.Name: base64.StdEncoding.EncodeToString(c.Key.Secret),
+		}
+		msg.SetTsig(c.Key.Name, c.Key.algorithm(), tsigFudge, time.Now().Unix())
+	}
+
+	conn, err := dnsClient.DialContext(ctx, c.Server)
+	if err != nil {
+		return fmt.Errorf("failed to connect to DNS server %s: %w", c.Server, err)
+	}
+	defer conn.Close()
+
+	// The signature of a signed response is verified when it is read.
+	resp, _, err := dnsClient.ExchangeWithConnContext(ctx, msg, conn)
+	if errors.Is(err, dns.ErrAuth) {
+		// The signature of a NOTAUTH response is not verified.
+		return &UpdateError{RCode: dns.RcodeNotAuth}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send update to DNS server %s: %w", c.Server, err)
+	}
+
+	// The server cannot sign a response to an update it could not
+	// authenticate, so the response code is checked before the signature.
+	if resp.Rcode != dns.RcodeSuccess {
+		return &UpdateError{RCode: resp.Rcode}
+	}
+	if c.Key != nil && resp.IsTsig() == nil {
+		return fmt.Errorf("invalid response from DNS server %s: %w", c.Server, ErrUnsigned)
+	}
+
+	return nil
+}
+
+func (c Client) newUpdateMessage(u Update) (*dns.Msg, error) {
+	if _, ok := dns.IsDomainName(u.Zone); !ok {
+		return nil, fmt.Errorf("invalid DNS name %q", u.Zone)
+	}
+
+	ttl := c.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	exists, err := newRRs(u.Exists, 0)
+	if err != nil {
+		return nil, err
+	}
+	notExists, err := newEmptyRRs(u.NotExists)
+	if err != nil {
+		return nil, err
+	}
+	deleteRRsets, err := newEmptyRRs(u.DeleteRRsets)
+	if err != nil {
+		return nil, err
+	}
+	deletes, err := newRRs(u.Deletes, 0)
+	if err != nil {
+		return nil, err
+	}
+	adds, err := newRRs(u.Adds, uint32(ttl.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &dns.Msg{}
+	msg.SetUpdate(Fqdn(u.Zone))
+	msg.Used(exists)
+	msg.RRsetNotUsed(notExists)
+	msg.RemoveRRset(deleteRRsets)
+	msg.Remove(deletes)
+	msg.Insert(adds)
+
+	return msg, nil
+}
+
+func newRRs(records []Record, ttl uint32) ([]dns.RR, error) {
+	rrs := make([]dns.RR, 0, len(records))
+	for _, r := range records {
+		rr, err := newRR(r, ttl)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// newEmptyRRs returns the records without their data, which is how RRsets are
+// identified in the prerequisites and deletes of an update.
+func newEmptyRRs(records []Record) ([]dns.RR, error) {
+	rrs := make([]dns.RR, 0, len(records))
+	for _, r := range records {
+		name, err := newName(r.Name)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, &dns.ANY{Hdr: dns.RR_Header{Name: name, Rrtype: r.Type}})
+	}
+	return rrs, nil
+}
+
+func newRR(r Record, ttl uint32) (dns.RR, error) {
+	name, err := newName(r.Name)
+	if err != nil {
+		return nil, err
+	}
+	h := dns.RR_Header{
+		Name:   name,
+		Rrtype: r.Type,
+		Class:  dns.ClassINET,
+		Ttl:    ttl,
+	}
+
+	switch r.Type {
+	case dns.TypeA, dns.TypeAAAA:
+		addr, err := netip.ParseAddr(r.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s record %q: %w", dns.Type(r.Type), r.Data, err)
+		}
+		if r.Type == dns.TypeA {
+			if !addr.Is4() {
+				return nil, fmt.Errorf("invalid A record %q: not an IPv4 address", r.Data)
+			}
+			return &dns.A{Hdr: h, A: addr.AsSlice()}, nil
+		}
+		if !addr.Is6() || addr.Is4In6() {
+			return nil, fmt.Errorf("invalid AAAA record %q: not an IPv6 address", r.Data)
+		}
+		return &dns.AAAA{Hdr: h, AAAA: addr.AsSlice()}, nil
+
+	case dns.TypePTR:
+		ptr, err := newName(r.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &dns.PTR{Hdr: h, Ptr: ptr}, nil
+
+	case dns.TypeTXT:
+		// The text of a TXT record is split into strings of at most 255
+		// bytes.
+		var txt []string
+		for s := r.Data; s != ""; {
+			n := min(len(s), 255)
+			txt = append(txt, s[:n])
+			s = s[n:]
+		}
+		return &dns.TXT{Hdr: h, Txt: txt}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", dns.Type(r.Type))
+	}
+}
+
+// UpdateError is returned when the DNS server refuses an update.
+type UpdateError struct {
+	// RCode is the response code of the server, ex. dns.RcodeNXRrset.
+	RCode int
+}
+
+func (e *UpdateError) Error() string {
+	name, ok := dns.RcodeToString[e.RCode]
+	if !ok {
+		name = fmt.Sprintf("RCODE%d", e.RCode)
+	}
+	return fmt.Sprintf("DNS server refused the update: %s", name)
+}
+
+// IsRCode returns true if err is an UpdateError with the provided response
+// code.
+func IsRCode(err error, rcode int) bool {
+	var updateErr *UpdateError
+	return errors.As(err, &updateErr) && updateErr.RCode == rcode
+}
+
+// ReverseName returns the fully qualified name of the PTR record of the
+// provided IP address in the in-addr.arpa or ip6.arpa domain.
+func ReverseName(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	addr = addr.Unmap()
+
+	var sb strings.Builder
+	if addr.Is4() {
+		b := addr.As4()
+		for i := len(b) - 1; i >= 0; i-- {
+			fmt.Fprintf(&sb, "%d.", b[i])
+		}
+		sb.WriteString("in-addr.arpa.")
+		return sb.String(), nil
+	}
+
+	b := addr.As16()
+	for i := len(b) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, "%x.%x.", b[i]&0xf, b[i]>>4)
+	}
+	sb.WriteString("ip6.arpa.")
+	return sb.String(), nil
+}
+
+// Fqdn returns the provided name with a trailing dot.
+func Fqdn(name string) string {
+	return dns.Fqdn(name)
+}
+
+// FindZone returns the longest of the provided zones that contains the
+// provided name, or an empty string if none of them do.
+func FindZone(name string, zones []string) string {
+	name = strings.ToLower(Fqdn(name))
+
+	var match string
+	for _, z := range zones {
+		z = strings.ToLower(Fqdn(z))
+		if (name == z || strings.HasSuffix(name, "."+z) || z == ".") && len(z) > len(match) {
+			match = z
+		}
+	}
+	return match
+}
+
+func newName(name string) (string, error) {
+	name = Fqdn(name)
+	if _, ok := dns.IsDomainName(name); !ok {
+		return "", fmt.Errorf("invalid DNS name %q", name)
+	}
+	return name, nil
+}
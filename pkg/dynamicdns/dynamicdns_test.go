@@ -0,0 +1,316 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/miekg/dns"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/dynamicdns"
+	"github.com/vmware-tanzu/vm-operator/pkg/dynamicdns/dnstest"
+)
+
+var secret = base64.StdEncoding.EncodeToString([]byte("my-tsig-key-secret"))
+
+func newKey(name, algorithm string) dynamicdns.TSIGKey {
+	key, err := dynamicdns.ParseTSIGKey(name, algorithm, secret)
+	Expect(err).ToNot(HaveOccurred())
+	return key
+}
+
+var _ = Describe("ReverseName", func() {
+	DescribeTable("returns the name of the PTR record",
+		func(ip, expected string) {
+			name, err := dynamicdns.ReverseName(ip)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(Equal(expected))
+		},
+		Entry("IPv4", "192.168.1.10", "10.1.168.192.in-addr.arpa."),
+		Entry("IPv4-mapped IPv6", "::ffff:192.168.1.10", "10.1.168.192.in-addr.arpa."),
+		Entry("IPv6", "2001:db8::567:89ab",
+			"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."),
+	)
+
+	It("returns an error for an invalid address", func() {
+		_, err := dynamicdns.ReverseName("not-an-ip")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("FindZone", func() {
+	zones := []string{"example.com", "dev.example.com.", "168.192.in-addr.arpa"}
+
+	DescribeTable("returns the longest zone that contains the name",
+		func(name, expected string) {
+			Expect(dynamicdns.FindZone(name, zones)).To(Equal(expected))
+		},
+		Entry("name in zone", "vm.example.com.", "example.com."),
+		Entry("name in sub-zone", "VM.Dev.Example.com", "dev.example.com."),
+		Entry("zone apex", "example.com", "example.com."),
+		Entry("reverse name", "10.1.168.192.in-addr.arpa.", "168.192.in-addr.arpa."),
+		Entry("name not in a zone", "vm.example.org.", ""),
+		Entry("name that only ends with a zone", "vm.myexample.com.", ""),
+	)
+})
+
+var _ = Describe("ParseTSIGKey", func() {
+	It("defaults the algorithm to HMAC-SHA256", func() {
+		key := newKey("My-Key", "")
+		Expect(key.Name).To(Equal("my-key."))
+		Expect(key.Algorithm).To(Equal(dynamicdns.HMACSHA256))
+		Expect(key.Secret).To(Equal([]byte("my-tsig-key-secret")))
+	})
+
+	It("returns an error for an invalid key", func() {
+		_, err := dynamicdns.ParseTSIGKey("", "", secret)
+		Expect(err).To(MatchError("TSIG key name is empty"))
+
+		_, err = dynamicdns.ParseTSIGKey("my-key", "hmac-md5", secret)
+		Expect(err).To(MatchError(`unsupported TSIG algorithm "hmac-md5."`))
+
+		_, err = dynamicdns.ParseTSIGKey("my-key", "", "not base64")
+		Expect(err).To(MatchError(ContainSubstring("invalid TSIG key secret")))
+	})
+})
+
+var _ = Describe("Client", func() {
+	var (
+		ctx       context.Context
+		key       dynamicdns.TSIGKey
+		server    *dnstest.Server
+		client    dynamicdns.Client
+		serverKey *dynamicdns.TSIGKey
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		key = newKey("my-key", "")
+		serverKey = &key
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		server, err = dnstest.NewServer(serverKey, "example.com", "168.192.in-addr.arpa")
+		Expect(err).ToNot(HaveOccurred())
+		client = dynamicdns.Client{
+			Server: server.Addr,
+			Key:    &key,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	vmA := dynamicdns.Record{Name: "vm.example.com", Type: dns.TypeA, Data: "192.168.1.10"}
+
+	It("adds and replaces records", func() {
+		Expect(client.Update(ctx, dynamicdns.Update{
+			Zone: "example.com",
+			Adds: []dynamicdns.Record{
+				vmA,
+				{Name: "vm.example.com", Type: dns.TypeAAAA, Data: "2001:db8::10"},
+				{Name: "vm.example.com", Type: dns.TypeTXT, Data: "owner"},
+			},
+		})).To(Succeed())
+		Expect(client.Update(ctx, dynamicdns.Update{
+			Zone: "168.192.in-addr.arpa",
+			Adds: []dynamicdns.Record{
+				{Name: "10.1.168.192.in-addr.arpa", Type: dns.TypePTR, Data: "vm.example.com"},
+			},
+		})).To(Succeed())
+
+		Expect(server.Records()).To(Equal([]dynamicdns.Record{
+			{Name: "10.1.168.192.in-addr.arpa.", Type: dns.TypePTR, Data: "vm.example.com."},
+			{Name: "vm.example.com.", Type: dns.TypeA, Data: "192.168.1.10"},
+			{Name: "vm.example.com.", Type: dns.TypeTXT, Data: "owner"},
+			{Name: "vm.example.com.", Type: dns.TypeAAAA, Data: "2001:db8::10"},
+		}))
+
+		Expect(client.Update(ctx, dynamicdns.Update{
+			Zone: "example.com",
+			DeleteRRsets: []dynamicdns.Record{
+				{Name: "vm.example.com", Type: dns.TypeA},
+				{Name: "vm.example.com", Type: dns.TypeAAAA},
+			},
+			Deletes: []dynamicdns.Record{
+				{Name: "vm.example.com", Type: dns.TypeTXT, Data: "owner"},
+			},
+			Adds: []dynamicdns.Record{
+				{Name: "vm.example.com", Type: dns.TypeA, Data: "192.168.1.11"},
+			},
+		})).To(Succeed())
+
+		Expect(server.Lookup("vm.example.com", dns.TypeA)).To(Equal([]string{"192.168.1.11"}))
+		Expect(server.Lookup("vm.example.com", dns.TypeAAAA)).To(BeEmpty())
+		Expect(server.Lookup("vm.example.com", dns.TypeTXT)).To(BeEmpty())
+		Expect(server.Updates()).To(Equal(3))
+	})
+
+	DescribeTable("signs the updates with the key",
+		func(algorithm string) {
+			key = newKey("my-key", algorithm)
+			Expect(client.Update(ctx, dynamicdns.Update{
+				Zone: "example.com",
+				Adds: []dynamicdns.Record{vmA},
+			})).To(Succeed())
+			Expect(server.Lookup("vm.example.com", dns.TypeA)).To(Equal([]string{"192.168.1.10"}))
+		},
+		Entry("HMAC-SHA1", "hmac-sha1"),
+		Entry("HMAC-SHA224", "hmac-sha224"),
+		Entry("HMAC-SHA256", "hmac-sha256"),
+		Entry("HMAC-SHA384", "hmac-sha384"),
+		Entry("HMAC-SHA512", "hmac-sha512"),
+	)
+
+	Context("prerequisites", func() {
+		JustBeforeEach(func() {
+			server.AddRecords(
+				vmA,
+				dynamicdns.Record{Name: "vm.example.com", Type: dns.TypeTXT, Data: "owner"},
+			)
+		})
+
+		It("applies the update when the records exist", func() {
+			Expect(client.Update(ctx, dynamicdns.Update{
+				Zone:    "example.com",
+				Exists:  []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeTXT, Data: "owner"}},
+				Deletes: []dynamicdns.Record{vmA},
+			})).To(Succeed())
+			Expect(server.Lookup("vm.example.com", dns.TypeA)).To(BeEmpty())
+		})
+
+		It("refuses the update when the records do not exist", func() {
+			err := client.Update(ctx, dynamicdns.Update{
+				Zone:    "example.com",
+				Exists:  []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeTXT, Data: "other"}},
+				Deletes: []dynamicdns.Record{vmA},
+			})
+			Expect(err).To(MatchError("DNS server refused the update: NXRRSET"))
+			Expect(dynamicdns.IsRCode(err, dns.RcodeNXRrset)).To(BeTrue())
+			Expect(server.Lookup("vm.example.com", dns.TypeA)).To(Equal([]string{"192.168.1.10"}))
+		})
+
+		It("refuses the update when the RRsets exist", func() {
+			err := client.Update(ctx, dynamicdns.Update{
+				Zone:      "example.com",
+				NotExists: []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeA}},
+				Adds:      []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeA, Data: "192.168.1.11"}},
+			})
+			Expect(err).To(MatchError("DNS server refused the update: YXRRSET"))
+			Expect(dynamicdns.IsRCode(err, dns.RcodeYXRrset)).To(BeTrue())
+			Expect(server.Lookup("vm.example.com", dns.TypeA)).To(Equal([]string{"192.168.1.10"}))
+		})
+
+		It("applies the update when the RRsets do not exist", func() {
+			Expect(client.Update(ctx, dynamicdns.Update{
+				Zone:      "example.com",
+				NotExists: []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeAAAA}},
+				Adds:      []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeAAAA, Data: "2001:db8::10"}},
+			})).To(Succeed())
+			Expect(server.Lookup("vm.example.com", dns.TypeAAAA)).To(Equal([]string{"2001:db8::10"}))
+		})
+	})
+
+	It("returns an error when the server refuses the update", func() {
+		server.SetRCode(dns.RcodeRefused)
+		err := client.Update(ctx, dynamicdns.Update{
+			Zone: "example.com",
+			Adds: []dynamicdns.Record{vmA},
+		})
+		var updateErr *dynamicdns.UpdateError
+		Expect(errors.As(err, &updateErr)).To(BeTrue())
+		Expect(updateErr.RCode).To(Equal(dns.RcodeRefused))
+		Expect(server.Records()).To(BeEmpty())
+	})
+
+	It("returns an error when the server is not authoritative for the zone", func() {
+		err := client.Update(ctx, dynamicdns.Update{
+			Zone: "example.org",
+			Adds: []dynamicdns.Record{{Name: "vm.example.org", Type: dns.TypeA, Data: "192.168.1.10"}},
+		})
+		Expect(err).To(MatchError("DNS server refused the update: NOTAUTH"))
+	})
+
+	It("returns an error when a record is not in the zone", func() {
+		err := client.Update(ctx, dynamicdns.Update{
+			Zone: "example.com",
+			Adds: []dynamicdns.Record{{Name: "vm.example.org", Type: dns.TypeA, Data: "192.168.1.10"}},
+		})
+		Expect(err).To(MatchError("DNS server refused the update: NOTZONE"))
+	})
+
+	It("returns an error for an invalid record", func() {
+		err := client.Update(ctx, dynamicdns.Update{
+			Zone: "example.com",
+			Adds: []dynamicdns.Record{{Name: "vm.example.com", Type: dns.TypeA, Data: "2001:db8::10"}},
+		})
+		Expect(err).To(MatchError(`invalid A record "2001:db8::10": not an IPv4 address`))
+		Expect(server.Updates()).To(BeZero())
+	})
+
+	When("the update is signed with another key", func() {
+		BeforeEach(func() {
+			otherKey := newKey("other-key", "")
+			serverKey = &otherKey
+		})
+
+		It("returns an error", func() {
+			err := client.Update(ctx, dynamicdns.Update{
+				Zone: "example.com",
+				Adds: []dynamicdns.Record{vmA},
+			})
+			Expect(err).To(MatchError("DNS server refused the update: NOTAUTH"))
+			Expect(server.Records()).To(BeEmpty())
+		})
+	})
+
+	When("the update is signed with another secret", func() {
+		BeforeEach(func() {
+			otherKey := key
+			otherKey.Secret = []byte("other-secret")
+			serverKey = &otherKey
+		})
+
+		It("returns an error", func() {
+			err := client.Update(ctx, dynamicdns.Update{
+				Zone: "example.com",
+				Adds: []dynamicdns.Record{vmA},
+			})
+			Expect(err).To(MatchError("DNS server refused the update: NOTAUTH"))
+			Expect(server.Records()).To(BeEmpty())
+		})
+	})
+
+	When("the server does not sign its responses", func() {
+		BeforeEach(func() {
+			serverKey = nil
+		})
+
+		It("returns an error", func() {
+			err := client.Update(ctx, dynamicdns.Update{
+				Zone: "example.com",
+				Adds: []dynamicdns.Record{vmA},
+			})
+			Expect(err).To(MatchError(dynamicdns.ErrUnsigned))
+		})
+	})
+
+	When("the server cannot be reached", func() {
+		It("returns an error", func() {
+			server.Close()
+			client.Timeout = time.Second
+			err := client.Update(ctx, dynamicdns.Update{Zone: "example.com"})
+			Expect(err).To(MatchError(ContainSubstring("failed to connect to DNS server")))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamicdns
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// The TSIG algorithms defined by RFC 8945.
+const (
+	HMACSHA1   = dns.HmacSHA1
+	HMACSHA224 = dns.HmacSHA224
+	HMACSHA256 = dns.HmacSHA256
+	HMACSHA384 = dns.HmacSHA384
+	HMACSHA512 = dns.HmacSHA512
+)
+
+// tsigFudge is the number of seconds by which the time a message was signed
+// may differ from the time it is verified.
+const tsigFudge = 300
+
+// ErrUnsigned is returned when the response to a signed update is not signed.
+var ErrUnsigned = errors.New("message is not signed")
+
+// TSIGKey is a shared secret used to sign DNS messages.
+type TSIGKey struct {
+	// Name is the fully qualified name of the key.
+	Name string
+
+	// Algorithm is the name of the HMAC algorithm. Defaults to HMACSHA256.
+	Algorithm string
+
+	// Secret is the shared secret.
+	Secret []byte
+}
+
+// ParseTSIGKey returns the TSIG key with the provided name, algorithm, and
+// base64 encoded secret, in the format used by BIND's key statement.
+func ParseTSIGKey(name, algorithm, secret string) (TSIGKey, error) {
+	if name == "" {
+		return TSIGKey{}, errors.New("TSIG key name is empty")
+	}
+	key := TSIGKey{
+		Name:      Fqdn(strings.ToLower(name)),
+		Algorithm: HMACSHA256,
+	}
+	if algorithm != "" {
+		key.Algorithm = Fqdn(strings.ToLower(algorithm))
+	}
+	switch key.Algorithm {
+	case HMACSHA1, HMACSHA224, HMACSHA256, HMACSHA384, HMACSHA512:
+	default:
+		return TSIGKey{}, fmt.Errorf("unsupported TSIG algorithm %q", key.Algorithm)
+	}
+	s, err := base64.StdEncoding.DecodeString(strings.TrimSpace(secret))
+	if err != nil {
+		return TSIGKey{}, fmt.Errorf("invalid TSIG key secret: %w", err)
+	}
+	if len(s) == 0 {
+		return TSIGKey{}, errors.New("TSIG key secret is empty")
+	}
+	key.Secret = s
+	return key, nil
+}
+
+func (k TSIGKey) algorithm() string {
+	if k.Algorithm == "" {
+		return HMACSHA256
+	}
+	return Fqdn(strings.ToLower(k.Algorithm))
+}
@@ -0,0 +1,71 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageimport
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// checksumReader computes the checksum of the bytes read through it.
+type checksumReader struct {
+	r        io.Reader
+	h        hash.Hash
+	name     string
+	expected string
+}
+
+// newChecksumReader returns a reader that verifies the checksum of r. A nil
+// checksum is never verified.
+func newChecksumReader(
+	r io.Reader,
+	name string,
+	checksum *vmopv1.VirtualMachineImageImportChecksum) (*checksumReader, error) {
+
+	cr := &checksumReader{r: r, name: name}
+	if checksum == nil {
+		return cr, nil
+	}
+
+	switch checksum.Algorithm {
+	case vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256:
+		cr.h = sha256.New()
+	case vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA512:
+		cr.h = sha512.New()
+	default:
+		return nil, fmt.Errorf("%w: unsupported checksum algorithm %q", ErrInvalidSource, checksum.Algorithm)
+	}
+	cr.r = io.TeeReader(r, cr.h)
+	cr.expected = strings.ToLower(checksum.Value)
+
+	return cr, nil
+}
+
+func (r *checksumReader) Read(b []byte) (int, error) {
+	return r.r.Read(b)
+}
+
+// verify reads the rest of the underlying reader and returns an error
+// wrapping ErrChecksumMismatch if its checksum does not match.
+func (r *checksumReader) verify() error {
+	if _, err := io.Copy(io.Discard, r.r); err != nil {
+		return err
+	}
+	if r.h == nil {
+		return nil
+	}
+	if actual := hex.EncodeToString(r.h.Sum(nil)); actual != r.expected {
+		return fmt.Errorf("%w: %s has checksum %s, expected %s",
+			ErrChecksumMismatch, r.name, actual, r.expected)
+	}
+	return nil
+}
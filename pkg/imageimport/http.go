@@ -0,0 +1,218 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageimport
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/ovf"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// maxOVFDescriptorSize is the maximum size of an OVF descriptor, which is
+// read into memory so the files it references are known.
+const maxOVFDescriptorSize = 16 << 20
+
+type httpSource struct {
+	url      *url.URL
+	checksum *vmopv1.VirtualMachineImageImportChecksum
+	itemType string
+	client   *http.Client
+	progress *Progress
+}
+
+func newHTTPSource(spec vmopv1.VirtualMachineImageImportHTTPSource, opts Options) (Source, error) {
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid url: %w", ErrInvalidSource, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: unsupported url scheme %q", ErrInvalidSource, u.Scheme)
+	}
+
+	itemType, err := itemTypeForFile(u.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &httpSource{
+		url:      u,
+		checksum: spec.Checksum,
+		itemType: itemType,
+		client:   client,
+		progress: opts.Progress,
+	}, nil
+}
+
+func (s *httpSource) ItemType() string {
+	return s.itemType
+}
+
+func (s *httpSource) Walk(ctx context.Context, fn WalkFunc) error {
+	name := path.Base(s.url.Path)
+
+	body, size, err := s.get(ctx, s.url, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	r, err := newChecksumReader(s.progress.reader(body), name, s.checksum)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case extOVA:
+		if err := walkOVA(r, fn); err != nil {
+			return err
+		}
+	case extOVF:
+		if err := s.walkOVF(ctx, name, r, fn); err != nil {
+			return err
+		}
+	default:
+		if err := fn(name, size, r); err != nil {
+			return err
+		}
+	}
+
+	return r.verify()
+}
+
+// walkOVF passes the OVF descriptor to fn, followed by each of the files it
+// references. The descriptor's checksum is verified before any files are
+// passed to fn.
+func (s *httpSource) walkOVF(
+	ctx context.Context,
+	name string,
+	r *checksumReader,
+	fn WalkFunc) error {
+
+	data, err := io.ReadAll(io.LimitReader(r, maxOVFDescriptorSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read OVF descriptor: %w", err)
+	}
+	if len(data) > maxOVFDescriptorSize {
+		return fmt.Errorf("%w: OVF descriptor exceeds %d bytes", ErrInvalidSource, maxOVFDescriptorSize)
+	}
+	if err := r.verify(); err != nil {
+		return err
+	}
+
+	envelope, err := ovf.Unmarshal(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: invalid OVF descriptor: %w", ErrInvalidSource, err)
+	}
+
+	refs := make([]*url.URL, len(envelope.References))
+	for i, file := range envelope.References {
+		ref, err := url.Parse(file.Href)
+		if err != nil || ref.IsAbs() || ref.Host != "" || strings.HasPrefix(ref.Path, "/") {
+			return fmt.Errorf("%w: OVF descriptor references invalid file %q", ErrInvalidSource, file.Href)
+		}
+		refs[i] = s.url.ResolveReference(ref)
+		s.progress.addTotal(int64(file.Size))
+	}
+
+	if err := fn(name, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	for i, ref := range refs {
+		file := envelope.References[i]
+		if err := s.walkFile(ctx, path.Base(file.Href), ref, file.Size > 0, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *httpSource) walkFile(
+	ctx context.Context,
+	name string,
+	u *url.URL,
+	sizeKnown bool,
+	fn WalkFunc) error {
+
+	body, size, err := s.get(ctx, u, !sizeKnown)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	return fn(name, size, s.progress.reader(body))
+}
+
+// get sends a GET request for the URL, and returns the response body and its
+// size. When addTotal is true, the size is added to the total of the
+// progress.
+func (s *httpSource) get(ctx context.Context, u *url.URL, addTotal bool) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download %s: %w", u.Redacted(), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+		return nil, 0, fmt.Errorf("failed to download %s: %s", u.Redacted(), res.Status)
+	}
+
+	if addTotal {
+		s.progress.addTotal(res.ContentLength)
+	}
+
+	return res.Body, res.ContentLength, nil
+}
+
+// walkOVA passes each of the files in the OVA, which is a tar archive, to fn.
+func walkOVA(r io.Reader, fn WalkFunc) error {
+	tr := tar.NewReader(r)
+	var n int
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: invalid OVA: %w", ErrInvalidSource, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(path.Base(hdr.Name), hdr.Size, tr); err != nil {
+			return err
+		}
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: OVA does not contain any files", ErrInvalidSource)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imageimport reads the files of images that are imported into
+// content libraries from HTTP(S) URLs and OCI registries.
+package imageimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/vmware/govmomi/vapi/library"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+var (
+	// ErrChecksumMismatch is returned when the checksum of a downloaded file
+	// does not match its expected checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrInvalidSource is returned when a source cannot be imported, ex. its
+	// type is not supported.
+	ErrInvalidSource = errors.New("invalid source")
+)
+
+const (
+	extOVA = ".ova"
+	extOVF = ".ovf"
+	extISO = ".iso"
+)
+
+// WalkFunc is called for each file of an image, in the order in which the
+// files are uploaded. The reader is only valid until the function returns.
+type WalkFunc func(name string, size int64, r io.Reader) error
+
+// Source is an image that is imported into a content library item.
+type Source interface {
+	// ItemType returns the type of the content library item into which the
+	// image is imported, i.e. library.ItemTypeOVF or library.ItemTypeISO.
+	ItemType() string
+
+	// Walk downloads the image's files and calls fn for each of them. The
+	// files of an OVA are passed to fn individually. An error wrapping
+	// ErrChecksumMismatch is returned if a file's checksum does not match.
+	Walk(ctx context.Context, fn WalkFunc) error
+}
+
+// Options are the options used to download an image.
+type Options struct {
+	// HTTPClient is the client used to download an image from an HTTP(S)
+	// URL. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Auth is used to authenticate with an OCI registry. Defaults to
+	// anonymous access.
+	Auth authn.Authenticator
+
+	// Progress, when set, tracks the number of bytes that are downloaded.
+	Progress *Progress
+}
+
+// NewSource returns the Source for the spec. The manifest of an OCI artifact
+// is fetched so the type of the image is known.
+func NewSource(
+	ctx context.Context,
+	spec vmopv1.VirtualMachineImageImportSource,
+	opts Options) (Source, error) {
+
+	if opts.Progress == nil {
+		opts.Progress = &Progress{}
+	}
+
+	switch {
+	case spec.HTTP != nil && spec.OCI != nil:
+		return nil, fmt.Errorf("%w: only one of http or oci may be set", ErrInvalidSource)
+	case spec.HTTP != nil:
+		return newHTTPSource(*spec.HTTP, opts)
+	case spec.OCI != nil:
+		return newOCISource(ctx, *spec.OCI, opts)
+	default:
+		return nil, fmt.Errorf("%w: one of http or oci must be set", ErrInvalidSource)
+	}
+}
+
+// itemTypeForFile returns the content library item type for a file name.
+func itemTypeForFile(name string) (string, error) {
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case extOVA, extOVF:
+		return library.ItemTypeOVF, nil
+	case extISO:
+		return library.ItemTypeISO, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported file type %q", ErrInvalidSource, ext)
+	}
+}
+
+// Progress tracks the number of bytes that are downloaded. It is safe to use
+// from multiple goroutines.
+type Progress struct {
+	total       atomic.Int64
+	transferred atomic.Int64
+}
+
+// Total returns the total number of bytes, or zero if it is not known yet.
+func (p *Progress) Total() int64 {
+	return p.total.Load()
+}
+
+// Transferred returns the number of bytes that have been downloaded.
+func (p *Progress) Transferred() int64 {
+	return p.transferred.Load()
+}
+
+// Percent returns the percentage of the total bytes that have been
+// downloaded.
+func (p *Progress) Percent() int32 {
+	total := p.Total()
+	if total <= 0 {
+		return 0
+	}
+	return int32(min(100, p.Transferred()*100/total)) //nolint:gosec // bounded to 100
+}
+
+func (p *Progress) addTotal(n int64) {
+	if n > 0 {
+		p.total.Add(n)
+	}
+}
+
+// reader returns a reader that adds the bytes read from r to the progress.
+func (p *Progress) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, p: p}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *Progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.p.transferred.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,436 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageimport_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/vmware/govmomi/vapi/library"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+)
+
+const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <References>
+    <File ovf:href="disk.vmdk" ovf:id="file1" ovf:size="%d"/>
+  </References>
+</Envelope>`
+
+type walkedFile struct {
+	name string
+	size int64
+	data string
+}
+
+func walk(ctx context.Context, src imageimport.Source) ([]walkedFile, error) {
+	var files []walkedFile
+	err := src.Walk(ctx, func(name string, size int64, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		files = append(files, walkedFile{name: name, size: size, data: string(data)})
+		return nil
+	})
+	return files, err
+}
+
+func newOVA(files ...walkedFile) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		Expect(tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0600,
+			Size:     int64(len(f.data)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := tw.Write([]byte(f.data))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("HTTP source", func() {
+	const disk = "disk contents"
+
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		files    map[string][]byte
+		spec     vmopv1.VirtualMachineImageImportHTTPSource
+		progress *imageimport.Progress
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		files = map[string][]byte{
+			"/images/photon.iso": []byte("iso contents"),
+			"/images/vm.ovf":     fmt.Appendf(nil, ovfDescriptor, len(disk)),
+			"/images/disk.vmdk":  []byte(disk),
+			"/images/vm.ova": newOVA(
+				walkedFile{name: "vm.ovf", data: "ovf"},
+				walkedFile{name: "vm.mf", data: "mf"},
+				walkedFile{name: "disk.vmdk", data: disk}),
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write(data)
+		}))
+		spec = vmopv1.VirtualMachineImageImportHTTPSource{}
+		progress = &imageimport.Progress{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newSource := func() (imageimport.Source, error) {
+		return imageimport.NewSource(
+			ctx,
+			vmopv1.VirtualMachineImageImportSource{HTTP: &spec},
+			imageimport.Options{Progress: progress})
+	}
+
+	When("the URL is an ISO file", func() {
+		BeforeEach(func() {
+			spec.URL = server.URL + "/images/photon.iso"
+		})
+
+		It("walks the file", func() {
+			src, err := newSource()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(src.ItemType()).To(Equal(library.ItemTypeISO))
+
+			walked, err := walk(ctx, src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(walked).To(ConsistOf(walkedFile{name: "photon.iso", size: 12, data: "iso contents"}))
+
+			Expect(progress.Total()).To(BeEquivalentTo(12))
+			Expect(progress.Transferred()).To(BeEquivalentTo(12))
+			Expect(progress.Percent()).To(BeEquivalentTo(100))
+		})
+
+		When("the checksum matches", func() {
+			BeforeEach(func() {
+				spec.Checksum = &vmopv1.VirtualMachineImageImportChecksum{
+					Algorithm: vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256,
+					Value:     strings.ToUpper(sha256Hex(files["/images/photon.iso"])),
+				}
+			})
+
+			It("walks the file", func() {
+				src, err := newSource()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = walk(ctx, src)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("the checksum does not match", func() {
+			BeforeEach(func() {
+				spec.Checksum = &vmopv1.VirtualMachineImageImportChecksum{
+					Algorithm: vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256,
+					Value:     sha256Hex([]byte("other")),
+				}
+			})
+
+			It("returns a checksum mismatch error", func() {
+				src, err := newSource()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = walk(ctx, src)
+				Expect(err).To(MatchError(imageimport.ErrChecksumMismatch))
+			})
+		})
+	})
+
+	When("the URL is an OVA file", func() {
+		BeforeEach(func() {
+			spec.URL = server.URL + "/images/vm.ova"
+		})
+
+		It("walks each of the files in the OVA", func() {
+			src, err := newSource()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(src.ItemType()).To(Equal(library.ItemTypeOVF))
+
+			walked, err := walk(ctx, src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(walked).To(Equal([]walkedFile{
+				{name: "vm.ovf", size: 3, data: "ovf"},
+				{name: "vm.mf", size: 2, data: "mf"},
+				{name: "disk.vmdk", size: int64(len(disk)), data: disk},
+			}))
+			Expect(progress.Percent()).To(BeEquivalentTo(100))
+		})
+
+		When("the checksum does not match", func() {
+			BeforeEach(func() {
+				spec.Checksum = &vmopv1.VirtualMachineImageImportChecksum{
+					Algorithm: vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA512,
+					Value:     "00",
+				}
+			})
+
+			It("returns a checksum mismatch error", func() {
+				src, err := newSource()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = walk(ctx, src)
+				Expect(err).To(MatchError(imageimport.ErrChecksumMismatch))
+			})
+		})
+	})
+
+	When("the URL is an OVF descriptor", func() {
+		BeforeEach(func() {
+			spec.URL = server.URL + "/images/vm.ovf"
+		})
+
+		It("walks the descriptor and the files it references", func() {
+			src, err := newSource()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(src.ItemType()).To(Equal(library.ItemTypeOVF))
+
+			walked, err := walk(ctx, src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(walked).To(HaveLen(2))
+			Expect(walked[0].name).To(Equal("vm.ovf"))
+			Expect(walked[1]).To(Equal(walkedFile{name: "disk.vmdk", size: int64(len(disk)), data: disk}))
+
+			Expect(progress.Total()).To(BeEquivalentTo(len(files["/images/vm.ovf"]) + len(disk)))
+			Expect(progress.Percent()).To(BeEquivalentTo(100))
+		})
+
+		When("the descriptor references an absolute URL", func() {
+			BeforeEach(func() {
+				files["/images/vm.ovf"] = []byte(strings.Replace(
+					string(files["/images/vm.ovf"]), "disk.vmdk", "https://example.com/disk.vmdk", 1))
+			})
+
+			It("returns an invalid source error", func() {
+				src, err := newSource()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = walk(ctx, src)
+				Expect(err).To(MatchError(imageimport.ErrInvalidSource))
+			})
+		})
+
+		When("a referenced file does not exist", func() {
+			BeforeEach(func() {
+				delete(files, "/images/disk.vmdk")
+			})
+
+			It("returns an error", func() {
+				src, err := newSource()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = walk(ctx, src)
+				Expect(err).To(MatchError(ContainSubstring("404")))
+			})
+		})
+	})
+
+	When("the URL has an unsupported extension", func() {
+		BeforeEach(func() {
+			spec.URL = server.URL + "/images/disk.vmdk"
+		})
+
+		It("returns an invalid source error", func() {
+			_, err := newSource()
+			Expect(err).To(MatchError(imageimport.ErrInvalidSource))
+		})
+	})
+})
+
+var _ = Describe("OCI source", func() {
+	const repo = "images/photon"
+
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		host     string
+		progress *imageimport.Progress
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.TrimPrefix(server.URL, "http://")
+		progress = &imageimport.Progress{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	push := func(tag string, layers ...walkedFile) string {
+		img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+		for _, l := range layers {
+			addendum := mutate.Addendum{
+				Layer: static.NewLayer([]byte(l.data), "application/octet-stream"),
+			}
+			if l.name != "" {
+				addendum.Annotations = map[string]string{imageimport.LayerTitleAnnotation: l.name}
+			}
+			var err error
+			img, err = mutate.Append(img, addendum)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		reference := fmt.Sprintf("%s/%s:%s", host, repo, tag)
+		ref, err := name.ParseReference(reference)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(ref, img)).To(Succeed())
+		return reference
+	}
+
+	newSource := func(reference string) (imageimport.Source, error) {
+		return imageimport.NewSource(
+			ctx,
+			vmopv1.VirtualMachineImageImportSource{
+				OCI: &vmopv1.VirtualMachineImageImportOCISource{Reference: reference},
+			},
+			imageimport.Options{Progress: progress})
+	}
+
+	It("walks an ISO artifact", func() {
+		src, err := newSource(push("iso", walkedFile{name: "photon.iso", data: "iso"}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src.ItemType()).To(Equal(library.ItemTypeISO))
+
+		walked, err := walk(ctx, src)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walked).To(ConsistOf(walkedFile{name: "photon.iso", size: 3, data: "iso"}))
+		Expect(progress.Total()).To(BeEquivalentTo(3))
+		Expect(progress.Percent()).To(BeEquivalentTo(100))
+	})
+
+	It("walks an OVF artifact with the descriptor first", func() {
+		src, err := newSource(push("ovf",
+			walkedFile{name: "disk.vmdk", data: "disk"},
+			walkedFile{name: "vm.ovf", data: "ovf"}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src.ItemType()).To(Equal(library.ItemTypeOVF))
+
+		walked, err := walk(ctx, src)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walked).To(Equal([]walkedFile{
+			{name: "vm.ovf", size: 3, data: "ovf"},
+			{name: "disk.vmdk", size: 4, data: "disk"},
+		}))
+	})
+
+	It("walks each of the files in an OVA artifact", func() {
+		src, err := newSource(push("ova", walkedFile{
+			name: "vm.ova",
+			data: string(newOVA(
+				walkedFile{name: "vm.ovf", data: "ovf"},
+				walkedFile{name: "disk.vmdk", data: "disk"})),
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src.ItemType()).To(Equal(library.ItemTypeOVF))
+
+		walked, err := walk(ctx, src)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walked).To(Equal([]walkedFile{
+			{name: "vm.ovf", size: 3, data: "ovf"},
+			{name: "disk.vmdk", size: 4, data: "disk"},
+		}))
+		Expect(progress.Percent()).To(BeEquivalentTo(100))
+	})
+
+	It("returns an invalid source error when the layers do not have titles", func() {
+		_, err := newSource(push("untitled", walkedFile{data: "data"}))
+		Expect(err).To(MatchError(imageimport.ErrInvalidSource))
+	})
+
+	It("returns an invalid source error when the artifact has two images", func() {
+		_, err := newSource(push("two",
+			walkedFile{name: "a.iso", data: "a"},
+			walkedFile{name: "b.iso", data: "b"}))
+		Expect(err).To(MatchError(imageimport.ErrInvalidSource))
+	})
+
+	It("returns an error when the artifact does not exist", func() {
+		_, err := newSource(fmt.Sprintf("%s/%s:missing", host, repo))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("AuthFromDockerConfigJSON", func() {
+	const reference = "registry.example.com/images/photon:5.0"
+
+	authConfig := func(auth authn.Authenticator) *authn.AuthConfig {
+		cfg, err := auth.Authorization()
+		Expect(err).ToNot(HaveOccurred())
+		return cfg
+	}
+
+	It("returns the username and password for the registry", func() {
+		auth, err := imageimport.AuthFromDockerConfigJSON([]byte(`{"auths":{
+			"other.example.com":{"username":"other","password":"other"},
+			"https://registry.example.com":{"username":"user","password":"pass"}}}`), reference)
+		Expect(err).ToNot(HaveOccurred())
+		cfg := authConfig(auth)
+		Expect(cfg.Username).To(Equal("user"))
+		Expect(cfg.Password).To(Equal("pass"))
+	})
+
+	It("decodes the auth field", func() {
+		data := fmt.Sprintf(`{"auths":{"registry.example.com":{"auth":%q}}}`,
+			base64.StdEncoding.EncodeToString([]byte("user:pass")))
+		auth, err := imageimport.AuthFromDockerConfigJSON([]byte(data), reference)
+		Expect(err).ToNot(HaveOccurred())
+		cfg := authConfig(auth)
+		Expect(cfg.Username).To(Equal("user"))
+		Expect(cfg.Password).To(Equal("pass"))
+	})
+
+	It("returns anonymous access when there are no credentials for the registry", func() {
+		auth, err := imageimport.AuthFromDockerConfigJSON([]byte(`{"auths":{}}`), reference)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(auth).To(Equal(authn.Anonymous))
+	})
+
+	It("returns an error for invalid JSON", func() {
+		_, err := imageimport.AuthFromDockerConfigJSON([]byte(`{`), reference)
+		Expect(err).To(MatchError(imageimport.ErrInvalidSource))
+	})
+})
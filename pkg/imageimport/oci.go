@@ -0,0 +1,256 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageimport
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/vmware/govmomi/vapi/library"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// LayerTitleAnnotation is the annotation of an OCI layer that has the name of
+// the file in the layer.
+const LayerTitleAnnotation = "org.opencontainers.image.title"
+
+type ociFile struct {
+	name string
+	desc v1.Descriptor
+}
+
+type ociSource struct {
+	repo      name.Repository
+	files     []ociFile
+	itemType  string
+	isOVA     bool
+	transport http.RoundTripper
+	auth      authn.Authenticator
+	progress  *Progress
+}
+
+func newOCISource(
+	ctx context.Context,
+	spec vmopv1.VirtualMachineImageImportOCISource,
+	opts Options) (Source, error) {
+
+	var nameOpts []name.Option
+	t := remote.DefaultTransport
+	if spec.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+		tr := remote.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // requested by the user
+		t = tr
+	}
+
+	ref, err := name.ParseReference(spec.Reference, nameOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid reference: %w", ErrInvalidSource, err)
+	}
+
+	auth := opts.Auth
+	if auth == nil {
+		auth = authn.Anonymous
+	}
+
+	img, err := remote.Image(ref,
+		remote.WithContext(ctx),
+		remote.WithAuth(auth),
+		remote.WithTransport(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest for %s: %w", ref, err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest for %s: %w", ref, err)
+	}
+
+	s := &ociSource{
+		repo:      ref.Context(),
+		transport: t,
+		auth:      auth,
+		progress:  opts.Progress,
+	}
+	if err := s.setFiles(manifest.Layers); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// setFiles sets the files of the source from the artifact's layers. The
+// layers must be a single OVA or ISO file, or an OVF descriptor and the files
+// it references. The OVF descriptor is always walked first.
+func (s *ociSource) setFiles(layers []v1.Descriptor) error {
+	counts := map[string]int{}
+	for _, l := range layers {
+		title := l.Annotations[LayerTitleAnnotation]
+		if title == "" {
+			continue
+		}
+		file := ociFile{name: path.Base(title), desc: l}
+		ext := strings.ToLower(path.Ext(file.name))
+		counts[ext]++
+		if ext == extOVF {
+			s.files = slices.Insert(s.files, 0, file)
+		} else {
+			s.files = append(s.files, file)
+		}
+	}
+
+	switch {
+	case len(s.files) == 1 && counts[extOVA] == 1:
+		s.itemType = library.ItemTypeOVF
+		s.isOVA = true
+	case len(s.files) == 1 && counts[extISO] == 1:
+		s.itemType = library.ItemTypeISO
+	case counts[extOVF] == 1:
+		s.itemType = library.ItemTypeOVF
+	default:
+		return fmt.Errorf("%w: artifact must have layers with a single OVA, OVF or ISO file, "+
+			"named with the %s annotation", ErrInvalidSource, LayerTitleAnnotation)
+	}
+
+	for _, f := range s.files {
+		s.progress.addTotal(f.desc.Size)
+	}
+
+	return nil
+}
+
+func (s *ociSource) ItemType() string {
+	return s.itemType
+}
+
+func (s *ociSource) Walk(ctx context.Context, fn WalkFunc) error {
+	t, err := transport.NewWithContext(
+		ctx,
+		s.repo.Registry,
+		s.auth,
+		s.transport,
+		[]string{s.repo.Scope(transport.PullScope)})
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with %s: %w", s.repo.RegistryStr(), err)
+	}
+	client := &http.Client{Transport: t}
+
+	for _, f := range s.files {
+		if err := s.walkBlob(ctx, client, f, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkBlob downloads the layer's blob and passes it to fn, or passes each of
+// its files to fn when it is an OVA. The blob's digest is verified.
+func (s *ociSource) walkBlob(
+	ctx context.Context,
+	client *http.Client,
+	f ociFile,
+	fn WalkFunc) error {
+
+	var algorithm vmopv1.VirtualMachineImageImportChecksumAlgorithm
+	switch f.desc.Digest.Algorithm {
+	case "sha256":
+		algorithm = vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256
+	case "sha512":
+		algorithm = vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA512
+	default:
+		return fmt.Errorf("%w: unsupported digest algorithm %q", ErrInvalidSource, f.desc.Digest.Algorithm)
+	}
+
+	u := fmt.Sprintf("%s://%s/v2/%s/blobs/%s",
+		s.repo.Scheme(), s.repo.RegistryStr(), s.repo.RepositoryStr(), f.desc.Digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", f.name, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", f.name, res.Status)
+	}
+
+	r, err := newChecksumReader(
+		s.progress.reader(io.LimitReader(res.Body, f.desc.Size+1)),
+		f.name,
+		&vmopv1.VirtualMachineImageImportChecksum{
+			Algorithm: algorithm,
+			Value:     f.desc.Digest.Hex,
+		})
+	if err != nil {
+		return err
+	}
+
+	if s.isOVA {
+		err = walkOVA(r, fn)
+	} else {
+		err = fn(f.name, f.desc.Size, r)
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.verify()
+}
+
+// AuthFromDockerConfigJSON returns the credentials for the registry from the
+// contents of a Secret of type kubernetes.io/dockerconfigjson. Anonymous
+// access is returned if the Secret does not have credentials for the
+// registry.
+func AuthFromDockerConfigJSON(data []byte, reference string) (authn.Authenticator, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid reference: %w", ErrInvalidSource, err)
+	}
+
+	var cfg struct {
+		Auths map[string]authn.AuthConfig `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: invalid docker config: %w", ErrInvalidSource, err)
+	}
+
+	registry := ref.Context().RegistryStr()
+	for key, auth := range cfg.Auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host != registry && !(registry == name.DefaultRegistry && host == "index.docker.io") {
+			continue
+		}
+		if auth.Auth != "" && auth.Username == "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid auth for %s: %w", ErrInvalidSource, key, err)
+			}
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+			auth.Auth = ""
+		}
+		return authn.FromConfig(auth), nil
+	}
+
+	return authn.Anonymous, nil
+}
@@ -0,0 +1,187 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagepublish
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	tarBlockSize = 512
+
+	// maxUSTARSize is the maximum size of a file in a USTAR archive. Larger
+	// files are written with the GNU base-256 size extension, which does not
+	// add any extra headers.
+	maxUSTARSize = 1<<33 - 1
+
+	// maxTarNameLength is the maximum length of a file name that fits in a
+	// single tar header.
+	maxTarNameLength = 99
+)
+
+// UploadHTTP uploads the files as an OVA to the URL with an HTTP PUT request.
+// The OVF descriptor is the first file in the OVA.
+//
+// The size of the OVA is computed before it is streamed so the request has a
+// Content-Length, which is required by most pre-signed URLs.
+func UploadHTTP(
+	ctx context.Context,
+	rawURL string,
+	paths []string,
+	opts Options) (Result, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: invalid url: %w", ErrInvalidTarget, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Result{}, fmt.Errorf("%w: unsupported url scheme %q", ErrInvalidTarget, u.Scheme)
+	}
+
+	files, err := statFiles(paths)
+	if err != nil {
+		return Result{}, err
+	}
+	size, err := ovaSize(files)
+	if err != nil {
+		return Result{}, err
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var (
+		pr, pw = io.Pipe()
+		h      = sha256.New()
+		done   = make(chan error, 1)
+	)
+	go func() {
+		err := writeOVA(io.MultiWriter(pw, h), files)
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), pr)
+	if err != nil {
+		_ = pr.Close()
+		<-done
+		return Result{}, err
+	}
+	req.ContentLength = size
+
+	// Remove any credentials from the URL before it is used in errors or
+	// returned.
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	res, err := client.Do(req)
+	_ = pr.Close()
+	writeErr := <-done
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to upload to %s: %w", u, unwrapURLError(err))
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return Result{}, fmt.Errorf("failed to upload to %s: %s", u, res.Status)
+	}
+	if writeErr != nil {
+		return Result{}, fmt.Errorf("failed to upload to %s: %w", u, writeErr)
+	}
+
+	return Result{
+		URL:    u.String(),
+		Digest: "sha256:" + hex.EncodeToString(h.Sum(nil)),
+		Size:   size,
+	}, nil
+}
+
+// unwrapURLError returns the error wrapped by a *url.Error since its message
+// includes the URL, which may have credentials in its query.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// ovaSize returns the size of the OVA that contains the files. Each file has a
+// single header block and its contents are padded to a multiple of the block
+// size. The archive ends with two zero blocks.
+func ovaSize(files []file) (int64, error) {
+	var n int64
+	for _, f := range files {
+		if len(f.name) > maxTarNameLength || !isASCII(f.name) {
+			return 0, fmt.Errorf("invalid file name %q", f.name)
+		}
+		n += tarBlockSize + (f.size+tarBlockSize-1)/tarBlockSize*tarBlockSize
+	}
+	return n + 2*tarBlockSize, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0 || s[i] > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+func writeOVA(w io.Writer, files []file) error {
+	var (
+		tw      = tar.NewWriter(w)
+		modTime = time.Now().Truncate(time.Second)
+	)
+	for _, f := range files {
+		format := tar.FormatUSTAR
+		if f.size > maxUSTARSize {
+			format = tar.FormatGNU
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0644,
+			Size:     f.size,
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+			Format:   format,
+		}); err != nil {
+			return err
+		}
+		if err := copyFile(tw, f); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func copyFile(w io.Writer, f file) error {
+	r, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if _, err := io.CopyN(w, r, f.size); err != nil {
+		return fmt.Errorf("failed to read %s: %w", f.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imagepublish uploads the files of VMs that are exported for
+// publication to OCI registries and pre-signed HTTP URLs.
+package imagepublish
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+// ErrInvalidTarget is returned when files cannot be published to a target,
+// ex. its reference or URL is invalid.
+var ErrInvalidTarget = errors.New("invalid target")
+
+const extOVF = ".ovf"
+
+// Result describes the artifact that was published.
+type Result struct {
+	// Reference is the digest reference of the OCI artifact that was pushed.
+	Reference string
+
+	// URL is the URL to which the OVA was uploaded, without its query.
+	URL string
+
+	// Digest is the digest of the OCI artifact's manifest, or the SHA-256
+	// digest of the uploaded OVA.
+	Digest string
+
+	// Size is the number of bytes that were uploaded.
+	Size int64
+}
+
+// Options are the options used to publish the files.
+type Options struct {
+	// HTTPClient is the client used to upload an OVA to a URL. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Auth is used to authenticate with an OCI registry. Defaults to
+	// anonymous access.
+	Auth authn.Authenticator
+}
+
+type file struct {
+	name string
+	path string
+	size int64
+}
+
+// statFiles returns the files at the provided paths. The OVF descriptor is
+// always first, and there must be exactly one.
+func statFiles(paths []string) ([]file, error) {
+	var (
+		files []file
+		ovfs  int
+	)
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		f := file{name: filepath.Base(p), path: p, size: fi.Size()}
+		if strings.EqualFold(filepath.Ext(f.name), extOVF) {
+			ovfs++
+			files = slices.Insert(files, 0, f)
+		} else {
+			files = append(files, f)
+		}
+	}
+	if ovfs != 1 {
+		return nil, fmt.Errorf("expected one OVF descriptor, found %d", ovfs)
+	}
+	return files, nil
+}
@@ -0,0 +1,223 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagepublish_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+	"github.com/vmware-tanzu/vm-operator/pkg/imagepublish"
+)
+
+type publishedFile struct {
+	name string
+	data string
+}
+
+func writeFiles(dir string, files ...publishedFile) []string {
+	var paths []string
+	for _, f := range files {
+		p := filepath.Join(dir, f.name)
+		Expect(os.WriteFile(p, []byte(f.data), 0600)).To(Succeed())
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+var _ = Describe("PushOCI", func() {
+
+	var (
+		ctx    context.Context
+		server *httptest.Server
+		host   string
+		paths  []string
+		spec   vmopv1.VirtualMachinePublishRequestOCITarget
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.TrimPrefix(server.URL, "http://")
+		paths = writeFiles(GinkgoT().TempDir(),
+			publishedFile{name: "my-vm-disk-0.vmdk", data: "disk contents"},
+			publishedFile{name: "my-vm.ovf", data: "ovf contents"},
+			publishedFile{name: "my-vm-nvram.nvram", data: "nvram contents"})
+		spec = vmopv1.VirtualMachinePublishRequestOCITarget{
+			Reference: host + "/images/my-vm:v1",
+			Insecure:  true,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("pushes an artifact that may be imported", func() {
+		res, err := imagepublish.PushOCI(ctx, spec, paths, imagepublish.Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Digest).To(HavePrefix("sha256:"))
+		Expect(res.Reference).To(Equal(host + "/images/my-vm@" + res.Digest))
+		Expect(res.Size).To(Equal(int64(len("disk contents") + len("ovf contents") + len("nvram contents"))))
+		Expect(res.URL).To(BeEmpty())
+
+		src, err := imageimport.NewSource(ctx,
+			vmopv1.VirtualMachineImageImportSource{
+				OCI: &vmopv1.VirtualMachineImageImportOCISource{
+					Reference: res.Reference,
+					Insecure:  true,
+				},
+			},
+			imageimport.Options{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var files []publishedFile
+		Expect(src.Walk(ctx, func(name string, _ int64, r io.Reader) error {
+			data, err := io.ReadAll(r)
+			files = append(files, publishedFile{name: name, data: string(data)})
+			return err
+		})).To(Succeed())
+		Expect(files).To(Equal([]publishedFile{
+			{name: "my-vm.ovf", data: "ovf contents"},
+			{name: "my-vm-disk-0.vmdk", data: "disk contents"},
+			{name: "my-vm-nvram.nvram", data: "nvram contents"},
+		}))
+	})
+
+	When("the reference is invalid", func() {
+		BeforeEach(func() {
+			spec.Reference = "not a reference"
+		})
+		It("returns an error", func() {
+			_, err := imagepublish.PushOCI(ctx, spec, paths, imagepublish.Options{})
+			Expect(errors.Is(err, imagepublish.ErrInvalidTarget)).To(BeTrue())
+		})
+	})
+
+	When("there is no OVF descriptor", func() {
+		BeforeEach(func() {
+			paths = paths[:1]
+		})
+		It("returns an error", func() {
+			_, err := imagepublish.PushOCI(ctx, spec, paths, imagepublish.Options{})
+			Expect(err).To(MatchError("expected one OVF descriptor, found 0"))
+		})
+	})
+})
+
+var _ = Describe("UploadHTTP", func() {
+
+	var (
+		ctx        context.Context
+		server     *httptest.Server
+		paths      []string
+		status     int
+		body       []byte
+		method     string
+		length     int64
+		requestURI string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		status = http.StatusOK
+		body, method, length, requestURI = nil, "", 0, ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			length = r.ContentLength
+			requestURI = r.RequestURI
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(status)
+		}))
+		paths = writeFiles(GinkgoT().TempDir(),
+			publishedFile{name: "my-vm-disk-0.vmdk", data: strings.Repeat("d", 1000)},
+			publishedFile{name: "my-vm.ovf", data: "ovf contents"})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads an OVA", func() {
+		res, err := imagepublish.UploadHTTP(ctx,
+			server.URL+"/images/my-vm.ova?signature=secret", paths, imagepublish.Options{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(requestURI).To(Equal("/images/my-vm.ova?signature=secret"))
+		Expect(length).To(Equal(int64(len(body))))
+
+		sum := sha256.Sum256(body)
+		Expect(res).To(Equal(imagepublish.Result{
+			URL:    server.URL + "/images/my-vm.ova",
+			Digest: "sha256:" + hex.EncodeToString(sum[:]),
+			Size:   int64(len(body)),
+		}))
+
+		var files []publishedFile
+		tr := tar.NewReader(bytes.NewReader(body))
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			data, err := io.ReadAll(tr)
+			Expect(err).ToNot(HaveOccurred())
+			files = append(files, publishedFile{name: hdr.Name, data: string(data)})
+		}
+		Expect(files).To(Equal([]publishedFile{
+			{name: "my-vm.ovf", data: "ovf contents"},
+			{name: "my-vm-disk-0.vmdk", data: strings.Repeat("d", 1000)},
+		}))
+	})
+
+	When("the server returns an error", func() {
+		BeforeEach(func() {
+			status = http.StatusForbidden
+		})
+		It("returns an error without the query of the url", func() {
+			_, err := imagepublish.UploadHTTP(ctx,
+				server.URL+"/images/my-vm.ova?signature=secret", paths, imagepublish.Options{})
+			Expect(err).To(MatchError("failed to upload to " + server.URL + "/images/my-vm.ova: 403 Forbidden"))
+		})
+	})
+
+	When("the url scheme is not supported", func() {
+		It("returns an error", func() {
+			_, err := imagepublish.UploadHTTP(ctx, "ftp://example.com/my-vm.ova", paths, imagepublish.Options{})
+			Expect(errors.Is(err, imagepublish.ErrInvalidTarget)).To(BeTrue())
+		})
+	})
+
+	When("a file name is too long", func() {
+		BeforeEach(func() {
+			paths = append(paths, writeFiles(filepath.Dir(paths[0]),
+				publishedFile{name: strings.Repeat("a", 100) + ".vmdk"})...)
+		})
+		It("returns an error", func() {
+			_, err := imagepublish.UploadHTTP(ctx, server.URL+"/my-vm.ova", paths, imagepublish.Options{})
+			Expect(err).To(MatchError(ContainSubstring("invalid file name")))
+			Expect(method).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,150 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagepublish
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
+)
+
+// LayerMediaType is the media type of the layers of a pushed artifact.
+const LayerMediaType types.MediaType = "application/octet-stream"
+
+// PushOCI pushes the files as an OCI artifact with one layer for each file.
+// The OVF descriptor is the first layer, and each layer is named with the
+// imageimport.LayerTitleAnnotation annotation so the artifact may be imported
+// again.
+func PushOCI(
+	ctx context.Context,
+	spec vmopv1.VirtualMachinePublishRequestOCITarget,
+	paths []string,
+	opts Options) (Result, error) {
+
+	var nameOpts []name.Option
+	t := remote.DefaultTransport
+	if spec.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+		tr := remote.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // requested by the user
+		t = tr
+	}
+
+	ref, err := name.ParseReference(spec.Reference, nameOpts...)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: invalid reference: %w", ErrInvalidTarget, err)
+	}
+
+	files, err := statFiles(paths)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var (
+		img  = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+		adds = make([]mutate.Addendum, 0, len(files))
+		size int64
+	)
+	for _, f := range files {
+		l, err := newFileLayer(f)
+		if err != nil {
+			return Result{}, err
+		}
+		adds = append(adds, mutate.Addendum{
+			Layer:       l,
+			MediaType:   LayerMediaType,
+			Annotations: map[string]string{imageimport.LayerTitleAnnotation: f.name},
+		})
+		size += f.size
+	}
+	if img, err = mutate.Append(img, adds...); err != nil {
+		return Result{}, err
+	}
+
+	auth := opts.Auth
+	if auth == nil {
+		auth = authn.Anonymous
+	}
+
+	if err := remote.Write(ref, img,
+		remote.WithContext(ctx),
+		remote.WithAuth(auth),
+		remote.WithTransport(t)); err != nil {
+
+		return Result{}, fmt.Errorf("failed to push %s: %w", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Reference: ref.Context().Digest(digest.String()).String(),
+		Digest:    digest.String(),
+		Size:      size,
+	}, nil
+}
+
+// fileLayer is an uncompressed layer whose contents are read from a file.
+type fileLayer struct {
+	file   file
+	digest v1.Hash
+}
+
+func newFileLayer(f file) (*fileLayer, error) {
+	r, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	digest, _, err := v1.SHA256(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest of %s: %w", f.name, err)
+	}
+
+	return &fileLayer{file: f, digest: digest}, nil
+}
+
+func (l *fileLayer) Digest() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+func (l *fileLayer) DiffID() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+func (l *fileLayer) Compressed() (io.ReadCloser, error) {
+	return os.Open(l.file.path)
+}
+
+func (l *fileLayer) Uncompressed() (io.ReadCloser, error) {
+	return os.Open(l.file.path)
+}
+
+func (l *fileLayer) Size() (int64, error) {
+	return l.file.size, nil
+}
+
+func (l *fileLayer) MediaType() (types.MediaType, error) {
+	return LayerMediaType, nil
+}
@@ -0,0 +1,445 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imagesignature verifies the signatures of the OVF manifests of
+// content library items against a set of trusted certificates and public
+// keys.
+//
+// Two kinds of signatures are supported:
+//
+//   - An OVF manifest certificate, i.e. the .cert file that is produced when
+//     an OVF is signed with ovftool. The certificate chain in the file must
+//     verify to one of the trusted certificates.
+//   - A detached signature, i.e. a <manifest>.sig file that contains the
+//     base64 encoded signature of the manifest, as produced by
+//     "cosign sign-blob". The signature must verify with one of the trusted
+//     public keys.
+package imagesignature
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// ManifestExt is the extension of an OVF manifest.
+	ManifestExt = ".mf"
+
+	// CertificateExt is the extension of an OVF manifest certificate.
+	CertificateExt = ".cert"
+
+	// SignatureExt is the extension of a detached signature.
+	SignatureExt = ".sig"
+
+	// DescriptorExt is the extension of an OVF descriptor.
+	DescriptorExt = ".ovf"
+)
+
+// Method is the method with which a signature was verified.
+type Method string
+
+const (
+	// MethodCertificate indicates the manifest was verified with an OVF
+	// manifest certificate.
+	MethodCertificate Method = "Certificate"
+
+	// MethodSignature indicates the manifest was verified with a detached
+	// signature.
+	MethodSignature Method = "Signature"
+)
+
+var (
+	// ErrNotSigned is returned when there is no manifest, or the manifest
+	// does not have a certificate or signature.
+	ErrNotSigned = errors.New("image is not signed")
+
+	// ErrUntrusted is returned when the manifest is signed, but not by any of
+	// the trust roots.
+	ErrUntrusted = errors.New("image signer is not trusted")
+
+	// ErrInvalid is returned when a signature or certificate is malformed,
+	// or the manifest does not match the files it describes.
+	ErrInvalid = errors.New("image signature is invalid")
+)
+
+// manifestLineRx matches a line of an OVF manifest or certificate, ex.
+// SHA256(photon.ovf)= 0123...
+var manifestLineRx = regexp.MustCompile(`^(SHA1|SHA256|SHA512)\((.+)\)\s*=\s*([0-9a-fA-F]+)$`)
+
+// IsSignatureFile returns true if the named file of a library item is needed
+// to verify the item's signature.
+func IsSignatureFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ManifestExt, CertificateExt, SignatureExt, DescriptorExt:
+		return true
+	}
+	return false
+}
+
+// TrustRoots are the certificates and public keys that are trusted to sign
+// images.
+type TrustRoots struct {
+	// Certificates are the trusted CA certificates.
+	Certificates *x509.CertPool
+
+	// PublicKeys are the trusted public keys.
+	PublicKeys []crypto.PublicKey
+
+	// Digest uniquely identifies the contents of the trust roots.
+	Digest string
+}
+
+// ParseTrustRoots returns the trust roots from the PEM encoded CERTIFICATE and
+// PUBLIC KEY blocks in the provided data. PEM blocks of any other type are
+// ignored.
+func ParseTrustRoots(data map[string][]byte) (TrustRoots, error) {
+	roots := TrustRoots{
+		Certificates: x509.NewCertPool(),
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		rest := data[k]
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00", k, len(rest))
+		_, _ = h.Write(rest)
+
+		for {
+			var block *pem.Block
+			if block, rest = pem.Decode(rest); block == nil {
+				break
+			}
+			switch block.Type {
+			case "CERTIFICATE":
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return TrustRoots{}, fmt.Errorf("failed to parse certificate in %q: %w", k, err)
+				}
+				roots.Certificates.AddCert(cert)
+			case "PUBLIC KEY":
+				key, err := x509.ParsePKIXPublicKey(block.Bytes)
+				if err != nil {
+					return TrustRoots{}, fmt.Errorf("failed to parse public key in %q: %w", k, err)
+				}
+				roots.PublicKeys = append(roots.PublicKeys, key)
+			}
+		}
+	}
+	roots.Digest = hex.EncodeToString(h.Sum(nil))
+
+	return roots, nil
+}
+
+// Result describes a verified signature.
+type Result struct {
+	// Method is how the signature was verified.
+	Method Method
+
+	// Signer identifies who signed the manifest. It is the subject of the
+	// signing certificate, or the SHA-256 fingerprint of the public key.
+	Signer string
+}
+
+// Item is a library item whose signature is verified.
+type Item struct {
+	// Names are the names of all of the item's files.
+	Names []string
+
+	// Files are the contents of the item's files for which IsSignatureFile
+	// returns true, keyed by name.
+	Files map[string][]byte
+
+	// Copy writes the contents of one of the item's other files, ex. a disk,
+	// to w.
+	Copy func(name string, w io.Writer) error
+}
+
+// Verify verifies the signature of the item's OVF manifest, and that the
+// manifest covers each of the item's files other than the manifest, its
+// certificate and its signature with a matching digest.
+//
+// ErrNotSigned, ErrUntrusted or ErrInvalid are wrapped by the returned error
+// when the signature cannot be verified.
+func Verify(item Item, roots TrustRoots) (Result, error) {
+	files := item.Files
+
+	var manifestName string
+	for name := range files {
+		if strings.EqualFold(path.Ext(name), ManifestExt) {
+			if manifestName != "" {
+				return Result{}, fmt.Errorf("%w: more than one manifest", ErrInvalid)
+			}
+			manifestName = name
+		}
+	}
+	if manifestName == "" {
+		return Result{}, fmt.Errorf("%w: no manifest", ErrNotSigned)
+	}
+	manifest := files[manifestName]
+
+	var (
+		base = strings.TrimSuffix(manifestName, path.Ext(manifestName))
+		cert = files[base+CertificateExt]
+		sig  = files[manifestName+SignatureExt]
+		errs []error
+	)
+
+	if cert == nil && sig == nil {
+		return Result{}, fmt.Errorf("%w: manifest %s has no certificate or signature", ErrNotSigned, manifestName)
+	}
+
+	// The signature is verified before the manifest so the disks of an item
+	// that is not signed by a trust root are not read.
+	var result Result
+	if cert != nil {
+		r, err := verifyCertificate(manifestName, manifest, cert, roots)
+		if err == nil {
+			result = r
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	if result.Method == "" && sig != nil {
+		r, err := verifySignature(manifest, sig, roots)
+		if err == nil {
+			result = r
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	if result.Method == "" {
+		return Result{}, errors.Join(errs...)
+	}
+
+	if err := verifyManifest(manifest, item); err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// verifyManifest verifies that each of the item's files, other than the
+// manifest, its certificate and its signature, is in the manifest, and that
+// the digest of each file in the manifest matches the file.
+func verifyManifest(manifest []byte, item Item) error {
+	names := map[string]struct{}{}
+	for _, name := range item.Names {
+		names[name] = struct{}{}
+	}
+	for name := range item.Files {
+		names[name] = struct{}{}
+	}
+
+	covered := map[string]struct{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		m := manifestLineRx.FindStringSubmatch(line)
+		if m == nil {
+			return fmt.Errorf("%w: malformed manifest line %q", ErrInvalid, line)
+		}
+		name := m[2]
+		if _, ok := names[name]; !ok {
+			return fmt.Errorf("%w: manifest entry %s does not match a file", ErrInvalid, name)
+		}
+		if _, ok := covered[name]; ok {
+			return fmt.Errorf("%w: manifest has more than one entry for %s", ErrInvalid, name)
+		}
+		covered[name] = struct{}{}
+
+		h, _, err := newHash(m[1])
+		if err != nil {
+			return err
+		}
+		if data, ok := item.Files[name]; ok {
+			_, _ = h.Write(data)
+		} else {
+			if item.Copy == nil {
+				return fmt.Errorf("failed to read %s: no copy function", name)
+			}
+			if err := item.Copy(name, h); err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+		}
+		if !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), m[3]) {
+			return fmt.Errorf("%w: digest of %s does not match the manifest", ErrInvalid, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		switch strings.ToLower(path.Ext(name)) {
+		case ManifestExt, CertificateExt, SignatureExt:
+			continue
+		}
+		if _, ok := covered[name]; !ok {
+			return fmt.Errorf("%w: %s is not in the manifest", ErrInvalid, name)
+		}
+	}
+
+	return nil
+}
+
+// verifyCertificate verifies an OVF manifest certificate. The first line of
+// the certificate is the signature of the manifest, ex.
+// SHA256(photon.mf)= 0123..., and it is followed by the PEM encoded signing
+// certificate and any intermediate certificates.
+func verifyCertificate(
+	manifestName string,
+	manifest, data []byte,
+	roots TrustRoots) (Result, error) {
+
+	line, rest, _ := bytes.Cut(data, []byte("\n"))
+	m := manifestLineRx.FindStringSubmatch(strings.TrimSpace(string(line)))
+	if m == nil || m[2] != manifestName {
+		return Result{}, fmt.Errorf("%w: malformed certificate signature line", ErrInvalid)
+	}
+	_, hashAlg, err := newHash(m[1])
+	if err != nil {
+		return Result{}, err
+	}
+	sig, err := hex.DecodeString(m[3])
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return Result{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return Result{}, fmt.Errorf("%w: certificate file has no certificates", ErrInvalid)
+	}
+
+	leaf := certs[0]
+	if err := verifyWithKey(leaf.PublicKey, hashAlg, manifest, sig); err != nil {
+		return Result{}, err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if roots.Certificates == nil {
+		return Result{}, fmt.Errorf("%w: no trusted certificates", ErrUntrusted)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots.Certificates,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrUntrusted, err)
+	}
+
+	return Result{
+		Method: MethodCertificate,
+		Signer: leaf.Subject.String(),
+	}, nil
+}
+
+// verifySignature verifies a detached, base64 encoded signature of the
+// manifest with each of the trusted public keys.
+func verifySignature(manifest, data []byte, roots TrustRoots) (Result, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	for _, key := range roots.PublicKeys {
+		if verifyWithKey(key, crypto.SHA256, manifest, sig) != nil {
+			continue
+		}
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return Result{}, err
+		}
+		fingerprint := sha256.Sum256(der)
+		return Result{
+			Method: MethodSignature,
+			Signer: "SHA256:" + hex.EncodeToString(fingerprint[:]),
+		}, nil
+	}
+
+	return Result{}, fmt.Errorf("%w: signature does not match any trusted public key", ErrUntrusted)
+}
+
+// verifyWithKey verifies the signature of data with the public key.
+func verifyWithKey(key crypto.PublicKey, hashAlg crypto.Hash, data, sig []byte) error {
+	var digest []byte
+	if hashAlg != 0 {
+		h := hashAlg.New()
+		_, _ = h.Write(data)
+		digest = h.Sum(nil)
+	}
+
+	var ok bool
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(key, hashAlg, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(key, digest, sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, data, sig)
+	default:
+		return fmt.Errorf("%w: unsupported public key type %T", ErrInvalid, key)
+	}
+	if !ok {
+		return fmt.Errorf("%w: signature verification failed", ErrInvalid)
+	}
+	return nil
+}
+
+// newHash returns the hash for the name of an algorithm in an OVF manifest.
+// SHA1 is not supported for signatures.
+func newHash(name string) (hash.Hash, crypto.Hash, error) {
+	switch name {
+	case "SHA1":
+		return nil, 0, fmt.Errorf("%w: SHA1 is not supported", ErrInvalid)
+	case "SHA256":
+		return sha256.New(), crypto.SHA256, nil
+	case "SHA512":
+		return sha512.New(), crypto.SHA512, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unsupported algorithm %s", ErrInvalid, name)
+}
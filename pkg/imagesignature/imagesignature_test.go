@@ -0,0 +1,403 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagesignature_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/imagesignature"
+)
+
+const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1"/>
+`
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(cn string) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) issue(cn string) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return cert, key
+}
+
+func pemEncode(typ string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+}
+
+func pemPublicKey(key crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	Expect(err).ToNot(HaveOccurred())
+	return pemEncode("PUBLIC KEY", der)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func certFile(manifest []byte, cert *x509.Certificate, key *rsa.PrivateKey) []byte {
+	digest := sha256.Sum256(manifest)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	Expect(err).ToNot(HaveOccurred())
+	return append(
+		fmt.Appendf(nil, "SHA256(photon.mf)= %s\n", hex.EncodeToString(sig)),
+		pemEncode("CERTIFICATE", cert.Raw)...)
+}
+
+func parseRoots(data map[string][]byte) imagesignature.TrustRoots {
+	roots, err := imagesignature.ParseTrustRoots(data)
+	Expect(err).ToNot(HaveOccurred())
+	return roots
+}
+
+var _ = Describe("IsSignatureFile", func() {
+	DescribeTable("returns whether the file is needed",
+		func(name string, expected bool) {
+			Expect(imagesignature.IsSignatureFile(name)).To(Equal(expected))
+		},
+		Entry("manifest", "photon.mf", true),
+		Entry("certificate", "photon.cert", true),
+		Entry("signature", "photon.mf.sig", true),
+		Entry("descriptor", "photon.OVF", true),
+		Entry("disk", "photon-disk1.vmdk", false),
+		Entry("iso", "photon.iso", false),
+	)
+})
+
+var _ = Describe("ParseTrustRoots", func() {
+	var ca testCA
+
+	BeforeEach(func() {
+		ca = newTestCA("root")
+	})
+
+	It("parses certificates and public keys, and ignores other blocks", func() {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		roots := parseRoots(map[string][]byte{
+			"ca.pem":  pemEncode("CERTIFICATE", ca.cert.Raw),
+			"key.pem": append(pemPublicKey(pub), pemEncode("PRIVATE KEY", []byte("ignored"))...),
+		})
+		Expect(roots.PublicKeys).To(HaveLen(1))
+		Expect(roots.Digest).To(HaveLen(64))
+	})
+
+	It("returns a digest that changes with the data", func() {
+		data := map[string][]byte{"ca.pem": pemEncode("CERTIFICATE", ca.cert.Raw)}
+		digest := parseRoots(data).Digest
+		Expect(parseRoots(data).Digest).To(Equal(digest))
+
+		data["other.pem"] = pemEncode("CERTIFICATE", newTestCA("other").cert.Raw)
+		Expect(parseRoots(data).Digest).ToNot(Equal(digest))
+	})
+
+	It("returns an error for an invalid certificate", func() {
+		_, err := imagesignature.ParseTrustRoots(map[string][]byte{
+			"ca.pem": pemEncode("CERTIFICATE", []byte("invalid")),
+		})
+		Expect(err).To(MatchError(ContainSubstring(`failed to parse certificate in "ca.pem"`)))
+	})
+})
+
+var _ = Describe("Verify", func() {
+	const disk = "photon-disk1.vmdk"
+
+	var (
+		ca       testCA
+		manifest []byte
+		files    map[string][]byte
+		disks    map[string][]byte
+		copied   []string
+		roots    imagesignature.TrustRoots
+	)
+
+	item := func() imagesignature.Item {
+		names := make([]string, 0, len(files)+len(disks))
+		for name := range files {
+			names = append(names, name)
+		}
+		for name := range disks {
+			names = append(names, name)
+		}
+		return imagesignature.Item{
+			Names: names,
+			Files: files,
+			Copy: func(name string, w io.Writer) error {
+				copied = append(copied, name)
+				data, ok := disks[name]
+				if !ok {
+					return fmt.Errorf("file %s not found", name)
+				}
+				_, err := w.Write(data)
+				return err
+			},
+		}
+	}
+
+	sign := func() {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		files["photon.mf"] = manifest
+		files["photon.mf.sig"] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, manifest)))
+		roots = parseRoots(map[string][]byte{"ed25519.pub": pemPublicKey(pub)})
+	}
+
+	BeforeEach(func() {
+		ca = newTestCA("root")
+		disks = map[string][]byte{
+			disk: []byte("disk contents"),
+		}
+		copied = nil
+		manifest = fmt.Appendf(nil, "SHA256(photon.ovf)= %s\nSHA256(%s)= %s\n",
+			sha256Hex([]byte(ovfDescriptor)), disk, sha256Hex(disks[disk]))
+		files = map[string][]byte{
+			"photon.ovf": []byte(ovfDescriptor),
+			"photon.mf":  manifest,
+		}
+		roots = parseRoots(map[string][]byte{
+			"ca.pem": pemEncode("CERTIFICATE", ca.cert.Raw),
+		})
+	})
+
+	When("there is no manifest", func() {
+		It("returns ErrNotSigned", func() {
+			delete(files, "photon.mf")
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(imagesignature.ErrNotSigned))
+		})
+	})
+
+	When("the manifest has no certificate or signature", func() {
+		It("returns ErrNotSigned without reading the disks", func() {
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(imagesignature.ErrNotSigned))
+			Expect(copied).To(BeEmpty())
+		})
+	})
+
+	When("the manifest is signed", func() {
+		JustBeforeEach(func() {
+			sign()
+		})
+
+		It("verifies the digests of the descriptor and the disks", func() {
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copied).To(ConsistOf(disk))
+		})
+
+		When("the descriptor does not match the manifest", func() {
+			BeforeEach(func() {
+				files["photon.ovf"] = []byte("tampered")
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("digest of photon.ovf does not match")))
+			})
+		})
+
+		When("a disk does not match the manifest", func() {
+			BeforeEach(func() {
+				disks[disk] = []byte("tampered")
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("digest of " + disk + " does not match")))
+			})
+		})
+
+		When("a disk is not in the manifest", func() {
+			BeforeEach(func() {
+				disks["photon-disk2.vmdk"] = []byte("unsigned disk")
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("photon-disk2.vmdk is not in the manifest")))
+			})
+		})
+
+		When("a manifest entry does not match a file", func() {
+			BeforeEach(func() {
+				delete(disks, disk)
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("manifest entry " + disk + " does not match a file")))
+			})
+		})
+
+		When("the descriptor is swapped for one with another name", func() {
+			BeforeEach(func() {
+				delete(files, "photon.ovf")
+				files["evil.ovf"] = []byte("tampered")
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("manifest entry photon.ovf does not match a file")))
+			})
+		})
+
+		When("the descriptor is added with another name", func() {
+			BeforeEach(func() {
+				files["evil.ovf"] = []byte("tampered")
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("evil.ovf is not in the manifest")))
+			})
+		})
+
+		When("the manifest has more than one entry for a file", func() {
+			BeforeEach(func() {
+				manifest = fmt.Appendf(manifest, "SHA256(photon.ovf)= %s\n", sha256Hex([]byte(ovfDescriptor)))
+			})
+
+			It("returns ErrInvalid", func() {
+				_, err := imagesignature.Verify(item(), roots)
+				Expect(err).To(MatchError(imagesignature.ErrInvalid))
+				Expect(err).To(MatchError(ContainSubstring("more than one entry for photon.ovf")))
+			})
+		})
+	})
+
+	When("the manifest has a certificate", func() {
+		It("verifies a certificate issued by a trusted CA", func() {
+			cert, key := ca.issue("Image Signer")
+			files["photon.cert"] = certFile(manifest, cert, key)
+
+			result, err := imagesignature.Verify(item(), roots)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Method).To(Equal(imagesignature.MethodCertificate))
+			Expect(result.Signer).To(Equal("CN=Image Signer"))
+		})
+
+		It("returns ErrUntrusted for a certificate issued by another CA", func() {
+			cert, key := newTestCA("other").issue("Image Signer")
+			files["photon.cert"] = certFile(manifest, cert, key)
+
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(imagesignature.ErrUntrusted))
+		})
+
+		It("returns ErrInvalid when the signature does not match the manifest", func() {
+			cert, key := ca.issue("Image Signer")
+			files["photon.cert"] = certFile([]byte("other manifest"), cert, key)
+
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(imagesignature.ErrInvalid))
+		})
+
+		It("returns ErrInvalid for a SHA1 signature", func() {
+			cert, _ := ca.issue("Image Signer")
+			files["photon.cert"] = append(
+				[]byte("SHA1(photon.mf)= 00\n"),
+				pemEncode("CERTIFICATE", cert.Raw)...)
+
+			_, err := imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(ContainSubstring("SHA1 is not supported")))
+		})
+	})
+
+	When("the manifest has a detached signature", func() {
+		It("verifies an ECDSA signature with a trusted public key", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			digest := sha256.Sum256(manifest)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			Expect(err).ToNot(HaveOccurred())
+			files["photon.mf.sig"] = []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+			roots = parseRoots(map[string][]byte{"cosign.pub": pemPublicKey(&key.PublicKey)})
+
+			result, err := imagesignature.Verify(item(), roots)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Method).To(Equal(imagesignature.MethodSignature))
+			Expect(result.Signer).To(HavePrefix("SHA256:"))
+		})
+
+		It("verifies an Ed25519 signature with a trusted public key", func() {
+			pub, priv, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			files["photon.mf.sig"] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, manifest)))
+			roots = parseRoots(map[string][]byte{"ed25519.pub": pemPublicKey(pub)})
+
+			result, err := imagesignature.Verify(item(), roots)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Method).To(Equal(imagesignature.MethodSignature))
+		})
+
+		It("returns ErrUntrusted when no public key matches", func() {
+			_, priv, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			files["photon.mf.sig"] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, manifest)))
+
+			_, err = imagesignature.Verify(item(), roots)
+			Expect(err).To(MatchError(imagesignature.ErrUntrusted))
+		})
+	})
+})
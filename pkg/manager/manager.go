@@ -24,6 +24,7 @@ import (
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 	imgregv1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha2"
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
 	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
@@ -84,8 +85,11 @@ func New(ctx context.Context, opts Options) (Manager, error) {
 		_ = imgregv1.AddToScheme(opts.Scheme)
 	}
 
-	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+	switch pkgcfg.FromContext(ctx).NetworkProviderType {
+	case pkgcfg.NetworkProviderTypeVPC:
 		_ = vpcv1alpha1.AddToScheme(opts.Scheme)
+	case pkgcfg.NetworkProviderTypeNSXT:
+		_ = nsxv1alpha1.AddToScheme(opts.Scheme)
 	}
 
 	// Build the controller manager.
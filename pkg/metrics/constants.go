@@ -17,6 +17,10 @@ const (
 	specLabel            = "spec"
 	statusLabel          = "status"
 
+	// Storage version migration related metrics labels.
+	kindLabel  = "kind"
+	stateLabel = "state"
+
 	// VMImage related metrics labels (from image registry service).
 	vmiNameLabel      = "vmi_name"
 	vmiNamespaceLabel = "vmi_namespace"
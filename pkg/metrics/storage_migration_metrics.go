@@ -0,0 +1,100 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	storageMigrationStateExpected = "expected"
+	storageMigrationStateTotal    = "total"
+	storageMigrationStateMigrated = "migrated"
+	storageMigrationStateFailed   = "failed"
+	storageMigrationStateLossy    = "lossy"
+)
+
+var (
+	storageMigrationMetricsOnce sync.Once
+	storageMigrationMetrics     *StorageVersionMigrationMetrics
+)
+
+type StorageVersionMigrationMetrics struct {
+	objects  *prometheus.GaugeVec
+	progress *prometheus.GaugeVec
+}
+
+// NewStorageVersionMigrationMetrics initializes a singleton and registers all
+// the defined metrics.
+func NewStorageVersionMigrationMetrics() *StorageVersionMigrationMetrics {
+	storageMigrationMetricsOnce.Do(func() {
+		storageMigrationMetrics = &StorageVersionMigrationMetrics{
+			objects: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "storage_version_migration",
+				Name:      "objects",
+				Help:      "Number of objects processed by the current or last storage version migration of a kind",
+			}, []string{
+				kindLabel,
+				stateLabel,
+			}),
+			progress: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "storage_version_migration",
+				Name:      "progress_ratio",
+				Help:      "Ratio of migrated to expected objects of the current or last storage version migration of a kind",
+			}, []string{
+				kindLabel,
+			}),
+		}
+
+		metrics.Registry.MustRegister(
+			storageMigrationMetrics.objects,
+			storageMigrationMetrics.progress,
+		)
+	})
+
+	return storageMigrationMetrics
+}
+
+// RegisterStorageVersionMigration registers the progress, or the result, of
+// migrating the objects of the given kind to the storage version.
+func (m *StorageVersionMigrationMetrics) RegisterStorageVersionMigration(
+	logger logr.Logger,
+	kind string,
+	expected, total, migrated, failed, lossy int) {
+
+	for state, val := range map[string]int{
+		storageMigrationStateExpected: expected,
+		storageMigrationStateTotal:    total,
+		storageMigrationStateMigrated: migrated,
+		storageMigrationStateFailed:   failed,
+		storageMigrationStateLossy:    lossy,
+	} {
+		m.objects.With(prometheus.Labels{
+			kindLabel:  kind,
+			stateLabel: state,
+		}).Set(float64(val))
+	}
+
+	progress := 1.0
+	if expected > 0 {
+		progress = float64(migrated) / float64(expected)
+	}
+	m.progress.With(prometheus.Labels{kindLabel: kind}).Set(progress)
+
+	logger.V(5).WithValues(
+		"kind", kind,
+		"expected", expected,
+		"total", total,
+		"migrated", migrated,
+		"failed", failed,
+		"lossy", lossy).Info("Set metrics for storage version migration")
+}
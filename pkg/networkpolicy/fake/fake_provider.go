@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/networkpolicy"
+)
+
+// Provider is a fake network policy provider that records the policies it
+// applies. Set ApplyFn to override the result of Apply.
+type Provider struct {
+	sync.Mutex
+
+	ApplyFn func(ctx context.Context, policy *vmopv1.VirtualMachineNetworkPolicy) (networkpolicy.Status, error)
+
+	applied map[client.ObjectKey]vmopv1.VirtualMachineNetworkPolicySpec
+}
+
+var _ networkpolicy.Provider = &Provider{}
+
+// NewProvider returns a fake provider that realizes all of the policies.
+func NewProvider() *Provider {
+	return &Provider{
+		applied: map[client.ObjectKey]vmopv1.VirtualMachineNetworkPolicySpec{},
+	}
+}
+
+func (p *Provider) Name() string {
+	return "FAKE"
+}
+
+func (p *Provider) ObjectType() client.Object {
+	return nil
+}
+
+func (p *Provider) Apply(
+	ctx context.Context,
+	policy *vmopv1.VirtualMachineNetworkPolicy) (networkpolicy.Status, error) {
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.ApplyFn != nil {
+		status, err := p.ApplyFn(ctx, policy)
+		if err != nil {
+			return status, err
+		}
+		p.applied[client.ObjectKeyFromObject(policy)] = *policy.Spec.DeepCopy()
+		return status, nil
+	}
+
+	p.applied[client.ObjectKeyFromObject(policy)] = *policy.Spec.DeepCopy()
+	return networkpolicy.Status{Realized: true}, nil
+}
+
+// Applied returns the spec of the policy the last time it was applied.
+func (p *Provider) Applied(key client.ObjectKey) (vmopv1.VirtualMachineNetworkPolicySpec, bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	spec, ok := p.applied[key]
+	return spec, ok
+}
+
+// Reset clears the applied policies and ApplyFn.
+func (p *Provider) Reset() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ApplyFn = nil
+	p.applied = map[client.ObjectKey]vmopv1.VirtualMachineNetworkPolicySpec{}
+}
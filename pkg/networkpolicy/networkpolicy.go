@@ -0,0 +1,64 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package networkpolicy translates VirtualMachineNetworkPolicy resources into
+// the security constructs of the active network provider.
+package networkpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+// ErrProviderNotSupported is returned by NewProvider when the network provider
+// cannot enforce network policies.
+var ErrProviderNotSupported = errors.New("network provider does not support network policies")
+
+// Status describes whether the network provider has realized a policy.
+type Status struct {
+	// Realized is true when the provider enforces the policy.
+	Realized bool
+
+	// Message describes why the policy is not realized.
+	Message string
+}
+
+// Provider applies network policies with the security constructs of a
+// network provider.
+type Provider interface {
+	// Name returns the name of the network provider, ex. NSXT_VPC.
+	Name() string
+
+	// ObjectType returns the type of the security constructs the provider
+	// creates for a policy. The constructs are owned by the policy, so they
+	// are removed when the policy is deleted.
+	ObjectType() client.Object
+
+	// Apply creates or updates the security constructs for the policy, and
+	// returns whether the provider has realized them.
+	Apply(ctx context.Context, policy *vmopv1.VirtualMachineNetworkPolicy) (Status, error)
+}
+
+// NewProvider returns the Provider for the network provider type. An error
+// that wraps ErrProviderNotSupported is returned if the network provider
+// cannot enforce network policies.
+func NewProvider(
+	providerType pkgcfg.NetworkProviderType,
+	k8sClient client.Client) (Provider, error) {
+
+	switch providerType {
+	case pkgcfg.NetworkProviderTypeVPC:
+		return &vpcProvider{client: k8sClient}, nil
+	case pkgcfg.NetworkProviderTypeNSXT:
+		return &nsxtProvider{client: k8sClient}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrProviderNotSupported, providerType)
+	}
+}
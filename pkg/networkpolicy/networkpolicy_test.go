@@ -0,0 +1,345 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/networkpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("NewProvider", func() {
+	DescribeTable("returns the provider for the network provider type",
+		func(providerType pkgcfg.NetworkProviderType, expectedObj client.Object) {
+			p, err := networkpolicy.NewProvider(providerType, builder.NewFakeClient())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.Name()).To(Equal(string(providerType)))
+			Expect(p.ObjectType()).To(BeAssignableToTypeOf(expectedObj))
+		},
+		Entry("NSXT_VPC", pkgcfg.NetworkProviderTypeVPC, &vpcv1alpha1.SecurityPolicy{}),
+		Entry("NSXT", pkgcfg.NetworkProviderTypeNSXT, &nsxv1alpha1.SecurityPolicy{}),
+	)
+
+	DescribeTable("returns an error for the network providers that do not support policies",
+		func(providerType pkgcfg.NetworkProviderType) {
+			_, err := networkpolicy.NewProvider(providerType, builder.NewFakeClient())
+			Expect(err).To(MatchError(networkpolicy.ErrProviderNotSupported))
+		},
+		Entry("VSPHERE_NETWORK", pkgcfg.NetworkProviderTypeVDS),
+		Entry("NAMED", pkgcfg.NetworkProviderTypeNamed),
+		Entry("empty", pkgcfg.NetworkProviderType("")),
+	)
+})
+
+var _ = Describe("Translate", func() {
+	var (
+		allow = vpcv1alpha1.RuleActionAllow
+		drop  = vpcv1alpha1.RuleActionDrop
+		in    = vpcv1alpha1.RuleDirectionIn
+		out   = vpcv1alpha1.RuleDirectionOut
+
+		dbSelector  = metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}}
+		appSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "app"}}
+		nsSelector  = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+
+		policy *vmopv1.VirtualMachineNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		policy = &vmopv1.VirtualMachineNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "my-ns",
+			},
+			Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+				VMSelector: dbSelector,
+			},
+		}
+	})
+
+	It("isolates the selected VMs from ingress traffic by default", func() {
+		Expect(networkpolicy.Translate(policy)).To(Equal(vpcv1alpha1.SecurityPolicySpec{
+			AppliedTo: []vpcv1alpha1.SecurityPolicyTarget{{VMSelector: &dbSelector}},
+			Rules: []vpcv1alpha1.SecurityPolicyRule{
+				{Name: "ingress-isolation", Action: &drop, Direction: &in},
+			},
+		}))
+	})
+
+	It("allows the traffic from the app tier to the database port", func() {
+		policy.Spec.Ingress = []vmopv1.VirtualMachineNetworkPolicyIngressRule{
+			{
+				From: []vmopv1.VirtualMachineNetworkPolicyPeer{
+					{VMSelector: appSelector},
+				},
+				Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+					{Port: ptr.To(intstr.FromInt32(5432))},
+				},
+			},
+		}
+
+		Expect(networkpolicy.Translate(policy)).To(Equal(vpcv1alpha1.SecurityPolicySpec{
+			AppliedTo: []vpcv1alpha1.SecurityPolicyTarget{{VMSelector: &dbSelector}},
+			Rules: []vpcv1alpha1.SecurityPolicyRule{
+				{
+					Name:      "ingress-0",
+					Action:    &allow,
+					Direction: &in,
+					Sources:   []vpcv1alpha1.SecurityPolicyPeer{{VMSelector: appSelector}},
+					Ports: []vpcv1alpha1.SecurityPolicyPort{
+						{Protocol: corev1.ProtocolTCP, Port: intstr.FromInt32(5432)},
+					},
+				},
+				{Name: "ingress-isolation", Action: &drop, Direction: &in},
+			},
+		}))
+	})
+
+	It("translates egress rules with namespace selectors, IP blocks, and port ranges", func() {
+		policy.Spec.Egress = []vmopv1.VirtualMachineNetworkPolicyEgressRule{
+			{
+				To: []vmopv1.VirtualMachineNetworkPolicyPeer{
+					{NamespaceSelector: nsSelector},
+					{IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0/24"}},
+				},
+				Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+					{
+						Protocol: ptr.To(corev1.ProtocolUDP),
+						Port:     ptr.To(intstr.FromInt32(5000)),
+						EndPort:  ptr.To[int32](5010),
+					},
+				},
+			},
+		}
+
+		Expect(networkpolicy.Translate(policy)).To(Equal(vpcv1alpha1.SecurityPolicySpec{
+			AppliedTo: []vpcv1alpha1.SecurityPolicyTarget{{VMSelector: &dbSelector}},
+			Rules: []vpcv1alpha1.SecurityPolicyRule{
+				{Name: "ingress-isolation", Action: &drop, Direction: &in},
+				{
+					Name:      "egress-0",
+					Action:    &allow,
+					Direction: &out,
+					Destinations: []vpcv1alpha1.SecurityPolicyPeer{
+						{VMSelector: &metav1.LabelSelector{}, NamespaceSelector: nsSelector},
+						{IPBlocks: []vpcv1alpha1.IPBlock{{CIDR: "10.0.0.0/24"}}},
+					},
+					Ports: []vpcv1alpha1.SecurityPolicyPort{
+						{Protocol: corev1.ProtocolUDP, Port: intstr.FromInt32(5000), EndPort: 5010},
+					},
+				},
+				{Name: "egress-isolation", Action: &drop, Direction: &out},
+			},
+		}))
+	})
+
+	It("only applies to the directions of the policy types", func() {
+		policy.Spec.PolicyTypes = []vmopv1.VirtualMachineNetworkPolicyType{
+			vmopv1.VirtualMachineNetworkPolicyTypeEgress,
+		}
+		policy.Spec.Ingress = []vmopv1.VirtualMachineNetworkPolicyIngressRule{{}}
+
+		Expect(networkpolicy.Translate(policy)).To(Equal(vpcv1alpha1.SecurityPolicySpec{
+			AppliedTo: []vpcv1alpha1.SecurityPolicyTarget{{VMSelector: &dbSelector}},
+			Rules: []vpcv1alpha1.SecurityPolicyRule{
+				{Name: "egress-isolation", Action: &drop, Direction: &out},
+			},
+		}))
+	})
+})
+
+var _ = Describe("Apply", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		policy    *vmopv1.VirtualMachineNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		policy = &vmopv1.VirtualMachineNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "my-ns",
+				UID:       "policy-uid",
+			},
+			Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+				VMSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "db"},
+				},
+			},
+		}
+	})
+
+	Context("NSXT_VPC", func() {
+		var provider networkpolicy.Provider
+
+		JustBeforeEach(func() {
+			var err error
+			provider, err = networkpolicy.NewProvider(pkgcfg.NetworkProviderTypeVPC, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the SecurityPolicy does not exist", func() {
+			BeforeEach(func() {
+				k8sClient = builder.NewFakeClient()
+			})
+
+			It("creates the SecurityPolicy", func() {
+				status, err := provider.Apply(ctx, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Realized).To(BeFalse())
+				Expect(status.Message).To(Equal("Waiting for the SecurityPolicy to be realized"))
+
+				sp := &vpcv1alpha1.SecurityPolicy{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), sp)).To(Succeed())
+				Expect(metav1.IsControlledBy(sp, policy)).To(BeTrue())
+				Expect(sp.Spec).To(Equal(networkpolicy.Translate(policy)))
+			})
+		})
+
+		When("the SecurityPolicy is realized", func() {
+			BeforeEach(func() {
+				sp := &vpcv1alpha1.SecurityPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      policy.Name,
+						Namespace: policy.Namespace,
+					},
+					Status: vpcv1alpha1.SecurityPolicyStatus{
+						Conditions: []vpcv1alpha1.Condition{
+							{Type: vpcv1alpha1.Ready, Status: corev1.ConditionTrue},
+						},
+					},
+				}
+				k8sClient = builder.NewFakeClient(sp)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sp), sp)).To(Succeed())
+				sp.OwnerReferences = []metav1.OwnerReference{
+					{
+						APIVersion: vmopv1.GroupVersion.String(),
+						Kind:       "VirtualMachineNetworkPolicy",
+						Name:       policy.Name,
+						UID:        policy.UID,
+						Controller: ptr.To(true),
+					},
+				}
+				Expect(k8sClient.Update(ctx, sp)).To(Succeed())
+			})
+
+			It("updates the SecurityPolicy and reports it is realized", func() {
+				status, err := provider.Apply(ctx, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Realized).To(BeTrue())
+
+				sp := &vpcv1alpha1.SecurityPolicy{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), sp)).To(Succeed())
+				Expect(sp.Spec).To(Equal(networkpolicy.Translate(policy)))
+			})
+		})
+
+		When("the SecurityPolicy is not realized", func() {
+			BeforeEach(func() {
+				k8sClient = builder.NewFakeClient()
+			})
+
+			It("reports the SecurityPolicy's message", func() {
+				_, err := provider.Apply(ctx, policy)
+				Expect(err).ToNot(HaveOccurred())
+
+				sp := &vpcv1alpha1.SecurityPolicy{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), sp)).To(Succeed())
+				sp.Status.Conditions = []vpcv1alpha1.Condition{
+					{
+						Type:    vpcv1alpha1.Ready,
+						Status:  corev1.ConditionFalse,
+						Reason:  "SecurityPolicyNotReady",
+						Message: "Error occurred while processing the SecurityPolicy CR",
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, sp)).To(Succeed())
+
+				status, err := provider.Apply(ctx, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Realized).To(BeFalse())
+				Expect(status.Message).To(Equal("Error occurred while processing the SecurityPolicy CR"))
+			})
+		})
+
+		When("a SecurityPolicy that is not controlled by the policy exists", func() {
+			BeforeEach(func() {
+				k8sClient = builder.NewFakeClient(&vpcv1alpha1.SecurityPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      policy.Name,
+						Namespace: policy.Namespace,
+					},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := provider.Apply(ctx, policy)
+				Expect(err).To(MatchError(ContainSubstring(
+					"SecurityPolicy my-ns/db already exists and is not controlled by the policy")))
+			})
+		})
+	})
+
+	Context("NSXT", func() {
+		BeforeEach(func() {
+			k8sClient = builder.NewFakeClient()
+			policy.Spec.Ingress = []vmopv1.VirtualMachineNetworkPolicyIngressRule{
+				{
+					Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+						{Port: ptr.To(intstr.FromString("postgres"))},
+					},
+				},
+			}
+		})
+
+		It("creates the SecurityPolicy", func() {
+			provider, err := networkpolicy.NewProvider(pkgcfg.NetworkProviderTypeNSXT, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			status, err := provider.Apply(ctx, policy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Realized).To(BeFalse())
+
+			sp := &nsxv1alpha1.SecurityPolicy{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), sp)).To(Succeed())
+			Expect(metav1.IsControlledBy(sp, policy)).To(BeTrue())
+
+			allow := nsxv1alpha1.RuleActionAllow
+			drop := nsxv1alpha1.RuleActionDrop
+			in := nsxv1alpha1.RuleDirectionIn
+			Expect(sp.Spec).To(Equal(nsxv1alpha1.SecurityPolicySpec{
+				AppliedTo: []nsxv1alpha1.SecurityPolicyTarget{{VMSelector: &policy.Spec.VMSelector}},
+				Rules: []nsxv1alpha1.SecurityPolicyRule{
+					{
+						Name:      "ingress-0",
+						Action:    &allow,
+						Direction: &in,
+						Ports: []nsxv1alpha1.SecurityPolicyPort{
+							{Protocol: corev1.ProtocolTCP, Port: intstr.FromString("postgres")},
+						},
+					},
+					{Name: "ingress-isolation", Action: &drop, Direction: &in},
+				},
+			}))
+		})
+	})
+})
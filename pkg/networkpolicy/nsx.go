@@ -0,0 +1,256 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+// vpcProvider enforces policies with NSX VPC SecurityPolicy resources.
+type vpcProvider struct {
+	client client.Client
+}
+
+func (p *vpcProvider) Name() string {
+	return string(pkgcfg.NetworkProviderTypeVPC)
+}
+
+func (p *vpcProvider) ObjectType() client.Object {
+	return &vpcv1alpha1.SecurityPolicy{}
+}
+
+func (p *vpcProvider) Apply(
+	ctx context.Context,
+	policy *vmopv1.VirtualMachineNetworkPolicy) (Status, error) {
+
+	sp := &vpcv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policy.Name,
+			Namespace: policy.Namespace,
+		},
+	}
+
+	if _, err := controllerutil.CreateOrPatch(ctx, p.client, sp, func() error {
+		if err := setOwner(p.client, policy, sp); err != nil {
+			return err
+		}
+		sp.Spec = Translate(policy)
+		return nil
+	}); err != nil {
+		return Status{}, fmt.Errorf("failed to create or patch SecurityPolicy %s: %w",
+			client.ObjectKeyFromObject(sp), err)
+	}
+
+	for _, c := range sp.Status.Conditions {
+		if c.Type == vpcv1alpha1.Ready {
+			return readyStatus(c.Status, c.Reason, c.Message), nil
+		}
+	}
+	return readyStatus(corev1.ConditionUnknown, "", ""), nil
+}
+
+// nsxtProvider enforces policies with NSX-T SecurityPolicy resources, which
+// are realized as distributed firewall rules.
+type nsxtProvider struct {
+	client client.Client
+}
+
+func (p *nsxtProvider) Name() string {
+	return string(pkgcfg.NetworkProviderTypeNSXT)
+}
+
+func (p *nsxtProvider) ObjectType() client.Object {
+	return &nsxv1alpha1.SecurityPolicy{}
+}
+
+func (p *nsxtProvider) Apply(
+	ctx context.Context,
+	policy *vmopv1.VirtualMachineNetworkPolicy) (Status, error) {
+
+	sp := &nsxv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policy.Name,
+			Namespace: policy.Namespace,
+		},
+	}
+
+	if _, err := controllerutil.CreateOrPatch(ctx, p.client, sp, func() error {
+		if err := setOwner(p.client, policy, sp); err != nil {
+			return err
+		}
+		// The NSX-T and VPC SecurityPolicy APIs share the same schema.
+		data, err := json.Marshal(Translate(policy))
+		if err != nil {
+			return err
+		}
+		sp.Spec = nsxv1alpha1.SecurityPolicySpec{}
+		return json.Unmarshal(data, &sp.Spec)
+	}); err != nil {
+		return Status{}, fmt.Errorf("failed to create or patch SecurityPolicy %s: %w",
+			client.ObjectKeyFromObject(sp), err)
+	}
+
+	for _, c := range sp.Status.Conditions {
+		if c.Type == nsxv1alpha1.Ready {
+			return readyStatus(c.Status, c.Reason, c.Message), nil
+		}
+	}
+	return readyStatus(corev1.ConditionUnknown, "", ""), nil
+}
+
+// setOwner makes the policy the controller of the object, unless the object
+// already exists and was not created for the policy.
+func setOwner(
+	k8sClient client.Client,
+	policy *vmopv1.VirtualMachineNetworkPolicy,
+	obj client.Object) error {
+
+	if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, policy) {
+		return fmt.Errorf("SecurityPolicy %s already exists and is not controlled by the policy",
+			client.ObjectKeyFromObject(obj))
+	}
+	return controllerutil.SetControllerReference(policy, obj, k8sClient.Scheme())
+}
+
+func readyStatus(status corev1.ConditionStatus, reason, message string) Status {
+	switch {
+	case status == corev1.ConditionTrue:
+		return Status{Realized: true}
+	case message != "":
+		return Status{Message: message}
+	case reason != "":
+		return Status{Message: reason}
+	default:
+		return Status{Message: "Waiting for the SecurityPolicy to be realized"}
+	}
+}
+
+// Translate returns the spec of the NSX SecurityPolicy that enforces the
+// policy.
+//
+// The traffic allowed by each of the policy's rules is allowed by a rule of
+// the SecurityPolicy, in order. For each direction to which the policy
+// applies, a final rule drops the rest of the traffic to or from the selected
+// VMs.
+func Translate(policy *vmopv1.VirtualMachineNetworkPolicy) vpcv1alpha1.SecurityPolicySpec {
+	var (
+		allow           = vpcv1alpha1.RuleActionAllow
+		drop            = vpcv1alpha1.RuleActionDrop
+		in              = vpcv1alpha1.RuleDirectionIn
+		out             = vpcv1alpha1.RuleDirectionOut
+		ingress, egress = policyTypes(policy.Spec)
+	)
+
+	spec := vpcv1alpha1.SecurityPolicySpec{
+		AppliedTo: []vpcv1alpha1.SecurityPolicyTarget{
+			{
+				VMSelector: policy.Spec.VMSelector.DeepCopy(),
+			},
+		},
+	}
+
+	if ingress {
+		for i, r := range policy.Spec.Ingress {
+			spec.Rules = append(spec.Rules, vpcv1alpha1.SecurityPolicyRule{
+				Name:      fmt.Sprintf("ingress-%d", i),
+				Action:    &allow,
+				Direction: &in,
+				Sources:   translatePeers(r.From),
+				Ports:     translatePorts(r.Ports),
+			})
+		}
+		spec.Rules = append(spec.Rules, vpcv1alpha1.SecurityPolicyRule{
+			Name:      "ingress-isolation",
+			Action:    &drop,
+			Direction: &in,
+		})
+	}
+
+	if egress {
+		for i, r := range policy.Spec.Egress {
+			spec.Rules = append(spec.Rules, vpcv1alpha1.SecurityPolicyRule{
+				Name:         fmt.Sprintf("egress-%d", i),
+				Action:       &allow,
+				Direction:    &out,
+				Destinations: translatePeers(r.To),
+				Ports:        translatePorts(r.Ports),
+			})
+		}
+		spec.Rules = append(spec.Rules, vpcv1alpha1.SecurityPolicyRule{
+			Name:      "egress-isolation",
+			Action:    &drop,
+			Direction: &out,
+		})
+	}
+
+	return spec
+}
+
+// policyTypes returns whether the policy applies to ingress and egress
+// traffic.
+func policyTypes(spec vmopv1.VirtualMachineNetworkPolicySpec) (ingress, egress bool) {
+	if len(spec.PolicyTypes) == 0 {
+		return true, len(spec.Egress) > 0
+	}
+	return slices.Contains(spec.PolicyTypes, vmopv1.VirtualMachineNetworkPolicyTypeIngress),
+		slices.Contains(spec.PolicyTypes, vmopv1.VirtualMachineNetworkPolicyTypeEgress)
+}
+
+func translatePeers(peers []vmopv1.VirtualMachineNetworkPolicyPeer) []vpcv1alpha1.SecurityPolicyPeer {
+	var out []vpcv1alpha1.SecurityPolicyPeer
+	for _, p := range peers {
+		if p.IPBlock != nil {
+			out = append(out, vpcv1alpha1.SecurityPolicyPeer{
+				IPBlocks: []vpcv1alpha1.IPBlock{{CIDR: p.IPBlock.CIDR}},
+			})
+			continue
+		}
+
+		peer := vpcv1alpha1.SecurityPolicyPeer{
+			VMSelector:        p.VMSelector.DeepCopy(),
+			NamespaceSelector: p.NamespaceSelector.DeepCopy(),
+		}
+		if peer.VMSelector == nil {
+			// Only the VMs, and not the pods, in the namespaces are selected.
+			peer.VMSelector = &metav1.LabelSelector{}
+		}
+		out = append(out, peer)
+	}
+	return out
+}
+
+func translatePorts(ports []vmopv1.VirtualMachineNetworkPolicyPort) []vpcv1alpha1.SecurityPolicyPort {
+	var out []vpcv1alpha1.SecurityPolicyPort
+	for _, p := range ports {
+		port := vpcv1alpha1.SecurityPolicyPort{
+			Protocol: corev1.ProtocolTCP,
+		}
+		if p.Protocol != nil {
+			port.Protocol = *p.Protocol
+		}
+		if p.Port != nil {
+			port.Port = *p.Port
+		}
+		if p.EndPort != nil {
+			port.EndPort = int(*p.EndPort)
+		}
+		out = append(out, port)
+	}
+	return out
+}
@@ -7,6 +7,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/vmware/govmomi/object"
@@ -19,6 +20,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
@@ -37,17 +39,33 @@ type funcs struct {
 	CleanupVirtualMachineFn             func(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachineFn             func(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
-	GetVirtualMachineGuestHeartbeatFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
-	GetVirtualMachinePropertiesFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
-	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
-	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
-	PlaceVirtualMachineGroupFn         func(ctx context.Context, group *vmopv1.VirtualMachineGroup, groupPlacement []providers.VMGroupPlacement) error
+	ExportVirtualMachineFn                func(ctx context.Context, vm *vmopv1.VirtualMachine, name, dir string) ([]string, error)
+	GetVirtualMachineGuestHeartbeatFn     func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
+	GetVirtualMachinePropertiesFn         func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
+	GetVirtualMachineWebMKSTicketFn       func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
+	GetVirtualMachineHardwareVersionFn    func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	PlaceVirtualMachineGroupFn            func(ctx context.Context, group *vmopv1.VirtualMachineGroup, groupPlacement []providers.VMGroupPlacement) error
+	PlaceVirtualMachinesFn                func(ctx context.Context, vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult
+	EvaluateVirtualMachineHostPlacementFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (providers.VMHostPlacementEvaluation, error)
+	RelocateVirtualMachineToHostFn        func(ctx context.Context, vm *vmopv1.VirtualMachine, hostMoID, poolMoID string) error
+	CopyFileToVirtualMachineGuestFn       func(ctx context.Context, vm *vmopv1.VirtualMachine, creds providers.GuestCredentials,
+		guestPath string, data []byte, overwrite bool) error
+	CopyFileFromVirtualMachineGuestFn func(ctx context.Context, vm *vmopv1.VirtualMachine, creds providers.GuestCredentials,
+		guestPath string, maxSize int64) ([]byte, error)
+	RunProgramInVirtualMachineGuestFn func(ctx context.Context, vm *vmopv1.VirtualMachine, creds providers.GuestCredentials,
+		spec providers.GuestProgramSpec) (providers.GuestProgramResult, error)
 
 	GetItemFromLibraryByNameFn   func(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
 	GetItemFromInventoryByNameFn func(ctx context.Context, contentLibrary, itemName string) (object.Reference, error)
 	ContainsExtraConfigEntryFn   func(ctx context.Context, objVM *object.VirtualMachine, key, value string) (bool, error)
 	UpdateContentLibraryItemFn   func(ctx context.Context, itemID, newName string, newDescription *string) error
 	SyncVirtualMachineImageFn    func(ctx context.Context, cli, vmi client.Object) error
+	ImportContentLibraryItemFn   func(ctx context.Context, libraryUUID, itemName, itemDescription string,
+		src imageimport.Source) (string, error)
+	DeleteContentLibraryItemFn   func(ctx context.Context, itemID string) error
+	GetContentLibraryItemFilesFn func(ctx context.Context, itemID string, match func(name string) bool) (map[string][]byte, error)
+	ListContentLibraryItemFilesFn func(ctx context.Context, itemID string) ([]string, error)
+	CopyContentLibraryItemFileFn  func(ctx context.Context, itemID, name string, w io.Writer) error
 
 	UpdateVcPNIDFn           func(ctx context.Context, vcPNID, vcPort string) error
 	UpdateVcCredsFn          func(ctx context.Context, data map[string][]byte) error
@@ -152,6 +170,24 @@ func (s *VMProvider) PublishVirtualMachine(
 	return "dummy-id", nil
 }
 
+func (s *VMProvider) ExportVirtualMachine(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	name, dir string) ([]string, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	// Do not hold the lock while the export runs since it may run in the
+	// background while other functions are called.
+	s.Lock()
+	fn := s.ExportVirtualMachineFn
+	s.Unlock()
+	if fn != nil {
+		return fn(ctx, vm, name, dir)
+	}
+	return nil, nil
+}
+
 func (s *VMProvider) GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
 	_ = pkgcfg.FromContext(ctx)
 
@@ -211,6 +247,99 @@ func (s *VMProvider) PlaceVirtualMachineGroup(ctx context.Context, group *vmopv1
 	return nil
 }
 
+func (s *VMProvider) PlaceVirtualMachines(ctx context.Context, vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.PlaceVirtualMachinesFn != nil {
+		return s.PlaceVirtualMachinesFn(ctx, vms)
+	}
+	results := make([]providers.VMPlacementResult, len(vms))
+	for i := range results {
+		results[i].Placement = &vmopv1.VirtualMachinePlacementStatus{}
+	}
+	return results
+}
+
+func (s *VMProvider) EvaluateVirtualMachineHostPlacement(ctx context.Context, vm *vmopv1.VirtualMachine) (providers.VMHostPlacementEvaluation, error) {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.EvaluateVirtualMachineHostPlacementFn != nil {
+		return s.EvaluateVirtualMachineHostPlacementFn(ctx, vm)
+	}
+	return providers.VMHostPlacementEvaluation{}, nil
+}
+
+func (s *VMProvider) RelocateVirtualMachineToHost(ctx context.Context, vm *vmopv1.VirtualMachine, hostMoID, poolMoID string) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.RelocateVirtualMachineToHostFn != nil {
+		return s.RelocateVirtualMachineToHostFn(ctx, vm, hostMoID, poolMoID)
+	}
+	return nil
+}
+
+func (s *VMProvider) CopyFileToVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	data []byte,
+	overwrite bool) error {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	// Do not hold the lock while the guest operations run since they may run
+	// in the background while other functions are called.
+	s.Lock()
+	fn := s.CopyFileToVirtualMachineGuestFn
+	s.Unlock()
+	if fn != nil {
+		return fn(ctx, vm, creds, guestPath, data, overwrite)
+	}
+	return nil
+}
+
+func (s *VMProvider) CopyFileFromVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	maxSize int64) ([]byte, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	fn := s.CopyFileFromVirtualMachineGuestFn
+	s.Unlock()
+	if fn != nil {
+		return fn(ctx, vm, creds, guestPath, maxSize)
+	}
+	return nil, nil
+}
+
+func (s *VMProvider) RunProgramInVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	fn := s.RunProgramInVirtualMachineGuestFn
+	s.Unlock()
+	if fn != nil {
+		return fn(ctx, vm, creds, spec)
+	}
+	return providers.GuestProgramResult{}, nil
+}
+
 func (s *VMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	_ = pkgcfg.FromContext(ctx)
 
@@ -328,6 +457,79 @@ func (s *VMProvider) UpdateContentLibraryItem(ctx context.Context, itemID, newNa
 	return nil
 }
 
+func (s *VMProvider) ImportContentLibraryItem(
+	ctx context.Context,
+	libraryUUID, itemName, itemDescription string,
+	src imageimport.Source) (string, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	// Do not hold the lock while the import runs since it may run in the
+	// background while other functions are called.
+	s.Lock()
+	fn := s.ImportContentLibraryItemFn
+	s.Unlock()
+	if fn != nil {
+		return fn(ctx, libraryUUID, itemName, itemDescription, src)
+	}
+	return "", nil
+}
+
+func (s *VMProvider) DeleteContentLibraryItem(ctx context.Context, itemID string) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.DeleteContentLibraryItemFn != nil {
+		return s.DeleteContentLibraryItemFn(ctx, itemID)
+	}
+	return nil
+}
+
+func (s *VMProvider) GetContentLibraryItemFiles(
+	ctx context.Context,
+	itemID string,
+	match func(name string) bool) (map[string][]byte, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.GetContentLibraryItemFilesFn != nil {
+		return s.GetContentLibraryItemFilesFn(ctx, itemID, match)
+	}
+	return nil, nil
+}
+
+func (s *VMProvider) ListContentLibraryItemFiles(
+	ctx context.Context,
+	itemID string) ([]string, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.ListContentLibraryItemFilesFn != nil {
+		return s.ListContentLibraryItemFilesFn(ctx, itemID)
+	}
+	return nil, nil
+}
+
+func (s *VMProvider) CopyContentLibraryItemFile(
+	ctx context.Context,
+	itemID, name string,
+	w io.Writer) error {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.CopyContentLibraryItemFileFn != nil {
+		return s.CopyContentLibraryItemFileFn(ctx, itemID, name, w)
+	}
+	return nil
+}
+
 func (s *VMProvider) GetTasksByActID(ctx context.Context, vm *vmopv1.VirtualMachine, actID string) (tasksInfo []vimtypes.TaskInfo, retErr error) {
 	_ = pkgcfg.FromContext(ctx)
 
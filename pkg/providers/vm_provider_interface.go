@@ -7,6 +7,7 @@ package providers
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vapi/library"
@@ -16,6 +17,7 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
 
@@ -29,6 +31,11 @@ var (
 	// CreateOrUpdateVirtualMachine and DeleteVirtualMachine functions when
 	// the VM is still being reconciled in a background thread.
 	ErrReconcileInProgress = errors.New("reconcile already in progress")
+
+	// ErrGuestFileTooLarge is returned from the
+	// CopyFileFromVirtualMachineGuest function when the guest file is larger
+	// than the maximum size.
+	ErrGuestFileTooLarge = errors.New("guest file is too large")
 )
 
 type VMGroupPlacement struct {
@@ -36,6 +43,75 @@ type VMGroupPlacement struct {
 	VMMembers []*vmopv1.VirtualMachine
 }
 
+// VMPlacementResult is where a VM would be placed if it were created, or why
+// it could not be placed.
+type VMPlacementResult struct {
+	Placement *vmopv1.VirtualMachinePlacementStatus
+	Err       error
+}
+
+// VMHostPlacementEvaluation is the result of evaluating a VM's preferred
+// placement rules across the hosts in the cluster on which it is running.
+type VMHostPlacementEvaluation struct {
+	// Violations describes the preferred rules that are violated on the VM's
+	// current host.
+	Violations []string
+
+	// TargetHost is the name of the host to which the VM may be moved so that
+	// fewer of its preferred rules are violated. It is empty when there is no
+	// such host.
+	TargetHost string
+
+	// TargetHostMoID is the MoID of TargetHost.
+	TargetHostMoID string
+
+	// TargetPoolMoID is the MoID of the ResourcePool in the cluster of
+	// TargetHost that the VM is moved to.
+	TargetPoolMoID string
+
+	// TargetViolations describes the preferred rules that would be violated
+	// on TargetHost.
+	TargetViolations []string
+}
+
+// GuestCredentials are the credentials of the guest OS user as whom guest
+// operations are performed.
+type GuestCredentials struct {
+	Username string
+	Password string
+}
+
+// GuestProgramSpec describes a program that is run in a VM's guest.
+type GuestProgramSpec struct {
+	// Path is the path of the program in the guest.
+	Path string
+
+	// Args are the arguments passed to the program.
+	Args []string
+
+	// Env are the program's environment variables in the form NAME=VALUE.
+	Env []string
+
+	// WorkingDirectory is the program's working directory in the guest.
+	WorkingDirectory string
+
+	// MaxOutputBytes is the number of bytes of the program's standard output
+	// and standard error that are returned.
+	MaxOutputBytes int64
+}
+
+// GuestProgramResult is the result of a program that was run in a VM's
+// guest.
+type GuestProgramResult struct {
+	ExitCode int32
+	Stdout   []byte
+	Stderr   []byte
+
+	// OutputTruncated is true when Stdout or Stderr was truncated to
+	// GuestProgramSpec.MaxOutputBytes.
+	OutputTruncated bool
+}
+
 // VirtualMachineProviderInterface is a pluggable interface for VM Providers.
 type VirtualMachineProviderInterface interface {
 	CreateOrUpdateVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error
@@ -47,11 +123,40 @@ type VirtualMachineProviderInterface interface {
 	CleanupVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
+	// ExportVirtualMachine exports the VM as an OVF into the directory dir.
+	// The paths of the exported files are returned with the OVF descriptor
+	// first.
+	ExportVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine, name, dir string) ([]string, error)
 	GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
 	GetVirtualMachineProperties(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
 	GetVirtualMachineWebMKSTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
 	GetVirtualMachineHardwareVersion(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
 	PlaceVirtualMachineGroup(ctx context.Context, group *vmopv1.VirtualMachineGroup, groupPlacements []VMGroupPlacement) error
+	// PlaceVirtualMachines returns where each of the VMs would be placed if
+	// they were created in order, without creating them or updating the VM
+	// objects. The results are in the same order as the VMs.
+	PlaceVirtualMachines(ctx context.Context, vms []*vmopv1.VirtualMachine) []VMPlacementResult
+	// EvaluateVirtualMachineHostPlacement returns the VM's preferred rules
+	// that are violated on its current host, and the host in the same zone
+	// on which the fewest of them would be violated.
+	EvaluateVirtualMachineHostPlacement(ctx context.Context, vm *vmopv1.VirtualMachine) (VMHostPlacementEvaluation, error)
+	// RelocateVirtualMachineToHost moves the VM to the host and ResourcePool,
+	// which must be in the host's cluster, without changing its datastores.
+	RelocateVirtualMachineToHost(ctx context.Context, vm *vmopv1.VirtualMachine, hostMoID, poolMoID string) error
+	// CopyFileToVirtualMachineGuest writes the data to the file at guestPath
+	// in the VM's guest through VMware Tools.
+	CopyFileToVirtualMachineGuest(ctx context.Context, vm *vmopv1.VirtualMachine, creds GuestCredentials,
+		guestPath string, data []byte, overwrite bool) error
+	// CopyFileFromVirtualMachineGuest returns the contents of the file at
+	// guestPath in the VM's guest through VMware Tools. ErrGuestFileTooLarge
+	// is returned if the file is larger than maxSize bytes.
+	CopyFileFromVirtualMachineGuest(ctx context.Context, vm *vmopv1.VirtualMachine, creds GuestCredentials,
+		guestPath string, maxSize int64) ([]byte, error)
+	// RunProgramInVirtualMachineGuest runs the program in the VM's guest
+	// through VMware Tools and waits for it to exit. If ctx is done before the
+	// program exits, the program is terminated and ctx's error is returned.
+	RunProgramInVirtualMachineGuest(ctx context.Context, vm *vmopv1.VirtualMachine, creds GuestCredentials,
+		spec GuestProgramSpec) (GuestProgramResult, error)
 
 	CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
 	DeleteVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
@@ -66,6 +171,22 @@ type VirtualMachineProviderInterface interface {
 	ContainsExtraConfigEntry(ctx context.Context, objVM *object.VirtualMachine, key, value string) (bool, error)
 
 	UpdateContentLibraryItem(ctx context.Context, itemID, newName string, newDescription *string) error
+	// ImportContentLibraryItem creates an item in the content library and
+	// uploads each of the files from the source into it. The ID of the item
+	// is returned.
+	ImportContentLibraryItem(ctx context.Context, libraryUUID, itemName, itemDescription string,
+		src imageimport.Source) (string, error)
+	// DeleteContentLibraryItem deletes the content library item.
+	DeleteContentLibraryItem(ctx context.Context, itemID string) error
+	// GetContentLibraryItemFiles returns the contents of the files of the
+	// content library item whose names match.
+	GetContentLibraryItemFiles(ctx context.Context, itemID string, match func(name string) bool) (map[string][]byte, error)
+	// ListContentLibraryItemFiles returns the names of all of the files of the
+	// content library item.
+	ListContentLibraryItemFiles(ctx context.Context, itemID string) ([]string, error)
+	// CopyContentLibraryItemFile writes the contents of the named file of the
+	// content library item to w.
+	CopyContentLibraryItemFile(ctx context.Context, itemID, name string, w io.Writer) error
 	SyncVirtualMachineImage(ctx context.Context, cli, vmi ctrlclient.Object) error
 
 	GetTasksByActID(ctx context.Context, vm *vmopv1.VirtualMachine, actID string) (tasksInfo []vimtypes.TaskInfo, retErr error)
@@ -199,7 +199,7 @@ func GetProviderConfig(
 }
 
 // ProviderConfigToConfigMap returns the ConfigMap for the config.
-// Used only in testing.
+// Used only in testing and by the simulator.
 func ProviderConfigToConfigMap(
 	namespace string,
 	config *VSphereVMProviderConfig) *corev1.ConfigMap {
@@ -214,6 +214,7 @@ func ProviderConfigToConfigMap(
 			vcPortKey:                config.VcPort,
 			datacenterKey:            config.Datacenter,
 			datastoreKey:             config.Datastore,
+			networkNameKey:           config.Network,
 			scRequiredKey:            strconv.FormatBool(config.StorageClassRequired),
 			useInventoryKey:          strconv.FormatBool(config.UseInventoryAsContentSource),
 			caFilePathKey:            config.CAFilePath,
@@ -28,6 +28,11 @@ const (
 	// deletion operation is paused.
 	VMPausedByAdminError = "failed to delete this VM because extraConfig Key 'vmservice.virtualmachine.pause' is set by admin"
 
+	// ExtraConfigGuestInfoStatusPrefix is the prefix of the guestinfo keys in
+	// which the guest publishes the application metadata that is reported in
+	// the VM's status.guest.properties.
+	ExtraConfigGuestInfoStatusPrefix = "guestinfo.vmservice.status."
+
 	// VMOperatorV1Alpha1ExtraConfigKey Special ExtraConfig key for v1alpha1 images.
 	VMOperatorV1Alpha1ExtraConfigKey = "guestinfo.vmservice.defer-cloud-init"
 	VMOperatorV1Alpha1ConfigReady    = "ready"
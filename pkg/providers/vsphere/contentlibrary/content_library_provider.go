@@ -25,6 +25,7 @@ import (
 	"github.com/vmware/govmomi/vim25/soap"
 
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 )
 
@@ -37,9 +38,14 @@ type Provider interface {
 	UpdateLibraryItem(ctx context.Context, itemID, newName string, newDescription *string) error
 	RetrieveOvfEnvelopeFromLibraryItem(ctx context.Context, item *library.Item) (*ovf.Envelope, error)
 	RetrieveOvfEnvelopeByLibraryItemID(ctx context.Context, itemID string) (*ovf.Envelope, error)
+	RetrieveLibraryItemFiles(ctx context.Context, itemID string, match func(name string) bool) (map[string][]byte, error)
+	ListLibraryItemFileNames(ctx context.Context, itemID string) ([]string, error)
+	CopyLibraryItemFile(ctx context.Context, itemID, name string, w io.Writer) error
 	SyncLibraryItem(ctx context.Context, item *library.Item, force bool) error
 	ListLibraryItemStorage(ctx context.Context, itemID string) ([]library.Storage, error)
 	ResolveLibraryItemStorage(ctx context.Context, datacenter *object.Datacenter, storage []library.Storage) error
+	ImportLibraryItem(ctx context.Context, libraryItem library.Item, src imageimport.Source) (string, error)
+	DeleteLibraryItem(ctx context.Context, itemID string) error
 
 	// TODO: Testing only. Remove these from this file.
 	CreateLibraryItem(ctx context.Context, libraryItem library.Item, path string) error
@@ -229,6 +235,123 @@ func (cs *provider) RetrieveOvfEnvelopeFromLibraryItem(ctx context.Context, item
 	return envelope, nil
 }
 
+// maxRetrievedFileSize is the maximum size of a file that may be retrieved by
+// RetrieveLibraryItemFiles.
+const maxRetrievedFileSize = 16 * 1024 * 1024
+
+// RetrieveLibraryItemFiles downloads the files of the library item whose names
+// match, and returns their contents by name. It is intended for small files,
+// such as manifests and certificates, and returns an error if a file is larger
+// than 16MiB.
+func (cs *provider) RetrieveLibraryItemFiles(
+	ctx context.Context,
+	itemID string,
+	match func(name string) bool) (map[string][]byte, error) {
+
+	sessionID, err := cs.libMgr.CreateLibraryItemDownloadSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.WithValues("sessionID", sessionID, "itemID", itemID)
+	logger.V(4).Info("download session for item created")
+
+	defer func() {
+		if err := cs.libMgr.DeleteLibraryItemDownloadSession(ctx, sessionID); err != nil {
+			logger.Error(err, "Error deleting download session")
+		}
+	}()
+
+	files, err := cs.libMgr.ListLibraryItemDownloadSessionFile(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := map[string][]byte{}
+	for _, file := range files {
+		if !match(file.Name) {
+			continue
+		}
+		if file.Size > maxRetrievedFileSize {
+			return nil, fmt.Errorf("library item file %s is larger than %d bytes", file.Name, maxRetrievedFileSize)
+		}
+
+		fileURL, err := cs.prepareLibraryItemDownloadSessionFile(ctx, logger, sessionID, file.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		rc, err := readerFromURL(ctx, cs.libMgr.Client, fileURL)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxRetrievedFileSize+1))
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxRetrievedFileSize {
+			return nil, fmt.Errorf("library item file %s is larger than %d bytes", file.Name, maxRetrievedFileSize)
+		}
+		contents[file.Name] = data
+	}
+
+	return contents, nil
+}
+
+// ListLibraryItemFileNames returns the names of all of the library item's
+// files.
+func (cs *provider) ListLibraryItemFileNames(ctx context.Context, itemID string) ([]string, error) {
+	files, err := cs.libMgr.ListLibraryItemFiles(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	return names, nil
+}
+
+// CopyLibraryItemFile downloads the named file of the library item and writes
+// its contents to w. Unlike RetrieveLibraryItemFiles, the file is streamed, so
+// it may be used for large files, such as disks.
+func (cs *provider) CopyLibraryItemFile(
+	ctx context.Context,
+	itemID, name string,
+	w io.Writer) error {
+
+	sessionID, err := cs.libMgr.CreateLibraryItemDownloadSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return err
+	}
+
+	logger := log.WithValues("sessionID", sessionID, "itemID", itemID)
+	logger.V(4).Info("download session for item created")
+
+	defer func() {
+		if err := cs.libMgr.DeleteLibraryItemDownloadSession(ctx, sessionID); err != nil {
+			logger.Error(err, "Error deleting download session")
+		}
+	}()
+
+	fileURL, err := cs.prepareLibraryItemDownloadSessionFile(ctx, logger, sessionID, name)
+	if err != nil {
+		return err
+	}
+
+	rc, err := readerFromURL(ctx, cs.libMgr.Client, fileURL)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("failed to download library item file %s: %w", name, err)
+	}
+	return nil
+}
+
 // UpdateLibraryItem updates the content library item's name and description.
 func (cs *provider) UpdateLibraryItem(ctx context.Context, itemID, newName string, newDescription *string) error {
 	log.Info("Updating Library Item", "itemID", itemID,
@@ -260,6 +383,94 @@ func (cs *provider) SyncLibraryItem(
 	return cs.libMgr.SyncLibraryItem(ctx, item, force)
 }
 
+// ImportLibraryItem creates a new library item and uploads each of the files
+// from the source into it. The library item is deleted if any of the files
+// cannot be uploaded. The ID of the library item is returned.
+func (cs *provider) ImportLibraryItem(
+	ctx context.Context,
+	libraryItem library.Item,
+	src imageimport.Source) (_ string, retErr error) {
+
+	logger := log.WithValues("libraryID", libraryItem.LibraryID, "itemName", libraryItem.Name)
+	logger.Info("Importing Library Item", "type", src.ItemType())
+
+	libraryItem.Type = src.ItemType()
+	itemID, err := cs.libMgr.CreateLibraryItem(ctx, libraryItem)
+	if err != nil {
+		return "", fmt.Errorf("failed to create library item: %w", err)
+	}
+
+	var sessionID string
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		// Clean up even if the import was canceled.
+		ctx := context.WithoutCancel(ctx)
+		if sessionID != "" {
+			if err := cs.libMgr.CancelLibraryItemUpdateSession(ctx, sessionID); err != nil {
+				logger.Error(err, "failed to cancel library item update session", "sessionID", sessionID)
+			}
+		}
+		if err := cs.libMgr.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); err != nil {
+			logger.Error(err, "failed to delete library item", "itemID", itemID)
+		}
+	}()
+
+	sessionID, err = cs.libMgr.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return "", fmt.Errorf("failed to create library item update session: %w", err)
+	}
+
+	err = src.Walk(ctx, func(name string, size int64, r io.Reader) error {
+		info := library.UpdateFile{
+			Name:       name,
+			SourceType: "PUSH",
+			Size:       max(size, 0),
+		}
+
+		update, err := cs.libMgr.AddLibraryItemFile(ctx, sessionID, info)
+		if err != nil {
+			return fmt.Errorf("failed to add library item file %s: %w", name, err)
+		}
+
+		u, err := url.Parse(update.UploadEndpoint.URI)
+		if err != nil {
+			return err
+		}
+
+		p := soap.DefaultUpload
+		p.ContentLength = size
+
+		if err := cs.libMgr.Client.Upload(ctx, r, u, &p); err != nil {
+			return fmt.Errorf("failed to upload library item file %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := cs.libMgr.CompleteLibraryItemUpdateSession(ctx, sessionID); err != nil {
+		return "", fmt.Errorf("failed to complete library item update session: %w", err)
+	}
+
+	if err := cs.libMgr.WaitOnLibraryItemUpdateSession(ctx, sessionID, cs.retryInterval, nil); err != nil {
+		return "", fmt.Errorf("failed to wait on library item update session: %w", err)
+	}
+
+	return itemID, nil
+}
+
+// DeleteLibraryItem deletes the library item.
+func (cs *provider) DeleteLibraryItem(ctx context.Context, itemID string) error {
+	log.Info("Deleting Library Item", "itemID", itemID)
+	if err := cs.libMgr.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); err != nil {
+		return fmt.Errorf("failed to delete library item %s: %w", itemID, err)
+	}
+	return nil
+}
+
 // Only used in testing.
 func (cs *provider) CreateLibraryItem(ctx context.Context, libraryItem library.Item, path string) error {
 	log.Info("Creating Library Item", "item", libraryItem, "path", path)
@@ -346,7 +557,18 @@ func (cs *provider) generateDownloadURLForLibraryItem(
 		return nil, fmt.Errorf("no files with supported deploy type are available for download for %s", item.ID)
 	}
 
-	_, err = cs.libMgr.PrepareLibraryItemDownloadSessionFile(ctx, sessionID, fileToDownload)
+	return cs.prepareLibraryItemDownloadSessionFile(ctx, logger, sessionID, fileToDownload)
+}
+
+// prepareLibraryItemDownloadSessionFile prepares a file in a download session
+// and returns the URL from which it may be downloaded.
+func (cs *provider) prepareLibraryItemDownloadSessionFile(
+	ctx context.Context,
+	logger logr.Logger,
+	sessionID string,
+	fileToDownload string) (*url.URL, error) {
+
+	_, err := cs.libMgr.PrepareLibraryItemDownloadSessionFile(ctx, sessionID, fileToDownload)
 	if err != nil {
 		return nil, err
 	}
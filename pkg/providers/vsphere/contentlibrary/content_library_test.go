@@ -5,6 +5,8 @@
 package contentlibrary_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strings"
 
@@ -14,10 +16,31 @@ import (
 	"github.com/vmware/govmomi/vapi/library"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
+type fakeImportSource struct {
+	files   map[string]string
+	walkErr error
+}
+
+func (s fakeImportSource) ItemType() string {
+	return library.ItemTypeISO
+}
+
+func (s fakeImportSource) Walk(_ context.Context, fn imageimport.WalkFunc) error {
+	for name, data := range s.files {
+		if err := fn(name, int64(len(data)), strings.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return s.walkErr
+}
+
+var _ imageimport.Source = fakeImportSource{}
+
 func clTests() {
 	Describe("Content Library", func() {
 
@@ -98,6 +121,94 @@ func clTests() {
 			})
 		})
 
+		Context("ImportLibraryItem", func() {
+			var (
+				libItem library.Item
+				src     fakeImportSource
+			)
+
+			BeforeEach(func() {
+				src = fakeImportSource{
+					files: map[string]string{"photon.iso": "iso contents"},
+				}
+			})
+
+			JustBeforeEach(func() {
+				libItem = library.Item{
+					Name:      "imported-iso",
+					LibraryID: ctx.LocalContentLibraryID,
+				}
+			})
+
+			It("creates the library item with the files from the source", func() {
+				itemID, err := clProvider.ImportLibraryItem(ctx, libItem, src)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(itemID).ToNot(BeEmpty())
+
+				item, err := clProvider.GetLibraryItemID(ctx, itemID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item.Name).To(Equal(libItem.Name))
+				Expect(item.Type).To(Equal(library.ItemTypeISO))
+			})
+
+			When("the source cannot be walked", func() {
+				BeforeEach(func() {
+					src.walkErr = errors.New("fake walk error")
+				})
+
+				It("deletes the library item", func() {
+					_, err := clProvider.ImportLibraryItem(ctx, libItem, src)
+					Expect(err).To(MatchError("fake walk error"))
+
+					item, err := clProvider.GetLibraryItem(ctx, ctx.LocalContentLibraryID, libItem.Name, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(item).To(BeNil())
+				})
+			})
+		})
+
+		Context("DeleteLibraryItem", func() {
+			It("deletes the library item", func() {
+				itemID, err := clProvider.ImportLibraryItem(ctx, library.Item{
+					Name:      "deleted-iso",
+					LibraryID: ctx.LocalContentLibraryID,
+				}, fakeImportSource{
+					files: map[string]string{"photon.iso": "iso contents"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(clProvider.DeleteLibraryItem(ctx, itemID)).To(Succeed())
+
+				item, err := clProvider.GetLibraryItem(ctx, ctx.LocalContentLibraryID, "deleted-iso", false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item).To(BeNil())
+			})
+		})
+
+		Context("RetrieveLibraryItemFiles", func() {
+			It("returns the contents of the matching files", func() {
+				src := fakeImportSource{
+					files: map[string]string{
+						"photon.iso": "iso contents",
+						"photon.mf":  "manifest contents",
+					},
+				}
+				itemID, err := clProvider.ImportLibraryItem(ctx, library.Item{
+					Name:      "signed-iso",
+					LibraryID: ctx.LocalContentLibraryID,
+				}, src)
+				Expect(err).ToNot(HaveOccurred())
+
+				files, err := clProvider.RetrieveLibraryItemFiles(ctx, itemID, func(name string) bool {
+					return strings.HasSuffix(name, ".mf")
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(files).To(Equal(map[string][]byte{
+					"photon.mf": []byte("manifest contents"),
+				}))
+			})
+		})
+
 		Context("called with an OVF that is invalid because of network connectivity issue", func() {
 			var ovfPath string
 
@@ -6,6 +6,7 @@ package credentials
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 
@@ -14,10 +15,42 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// UsernameKey is the key in the provider Secret for the vCenter username.
+	UsernameKey = "username"
+
+	// PasswordKey is the key in the provider Secret for the vCenter password.
+	PasswordKey = "password"
+
+	// CertificateKey is the key in the provider Secret for the PEM-encoded
+	// certificate of a vCenter solution user.
+	CertificateKey = corev1.TLSCertKey
+
+	// PrivateKeyKey is the key in the provider Secret for the PEM-encoded
+	// private key of a vCenter solution user.
+	PrivateKeyKey = corev1.TLSPrivateKeyKey
+
+	// TokenKey is the key in the provider Secret for a SAML bearer token
+	// issued by the vCenter Security Token Service.
+	TokenKey = "token"
+)
+
 // VSphereVMProviderCredentials wraps the data needed to login to vCenter.
+//
+// Exactly one method of authentication is used, in the following order of
+// precedence: a solution user certificate and private key, a SAML bearer
+// token, and a username and password.
 type VSphereVMProviderCredentials struct {
 	Username string
 	Password string
+
+	// Certificate and PrivateKey are the PEM-encoded certificate and private
+	// key of a solution user.
+	Certificate string
+	PrivateKey  string
+
+	// Token is a SAML bearer token.
+	Token string
 }
 
 func GetProviderCredentials(
@@ -35,9 +68,30 @@ func GetProviderCredentials(
 }
 
 func ExtractVCCredentials(data map[string][]byte) (VSphereVMProviderCredentials, error) {
+	cert, key := data[CertificateKey], data[PrivateKeyKey]
+
+	switch {
+	case len(cert) > 0 || len(key) > 0:
+		if len(cert) == 0 || len(key) == 0 {
+			return VSphereVMProviderCredentials{}, errors.New("vCenter solution user certificate or private key is missing")
+		}
+		if _, err := tls.X509KeyPair(cert, key); err != nil {
+			return VSphereVMProviderCredentials{}, fmt.Errorf("invalid vCenter solution user certificate: %w", err)
+		}
+		return VSphereVMProviderCredentials{
+			Certificate: string(cert),
+			PrivateKey:  string(key),
+		}, nil
+
+	case len(data[TokenKey]) > 0:
+		return VSphereVMProviderCredentials{
+			Token: string(data[TokenKey]),
+		}, nil
+	}
+
 	credentials := VSphereVMProviderCredentials{
-		Username: string(data["username"]),
-		Password: string(data["password"]),
+		Username: string(data[UsernameKey]),
+		Password: string(data[PasswordKey]),
 	}
 
 	if credentials.Username == "" || credentials.Password == "" {
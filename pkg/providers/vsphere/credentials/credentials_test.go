@@ -86,3 +86,87 @@ var _ = Describe("GetProviderCredentials", func() {
 		})
 	})
 })
+
+var _ = Describe("ExtractVCCredentials", func() {
+	var (
+		certPEM []byte
+		keyPEM  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		certPEM, keyPEM, err = builder.NewSelfSignedCertificatePEM("vmop-solution-user")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("with a certificate and private key", func() {
+		Specify("returns the certificate and private key", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{
+				credentials.UsernameKey:    []byte("some-user"),
+				credentials.PasswordKey:    []byte("some-pass"),
+				credentials.CertificateKey: certPEM,
+				credentials.PrivateKeyKey:  keyPEM,
+				credentials.TokenKey:       []byte("some-token"),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(creds).To(Equal(credentials.VSphereVMProviderCredentials{
+				Certificate: string(certPEM),
+				PrivateKey:  string(keyPEM),
+			}))
+		})
+	})
+
+	Context("with a certificate and no private key", func() {
+		Specify("returns an error", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{
+				credentials.CertificateKey: certPEM,
+			})
+			Expect(err).To(MatchError("vCenter solution user certificate or private key is missing"))
+			Expect(creds).To(BeZero())
+		})
+	})
+
+	Context("with a private key and no certificate", func() {
+		Specify("returns an error", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{
+				credentials.PrivateKeyKey: keyPEM,
+			})
+			Expect(err).To(MatchError("vCenter solution user certificate or private key is missing"))
+			Expect(creds).To(BeZero())
+		})
+	})
+
+	Context("with an invalid certificate", func() {
+		Specify("returns an error", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{
+				credentials.CertificateKey: []byte("invalid"),
+				credentials.PrivateKeyKey:  keyPEM,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("invalid vCenter solution user certificate"))
+			Expect(creds).To(BeZero())
+		})
+	})
+
+	Context("with a token", func() {
+		Specify("returns the token", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{
+				credentials.UsernameKey: []byte("some-user"),
+				credentials.PasswordKey: []byte("some-pass"),
+				credentials.TokenKey:    []byte("some-token"),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(creds).To(Equal(credentials.VSphereVMProviderCredentials{
+				Token: "some-token",
+			}))
+		})
+	})
+
+	Context("with no credentials", func() {
+		Specify("returns an error", func() {
+			creds, err := credentials.ExtractVCCredentials(map[string][]byte{})
+			Expect(err).To(MatchError("vCenter username and password are missing"))
+			Expect(creds).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"net"
+	"slices"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// NetworkDeviceResult describes a guest network device, such as a bond, VLAN,
+// or bridge, that is composed of the VM's network interfaces.
+type NetworkDeviceResult struct {
+	Name string
+	Type vmopv1.VirtualMachineNetworkGuestDeviceType
+
+	// Interfaces are the members of a bond or bridge, or the link of a VLAN.
+	Interfaces []string
+
+	VLANID int32
+	Bond   *vmopv1.VirtualMachineNetworkBondSpec
+	Bridge *vmopv1.VirtualMachineNetworkBridgeSpec
+
+	IPConfigs     []NetworkInterfaceIPConfig
+	DHCP4         bool
+	DHCP6         bool
+	MTU           int64
+	Nameservers   []string
+	SearchDomains []string
+	Routes        []NetworkInterfaceRoute
+}
+
+// IsGuestDeviceMember returns true if the named interface or device is a
+// member of one of the bonds or bridges. Members are not configured with IP
+// addresses since the addresses belong to the bond or bridge.
+func (r NetworkInterfaceResults) IsGuestDeviceMember(name string) bool {
+	for _, d := range r.Devices {
+		if d.Type == vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN {
+			continue
+		}
+		if slices.Contains(d.Interfaces, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// guestDeviceResults returns the results for the bonds, VLANs, and bridges
+// from the network spec, in that order, so a device is always listed after
+// the devices it is composed of.
+func guestDeviceResults(
+	networkSpec *vmopv1.VirtualMachineNetworkSpec,
+	defaultToGlobalNameservers bool,
+	defaultToGlobalSearchDomains bool) []NetworkDeviceResult {
+
+	n := len(networkSpec.Bonds) + len(networkSpec.VLANs) + len(networkSpec.Bridges)
+	if n == 0 {
+		return nil
+	}
+
+	results := make([]NetworkDeviceResult, 0, n)
+
+	for i := range networkSpec.Bonds {
+		bond := &networkSpec.Bonds[i]
+		result := NetworkDeviceResult{
+			Name:       bond.Name,
+			Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeBond,
+			Interfaces: bond.Interfaces,
+			Bond:       bond,
+		}
+		applyGuestDeviceIPSpecToResult(networkSpec, &bond.VirtualMachineNetworkGuestDeviceIPSpec,
+			defaultToGlobalNameservers, defaultToGlobalSearchDomains, &result)
+		results = append(results, result)
+	}
+
+	for i := range networkSpec.VLANs {
+		vlan := &networkSpec.VLANs[i]
+		result := NetworkDeviceResult{
+			Name:       vlan.Name,
+			Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN,
+			Interfaces: []string{vlan.Link},
+			VLANID:     vlan.ID,
+		}
+		applyGuestDeviceIPSpecToResult(networkSpec, &vlan.VirtualMachineNetworkGuestDeviceIPSpec,
+			defaultToGlobalNameservers, defaultToGlobalSearchDomains, &result)
+		results = append(results, result)
+	}
+
+	for i := range networkSpec.Bridges {
+		bridge := &networkSpec.Bridges[i]
+		result := NetworkDeviceResult{
+			Name:       bridge.Name,
+			Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeBridge,
+			Interfaces: bridge.Interfaces,
+			Bridge:     bridge,
+		}
+		applyGuestDeviceIPSpecToResult(networkSpec, &bridge.VirtualMachineNetworkGuestDeviceIPSpec,
+			defaultToGlobalNameservers, defaultToGlobalSearchDomains, &result)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func applyGuestDeviceIPSpecToResult(
+	networkSpec *vmopv1.VirtualMachineNetworkSpec,
+	ipSpec *vmopv1.VirtualMachineNetworkGuestDeviceIPSpec,
+	defaultToGlobalNameservers bool,
+	defaultToGlobalSearchDomains bool,
+	result *NetworkDeviceResult) {
+
+	result.DHCP4 = ipSpec.DHCP4
+	result.DHCP6 = ipSpec.DHCP6
+
+	if ipSpec.MTU != nil {
+		result.MTU = *ipSpec.MTU
+	}
+
+	for _, addr := range ipSpec.Addresses {
+		ip, _, err := net.ParseCIDR(addr)
+		if err != nil {
+			continue
+		}
+
+		ipConfig := NetworkInterfaceIPConfig{
+			IPCIDR: addr,
+			IsIPv4: ip.To4() != nil,
+		}
+		if ipConfig.IsIPv4 {
+			ipConfig.Gateway = ipSpec.Gateway4
+		} else {
+			ipConfig.Gateway = ipSpec.Gateway6
+		}
+		if ipConfig.Gateway == gatewayIgnored {
+			ipConfig.Gateway = ""
+		}
+
+		result.IPConfigs = append(result.IPConfigs, ipConfig)
+	}
+
+	for _, route := range ipSpec.Routes {
+		result.Routes = append(result.Routes, NetworkInterfaceRoute{To: route.To, Via: route.Via, Metric: route.Metric})
+	}
+
+	if n := ipSpec.Nameservers; len(n) > 0 {
+		result.Nameservers = n
+	} else if defaultToGlobalNameservers {
+		result.Nameservers = networkSpec.Nameservers
+	}
+
+	if d := ipSpec.SearchDomains; len(d) > 0 {
+		result.SearchDomains = d
+	} else if defaultToGlobalSearchDomains {
+		result.SearchDomains = networkSpec.SearchDomains
+	}
+}
@@ -0,0 +1,178 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineippools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineippools/status,verbs=get;update;patch
+
+// claimIPPoolAddress allocates an address from the interface's IP pool to the
+// VM's interface, and returns the IP configuration for the address. If an
+// address was already allocated to the interface then that address is
+// returned.
+//
+// The allocations are stored in the pool's status, and the status is updated
+// instead of patched so concurrent allocations from the same pool result in a
+// conflict instead of the same address being allocated twice.
+func claimIPPoolAddress(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	interfaceSpec *vmopv1.VirtualMachineNetworkInterfaceSpec) (NetworkInterfaceIPConfig, error) {
+
+	var ipConfig NetworkInterfaceIPConfig
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		pool := &vmopv1.VirtualMachineIPPool{}
+		poolKey := ctrlclient.ObjectKey{Namespace: vmCtx.VM.Namespace, Name: interfaceSpec.IPPoolName}
+		if err := client.Get(vmCtx, poolKey, pool); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("IP pool %q does not exist", interfaceSpec.IPPoolName)
+			}
+			return fmt.Errorf("failed to get IP pool %q: %w", interfaceSpec.IPPoolName, err)
+		}
+
+		var gateway netip.Addr
+		if pool.Spec.Gateway != "" {
+			gw, err := netip.ParseAddr(pool.Spec.Gateway)
+			if err != nil {
+				return fmt.Errorf("IP pool %q has an invalid gateway: %w", pool.Name, err)
+			}
+			gateway = gw.Unmap()
+		}
+
+		used := make(map[netip.Addr]struct{}, len(pool.Status.Allocations)+1)
+		if gateway.IsValid() {
+			used[gateway] = struct{}{}
+		}
+
+		for _, a := range pool.Status.Allocations {
+			if a.VirtualMachineUID == vmCtx.VM.UID && a.InterfaceName == interfaceSpec.Name {
+				addr, err := netip.ParseAddr(a.Address)
+				if err != nil {
+					return fmt.Errorf("IP pool %q has an invalid allocation: %w", pool.Name, err)
+				}
+				ipConfig = ipPoolIPConfig(pool, addr)
+				return nil
+			}
+			if addr, err := netip.ParseAddr(a.Address); err == nil {
+				used[addr.Unmap()] = struct{}{}
+			}
+		}
+
+		ranges := make([]pkgutil.IPRange, 0, len(pool.Spec.Addresses))
+		for _, s := range pool.Spec.Addresses {
+			r, err := pkgutil.ParseIPRange(s)
+			if err != nil {
+				return fmt.Errorf("IP pool %q has invalid addresses: %w", pool.Name, err)
+			}
+			ranges = append(ranges, r)
+		}
+
+		addr, ok := pkgutil.NextFreeIP(ranges, func(a netip.Addr) bool {
+			_, ok := used[a]
+			return ok
+		})
+		if !ok {
+			return fmt.Errorf("IP pool %q has no free addresses", pool.Name)
+		}
+
+		pool.Status.Allocations = append(pool.Status.Allocations,
+			vmopv1.VirtualMachineIPPoolAllocation{
+				Address:            addr.String(),
+				VirtualMachineName: vmCtx.VM.Name,
+				VirtualMachineUID:  vmCtx.VM.UID,
+				InterfaceName:      interfaceSpec.Name,
+			})
+		if err := client.Status().Update(vmCtx, pool); err != nil {
+			return err
+		}
+
+		vmCtx.Logger.Info("Allocated address from IP pool",
+			"ipPool", pool.Name, "address", addr.String(), "interface", interfaceSpec.Name)
+
+		ipConfig = ipPoolIPConfig(pool, addr)
+		return nil
+	})
+
+	return ipConfig, err
+}
+
+func ipPoolIPConfig(
+	pool *vmopv1.VirtualMachineIPPool,
+	addr netip.Addr) NetworkInterfaceIPConfig {
+
+	return NetworkInterfaceIPConfig{
+		IPCIDR:  netip.PrefixFrom(addr, int(pool.Spec.Prefix)).String(),
+		IsIPv4:  addr.Is4(),
+		Gateway: pool.Spec.Gateway,
+	}
+}
+
+// applyIPPoolAddressToResult replaces the IP configuration from the network
+// provider with the address allocated from the interface's IP pool.
+func applyIPPoolAddressToResult(
+	ipConfig NetworkInterfaceIPConfig,
+	result *NetworkInterfaceResult) {
+
+	result.IPConfigs = []NetworkInterfaceIPConfig{ipConfig}
+	result.DHCP4 = false
+	result.DHCP6 = false
+	result.NoIPAM = false
+}
+
+// ReleaseIPPoolAddresses releases the addresses allocated to the VM from all of
+// the IP pools in the VM's namespace.
+func ReleaseIPPoolAddresses(
+	ctx context.Context,
+	client ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) error {
+
+	var list vmopv1.VirtualMachineIPPoolList
+	if err := client.List(ctx, &list, ctrlclient.InNamespace(vm.Namespace)); err != nil {
+		return fmt.Errorf("failed to list IP pools: %w", err)
+	}
+
+	isVMAllocation := func(a vmopv1.VirtualMachineIPPoolAllocation) bool {
+		return a.VirtualMachineUID == vm.UID
+	}
+
+	for i := range list.Items {
+		if !slices.ContainsFunc(list.Items[i].Status.Allocations, isVMAllocation) {
+			continue
+		}
+
+		poolKey := ctrlclient.ObjectKeyFromObject(&list.Items[i])
+		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			pool := &vmopv1.VirtualMachineIPPool{}
+			if err := client.Get(ctx, poolKey, pool); err != nil {
+				return ctrlclient.IgnoreNotFound(err)
+			}
+			n := len(pool.Status.Allocations)
+			pool.Status.Allocations = slices.DeleteFunc(pool.Status.Allocations, isVMAllocation)
+			if len(pool.Status.Allocations) == n {
+				return nil
+			}
+			return client.Status().Update(ctx, pool)
+		}); err != nil {
+			return fmt.Errorf("failed to release addresses from IP pool %q: %w", poolKey.Name, err)
+		}
+	}
+
+	return nil
+}
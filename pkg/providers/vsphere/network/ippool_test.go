@@ -0,0 +1,115 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("ReleaseIPPoolAddresses", func() {
+	const namespace = "my-namespace"
+
+	var (
+		ctx    context.Context
+		client ctrlclient.Client
+		vm     *vmopv1.VirtualMachine
+		pool1  *vmopv1.VirtualMachineIPPool
+		pool2  *vmopv1.VirtualMachineIPPool
+		err    error
+	)
+
+	newPool := func(name string, allocations ...vmopv1.VirtualMachineIPPoolAllocation) *vmopv1.VirtualMachineIPPool {
+		return &vmopv1.VirtualMachineIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: vmopv1.VirtualMachineIPPoolSpec{
+				Addresses: []string{"192.168.0.0/24"},
+				Prefix:    24,
+			},
+			Status: vmopv1.VirtualMachineIPPoolStatus{
+				Allocations: allocations,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vm",
+				Namespace: namespace,
+				UID:       "my-vm-uid",
+			},
+		}
+
+		pool1 = newPool("pool-1",
+			vmopv1.VirtualMachineIPPoolAllocation{
+				Address:            "192.168.0.1",
+				VirtualMachineName: vm.Name,
+				VirtualMachineUID:  vm.UID,
+				InterfaceName:      "eth0",
+			},
+			vmopv1.VirtualMachineIPPoolAllocation{
+				Address:            "192.168.0.2",
+				VirtualMachineName: "other-vm",
+				VirtualMachineUID:  "other-vm-uid",
+				InterfaceName:      "eth0",
+			},
+		)
+		pool2 = newPool("pool-2",
+			vmopv1.VirtualMachineIPPoolAllocation{
+				Address:            "192.168.0.1",
+				VirtualMachineName: vm.Name,
+				VirtualMachineUID:  vm.UID,
+				InterfaceName:      "eth1",
+			},
+		)
+	})
+
+	JustBeforeEach(func() {
+		client = builder.NewFakeClient(pool1, pool2)
+		err = network.ReleaseIPPoolAddresses(ctx, client, vm)
+	})
+
+	It("releases the VM's addresses from all the pools", func() {
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(pool1), pool1)).To(Succeed())
+		Expect(pool1.Status.Allocations).To(HaveLen(1))
+		Expect(pool1.Status.Allocations[0].VirtualMachineName).To(Equal("other-vm"))
+
+		Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(pool2), pool2)).To(Succeed())
+		Expect(pool2.Status.Allocations).To(BeEmpty())
+	})
+
+	When("the pools are in a different namespace", func() {
+		BeforeEach(func() {
+			vm.Namespace = "other-namespace"
+		})
+
+		It("does not release any addresses", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(pool1), pool1)).To(Succeed())
+			Expect(pool1.Status.Allocations).To(HaveLen(2))
+
+			Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(pool2), pool2)).To(Succeed())
+			Expect(pool2.Status.Allocations).To(HaveLen(1))
+		})
+	})
+})
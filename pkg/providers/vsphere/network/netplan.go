@@ -5,8 +5,10 @@
 package network
 
 import (
+	"strconv"
 	"strings"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -24,81 +26,233 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 				Macaddress: ptr.To(NormalizeNetplanMac(r.MacAddress)),
 			},
 			SetName: &r.GuestDeviceName,
-			Nameservers: &netplan.Nameserver{
-				Addresses: r.Nameservers,
-				Search:    r.SearchDomains,
-			},
 		}
 
 		if r.MTU > 0 {
 			npEth.MTU = &r.MTU
 		}
 
+		if result.IsGuestDeviceMember(r.Name) {
+			// The addresses of a bond or bridge belong to the bond or bridge,
+			// so its members are not configured with any IP settings.
+			npEth.Dhcp4 = ptr.To(false)
+			npEth.Dhcp6 = ptr.To(false)
+			npEth.AcceptRa = ptr.To(false)
+			netPlan.Ethernets[r.Name] = npEth
+			continue
+		}
+
+		npEth.Nameservers = &netplan.Nameserver{
+			Addresses: r.Nameservers,
+			Search:    r.SearchDomains,
+		}
+
 		npEth.Dhcp4 = &r.DHCP4
 		npEth.Dhcp6 = &r.DHCP6
 		// Right now we can set the same value as DHCPv6 configuration
 		// and in some future separate/specialize if required.
 		npEth.AcceptRa = &r.DHCP6
 
-		if !*npEth.Dhcp4 {
-			for i := range r.IPConfigs {
-				ipConfig := r.IPConfigs[i]
-				if ipConfig.IsIPv4 {
-					if ipConfig.Gateway != "" {
-						if npEth.Gateway4 == nil || *npEth.Gateway4 == "" {
-							npEth.Gateway4 = &ipConfig.Gateway
-						}
+		npIP := netPlanIPConfig(r.IPConfigs, r.DHCP4, r.DHCP6, r.Routes)
+		npEth.Addresses = npIP.addresses
+		npEth.Gateway4 = npIP.gateway4
+		npEth.Gateway6 = npIP.gateway6
+		npEth.Routes = npIP.routes
+
+		netPlan.Ethernets[r.Name] = npEth
+	}
+
+	for _, d := range result.Devices {
+		var (
+			npIP     netPlanIP
+			npDNS    *netplan.Nameserver
+			mtu      *int64
+			isMember = result.IsGuestDeviceMember(d.Name)
+		)
+
+		// Like with interfaces, a bond or VLAN that is a member of a bridge
+		// is not configured with any IP settings.
+		if !isMember {
+			npIP = netPlanIPConfig(d.IPConfigs, d.DHCP4, d.DHCP6, d.Routes)
+			npDNS = &netplan.Nameserver{
+				Addresses: d.Nameservers,
+				Search:    d.SearchDomains,
+			}
+		}
+
+		if d.MTU > 0 {
+			mtu = &d.MTU
+		}
+
+		dhcp4, dhcp6 := d.DHCP4 && !isMember, d.DHCP6 && !isMember
+
+		switch d.Type {
+		case vmopv1.VirtualMachineNetworkGuestDeviceTypeBond:
+			if netPlan.Bonds == nil {
+				netPlan.Bonds = make(map[string]netplan.Bond)
+			}
+			netPlan.Bonds[d.Name] = netplan.Bond{
+				Interfaces:  d.Interfaces,
+				Parameters:  netPlanBondParameters(d.Bond),
+				Addresses:   npIP.addresses,
+				Gateway4:    npIP.gateway4,
+				Gateway6:    npIP.gateway6,
+				Routes:      npIP.routes,
+				Nameservers: npDNS,
+				MTU:         mtu,
+				Dhcp4:       &dhcp4,
+				Dhcp6:       &dhcp6,
+				AcceptRa:    &dhcp6,
+			}
+
+		case vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN:
+			if netPlan.Vlans == nil {
+				netPlan.Vlans = make(map[string]netplan.VLAN)
+			}
+			var link *string
+			if len(d.Interfaces) > 0 {
+				link = &d.Interfaces[0]
+			}
+			netPlan.Vlans[d.Name] = netplan.VLAN{
+				ID:          ptr.To(int64(d.VLANID)),
+				Link:        link,
+				Addresses:   npIP.addresses,
+				Gateway4:    npIP.gateway4,
+				Gateway6:    npIP.gateway6,
+				Routes:      npIP.routes,
+				Nameservers: npDNS,
+				MTU:         mtu,
+				Dhcp4:       &dhcp4,
+				Dhcp6:       &dhcp6,
+				AcceptRa:    &dhcp6,
+			}
+
+		case vmopv1.VirtualMachineNetworkGuestDeviceTypeBridge:
+			if netPlan.Bridges == nil {
+				netPlan.Bridges = make(map[string]netplan.Bridge)
+			}
+			var params *netplan.BridgeParameters
+			if d.Bridge != nil && d.Bridge.STP != nil {
+				params = &netplan.BridgeParameters{
+					Stp: d.Bridge.STP,
+				}
+			}
+			netPlan.Bridges[d.Name] = netplan.Bridge{
+				Interfaces:  d.Interfaces,
+				Parameters:  params,
+				Addresses:   npIP.addresses,
+				Gateway4:    npIP.gateway4,
+				Gateway6:    npIP.gateway6,
+				Routes:      npIP.routes,
+				Nameservers: npDNS,
+				MTU:         mtu,
+				Dhcp4:       &dhcp4,
+				Dhcp6:       &dhcp6,
+				AcceptRa:    &dhcp6,
+			}
+		}
+	}
+
+	return netPlan, nil
+}
+
+type netPlanIP struct {
+	addresses []netplan.Address
+	gateway4  *string
+	gateway6  *string
+	routes    []netplan.Route
+}
+
+// netPlanIPConfig returns the netplan addresses, gateways, and routes for the
+// provided IP configuration. The IP4 addresses are listed first, and the IP4
+// and IP6 addresses are omitted when DHCP4 and DHCP6 are used, respectively.
+func netPlanIPConfig(
+	ipConfigs []NetworkInterfaceIPConfig,
+	dhcp4, dhcp6 bool,
+	routes []NetworkInterfaceRoute) netPlanIP {
+
+	var npIP netPlanIP
+
+	if !dhcp4 {
+		for i := range ipConfigs {
+			ipConfig := ipConfigs[i]
+			if ipConfig.IsIPv4 {
+				if ipConfig.Gateway != "" {
+					if npIP.gateway4 == nil || *npIP.gateway4 == "" {
+						npIP.gateway4 = &ipConfig.Gateway
 					}
-					npEth.Addresses = append(
-						npEth.Addresses,
-						netplan.Address{
-							String: &ipConfig.IPCIDR,
-						},
-					)
 				}
+				npIP.addresses = append(
+					npIP.addresses,
+					netplan.Address{
+						String: &ipConfig.IPCIDR,
+					},
+				)
 			}
 		}
-		if !*npEth.Dhcp6 {
-			for i := range r.IPConfigs {
-				ipConfig := r.IPConfigs[i]
-				if !ipConfig.IsIPv4 {
-					if ipConfig.Gateway != "" {
-						if npEth.Gateway6 == nil || *npEth.Gateway6 == "" {
-							npEth.Gateway6 = &ipConfig.Gateway
-						}
+	}
+	if !dhcp6 {
+		for i := range ipConfigs {
+			ipConfig := ipConfigs[i]
+			if !ipConfig.IsIPv4 {
+				if ipConfig.Gateway != "" {
+					if npIP.gateway6 == nil || *npIP.gateway6 == "" {
+						npIP.gateway6 = &ipConfig.Gateway
 					}
-					npEth.Addresses = append(
-						npEth.Addresses,
-						netplan.Address{
-							String: &ipConfig.IPCIDR,
-						},
-					)
 				}
+				npIP.addresses = append(
+					npIP.addresses,
+					netplan.Address{
+						String: &ipConfig.IPCIDR,
+					},
+				)
 			}
 		}
+	}
 
-		for i := range r.Routes {
-			route := r.Routes[i]
-
-			var metric *int64
-			if route.Metric != 0 {
-				metric = ptr.To(int64(route.Metric))
-			}
+	for i := range routes {
+		route := routes[i]
 
-			npEth.Routes = append(
-				npEth.Routes,
-				netplan.Route{
-					To:     &route.To,
-					Metric: metric,
-					Via:    &route.Via,
-				},
-			)
+		var metric *int64
+		if route.Metric != 0 {
+			metric = ptr.To(int64(route.Metric))
 		}
 
-		netPlan.Ethernets[r.Name] = npEth
+		npIP.routes = append(
+			npIP.routes,
+			netplan.Route{
+				To:     &route.To,
+				Metric: metric,
+				Via:    &route.Via,
+			},
+		)
 	}
 
-	return netPlan, nil
+	return npIP
+}
+
+func netPlanBondParameters(bond *vmopv1.VirtualMachineNetworkBondSpec) *netplan.BondParameters {
+	if bond == nil {
+		return nil
+	}
+
+	params := &netplan.BondParameters{
+		Mode: ptr.To(netplan.BondMode(bond.Mode)),
+	}
+	if bond.LACPRate != "" {
+		params.LACPRate = ptr.To(netplan.LACPRate(bond.LACPRate))
+	}
+	if bond.MIIMonitorInterval != nil {
+		params.MiiMonitorInterval = ptr.To(strconv.Itoa(int(*bond.MIIMonitorInterval)))
+	}
+	if bond.TransmitHashPolicy != "" {
+		params.TransmitHashPolicy = ptr.To(netplan.TransmitHashPolicy(bond.TransmitHashPolicy))
+	}
+	if bond.Primary != "" {
+		params.Primary = &bond.Primary
+	}
+
+	return params
 }
 
 // NormalizeNetplanMac normalizes the mac address format to one compatible with netplan.
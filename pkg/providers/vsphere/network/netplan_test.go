@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
@@ -245,5 +246,146 @@ var _ = Describe("Netplan", func() {
 				Expect(np.AcceptRa).To(HaveValue(BeFalse()))
 			})
 		})
+
+		Context("Bond, VLAN, and Bridge", func() {
+			const (
+				ifName2       = "my-interface-2"
+				guestDevName2 = "eth43"
+				macAddr2      = "50-8A-80-9D-28-23"
+				bondName      = "bond0"
+				vlanName      = "bond0.100"
+				bridgeName    = "br0"
+			)
+
+			BeforeEach(func() {
+				results.Results = []network.NetworkInterfaceResult{
+					{
+						MacAddress:      macAddr1,
+						Name:            ifName,
+						GuestDeviceName: guestDevName,
+						DHCP4:           true,
+						Nameservers:     []string{dnsServer1},
+					},
+					{
+						MacAddress:      macAddr2,
+						Name:            ifName2,
+						GuestDeviceName: guestDevName2,
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR: ipv4CIDR,
+								IsIPv4: true,
+							},
+						},
+					},
+				}
+				results.Devices = []network.NetworkDeviceResult{
+					{
+						Name:       bondName,
+						Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeBond,
+						Interfaces: []string{ifName, ifName2},
+						Bond: &vmopv1.VirtualMachineNetworkBondSpec{
+							Name:               bondName,
+							Interfaces:         []string{ifName, ifName2},
+							Mode:               vmopv1.VirtualMachineNetworkBondMode8023AD,
+							LACPRate:           vmopv1.VirtualMachineNetworkBondLACPRateFast,
+							MIIMonitorInterval: ptr.To[int32](100),
+							TransmitHashPolicy: vmopv1.VirtualMachineNetworkBondTransmitHashPolicyLayer34,
+						},
+						MTU: 9000,
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR:  ipv4CIDR,
+								IsIPv4:  true,
+								Gateway: ipv4Gateway,
+							},
+						},
+						Nameservers: []string{dnsServer1},
+					},
+					{
+						Name:       vlanName,
+						Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN,
+						Interfaces: []string{bondName},
+						VLANID:     100,
+					},
+					{
+						Name:       bridgeName,
+						Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeBridge,
+						Interfaces: []string{vlanName},
+						Bridge: &vmopv1.VirtualMachineNetworkBridgeSpec{
+							Name:       bridgeName,
+							Interfaces: []string{vlanName},
+							STP:        ptr.To(false),
+						},
+						DHCP6:         true,
+						SearchDomains: []string{searchDomain1},
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).ToNot(BeNil())
+
+				By("bond members are not configured with IP settings", func() {
+					Expect(config.Ethernets).To(HaveLen(2))
+					for _, name := range []string{ifName, ifName2} {
+						Expect(config.Ethernets).To(HaveKey(name))
+						np := config.Ethernets[name]
+						Expect(np.Match).ToNot(BeNil())
+						Expect(np.SetName).ToNot(BeNil())
+						Expect(np.Addresses).To(BeEmpty())
+						Expect(np.Nameservers).To(BeNil())
+						Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+						Expect(np.Dhcp6).To(HaveValue(BeFalse()))
+						Expect(np.AcceptRa).To(HaveValue(BeFalse()))
+					}
+				})
+
+				By("has expected bond", func() {
+					Expect(config.Bonds).To(HaveLen(1))
+					Expect(config.Bonds).To(HaveKey(bondName))
+					np := config.Bonds[bondName]
+					Expect(np.Interfaces).To(HaveExactElements(ifName, ifName2))
+					Expect(np.Parameters).ToNot(BeNil())
+					Expect(np.Parameters.Mode).To(HaveValue(BeEquivalentTo("802.3ad")))
+					Expect(np.Parameters.LACPRate).To(HaveValue(BeEquivalentTo("fast")))
+					Expect(np.Parameters.MiiMonitorInterval).To(HaveValue(Equal("100")))
+					Expect(np.Parameters.TransmitHashPolicy).To(HaveValue(BeEquivalentTo("layer3+4")))
+					Expect(np.Parameters.Primary).To(BeNil())
+					Expect(np.MTU).To(HaveValue(BeEquivalentTo(9000)))
+					Expect(np.Addresses).To(HaveExactElements(netplan.Address{String: ptr.To(ipv4CIDR)}))
+					Expect(np.Gateway4).To(HaveValue(Equal(ipv4Gateway)))
+					Expect(np.Nameservers.Addresses).To(HaveExactElements(dnsServer1))
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Dhcp6).To(HaveValue(BeFalse()))
+				})
+
+				By("has expected VLAN that is a bridge member", func() {
+					Expect(config.Vlans).To(HaveLen(1))
+					Expect(config.Vlans).To(HaveKey(vlanName))
+					np := config.Vlans[vlanName]
+					Expect(np.ID).To(HaveValue(BeEquivalentTo(100)))
+					Expect(np.Link).To(HaveValue(Equal(bondName)))
+					Expect(np.Addresses).To(BeEmpty())
+					Expect(np.Nameservers).To(BeNil())
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Dhcp6).To(HaveValue(BeFalse()))
+				})
+
+				By("has expected bridge", func() {
+					Expect(config.Bridges).To(HaveLen(1))
+					Expect(config.Bridges).To(HaveKey(bridgeName))
+					np := config.Bridges[bridgeName]
+					Expect(np.Interfaces).To(HaveExactElements(vlanName))
+					Expect(np.Parameters).ToNot(BeNil())
+					Expect(np.Parameters.Stp).To(HaveValue(BeFalse()))
+					Expect(np.Addresses).To(BeEmpty())
+					Expect(np.Nameservers.Search).To(HaveExactElements(searchDomain1))
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Dhcp6).To(HaveValue(BeTrue()))
+					Expect(np.AcceptRa).To(HaveValue(BeTrue()))
+				})
+			})
+		})
 	})
 })
@@ -17,6 +17,7 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,12 +38,14 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
 type NetworkInterfaceResults struct {
 	Results                   []NetworkInterfaceResult
+	Devices                   []NetworkDeviceResult
 	UpdatedEthCards           bool
 	OrphanedNetworkInterfaces []ctrlclient.Object
 }
@@ -129,7 +132,11 @@ func CreateAndWaitForNetworkInterfaces(
 	vimClient *vim25.Client,
 	finder *find.Finder,
 	clusterMoRef *vimtypes.ManagedObjectReference,
-	networkSpec *vmopv1.VirtualMachineNetworkSpec) (NetworkInterfaceResults, error) {
+	networkSpec *vmopv1.VirtualMachineNetworkSpec) (_ NetworkInterfaceResults, retErr error) {
+
+	var span trace.Span
+	vmCtx.Context, span = pkgtracing.Start(vmCtx.Context, "network.CreateAndWaitForNetworkInterfaces")
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	networkType := pkgcfg.FromContext(vmCtx).NetworkProviderType
 	if networkType == "" {
@@ -168,6 +175,15 @@ func CreateAndWaitForNetworkInterfaces(
 				fmt.Errorf("network interface %q error: %w", interfaceSpec.Name, err)
 		}
 
+		if interfaceSpec.IPPoolName != "" {
+			ipConfig, err := claimIPPoolAddress(vmCtx, client, interfaceSpec)
+			if err != nil {
+				return NetworkInterfaceResults{},
+					fmt.Errorf("network interface %q error: %w", interfaceSpec.Name, err)
+			}
+			applyIPPoolAddressToResult(ipConfig, result)
+		}
+
 		applyInterfaceSpecToResult(
 			networkSpec,
 			interfaceSpec,
@@ -180,6 +196,10 @@ func CreateAndWaitForNetworkInterfaces(
 
 	return NetworkInterfaceResults{
 		Results: results,
+		Devices: guestDeviceResults(
+			networkSpec,
+			defaultToGlobalNameservers,
+			defaultToGlobalSearchDomains),
 	}, nil
 }
 
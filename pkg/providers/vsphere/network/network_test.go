@@ -260,6 +260,180 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 			})
 		})
 
+		Context("guest has a bond and VLAN", func() {
+			BeforeEach(func() {
+				vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+				}
+
+				networkSpec.Nameservers = []string{"149.112.112.112"}
+				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{
+						Name:    "eth0",
+						Network: &common.PartialObjectRef{Name: networkName},
+					},
+					{
+						Name:    "eth1",
+						Network: &common.PartialObjectRef{Name: networkName},
+					},
+				}
+				networkSpec.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+					{
+						Name:       "bond0",
+						Interfaces: []string{"eth0", "eth1"},
+						Mode:       vmopv1.VirtualMachineNetworkBondModeActiveBackup,
+						VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+							Addresses: []string{"172.42.1.100/24", "fd1a:6c85:79fe:7c98::f/64"},
+							Gateway4:  "172.42.1.1",
+							Gateway6:  "fd1a:6c85:79fe:7c98::1",
+						},
+					},
+				}
+				networkSpec.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+					{
+						Name: "bond0.100",
+						ID:   100,
+						Link: "bond0",
+						VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+							DHCP4:       true,
+							Nameservers: []string{"9.9.9.9"},
+						},
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results.Results).To(HaveLen(2))
+				Expect(results.Devices).To(HaveLen(2))
+
+				bond := results.Devices[0]
+				Expect(bond.Name).To(Equal("bond0"))
+				Expect(bond.Type).To(Equal(vmopv1.VirtualMachineNetworkGuestDeviceTypeBond))
+				Expect(bond.Interfaces).To(HaveExactElements("eth0", "eth1"))
+				Expect(bond.Bond).ToNot(BeNil())
+				Expect(bond.IPConfigs).To(HaveExactElements(
+					network.NetworkInterfaceIPConfig{IPCIDR: "172.42.1.100/24", IsIPv4: true, Gateway: "172.42.1.1"},
+					network.NetworkInterfaceIPConfig{IPCIDR: "fd1a:6c85:79fe:7c98::f/64", Gateway: "fd1a:6c85:79fe:7c98::1"},
+				))
+				Expect(bond.Nameservers).To(HaveExactElements("149.112.112.112"))
+
+				vlan := results.Devices[1]
+				Expect(vlan.Name).To(Equal("bond0.100"))
+				Expect(vlan.Type).To(Equal(vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN))
+				Expect(vlan.Interfaces).To(HaveExactElements("bond0"))
+				Expect(vlan.VLANID).To(BeEquivalentTo(100))
+				Expect(vlan.DHCP4).To(BeTrue())
+				Expect(vlan.IPConfigs).To(BeEmpty())
+				Expect(vlan.Nameservers).To(HaveExactElements("9.9.9.9"))
+
+				Expect(results.IsGuestDeviceMember("eth0")).To(BeTrue())
+				Expect(results.IsGuestDeviceMember("bond0")).To(BeFalse())
+			})
+		})
+
+		Context("interface uses an IP pool", func() {
+			var pool *vmopv1.VirtualMachineIPPool
+
+			BeforeEach(func() {
+				pool = &vmopv1.VirtualMachineIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-ip-pool",
+						Namespace: vm.Namespace,
+					},
+					Spec: vmopv1.VirtualMachineIPPoolSpec{
+						Addresses: []string{"172.42.1.1-172.42.1.3"},
+						Prefix:    24,
+						Gateway:   "172.42.1.1",
+					},
+				}
+				initObjects = append(initObjects, pool)
+
+				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{
+						Name:       "eth0",
+						Network:    &common.PartialObjectRef{Name: networkName},
+						IPPoolName: pool.Name,
+					},
+				}
+			})
+
+			It("returns success with an address from the pool", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results.Results).To(HaveLen(1))
+
+				result := results.Results[0]
+				Expect(result.DHCP4).To(BeFalse())
+				Expect(result.DHCP6).To(BeFalse())
+				Expect(result.NoIPAM).To(BeFalse())
+				Expect(result.IPConfigs).To(HaveLen(1))
+				Expect(result.IPConfigs[0].IPCIDR).To(Equal("172.42.1.2/24"))
+				Expect(result.IPConfigs[0].IsIPv4).To(BeTrue())
+				Expect(result.IPConfigs[0].Gateway).To(Equal("172.42.1.1"))
+
+				By("pool has the allocation", func() {
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+					Expect(pool.Status.Allocations).To(HaveExactElements(vmopv1.VirtualMachineIPPoolAllocation{
+						Address:            "172.42.1.2",
+						VirtualMachineName: vm.Name,
+						VirtualMachineUID:  vm.UID,
+						InterfaceName:      "eth0",
+					}))
+				})
+
+				By("returns the same address when called again", func() {
+					results, err = network.CreateAndWaitForNetworkInterfaces(
+						vmCtx,
+						ctx.Client,
+						ctx.VCClient.Client,
+						ctx.Finder,
+						nil,
+						networkSpec)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+					Expect(results.Results[0].IPConfigs[0].IPCIDR).To(Equal("172.42.1.2/24"))
+
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+					Expect(pool.Status.Allocations).To(HaveLen(1))
+				})
+			})
+
+			When("the pool has no free addresses", func() {
+				BeforeEach(func() {
+					pool.Status.Allocations = []vmopv1.VirtualMachineIPPoolAllocation{
+						{
+							Address:            "172.42.1.2",
+							VirtualMachineName: "other-vm-1",
+							VirtualMachineUID:  "other-vm-1-uid",
+							InterfaceName:      "eth0",
+						},
+						{
+							Address:            "172.42.1.3",
+							VirtualMachineName: "other-vm-2",
+							VirtualMachineUID:  "other-vm-2-uid",
+							InterfaceName:      "eth0",
+						},
+					}
+				})
+
+				It("returns error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`IP pool "my-ip-pool" has no free addresses`))
+				})
+			})
+
+			When("the pool does not exist", func() {
+				BeforeEach(func() {
+					networkSpec.Interfaces[0].IPPoolName = "bogus"
+				})
+
+				It("returns error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`IP pool "bogus" does not exist`))
+				})
+			})
+		})
+
 		Context("network does not exist", func() {
 			BeforeEach(func() {
 				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
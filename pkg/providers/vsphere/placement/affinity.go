@@ -0,0 +1,562 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+var (
+	// ErrNoZoneAffinityMatch is returned when none of the candidate zones
+	// satisfy the VM's required zone affinity.
+	ErrNoZoneAffinityMatch = fmt.Errorf(
+		"no zones satisfy the required zone affinity: %w", ErrNoPlacementCandidates)
+
+	// ErrNoHostAffinityMatch is returned when none of the candidate hosts
+	// satisfy the VM's required host affinity.
+	ErrNoHostAffinityMatch = fmt.Errorf(
+		"no hosts satisfy the required host affinity: %w", ErrNoPlacementCandidates)
+)
+
+// hasZoneAffinity returns true if the VM has zone affinity terms.
+func hasZoneAffinity(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.Affinity == nil || vm.Spec.Affinity.ZoneAffinity == nil {
+		return false
+	}
+	za := vm.Spec.Affinity.ZoneAffinity
+	return len(za.RequiredDuringSchedulingIgnoredDuringExecution) > 0 ||
+		len(za.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+// hasHostAffinity returns true if the VM has host affinity terms.
+func hasHostAffinity(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.Affinity == nil || vm.Spec.Affinity.HostAffinity == nil {
+		return false
+	}
+	ha := vm.Spec.Affinity.HostAffinity
+	return len(ha.RequiredDuringSchedulingIgnoredDuringExecution) > 0 ||
+		len(ha.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+// zoneAffinity evaluates a VM's zone affinity against the labels of the zones
+// available to its namespace.
+type zoneAffinity struct {
+	required  []labels.Selector
+	preferred []labels.Selector
+
+	// zoneLabels are the labels of each zone, keyed by the zone's name.
+	zoneLabels map[string]labels.Set
+}
+
+func zoneSelectors(terms []vmopv1.ZoneSelectorTerm) ([]labels.Selector, error) {
+	selectors := make([]labels.Selector, len(terms))
+	for i := range terms {
+		selectors[i] = labels.Nothing()
+		if terms[i].LabelSelector != nil {
+			s, err := metav1.LabelSelectorAsSelector(terms[i].LabelSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid zone affinity label selector: %w", err)
+			}
+			selectors[i] = s
+		}
+	}
+	return selectors, nil
+}
+
+// newZoneAffinity returns the zone affinity of the VM. The VM must have zone
+// affinity terms.
+func newZoneAffinity(
+	ctx context.Context,
+	client ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) (*zoneAffinity, error) {
+
+	spec := vm.Spec.Affinity.ZoneAffinity
+
+	required, err := zoneSelectors(spec.RequiredDuringSchedulingIgnoredDuringExecution)
+	if err != nil {
+		return nil, err
+	}
+	preferred, err := zoneSelectors(spec.PreferredDuringSchedulingIgnoredDuringExecution)
+	if err != nil {
+		return nil, err
+	}
+
+	za := &zoneAffinity{
+		required:   required,
+		preferred:  preferred,
+		zoneLabels: map[string]labels.Set{},
+	}
+
+	if pkgcfg.FromContext(ctx).Features.WorkloadDomainIsolation {
+		zones, err := topology.GetZones(ctx, client, vm.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for i := range zones {
+			za.zoneLabels[zones[i].Name] = zones[i].Labels
+		}
+	} else {
+		azs, err := topology.GetAvailabilityZones(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		for i := range azs {
+			za.zoneLabels[azs[i].Name] = azs[i].Labels
+		}
+	}
+
+	return za, nil
+}
+
+// filterCandidates removes the candidate zones that do not satisfy all of the
+// required terms.
+func (za *zoneAffinity) filterCandidates(
+	candidates map[string][]string) (map[string][]string, error) {
+
+	if len(za.required) == 0 {
+		return candidates, nil
+	}
+
+	allowedCandidates := map[string][]string{}
+	for zoneName, rpMoIDs := range candidates {
+		if matchesAllSelectors(za.required, za.zoneLabels[zoneName]) {
+			allowedCandidates[zoneName] = rpMoIDs
+		}
+	}
+
+	if len(allowedCandidates) == 0 {
+		return nil, ErrNoZoneAffinityMatch
+	}
+
+	return allowedCandidates, nil
+}
+
+// rankCandidates groups the candidate zones into tiers by the number of
+// preferred terms they satisfy, most first. Each tier also includes the zones
+// of the tiers before it, so the last tier is all of the candidates and a zone
+// that does not satisfy any preferred term is still used when the more
+// preferred zones cannot be placed on.
+func (za *zoneAffinity) rankCandidates(
+	candidates map[string][]string) []map[string][]string {
+
+	if len(za.preferred) == 0 {
+		return []map[string][]string{candidates}
+	}
+
+	scores := make(map[string]int, len(candidates))
+	for zoneName := range candidates {
+		scores[zoneName] = 0
+		for _, s := range za.preferred {
+			if s.Matches(za.zoneLabels[zoneName]) {
+				scores[zoneName]++
+			}
+		}
+	}
+
+	distinctScores := slices.Sorted(maps.Values(scores))
+	distinctScores = slices.Compact(distinctScores)
+	slices.Reverse(distinctScores)
+
+	tiers := make([]map[string][]string, 0, len(distinctScores))
+	for _, minScore := range distinctScores {
+		tier := map[string][]string{}
+		for zoneName, rpMoIDs := range candidates {
+			if scores[zoneName] >= minScore {
+				tier[zoneName] = rpMoIDs
+			}
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers
+}
+
+func matchesAllSelectors(selectors []labels.Selector, set labels.Set) bool {
+	for _, s := range selectors {
+		if !s.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagKey identifies a vSphere tag by the names of its category and itself.
+type tagKey struct {
+	category string
+	name     string
+}
+
+// hostAffinity evaluates a VM's host affinity against the vSphere tags
+// attached to the candidate hosts and their clusters.
+type hostAffinity struct {
+	required  []vmopv1.HostSelectorTerm
+	preferred []vmopv1.HostSelectorTerm
+
+	// tags are the tags attached to each candidate host and cluster, keyed by
+	// the object's MoID.
+	tags map[string]sets.Set[tagKey]
+
+	// hostClusters is the cluster MoID of each candidate host, keyed by the
+	// host's MoID.
+	hostClusters map[string]string
+
+	// rpClusters is the cluster MoID of each candidate ResourcePool, keyed by
+	// the ResourcePool's MoID.
+	rpClusters map[string]string
+
+	// clusterHosts are the eligible hosts that satisfy all of the required
+	// terms in each cluster, keyed by the cluster's MoID and then by the
+	// host's name.
+	clusterHosts map[string]map[string]vimtypes.ManagedObjectReference
+}
+
+// newHostAffinity returns the host affinity of the VM for the hosts of the
+// candidate ResourcePools. The VM must have host affinity terms, and the
+// context must have a REST client.
+func newHostAffinity(
+	ctx context.Context,
+	vcClient *vim25.Client,
+	vm *vmopv1.VirtualMachine,
+	candidates map[string][]string) (*hostAffinity, error) {
+
+	restClient := pkgctx.GetRestClient(ctx)
+	if restClient == nil {
+		return nil, errors.New("rest client not found in context")
+	}
+
+	spec := vm.Spec.Affinity.HostAffinity
+	ha := &hostAffinity{
+		required:     spec.RequiredDuringSchedulingIgnoredDuringExecution,
+		preferred:    spec.PreferredDuringSchedulingIgnoredDuringExecution,
+		tags:         map[string]sets.Set[tagKey]{},
+		hostClusters: map[string]string{},
+		rpClusters:   map[string]string{},
+		clusterHosts: map[string]map[string]vimtypes.ManagedObjectReference{},
+	}
+
+	var objs []mo.Reference
+	for _, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			rpMoRef := vimtypes.ManagedObjectReference{
+				Type:  string(vimtypes.ManagedObjectTypeResourcePool),
+				Value: rpMoID,
+			}
+
+			cluster, err := rpMoIDToCluster(ctx, vcClient, rpMoRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster for ResourcePool %s: %w", rpMoID, err)
+			}
+
+			clusterMoID := cluster.Reference().Value
+			ha.rpClusters[rpMoID] = clusterMoID
+			if _, ok := ha.clusterHosts[clusterMoID]; ok {
+				continue
+			}
+
+			hosts, err := getClusterHosts(ctx, vcClient, cluster)
+			if err != nil {
+				return nil, err
+			}
+
+			ha.clusterHosts[clusterMoID] = hosts
+			objs = append(objs, cluster.Reference())
+			for _, hostMoRef := range hosts {
+				ha.hostClusters[hostMoRef.Value] = clusterMoID
+				objs = append(objs, hostMoRef)
+			}
+		}
+	}
+
+	if len(objs) > 0 {
+		mgr := tags.NewManager(restClient)
+
+		categories, err := mgr.GetCategories(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag categories: %w", err)
+		}
+		categoryNames := make(map[string]string, len(categories))
+		for _, c := range categories {
+			categoryNames[c.ID] = c.Name
+		}
+
+		attached, err := mgr.GetAttachedTagsOnObjects(ctx, objs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attached tags: %w", err)
+		}
+		for _, a := range attached {
+			moID := a.ObjectID.Reference().Value
+			if ha.tags[moID] == nil {
+				ha.tags[moID] = sets.New[tagKey]()
+			}
+			for _, t := range a.Tags {
+				ha.tags[moID].Insert(tagKey{category: categoryNames[t.CategoryID], name: t.Name})
+			}
+		}
+	}
+
+	// Remove the hosts that do not satisfy the required terms.
+	for _, hosts := range ha.clusterHosts {
+		for name, hostMoRef := range hosts {
+			if !ha.matchesAll(ha.required, hostMoRef.Value) {
+				delete(hosts, name)
+			}
+		}
+	}
+
+	return ha, nil
+}
+
+// matches returns true if the host satisfies the term.
+func (ha *hostAffinity) matches(term vmopv1.HostSelectorTerm, hostMoID string) bool {
+	var found bool
+	for _, moID := range []string{hostMoID, ha.hostClusters[hostMoID]} {
+		for _, name := range term.Tags {
+			if ha.tags[moID].Has(tagKey{category: term.Category, name: name}) {
+				found = true
+			}
+		}
+	}
+	if term.Operator == vmopv1.HostSelectorOpNotIn {
+		return !found
+	}
+	return found
+}
+
+func (ha *hostAffinity) matchesAll(terms []vmopv1.HostSelectorTerm, hostMoID string) bool {
+	for _, term := range terms {
+		if !ha.matches(term, hostMoID) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterCandidates removes the candidate ResourcePools whose clusters do not
+// have any hosts that satisfy all of the required terms.
+func (ha *hostAffinity) filterCandidates(
+	candidates map[string][]string) (map[string][]string, error) {
+
+	allowedCandidates := map[string][]string{}
+	for zoneName, rpMoIDs := range candidates {
+		var allowedRPMoIDs []string
+		for _, rpMoID := range rpMoIDs {
+			if len(ha.clusterHosts[ha.rpClusters[rpMoID]]) > 0 {
+				allowedRPMoIDs = append(allowedRPMoIDs, rpMoID)
+			}
+		}
+		if len(allowedRPMoIDs) > 0 {
+			allowedCandidates[zoneName] = allowedRPMoIDs
+		}
+	}
+
+	if len(allowedCandidates) == 0 {
+		return nil, ErrNoHostAffinityMatch
+	}
+
+	return allowedCandidates, nil
+}
+
+// eligibleHosts returns the hosts in the cluster of the ResourcePool that
+// satisfy all of the required terms, keyed by their names.
+func (ha *hostAffinity) eligibleHosts(
+	rpMoRef vimtypes.ManagedObjectReference) map[string]vimtypes.ManagedObjectReference {
+
+	return ha.clusterHosts[ha.rpClusters[rpMoRef.Value]]
+}
+
+// rankCandidates groups the candidate ResourcePools into tiers by the most
+// preferred terms satisfied by an eligible host in their clusters, most
+// first. Each tier also includes the ResourcePools of the tiers before it, so
+// the last tier is all of the candidates.
+func (ha *hostAffinity) rankCandidates(
+	candidates map[string][]string) []map[string][]string {
+
+	if len(ha.preferred) == 0 {
+		return []map[string][]string{candidates}
+	}
+
+	scores := map[string]int{}
+	for _, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			scores[rpMoID] = 0
+			hosts := ha.clusterHosts[ha.rpClusters[rpMoID]]
+			for _, score := range ha.scoreHosts(hosts, slices.Collect(maps.Keys(hosts))) {
+				scores[rpMoID] = max(scores[rpMoID], score)
+			}
+		}
+	}
+
+	distinctScores := slices.Sorted(maps.Values(scores))
+	distinctScores = slices.Compact(distinctScores)
+	slices.Reverse(distinctScores)
+
+	tiers := make([]map[string][]string, 0, len(distinctScores))
+	for _, minScore := range distinctScores {
+		tier := map[string][]string{}
+		for zoneName, rpMoIDs := range candidates {
+			for _, rpMoID := range rpMoIDs {
+				if scores[rpMoID] >= minScore {
+					tier[zoneName] = append(tier[zoneName], rpMoID)
+				}
+			}
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers
+}
+
+// scoreHosts returns the number of preferred terms each of the named hosts
+// satisfies.
+func (ha *hostAffinity) scoreHosts(
+	hosts map[string]vimtypes.ManagedObjectReference,
+	names []string) map[string]int {
+
+	scores := make(map[string]int, len(names))
+	for _, name := range names {
+		for _, term := range ha.preferred {
+			if ha.matches(term, hosts[name].Value) {
+				scores[name]++
+			}
+		}
+	}
+	return scores
+}
+
+// selectHost returns the host in which to place the VM so that its host
+// affinity is satisfied. The hosts map is keyed by the name of each eligible
+// host. The recommended host is returned if it is eligible and satisfies the
+// most preferred terms, otherwise the eligible host that satisfies the most
+// preferred terms is returned. If hostFixed is true, the recommended host may
+// not be changed, and an error is returned if it is not eligible.
+func (ha *hostAffinity) selectHost(
+	hosts map[string]vimtypes.ManagedObjectReference,
+	recHost *vimtypes.ManagedObjectReference,
+	hostFixed bool) (*vimtypes.ManagedObjectReference, error) {
+
+	hostNames := slices.Sorted(maps.Keys(hosts))
+
+	var recHostName string
+	if recHost != nil {
+		for name, hostMoRef := range hosts {
+			if hostMoRef == *recHost {
+				recHostName = name
+			}
+		}
+	}
+
+	if recHost != nil && hostFixed {
+		if recHostName == "" {
+			return nil, fmt.Errorf("host %s: %w", recHost.Value, ErrNoHostAffinityMatch)
+		}
+		return recHost, nil
+	}
+
+	if len(hostNames) == 0 {
+		return nil, ErrNoHostAffinityMatch
+	}
+
+	// The preferred terms only order the eligible hosts, so a host that does
+	// not satisfy any of them is still used when it is the only one eligible.
+	scores := ha.scoreHosts(hosts, hostNames)
+	bestScore := slices.Max(slices.Collect(maps.Values(scores)))
+
+	if recHostName != "" && scores[recHostName] == bestScore {
+		return recHost, nil
+	}
+
+	// The hosts are sorted by name so the result is stable.
+	for _, name := range hostNames {
+		if scores[name] == bestScore {
+			hostMoRef := hosts[name]
+			return &hostMoRef, nil
+		}
+	}
+
+	return nil, ErrNoHostAffinityMatch
+}
+
+// getEligibleHosts returns the hosts that are connected and not in maintenance
+// mode in the cluster that owns the resource pool, keyed by their names.
+func getEligibleHosts(
+	ctx context.Context,
+	vcClient *vim25.Client,
+	rpMoRef vimtypes.ManagedObjectReference) (map[string]vimtypes.ManagedObjectReference, error) {
+
+	cluster, err := rpMoIDToCluster(ctx, vcClient, rpMoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster for ResourcePool %s: %w", rpMoRef.Value, err)
+	}
+
+	return getClusterHosts(ctx, vcClient, cluster)
+}
+
+// getClusterHosts returns the hosts that are connected and not in maintenance
+// mode in the cluster, keyed by their names.
+func getClusterHosts(
+	ctx context.Context,
+	vcClient *vim25.Client,
+	cluster *object.ClusterComputeResource) (map[string]vimtypes.ManagedObjectReference, error) {
+
+	hostRefs, err := cluster.Hosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hosts of cluster %s: %w", cluster.Reference().Value, err)
+	}
+	if len(hostRefs) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]vimtypes.ManagedObjectReference, len(hostRefs))
+	for i := range hostRefs {
+		refs[i] = hostRefs[i].Reference()
+	}
+
+	var moHosts []mo.HostSystem
+	pc := property.DefaultCollector(vcClient)
+	if err := pc.Retrieve(
+		ctx,
+		refs,
+		[]string{
+			"name",
+			"runtime.connectionState",
+			"runtime.inMaintenanceMode",
+		},
+		&moHosts); err != nil {
+
+		return nil, fmt.Errorf("failed to get host properties: %w", err)
+	}
+
+	hosts := make(map[string]vimtypes.ManagedObjectReference, len(moHosts))
+	for i := range moHosts {
+		h := moHosts[i]
+		if h.Runtime.ConnectionState != vimtypes.HostSystemConnectionStateConnected ||
+			h.Runtime.InMaintenanceMode {
+			continue
+		}
+		hosts[h.Name] = h.Reference()
+	}
+
+	return hosts, nil
+}
@@ -13,10 +13,12 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/trace"
 
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 )
 
@@ -183,7 +185,11 @@ func CloneVMRelocateSpec(
 func PlaceVMForCreate(
 	vmCtx pkgctx.VirtualMachineContext,
 	cluster *object.ClusterComputeResource,
-	configSpec vimtypes.VirtualMachineConfigSpec) (*Recommendation, error) {
+	configSpec vimtypes.VirtualMachineConfigSpec) (_ *Recommendation, retErr error) {
+
+	var span trace.Span
+	vmCtx.Context, span = pkgtracing.Start(vmCtx.Context, "placement.PlaceVMForCreate")
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	placementSpec := vimtypes.PlacementSpec{
 		PlacementType: string(vimtypes.PlacementSpecPlacementTypeCreate),
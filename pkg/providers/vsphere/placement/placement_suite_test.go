@@ -15,6 +15,7 @@ import (
 
 func vcSimTests() {
 	Describe("Placement", Label(testlabels.VCSim), vcSimPlacement)
+	Describe("Rebalance", Label(testlabels.VCSim), vcSimRebalance)
 }
 
 var suite = builder.NewTestSuite()
@@ -0,0 +1,344 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/vmware/govmomi/vim25"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// HostEvaluation is the result of evaluating the preferred rules of a VM
+// against the eligible hosts in its zone.
+type HostEvaluation struct {
+	// Violations describes the preferred rules that are violated on the VM's
+	// current host.
+	Violations []string
+
+	// TargetHost is the name of the eligible host on which the fewest
+	// preferred rules are violated. It is empty when no host violates fewer
+	// rules than the VM's current host.
+	TargetHost string
+
+	// TargetHostMoRef is the reference to TargetHost.
+	TargetHostMoRef vimtypes.ManagedObjectReference
+
+	// TargetPoolMoRef is the reference to the ResourcePool in the cluster of
+	// TargetHost that the VM is moved to.
+	TargetPoolMoRef vimtypes.ManagedObjectReference
+
+	// TargetViolations describes the preferred rules that would be violated
+	// on TargetHost.
+	TargetViolations []string
+}
+
+// HasRebalanceRules returns true if the VM has rules that are preferred while
+// it is running, which may be repaired by moving the VM to another host in
+// its zone. These are:
+//
+//   - VM affinity and anti-affinity terms across hosts that are preferred
+//     during execution.
+//   - Preferred host and zone affinity terms.
+//
+// VM affinity and anti-affinity terms across zones are not considered because
+// the VM cannot be moved to another zone.
+func HasRebalanceRules(vm *vmopv1.VirtualMachine) bool {
+	affinity, antiAffinity := getHostVMAffinityTerms(vm)
+	if len(affinity) > 0 || len(antiAffinity) > 0 {
+		return true
+	}
+	if a := vm.Spec.Affinity; a != nil {
+		if a.HostAffinity != nil && len(a.HostAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+			return true
+		}
+		if a.ZoneAffinity != nil && len(a.ZoneAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getHostVMAffinityTerms returns the VM's affinity and anti-affinity terms
+// across hosts that are preferred during execution.
+func getHostVMAffinityTerms(vm *vmopv1.VirtualMachine) (affinity, antiAffinity []vmopv1.VMAffinityTerm) {
+	a := vm.Spec.Affinity
+	if a == nil {
+		return nil, nil
+	}
+
+	filter := func(terms ...[]vmopv1.VMAffinityTerm) []vmopv1.VMAffinityTerm {
+		var out []vmopv1.VMAffinityTerm
+		for _, t := range slices.Concat(terms...) {
+			if t.TopologyKey == corev1.LabelHostname {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+
+	if a.VMAffinity != nil {
+		affinity = filter(
+			a.VMAffinity.RequiredDuringSchedulingPreferredDuringExecution,
+			a.VMAffinity.PreferredDuringSchedulingPreferredDuringExecution)
+	}
+	if a.VMAntiAffinity != nil {
+		antiAffinity = filter(
+			a.VMAntiAffinity.RequiredDuringSchedulingPreferredDuringExecution,
+			a.VMAntiAffinity.PreferredDuringSchedulingPreferredDuringExecution)
+	}
+	return affinity, antiAffinity
+}
+
+// EvaluateHosts returns the preferred rules of the VM that are violated on its
+// current host, and the eligible host in the VM's zone on which the fewest of
+// them would be violated. The eligible hosts are those in the clusters of the
+// zone's ResourcePools, or of their child ResourcePools named childRPName, so
+// the VM may be moved to another ResourcePool in its zone. The VM's current
+// host is the value of its status.nodeName field, and rpMoRef is its current
+// ResourcePool.
+func EvaluateHosts(
+	ctx context.Context,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	vm *vmopv1.VirtualMachine,
+	rpMoRef vimtypes.ManagedObjectReference,
+	childRPName string) (HostEvaluation, error) {
+
+	if vm.Status.NodeName == "" || !HasRebalanceRules(vm) {
+		return HostEvaluation{}, nil
+	}
+
+	zoneName := vm.Status.Zone
+	if zoneName == "" {
+		zoneName = vm.Labels[corev1.LabelTopologyZone]
+	}
+
+	// The VM's current ResourcePool is first so that a host in its cluster
+	// keeps the VM in that ResourcePool.
+	rpMoIDs := []string{rpMoRef.Value}
+	if zoneName != "" {
+		candidates, _, err := getPlacementCandidates(ctx, client, vcClient, zoneName, vm.Namespace, childRPName)
+		if err != nil {
+			return HostEvaluation{}, err
+		}
+		for _, rpMoID := range candidates[zoneName] {
+			if !slices.Contains(rpMoIDs, rpMoID) {
+				rpMoIDs = append(rpMoIDs, rpMoID)
+			}
+		}
+	}
+
+	var (
+		ha  *hostAffinity
+		za  *zoneAffinity
+		err error
+	)
+	if hasHostAffinity(vm) {
+		// Only the hosts that satisfy the required host affinity are eligible.
+		if ha, err = newHostAffinity(ctx, vcClient, vm, map[string][]string{zoneName: rpMoIDs}); err != nil {
+			return HostEvaluation{}, err
+		}
+	}
+	if hasZoneAffinity(vm) {
+		if za, err = newZoneAffinity(ctx, client, vm); err != nil {
+			return HostEvaluation{}, err
+		}
+	}
+
+	hosts := map[string]vimtypes.ManagedObjectReference{}
+	hostPools := map[string]vimtypes.ManagedObjectReference{}
+	for _, rpMoID := range rpMoIDs {
+		rp := vimtypes.ManagedObjectReference{
+			Type:  string(vimtypes.ManagedObjectTypeResourcePool),
+			Value: rpMoID,
+		}
+
+		var rpHosts map[string]vimtypes.ManagedObjectReference
+		if ha != nil {
+			rpHosts = ha.eligibleHosts(rp)
+		} else if rpHosts, err = getEligibleHosts(ctx, vcClient, rp); err != nil {
+			return HostEvaluation{}, err
+		}
+
+		for name, hostMoRef := range rpHosts {
+			if _, ok := hosts[name]; !ok {
+				hosts[name] = hostMoRef
+				hostPools[name] = rp
+			}
+		}
+	}
+
+	var peers []vmopv1.VirtualMachine
+	if affinity, antiAffinity := getHostVMAffinityTerms(vm); len(affinity) > 0 || len(antiAffinity) > 0 {
+		if peers, err = getPeerVMs(ctx, client, vm); err != nil {
+			return HostEvaluation{}, err
+		}
+	}
+
+	result, err := evaluateHosts(vm, peers, hosts, ha, za, zoneName)
+	if err != nil {
+		return HostEvaluation{}, err
+	}
+	if result.TargetHost != "" {
+		result.TargetPoolMoRef = hostPools[result.TargetHost]
+	}
+
+	return result, nil
+}
+
+// evaluateHosts returns the preferred rules of the VM that are violated on its
+// current host, and the host on which the fewest of them would be violated.
+// A host is only returned if fewer rules are violated on it than on the VM's
+// current host. The hosts are compared in order of their names so the result
+// is stable.
+func evaluateHosts(
+	vm *vmopv1.VirtualMachine,
+	peers []vmopv1.VirtualMachine,
+	hosts map[string]vimtypes.ManagedObjectReference,
+	ha *hostAffinity,
+	za *zoneAffinity,
+	zoneName string) (HostEvaluation, error) {
+
+	toSelectors := func(terms []vmopv1.VMAffinityTerm) ([]labels.Selector, error) {
+		selectors := make([]labels.Selector, len(terms))
+		for i, term := range terms {
+			selectors[i] = labels.Nothing()
+			if term.LabelSelector != nil {
+				s, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+				if err != nil {
+					return nil, fmt.Errorf("invalid affinity label selector: %w", err)
+				}
+				selectors[i] = s
+			}
+		}
+		return selectors, nil
+	}
+
+	affinityTerms, antiAffinityTerms := getHostVMAffinityTerms(vm)
+	affinity, err := toSelectors(affinityTerms)
+	if err != nil {
+		return HostEvaluation{}, err
+	}
+	antiAffinity, err := toSelectors(antiAffinityTerms)
+	if err != nil {
+		return HostEvaluation{}, err
+	}
+
+	curHost := vm.Status.NodeName
+	hostNames := make([]string, 0, len(hosts)+1)
+	for name := range hosts {
+		hostNames = append(hostNames, name)
+	}
+	if _, ok := hosts[curHost]; !ok {
+		// The current host is always counted, even when it is no longer
+		// eligible, so the VMs on it are not ignored.
+		hostNames = append(hostNames, curHost)
+	}
+	slices.Sort(hostNames)
+
+	countMatches := func(selector labels.Selector, hostName string) int {
+		var n int
+		for j := range peers {
+			if peers[j].Status.NodeName == hostName && selector.Matches(labels.Set(peers[j].Labels)) {
+				n++
+			}
+		}
+		return n
+	}
+
+	violations := func(hostName string) []string {
+		var v []string
+		for i, selector := range affinity {
+			if countMatches(selector, hostName) == 0 {
+				v = append(v, fmt.Sprintf(
+					"affinity term %d matches no VMs on the host", i))
+			}
+		}
+		for i, selector := range antiAffinity {
+			if n := countMatches(selector, hostName); n > 0 {
+				v = append(v, fmt.Sprintf(
+					"anti-affinity term %d matches %d VMs on the host", i, n))
+			}
+		}
+		if ha != nil {
+			// An ineligible current host does not satisfy any term.
+			hostMoRef, ok := hosts[hostName]
+			for i, term := range ha.preferred {
+				if !ok || !ha.matches(term, hostMoRef.Value) {
+					v = append(v, fmt.Sprintf(
+						"preferred host affinity term %d is not satisfied by the host", i))
+				}
+			}
+		}
+		if za != nil {
+			// All of the hosts are in the same zone, so these are only
+			// reported and do not change which host is selected.
+			for i, selector := range za.preferred {
+				if !selector.Matches(za.zoneLabels[zoneName]) {
+					v = append(v, fmt.Sprintf(
+						"preferred zone affinity term %d is not satisfied by zone %s", i, zoneName))
+				}
+			}
+		}
+		return v
+	}
+
+	result := HostEvaluation{
+		Violations: violations(curHost),
+	}
+	if len(result.Violations) == 0 {
+		return result, nil
+	}
+
+	for _, name := range hostNames {
+		hostMoRef, ok := hosts[name]
+		if !ok || name == curHost {
+			continue
+		}
+		v := violations(name)
+		if result.TargetHost == "" && len(v) < len(result.Violations) ||
+			result.TargetHost != "" && len(v) < len(result.TargetViolations) {
+
+			result.TargetHost = name
+			result.TargetHostMoRef = hostMoRef
+			result.TargetViolations = v
+		}
+	}
+
+	return result, nil
+}
+
+// getPeerVMs returns the other VMs in the VM's namespace that may be matched
+// by the VM's affinity and anti-affinity terms.
+func getPeerVMs(
+	ctx context.Context,
+	client ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) ([]vmopv1.VirtualMachine, error) {
+
+	var list vmopv1.VirtualMachineList
+	if err := client.List(ctx, &list, ctrlclient.InNamespace(vm.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VMs for affinity terms: %w", err)
+	}
+
+	peers := make([]vmopv1.VirtualMachine, 0, len(list.Items))
+	for i := range list.Items {
+		peer := list.Items[i]
+		if peer.Name == vm.Name || !peer.DeletionTimestamp.IsZero() {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+
+	return peers, nil
+}
@@ -0,0 +1,226 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func vcSimRebalance() {
+	const appLabelKey = "app"
+
+	var (
+		parentCtx  context.Context
+		ctx        *builder.TestContextForVCSim
+		testConfig builder.VCSimTestConfig
+
+		vm        *vmopv1.VirtualMachine
+		peers     []*vmopv1.VirtualMachine
+		curHost   string
+		rpMoRef   vimtypes.ManagedObjectReference
+		hostNames []string
+	)
+
+	newPeer := func(name string) *vmopv1.VirtualMachine {
+		peer := builder.DummyVirtualMachine()
+		peer.Name = name
+		peer.Labels = map[string]string{appLabelKey: "db"}
+		return peer
+	}
+
+	BeforeEach(func() {
+		parentCtx = pkgcfg.NewContextWithDefaultConfig()
+		testConfig = builder.VCSimTestConfig{
+			NumFaultDomains: 1,
+		}
+
+		vm = builder.DummyVirtualMachine()
+		vm.Name = "rebalance-test"
+		vm.Labels = map[string]string{appLabelKey: "db"}
+		peers = nil
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSimWithParentContext(parentCtx, testConfig)
+		nsInfo := ctx.CreateWorkloadNamespace()
+
+		hosts, err := ctx.Finder.HostSystemList(ctx, "*")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(hosts)).To(BeNumerically(">", 1))
+
+		rp, err := hosts[0].ResourcePool(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		rpMoRef = rp.Reference()
+
+		curHost = hosts[0].Name()
+		hostNames = nil
+		for _, h := range hosts {
+			hostNames = append(hostNames, h.Name())
+		}
+
+		vm.Namespace = nsInfo.Namespace
+		vm.Status.NodeName = curHost
+
+		for _, peer := range peers {
+			peer.Namespace = nsInfo.Namespace
+			if peer.Status.NodeName == "" {
+				peer.Status.NodeName = curHost
+			}
+			status := peer.Status.DeepCopy()
+			Expect(ctx.Client.Create(ctx, peer)).To(Succeed())
+			peer.Status = *status
+			Expect(ctx.Client.Status().Update(ctx, peer)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	evaluate := func() placement.HostEvaluation {
+		result, err := placement.EvaluateHosts(ctx, ctx.Client, ctx.VCClient.Client, vm, rpMoRef, "")
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	expectTargetHost := func(result placement.HostEvaluation) {
+		Expect(result.TargetHost).To(BeElementOf(hostNames))
+		Expect(result.TargetHost).ToNot(Equal(curHost))
+		Expect(result.TargetViolations).To(BeEmpty())
+
+		name, err := object.NewHostSystem(ctx.VCClient.Client, result.TargetHostMoRef).ObjectName(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(Equal(result.TargetHost))
+		Expect(result.TargetPoolMoRef).To(Equal(rpMoRef))
+	}
+
+	When("the VM does not have preferred rules across hosts", func() {
+		BeforeEach(func() {
+			peers = append(peers, newPeer("peer-0"))
+		})
+
+		It("returns no violations", func() {
+			result := evaluate()
+			Expect(result.Violations).To(BeEmpty())
+			Expect(result.TargetHost).To(BeEmpty())
+		})
+	})
+
+	Context("Preferred anti-affinity", func() {
+		BeforeEach(func() {
+			vm.Spec.Affinity = &vmopv1.AffinitySpec{
+				VMAntiAffinity: &vmopv1.VMAntiAffinitySpec{
+					PreferredDuringSchedulingPreferredDuringExecution: []vmopv1.VMAffinityTerm{
+						{
+							TopologyKey: corev1.LabelHostname,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{appLabelKey: "db"},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		When("a matching VM is on the same host", func() {
+			BeforeEach(func() {
+				peers = append(peers, newPeer("peer-0"))
+			})
+
+			It("returns the violation and a host without matching VMs", func() {
+				result := evaluate()
+				Expect(result.Violations).To(HaveLen(1))
+				Expect(result.Violations[0]).To(ContainSubstring("anti-affinity"))
+				expectTargetHost(result)
+			})
+		})
+
+		When("the matching VMs are on other hosts", func() {
+			BeforeEach(func() {
+				peer := newPeer("peer-0")
+				peer.Status.NodeName = "some-other-host"
+				peers = append(peers, peer)
+			})
+
+			It("returns no violations", func() {
+				result := evaluate()
+				Expect(result.Violations).To(BeEmpty())
+				Expect(result.TargetHost).To(BeEmpty())
+			})
+		})
+
+		When("the VM does not have a current host", func() {
+			JustBeforeEach(func() {
+				vm.Status.NodeName = ""
+			})
+
+			It("returns no violations", func() {
+				result := evaluate()
+				Expect(result.Violations).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Preferred affinity", func() {
+		BeforeEach(func() {
+			vm.Spec.Affinity = &vmopv1.AffinitySpec{
+				VMAffinity: &vmopv1.VMAffinitySpec{
+					PreferredDuringSchedulingPreferredDuringExecution: []vmopv1.VMAffinityTerm{
+						{
+							TopologyKey: corev1.LabelHostname,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{appLabelKey: "cache"},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		When("a matching VM is on another host", func() {
+			BeforeEach(func() {
+				peer := newPeer("peer-0")
+				peer.Labels[appLabelKey] = "cache"
+				peers = append(peers, peer)
+			})
+
+			JustBeforeEach(func() {
+				peer := peers[0]
+				Expect(ctx.Client.Get(ctx, ctrlclient.ObjectKeyFromObject(peer), peer)).To(Succeed())
+				for _, h := range hostNames {
+					if h != curHost {
+						peer.Status.NodeName = h
+						break
+					}
+				}
+				Expect(ctx.Client.Status().Update(ctx, peer)).To(Succeed())
+			})
+
+			It("returns the violation and the host with the matching VM", func() {
+				result := evaluate()
+				Expect(result.Violations).To(HaveLen(1))
+				Expect(result.Violations[0]).To(ContainSubstring("affinity term 0 matches no VMs"))
+				expectTargetHost(result)
+				Expect(result.TargetHost).To(Equal(peers[0].Status.NodeName))
+			})
+		})
+	})
+
+}
@@ -0,0 +1,131 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// ErrInsufficientReservation is returned when none of the candidate
+// ResourcePools have enough unreserved capacity left for a VM's reservations.
+var ErrInsufficientReservation = fmt.Errorf(
+	"no ResourcePools have enough unreserved capacity: %w", ErrNoPlacementCandidates)
+
+// Reservation is the CPU and memory reserved by one or more VMs.
+type Reservation struct {
+	// CPU is the reserved CPU in MHz.
+	CPU int64
+
+	// Memory is the reserved memory in MB.
+	Memory int64
+}
+
+// Add returns the sum of the reservations.
+func (r Reservation) Add(o Reservation) Reservation {
+	return Reservation{
+		CPU:    r.CPU + o.CPU,
+		Memory: r.Memory + o.Memory,
+	}
+}
+
+// IsZero returns true if nothing is reserved.
+func (r Reservation) IsZero() bool {
+	return r.CPU == 0 && r.Memory == 0
+}
+
+// ConfigSpecReservation returns the CPU and memory reservations of the
+// ConfigSpec.
+func ConfigSpecReservation(configSpec vimtypes.VirtualMachineConfigSpec) Reservation {
+	var r Reservation
+	if a := configSpec.CpuAllocation; a != nil {
+		r.CPU = ptr.Deref(a.Reservation)
+	}
+	if a := configSpec.MemoryAllocation; a != nil {
+		r.Memory = ptr.Deref(a.Reservation)
+	}
+	return r
+}
+
+// filterCandidatesByReservation removes the candidate ResourcePools that do
+// not have enough unreserved capacity for the VM's reservations once the
+// reservations of the VMs already placed on them are subtracted.
+func filterCandidatesByReservation(
+	ctx context.Context,
+	vcClient *vim25.Client,
+	candidates map[string][]string,
+	configSpec vimtypes.VirtualMachineConfigSpec,
+	reserved map[string]Reservation) (map[string][]string, error) {
+
+	need := ConfigSpecReservation(configSpec)
+	if need.IsZero() || len(reserved) == 0 {
+		return candidates, nil
+	}
+
+	var rpMoRefs []vimtypes.ManagedObjectReference
+	for _, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			if _, ok := reserved[rpMoID]; ok {
+				rpMoRefs = append(rpMoRefs, vimtypes.ManagedObjectReference{
+					Type:  string(vimtypes.ManagedObjectTypeResourcePool),
+					Value: rpMoID,
+				})
+			}
+		}
+	}
+
+	if len(rpMoRefs) == 0 {
+		// Nothing has been placed on any of the candidates yet.
+		return candidates, nil
+	}
+
+	var moRPs []mo.ResourcePool
+	if err := property.DefaultCollector(vcClient).Retrieve(
+		ctx,
+		rpMoRefs,
+		[]string{"runtime.cpu", "runtime.memory"},
+		&moRPs); err != nil {
+
+		return nil, fmt.Errorf("failed to get ResourcePool runtime: %w", err)
+	}
+
+	full := map[string]struct{}{}
+	for i := range moRPs {
+		rt := moRPs[i].Runtime
+		r := reserved[moRPs[i].Self.Value]
+		// The unreserved memory is in bytes, while reservations are in MB.
+		if rt.Cpu.UnreservedForVm-r.CPU < need.CPU ||
+			rt.Memory.UnreservedForVm/(1024*1024)-r.Memory < need.Memory {
+
+			full[moRPs[i].Self.Value] = struct{}{}
+		}
+	}
+
+	allowedCandidates := map[string][]string{}
+	for zoneName, rpMoIDs := range candidates {
+		var allowedRPMoIDs []string
+		for _, rpMoID := range rpMoIDs {
+			if _, ok := full[rpMoID]; !ok {
+				allowedRPMoIDs = append(allowedRPMoIDs, rpMoID)
+			}
+		}
+		if len(allowedRPMoIDs) > 0 {
+			allowedCandidates[zoneName] = allowedRPMoIDs
+		}
+	}
+
+	if len(allowedCandidates) == 0 {
+		return nil, ErrInsufficientReservation
+	}
+
+	return allowedCandidates, nil
+}
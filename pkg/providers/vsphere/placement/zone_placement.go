@@ -40,6 +40,12 @@ type Constraints struct {
 	// will further be filtered by.
 	Zones sets.Set[string]
 
+	// Reserved when non-empty is the CPU and memory reserved by VMs that were
+	// placed but not yet created, keyed by the MoID of their ResourcePools.
+	// A candidate ResourcePool is removed if it does not have enough
+	// unreserved capacity left for the VM's reservations.
+	Reserved map[string]Reservation
+
 	// TODO: ClusterModules?
 }
 
@@ -99,6 +105,11 @@ func doesVMNeedPlacement(vmCtx pkgctx.VirtualMachineContext) (res Result) {
 		}
 	}
 
+	if res.HostMoRef == nil && hasHostAffinity(vmCtx.VM) {
+		// VM has host affinity so we need to select a host.
+		res.needHostPlacement = true
+	}
+
 	if pkgcfg.FromContext(vmCtx).Features.FastDeploy {
 		res.needDatastorePlacement = true
 	}
@@ -164,8 +175,8 @@ func getPlacementCandidates(
 		}
 
 		for _, zone := range zones {
-			// Filter out the zone that is to be deleted, so we don't have it as a candidate when doing placement.
-			if preAssignedZoneName == "" && !zone.DeletionTimestamp.IsZero() {
+			// Filter out the zone that is to be deleted or is cordoned, so we don't have it as a candidate when doing placement.
+			if preAssignedZoneName == "" && topology.IsZoneDraining(zone) {
 				continue
 			}
 			rpMoIDs := zone.Spec.ManagedVMs.PoolMoIDs
@@ -449,22 +460,87 @@ func Placement(
 		candidates = allowedCandidates
 	}
 
+	candidates, err = filterCandidatesByReservation(
+		vmCtx,
+		vcClient,
+		candidates,
+		configSpec,
+		constraints.Reserved)
+	if err != nil {
+		return nil, err
+	}
+
+	var zoneAff *zoneAffinity
+	if curResult.needZonePlacement && hasZoneAffinity(vmCtx.VM) {
+		// Remove the zones that do not satisfy the VM's required zone
+		// affinity.
+		zoneAff, err = newZoneAffinity(vmCtx, client, vmCtx.VM)
+		if err != nil {
+			return nil, err
+		}
+		candidates, err = zoneAff.filterCandidates(candidates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var hostAff *hostAffinity
+	if curResult.needHostPlacement && hasHostAffinity(vmCtx.VM) {
+		// Remove the ResourcePools whose clusters do not have any hosts that
+		// satisfy the VM's required host affinity.
+		hostAff, err = newHostAffinity(vmCtx, vcClient, vmCtx.VM, candidates)
+		if err != nil {
+			return nil, err
+		}
+		candidates, err = hostAff.filterCandidates(candidates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The preferred terms are applied last so they do not rule out the zones
+	// that satisfy the VM's other requirements. The most preferred zones and
+	// ResourcePools are tried first, and then the less preferred ones if the
+	// VM cannot be placed on them.
+	rankedCandidates := []map[string][]string{candidates}
+	if zoneAff != nil {
+		rankedCandidates = zoneAff.rankCandidates(candidates)
+	}
+	if hostAff != nil {
+		var hostRankedCandidates []map[string][]string
+		for _, c := range rankedCandidates {
+			hostRankedCandidates = append(hostRankedCandidates, hostAff.rankCandidates(c)...)
+		}
+		rankedCandidates = hostRankedCandidates
+	}
+
 	var recommendation Recommendation
-	if curResult.needZonePlacement {
-		recommendation, err = getZonalPlacementRecommendations(
-			vmCtx,
-			vcClient,
-			finder,
-			candidates,
-			configSpec,
-			curResult.needHostPlacement,
-			curResult.needDatastorePlacement)
-	} else {
-		recommendation, err = getPlacementRecommendations(
-			vmCtx,
-			vcClient,
-			candidates,
-			configSpec)
+	for i, rankCandidates := range rankedCandidates {
+		if curResult.needZonePlacement {
+			recommendation, err = getZonalPlacementRecommendations(
+				vmCtx,
+				vcClient,
+				finder,
+				rankCandidates,
+				configSpec,
+				curResult.needHostPlacement,
+				curResult.needDatastorePlacement)
+		} else {
+			recommendation, err = getPlacementRecommendations(
+				vmCtx,
+				vcClient,
+				rankCandidates,
+				configSpec)
+		}
+
+		if err == nil {
+			break
+		}
+
+		if i < len(rankedCandidates)-1 {
+			vmCtx.Logger.V(4).Info("Failed to place VM in the preferred zones, trying the less preferred zones",
+				"preferredZones", maps.Keys(rankCandidates), "err", err.Error())
+		}
 	}
 
 	if err != nil {
@@ -478,6 +554,19 @@ func Placement(
 			recommendation.PoolMoRef.Value)
 	}
 
+	if curResult.needHostPlacement && hostAff != nil {
+		// The host of a VM with instance storage is selected for the
+		// capacity of its local disks, so it may not be changed here.
+		hostMoRef, err := hostAff.selectHost(
+			hostAff.eligibleHosts(recommendation.PoolMoRef),
+			recommendation.HostMoRef,
+			curResult.InstanceStoragePlacement)
+		if err != nil {
+			return nil, err
+		}
+		recommendation.HostMoRef = hostMoRef
+	}
+
 	if pkgcfg.FromContext(vmCtx).Features.FastDeploy {
 		// Get the name and type of the datastores.
 		if err := getDatastoreProperties(vmCtx, vcClient, &recommendation); err != nil {
@@ -487,7 +576,7 @@ func Placement(
 
 	result := Result{
 		ZonePlacement:            curResult.needZonePlacement,
-		InstanceStoragePlacement: curResult.needHostPlacement,
+		InstanceStoragePlacement: curResult.InstanceStoragePlacement && curResult.needHostPlacement,
 		ZoneName:                 zoneName,
 		PoolMoRef:                recommendation.PoolMoRef,
 		HostMoRef:                recommendation.HostMoRef,
@@ -6,11 +6,15 @@ package placement_test
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -320,6 +324,240 @@ func vcSimPlacement() {
 				})
 			})
 		})
+		Context("Zone affinity", func() {
+			var lastZoneName string
+
+			JustBeforeEach(func() {
+				Expect(len(ctx.ZoneNames)).To(BeNumerically(">", 1))
+				lastZoneName = ctx.ZoneNames[len(ctx.ZoneNames)-1]
+
+				zone := &topologyv1.Zone{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: lastZoneName}, zone)).To(Succeed())
+				zone.Labels = map[string]string{"gpu": "true"}
+				Expect(ctx.Client.Update(ctx, zone)).To(Succeed())
+			})
+
+			When("there are required terms", func() {
+				BeforeEach(func() {
+					vm.Spec.Affinity = &vmopv1.AffinitySpec{
+						ZoneAffinity: &vmopv1.ZoneAffinitySpec{
+							RequiredDuringSchedulingIgnoredDuringExecution: []vmopv1.ZoneSelectorTerm{
+								{
+									LabelSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"gpu": "true"},
+									},
+								},
+							},
+						},
+					}
+				})
+
+				It("returns the zone that matches", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(result.ZonePlacement).To(BeTrue())
+					Expect(result.ZoneName).To(Equal(lastZoneName))
+				})
+
+				When("no zone matches", func() {
+					BeforeEach(func() {
+						vm.Spec.Affinity.ZoneAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels["gpu"] = "false"
+					})
+
+					It("returns an error", func() {
+						_, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).To(MatchError(placement.ErrNoZoneAffinityMatch))
+						Expect(errors.Is(err, placement.ErrNoPlacementCandidates)).To(BeTrue())
+					})
+				})
+			})
+
+			When("there are preferred terms", func() {
+				BeforeEach(func() {
+					vm.Spec.Affinity = &vmopv1.AffinitySpec{
+						ZoneAffinity: &vmopv1.ZoneAffinitySpec{
+							PreferredDuringSchedulingIgnoredDuringExecution: []vmopv1.ZoneSelectorTerm{
+								{
+									LabelSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"gpu": "true"},
+									},
+								},
+							},
+						},
+					}
+				})
+
+				It("returns the zone that matches", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(result.ZoneName).To(Equal(lastZoneName))
+				})
+
+				When("no zone matches", func() {
+					BeforeEach(func() {
+						vm.Spec.Affinity.ZoneAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels["gpu"] = "false"
+					})
+
+					It("returns any zone", func() {
+						result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(result.ZonePlacement).To(BeTrue())
+						Expect(result.ZoneName).ToNot(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Context("Host affinity", func() {
+			const (
+				categoryName = "licensing"
+				tagName      = "oracle"
+			)
+
+			var (
+				tagMgr    *tags.Manager
+				tagID     string
+				hostNames []string
+				hosts     map[string]*object.HostSystem
+			)
+
+			BeforeEach(func() {
+				testConfig.NumFaultDomains = 1
+
+				vm.Spec.Affinity = &vmopv1.AffinitySpec{
+					HostAffinity: &vmopv1.HostAffinitySpec{
+						RequiredDuringSchedulingIgnoredDuringExecution: []vmopv1.HostSelectorTerm{
+							{
+								Category: categoryName,
+								Operator: vmopv1.HostSelectorOpIn,
+								Tags:     []string{tagName},
+							},
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				vmCtx.Context = pkgctx.WithRestClient(vmCtx.Context, ctx.RestClient)
+				tagMgr = tags.NewManager(ctx.RestClient)
+
+				categoryID, err := tagMgr.CreateCategory(ctx, &tags.Category{
+					Name:        categoryName,
+					Cardinality: "MULTIPLE",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				tagID, err = tagMgr.CreateTag(ctx, &tags.Tag{
+					Name:       tagName,
+					CategoryID: categoryID,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				hostList, err := ctx.Finder.HostSystemList(ctx, "*")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(hostList)).To(BeNumerically(">", 1))
+
+				hostNames = nil
+				hosts = map[string]*object.HostSystem{}
+				for _, h := range hostList {
+					hostNames = append(hostNames, h.Name())
+					hosts[h.Name()] = h
+				}
+				slices.Sort(hostNames)
+			})
+
+			When("the tag is attached to a host", func() {
+				JustBeforeEach(func() {
+					Expect(tagMgr.AttachTag(ctx, tagID, hosts[hostNames[len(hostNames)-1]].Reference())).To(Succeed())
+				})
+
+				It("returns the host with the tag", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(result.InstanceStoragePlacement).To(BeFalse())
+					Expect(result.HostMoRef).ToNot(BeNil())
+					Expect(*result.HostMoRef).To(Equal(hosts[hostNames[len(hostNames)-1]].Reference()))
+				})
+
+				When("the operator is NotIn", func() {
+					BeforeEach(func() {
+						vm.Spec.Affinity.HostAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Operator = vmopv1.HostSelectorOpNotIn
+					})
+
+					It("returns a host without the tag", func() {
+						result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(result.HostMoRef).ToNot(BeNil())
+						Expect(*result.HostMoRef).ToNot(Equal(hosts[hostNames[len(hostNames)-1]].Reference()))
+					})
+				})
+
+				When("the term is preferred", func() {
+					BeforeEach(func() {
+						ha := vm.Spec.Affinity.HostAffinity
+						ha.PreferredDuringSchedulingIgnoredDuringExecution = ha.RequiredDuringSchedulingIgnoredDuringExecution
+						ha.RequiredDuringSchedulingIgnoredDuringExecution = nil
+					})
+
+					It("returns the host with the tag", func() {
+						result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(result.HostMoRef).ToNot(BeNil())
+						Expect(*result.HostMoRef).To(Equal(hosts[hostNames[len(hostNames)-1]].Reference()))
+					})
+
+					When("no host matches", func() {
+						BeforeEach(func() {
+							vm.Spec.Affinity.HostAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Tags = []string{"other"}
+						})
+
+						It("returns any host", func() {
+							result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+							Expect(err).ToNot(HaveOccurred())
+
+							Expect(result.HostMoRef).ToNot(BeNil())
+						})
+					})
+				})
+			})
+
+			When("the tag is attached to the cluster", func() {
+				JustBeforeEach(func() {
+					Expect(tagMgr.AttachTag(ctx, tagID, ctx.GetFirstClusterFromFirstZone().Reference())).To(Succeed())
+				})
+
+				It("returns a host in the cluster", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(result.HostMoRef).ToNot(BeNil())
+				})
+
+				When("the operator is NotIn", func() {
+					BeforeEach(func() {
+						vm.Spec.Affinity.HostAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Operator = vmopv1.HostSelectorOpNotIn
+					})
+
+					It("returns an error", func() {
+						_, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).To(MatchError(placement.ErrNoHostAffinityMatch))
+					})
+				})
+			})
+
+			When("the tag is not attached to any host", func() {
+				It("returns an error", func() {
+					_, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).To(MatchError(placement.ErrNoHostAffinityMatch))
+					Expect(errors.Is(err, placement.ErrNoPlacementCandidates)).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Describe("When WorkloadDomainIsolation capability disabled", func() {
@@ -16,6 +16,7 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/trace"
 	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +35,7 @@ import (
 	res "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/resources"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/paused"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/resize"
@@ -45,6 +47,7 @@ import (
 	vmconfcdrom "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/cdrom"
 	vmconfcrypto "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/crypto"
 	vmconfdiskpromo "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/diskpromo"
+	vmconfdrift "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/drift"
 	vmconfpolicy "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/policy"
 	vmconfvirtualcontroller "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/virtualcontroller"
 	vmconfunmanagedvolsreg "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/volumes/unmanaged/register"
@@ -81,7 +84,11 @@ func (s *Session) UpdateVirtualMachine(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcVM *object.VirtualMachine,
 	getUpdateArgsFn func() (*VMUpdateArgs, error),
-	getResizeArgsFn func() (*VMResizeArgs, error)) error {
+	getResizeArgsFn func() (*VMResizeArgs, error)) (retErr error) {
+
+	var span trace.Span
+	vmCtx.Context, span = pkgtracing.Start(vmCtx.Context, "session.UpdateVirtualMachine")
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	var (
 		updateErr  error
@@ -1312,6 +1319,25 @@ func reconcileCdrom(
 		configSpec)
 }
 
+func reconcileDrift(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vm *vmopv1.VirtualMachine,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec) error {
+
+	pkglog.FromContextOrDefault(ctx).V(4).Info("Reconciling drift")
+
+	return vmconfdrift.Reconcile(
+		ctx,
+		k8sClient,
+		vcVM.Client(),
+		vm,
+		moVM,
+		configSpec)
+}
+
 func doReconfigure(
 	ctx context.Context,
 	k8sClient ctrlclient.Client,
@@ -1418,6 +1444,19 @@ func doReconfigure(
 		}
 	}
 
+	// Drift is reconciled last so it observes the changes from all of the
+	// other reconcilers.
+	if err := reconcileDrift(
+		ctx,
+		k8sClient,
+		vm,
+		vcVM,
+		moVM,
+		&configSpec); err != nil {
+
+		return err
+	}
+
 	var defaultConfigSpec vimtypes.VirtualMachineConfigSpec
 	if apiEquality.Semantic.DeepEqual(configSpec, defaultConfigSpec) {
 		return nil
@@ -0,0 +1,107 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// isExportedFile returns true if the export lease item should be included in
+// the exported OVF. Only the VM's disks and NVRAM are exported, not the
+// images of any attached CD-ROMs.
+func isExportedFile(item nfc.FileItem) bool {
+	switch strings.ToLower(path.Ext(item.Path)) {
+	case ".vmdk", ".nvram":
+		return true
+	default:
+		return false
+	}
+}
+
+// ExportOVF exports the VM with an export lease into the directory dir, and
+// writes an OVF descriptor named name + ".ovf" that references the exported
+// files. The paths of the written files are returned with the OVF descriptor
+// first.
+func ExportOVF(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	name, dir string) (_ []string, retErr error) {
+
+	lease, err := vcVM.Export(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export lease: %w", err)
+	}
+
+	info, err := lease.Wait(vmCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for export lease: %w", err)
+	}
+
+	defer func() {
+		if retErr != nil {
+			if err := lease.Abort(vmCtx, nil); err != nil {
+				vmCtx.Logger.Error(err, "Failed to abort export lease")
+			}
+		}
+	}()
+
+	updater := lease.StartUpdater(vmCtx, info)
+	defer updater.Done()
+
+	var (
+		ovfPath = filepath.Join(dir, name+".ovf")
+		paths   = []string{ovfPath}
+		cdp     = vimtypes.OvfCreateDescriptorParams{Name: name}
+	)
+
+	for _, item := range info.Items {
+		if !isExportedFile(item) {
+			continue
+		}
+
+		// Prefix the files with the name so they are unique once imported.
+		item.Path = name + "-" + path.Base(item.Path)
+		p := filepath.Join(dir, item.Path)
+
+		vmCtx.Logger.V(4).Info("Downloading exported file", "path", item.Path)
+		if err := lease.DownloadFile(vmCtx, p, item, soap.DefaultDownload); err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", item.Path, err)
+		}
+
+		cdp.OvfFiles = append(cdp.OvfFiles, item.File())
+		paths = append(paths, p)
+	}
+
+	desc, err := ovf.NewManager(vcVM.Client()).CreateDescriptor(vmCtx, vcVM, cdp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OVF descriptor: %w", err)
+	}
+	if len(desc.Error) > 0 {
+		return nil, fmt.Errorf("failed to create OVF descriptor: %s",
+			desc.Error[0].LocalizedMessage)
+	}
+
+	if err := os.WriteFile(ovfPath, []byte(desc.OvfDescriptor), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write OVF descriptor: %w", err)
+	}
+
+	if err := lease.Complete(vmCtx); err != nil {
+		return nil, fmt.Errorf("failed to complete export lease: %w", err)
+	}
+
+	return paths, nil
+}
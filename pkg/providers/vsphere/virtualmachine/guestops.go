@@ -0,0 +1,346 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/guest/toolbox"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+)
+
+const (
+	// guestProcessPollInterval is how often the state of a program that is
+	// run in the guest is checked.
+	guestProcessPollInterval = time.Second
+
+	// guestProcessTerminateTimeout is how long to wait for a program that
+	// timed out to be terminated.
+	guestProcessTerminateTimeout = 30 * time.Second
+
+	windowsShell = `C:\Windows\System32\cmd.exe`
+	posixShell   = "/bin/sh"
+)
+
+func newGuestClient(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	creds providers.GuestCredentials) (*toolbox.Client, error) {
+
+	auth := &vimtypes.NamePasswordAuthentication{
+		Username: creds.Username,
+		Password: creds.Password,
+	}
+
+	c, err := toolbox.NewClient(vmCtx, vcVM.Client(), vcVM, auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guest operations client: %w", err)
+	}
+	return c, nil
+}
+
+func isWindowsGuest(c *toolbox.Client) bool {
+	return c.GuestFamily == vimtypes.VirtualMachineGuestOsFamilyWindowsGuest
+}
+
+func guestFileAttributes(c *toolbox.Client) vimtypes.BaseGuestFileAttributes {
+	if isWindowsGuest(c) {
+		return &vimtypes.GuestWindowsFileAttributes{}
+	}
+	return &vimtypes.GuestPosixFileAttributes{}
+}
+
+// CopyFileToGuest writes the data to the file at guestPath in the VM's guest.
+func CopyFileToGuest(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	data []byte,
+	overwrite bool) error {
+
+	c, err := newGuestClient(vmCtx, vcVM, creds)
+	if err != nil {
+		return err
+	}
+
+	vmCtx.Logger.V(4).Info("Copying file to guest", "guestPath", guestPath, "size", len(data))
+
+	p := soap.DefaultUpload
+	p.ContentLength = int64(len(data))
+
+	if err := c.Upload(
+		vmCtx,
+		bytes.NewReader(data),
+		guestPath,
+		p,
+		guestFileAttributes(c),
+		overwrite); err != nil {
+
+		return fmt.Errorf("failed to copy file to guest path %q: %w", guestPath, err)
+	}
+
+	return nil
+}
+
+// CopyFileFromGuest returns the contents of the file at guestPath in the VM's
+// guest. providers.ErrGuestFileTooLarge is returned if the file is larger than
+// maxSize bytes.
+func CopyFileFromGuest(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	maxSize int64) ([]byte, error) {
+
+	c, err := newGuestClient(vmCtx, vcVM, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	vmCtx.Logger.V(4).Info("Copying file from guest", "guestPath", guestPath)
+
+	data, _, err := downloadGuestFile(vmCtx, c, guestPath, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: %q is larger than %d bytes",
+			providers.ErrGuestFileTooLarge, guestPath, maxSize)
+	}
+
+	return data, nil
+}
+
+// downloadGuestFile returns at most maxSize+1 bytes of the file at guestPath,
+// so callers may tell if the file is larger than maxSize. The file's size is
+// also returned.
+func downloadGuestFile(
+	ctx context.Context,
+	c *toolbox.Client,
+	guestPath string,
+	maxSize int64) ([]byte, int64, error) {
+
+	r, size, err := c.Download(ctx, guestPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to copy file from guest path %q: %w", guestPath, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file from guest path %q: %w", guestPath, err)
+	}
+
+	return data, size, nil
+}
+
+// RunProgramInGuest runs the program in the VM's guest and waits for it to
+// exit. The program is run with the guest's shell so that its standard output
+// and standard error may be redirected to temporary files, which are copied
+// out of the guest once the program exits. If ctx is done before the program
+// exits, the program is terminated and ctx's error is returned.
+func RunProgramInGuest(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	creds providers.GuestCredentials,
+	spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+	var result providers.GuestProgramResult
+
+	c, err := newGuestClient(vmCtx, vcVM, creds)
+	if err != nil {
+		return result, err
+	}
+
+	// The temporary files are removed even if ctx is done.
+	cleanupCtx := context.WithoutCancel(vmCtx)
+
+	var outPaths [2]string
+	for i := range outPaths {
+		p, err := c.FileManager.CreateTemporaryFile(vmCtx, c.Authentication, "vmop-", ".out", "")
+		if err != nil {
+			return result, fmt.Errorf("failed to create temporary file in guest: %w", err)
+		}
+		defer func() {
+			if err := c.FileManager.DeleteFile(cleanupCtx, c.Authentication, p); err != nil {
+				vmCtx.Logger.Error(err, "Failed to delete temporary file in guest", "guestPath", p)
+			}
+		}()
+		outPaths[i] = p
+	}
+
+	programSpec := &vimtypes.GuestProgramSpec{
+		EnvVariables:     spec.Env,
+		WorkingDirectory: spec.WorkingDirectory,
+	}
+	if isWindowsGuest(c) {
+		programSpec.ProgramPath = windowsShell
+		programSpec.Arguments = windowsShellArguments(spec.Path, spec.Args, outPaths[0], outPaths[1])
+	} else {
+		programSpec.ProgramPath = posixShell
+		programSpec.Arguments = posixShellArguments(spec.Path, spec.Args, outPaths[0], outPaths[1])
+	}
+
+	pid, err := c.ProcessManager.StartProgram(vmCtx, c.Authentication, programSpec)
+	if err != nil {
+		return result, fmt.Errorf("failed to start program %q in guest: %w", spec.Path, err)
+	}
+
+	vmCtx.Logger.V(4).Info("Started program in guest", "path", spec.Path, "pid", pid)
+
+	exitCode, err := waitForGuestProcess(vmCtx, c, pid)
+	if err != nil {
+		if vmCtx.Err() != nil {
+			terminateCtx, cancel := context.WithTimeout(cleanupCtx, guestProcessTerminateTimeout)
+			defer cancel()
+			if err := c.ProcessManager.TerminateProcess(terminateCtx, c.Authentication, pid); err != nil {
+				vmCtx.Logger.Error(err, "Failed to terminate program in guest", "pid", pid)
+			}
+		}
+		return result, err
+	}
+
+	result.ExitCode = exitCode
+
+	for i, out := range []*[]byte{&result.Stdout, &result.Stderr} {
+		data, _, err := downloadGuestFile(vmCtx, c, outPaths[i], spec.MaxOutputBytes)
+		if err != nil {
+			return result, err
+		}
+		if int64(len(data)) > spec.MaxOutputBytes {
+			data = data[:spec.MaxOutputBytes]
+			result.OutputTruncated = true
+		}
+		*out = data
+	}
+
+	return result, nil
+}
+
+// waitForGuestProcess waits for the guest process to exit and returns its
+// exit code.
+func waitForGuestProcess(
+	ctx context.Context,
+	c *toolbox.Client,
+	pid int64) (int32, error) {
+
+	ticker := time.NewTicker(guestProcessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		procs, err := c.ProcessManager.ListProcesses(ctx, c.Authentication, []int64{pid})
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			return 0, fmt.Errorf("failed to get state of guest process %d: %w", pid, err)
+		}
+		if len(procs) == 0 {
+			return 0, fmt.Errorf("guest process %d not found", pid)
+		}
+		if procs[0].EndTime != nil {
+			return procs[0].ExitCode, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// posixShellArguments returns the arguments to /bin/sh that run the program
+// with its output redirected to the files.
+func posixShellArguments(path string, args []string, stdout, stderr string) string {
+	quoted := make([]string, 0, len(args)+1)
+	for _, a := range append([]string{path}, args...) {
+		quoted = append(quoted, posixQuote(a))
+	}
+	cmd := fmt.Sprintf("%s >%s 2>%s",
+		strings.Join(quoted, " "), posixQuote(stdout), posixQuote(stderr))
+	return "-c " + posixQuote(cmd)
+}
+
+// posixQuote quotes s as a single word for a POSIX shell.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// windowsShellArguments returns the arguments to cmd.exe that run the program
+// with its output redirected to the files.
+func windowsShellArguments(path string, args []string, stdout, stderr string) string {
+	escaped := make([]string, 0, len(args)+1)
+	for _, a := range append([]string{path}, args...) {
+		escaped = append(escaped, cmdEscape(windowsQuote(a)))
+	}
+	// With /s, cmd.exe removes the outer quotes and parses the rest of the
+	// line as is, which is why the metacharacters in the quoted arguments,
+	// including their quotes, are escaped.
+	return fmt.Sprintf(`/s /c "%s 1>%s 2>%s"`,
+		strings.Join(escaped, " "),
+		cmdEscape(windowsQuote(stdout)),
+		cmdEscape(windowsQuote(stderr)))
+}
+
+// windowsQuote quotes s as a single argument for a Windows program that
+// parses its command line with CommandLineToArgvW, like Go's
+// syscall.EscapeArg.
+func windowsQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(s, " \t\"") {
+		// Backslashes are only special before a quote.
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			slashes++
+		case '"':
+			// The backslashes before a quote and the quote itself are
+			// escaped.
+			sb.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		sb.WriteByte(s[i])
+	}
+	// The backslashes before the closing quote are escaped.
+	sb.WriteString(strings.Repeat(`\`, slashes))
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// cmdEscape escapes the metacharacters of cmd.exe in s with a caret so
+// cmd.exe passes them to the program as is. A percent sign is escaped so it
+// does not expand an environment variable.
+func cmdEscape(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(`()%!^"<>&|`, c) {
+			sb.WriteByte('^')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
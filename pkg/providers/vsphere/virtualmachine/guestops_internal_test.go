@@ -0,0 +1,137 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// commandLineToArgv splits the command line into arguments with the rules of
+// CommandLineToArgvW for the arguments after the program name.
+func commandLineToArgv(cmdLine string) []string {
+	var (
+		args    []string
+		arg     strings.Builder
+		inArg   bool
+		inQuote bool
+	)
+	for i := 0; i < len(cmdLine); i++ {
+		c := cmdLine[i]
+		switch {
+		case c == '\\':
+			slashes := 0
+			for i < len(cmdLine) && cmdLine[i] == '\\' {
+				slashes++
+				i++
+			}
+			if i < len(cmdLine) && cmdLine[i] == '"' {
+				arg.WriteString(strings.Repeat(`\`, slashes/2))
+				if slashes%2 == 1 {
+					arg.WriteByte('"')
+				} else {
+					inQuote = !inQuote
+				}
+			} else {
+				arg.WriteString(strings.Repeat(`\`, slashes))
+				i--
+			}
+			inArg = true
+		case c == '"':
+			inQuote = !inQuote
+			inArg = true
+		case (c == ' ' || c == '\t') && !inQuote:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// cmdUnescape removes the carets cmd.exe removes from a line with no quotes
+// that are not escaped.
+func cmdUnescape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '^' && i+1 < len(s) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+var _ = Describe("windowsQuote", func() {
+	DescribeTable("quotes the argument",
+		func(arg, expected string) {
+			quoted := windowsQuote(arg)
+			Expect(quoted).To(Equal(expected))
+			Expect(commandLineToArgv(quoted)).To(Equal([]string{arg}))
+		},
+		Entry("empty", ``, `""`),
+		Entry("plain", `hello`, `hello`),
+		Entry("path", `C:\Windows\System32`, `C:\Windows\System32`),
+		Entry("space", `hello world`, `"hello world"`),
+		Entry("tab", "hello\tworld", "\"hello\tworld\""),
+		Entry("quote", `say "hi"`, `"say \"hi\""`),
+		Entry("quote without space", `a"b`, `"a\"b"`),
+		Entry("backslash before quote", `a\"b`, `"a\\\"b"`),
+		Entry("trailing backslash", `C:\Program Files\`, `"C:\Program Files\\"`),
+		Entry("trailing backslashes", `C:\my dir\\`, `"C:\my dir\\\\"`),
+		Entry("backslashes not before quote", `C:\my dir\file`, `"C:\my dir\file"`),
+	)
+})
+
+var _ = Describe("cmdEscape", func() {
+	It("escapes the metacharacters of cmd.exe", func() {
+		Expect(cmdEscape(`"a b" & echo %PATH% | (x) < y > z ^ !`)).To(Equal(
+			`^"a b^" ^& echo ^%PATH^% ^| ^(x^) ^< y ^> z ^^ ^!`))
+	})
+})
+
+var _ = Describe("windowsShellArguments", func() {
+	It("returns the arguments to cmd.exe", func() {
+		Expect(windowsShellArguments(
+			`C:\Program Files\app.exe`,
+			[]string{"a b", `50%`, `x&y`, `"q"`},
+			`C:\Temp\out.txt`,
+			`C:\Temp\err.txt`)).To(Equal(
+			`/s /c "^"C:\Program Files\app.exe^" ^"a b^" 50^% x^&y ^"\^"q\^"^" 1>C:\Temp\out.txt 2>C:\Temp\err.txt"`))
+	})
+
+	It("passes the arguments to the program as is", func() {
+		args := []string{
+			"a b",
+			"%PATH%",
+			"x & del *",
+			`say "hi"`,
+			`C:\my dir\`,
+			"^(|)<>!",
+			"",
+		}
+		cmdLine := windowsShellArguments(`C:\app.exe`, args, `C:\out.txt`, `C:\err.txt`)
+
+		// cmd.exe removes the outer quotes and the carets.
+		cmdLine = strings.TrimPrefix(cmdLine, `/s /c "`)
+		cmdLine = strings.TrimSuffix(cmdLine, `"`)
+		cmdLine, redirects, ok := strings.Cut(cmdLine, " 1>")
+		Expect(ok).To(BeTrue())
+		Expect(redirects).To(Equal(`C:\out.txt 2>C:\err.txt`))
+
+		Expect(commandLineToArgv(cmdUnescape(cmdLine))).To(Equal(
+			append([]string{`C:\app.exe`}, args...)))
+	})
+})
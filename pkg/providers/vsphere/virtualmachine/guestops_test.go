@@ -0,0 +1,91 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/test/testutil"
+)
+
+func guestOpsTests() {
+	var (
+		ctx   *builder.TestContextForVCSim
+		vcVM  *object.VirtualMachine
+		vmCtx pkgctx.VirtualMachineContext
+		creds providers.GuestCredentials
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := testutil.GinkgoLogr(5)
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: logr.NewContext(ctx, logger),
+			Logger:  logger.WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+
+		creds = providers.GuestCredentials{
+			Username: "root",
+			Password: "password",
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		vcVM = nil
+	})
+
+	// The simulator only supports guest operations for VMs that are backed
+	// by containers.
+	When("guest operations are unavailable", func() {
+		It("CopyFileToGuest returns an error", func() {
+			err := virtualmachine.CopyFileToGuest(vmCtx, vcVM, creds, "/tmp/file", []byte("hello"), true)
+			Expect(err).To(MatchError(ContainSubstring(`failed to copy file to guest path "/tmp/file"`)))
+		})
+
+		It("CopyFileFromGuest returns an error", func() {
+			_, err := virtualmachine.CopyFileFromGuest(vmCtx, vcVM, creds, "/tmp/file", 1024)
+			Expect(err).To(MatchError(ContainSubstring(`failed to copy file from guest path "/tmp/file"`)))
+		})
+
+		It("RunProgramInGuest returns an error", func() {
+			_, err := virtualmachine.RunProgramInGuest(vmCtx, vcVM, creds, providers.GuestProgramSpec{
+				Path:           "/bin/true",
+				MaxOutputBytes: 1024,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the context is canceled", func() {
+		It("RunProgramInGuest returns an error", func() {
+			cancelCtx, cancel := context.WithCancel(vmCtx.Context)
+			cancel()
+			vmCtx.Context = cancelCtx
+
+			_, err := virtualmachine.RunProgramInGuest(vmCtx, vcVM, creds, providers.GuestProgramSpec{
+				Path: "/bin/true",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+}
@@ -23,6 +23,7 @@ func vcSimTests() {
 	Describe("Snapshot", Label(testlabels.VCSim), snapShotTests)
 	Describe("ExtraConfig", Label(testlabels.VCSim), extraConfigTests)
 	Describe("CleanupOnDelete", Label(testlabels.VCSim), cleanupOnDeleteTests)
+	Describe("GuestOps", Label(testlabels.VCSim), guestOpsTests)
 }
 
 var suite = builder.NewTestSuite()
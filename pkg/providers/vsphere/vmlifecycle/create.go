@@ -11,9 +11,11 @@ import (
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vim25"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/trace"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 )
 
 // CreateArgs contains the arguments needed to create a VM.
@@ -55,7 +57,11 @@ func CreateVirtualMachine(
 	restClient *rest.Client,
 	vimClient *vim25.Client,
 	finder *find.Finder,
-	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
+	createArgs *CreateArgs) (_ *vimtypes.ManagedObjectReference, retErr error) {
+
+	var span trace.Span
+	vmCtx.Context, span = pkgtracing.Start(vmCtx.Context, "vmlifecycle.CreateVirtualMachine")
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	if strings.HasPrefix(createArgs.ProviderItemID, "vm-") {
 		// This is a VM-backed image, and it can only be provisioned via fast
@@ -441,9 +441,92 @@ func reconcileStatusGuest(
 		}
 	}
 
+	updateGuestProperties(vmCtx.VM, extraConfig)
+
 	return nil
 }
 
+const (
+	// maxGuestProperties is the maximum number of guest properties that are
+	// reported in the VM's status.
+	maxGuestProperties = 64
+
+	// maxGuestPropertiesSize is the maximum total size of the keys and values
+	// of the guest properties that are reported in the VM's status.
+	maxGuestPropertiesSize = 16 * 1024
+
+	// maxGuestPropertyKeyLength is the maximum length of a guest property's
+	// key, without the guestinfo prefix.
+	maxGuestPropertyKeyLength = 128
+
+	// maxGuestPropertyValueSize is the maximum size of a guest property's
+	// value.
+	maxGuestPropertyValueSize = 1024
+)
+
+var guestPropertyKeyRx = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+
+// updateGuestProperties reports the application metadata that the guest
+// publishes in the guestinfo keys with the prefix
+// constants.ExtraConfigGuestInfoStatusPrefix. The properties are added in the
+// order of their keys until a limit is reached, so the same properties are
+// reported each time the VM is reconciled.
+func updateGuestProperties(vm *vmopv1.VirtualMachine, extraConfig map[string]string) {
+	var (
+		keys    []string
+		skipped []string
+	)
+	for k := range extraConfig {
+		if key, ok := strings.CutPrefix(k, constants.ExtraConfigGuestInfoStatusPrefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var (
+		props map[string]string
+		size  int
+	)
+	for _, key := range keys {
+		value := extraConfig[constants.ExtraConfigGuestInfoStatusPrefix+key]
+		if len(key) > maxGuestPropertyKeyLength ||
+			!guestPropertyKeyRx.MatchString(key) ||
+			len(value) > maxGuestPropertyValueSize ||
+			len(props) == maxGuestProperties ||
+			size+len(key)+len(value) > maxGuestPropertiesSize {
+
+			skipped = append(skipped, key)
+			continue
+		}
+		if props == nil {
+			props = make(map[string]string, len(keys))
+		}
+		props[key] = value
+		size += len(key) + len(value)
+	}
+
+	if len(skipped) > 0 {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.GuestPropertiesCondition,
+			vmopv1.GuestPropertiesLimitExceededReason,
+			"Guest properties not reported because they exceed the limits: %s",
+			strings.Join(skipped, ","))
+	} else {
+		conditions.Delete(vm, vmopv1.GuestPropertiesCondition)
+	}
+
+	switch {
+	case props != nil:
+		if vm.Status.Guest == nil {
+			vm.Status.Guest = &vmopv1.VirtualMachineGuestStatus{}
+		}
+		vm.Status.Guest.Properties = props
+	case vm.Status.Guest != nil:
+		vm.Status.Guest.Properties = nil
+	}
+}
+
 // reconcileStatusStorage updates the status for all storage-related fields.
 func reconcileStatusStorage(
 	vmCtx pkgctx.VirtualMachineContext,
@@ -905,85 +988,16 @@ func UpdateNetworkStatusConfig(vm *vmopv1.VirtualMachine, args BootstrapArgs) {
 
 	// Iterate over each network result.
 	for i := range args.NetworkResults.Results {
-
-		// Declare the interface's config status.
-		var ifc vmopv1.VirtualMachineNetworkConfigInterfaceStatus
-
-		// Define a short alias for the indexed result.
 		r := args.NetworkResults.Results[i]
 
-		// Grab a temp copy of the result's IP configuration list to make it
-		// more obvious that the purpose of the next three lines of code is not
-		// to update the r.IPConfigs directly.
-		ipConfigs := args.NetworkResults.Results[i].IPConfigs
-
-		// The intended DHCP configuration is presented per interface, so if
-		// there are no resulting IP configs, but DHCP4 or DHCP6 is configured,
-		// go ahead and create a single, fake IP config so the DHCP info can be
-		// collected the same way below.
-		if len(ipConfigs) == 0 && (r.DHCP4 || r.DHCP6) {
-			ipConfigs = []network.NetworkInterfaceIPConfig{{}}
-		}
-
-		// If there *are* resulting IP configs, then ensure the field ifc.IP
-		// is not nil so avoid an NPE later. We do not initialize this field
-		// unless there *are* resulting IP configs to avoid an empty object
-		// when printing the VM's status.
-		if len(ipConfigs) > 0 {
-			ifc.IP = &vmopv1.VirtualMachineNetworkConfigInterfaceIPStatus{}
-		}
-
-		// Iterate over each of the result's IP configurations.
-		for j := range ipConfigs {
-			ipc := ipConfigs[j]
-
-			// Assign the gateways.
-			if gw := ipc.Gateway; gw != "" {
-				if ipc.IsIPv4 && ifc.IP.Gateway4 == "" {
-					ifc.IP.Gateway4 = gw
-				} else if !ipc.IsIPv4 && ifc.IP.Gateway6 == "" {
-					ifc.IP.Gateway6 = gw
-				}
-			}
-
-			// Append the IP address.
-			if ip := ipc.IPCIDR; ip != "" {
-				ifc.IP.Addresses = append(ifc.IP.Addresses, ip)
-			}
-
-			// Update DHCP information.
-			if v4, v6 := r.DHCP4, r.DHCP6; v4 || v6 {
-				ifc.IP.DHCP = &vmopv1.VirtualMachineNetworkConfigDHCPStatus{}
-				if v4 {
-					ifc.IP.DHCP.IP4 = &vmopv1.VirtualMachineNetworkConfigDHCPOptionsStatus{
-						Enabled: v4,
-					}
-				}
-				if v6 {
-					ifc.IP.DHCP.IP6 = &vmopv1.VirtualMachineNetworkConfigDHCPOptionsStatus{
-						Enabled: v6,
-					}
-				}
-			}
-
-			// Update DNS information.
-			{
-				ns, sd := r.Nameservers, r.SearchDomains
-				if ln, ls := len(ns), len(sd); ln > 0 || ls > 0 {
-					ifc.DNS = &vmopv1.VirtualMachineNetworkConfigDNSStatus{}
-					if ln > 0 {
-						ifc.DNS.Nameservers = ns
-					}
-					if ls > 0 {
-						ifc.DNS.SearchDomains = sd
-					}
-				}
-			}
+		// The members of a bond or bridge are not configured with any IP
+		// settings.
+		if args.NetworkResults.IsGuestDeviceMember(r.Name) {
+			continue
 		}
 
-		if ip := ifc.IP; ip != nil && len(ip.Addresses) > 0 {
-			slices.Sort(ifc.IP.Addresses)
-		}
+		ifc := networkConfigInterfaceStatus(
+			r.IPConfigs, r.DHCP4, r.DHCP6, r.Nameservers, r.SearchDomains)
 
 		// Only append the interface config if it is not empty.
 		if !reflect.DeepEqual(ifc, emptyIfaceConfig) {
@@ -995,6 +1009,25 @@ func UpdateNetworkStatusConfig(vm *vmopv1.VirtualMachine, args BootstrapArgs) {
 		}
 	}
 
+	// Iterate over each of the bonds, VLANs, and bridges.
+	for i := range args.NetworkResults.Devices {
+		d := args.NetworkResults.Devices[i]
+
+		dc := vmopv1.VirtualMachineNetworkConfigDeviceStatus{
+			Name:       d.Name,
+			Type:       d.Type,
+			Interfaces: d.Interfaces,
+		}
+
+		if !args.NetworkResults.IsGuestDeviceMember(d.Name) {
+			ifc := networkConfigInterfaceStatus(
+				d.IPConfigs, d.DHCP4, d.DHCP6, d.Nameservers, d.SearchDomains)
+			dc.IP, dc.DNS = ifc.IP, ifc.DNS
+		}
+
+		nc.Devices = append(nc.Devices, dc)
+	}
+
 	// If the network config ended up empty, then ensure the VM's field
 	// status.network.config is nil IFF status.network is non-nil.
 	// Otherwise, assign the network config to the VM's status.network.config
@@ -1011,6 +1044,87 @@ func UpdateNetworkStatusConfig(vm *vmopv1.VirtualMachine, args BootstrapArgs) {
 	}
 }
 
+// networkConfigInterfaceStatus returns the configured IP and DNS state for the
+// provided IP configuration of an interface or guest network device.
+func networkConfigInterfaceStatus(
+	ipConfigs []network.NetworkInterfaceIPConfig,
+	dhcp4, dhcp6 bool,
+	nameservers, searchDomains []string) vmopv1.VirtualMachineNetworkConfigInterfaceStatus {
+
+	// Declare the interface's config status.
+	var ifc vmopv1.VirtualMachineNetworkConfigInterfaceStatus
+
+	// The intended DHCP configuration is presented per interface, so if
+	// there are no resulting IP configs, but DHCP4 or DHCP6 is configured,
+	// go ahead and create a single, fake IP config so the DHCP info can be
+	// collected the same way below.
+	if len(ipConfigs) == 0 && (dhcp4 || dhcp6) {
+		ipConfigs = []network.NetworkInterfaceIPConfig{{}}
+	}
+
+	// If there *are* resulting IP configs, then ensure the field ifc.IP
+	// is not nil so avoid an NPE later. We do not initialize this field
+	// unless there *are* resulting IP configs to avoid an empty object
+	// when printing the VM's status.
+	if len(ipConfigs) > 0 {
+		ifc.IP = &vmopv1.VirtualMachineNetworkConfigInterfaceIPStatus{}
+	}
+
+	// Iterate over each of the result's IP configurations.
+	for j := range ipConfigs {
+		ipc := ipConfigs[j]
+
+		// Assign the gateways.
+		if gw := ipc.Gateway; gw != "" {
+			if ipc.IsIPv4 && ifc.IP.Gateway4 == "" {
+				ifc.IP.Gateway4 = gw
+			} else if !ipc.IsIPv4 && ifc.IP.Gateway6 == "" {
+				ifc.IP.Gateway6 = gw
+			}
+		}
+
+		// Append the IP address.
+		if ip := ipc.IPCIDR; ip != "" {
+			ifc.IP.Addresses = append(ifc.IP.Addresses, ip)
+		}
+
+		// Update DHCP information.
+		if v4, v6 := dhcp4, dhcp6; v4 || v6 {
+			ifc.IP.DHCP = &vmopv1.VirtualMachineNetworkConfigDHCPStatus{}
+			if v4 {
+				ifc.IP.DHCP.IP4 = &vmopv1.VirtualMachineNetworkConfigDHCPOptionsStatus{
+					Enabled: v4,
+				}
+			}
+			if v6 {
+				ifc.IP.DHCP.IP6 = &vmopv1.VirtualMachineNetworkConfigDHCPOptionsStatus{
+					Enabled: v6,
+				}
+			}
+		}
+
+		// Update DNS information.
+		{
+			ns, sd := nameservers, searchDomains
+			if ln, ls := len(ns), len(sd); ln > 0 || ls > 0 {
+				ifc.DNS = &vmopv1.VirtualMachineNetworkConfigDNSStatus{}
+				if ln > 0 {
+					ifc.DNS.Nameservers = ns
+				}
+				if ls > 0 {
+					ifc.DNS.SearchDomains = sd
+				}
+			}
+		}
+	}
+
+	if ip := ifc.IP; ip != nil && len(ip.Addresses) > 0 {
+		slices.Sort(ifc.IP.Addresses)
+	}
+
+	return ifc
+}
+
 // updateGuestNetworkStatus updates the provided VM's status.network
 // field with information from the guestInfo.
 //
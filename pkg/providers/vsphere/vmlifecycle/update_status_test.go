@@ -848,6 +848,101 @@ var _ = Describe("UpdateStatus", func() {
 			})
 		})
 
+		Context("status.guest.properties", func() {
+			var extraConfig map[string]string
+
+			BeforeEach(func() {
+				extraConfig = map[string]string{
+					"guestinfo.vmservice.status.app.version": "1.2.3",
+					"guestinfo.vmservice.status.app.health":  "healthy",
+					"guestinfo.vmservice.status.role":        "primary",
+					"guestinfo.local-ipv4":                   "192.168.0.2",
+				}
+			})
+
+			JustBeforeEach(func() {
+				// Reconcile again with the guest's extraConfig.
+				vmCtx.MoVM.Config = &vimtypes.VirtualMachineConfigInfo{}
+				for k, v := range extraConfig {
+					vmCtx.MoVM.Config.ExtraConfig = append(vmCtx.MoVM.Config.ExtraConfig,
+						&vimtypes.OptionValue{Key: k, Value: v})
+				}
+				Expect(vmlifecycle.ReconcileStatus(vmCtx, ctx.Client, vcVM, data)).To(Succeed())
+			})
+
+			It("should report the properties without the prefix", func() {
+				Expect(vmCtx.VM.Status.Guest).ToNot(BeNil())
+				Expect(vmCtx.VM.Status.Guest.Properties).To(Equal(map[string]string{
+					"app.version": "1.2.3",
+					"app.health":  "healthy",
+					"role":        "primary",
+				}))
+				Expect(conditions.Get(vmCtx.VM, vmopv1.GuestPropertiesCondition)).To(BeNil())
+			})
+
+			When("the guest does not publish any properties", func() {
+				BeforeEach(func() {
+					extraConfig = nil
+					vmCtx.VM.Status.Guest = &vmopv1.VirtualMachineGuestStatus{
+						Properties: map[string]string{"role": "primary"},
+					}
+				})
+
+				It("should remove the properties", func() {
+					Expect(vmCtx.VM.Status.Guest).ToNot(BeNil())
+					Expect(vmCtx.VM.Status.Guest.Properties).To(BeNil())
+				})
+			})
+
+			When("some of the properties exceed the limits", func() {
+				BeforeEach(func() {
+					extraConfig["guestinfo.vmservice.status.bad key"] = "1"
+					extraConfig["guestinfo.vmservice.status."+strings.Repeat("k", 129)] = "1"
+					extraConfig["guestinfo.vmservice.status.large"] = strings.Repeat("v", 1025)
+				})
+
+				It("should report the other properties and set the condition to false", func() {
+					Expect(vmCtx.VM.Status.Guest.Properties).To(HaveLen(3))
+					Expect(vmCtx.VM.Status.Guest.Properties).ToNot(HaveKey("large"))
+					cond := conditions.Get(vmCtx.VM, vmopv1.GuestPropertiesCondition)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(vmopv1.GuestPropertiesLimitExceededReason))
+					Expect(cond.Message).To(ContainSubstring("bad key"))
+					Expect(cond.Message).To(ContainSubstring("large"))
+				})
+			})
+
+			When("there are too many properties", func() {
+				BeforeEach(func() {
+					for i := range 70 {
+						extraConfig[fmt.Sprintf("guestinfo.vmservice.status.p%02d", i)] = "1"
+					}
+				})
+
+				It("should report at most 64 properties in the order of their keys", func() {
+					Expect(vmCtx.VM.Status.Guest.Properties).To(HaveLen(64))
+					Expect(vmCtx.VM.Status.Guest.Properties).To(HaveKey("app.version"))
+					Expect(vmCtx.VM.Status.Guest.Properties).ToNot(HaveKey("role"))
+					Expect(conditions.IsFalse(vmCtx.VM, vmopv1.GuestPropertiesCondition)).To(BeTrue())
+				})
+			})
+
+			When("the properties exceed the total size", func() {
+				BeforeEach(func() {
+					for i := range 20 {
+						extraConfig[fmt.Sprintf("guestinfo.vmservice.status.z%02d", i)] = strings.Repeat("v", 1000)
+					}
+				})
+
+				It("should report the properties up to the total size", func() {
+					Expect(vmCtx.VM.Status.Guest.Properties).To(HaveKey("role"))
+					Expect(vmCtx.VM.Status.Guest.Properties).To(HaveKey("z00"))
+					Expect(vmCtx.VM.Status.Guest.Properties).ToNot(HaveKey("z19"))
+					Expect(conditions.IsFalse(vmCtx.VM, vmopv1.GuestPropertiesCondition)).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Context("Storage", func() {
@@ -4300,6 +4395,68 @@ var _ = Describe("UpdateNetworkStatusConfig", func() {
 					ExpectWithOffset(1, ic.IP.Gateway6).To(Equal("FD00:F500::::"))
 				})
 			})
+
+			When("the network interface results are members of a bond", func() {
+				BeforeEach(func() {
+					args.NetworkResults.Results = append(
+						args.NetworkResults.Results,
+						network.NetworkInterfaceResult{
+							Name: "eth1",
+						})
+					args.NetworkResults.Devices = []network.NetworkDeviceResult{
+						{
+							Name:       "bond0",
+							Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeBond,
+							Interfaces: []string{"eth0", "eth1"},
+							IPConfigs: []network.NetworkInterfaceIPConfig{
+								{
+									IPCIDR:  "192.168.0.3/24",
+									IsIPv4:  true,
+									Gateway: "192.168.0.1",
+								},
+							},
+						},
+						{
+							Name:       "bond0.100",
+							Type:       vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN,
+							Interfaces: []string{"bond0"},
+							DHCP4:      true,
+							Nameservers: []string{
+								"1.1.1.1",
+							},
+						},
+					}
+				})
+
+				Specify("status.network.config.interfaces should be nil", func() {
+					Expect(config.Interfaces).To(BeNil())
+				})
+				Specify("status.network.config.devices should have the bond and VLAN", func() {
+					Expect(config.Devices).To(HaveLen(2))
+
+					dc := config.Devices[0]
+					Expect(dc.Name).To(Equal("bond0"))
+					Expect(dc.Type).To(Equal(vmopv1.VirtualMachineNetworkGuestDeviceTypeBond))
+					Expect(dc.Interfaces).To(Equal([]string{"eth0", "eth1"}))
+					Expect(dc.IP).ToNot(BeNil())
+					Expect(dc.IP.Addresses).To(Equal([]string{"192.168.0.3/24"}))
+					Expect(dc.IP.Gateway4).To(Equal("192.168.0.1"))
+					Expect(dc.IP.DHCP).To(BeNil())
+					Expect(dc.DNS).To(BeNil())
+
+					dc = config.Devices[1]
+					Expect(dc.Name).To(Equal("bond0.100"))
+					Expect(dc.Type).To(Equal(vmopv1.VirtualMachineNetworkGuestDeviceTypeVLAN))
+					Expect(dc.Interfaces).To(Equal([]string{"bond0"}))
+					Expect(dc.IP).ToNot(BeNil())
+					Expect(dc.IP.Addresses).To(BeEmpty())
+					Expect(dc.IP.DHCP).ToNot(BeNil())
+					Expect(dc.IP.DHCP.IP4).ToNot(BeNil())
+					Expect(dc.IP.DHCP.IP4.Enabled).To(BeTrue())
+					Expect(dc.DNS).ToNot(BeNil())
+					Expect(dc.DNS.Nameservers).To(Equal([]string{"1.1.1.1"}))
+				})
+			})
 		})
 	})
 })
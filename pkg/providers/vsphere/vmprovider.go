@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"strings"
 	"sync"
@@ -36,6 +37,7 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageimport"
 	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
@@ -47,6 +49,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
@@ -64,6 +67,8 @@ type vSphereVMProvider struct {
 
 	vcClientLock sync.Mutex
 	vcClient     *vcclient.Client
+
+	snapshotHooksOps *asyncop.Tracker[*snapshotHooksOp]
 }
 
 func NewVSphereVMProviderFromClient(
@@ -75,6 +80,7 @@ func NewVSphereVMProviderFromClient(
 		k8sClient:         client,
 		eventRecorder:     recorder,
 		globalExtraConfig: getExtraConfig(ctx),
+		snapshotHooksOps:  newSnapshotHooksTracker(),
 	}
 
 	ovfcache.SetGetter(ctx, p.getOvfEnvelope)
@@ -507,6 +513,94 @@ func (vs *vSphereVMProvider) UpdateContentLibraryItem(ctx context.Context, itemI
 	return contentLibraryProvider.UpdateLibraryItem(ctx, itemID, newName, newDescription)
 }
 
+func (vs *vSphereVMProvider) ImportContentLibraryItem(
+	ctx context.Context,
+	libraryUUID, itemName, itemDescription string,
+	src imageimport.Source) (string, error) {
+
+	pkglog.FromContextOrDefault(ctx).V(4).Info("Import Content Library Item",
+		"libraryUUID", libraryUUID, "itemName", itemName)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	item := library.Item{
+		LibraryID: libraryUUID,
+		Name:      itemName,
+	}
+	if itemDescription != "" {
+		item.Description = &itemDescription
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.ImportLibraryItem(ctx, item, src)
+}
+
+func (vs *vSphereVMProvider) DeleteContentLibraryItem(ctx context.Context, itemID string) error {
+	pkglog.FromContextOrDefault(ctx).V(4).Info("Delete Content Library Item",
+		"itemID", itemID)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.DeleteLibraryItem(ctx, itemID)
+}
+
+func (vs *vSphereVMProvider) GetContentLibraryItemFiles(
+	ctx context.Context,
+	itemID string,
+	match func(name string) bool) (map[string][]byte, error) {
+
+	pkglog.FromContextOrDefault(ctx).V(4).Info("Get Content Library Item Files",
+		"itemID", itemID)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.RetrieveLibraryItemFiles(ctx, itemID, match)
+}
+
+func (vs *vSphereVMProvider) ListContentLibraryItemFiles(
+	ctx context.Context,
+	itemID string) ([]string, error) {
+
+	pkglog.FromContextOrDefault(ctx).V(4).Info("List Content Library Item Files",
+		"itemID", itemID)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.ListLibraryItemFileNames(ctx, itemID)
+}
+
+func (vs *vSphereVMProvider) CopyContentLibraryItemFile(
+	ctx context.Context,
+	itemID, name string,
+	w io.Writer) error {
+
+	pkglog.FromContextOrDefault(ctx).V(4).Info("Copy Content Library Item File",
+		"itemID", itemID, "name", name)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.CopyLibraryItemFile(ctx, itemID, name, w)
+}
+
 func (vs *vSphereVMProvider) getOpID(ctx context.Context, obj ctrlclient.Object, operation string) string {
 	var id string
 
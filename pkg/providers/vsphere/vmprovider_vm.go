@@ -26,6 +26,7 @@ import (
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +63,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
@@ -72,6 +74,7 @@ import (
 	vmconfbootoptions "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/bootoptions"
 	vmconfcrypto "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/crypto"
 	vmconfdiskpromo "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/diskpromo"
+	vmconfdrift "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/drift"
 	vmconfpolicy "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/policy"
 	vmconfunmanagedvolsreg "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/volumes/unmanaged/register"
 )
@@ -135,7 +138,10 @@ var (
 
 func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachine(
 	ctx context.Context,
-	vm *vmopv1.VirtualMachine) error {
+	vm *vmopv1.VirtualMachine) (retErr error) {
+
+	ctx, span := startVMSpan(ctx, "CreateOrUpdateVirtualMachine", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	_, err := vs.createOrUpdateVirtualMachine(ctx, vm, false)
 	return err
@@ -143,7 +149,10 @@ func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachine(
 
 func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachineAsync(
 	ctx context.Context,
-	vm *vmopv1.VirtualMachine) (<-chan error, error) {
+	vm *vmopv1.VirtualMachine) (_ <-chan error, retErr error) {
+
+	ctx, span := startVMSpan(ctx, "CreateOrUpdateVirtualMachineAsync", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	return vs.createOrUpdateVirtualMachine(ctx, vm, true)
 }
@@ -317,7 +326,10 @@ func (vs *vSphereVMProvider) createOrUpdateVirtualMachine(
 // annotation is deleted.
 func (vs *vSphereVMProvider) CleanupVirtualMachine(
 	ctx context.Context,
-	vm *vmopv1.VirtualMachine) error {
+	vm *vmopv1.VirtualMachine) (retErr error) {
+
+	ctx, span := startVMSpan(ctx, "CleanupVirtualMachine", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	vmNamespacedName := vm.NamespacedName()
 
@@ -360,7 +372,10 @@ func (vs *vSphereVMProvider) CleanupVirtualMachine(
 
 func (vs *vSphereVMProvider) DeleteVirtualMachine(
 	ctx context.Context,
-	vm *vmopv1.VirtualMachine) error {
+	vm *vmopv1.VirtualMachine) (retErr error) {
+
+	ctx, span := startVMSpan(ctx, "DeleteVirtualMachine", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	vmNamespacedName := vm.NamespacedName()
 
@@ -376,6 +391,14 @@ func (vs *vSphereVMProvider) DeleteVirtualMachine(
 		VM:      vm,
 	}
 
+	// Release the addresses allocated to the VM from IP pools only once the
+	// vSphere VM no longer exists.
+	defer func() {
+		if retErr == nil {
+			retErr = network.ReleaseIPPoolAddresses(vmCtx, vs.k8sClient, vm)
+		}
+	}()
+
 	client, err := vs.getVcClient(vmCtx)
 	if err != nil {
 		return err
@@ -456,7 +479,10 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 	vm *vmopv1.VirtualMachine,
 	vmPub *vmopv1.VirtualMachinePublishRequest,
 	cl *imgregv1a1.ContentLibrary,
-	actID string) (string, error) {
+	actID string) (_ string, retErr error) {
+
+	ctx, span := startVMSpan(ctx, "PublishVirtualMachine", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
 
 	logger := pkglog.FromContextOrDefault(ctx).WithValues(
 		"vmName", vm.NamespacedName(), "clName", fmt.Sprintf("%s/%s", cl.Namespace, cl.Name))
@@ -512,6 +538,38 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 	return virtualmachine.CreateOVF(vmCtx, client.RestClient(), vmPub, cl, actID)
 }
 
+func (vs *vSphereVMProvider) ExportVirtualMachine(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	name, dir string) (_ []string, retErr error) {
+
+	ctx, span := startVMSpan(ctx, "ExportVirtualMachine", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
+
+	logger := pkglog.FromContextOrDefault(ctx).WithValues("vmName", vm.NamespacedName())
+	ctx = logr.NewContext(ctx, logger)
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(ctx, vm, "exportVM")),
+		Logger:  logger,
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.V(4).Info("Exporting VM as OVF", "name", name)
+
+	return virtualmachine.ExportOVF(vmCtx, vcVM, name, dir)
+}
+
 func (vs *vSphereVMProvider) GetVirtualMachineGuestHeartbeat(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
@@ -789,6 +847,10 @@ func (vs *vSphereVMProvider) getCreateArgs(
 		return nil, err
 	}
 
+	// Record the ExtraConfig values the VM is created with so a value that is
+	// later changed out of band is detected as drift.
+	vmconfdrift.SetLastAppliedExtraConfig(vmCtx.VM, createArgs.ConfigSpec.ExtraConfig)
+
 	return createArgs, nil
 }
 
@@ -1507,12 +1569,16 @@ func (vs *vSphereVMProvider) vmCreateDoPlacement(
 	vcClient *vcclient.Client,
 	createArgs *VMCreateArgs) (retErr error) {
 
+	var span trace.Span
+	vmCtx.Context, span = pkgtracing.Start(vmCtx.Context, "VSphereVMProvider.vmCreateDoPlacement")
+	defer func() { pkgtracing.End(span, retErr) }()
+
 	defer func() {
 		if retErr != nil {
 			pkgcnd.MarkError(
 				vmCtx.VM,
 				vmopv1.VirtualMachineConditionPlacementReady,
-				"NotReady",
+				placementErrorReason(retErr),
 				retErr)
 		} else {
 			pkgcnd.MarkTrue(
@@ -1586,6 +1652,10 @@ func (vs *vSphereVMProvider) vmCreateDoPlacement(
 		Zones:       pvcZones,
 	}
 
+	// The REST client is used to get the tags of the candidate hosts and
+	// clusters for the VM's host affinity.
+	vmCtx.Context = pkgctx.WithRestClient(vmCtx.Context, vcClient.RestClient())
+
 	result, err := placement.Placement(
 		vmCtx,
 		vs.k8sClient,
@@ -1600,6 +1670,19 @@ func (vs *vSphereVMProvider) vmCreateDoPlacement(
 	return processPlacementResult(vmCtx, vcClient, createArgs, *result)
 }
 
+// placementErrorReason returns the reason for the PlacementReady condition
+// when placement fails with the provided error.
+func placementErrorReason(err error) string {
+	switch {
+	case errors.Is(err, placement.ErrNoZoneAffinityMatch):
+		return vmopv1.VirtualMachinePlacementNoZoneAffinityMatchReason
+	case errors.Is(err, placement.ErrNoHostAffinityMatch):
+		return vmopv1.VirtualMachinePlacementNoHostAffinityMatchReason
+	default:
+		return "NotReady"
+	}
+}
+
 // vmCreateDoPlacementByGroup places the VM from the group's placement result.
 func (vs *vSphereVMProvider) vmCreateDoPlacementByGroup(
 	vmCtx pkgctx.VirtualMachineContext,
@@ -1835,6 +1918,10 @@ func (vs *vSphereVMProvider) vmCreateGetFolderAndRPMoIDs(
 	return nil
 }
 
+// imageCacheLastUsedResolution is the minimum amount of time between updates
+// to the time an image cache location was last used.
+const imageCacheLastUsedResolution = time.Minute
+
 // vmCreateGetSourceFilePaths gets paths to the source file(s) used to create
 // the VM.
 func (vs *vSphereVMProvider) vmCreateGetSourceFilePaths(
@@ -1871,6 +1958,15 @@ func (vs *vSphereVMProvider) vmCreateGetSourceFilePaths(
 				datacenterID,
 				datastoreID,
 				createArgs.StorageProfileID)
+
+			// Record that the location was used so its files are not evicted
+			// from the cache.
+			obj.MarkLocationUsed(
+				datacenterID,
+				datastoreID,
+				createArgs.StorageProfileID,
+				metav1.Now(),
+				imageCacheLastUsedResolution)
 			return nil
 		}); err != nil {
 		return fmt.Errorf(
@@ -3028,3 +3124,16 @@ func (vs *vSphereVMProvider) vmResizeGetArgs(
 
 	return resizeArgs, nil
 }
+
+// startVMSpan starts a span for a VM provider operation on the given VM.
+func startVMSpan(
+	ctx context.Context,
+	spanName string,
+	vm *vmopv1.VirtualMachine) (context.Context, trace.Span) {
+
+	return pkgtracing.Start(
+		ctx,
+		"VSphereVMProvider."+spanName,
+		pkgtracing.AttrNamespace.String(vm.Namespace),
+		pkgtracing.AttrName.String(vm.Name))
+}
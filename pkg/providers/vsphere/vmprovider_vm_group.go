@@ -150,7 +150,7 @@ func setVMPlacementReadyCondErr(
 	pkgcond.MarkError(
 		memberStatus,
 		vmopv1.VirtualMachineGroupMemberConditionPlacementReady,
-		"NotReady",
+		vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason,
 		err)
 }
 
@@ -291,8 +291,8 @@ func vmGroupTests() {
 			Expect(err).To(HaveOccurred())
 
 			Expect(vmGroup.Status.Members).To(HaveLen(2))
-			assertNotReadyMemberStatusForVM(vm1, vmGroup.Status.Members[0], "NotReady")
-			assertNotReadyMemberStatusForVM(vm2, vmGroup.Status.Members[1], "NotReady")
+			assertNotReadyMemberStatusForVM(vm1, vmGroup.Status.Members[0], vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason)
+			assertNotReadyMemberStatusForVM(vm2, vmGroup.Status.Members[1], vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason)
 
 			markPolicyEvalReady := func(vm *vmopv1.VirtualMachine) {
 				policyEval := &vspherepolv1.PolicyEvaluation{}
@@ -312,7 +312,7 @@ func vmGroupTests() {
 
 			Expect(vmGroup.Status.Members).To(HaveLen(2))
 			assertNotReadyMemberStatusForVM(vm1, vmGroup.Status.Members[0], "PendingPlacement")
-			assertNotReadyMemberStatusForVM(vm2, vmGroup.Status.Members[1], "NotReady")
+			assertNotReadyMemberStatusForVM(vm2, vmGroup.Status.Members[1], vmopv1.VirtualMachineGroupMemberPlacementNotReadyReason)
 
 			markPolicyEvalReady(vm2)
 			err = vmProvider.PlaceVirtualMachineGroup(ctx, vmGroup, groupPlacements)
@@ -323,4 +323,35 @@ func vmGroupTests() {
 			assertMemberStatusForVM(vm2, vmGroup.Status.Members[1])
 		})
 	})
+
+	Context("PlaceVirtualMachines", func() {
+		It("should return the placement of each VM without creating it", func() {
+			vm1.Spec.GroupName = ""
+			vm2.Spec.GroupName = ""
+			vmCopy := vm1.DeepCopy()
+
+			results := vmProvider.PlaceVirtualMachines(ctx, []*vmopv1.VirtualMachine{vm1, vm2})
+			Expect(results).To(HaveLen(2))
+			for _, r := range results {
+				Expect(r.Err).ToNot(HaveOccurred())
+				Expect(r.Placement).ToNot(BeNil())
+				Expect(r.Placement.Zone).ToNot(BeEmpty())
+				Expect(r.Placement.Pool).ToNot(BeEmpty())
+			}
+
+			Expect(vm1).To(Equal(vmCopy), "VM was modified")
+		})
+
+		It("should return an error when a VM cannot be placed", func() {
+			vm1.Spec.GroupName = ""
+			vm1.Spec.ClassName = "does-not-exist"
+			vm2.Spec.GroupName = ""
+
+			results := vmProvider.PlaceVirtualMachines(ctx, []*vmopv1.VirtualMachine{vm1, vm2})
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Err).To(HaveOccurred())
+			Expect(results[0].Placement).To(BeNil())
+			Expect(results[1].Err).ToNot(HaveOccurred())
+		})
+	})
 }
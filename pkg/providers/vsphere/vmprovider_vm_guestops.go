@@ -0,0 +1,107 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+// CopyFileToVirtualMachineGuest writes the data to the file at guestPath in
+// the VM's guest through VMware Tools.
+func (vs *vSphereVMProvider) CopyFileToVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	data []byte,
+	overwrite bool) (retErr error) {
+
+	ctx, span := startVMSpan(ctx, "CopyFileToVirtualMachineGuest", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
+
+	vmCtx, vcVM, err := vs.getGuestOperationVM(ctx, vm, "guestCopyIn")
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.CopyFileToGuest(vmCtx, vcVM, creds, guestPath, data, overwrite)
+}
+
+// CopyFileFromVirtualMachineGuest returns the contents of the file at
+// guestPath in the VM's guest through VMware Tools.
+func (vs *vSphereVMProvider) CopyFileFromVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	guestPath string,
+	maxSize int64) (_ []byte, retErr error) {
+
+	ctx, span := startVMSpan(ctx, "CopyFileFromVirtualMachineGuest", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
+
+	vmCtx, vcVM, err := vs.getGuestOperationVM(ctx, vm, "guestCopyOut")
+	if err != nil {
+		return nil, err
+	}
+
+	return virtualmachine.CopyFileFromGuest(vmCtx, vcVM, creds, guestPath, maxSize)
+}
+
+// RunProgramInVirtualMachineGuest runs the program in the VM's guest through
+// VMware Tools and waits for it to exit.
+func (vs *vSphereVMProvider) RunProgramInVirtualMachineGuest(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	creds providers.GuestCredentials,
+	spec providers.GuestProgramSpec) (_ providers.GuestProgramResult, retErr error) {
+
+	ctx, span := startVMSpan(ctx, "RunProgramInVirtualMachineGuest", vm)
+	defer func() { pkgtracing.End(span, retErr) }()
+
+	vmCtx, vcVM, err := vs.getGuestOperationVM(ctx, vm, "guestRunProgram")
+	if err != nil {
+		return providers.GuestProgramResult{}, err
+	}
+
+	return virtualmachine.RunProgramInGuest(vmCtx, vcVM, creds, spec)
+}
+
+func (vs *vSphereVMProvider) getGuestOperationVM(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	op string) (pkgctx.VirtualMachineContext, *object.VirtualMachine, error) {
+
+	logger := pkglog.FromContextOrDefault(ctx).WithValues("vmName", vm.NamespacedName())
+	ctx = logr.NewContext(ctx, logger)
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(ctx, vm, op)),
+		Logger:  logger,
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return vmCtx, nil, err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return vmCtx, nil, err
+	}
+
+	return vmCtx, vcVM, nil
+}
@@ -0,0 +1,236 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
+)
+
+// PlaceVirtualMachines returns where each of the VMs would be placed if they
+// were created in order, without creating them or updating the VM objects.
+// Each VM is placed by itself, the same way as vmCreateDoPlacement places a VM
+// that is not a member of a group, except that the reservations of the VMs
+// placed before it are subtracted from the unreserved capacity of their
+// ResourcePools.
+func (vs *vSphereVMProvider) PlaceVirtualMachines(
+	ctx context.Context,
+	vms []*vmopv1.VirtualMachine) []providers.VMPlacementResult {
+
+	results := make([]providers.VMPlacementResult, len(vms))
+
+	vcClient, err := vs.getVcClient(ctx)
+	if err != nil {
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	reserved := map[string]placement.Reservation{}
+	for i, vm := range vms {
+		result, reservation, err := vs.placeVirtualMachine(ctx, vcClient, vm, reserved)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Placement = placeResultToGroupMemberPlacement(result)
+		if pool := result.PoolMoRef.Value; pool != "" {
+			reserved[pool] = reserved[pool].Add(reservation)
+		}
+	}
+
+	return results
+}
+
+// placeVirtualMachine returns where the VM would be placed, and its
+// reservation.
+func (vs *vSphereVMProvider) placeVirtualMachine(
+	ctx context.Context,
+	vcClient *vcclient.Client,
+	vm *vmopv1.VirtualMachine,
+	reserved map[string]placement.Reservation) (*placement.Result, placement.Reservation, error) {
+
+	// Getting the prereqs may set conditions on the VM, so use a copy of it.
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: pkgctx.WithRestClient(
+			context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(ctx, vm, "placeVM")),
+			vcClient.RestClient()),
+		Logger: pkglog.FromContextOrDefault(ctx).WithValues("vm", vm.Name),
+		VM:     vm.DeepCopy(),
+	}
+
+	createArgs, err := vs.vmGroupGetVMCreatePrereqs(vmCtx, vcClient)
+	if err != nil {
+		return nil, placement.Reservation{}, fmt.Errorf("failed to get VM placement prereqs: %w", err)
+	}
+
+	placementConfigSpec, err := vs.vmGroupGetVMPlacementConfigSpec(vmCtx, vcClient, createArgs)
+	if err != nil {
+		return nil, placement.Reservation{}, fmt.Errorf("failed to get VM placement ConfigSpec: %w", err)
+	}
+
+	pvcZones, err := kubeutil.GetPVCZoneConstraints(
+		createArgs.Storage.StorageClasses,
+		createArgs.Storage.PVCs)
+	if err != nil {
+		return nil, placement.Reservation{}, err
+	}
+
+	result, err := placement.Placement(
+		vmCtx,
+		vs.k8sClient,
+		vcClient.VimClient(),
+		vcClient.Finder(),
+		*placementConfigSpec,
+		placement.Constraints{
+			ChildRPName: createArgs.ChildResourcePoolName,
+			Zones:       pvcZones,
+			Reserved:    reserved,
+		})
+	if err != nil {
+		return nil, placement.Reservation{}, err
+	}
+
+	return result, placement.ConfigSpecReservation(*placementConfigSpec), nil
+}
+
+// EvaluateVirtualMachineHostPlacement returns the VM's preferred rules that
+// are violated on its current host, and the host in the VM's zone on which the
+// fewest of them would be violated.
+func (vs *vSphereVMProvider) EvaluateVirtualMachineHostPlacement(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine) (providers.VMHostPlacementEvaluation, error) {
+
+	logger := pkglog.FromContextOrDefault(ctx).WithValues("vmName", vm.NamespacedName())
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(ctx, vm, "evaluateHostPlacement")),
+		Logger:  logger,
+		VM:      vm,
+	}
+
+	vcClient, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return providers.VMHostPlacementEvaluation{}, err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, vcClient, true)
+	if err != nil {
+		return providers.VMHostPlacementEvaluation{}, err
+	}
+
+	rp, err := vcVM.ResourcePool(vmCtx)
+	if err != nil {
+		return providers.VMHostPlacementEvaluation{}, fmt.Errorf("failed to get VM ResourcePool: %w", err)
+	}
+
+	// The VM may only be moved to the ResourcePools of its resource policy.
+	var childRPName string
+	resourcePolicy, err := GetVMSetResourcePolicy(
+		pkgctx.VirtualMachineContext{
+			Context: vmCtx,
+			Logger:  logger,
+			VM:      vm.DeepCopy(),
+		},
+		vs.k8sClient)
+	if err != nil {
+		return providers.VMHostPlacementEvaluation{}, err
+	}
+	if resourcePolicy != nil {
+		childRPName = resourcePolicy.Spec.ResourcePool.Name
+	}
+
+	result, err := placement.EvaluateHosts(
+		pkgctx.WithRestClient(vmCtx, vcClient.RestClient()),
+		vs.k8sClient,
+		vcClient.VimClient(),
+		vm,
+		rp.Reference(),
+		childRPName)
+	if err != nil {
+		return providers.VMHostPlacementEvaluation{}, err
+	}
+
+	return providers.VMHostPlacementEvaluation{
+		Violations:       result.Violations,
+		TargetHost:       result.TargetHost,
+		TargetHostMoID:   result.TargetHostMoRef.Value,
+		TargetPoolMoID:   result.TargetPoolMoRef.Value,
+		TargetViolations: result.TargetViolations,
+	}, nil
+}
+
+// RelocateVirtualMachineToHost moves the VM to the host and ResourcePool,
+// which must be in the host's cluster, without changing its datastores. The
+// VM's ResourcePool is not changed if poolMoID is empty.
+func (vs *vSphereVMProvider) RelocateVirtualMachineToHost(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	hostMoID, poolMoID string) error {
+
+	logger := pkglog.FromContextOrDefault(ctx).WithValues("vmName", vm.NamespacedName())
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(ctx, vm, "relocateToHost")),
+		Logger:  logger,
+		VM:      vm,
+	}
+
+	vcClient, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, vcClient, true)
+	if err != nil {
+		return err
+	}
+
+	var rpMoRef vimtypes.ManagedObjectReference
+	if poolMoID != "" {
+		rpMoRef = vimtypes.ManagedObjectReference{
+			Type:  string(vimtypes.ManagedObjectTypeResourcePool),
+			Value: poolMoID,
+		}
+	} else {
+		rp, err := vcVM.ResourcePool(vmCtx)
+		if err != nil {
+			return fmt.Errorf("failed to get VM ResourcePool: %w", err)
+		}
+		rpMoRef = rp.Reference()
+	}
+
+	hostMoRef := vimtypes.ManagedObjectReference{
+		Type:  string(vimtypes.ManagedObjectTypeHostSystem),
+		Value: hostMoID,
+	}
+
+	logger.Info("Relocating VM to host", "host", hostMoID, "pool", rpMoRef.Value)
+
+	task, err := vcVM.Relocate(
+		vmCtx,
+		vimtypes.VirtualMachineRelocateSpec{
+			Host: &hostMoRef,
+			Pool: &rpMoRef,
+		},
+		vimtypes.VirtualMachineMovePriorityDefaultPriority)
+	if err != nil {
+		return fmt.Errorf("failed to relocate VM to host %s: %w", hostMoID, err)
+	}
+	if err := task.Wait(vmCtx); err != nil {
+		return fmt.Errorf("failed to relocate VM to host %s: %w", hostMoID, err)
+	}
+
+	return nil
+}
@@ -846,6 +846,17 @@ func (vs *vSphereVMProvider) reconcileCurrentSnapshot(
 			continue
 		}
 
+		// Skip snapshots that were aborted by a failed pre-snapshot hook.
+		if pkgcnd.GetReason(
+			&snapshot,
+			vmopv1.VirtualMachineSnapshotCreatedCondition) ==
+			vmopv1.VirtualMachineSnapshotCreationAbortedReason {
+
+			logger.V(4).Info("Skipping snapshot that was aborted",
+				"snapshotName", snapshot.Name)
+			continue
+		}
+
 		if snapshot.DeletionTimestamp.IsZero() {
 			totalPendingSnapshots++
 		}
@@ -887,42 +898,53 @@ func (vs *vSphereVMProvider) reconcileCurrentSnapshot(
 		return nil
 	}
 
-	// If the oldest snapshot is in progress, wait for it to complete
-	if pkgcnd.GetReason(
-		snapshotToProcess,
-		vmopv1.VirtualMachineSnapshotReadyCondition) ==
-		vmopv1.VirtualMachineSnapshotCreationInProgressReason {
+	var snapNode *vimtypes.VirtualMachineSnapshotTree
 
-		logger.V(4).Info("Snapshot is in progress, waiting for completion")
-		return nil
-	}
+	if hasSnapshotHooks(snapshotToProcess) {
+		// The hooks and the snapshot are run in the background. The VM is
+		// reconciled again once they are done.
+		snapNode, err = vs.snapshotVirtualMachineWithHooks(vmCtx, vcVM, snapshotToProcess)
+		if err != nil || snapNode == nil {
+			return err
+		}
+	} else {
+		// If the oldest snapshot is in progress, wait for it to complete
+		if pkgcnd.GetReason(
+			snapshotToProcess,
+			vmopv1.VirtualMachineSnapshotReadyCondition) ==
+			vmopv1.VirtualMachineSnapshotCreationInProgressReason {
+
+			logger.V(4).Info("Snapshot is in progress, waiting for completion")
+			return nil
+		}
 
-	// Mark the snapshot as in progress before starting the operation to
-	// prevent concurrent snapshots.
-	if err := vs.markSnapshotInProgress(vmCtx, snapshotToProcess); err != nil {
-		return fmt.Errorf("failed to mark snapshot %q in progress: %w",
-			snapshotToProcess.Name, err)
-	}
+		// Mark the snapshot as in progress before starting the operation to
+		// prevent concurrent snapshots.
+		if err := vs.markSnapshotInProgress(vmCtx, snapshotToProcess); err != nil {
+			return fmt.Errorf("failed to mark snapshot %q in progress: %w",
+				snapshotToProcess.Name, err)
+		}
 
-	snapArgs := virtualmachine.SnapshotArgs{
-		VMCtx:      vmCtx,
-		VcVM:       vcVM,
-		VMSnapshot: *snapshotToProcess,
-	}
+		snapArgs := virtualmachine.SnapshotArgs{
+			VMCtx:      vmCtx,
+			VcVM:       vcVM,
+			VMSnapshot: *snapshotToProcess,
+		}
 
-	logger.Info("Creating snapshot on vSphere")
-	snapNode, err := virtualmachine.SnapshotVirtualMachine(snapArgs)
-	if err != nil {
-		// Mark the snapshot as failed and clear in-progress status
-		// TODO: we wait for in-progress snapshots to complete when
-		// taking a snapshot. So, if we are unable to clear the
-		// in-progress condition because status patching fails, we
-		// will forever be waiting.
-		if err := vs.markSnapshotFailed(vmCtx, snapshotToProcess, err); err != nil {
-			return fmt.Errorf("failed to mark snapshot as failed: %w", err)
+		logger.Info("Creating snapshot on vSphere")
+		snapNode, err = virtualmachine.SnapshotVirtualMachine(snapArgs)
+		if err != nil {
+			// Mark the snapshot as failed and clear in-progress status
+			// TODO: we wait for in-progress snapshots to complete when
+			// taking a snapshot. So, if we are unable to clear the
+			// in-progress condition because status patching fails, we
+			// will forever be waiting.
+			if err := vs.markSnapshotFailed(vmCtx, snapshotToProcess, err); err != nil {
+				return fmt.Errorf("failed to mark snapshot as failed: %w", err)
+			}
+			return fmt.Errorf("failed to create snapshot %q: %w",
+				snapshotToProcess.Name, err)
 		}
-		return fmt.Errorf("failed to create snapshot %q: %w",
-			snapshotToProcess.Name, err)
 	}
 
 	// Update the snapshot status with the successful result
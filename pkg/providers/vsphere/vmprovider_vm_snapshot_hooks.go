@@ -0,0 +1,458 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
+)
+
+const (
+	// defaultSnapshotHookTimeout is the timeout of a snapshot hook that does
+	// not specify one.
+	defaultSnapshotHookTimeout = time.Minute
+
+	// snapshotHookMaxOutputBytes is the number of bytes of a command hook's
+	// standard error included in the hook's failure message.
+	snapshotHookMaxOutputBytes = 1024
+)
+
+// snapshotHooksOp is the value of the background operation that runs a
+// snapshot's pre-snapshot hooks, takes the snapshot, and runs its
+// post-snapshot hooks.
+type snapshotHooksOp struct {
+	logger   logr.Logger
+	vm       *vmopv1.VirtualMachine
+	snapshot *vmopv1.VirtualMachineSnapshot
+
+	getCredentials func(ctx context.Context, secretName string) (providers.GuestCredentials, error)
+	runProgram     func(ctx context.Context, creds providers.GuestCredentials, spec providers.GuestProgramSpec) (providers.GuestProgramResult, error)
+	takeSnapshot   func(ctx context.Context) (*vimtypes.VirtualMachineSnapshotTree, error)
+
+	// The following fields are written by the operation and may only be read
+	// once it is done. The hook conditions are recorded on snapshot.
+
+	snapNode *vimtypes.VirtualMachineSnapshotTree
+	aborted  bool
+}
+
+// newSnapshotHooksTracker returns the tracker of the snapshot hook
+// operations, keyed by VM. The VM is reconciled once its operation is done.
+func newSnapshotHooksTracker() *asyncop.Tracker[*snapshotHooksOp] {
+	return asyncop.NewTracker[*snapshotHooksOp](
+		asyncop.ReconcileOnDone("VirtualMachine", func() ctrlclient.Object {
+			return &vmopv1.VirtualMachine{}
+		}))
+}
+
+// hasSnapshotHooks returns true if the snapshot has any hooks.
+func hasSnapshotHooks(snapshot *vmopv1.VirtualMachineSnapshot) bool {
+	return len(snapshot.Spec.PreSnapshotHooks) > 0 ||
+		len(snapshot.Spec.PostSnapshotHooks) > 0
+}
+
+// snapshotVirtualMachineWithHooks takes the snapshot of a VM in the
+// background, with the snapshot's pre-snapshot hooks run before and its
+// post-snapshot hooks run after, so the reconcile of the VM is not blocked by
+// the hooks. It returns a nil snapshot tree until the snapshot is taken.
+func (vs *vSphereVMProvider) snapshotVirtualMachineWithHooks(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) (*vimtypes.VirtualMachineSnapshotTree, error) {
+
+	var (
+		key = types.NamespacedName{
+			Namespace: vmCtx.VM.Namespace,
+			Name:      vmCtx.VM.Name,
+		}
+		inProgress = pkgcnd.GetReason(
+			vmSnapshot,
+			vmopv1.VirtualMachineSnapshotCreatedCondition) ==
+			vmopv1.VirtualMachineSnapshotCreationInProgressReason
+	)
+
+	op := vs.snapshotHooksOps.Get(key)
+	if op != nil && op.Value().snapshot.UID != vmSnapshot.UID {
+		// The operation is for a snapshot that is no longer the one to be
+		// processed, ex. because it was deleted.
+		if !op.IsDone() {
+			vmCtx.Logger.V(4).Info("Waiting for the hooks of another snapshot",
+				"otherSnapshotName", op.Value().snapshot.Name)
+			return nil, nil
+		}
+		vs.snapshotHooksOps.Forget(key)
+		op = nil
+	}
+
+	if op == nil {
+		if vs.snapshotHooksOps.IsLost(key, inProgress) {
+			// The operation was lost, ex. because the pod restarted. Mark the
+			// snapshot as failed so it is retried, which also runs the
+			// post-snapshot hooks again.
+			err := errors.New("snapshot operation was interrupted")
+			if err := vs.markSnapshotFailed(vmCtx, vmSnapshot, err); err != nil {
+				return nil, fmt.Errorf("failed to mark snapshot as failed: %w", err)
+			}
+			return nil, fmt.Errorf("failed to create snapshot %q: %w", vmSnapshot.Name, err)
+		}
+
+		// Mark the snapshot as in progress before starting the operation so a
+		// lost operation can be detected.
+		if err := vs.markSnapshotInProgress(vmCtx, vmSnapshot); err != nil {
+			return nil, fmt.Errorf("failed to mark snapshot %q in progress: %w",
+				vmSnapshot.Name, err)
+		}
+
+		vs.snapshotHooksOps.Start(vmCtx, key, vs.newSnapshotHooksOp(vmCtx, vcVM, vmSnapshot), runSnapshotHooksOp)
+
+		vmCtx.Logger.Info("Started snapshot with hooks")
+		return nil, nil
+	}
+
+	if !op.IsDone() {
+		vmCtx.Logger.V(4).Info("Snapshot with hooks is in progress, waiting for completion")
+		return nil, nil
+	}
+
+	result := op.Value()
+
+	patch := ctrlclient.MergeFrom(vmSnapshot.DeepCopy())
+	for _, t := range []string{
+		vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition,
+		vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition,
+	} {
+		if c := pkgcnd.Get(result.snapshot, t); c != nil {
+			pkgcnd.Set(vmSnapshot, c)
+		} else {
+			pkgcnd.Delete(vmSnapshot, t)
+		}
+	}
+
+	opErr := op.Err()
+	switch {
+	case result.aborted:
+		pkgcnd.MarkFalse(
+			vmSnapshot,
+			vmopv1.VirtualMachineSnapshotCreatedCondition,
+			vmopv1.VirtualMachineSnapshotCreationAbortedReason,
+			"Snapshot aborted by a failed pre-snapshot hook")
+	case opErr != nil:
+		pkgcnd.MarkError(
+			vmSnapshot,
+			vmopv1.VirtualMachineSnapshotCreatedCondition,
+			vmopv1.VirtualMachineSnapshotCreationFailedReason,
+			opErr)
+	}
+
+	if err := vs.k8sClient.Status().Patch(vmCtx, vmSnapshot, patch); err != nil {
+		return nil, fmt.Errorf("failed to update snapshot %q hooks status: %w",
+			vmSnapshot.Name, err)
+	}
+
+	vs.snapshotHooksOps.Forget(key)
+
+	switch {
+	case result.aborted:
+		vmCtx.Logger.Info("Snapshot aborted by a failed pre-snapshot hook")
+		return nil, nil
+	case opErr != nil:
+		return nil, fmt.Errorf("failed to create snapshot %q: %w", vmSnapshot.Name, opErr)
+	}
+
+	return result.snapNode, nil
+}
+
+// newSnapshotHooksOp returns the value of the operation for the snapshot. The
+// objects are copied since they outlive the reconcile.
+func (vs *vSphereVMProvider) newSnapshotHooksOp(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) *snapshotHooksOp {
+
+	o := &snapshotHooksOp{
+		logger:   vmCtx.Logger,
+		vm:       vmCtx.VM.DeepCopy(),
+		snapshot: vmSnapshot.DeepCopy(),
+	}
+
+	opVMCtx := func(ctx context.Context) pkgctx.VirtualMachineContext {
+		c := vmCtx
+		c.Context = ctx
+		c.VM = o.vm
+		return c
+	}
+
+	o.getCredentials = func(ctx context.Context, secretName string) (providers.GuestCredentials, error) {
+		return getSnapshotHookCredentials(ctx, vs.k8sClient, o.snapshot.Namespace, secretName)
+	}
+	o.runProgram = func(
+		ctx context.Context,
+		creds providers.GuestCredentials,
+		spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+		return virtualmachine.RunProgramInGuest(opVMCtx(ctx), vcVM, creds, spec)
+	}
+	o.takeSnapshot = func(ctx context.Context) (*vimtypes.VirtualMachineSnapshotTree, error) {
+		o.logger.Info("Creating snapshot on vSphere")
+		return virtualmachine.SnapshotVirtualMachine(virtualmachine.SnapshotArgs{
+			VMCtx:      opVMCtx(ctx),
+			VcVM:       vcVM,
+			VMSnapshot: *o.snapshot,
+		})
+	}
+
+	return o
+}
+
+// runSnapshotHooksOp runs the pre-snapshot hooks, takes the snapshot unless a
+// pre-snapshot hook aborted it, and always runs the post-snapshot hooks, even
+// when a pre-snapshot hook or the snapshot failed or timed out, so the guest
+// is not left frozen.
+func runSnapshotHooksOp(ctx context.Context, o *snapshotHooksOp) error {
+	pkgcnd.Delete(o.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)
+	pkgcnd.Delete(o.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)
+
+	var err error
+
+	if o.runHooks(ctx, o.snapshot.Spec.PreSnapshotHooks,
+		vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition) {
+
+		o.snapNode, err = o.takeSnapshot(ctx)
+	} else {
+		o.aborted = true
+	}
+
+	// The post-snapshot hooks are run even if the operation was canceled.
+	o.runHooks(context.WithoutCancel(ctx), o.snapshot.Spec.PostSnapshotHooks,
+		vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)
+
+	return err
+}
+
+// runHooks runs the hooks in order and records their result in the provided
+// condition. It returns false if a hook with the Abort failure policy failed.
+func (o *snapshotHooksOp) runHooks(
+	ctx context.Context,
+	hooks []vmopv1.VirtualMachineSnapshotHook,
+	conditionType string) bool {
+
+	if len(hooks) == 0 {
+		return true
+	}
+
+	if o.vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		pkgcnd.MarkFalse(
+			o.snapshot,
+			conditionType,
+			vmopv1.VirtualMachineSnapshotHooksSkippedReason,
+			"VM is not powered on",
+		)
+		return true
+	}
+
+	var (
+		reason   string
+		messages []string
+	)
+
+	for _, hook := range hooks {
+		o.logger.Info("Running snapshot hook", "hookName", hook.Name, "condition", conditionType)
+
+		err := o.runHook(ctx, hook)
+		if err == nil {
+			continue
+		}
+
+		o.logger.Error(err, "Snapshot hook failed", "hookName", hook.Name)
+
+		hookReason := vmopv1.VirtualMachineSnapshotHookFailedReason
+		if errors.Is(err, context.DeadlineExceeded) {
+			hookReason = vmopv1.VirtualMachineSnapshotHookTimedOutReason
+		}
+		if reason == "" {
+			reason = hookReason
+		}
+		messages = append(messages, fmt.Sprintf("hook %q: %v", hook.Name, err))
+
+		if hook.OnFailure != vmopv1.VirtualMachineSnapshotHookFailurePolicyContinue {
+			pkgcnd.MarkFalse(o.snapshot, conditionType, reason, "%s", strings.Join(messages, "; "))
+			return false
+		}
+	}
+
+	if len(messages) > 0 {
+		pkgcnd.MarkFalse(o.snapshot, conditionType, reason, "%s", strings.Join(messages, "; "))
+	} else {
+		pkgcnd.MarkTrue(o.snapshot, conditionType)
+	}
+
+	return true
+}
+
+// runHook runs a single hook within its timeout.
+func (o *snapshotHooksOp) runHook(
+	ctx context.Context,
+	hook vmopv1.VirtualMachineSnapshotHook) error {
+
+	timeout := defaultSnapshotHookTimeout
+	if hook.Timeout != nil && hook.Timeout.Duration > 0 {
+		timeout = hook.Timeout.Duration
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case hook.Command != nil:
+		return o.runCommandHook(ctx, hook.Command)
+	case hook.HTTP != nil:
+		return runSnapshotHTTPHook(ctx, o.vm, hook.HTTP)
+	default:
+		return errors.New("one of command or http is required")
+	}
+}
+
+// runCommandHook runs the hook's program in the guest. The hook fails if the
+// program exits with a non-zero code.
+func (o *snapshotHooksOp) runCommandHook(
+	ctx context.Context,
+	cmd *vmopv1.VirtualMachineSnapshotHookCommand) error {
+
+	creds, err := o.getCredentials(ctx, cmd.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	spec := providers.GuestProgramSpec{
+		Path:           cmd.Path,
+		Args:           cmd.Args,
+		MaxOutputBytes: snapshotHookMaxOutputBytes,
+	}
+	for _, e := range cmd.Env {
+		spec.Env = append(spec.Env, e.Name+"="+e.Value)
+	}
+
+	result, err := o.runProgram(ctx, creds, spec)
+	if err != nil {
+		return err
+	}
+
+	if result.ExitCode != 0 {
+		if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+			return fmt.Errorf("program exited with code %d: %s", result.ExitCode, stderr)
+		}
+		return fmt.Errorf("program exited with code %d", result.ExitCode)
+	}
+
+	return nil
+}
+
+// getSnapshotHookCredentials returns the guest credentials from the named
+// Secret.
+func getSnapshotHookCredentials(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace, secretName string) (providers.GuestCredentials, error) {
+
+	secret := &corev1.Secret{}
+	objKey := ctrlclient.ObjectKey{Namespace: namespace, Name: secretName}
+	if err := k8sClient.Get(ctx, objKey, secret); err != nil {
+		return providers.GuestCredentials{}, fmt.Errorf("failed to get credentials secret %v: %w", objKey, err)
+	}
+
+	creds := providers.GuestCredentials{
+		Username: string(secret.Data[vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey]),
+		Password: string(secret.Data[vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey]),
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return providers.GuestCredentials{}, fmt.Errorf("credentials secret %s must have the %s and %s keys",
+			objKey.Name,
+			vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey,
+			vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey)
+	}
+
+	return creds, nil
+}
+
+// runSnapshotHTTPHook sends the hook's request to the VM's primary IP
+// address. The hook fails if the response status code is not 2xx.
+func runSnapshotHTTPHook(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	hook *vmopv1.VirtualMachineSnapshotHookHTTP) error {
+
+	var host string
+	if network := vm.Status.Network; network != nil {
+		host = network.PrimaryIP4
+		if host == "" {
+			host = network.PrimaryIP6
+		}
+	}
+	if host == "" {
+		return errors.New("VM does not have a primary IP address")
+	}
+
+	scheme := "http"
+	if hook.Scheme == vmopv1.VirtualMachineSnapshotHookHTTPSchemeHTTPS {
+		scheme = "https"
+	}
+
+	method := hook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	path := hook.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	url := scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(hook.Port))) + path
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if hook.InsecureSkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+	httpClient := &http.Client{Transport: transport}
+	defer httpClient.CloseIdleConnections()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s returned status %s", method, url, resp.Status)
+	}
+
+	return nil
+}
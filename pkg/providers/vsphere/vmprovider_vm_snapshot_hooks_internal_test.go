@@ -0,0 +1,285 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+)
+
+var _ = Describe("runSnapshotHooksOp", func() {
+	var (
+		ctx context.Context
+		op  *snapshotHooksOp
+		err error
+
+		server      *httptest.Server
+		httpMethod  string
+		httpPath    string
+		httpStatus  int
+		programSpec *providers.GuestProgramSpec
+		programCred providers.GuestCredentials
+		programHang bool
+		exitCode    int32
+		snapshotErr error
+		steps       []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		httpMethod, httpPath, httpStatus = "", "", http.StatusOK
+		programSpec, programHang, exitCode = nil, false, 0
+		snapshotErr, steps = nil, nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httpMethod, httpPath = r.Method, r.URL.Path
+			steps = append(steps, "post-hook")
+			w.WriteHeader(httpStatus)
+		}))
+		u, err := url.Parse(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		port, err := strconv.Atoi(u.Port())
+		Expect(err).ToNot(HaveOccurred())
+
+		vm := &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-vm", Namespace: "my-ns"},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+				Network:    &vmopv1.VirtualMachineNetworkStatus{PrimaryIP4: u.Hostname()},
+			},
+		}
+
+		snapshot := &vmopv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-snapshot", Namespace: vm.Namespace},
+			Spec:       vmopv1.VirtualMachineSnapshotSpec{VMName: vm.Name},
+		}
+		snapshot.Spec.PreSnapshotHooks = []vmopv1.VirtualMachineSnapshotHook{
+			{
+				Name: "freeze",
+				Command: &vmopv1.VirtualMachineSnapshotHookCommand{
+					Path:                  "/usr/sbin/fsfreeze",
+					Args:                  []string{"--freeze", "/data"},
+					Env:                   []vmopv1common.NameValuePair{{Name: "FOO", Value: "bar"}},
+					CredentialsSecretName: "guest-creds",
+				},
+			},
+		}
+		snapshot.Spec.PostSnapshotHooks = []vmopv1.VirtualMachineSnapshotHook{
+			{
+				Name: "thaw",
+				HTTP: &vmopv1.VirtualMachineSnapshotHookHTTP{
+					Port: int32(port),
+					Path: "/hooks/thaw",
+				},
+			},
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "guest-creds", Namespace: vm.Namespace},
+			Data: map[string][]byte{
+				vmopv1.VirtualMachineGuestOperationCredentialsUsernameKey: []byte("root"),
+				vmopv1.VirtualMachineGuestOperationCredentialsPasswordKey: []byte("secret"),
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithObjects(secret).Build()
+
+		op = &snapshotHooksOp{
+			logger:   logr.Discard(),
+			vm:       vm,
+			snapshot: snapshot,
+		}
+		op.getCredentials = func(ctx context.Context, secretName string) (providers.GuestCredentials, error) {
+			return getSnapshotHookCredentials(ctx, k8sClient, vm.Namespace, secretName)
+		}
+		op.runProgram = func(
+			ctx context.Context,
+			creds providers.GuestCredentials,
+			spec providers.GuestProgramSpec) (providers.GuestProgramResult, error) {
+
+			programSpec, programCred = &spec, creds
+			steps = append(steps, "pre-hook")
+			if programHang {
+				<-ctx.Done()
+				return providers.GuestProgramResult{}, ctx.Err()
+			}
+			return providers.GuestProgramResult{ExitCode: exitCode, Stderr: []byte("frozen already")}, nil
+		}
+		op.takeSnapshot = func(context.Context) (*vimtypes.VirtualMachineSnapshotTree, error) {
+			steps = append(steps, "snapshot")
+			if snapshotErr != nil {
+				return nil, snapshotErr
+			}
+			return &vimtypes.VirtualMachineSnapshotTree{Name: "my-snapshot"}, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = runSnapshotHooksOp(ctx, op)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("runs the pre-snapshot hooks, takes the snapshot, and runs the post-snapshot hooks", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(steps).To(Equal([]string{"pre-hook", "snapshot", "post-hook"}))
+		Expect(op.snapNode).ToNot(BeNil())
+		Expect(op.aborted).To(BeFalse())
+
+		Expect(programSpec).ToNot(BeNil())
+		Expect(programSpec.Path).To(Equal("/usr/sbin/fsfreeze"))
+		Expect(programSpec.Args).To(Equal([]string{"--freeze", "/data"}))
+		Expect(programSpec.Env).To(Equal([]string{"FOO=bar"}))
+		Expect(programCred).To(Equal(providers.GuestCredentials{Username: "root", Password: "secret"}))
+		Expect(httpMethod).To(Equal(http.MethodPost))
+		Expect(httpPath).To(Equal("/hooks/thaw"))
+
+		Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).To(BeTrue())
+		Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).To(BeTrue())
+	})
+
+	When("a pre-snapshot hook fails", func() {
+		BeforeEach(func() {
+			exitCode = 1
+		})
+
+		It("aborts the snapshot and runs the post-snapshot hooks", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps).To(Equal([]string{"pre-hook", "post-hook"}))
+			Expect(op.aborted).To(BeTrue())
+			Expect(op.snapNode).To(BeNil())
+
+			Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+				To(Equal(vmopv1.VirtualMachineSnapshotHookFailedReason))
+			Expect(pkgcnd.GetMessage(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+				To(Equal(`hook "freeze": program exited with code 1: frozen already`))
+			Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).To(BeTrue())
+		})
+
+		When("the hook's failure policy is Continue", func() {
+			BeforeEach(func() {
+				op.snapshot.Spec.PreSnapshotHooks[0].OnFailure = vmopv1.VirtualMachineSnapshotHookFailurePolicyContinue
+			})
+
+			It("records the failure and takes the snapshot", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(steps).To(Equal([]string{"pre-hook", "snapshot", "post-hook"}))
+				Expect(op.aborted).To(BeFalse())
+				Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+					To(Equal(vmopv1.VirtualMachineSnapshotHookFailedReason))
+			})
+		})
+	})
+
+	When("a pre-snapshot hook times out", func() {
+		BeforeEach(func() {
+			programHang = true
+			op.snapshot.Spec.PreSnapshotHooks[0].Timeout = &metav1.Duration{Duration: 10 * time.Millisecond}
+		})
+
+		It("aborts the snapshot and runs the post-snapshot hooks", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps).To(Equal([]string{"pre-hook", "post-hook"}))
+			Expect(op.aborted).To(BeTrue())
+			Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+				To(Equal(vmopv1.VirtualMachineSnapshotHookTimedOutReason))
+			Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).To(BeTrue())
+		})
+	})
+
+	When("the credentials secret does not exist", func() {
+		BeforeEach(func() {
+			op.snapshot.Spec.PreSnapshotHooks[0].Command.CredentialsSecretName = "missing"
+		})
+
+		It("aborts the snapshot without running the program", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(programSpec).To(BeNil())
+			Expect(op.aborted).To(BeTrue())
+			Expect(pkgcnd.GetMessage(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+				To(ContainSubstring("failed to get credentials secret"))
+			Expect(httpPath).To(Equal("/hooks/thaw"))
+		})
+	})
+
+	When("the snapshot fails", func() {
+		BeforeEach(func() {
+			snapshotErr = errors.New("task failed")
+		})
+
+		It("returns the error and runs the post-snapshot hooks", func() {
+			Expect(err).To(MatchError("task failed"))
+			Expect(steps).To(Equal([]string{"pre-hook", "snapshot", "post-hook"}))
+			Expect(op.aborted).To(BeFalse())
+			Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).To(BeTrue())
+		})
+	})
+
+	When("the operation is canceled", func() {
+		BeforeEach(func() {
+			programHang = true
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		})
+
+		It("runs the post-snapshot hooks", func() {
+			Expect(op.aborted).To(BeTrue())
+			Expect(httpPath).To(Equal("/hooks/thaw"))
+			Expect(pkgcnd.IsTrue(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).To(BeTrue())
+		})
+	})
+
+	When("a post-snapshot hook fails", func() {
+		BeforeEach(func() {
+			httpStatus = http.StatusInternalServerError
+		})
+
+		It("records the failure without affecting the snapshot", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op.snapNode).ToNot(BeNil())
+			Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).
+				To(Equal(vmopv1.VirtualMachineSnapshotHookFailedReason))
+			Expect(pkgcnd.GetMessage(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).
+				To(ContainSubstring("500 Internal Server Error"))
+		})
+	})
+
+	When("the VM is not powered on", func() {
+		BeforeEach(func() {
+			op.vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		})
+
+		It("skips the hooks and takes the snapshot", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps).To(Equal([]string{"snapshot"}))
+			Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+				To(Equal(vmopv1.VirtualMachineSnapshotHooksSkippedReason))
+			Expect(pkgcnd.GetReason(op.snapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).
+				To(Equal(vmopv1.VirtualMachineSnapshotHooksSkippedReason))
+		})
+	})
+})
@@ -5415,6 +5415,101 @@ func vmTests() {
 				})
 			})
 
+			Context("when the snapshot has hooks", func() {
+				JustBeforeEach(func() {
+					snapshot1 = builder.DummyVirtualMachineSnapshot(vm.Namespace, "snapshot-1", vm.Name)
+					snapshot1.Spec.PreSnapshotHooks = []vmopv1.VirtualMachineSnapshotHook{
+						{
+							Name: "freeze",
+							HTTP: &vmopv1.VirtualMachineSnapshotHookHTTP{Port: 8080},
+						},
+					}
+					snapshot1.Spec.PostSnapshotHooks = []vmopv1.VirtualMachineSnapshotHook{
+						{
+							Name: "thaw",
+							HTTP: &vmopv1.VirtualMachineSnapshotHookHTTP{Port: 8080},
+						},
+					}
+
+					o := vmopv1.VirtualMachine{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), &o)).To(Succeed())
+					Expect(controllerutil.SetOwnerReference(&o, snapshot1, ctx.Scheme)).To(Succeed())
+					Expect(ctx.Client.Create(ctx, snapshot1)).To(Succeed())
+				})
+
+				It("should take the snapshot with the hooks in the background", func() {
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					updatedSnapshot := &vmopv1.VirtualMachineSnapshot{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(snapshot1), updatedSnapshot)).To(Succeed())
+					Expect(conditions.GetReason(updatedSnapshot, vmopv1.VirtualMachineSnapshotCreatedCondition)).
+						To(Equal(vmopv1.VirtualMachineSnapshotCreationInProgressReason))
+
+					Eventually(func(g Gomega) {
+						g.Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+						g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(snapshot1), updatedSnapshot)).To(Succeed())
+						g.Expect(conditions.IsTrue(updatedSnapshot, vmopv1.VirtualMachineSnapshotCreatedCondition)).To(BeTrue())
+					}).Should(Succeed())
+
+					// The VM is powered off, so the hooks are skipped.
+					Expect(conditions.GetReason(updatedSnapshot, vmopv1.VirtualMachineSnapshotPreSnapshotHooksCondition)).
+						To(Equal(vmopv1.VirtualMachineSnapshotHooksSkippedReason))
+					Expect(conditions.GetReason(updatedSnapshot, vmopv1.VirtualMachineSnapshotPostSnapshotHooksCondition)).
+						To(Equal(vmopv1.VirtualMachineSnapshotHooksSkippedReason))
+				})
+
+				When("the snapshot was in progress before the operation was lost", func() {
+					JustBeforeEach(func() {
+						conditions.MarkFalse(snapshot1,
+							vmopv1.VirtualMachineSnapshotCreatedCondition,
+							vmopv1.VirtualMachineSnapshotCreationInProgressReason,
+							"snapshot in progress")
+						Expect(ctx.Client.Status().Update(ctx, snapshot1)).To(Succeed())
+					})
+
+					It("should mark the snapshot as failed", func() {
+						Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(MatchError(ContainSubstring("snapshot operation was interrupted")))
+
+						updatedSnapshot := &vmopv1.VirtualMachineSnapshot{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(snapshot1), updatedSnapshot)).To(Succeed())
+						Expect(conditions.GetReason(updatedSnapshot, vmopv1.VirtualMachineSnapshotCreatedCondition)).
+							To(Equal(vmopv1.VirtualMachineSnapshotCreationFailedReason))
+					})
+				})
+			})
+
+			Context("when the oldest snapshot was aborted by a pre-snapshot hook", func() {
+				It("should skip the aborted snapshot", func() {
+					o := vmopv1.VirtualMachine{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), &o)).To(Succeed())
+
+					snapshot1 = builder.DummyVirtualMachineSnapshot(vm.Namespace, "snapshot-1", vm.Name)
+					snapshot1.CreationTimestamp = metav1.NewTime(time.Now())
+					conditions.MarkFalse(snapshot1,
+						vmopv1.VirtualMachineSnapshotCreatedCondition,
+						vmopv1.VirtualMachineSnapshotCreationAbortedReason,
+						"aborted")
+					Expect(controllerutil.SetOwnerReference(&o, snapshot1, ctx.Scheme)).To(Succeed())
+					Expect(ctx.Client.Create(ctx, snapshot1)).To(Succeed())
+
+					snapshot2 = builder.DummyVirtualMachineSnapshot(vm.Namespace, "snapshot-2", vm.Name)
+					snapshot2.CreationTimestamp = metav1.NewTime(time.Now().Add(1 * time.Second))
+					Expect(controllerutil.SetOwnerReference(&o, snapshot2, ctx.Scheme)).To(Succeed())
+					Expect(ctx.Client.Create(ctx, snapshot2)).To(Succeed())
+
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					updatedSnapshot1 := &vmopv1.VirtualMachineSnapshot{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(snapshot1), updatedSnapshot1)).To(Succeed())
+					Expect(conditions.GetReason(updatedSnapshot1, vmopv1.VirtualMachineSnapshotCreatedCondition)).
+						To(Equal(vmopv1.VirtualMachineSnapshotCreationAbortedReason))
+
+					updatedSnapshot2 := &vmopv1.VirtualMachineSnapshot{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(snapshot2), updatedSnapshot2)).To(Succeed())
+					Expect(conditions.IsTrue(updatedSnapshot2, vmopv1.VirtualMachineSnapshotCreatedCondition)).To(BeTrue())
+				})
+			})
+
 			Context("when snapshot is being deleted", func() {
 				It("should skip all snapshot creation due to vSphere constraint", func() {
 					// Mark snapshot1 as being deleted
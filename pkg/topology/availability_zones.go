@@ -22,6 +22,10 @@ var (
 	ErrNoZones = errors.New("no zones in specified namespace")
 )
 
+// ZoneCordonedAnnotation may be set on a Zone to drain it as if it were being
+// deleted. No new VMs are placed in a cordoned zone.
+const ZoneCordonedAnnotation = "vmoperator.vmware.com/cordoned"
+
 // +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=availabilityzones,verbs=get;list;watch
 // +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=availabilityzones/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=zones,verbs=get;list;watch
@@ -230,3 +234,13 @@ func GetZone(
 	err := client.Get(ctx, ctrlclient.ObjectKey{Name: zoneName, Namespace: namespace}, &zone)
 	return zone, err
 }
+
+// IsZoneDraining returns true if the Zone is being deleted or is cordoned, in
+// which case no new VMs are placed in it and its VMs are drained.
+func IsZoneDraining(zone topologyv1.Zone) bool {
+	if !zone.DeletionTimestamp.IsZero() {
+		return true
+	}
+	_, ok := zone.Annotations[ZoneCordonedAnnotation]
+	return ok
+}
@@ -18,6 +18,7 @@ import (
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -473,3 +474,22 @@ var _ = Describe("Availability Zones and Zones", func() {
 		})
 	})
 })
+
+var _ = DescribeTable("IsZoneDraining",
+	func(deleting bool, annotations map[string]string, expected bool) {
+		zone := topologyv1.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-zone",
+				Annotations: annotations,
+			},
+		}
+		if deleting {
+			zone.DeletionTimestamp = ptr.To(metav1.Now())
+		}
+		Expect(topology.IsZoneDraining(zone)).To(Equal(expected))
+	},
+	Entry("zone is not deleted or cordoned", false, nil, false),
+	Entry("zone is being deleted", true, nil, true),
+	Entry("zone is cordoned", false, map[string]string{topology.ZoneCordonedAnnotation: ""}, true),
+	Entry("zone has other annotations", false, map[string]string{"foo": "bar"}, false),
+)
@@ -0,0 +1,46 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// AttrNamespace is the span attribute for an object's namespace.
+	AttrNamespace = attribute.Key("k8s.namespace.name")
+
+	// AttrName is the span attribute for an object's name.
+	AttrName = attribute.Key("k8s.object.name")
+
+	// AttrController is the span attribute for a controller's name.
+	AttrController = attribute.Key("vmoperator.controller")
+)
+
+// Reconciler returns a reconciler that starts a span for each call to the
+// provided reconciler's Reconcile function. The span is available from the
+// context passed to the wrapped reconciler.
+func Reconciler(
+	controllerName string,
+	r reconcile.Reconciler) reconcile.Reconciler {
+
+	return reconcile.Func(func(
+		ctx context.Context,
+		req reconcile.Request) (_ reconcile.Result, retErr error) {
+
+		ctx, span := Start(
+			ctx,
+			controllerName+".Reconcile",
+			AttrController.String(controllerName),
+			AttrNamespace.String(req.Namespace),
+			AttrName.String(req.Name))
+		defer func() { End(span, retErr) }()
+
+		return r.Reconcile(ctx, req)
+	})
+}
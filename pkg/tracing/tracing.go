@@ -0,0 +1,114 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/vm-operator/pkg"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+const (
+	// TracerName is the name of the tracer used to create VM Operator spans.
+	TracerName = "github.com/vmware-tanzu/vm-operator"
+
+	// ServiceName is the name of the service reported with exported spans.
+	ServiceName = "vm-operator"
+)
+
+// ShutdownFunc flushes any buffered spans and stops the exporter.
+type ShutdownFunc func(context.Context) error
+
+// Init configures the global tracer provider from the tracing configuration
+// in the provided context. The returned function must be called to flush any
+// remaining spans before the process exits.
+//
+// Tracing is disabled and a no-op function is returned if the OTLP endpoint
+// is not configured.
+func Init(ctx context.Context) (ShutdownFunc, error) {
+	cfg := pkgcfg.FromContext(ctx).Tracing
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	tp := NewTracerProvider(
+		sdktrace.NewBatchSpanProcessor(exporter),
+		cfg.SamplingRatio)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// NewTracerProvider returns a new tracer provider that samples the given ratio
+// of root spans and sends them to the provided span processor.
+func NewTracerProvider(
+	processor sdktrace.SpanProcessor,
+	samplingRatio float64) *sdktrace.TracerProvider {
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(pkg.BuildVersion),
+		)),
+	)
+}
+
+// Start creates a span and a context that contains the span. The span is a
+// child of any span already in the provided context.
+func Start(
+	ctx context.Context,
+	spanName string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	return otel.Tracer(TracerName).Start(
+		ctx,
+		spanName,
+		trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and ends the span. It is meant
+// to be deferred with a named error return value:
+//
+//	ctx, span := tracing.Start(ctx, "Foo")
+//	defer func() { tracing.End(span, retErr) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,152 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		ctx      context.Context
+		exporter *tracetest.InMemoryExporter
+		tp       *sdktrace.TracerProvider
+		prevTP   trace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		tp = pkgtracing.NewTracerProvider(
+			sdktrace.NewSimpleSpanProcessor(exporter),
+			1.0)
+		prevTP = otel.GetTracerProvider()
+		otel.SetTracerProvider(tp)
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(prevTP)
+		Expect(tp.Shutdown(ctx)).To(Succeed())
+	})
+
+	Describe("Init", func() {
+		When("the OTLP endpoint is not configured", func() {
+			It("should return a no-op shutdown function", func() {
+				shutdown, err := pkgtracing.Init(
+					pkgcfg.NewContextWithDefaultConfig())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(shutdown).ToNot(BeNil())
+				Expect(shutdown(ctx)).To(Succeed())
+				Expect(otel.GetTracerProvider()).To(BeIdenticalTo(tp))
+			})
+		})
+	})
+
+	Describe("Start and End", func() {
+		It("should record a span", func() {
+			_, span := pkgtracing.Start(
+				ctx, "Foo", pkgtracing.AttrName.String("bar"))
+			pkgtracing.End(span, nil)
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("Foo"))
+			Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+			Expect(spans[0].Attributes).To(ContainElement(
+				pkgtracing.AttrName.String("bar")))
+		})
+
+		It("should record an error", func() {
+			_, span := pkgtracing.Start(ctx, "Foo")
+			pkgtracing.End(span, errors.New("boom"))
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			Expect(spans[0].Status.Description).To(Equal("boom"))
+			Expect(spans[0].Events).To(HaveLen(1))
+		})
+
+		It("should create child spans", func() {
+			ctx, parent := pkgtracing.Start(ctx, "Parent")
+			_, child := pkgtracing.Start(ctx, "Child")
+			pkgtracing.End(child, nil)
+			pkgtracing.End(parent, nil)
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("Child"))
+			Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+		})
+	})
+
+	Describe("NewTracerProvider", func() {
+		When("the sampling ratio is zero", func() {
+			BeforeEach(func() {
+				tp = pkgtracing.NewTracerProvider(
+					sdktrace.NewSimpleSpanProcessor(exporter),
+					0)
+				otel.SetTracerProvider(tp)
+			})
+
+			It("should not sample root spans", func() {
+				_, span := pkgtracing.Start(ctx, "Foo")
+				pkgtracing.End(span, nil)
+				Expect(exporter.GetSpans()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Reconciler", func() {
+		var (
+			req         reconcile.Request
+			reconcileFn reconcile.Func
+			spanCtx     trace.SpanContext
+		)
+
+		BeforeEach(func() {
+			req = reconcile.Request{}
+			req.Namespace = "my-namespace"
+			req.Name = "my-name"
+			reconcileFn = func(
+				ctx context.Context,
+				_ reconcile.Request) (reconcile.Result, error) {
+
+				spanCtx = trace.SpanContextFromContext(ctx)
+				return reconcile.Result{}, errors.New("boom")
+			}
+		})
+
+		It("should record a span for each reconcile", func() {
+			_, err := pkgtracing.Reconciler("my-controller", reconcileFn).
+				Reconcile(ctx, req)
+			Expect(err).To(MatchError("boom"))
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("my-controller.Reconcile"))
+			Expect(spans[0].SpanContext).To(Equal(spanCtx))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			Expect(spans[0].Attributes).To(ContainElements(
+				pkgtracing.AttrController.String("my-controller"),
+				pkgtracing.AttrNamespace.String("my-namespace"),
+				pkgtracing.AttrName.String("my-name")))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asyncop tracks the operations a controller runs in the background,
+// ex. transferring an image, while the objects for which they are run are
+// requeued until the operations are done.
+//
+// Operations are only tracked in memory. A controller must persist that an
+// operation was started before starting it, so it can detect an operation
+// that was lost, ex. because the pod restarted, with Tracker.IsLost.
+package asyncop
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+)
+
+// Op is an operation that is run in the background.
+type Op[T any] struct {
+	value  T
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// err may only be read once done is closed.
+	err error
+}
+
+// Value returns the value the operation was started with. Fields of the
+// value that are written by the operation may only be read once the
+// operation is done.
+func (o *Op[T]) Value() T {
+	return o.value
+}
+
+// Done returns a channel that is closed when the operation returns.
+func (o *Op[T]) Done() <-chan struct{} {
+	return o.done
+}
+
+// IsDone returns true if the operation has returned.
+func (o *Op[T]) IsDone() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Err returns the error returned by the operation. It may only be called
+// once the operation is done.
+func (o *Op[T]) Err() error {
+	return o.err
+}
+
+// Func is the function run by an operation. The value is the one the
+// operation was started with.
+type Func[T any] func(ctx context.Context, value T) error
+
+// Tracker tracks the operations that are run in the background for the
+// objects reconciled by a controller.
+type Tracker[T any] struct {
+	onDone func(context.Context, types.NamespacedName)
+
+	mu  sync.Mutex
+	ops map[types.NamespacedName]*Op[T]
+}
+
+// NewTracker returns a new Tracker. If onDone is not nil, it is called with
+// the context and key of an operation once the operation returns, ex. to
+// reconcile the object again. The context has the values of the one the
+// operation was started with, but is already canceled.
+func NewTracker[T any](onDone func(context.Context, types.NamespacedName)) *Tracker[T] {
+	return &Tracker[T]{
+		onDone: onDone,
+		ops:    map[types.NamespacedName]*Op[T]{},
+	}
+}
+
+// Get returns the operation for the key, or nil if there is none.
+func (t *Tracker[T]) Get(key types.NamespacedName) *Op[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ops[key]
+}
+
+// IsLost returns true if an operation was started for the key, as recorded
+// by the caller, but the tracker does not have it, ex. because the pod
+// restarted.
+func (t *Tracker[T]) IsLost(key types.NamespacedName, started bool) bool {
+	return started && t.Get(key) == nil
+}
+
+// Start runs fn in the background for the key. The operation outlives the
+// provided context, so its context is only canceled with Cancel. If there is
+// already an operation for the key, that operation is returned instead.
+func (t *Tracker[T]) Start(
+	ctx context.Context,
+	key types.NamespacedName,
+	value T,
+	fn Func[T]) *Op[T] {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if op, ok := t.ops[key]; ok {
+		return op
+	}
+
+	op := &Op[T]{
+		value: value,
+		done:  make(chan struct{}),
+	}
+
+	var opCtx context.Context
+	opCtx, op.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	t.ops[key] = op
+
+	go func() {
+		defer func() {
+			op.cancel()
+			close(op.done)
+			if t.onDone != nil {
+				t.onDone(opCtx, key)
+			}
+		}()
+		op.err = fn(opCtx, value)
+	}()
+
+	return op
+}
+
+// Forget stops tracking the operation for the key, if any, without
+// canceling it. It is called once the result of a done operation has been
+// processed.
+func (t *Tracker[T]) Forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.ops, key)
+}
+
+// Cancel cancels the operation for the key, if any, and stops tracking it.
+// It is called when the object the operation was run for is deleted.
+func (t *Tracker[T]) Cancel(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if op, ok := t.ops[key]; ok {
+		op.cancel()
+		delete(t.ops, key)
+	}
+}
+
+// ReconcileOnDone returns a function for NewTracker that reconciles the
+// object for the key once its operation is done. The object is sent to the
+// cource channel for the kind, which the controller must watch.
+func ReconcileOnDone(
+	kind string,
+	newObj func() client.Object) func(context.Context, types.NamespacedName) {
+
+	return func(ctx context.Context, key types.NamespacedName) {
+		obj := newObj()
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		cource.FromContextWithBuffer(ctx, kind, 100) <- event.GenericEvent{
+			Object: obj,
+		}
+	}
+}
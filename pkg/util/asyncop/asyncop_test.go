@@ -0,0 +1,167 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package asyncop_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/asyncop"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+)
+
+type state struct {
+	result string
+}
+
+var _ = Describe("Tracker", func() {
+	var (
+		ctx     context.Context
+		key     types.NamespacedName
+		doneCh  chan types.NamespacedName
+		tracker *asyncop.Tracker[*state]
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		key = types.NamespacedName{Namespace: "ns", Name: "name"}
+		ch := make(chan types.NamespacedName, 1)
+		doneCh = ch
+		tracker = asyncop.NewTracker[*state](func(_ context.Context, key types.NamespacedName) {
+			select {
+			case ch <- key:
+			default:
+			}
+		})
+	})
+
+	It("should not have an operation for an unknown key", func() {
+		Expect(tracker.Get(key)).To(BeNil())
+	})
+
+	It("should run the operation in the background", func() {
+		release := make(chan struct{})
+		op := tracker.Start(ctx, key, &state{}, func(_ context.Context, s *state) error {
+			<-release
+			s.result = "done"
+			return nil
+		})
+
+		Expect(tracker.Get(key)).To(BeIdenticalTo(op))
+		Expect(op.IsDone()).To(BeFalse())
+
+		close(release)
+		Eventually(op.Done()).Should(BeClosed())
+		Expect(op.IsDone()).To(BeTrue())
+		Expect(op.Err()).ToNot(HaveOccurred())
+		Expect(op.Value().result).To(Equal("done"))
+		Eventually(doneCh).Should(Receive(Equal(key)))
+
+		// The operation is tracked until it is forgotten.
+		Expect(tracker.Get(key)).To(BeIdenticalTo(op))
+		tracker.Forget(key)
+		Expect(tracker.Get(key)).To(BeNil())
+	})
+
+	It("should return the error of the operation", func() {
+		op := tracker.Start(ctx, key, &state{}, func(_ context.Context, _ *state) error {
+			return errors.New("fake")
+		})
+		Eventually(op.Done()).Should(BeClosed())
+		Expect(op.Err()).To(MatchError("fake"))
+	})
+
+	It("should not start a second operation for the same key", func() {
+		release := make(chan struct{})
+		defer close(release)
+
+		op1 := tracker.Start(ctx, key, &state{}, func(_ context.Context, _ *state) error {
+			<-release
+			return nil
+		})
+		op2 := tracker.Start(ctx, key, &state{}, func(_ context.Context, _ *state) error {
+			Fail("second operation was started")
+			return nil
+		})
+		Expect(op2).To(BeIdenticalTo(op1))
+	})
+
+	It("should not cancel the operation when the start context is canceled", func() {
+		startCtx, cancel := context.WithCancel(ctx)
+		release := make(chan struct{})
+
+		var opCtx context.Context
+		op := tracker.Start(startCtx, key, &state{}, func(ctx context.Context, _ *state) error {
+			opCtx = ctx
+			<-release
+			return ctx.Err()
+		})
+		cancel()
+		close(release)
+
+		Eventually(op.Done()).Should(BeClosed())
+		Expect(op.Err()).ToNot(HaveOccurred())
+		Expect(opCtx).ToNot(BeNil())
+	})
+
+	It("should cancel the operation and stop tracking it", func() {
+		op := tracker.Start(ctx, key, &state{}, func(ctx context.Context, _ *state) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		tracker.Cancel(key)
+		Expect(tracker.Get(key)).To(BeNil())
+		Eventually(op.Done()).Should(BeClosed())
+		Expect(op.Err()).To(MatchError(context.Canceled))
+	})
+
+	Context("IsLost", func() {
+		It("should return true if the operation was started but is not tracked", func() {
+			Expect(tracker.IsLost(key, true)).To(BeTrue())
+		})
+
+		It("should return false if the operation was not started", func() {
+			Expect(tracker.IsLost(key, false)).To(BeFalse())
+		})
+
+		It("should return false if the operation is tracked", func() {
+			release := make(chan struct{})
+			defer close(release)
+			tracker.Start(ctx, key, &state{}, func(_ context.Context, _ *state) error {
+				<-release
+				return nil
+			})
+			Expect(tracker.IsLost(key, true)).To(BeFalse())
+		})
+	})
+
+	Context("ReconcileOnDone", func() {
+		It("should send the object to the channel for the kind", func() {
+			ctx = cource.WithContext(ctx)
+			tracker = asyncop.NewTracker[*state](asyncop.ReconcileOnDone(
+				"VirtualMachine",
+				func() client.Object { return &vmopv1.VirtualMachine{} }))
+
+			tracker.Start(ctx, key, &state{}, func(_ context.Context, _ *state) error {
+				return nil
+			})
+
+			var e event.GenericEvent
+			Eventually(cource.FromContext(ctx, "VirtualMachine")).Should(Receive(&e))
+			Expect(e.Object).To(BeAssignableToTypeOf(&vmopv1.VirtualMachine{}))
+			Expect(e.Object.GetNamespace()).To(Equal(key.Namespace))
+			Expect(e.Object.GetName()).To(Equal(key.Name))
+		})
+	})
+})
@@ -0,0 +1,221 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears is how far into the future Next searches for a time that
+// matches a schedule, ex. 0 0 30 2 * never matches.
+const maxSearchYears = 5
+
+// Schedule is a parsed, standard five-field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be a *, a value, a range (1-5), a step (*/15 or 1-30/5), or
+// a comma-separated list of any of them. The month and day-of-week fields
+// also accept the three-letter, case-insensitive names of the months and days,
+// ex. JAN or MON. Sunday is both 0 and 7.
+//
+// As with cron, when both the day-of-month and day-of-week fields are
+// restricted, a time matches the schedule if it matches either of them.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	domStar, dowStar bool
+}
+
+type fieldBounds struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteBounds = fieldBounds{name: "minute", min: 0, max: 59}
+	hourBounds   = fieldBounds{name: "hour", min: 0, max: 23}
+	domBounds    = fieldBounds{name: "day-of-month", min: 1, max: 31}
+	monthBounds  = fieldBounds{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = fieldBounds{name: "day-of-week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse returns the Schedule for the given cron expression.
+func Parse(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf(
+			"expected 5 fields, found %d: %q", len(fields), expr)
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	// Sunday is both 0 and 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+
+	return s, nil
+}
+
+func parseField(field string, b fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		v, err := parseRange(part, b)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", b.name, field, err)
+		}
+		bits |= v
+	}
+	return bits, nil
+}
+
+func parseRange(part string, b fieldBounds) (uint64, error) {
+	var (
+		rangeAndStep = strings.SplitN(part, "/", 2)
+		lowAndHigh   = strings.SplitN(rangeAndStep[0], "-", 2)
+		low, high    uint
+		step         uint = 1
+		err          error
+	)
+
+	switch {
+	case lowAndHigh[0] == "*" || lowAndHigh[0] == "?":
+		if len(lowAndHigh) > 1 {
+			return 0, fmt.Errorf("unexpected range after %q", lowAndHigh[0])
+		}
+		low, high = b.min, b.max
+	default:
+		if low, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		high = low
+		if len(lowAndHigh) > 1 {
+			if high, err = parseValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if len(rangeAndStep) > 1 {
+		v, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || v == 0 {
+			return 0, fmt.Errorf("invalid step %q", rangeAndStep[1])
+		}
+		step = uint(v)
+		if len(lowAndHigh) == 1 && lowAndHigh[0] != "*" && lowAndHigh[0] != "?" {
+			// N/step means N through the maximum value.
+			high = b.max
+		}
+	}
+
+	if low > high {
+		return 0, fmt.Errorf("start %d is greater than end %d", low, high)
+	}
+
+	var bits uint64
+	for i := low; i <= high; i += step {
+		bits |= 1 << i
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b fieldBounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if uint(v) < b.min || uint(v) > b.max {
+		return 0, fmt.Errorf(
+			"value %d is not between %d and %d", v, b.min, b.max)
+	}
+	return uint(v), nil
+}
+
+// Next returns the first time after t that matches the schedule, in t's
+// location. The zero time is returned if no time matches the schedule within
+// the next five years.
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// Start at the beginning of the next minute.
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second -
+		time.Duration(t.Nanosecond()))
+
+	yearLimit := t.Year() + maxSearchYears
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// Skip the repeated hour at the end of daylight saving
+				// time.
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	var (
+		domMatch = s.dom&(1<<uint(t.Day())) != 0
+		dowMatch = s.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
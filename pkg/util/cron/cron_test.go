@@ -0,0 +1,104 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cron_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+)
+
+var _ = Describe("Parse", func() {
+	DescribeTable("valid expressions",
+		func(expr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).ToNot(HaveOccurred())
+		},
+		Entry("every minute", "* * * * *"),
+		Entry("values", "30 19 1 12 5"),
+		Entry("ranges and steps", "*/15 8-18/2 1-10 * 1-5"),
+		Entry("lists", "0,30 7,19 * * *"),
+		Entry("names", "0 7 * jan-MAR mon,Wed,FRI"),
+		Entry("sunday as 7", "0 0 * * 7"),
+		Entry("value and step", "5/10 * * * *"),
+	)
+
+	DescribeTable("invalid expressions",
+		func(expr, expectedErr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("too few fields", "* * * *", "expected 5 fields, found 4"),
+		Entry("too many fields", "* * * * * *", "expected 5 fields, found 6"),
+		Entry("minute out of range", "60 * * * *", `invalid minute "60": value 60 is not between 0 and 59`),
+		Entry("day-of-month out of range", "* * 0 * *", `invalid day-of-month "0"`),
+		Entry("invalid name", "* * * * foo", `invalid day-of-week "foo": invalid value "foo"`),
+		Entry("reversed range", "* 18-8 * * *", "start 18 is greater than end 8"),
+		Entry("zero step", "*/0 * * * *", `invalid step "0"`),
+		Entry("range after star", "*-5 * * * *", "unexpected range"),
+	)
+})
+
+var _ = Describe("Next", func() {
+	var (
+		utc = time.UTC
+	)
+
+	next := func(expr string, t time.Time) time.Time {
+		s, err := cron.Parse(expr)
+		Expect(err).ToNot(HaveOccurred())
+		return s.Next(t)
+	}
+
+	It("returns the next minute", func() {
+		Expect(next("* * * * *", time.Date(2026, 1, 1, 10, 0, 30, 0, utc))).To(
+			Equal(time.Date(2026, 1, 1, 10, 1, 0, 0, utc)))
+	})
+
+	It("returns a time after the given time", func() {
+		Expect(next("0 19 * * *", time.Date(2026, 1, 1, 19, 0, 0, 0, utc))).To(
+			Equal(time.Date(2026, 1, 2, 19, 0, 0, 0, utc)))
+	})
+
+	It("returns the next weekday", func() {
+		// 2026-01-02 is a Friday.
+		Expect(next("0 7 * * mon-fri", time.Date(2026, 1, 2, 8, 0, 0, 0, utc))).To(
+			Equal(time.Date(2026, 1, 5, 7, 0, 0, 0, utc)))
+	})
+
+	It("matches either the day-of-month or the day-of-week", func() {
+		// 2026-01-04 is a Sunday.
+		Expect(next("0 0 15 * 0", time.Date(2026, 1, 1, 0, 0, 0, 0, utc))).To(
+			Equal(time.Date(2026, 1, 4, 0, 0, 0, 0, utc)))
+	})
+
+	It("returns the next month", func() {
+		Expect(next("0 0 1 mar *", time.Date(2026, 1, 15, 0, 0, 0, 0, utc))).To(
+			Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, utc)))
+	})
+
+	It("returns the time in the given location", func() {
+		loc, err := time.LoadLocation("America/New_York")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next("0 19 * * *", time.Date(2026, 7, 1, 12, 0, 0, 0, loc))).To(
+			Equal(time.Date(2026, 7, 1, 19, 0, 0, 0, loc)))
+	})
+
+	It("skips the hour that does not exist at the start of daylight saving time", func() {
+		loc, err := time.LoadLocation("America/New_York")
+		Expect(err).ToNot(HaveOccurred())
+		// 2026-03-08 02:00 does not exist in New York.
+		t := next("30 * * * *", time.Date(2026, 3, 8, 1, 45, 0, 0, loc))
+		Expect(t.Hour()).To(Equal(3))
+		Expect(t.Minute()).To(Equal(30))
+	})
+
+	It("returns the zero time when no time matches", func() {
+		Expect(next("0 0 30 2 *", time.Date(2026, 1, 1, 0, 0, 0, 0, utc))).To(BeZero())
+	})
+})
@@ -0,0 +1,93 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// IPRange is an inclusive range of IP addresses.
+type IPRange struct {
+	First netip.Addr
+	Last  netip.Addr
+}
+
+// ParseIPRange parses a single IP address, an inclusive range of IP addresses
+// such as "192.168.0.10-192.168.0.50", or a network in CIDR notation. The first
+// address of a network, and for IP4 networks the last address of the network,
+// are excluded from the range since they are not usable by hosts.
+func ParseIPRange(s string) (IPRange, error) {
+	if first, last, ok := strings.Cut(s, "-"); ok {
+		f, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid IP address %q", first)
+		}
+		l, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid IP address %q", last)
+		}
+		f, l = f.Unmap(), l.Unmap()
+		if f.Is4() != l.Is4() {
+			return IPRange{}, fmt.Errorf("range %q mixes IP address families", s)
+		}
+		if l.Less(f) {
+			return IPRange{}, fmt.Errorf("range %q ends before it starts", s)
+		}
+		return IPRange{First: f, Last: l}, nil
+	}
+
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid network %q", s)
+		}
+		p = p.Masked()
+
+		first := p.Addr()
+		last := lastAddr(p)
+		if p.Bits() < first.BitLen()-1 {
+			first = first.Next()
+			if first.Is4() {
+				last = last.Prev()
+			}
+		}
+		return IPRange{First: first, Last: last}, nil
+	}
+
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IP address %q", s)
+	}
+	a = a.Unmap()
+	return IPRange{First: a, Last: a}, nil
+}
+
+// lastAddr returns the last address of the network p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
+
+// NextFreeIP returns the first address in the ranges for which inUse returns
+// false.
+func NextFreeIP(
+	ranges []IPRange,
+	inUse func(netip.Addr) bool) (netip.Addr, bool) {
+
+	for _, r := range ranges {
+		for a := r.First; a.IsValid() && !r.Last.Less(a); a = a.Next() {
+			if !inUse(a) {
+				return a, true
+			}
+		}
+	}
+	return netip.Addr{}, false
+}
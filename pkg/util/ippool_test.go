@@ -0,0 +1,93 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var _ = DescribeTable("ParseIPRange",
+	func(s, expectedFirst, expectedLast, expectedErr string) {
+		r, err := util.ParseIPRange(s)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.First.String()).To(Equal(expectedFirst))
+		Expect(r.Last.String()).To(Equal(expectedLast))
+	},
+	Entry("ip4 address", "192.168.0.10", "192.168.0.10", "192.168.0.10", ""),
+	Entry("ip6 address", "2001:db8::a", "2001:db8::a", "2001:db8::a", ""),
+	Entry("ip4 range", "192.168.0.10-192.168.0.50", "192.168.0.10", "192.168.0.50", ""),
+	Entry("ip4 range with spaces", "192.168.0.10 - 192.168.0.50", "192.168.0.10", "192.168.0.50", ""),
+	Entry("ip6 range", "2001:db8::a-2001:db8::ff", "2001:db8::a", "2001:db8::ff", ""),
+	Entry("ip4 network", "192.168.0.0/24", "192.168.0.1", "192.168.0.254", ""),
+	Entry("ip4 network with host bits", "192.168.0.7/24", "192.168.0.1", "192.168.0.254", ""),
+	Entry("ip4 /31 network", "192.168.0.0/31", "192.168.0.0", "192.168.0.1", ""),
+	Entry("ip4 /32 network", "192.168.0.1/32", "192.168.0.1", "192.168.0.1", ""),
+	Entry("ip6 network", "2001:db8::/120", "2001:db8::1", "2001:db8::ff", ""),
+	Entry("invalid address", "192.168.0", "", "", "invalid IP address"),
+	Entry("invalid network", "192.168.0.0/33", "", "", "invalid network"),
+	Entry("invalid range", "192.168.0.10-192.168.0", "", "", "invalid IP address"),
+	Entry("range mixing families", "192.168.0.10-2001:db8::a", "", "", "mixes IP address families"),
+	Entry("range ending before it starts", "192.168.0.50-192.168.0.10", "", "", "ends before it starts"),
+)
+
+var _ = Describe("NextFreeIP", func() {
+	var (
+		ranges []util.IPRange
+		used   map[netip.Addr]struct{}
+	)
+
+	inUse := func(a netip.Addr) bool {
+		_, ok := used[a]
+		return ok
+	}
+
+	BeforeEach(func() {
+		r1, err := util.ParseIPRange("192.168.0.10-192.168.0.11")
+		Expect(err).ToNot(HaveOccurred())
+		r2, err := util.ParseIPRange("192.168.0.20")
+		Expect(err).ToNot(HaveOccurred())
+		ranges = []util.IPRange{r1, r2}
+		used = map[netip.Addr]struct{}{}
+	})
+
+	It("should return the first address", func() {
+		a, ok := util.NextFreeIP(ranges, inUse)
+		Expect(ok).To(BeTrue())
+		Expect(a.String()).To(Equal("192.168.0.10"))
+	})
+
+	It("should skip the addresses that are in use", func() {
+		used[netip.MustParseAddr("192.168.0.10")] = struct{}{}
+		used[netip.MustParseAddr("192.168.0.11")] = struct{}{}
+		a, ok := util.NextFreeIP(ranges, inUse)
+		Expect(ok).To(BeTrue())
+		Expect(a.String()).To(Equal("192.168.0.20"))
+	})
+
+	It("should return false when all of the addresses are in use", func() {
+		used[netip.MustParseAddr("192.168.0.10")] = struct{}{}
+		used[netip.MustParseAddr("192.168.0.11")] = struct{}{}
+		used[netip.MustParseAddr("192.168.0.20")] = struct{}{}
+		_, ok := util.NextFreeIP(ranges, inUse)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not overflow at the end of the address space", func() {
+		r, err := util.ParseIPRange("255.255.255.255")
+		Expect(err).ToNot(HaveOccurred())
+		used[netip.MustParseAddr("255.255.255.255")] = struct{}{}
+		_, ok := util.NextFreeIP([]util.IPRange{r}, inUse)
+		Expect(ok).To(BeFalse())
+	})
+})
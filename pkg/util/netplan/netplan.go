@@ -28,6 +28,22 @@ type Address = schema.AddressMapping
 
 type Renderer = schema.Renderer
 
+type Bond = schema.BondConfig
+
+type BondParameters = schema.BondParameters
+
+type BondMode = schema.BondMode
+
+type LACPRate = schema.LACPRate
+
+type TransmitHashPolicy = schema.TransmitHashPolicy
+
+type VLAN = schema.VLANConfig
+
+type Bridge = schema.BridgeConfig
+
+type BridgeParameters = schema.BridgeParameters
+
 func MarshalYAML(in Config) ([]byte, error) {
 	return yaml.Marshal(in)
 }
@@ -0,0 +1,89 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+)
+
+// IsVirtualMachineAvailable returns true if the VM is powered on and, when it
+// has a readiness probe, its Ready condition is true.
+func IsVirtualMachineAvailable(vm vmopv1.VirtualMachine) bool {
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		return false
+	}
+	if vm.Spec.ReadinessProbe != nil {
+		return conditions.IsTrue(&vm, vmopv1.ReadyConditionType)
+	}
+	return true
+}
+
+// GetExceededDisruptionBudget returns the name of a
+// VirtualMachineDisruptionBudget in the VM's namespace that selects the VM and
+// would be exceeded if the VM were disrupted. An empty string is returned if
+// the VM may be disrupted.
+func GetExceededDisruptionBudget(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) (string, error) {
+
+	var budgets vmopv1.VirtualMachineDisruptionBudgetList
+	if err := k8sClient.List(ctx, &budgets, ctrlclient.InNamespace(vm.Namespace)); err != nil {
+		return "", fmt.Errorf("failed to list VirtualMachineDisruptionBudgets: %w", err)
+	}
+	if len(budgets.Items) == 0 {
+		return "", nil
+	}
+
+	var vms *vmopv1.VirtualMachineList
+
+	for _, budget := range budgets.Items {
+		selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid selector for VirtualMachineDisruptionBudget %s: %w", budget.Name, err)
+		}
+		if !selector.Matches(labels.Set(vm.Labels)) {
+			continue
+		}
+
+		maxUnavailable := int32(1)
+		if budget.Spec.MaxUnavailable != nil {
+			maxUnavailable = *budget.Spec.MaxUnavailable
+		}
+
+		if vms == nil {
+			vms = &vmopv1.VirtualMachineList{}
+			if err := k8sClient.List(ctx, vms, ctrlclient.InNamespace(vm.Namespace)); err != nil {
+				return "", fmt.Errorf("failed to list VMs: %w", err)
+			}
+		}
+
+		// The VM being disrupted is always counted as unavailable.
+		unavailable := int32(1)
+		for i := range vms.Items {
+			other := vms.Items[i]
+			if other.Name == vm.Name || !selector.Matches(labels.Set(other.Labels)) {
+				continue
+			}
+			if !IsVirtualMachineAvailable(other) {
+				unavailable++
+			}
+		}
+
+		if unavailable > maxUnavailable {
+			return budget.Name, nil
+		}
+	}
+
+	return "", nil
+}
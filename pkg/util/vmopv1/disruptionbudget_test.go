@@ -0,0 +1,160 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("IsVirtualMachineAvailable", func() {
+	var vm vmopv1.VirtualMachine
+
+	BeforeEach(func() {
+		vm = vmopv1.VirtualMachine{}
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+	})
+
+	It("returns true for a powered on VM", func() {
+		Expect(vmopv1util.IsVirtualMachineAvailable(vm)).To(BeTrue())
+	})
+
+	It("returns false for a powered off VM", func() {
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		Expect(vmopv1util.IsVirtualMachineAvailable(vm)).To(BeFalse())
+	})
+
+	When("the VM has a readiness probe", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+		})
+
+		It("returns false when the VM is not ready", func() {
+			Expect(vmopv1util.IsVirtualMachineAvailable(vm)).To(BeFalse())
+		})
+
+		It("returns true when the VM is ready", func() {
+			vm.Status.Conditions = []metav1.Condition{
+				{
+					Type:   vmopv1.ReadyConditionType,
+					Status: metav1.ConditionTrue,
+				},
+			}
+			Expect(vmopv1util.IsVirtualMachineAvailable(vm)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("GetExceededDisruptionBudget", func() {
+	const ns = "my-namespace"
+
+	var (
+		initObjects []ctrlclient.Object
+		vm          *vmopv1.VirtualMachine
+		budget      *vmopv1.VirtualMachineDisruptionBudget
+	)
+
+	newVM := func(name string, powerState vmopv1.VirtualMachinePowerState) *vmopv1.VirtualMachine {
+		vm := &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{"app": "db"},
+			},
+		}
+		vm.Status.PowerState = powerState
+		return vm
+	}
+
+	BeforeEach(func() {
+		vm = newVM("vm-0", vmopv1.VirtualMachinePowerStateOn)
+		budget = &vmopv1.VirtualMachineDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-budget",
+				Namespace: ns,
+			},
+			Spec: vmopv1.VirtualMachineDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "db"},
+				},
+			},
+		}
+		initObjects = []ctrlclient.Object{
+			vm,
+			newVM("vm-1", vmopv1.VirtualMachinePowerStateOn),
+		}
+	})
+
+	getExceededBudget := func() string {
+		ctx := builder.NewUnitTestContext(initObjects...)
+		name, err := vmopv1util.GetExceededDisruptionBudget(ctx, ctx.Client, vm)
+		Expect(err).ToNot(HaveOccurred())
+		return name
+	}
+
+	When("there are no budgets", func() {
+		It("allows the disruption", func() {
+			Expect(getExceededBudget()).To(BeEmpty())
+		})
+	})
+
+	When("there is a budget", func() {
+		JustBeforeEach(func() {
+			initObjects = append(initObjects, budget)
+		})
+
+		It("allows the disruption when the other VMs are available", func() {
+			Expect(getExceededBudget()).To(BeEmpty())
+		})
+
+		When("another selected VM is unavailable", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, newVM("vm-2", vmopv1.VirtualMachinePowerStateOff))
+			})
+
+			It("returns the budget", func() {
+				Expect(getExceededBudget()).To(Equal(budget.Name))
+			})
+
+			When("the budget allows two unavailable VMs", func() {
+				BeforeEach(func() {
+					budget.Spec.MaxUnavailable = ptr.To[int32](2)
+				})
+
+				It("allows the disruption", func() {
+					Expect(getExceededBudget()).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the budget does not allow any unavailable VMs", func() {
+			BeforeEach(func() {
+				budget.Spec.MaxUnavailable = ptr.To[int32](0)
+			})
+
+			It("returns the budget", func() {
+				Expect(getExceededBudget()).To(Equal(budget.Name))
+			})
+		})
+
+		When("the budget does not select the VM", func() {
+			BeforeEach(func() {
+				budget.Spec.MaxUnavailable = ptr.To[int32](0)
+				budget.Spec.Selector.MatchLabels = map[string]string{"app": "web"}
+			})
+
+			It("allows the disruption", func() {
+				Expect(getExceededBudget()).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,31 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"fmt"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// PlacementRequestVMNames returns the names of the VMs described by a
+// placement request VM. When there is more than one replica, the names are
+// the request VM's name suffixed with a hyphen and the replica's index.
+func PlacementRequestVMNames(reqVM vmopv1.VirtualMachinePlacementRequestVM) []string {
+	replicas := int32(1)
+	if reqVM.Replicas != nil {
+		replicas = *reqVM.Replicas
+	}
+
+	if replicas <= 1 {
+		return []string{reqVM.Name}
+	}
+
+	names := make([]string, 0, replicas)
+	for i := range replicas {
+		names = append(names, fmt.Sprintf("%s-%d", reqVM.Name, i))
+	}
+	return names
+}
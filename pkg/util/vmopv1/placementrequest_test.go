@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = DescribeTable("PlacementRequestVMNames",
+	func(replicas *int32, expected []string) {
+		Expect(vmopv1util.PlacementRequestVMNames(vmopv1.VirtualMachinePlacementRequestVM{
+			Name:     "my-vm",
+			Replicas: replicas,
+		})).To(Equal(expected))
+	},
+	Entry("nil replicas", nil, []string{"my-vm"}),
+	Entry("one replica", ptr.To[int32](1), []string{"my-vm"}),
+	Entry("three replicas", ptr.To[int32](3), []string{"my-vm-0", "my-vm-1", "my-vm-2"}),
+)
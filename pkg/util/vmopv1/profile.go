@@ -0,0 +1,57 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"encoding/json"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// AppliedProfilesAnnotationKey records the VirtualMachineProfiles whose
+// values were set on a VM by the mutation webhook. The VM controller copies
+// the value of this annotation to the VM's status.appliedProfiles.
+const AppliedProfilesAnnotationKey = vmopv1.GroupName + "/applied-profiles"
+
+// AppliedProfiles returns the VirtualMachineProfiles recorded in the VM's
+// applied profiles annotation. Nil is returned if the annotation is missing or
+// cannot be parsed.
+func AppliedProfiles(vm vmopv1.VirtualMachine) []vmopv1.VirtualMachineAppliedProfile {
+	val := vm.Annotations[AppliedProfilesAnnotationKey]
+	if val == "" {
+		return nil
+	}
+
+	var applied []vmopv1.VirtualMachineAppliedProfile
+	if err := json.Unmarshal([]byte(val), &applied); err != nil {
+		return nil
+	}
+
+	return applied
+}
+
+// SetAppliedProfiles records the VirtualMachineProfiles in the VM's applied
+// profiles annotation. The annotation is removed if applied is empty.
+func SetAppliedProfiles(
+	vm *vmopv1.VirtualMachine,
+	applied []vmopv1.VirtualMachineAppliedProfile) error {
+
+	if len(applied) == 0 {
+		delete(vm.Annotations, AppliedProfilesAnnotationKey)
+		return nil
+	}
+
+	b, err := json.Marshal(applied)
+	if err != nil {
+		return err
+	}
+
+	if vm.Annotations == nil {
+		vm.Annotations = make(map[string]string)
+	}
+	vm.Annotations[AppliedProfilesAnnotationKey] = string(b)
+
+	return nil
+}
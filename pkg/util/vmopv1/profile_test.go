@@ -0,0 +1,68 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("AppliedProfiles", func() {
+
+	var (
+		vm *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyVirtualMachine()
+	})
+
+	When("the annotation is missing", func() {
+		It("returns nil", func() {
+			Expect(vmopv1util.AppliedProfiles(*vm)).To(BeNil())
+		})
+	})
+
+	When("the annotation is invalid", func() {
+		BeforeEach(func() {
+			vm.Annotations = map[string]string{
+				vmopv1util.AppliedProfilesAnnotationKey: "{",
+			}
+		})
+		It("returns nil", func() {
+			Expect(vmopv1util.AppliedProfiles(*vm)).To(BeNil())
+		})
+	})
+
+	When("the annotation is set", func() {
+		applied := []vmopv1.VirtualMachineAppliedProfile{
+			{
+				Name:   "my-profile",
+				Fields: []string{"spec.storageClass"},
+			},
+		}
+
+		BeforeEach(func() {
+			Expect(vmopv1util.SetAppliedProfiles(vm, applied)).To(Succeed())
+		})
+
+		It("returns the applied profiles", func() {
+			Expect(vmopv1util.AppliedProfiles(*vm)).To(Equal(applied))
+		})
+
+		When("the applied profiles are set to empty", func() {
+			BeforeEach(func() {
+				Expect(vmopv1util.SetAppliedProfiles(vm, nil)).To(Succeed())
+			})
+			It("removes the annotation", func() {
+				Expect(vm.Annotations).ToNot(HaveKey(vmopv1util.AppliedProfilesAnnotationKey))
+			})
+		})
+	})
+})
@@ -0,0 +1,285 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/sts"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+)
+
+// AuthType describes how a client authenticates to vCenter.
+type AuthType string
+
+const (
+	// AuthTypePassword authenticates with a username and password.
+	AuthTypePassword AuthType = "Password"
+
+	// AuthTypeCertificate authenticates as a solution user with a
+	// holder-of-key token issued by the vCenter STS for a client certificate.
+	AuthTypeCertificate AuthType = "Certificate"
+
+	// AuthTypeToken authenticates with a SAML bearer token.
+	AuthTypeToken AuthType = "Token"
+)
+
+const (
+	// tokenLifetime is the lifetime requested for the tokens issued for a
+	// solution user certificate.
+	tokenLifetime = 1 * time.Hour
+
+	// minTokenRenewInterval is the minimum time between attempts to renew a
+	// token.
+	minTokenRenewInterval = 10 * time.Second
+)
+
+// AuthType returns how a client created from this config authenticates to
+// vCenter. A certificate takes precedence over a token, and a token takes
+// precedence over a username and password.
+func (c Config) AuthType() AuthType {
+	switch {
+	case c.Certificate != "" || c.PrivateKey != "":
+		return AuthTypeCertificate
+	case c.Token != "":
+		return AuthTypeToken
+	default:
+		return AuthTypePassword
+	}
+}
+
+// authenticator logs the vim25 and REST clients into vCenter with the
+// credentials from a client config.
+type authenticator struct {
+	config Config
+	cert   *tls.Certificate
+
+	mu     sync.Mutex
+	signer *sts.Signer
+}
+
+func newAuthenticator(config Config) (*authenticator, error) {
+	a := &authenticator{
+		config: config,
+	}
+
+	if config.AuthType() == AuthTypeCertificate {
+		cert, err := tls.X509KeyPair(
+			[]byte(config.Certificate),
+			[]byte(config.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse solution user certificate: %w", err)
+		}
+		a.cert = &cert
+	}
+
+	return a, nil
+}
+
+func (a *authenticator) userInfo() *url.Userinfo {
+	return url.UserPassword(a.config.Username, a.config.Password)
+}
+
+// loginSOAP logs the vim25 client into vCenter.
+func (a *authenticator) loginSOAP(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	sm *session.Manager) error {
+
+	if a.config.AuthType() == AuthTypePassword {
+		return sm.Login(ctx, a.userInfo())
+	}
+
+	signer, err := a.getSigner(ctx, vimClient)
+	if err != nil {
+		return err
+	}
+
+	return sm.LoginByToken(vimClient.WithHeader(
+		ctx,
+		soap.Header{Security: signer}))
+}
+
+// loginREST logs the REST client into vCenter.
+func (a *authenticator) loginREST(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	restClient *rest.Client) error {
+
+	if a.config.AuthType() == AuthTypePassword {
+		return restClient.Login(ctx, a.userInfo())
+	}
+
+	signer, err := a.getSigner(ctx, vimClient)
+	if err != nil {
+		return err
+	}
+
+	return restClient.LoginByToken(restClient.WithSigner(ctx, signer))
+}
+
+// getSigner returns the signer used to login by token. A new token is issued
+// if there is no token yet or if the current token is due for renewal.
+func (a *authenticator) getSigner(
+	ctx context.Context,
+	vimClient *vim25.Client) (*sts.Signer, error) {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.signer != nil &&
+		(a.config.AuthType() == AuthTypeToken ||
+			time.Now().Before(renewAt(a.signer))) {
+
+		return a.signer, nil
+	}
+
+	return a.renewSignerLocked(ctx, vimClient)
+}
+
+// renewSigner issues a new token for the solution user certificate.
+func (a *authenticator) renewSigner(
+	ctx context.Context,
+	vimClient *vim25.Client) (*sts.Signer, error) {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.renewSignerLocked(ctx, vimClient)
+}
+
+func (a *authenticator) renewSignerLocked(
+	ctx context.Context,
+	vimClient *vim25.Client) (*sts.Signer, error) {
+
+	switch a.config.AuthType() {
+	case AuthTypeToken:
+		// A bearer token cannot be renewed without other credentials. A new
+		// token must be provided by updating the provider Secret.
+		signer := &sts.Signer{
+			Token: a.config.Token,
+		}
+		signer.Lifetime.Expires = tokenExpiry(a.config.Token)
+		a.signer = signer
+
+	case AuthTypeCertificate:
+		stsClient, err := sts.NewClient(ctx, vimClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sts client: %w", err)
+		}
+
+		signer, err := stsClient.Issue(ctx, sts.TokenRequest{
+			Certificate: a.cert,
+			Lifetime:    tokenLifetime,
+			Renewable:   true,
+			Delegatable: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to issue token for solution user: %w", err)
+		}
+		a.signer = signer
+
+	default:
+		return nil, fmt.Errorf(
+			"auth type %s does not use a token", a.config.AuthType())
+	}
+
+	return a.signer, nil
+}
+
+// startTokenRenewal starts renewing the token for a solution user
+// certificate before it expires, until the returned function is called. This
+// ensures the keepalive handlers always have a valid token with which to log
+// back in if the session expires.
+func (a *authenticator) startTokenRenewal(
+	ctx context.Context,
+	vimClient *vim25.Client) func() {
+
+	if a.config.AuthType() != AuthTypeCertificate {
+		return func() {}
+	}
+
+	log := pkglog.FromContextOrDefault(ctx).WithName("TokenRenewal")
+
+	ctx, cancel := context.WithCancel(
+		logr.NewContext(context.Background(), log))
+
+	go func() {
+		for {
+			a.mu.Lock()
+			d := time.Until(renewAt(a.signer))
+			a.mu.Unlock()
+
+			if d < minTokenRenewInterval {
+				d = minTokenRenewInterval
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(d):
+			}
+
+			signer, err := a.renewSigner(ctx, vimClient)
+			if err != nil {
+				log.Error(err, "Failed to renew token", "url", vimClient.URL())
+				continue
+			}
+
+			log.V(4).Info("Renewed token",
+				"url", vimClient.URL(),
+				"expires", signer.Lifetime.Expires)
+		}
+	}()
+
+	return cancel
+}
+
+// renewAt returns when the signer's token should be renewed, which is after
+// 80% of its lifetime has elapsed. The zero time is returned if the token's
+// expiry is unknown.
+func renewAt(signer *sts.Signer) time.Time {
+	if signer == nil || signer.Lifetime.Expires.IsZero() {
+		return time.Time{}
+	}
+	created := signer.Lifetime.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	lifetime := signer.Lifetime.Expires.Sub(created)
+	return signer.Lifetime.Expires.Add(-lifetime / 5)
+}
+
+// tokenExpiry returns the NotOnOrAfter condition of a SAML token, or the zero
+// time if the token cannot be parsed.
+func tokenExpiry(token string) time.Time {
+	var assertion struct {
+		Conditions struct {
+			NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+		} `xml:"Conditions"`
+	}
+	if err := xml.Unmarshal([]byte(token), &assertion); err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, assertion.Conditions.NotOnOrAfter)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
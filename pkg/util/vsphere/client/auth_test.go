@@ -0,0 +1,94 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/sts"
+)
+
+var _ = Describe("Config.AuthType", func() {
+	DescribeTable("returns the auth type",
+		func(config Config, expected AuthType) {
+			Expect(config.AuthType()).To(Equal(expected))
+		},
+		Entry("username and password",
+			Config{Username: "user", Password: "pass"},
+			AuthTypePassword),
+		Entry("token",
+			Config{Username: "user", Password: "pass", Token: "token"},
+			AuthTypeToken),
+		Entry("certificate",
+			Config{Token: "token", Certificate: "cert", PrivateKey: "key"},
+			AuthTypeCertificate),
+	)
+})
+
+var _ = Describe("renewAt", func() {
+	It("returns the zero time for a nil signer", func() {
+		Expect(renewAt(nil)).To(BeZero())
+	})
+
+	It("returns the zero time if the expiry is unknown", func() {
+		Expect(renewAt(&sts.Signer{})).To(BeZero())
+	})
+
+	It("returns when 80% of the lifetime has elapsed", func() {
+		now := time.Now()
+		signer := &sts.Signer{}
+		signer.Lifetime.Created = now
+		signer.Lifetime.Expires = now.Add(10 * time.Minute)
+		Expect(renewAt(signer)).To(Equal(now.Add(8 * time.Minute)))
+	})
+})
+
+var _ = Describe("tokenExpiry", func() {
+	It("returns the NotOnOrAfter condition", func() {
+		Expect(tokenExpiry(
+			`<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">` +
+				`<saml2:Conditions NotBefore="2018-03-04T00:22:01.401Z" NotOnOrAfter="2018-03-04T00:27:01.401Z"/>` +
+				`</saml2:Assertion>`)).To(Equal(
+			time.Date(2018, 3, 4, 0, 27, 1, 401000000, time.UTC)))
+	})
+
+	It("returns the zero time for an invalid token", func() {
+		Expect(tokenExpiry("invalid")).To(BeZero())
+	})
+})
+
+var _ = Describe("authenticator", func() {
+	It("does not use a token for username and password", func() {
+		a, err := newAuthenticator(Config{
+			Username: "user",
+			Password: "pass",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = a.getSigner(context.Background(), nil)
+		Expect(err).To(MatchError("auth type Password does not use a token"))
+		Expect(a.startTokenRenewal(context.Background(), nil)).ToNot(BeNil())
+	})
+
+	It("returns a bearer token signer for a token", func() {
+		a, err := newAuthenticator(Config{
+			Token: "token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		signer1, err := a.getSigner(context.Background(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signer1.Token).To(Equal("token"))
+		Expect(signer1.Certificate).To(BeNil())
+
+		signer2, err := a.getSigner(context.Background(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signer2).To(BeIdenticalTo(signer1))
+	})
+})
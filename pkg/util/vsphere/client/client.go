@@ -27,10 +27,22 @@ import (
 )
 
 type Config struct {
-	Host       string
-	Port       string
-	Username   string
-	Password   string
+	Host     string
+	Port     string
+	Username string
+	Password string
+
+	// Certificate and PrivateKey are the PEM-encoded certificate and private
+	// key of a solution user. When set, the client logs in with a token issued
+	// by the vCenter STS for the certificate, and renews the token before it
+	// expires.
+	Certificate string
+	PrivateKey  string
+
+	// Token is a SAML bearer token. When set, the client logs in with the
+	// token.
+	Token string
+
 	CAFilePath string
 	Insecure   bool
 	Datacenter string
@@ -43,13 +55,20 @@ type Client struct {
 	sessionManager *session.Manager
 	config         Config
 
+	stopTokenRenewal func()
+
 	finder     *find.Finder
 	datacenter *object.Datacenter
 }
 
 // NewClient returns a new client.
 func NewClient(ctx context.Context, config Config) (*Client, error) {
-	vimClient, sm, err := NewVimClient(ctx, config)
+	auth, err := newAuthenticator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	vimClient, sm, err := newVimClient(ctx, config, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +78,7 @@ func NewClient(ctx context.Context, config Config) (*Client, error) {
 		return nil, err
 	}
 
-	restClient, err := newRestClient(ctx, vimClient, config)
+	restClient, err := newRestClient(ctx, vimClient, config, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +96,8 @@ func NewClient(ctx context.Context, config Config) (*Client, error) {
 		pbmClient:      pbmClient,
 		sessionManager: sm,
 		config:         config,
+
+		stopTokenRenewal: auth.startTokenRenewal(ctx, vimClient),
 	}, nil
 }
 
@@ -94,13 +115,23 @@ func SoapKeepAliveHandlerFn(
 	sm *session.Manager,
 	userInfo *url.Userinfo) func() error {
 
+	return soapKeepAliveHandlerFn(ctx, sc, func(ctx context.Context) error {
+		return sm.Login(ctx, userInfo)
+	})
+}
+
+func soapKeepAliveHandlerFn(
+	ctx context.Context,
+	sc *soap.Client,
+	loginFn func(context.Context) error) func() error {
+
 	log := pkglog.FromContextOrDefault(ctx).WithName("SoapKeepAliveHandlerFn")
 
 	return func() error {
 		ctx := context.Background()
 		if _, err := methods.GetCurrentTime(ctx, sc); err != nil && IsNotAuthenticatedError(err) {
 			log.Info("Re-authenticating vim client")
-			if err = sm.Login(ctx, userInfo); err != nil {
+			if err = loginFn(ctx); err != nil {
 				if IsInvalidLogin(err) {
 					log.Error(err, "Invalid login in keepalive handler", "url", sc.URL())
 					return err
@@ -123,6 +154,16 @@ func RestKeepAliveHandlerFn(
 	c *rest.Client,
 	userInfo *url.Userinfo) func() error {
 
+	return restKeepAliveHandlerFn(ctx, c, func(ctx context.Context) error {
+		return c.Login(ctx, userInfo)
+	})
+}
+
+func restKeepAliveHandlerFn(
+	ctx context.Context,
+	c *rest.Client,
+	loginFn func(context.Context) error) func() error {
+
 	log := pkglog.FromContextOrDefault(ctx).WithName("RestKeepAliveHandlerFn")
 
 	return func() error {
@@ -130,7 +171,7 @@ func RestKeepAliveHandlerFn(
 		if sess, err := c.Session(ctx); err == nil && sess == nil {
 			// session is Unauthorized.
 			log.Info("Re-authenticating REST client")
-			if err = c.Login(ctx, userInfo); err != nil {
+			if err = loginFn(ctx); err != nil {
 				log.Error(err, "Invalid login in keepalive handler", "url", c.URL())
 				return err
 			}
@@ -146,23 +187,27 @@ func RestKeepAliveHandlerFn(
 func newRestClient(
 	ctx context.Context,
 	vimClient *vim25.Client,
-	config Config) (*rest.Client, error) {
+	config Config,
+	auth *authenticator) (*rest.Client, error) {
 
 	log := pkglog.FromContextOrDefault(ctx).WithName("newRestClient")
 
-	log.Info("Creating new REST Client", "VcPNID", config.Host, "VcPort", config.Port)
+	log.Info("Creating new REST Client",
+		"VcPNID", config.Host, "VcPort", config.Port, "AuthType", config.AuthType())
 	restClient := rest.NewClient(vimClient)
 
-	userInfo := url.UserPassword(config.Username, config.Password)
+	loginFn := func(ctx context.Context) error {
+		return auth.loginREST(ctx, vimClient, restClient)
+	}
 
 	// Set a custom keepalive handler function
-	restClient.Transport = keepalive.NewHandlerREST(
+	restClient.Transport = NewTracingTransport(keepalive.NewHandlerREST(
 		restClient,
 		keepAliveIdleTime,
-		RestKeepAliveHandlerFn(ctx, restClient, userInfo))
+		restKeepAliveHandlerFn(ctx, restClient, loginFn)))
 
 	// Initial login. This will also start the keepalive.
-	if err := restClient.Login(ctx, userInfo); err != nil {
+	if err := loginFn(ctx); err != nil {
 		// Log message used by VMC LINT. Refer to before making changes
 		return nil, fmt.Errorf("login failed for url: %v: %w", vimClient.URL(), err)
 	}
@@ -176,9 +221,23 @@ func NewVimClient(
 	ctx context.Context,
 	config Config) (*vim25.Client, *session.Manager, error) {
 
+	auth, err := newAuthenticator(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newVimClient(ctx, config, auth)
+}
+
+func newVimClient(
+	ctx context.Context,
+	config Config,
+	auth *authenticator) (*vim25.Client, *session.Manager, error) {
+
 	log := pkglog.FromContextOrDefault(ctx).WithName("NewVimClient")
 
-	log.Info("Creating new vim Client", "VcPNID", config.Host, "VcPort", config.Port)
+	log.Info("Creating new vim Client",
+		"VcPNID", config.Host, "VcPort", config.Port, "AuthType", config.AuthType())
 	soapURL, err := soap.ParseURL(net.JoinHostPort(config.Host, config.Port))
 	if err != nil {
 		return nil, nil, fmt.Errorf(
@@ -205,17 +264,20 @@ func NewVimClient(
 			"error setting vim client version for url: %v: %w", soapURL, err)
 	}
 
-	userInfo := url.UserPassword(config.Username, config.Password)
 	sm := session.NewManager(vimClient)
 
+	loginFn := func(ctx context.Context) error {
+		return auth.loginSOAP(ctx, vimClient, sm)
+	}
+
 	// Set a custom keepalive handler function
-	vimClient.RoundTripper = keepalive.NewHandlerSOAP(
+	vimClient.RoundTripper = NewTracingRoundTripper(keepalive.NewHandlerSOAP(
 		soapClient,
 		keepAliveIdleTime,
-		SoapKeepAliveHandlerFn(ctx, soapClient, sm, userInfo))
+		soapKeepAliveHandlerFn(ctx, soapClient, loginFn)))
 
 	// Initial login. This will also start the keepalive.
-	if err = sm.Login(ctx, userInfo); err != nil {
+	if err = loginFn(ctx); err != nil {
 		// Log message used by VMC LINT. Refer to before making changes
 		return nil, nil, fmt.Errorf(
 			"login failed for url: %v: %w", soapURL, err)
@@ -293,6 +355,10 @@ func (c *Client) Logout(ctx context.Context) {
 	clientURL := c.vimClient.URL()
 	log.Info("vsphere client logging out from", "VC", clientURL.Host)
 
+	if c.stopTokenRenewal != nil {
+		c.stopTokenRenewal()
+	}
+
 	if err := c.sessionManager.Logout(ctx); err != nil {
 		log.Error(err, "Error logging out the vim25 session",
 			"username", clientURL.User.Username(),
@@ -16,11 +16,13 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/vmware/govmomi"
-	_ "github.com/vmware/govmomi/pbm/simulator" // load PBM simulator
+	_ "github.com/vmware/govmomi/lookup/simulator" // load Lookup Service simulator
+	_ "github.com/vmware/govmomi/pbm/simulator"    // load PBM simulator
 	"github.com/vmware/govmomi/session"
 	"github.com/vmware/govmomi/session/keepalive"
 	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/simulator/sim25"
+	_ "github.com/vmware/govmomi/sts/simulator" // load STS simulator
 	"github.com/vmware/govmomi/vapi/rest"
 	_ "github.com/vmware/govmomi/vapi/simulator" // load VAPI simulator
 	"github.com/vmware/govmomi/vim25"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
 const (
@@ -117,6 +120,75 @@ var _ = Describe("Client", Label(testlabels.VCSim), func() {
 			})
 		})
 
+		When("a solution user certificate is used", func() {
+			JustBeforeEach(func() {
+				certPEM, keyPEM, err := builder.NewSelfSignedCertificatePEM("vmop-solution-user")
+				Expect(err).ToNot(HaveOccurred())
+				config.Username = ""
+				config.Password = ""
+				config.Certificate = string(certPEM)
+				config.PrivateKey = string(keyPEM)
+			})
+			It("should connect", func() {
+				Expect(config.AuthType()).To(Equal(client.AuthTypeCertificate))
+				c, err := client.NewClient(ctx, config)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(c).ToNot(BeNil())
+				Expect(c.Valid()).To(BeTrue())
+
+				s, err := c.RestClient().Session(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(s).ToNot(BeNil())
+
+				c.Logout(ctx)
+			})
+
+			When("the certificate is invalid", func() {
+				JustBeforeEach(func() {
+					config.Certificate = invalid
+				})
+				It("should fail to parse the certificate", func() {
+					c, err := client.NewClient(ctx, config)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(HavePrefix("failed to parse solution user certificate"))
+					Expect(c).To(BeNil())
+				})
+			})
+		})
+
+		When("a token is used", func() {
+			JustBeforeEach(func() {
+				config.Username = ""
+				config.Password = ""
+				config.Token = `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">` +
+					`<saml2:Subject><saml2:NameID>Administrator@VSPHERE.LOCAL</saml2:NameID></saml2:Subject>` +
+					`</saml2:Assertion>`
+			})
+			It("should connect", func() {
+				Expect(config.AuthType()).To(Equal(client.AuthTypeToken))
+				c, err := client.NewClient(ctx, config)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(c).ToNot(BeNil())
+				Expect(c.Valid()).To(BeTrue())
+
+				s, err := c.RestClient().Session(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(s).ToNot(BeNil())
+			})
+
+			When("the token is invalid", func() {
+				JustBeforeEach(func() {
+					config.Token = invalid
+				})
+				It("should fail to login", func() {
+					c, err := client.NewClient(ctx, config)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(HavePrefix("login failed for url"))
+					Expect(c).To(BeNil())
+				})
+			})
+		})
+
 		When("username and password are invalid", func() {
 			JustBeforeEach(func() {
 				config.Username = invalid
@@ -0,0 +1,143 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+)
+
+const (
+	// AttrSOAPMethod is the span attribute for the name of a vSphere API
+	// method.
+	AttrSOAPMethod = attribute.Key("vsphere.soap.method")
+
+	// AttrTask is the span attribute for the ID of the vSphere task returned
+	// by a method.
+	AttrTask = attribute.Key("vsphere.task")
+
+	// AttrHTTPMethod is the span attribute for the method of a REST request.
+	AttrHTTPMethod = attribute.Key("http.request.method")
+
+	// AttrHTTPPath is the span attribute for the path of a REST request.
+	AttrHTTPPath = attribute.Key("url.path")
+
+	// AttrHTTPStatusCode is the span attribute for the status code of a REST
+	// response.
+	AttrHTTPStatusCode = attribute.Key("http.response.status_code")
+)
+
+// NewTracingRoundTripper returns a SOAP round tripper that records a span for
+// each vSphere API call made with the provided round tripper.
+func NewTracingRoundTripper(rt soap.RoundTripper) soap.RoundTripper {
+	return tracingRoundTripper{RoundTripper: rt}
+}
+
+type tracingRoundTripper struct {
+	soap.RoundTripper
+}
+
+func (rt tracingRoundTripper) RoundTrip(
+	ctx context.Context,
+	req, res soap.HasFault) (retErr error) {
+
+	method := SOAPMethodName(req)
+
+	ctx, span := pkgtracing.Start(
+		ctx,
+		"vim25."+method,
+		AttrSOAPMethod.String(method))
+	defer func() { pkgtracing.End(span, retErr) }()
+
+	if err := rt.RoundTripper.RoundTrip(ctx, req, res); err != nil {
+		return err
+	}
+
+	if ref := taskRef(res); ref != nil {
+		span.SetAttributes(AttrTask.String(ref.Value))
+	}
+
+	return nil
+}
+
+// SOAPMethodName returns the name of the vSphere API method for the provided
+// request body, ex. CreateVM_Task for *methods.CreateVM_TaskBody.
+func SOAPMethodName(req soap.HasFault) string {
+	t := reflect.TypeOf(req)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return strings.TrimSuffix(t.Name(), "Body")
+}
+
+// taskRef returns the task returned by a method, if any.
+func taskRef(res soap.HasFault) *vimtypes.ManagedObjectReference {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		return nil
+	}
+	if v = v.FieldByName("Res"); !v.IsValid() ||
+		v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		return nil
+	}
+	if v = v.FieldByName("Returnval"); !v.IsValid() {
+		return nil
+	}
+	ref, ok := v.Interface().(vimtypes.ManagedObjectReference)
+	if !ok || ref.Type != "Task" {
+		return nil
+	}
+	return &ref
+}
+
+// NewTracingTransport returns an HTTP round tripper that records a span for
+// each vSphere REST API request made with the provided round tripper.
+func NewTracingTransport(rt http.RoundTripper) http.RoundTripper {
+	return tracingTransport{RoundTripper: rt}
+}
+
+type tracingTransport struct {
+	http.RoundTripper
+}
+
+func (rt tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := pkgtracing.Start(
+		req.Context(),
+		fmt.Sprintf("vapi %s %s", req.Method, req.URL.Path),
+		AttrHTTPMethod.String(req.Method),
+		AttrHTTPPath.String(req.URL.Path))
+
+	res, err := rt.RoundTripper.RoundTrip(req.WithContext(ctx))
+
+	spanErr := err
+	if res != nil {
+		span.SetAttributes(AttrHTTPStatusCode.Int(res.StatusCode))
+		if spanErr == nil && res.StatusCode >= http.StatusBadRequest {
+			spanErr = errors.New(res.Status)
+		}
+	}
+	pkgtracing.End(span, spanErr)
+
+	return res, err
+}
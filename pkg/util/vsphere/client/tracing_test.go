@@ -0,0 +1,157 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	pkgtracing "github.com/vmware-tanzu/vm-operator/pkg/tracing"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
+)
+
+type fakeSOAPRoundTripper struct {
+	err error
+	fn  func(res soap.HasFault)
+}
+
+func (rt fakeSOAPRoundTripper) RoundTrip(
+	_ context.Context,
+	_, res soap.HasFault) error {
+
+	if rt.fn != nil {
+		rt.fn(res)
+	}
+	return rt.err
+}
+
+var _ = Describe("Tracing", func() {
+	var (
+		ctx      context.Context
+		exporter *tracetest.InMemoryExporter
+		tp       *sdktrace.TracerProvider
+		prevTP   trace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		tp = pkgtracing.NewTracerProvider(
+			sdktrace.NewSimpleSpanProcessor(exporter),
+			1.0)
+		prevTP = otel.GetTracerProvider()
+		otel.SetTracerProvider(tp)
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(prevTP)
+		Expect(tp.Shutdown(ctx)).To(Succeed())
+	})
+
+	Describe("SOAPMethodName", func() {
+		It("should return the method name", func() {
+			Expect(client.SOAPMethodName(&methods.CreateVM_TaskBody{})).
+				To(Equal("CreateVM_Task"))
+			Expect(client.SOAPMethodName(&methods.RetrievePropertiesExBody{})).
+				To(Equal("RetrievePropertiesEx"))
+			Expect(client.SOAPMethodName(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("NewTracingRoundTripper", func() {
+		It("should record the method and task", func() {
+			rt := client.NewTracingRoundTripper(fakeSOAPRoundTripper{
+				fn: func(res soap.HasFault) {
+					res.(*methods.PowerOnVM_TaskBody).Res = &vimtypes.PowerOnVM_TaskResponse{
+						Returnval: vimtypes.ManagedObjectReference{
+							Type:  "Task",
+							Value: "task-1",
+						},
+					}
+				},
+			})
+
+			body := &methods.PowerOnVM_TaskBody{}
+			Expect(rt.RoundTrip(ctx, body, body)).To(Succeed())
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("vim25.PowerOnVM_Task"))
+			Expect(spans[0].Attributes).To(ContainElements(
+				client.AttrSOAPMethod.String("PowerOnVM_Task"),
+				client.AttrTask.String("task-1")))
+		})
+
+		It("should record an error", func() {
+			rt := client.NewTracingRoundTripper(fakeSOAPRoundTripper{
+				err: errors.New("boom"),
+			})
+
+			body := &methods.RetrievePropertiesExBody{}
+			Expect(rt.RoundTrip(ctx, body, body)).To(MatchError("boom"))
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			for _, a := range spans[0].Attributes {
+				Expect(a.Key).ToNot(Equal(client.AttrTask))
+			}
+		})
+	})
+
+	Describe("NewTracingTransport", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/missing" {
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		DescribeTable("should record the request",
+			func(path string, statusCode int, statusCodeErr codes.Code) {
+				c := &http.Client{
+					Transport: client.NewTracingTransport(http.DefaultTransport),
+				}
+				res, err := c.Get(server.URL + path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Body.Close()).To(Succeed())
+				Expect(res.StatusCode).To(Equal(statusCode))
+
+				spans := exporter.GetSpans()
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("vapi GET " + path))
+				Expect(spans[0].Status.Code).To(Equal(statusCodeErr))
+				Expect(spans[0].Attributes).To(ContainElements(
+					client.AttrHTTPMethod.String(http.MethodGet),
+					client.AttrHTTPPath.String(path),
+					client.AttrHTTPStatusCode.Int(statusCode)))
+			},
+			Entry("success", "/rest/com/vmware/cis/session", http.StatusOK, codes.Unset),
+			Entry("error", "/missing", http.StatusNotFound, codes.Error),
+		)
+	})
+})
@@ -26,6 +26,10 @@ type SourceFile struct {
 	Path    string
 	VDiskID string
 
+	// Size is the size of the file in bytes, if known. It is used to account
+	// for the capacity used by the cached file.
+	Size int64
+
 	DstDir        string
 	DstProfileID  string
 	DstDiskFormat vimtypes.DatastoreSectorFormat
@@ -0,0 +1,206 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package library
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkglog "github.com/vmware-tanzu/vm-operator/pkg/log"
+	pkgnil "github.com/vmware-tanzu/vm-operator/pkg/util/nil"
+)
+
+// CachedLocation describes the files cached for a library item in a single
+// location.
+type CachedLocation struct {
+	// ItemName is the name of the object that tracks the cached files.
+	ItemName string
+
+	DatacenterID string
+	DatastoreID  string
+	ProfileID    string
+
+	// Size is the capacity used by the cached files in bytes.
+	Size int64
+
+	// LastUsed is the last time the cached files were used.
+	LastUsed time.Time
+}
+
+// CacheLimits describes the limits used to determine which cached locations
+// should be evicted.
+type CacheLimits struct {
+	// DatastoreCapacity is the maximum number of bytes that may be used by
+	// cached files on a single datastore. There is no limit when this value is
+	// zero.
+	DatastoreCapacity int64
+
+	// ProfileCapacity is the maximum number of bytes that may be used by cached
+	// files with a single storage profile on a single datastore. There is no
+	// limit when this value is zero.
+	ProfileCapacity int64
+
+	// GracePeriod is how long cached files must go unused before they may be
+	// evicted.
+	GracePeriod time.Duration
+}
+
+type cachedProfileKey struct {
+	datastoreID string
+	profileID   string
+}
+
+// GetCachedLocationsToEvict returns the least recently used locations that
+// should be evicted so the capacity used by the cached files on each datastore,
+// and by each storage profile on each datastore, no longer exceeds the
+// provided limits.
+//
+// Locations used within the grace period are never returned, even if that
+// means the capacity used still exceeds the limits.
+func GetCachedLocationsToEvict(
+	now time.Time,
+	limits CacheLimits,
+	locations ...CachedLocation) []CachedLocation {
+
+	if limits.DatastoreCapacity <= 0 && limits.ProfileCapacity <= 0 {
+		return nil
+	}
+
+	var (
+		datastoreUsed = map[string]int64{}
+		profileUsed   = map[cachedProfileKey]int64{}
+		candidates    []CachedLocation
+	)
+
+	for _, l := range locations {
+		datastoreUsed[l.DatastoreID] += l.Size
+		profileUsed[cachedProfileKey{l.DatastoreID, l.ProfileID}] += l.Size
+		if now.Sub(l.LastUsed) >= limits.GracePeriod {
+			candidates = append(candidates, l)
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b CachedLocation) int {
+		return cmp.Or(
+			a.LastUsed.Compare(b.LastUsed),
+			strings.Compare(a.ItemName, b.ItemName),
+			strings.Compare(a.DatastoreID, b.DatastoreID),
+			strings.Compare(a.ProfileID, b.ProfileID))
+	})
+
+	var evict []CachedLocation
+	for _, l := range candidates {
+		pk := cachedProfileKey{l.DatastoreID, l.ProfileID}
+		if (limits.DatastoreCapacity > 0 &&
+			datastoreUsed[l.DatastoreID] > limits.DatastoreCapacity) ||
+			(limits.ProfileCapacity > 0 &&
+				profileUsed[pk] > limits.ProfileCapacity) {
+
+			evict = append(evict, l)
+			datastoreUsed[l.DatastoreID] -= l.Size
+			profileUsed[pk] -= l.Size
+		}
+	}
+
+	return evict
+}
+
+// DeleteCachedFilesClient implements the client methods used by the
+// DeleteCachedFiles method.
+type DeleteCachedFilesClient interface {
+	DeleteVirtualDisk(
+		ctx context.Context,
+		name string,
+		datacenter *object.Datacenter) (*object.Task, error)
+
+	DeleteDatastoreFile(
+		ctx context.Context,
+		name string,
+		datacenter *object.Datacenter) (*object.Task, error)
+
+	WaitForTask(ctx context.Context, task *object.Task) error
+}
+
+// DeleteCachedFiles deletes the provided cached files as well as the
+// directories that contain them. Files and directories that do not exist are
+// ignored.
+func DeleteCachedFiles(
+	ctx context.Context,
+	client DeleteCachedFilesClient,
+	datacenter *object.Datacenter,
+	filePaths ...string) error {
+
+	if pkgnil.IsNil(ctx) {
+		panic("context is nil")
+	}
+	if pkgnil.IsNil(client) {
+		panic("client is nil")
+	}
+	if datacenter == nil {
+		panic("datacenter is nil")
+	}
+
+	var (
+		dirs   []string
+		logger = pkglog.FromContextOrDefault(ctx)
+	)
+
+	for _, p := range filePaths {
+		var (
+			task *object.Task
+			err  error
+		)
+
+		if strings.EqualFold(".vmdk", path.Ext(p)) {
+			logger.Info("Deleting cached disk", "path", p)
+			task, err = client.DeleteVirtualDisk(ctx, p, datacenter)
+		} else {
+			logger.Info("Deleting cached file", "path", p)
+			task, err = client.DeleteDatastoreFile(ctx, p, datacenter)
+		}
+		if err := waitForDelete(ctx, client, task, err); err != nil {
+			return fmt.Errorf("failed to delete cached file %q: %w", p, err)
+		}
+
+		// Files in the root of the datastore have a parent directory of ".".
+		if d := path.Dir(p); d != "." && !slices.Contains(dirs, d) {
+			dirs = append(dirs, d)
+		}
+	}
+
+	for _, d := range dirs {
+		logger.Info("Deleting cache directory", "path", d)
+		task, err := client.DeleteDatastoreFile(ctx, d, datacenter)
+		if err := waitForDelete(ctx, client, task, err); err != nil {
+			return fmt.Errorf("failed to delete cache directory %q: %w", d, err)
+		}
+	}
+
+	return nil
+}
+
+func waitForDelete(
+	ctx context.Context,
+	client DeleteCachedFilesClient,
+	task *object.Task,
+	err error) error {
+
+	if err == nil {
+		err = client.WaitForTask(ctx, task)
+	}
+	if err != nil && !fault.Is(err, &vimtypes.FileNotFound{}) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package library_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
+)
+
+type fakeDeleteCachedFilesClient struct {
+	deleteDiskErr error
+	deleteDisks   []string
+
+	deleteFileErr error
+	deleteFiles   []string
+
+	waitErr   error
+	waitCalls int
+}
+
+func (m *fakeDeleteCachedFilesClient) DeleteVirtualDisk(
+	ctx context.Context,
+	name string,
+	datacenter *object.Datacenter) (*object.Task, error) {
+
+	m.deleteDisks = append(m.deleteDisks, name)
+	return nil, m.deleteDiskErr
+}
+
+func (m *fakeDeleteCachedFilesClient) DeleteDatastoreFile(
+	ctx context.Context,
+	name string,
+	datacenter *object.Datacenter) (*object.Task, error) {
+
+	m.deleteFiles = append(m.deleteFiles, name)
+	return nil, m.deleteFileErr
+}
+
+func (m *fakeDeleteCachedFilesClient) WaitForTask(
+	ctx context.Context, task *object.Task) error {
+
+	m.waitCalls++
+	return m.waitErr
+}
+
+var _ = Describe("GetCachedLocationsToEvict", func() {
+
+	const gi = int64(1024 * 1024 * 1024)
+
+	var (
+		now       time.Time
+		limits    clsutil.CacheLimits
+		locations []clsutil.CachedLocation
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		limits = clsutil.CacheLimits{
+			DatastoreCapacity: 10 * gi,
+			GracePeriod:       time.Hour,
+		}
+		locations = []clsutil.CachedLocation{
+			{
+				ItemName:    "vmi-1",
+				DatastoreID: "ds-1",
+				ProfileID:   "profile-1",
+				Size:        4 * gi,
+				LastUsed:    now.Add(-3 * time.Hour),
+			},
+			{
+				ItemName:    "vmi-2",
+				DatastoreID: "ds-1",
+				ProfileID:   "profile-2",
+				Size:        4 * gi,
+				LastUsed:    now.Add(-4 * time.Hour),
+			},
+			{
+				ItemName:    "vmi-3",
+				DatastoreID: "ds-1",
+				ProfileID:   "profile-1",
+				Size:        4 * gi,
+				LastUsed:    now.Add(-2 * time.Hour),
+			},
+			{
+				ItemName:    "vmi-1",
+				DatastoreID: "ds-2",
+				ProfileID:   "profile-1",
+				Size:        4 * gi,
+				LastUsed:    now.Add(-5 * time.Hour),
+			},
+		}
+	})
+
+	itemNames := func(l []clsutil.CachedLocation) []string {
+		var out []string
+		for i := range l {
+			out = append(out, l[i].ItemName+"/"+l[i].DatastoreID)
+		}
+		return out
+	}
+
+	When("there are no limits", func() {
+		BeforeEach(func() {
+			limits.DatastoreCapacity = 0
+		})
+		It("should not evict anything", func() {
+			Expect(clsutil.GetCachedLocationsToEvict(now, limits, locations...)).To(BeEmpty())
+		})
+	})
+
+	When("the datastore capacity is exceeded", func() {
+		It("should evict the least recently used locations on the datastore", func() {
+			Expect(itemNames(clsutil.GetCachedLocationsToEvict(now, limits, locations...))).
+				To(Equal([]string{"vmi-2/ds-1"}))
+		})
+	})
+
+	When("the profile capacity is exceeded", func() {
+		BeforeEach(func() {
+			limits.DatastoreCapacity = 0
+			limits.ProfileCapacity = 5 * gi
+		})
+		It("should evict the least recently used locations with the profile", func() {
+			Expect(itemNames(clsutil.GetCachedLocationsToEvict(now, limits, locations...))).
+				To(Equal([]string{"vmi-1/ds-1"}))
+		})
+	})
+
+	When("the locations were used within the grace period", func() {
+		BeforeEach(func() {
+			limits.DatastoreCapacity = 1
+			limits.GracePeriod = 150 * time.Minute
+		})
+		It("should only evict the locations outside of the grace period", func() {
+			Expect(itemNames(clsutil.GetCachedLocationsToEvict(now, limits, locations...))).
+				To(Equal([]string{"vmi-1/ds-2", "vmi-2/ds-1", "vmi-1/ds-1"}))
+		})
+	})
+
+	When("the locations were last used at the same time", func() {
+		BeforeEach(func() {
+			for i := range locations {
+				locations[i].LastUsed = now.Add(-2 * time.Hour)
+			}
+		})
+		It("should evict the locations in order of their names", func() {
+			Expect(itemNames(clsutil.GetCachedLocationsToEvict(now, limits, locations...))).
+				To(Equal([]string{"vmi-1/ds-1"}))
+		})
+	})
+})
+
+var _ = Describe("DeleteCachedFiles", func() {
+
+	var (
+		ctx        context.Context
+		client     *fakeDeleteCachedFilesClient
+		datacenter *object.Datacenter
+		filePaths  []string
+		err        error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		client = &fakeDeleteCachedFilesClient{}
+		datacenter = object.NewDatacenter(
+			nil, vimtypes.ManagedObjectReference{
+				Type:  "Datacenter",
+				Value: "datacenter-1",
+			})
+		filePaths = []string{
+			"[my-datastore-1] vmi-1-abc/disk1.vmdk",
+			"[my-datastore-1] vmi-1-abc/firmware.nvram",
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = clsutil.DeleteCachedFiles(ctx, client, datacenter, filePaths...)
+	})
+
+	It("should panic if the datacenter is nil", func() {
+		Expect(func() {
+			_ = clsutil.DeleteCachedFiles(ctx, client, nil)
+		}).To(PanicWith("datacenter is nil"))
+	})
+
+	It("should delete the disks, files, and directory", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.deleteDisks).To(Equal([]string{
+			"[my-datastore-1] vmi-1-abc/disk1.vmdk",
+		}))
+		Expect(client.deleteFiles).To(Equal([]string{
+			"[my-datastore-1] vmi-1-abc/firmware.nvram",
+			"[my-datastore-1] vmi-1-abc",
+		}))
+		Expect(client.waitCalls).To(Equal(3))
+	})
+
+	When("the files do not exist", func() {
+		BeforeEach(func() {
+			client.waitErr = soap.WrapVimFault(&vimtypes.FileNotFound{})
+		})
+		It("should not return an error", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.deleteFiles).To(HaveLen(2))
+		})
+	})
+
+	When("deleting a disk fails", func() {
+		BeforeEach(func() {
+			client.deleteDiskErr = errors.New("fubar")
+		})
+		It("should return an error", func() {
+			Expect(err).To(MatchError(
+				`failed to delete cached file "[my-datastore-1] vmi-1-abc/disk1.vmdk": fubar`))
+			Expect(client.deleteFiles).To(BeEmpty())
+		})
+	})
+})
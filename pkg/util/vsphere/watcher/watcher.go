@@ -39,6 +39,8 @@ func DefaultWatchedPropertyPaths() []string {
 
 		"config.extraConfig",
 		"config.hardware.device",
+		"config.hardware.memoryMB",
+		"config.hardware.numCPU",
 		"config.keyId",
 
 		// The following properties are omitted because it would cause too much
@@ -156,6 +158,15 @@ var defaultIgnoredExtraConfigKeys = []string{
 	// "guestinfo.vendordata",
 	// "guestinfo.vendordata.encoding",
 
+	//
+	// !! Do not ignore !!
+	//
+	// The guest publishes application metadata in the keys with the prefix
+	// "guestinfo.vmservice.status.", which are reported in the VM's
+	// status.guest.properties. If they change then the VM *should* be
+	// reconciled.
+	//
+
 	//
 	// !! Do not ignore !!
 	//
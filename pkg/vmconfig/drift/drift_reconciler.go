@@ -0,0 +1,437 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/paused"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig"
+)
+
+const (
+	// DriftDetectedReason is the reason of the event emitted when the set of
+	// diverged properties of a VM changes.
+	DriftDetectedReason = "DriftDetected"
+
+	numCPUProperty   = "config.hardware.numCPU"
+	memoryMBProperty = "config.hardware.memoryMB"
+	deviceProperty   = "config.hardware.device"
+
+	// guestInfoPrefix is the prefix of the ExtraConfig keys that may also be
+	// written by the guest, and therefore are not compared.
+	guestInfoPrefix = "guestinfo."
+)
+
+// Reconcile detects the properties of the vSphere VM that diverged from the
+// configuration derived from the VM's spec and class, and applies the VM's
+// drift policy to the ConfigSpec.
+func Reconcile(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vimClient *vim25.Client,
+	vm *vmopv1.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec) error {
+
+	return New().Reconcile(ctx, k8sClient, vimClient, vm, moVM, configSpec)
+}
+
+type reconciler struct{}
+
+var _ vmconfig.Reconciler = reconciler{}
+
+func New() vmconfig.Reconciler {
+	return reconciler{}
+}
+
+func (r reconciler) Name() string {
+	return "drift"
+}
+
+func (r reconciler) OnResult(
+	_ context.Context,
+	_ *vmopv1.VirtualMachine,
+	_ mo.VirtualMachine,
+	_ error) error {
+
+	return nil
+}
+
+func (r reconciler) Reconcile(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vimClient *vim25.Client,
+	vm *vmopv1.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec) error {
+
+	if ctx == nil {
+		panic("context is nil")
+	}
+	if k8sClient == nil {
+		panic("k8sClient is nil")
+	}
+	if vimClient == nil {
+		panic("vimClient is nil")
+	}
+	if vm == nil {
+		panic("vm is nil")
+	}
+	if configSpec == nil {
+		panic("configSpec is nil")
+	}
+
+	policy := vm.Spec.DriftPolicy
+	if policy == "" {
+		vm.Status.Drift = nil
+		conditions.Delete(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+		delete(vm.Annotations, LastAppliedAnnotationKey)
+		return nil
+	}
+
+	if moVM.Config == nil || paused.ByAdmin(moVM) {
+		// Drift is not detected while an admin has paused the VM since the
+		// admin may be changing the VM on purpose.
+		return nil
+	}
+
+	var (
+		drift []vmopv1.VirtualMachineDriftStatus
+		last  = getLastApplied(vm)
+	)
+
+	hwDrift, err := reconcileHardware(ctx, k8sClient, vm, moVM, configSpec, last)
+	if err != nil {
+		return err
+	}
+	drift = append(drift, hwDrift...)
+	drift = append(drift, reconcileExtraConfig(vm, moVM, configSpec, last)...)
+	drift = append(drift, reconcileNetworkInterfaces(vm, moVM, configSpec, last)...)
+
+	// The adopted values are recorded so they are neither reported nor
+	// reverted until they or the desired values change.
+	for _, d := range drift {
+		if d.Action == vmopv1.VirtualMachineDriftActionAdopted {
+			last.Adopted[d.Property] = adoptedValue{
+				Desired: d.Desired,
+				Actual:  d.Actual,
+			}
+		}
+	}
+
+	setLastApplied(vm, last)
+
+	slices.SortFunc(drift, func(a, b vmopv1.VirtualMachineDriftStatus) int {
+		return strings.Compare(a.Property, b.Property)
+	})
+
+	updateStatus(ctx, vm, drift)
+
+	return nil
+}
+
+// reconcileHardware compares the number of CPUs and the memory of the vSphere
+// VM with the VM's class. The class is not compared while the VM is being
+// resized to a different class.
+func reconcileHardware(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vm *vmopv1.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	last lastApplied) ([]vmopv1.VirtualMachineDriftStatus, error) {
+
+	if vm.Spec.ClassName == "" || moVM.Config.Hardware.NumCPU == 0 {
+		return nil, nil
+	}
+
+	var vmClass vmopv1.VirtualMachineClass
+	if err := k8sClient.Get(
+		ctx,
+		ctrlclient.ObjectKey{
+			Namespace: vm.Namespace,
+			Name:      vm.Spec.ClassName,
+		},
+		&vmClass); err != nil {
+
+		return nil, ctrlclient.IgnoreNotFound(err)
+	}
+
+	if vmopv1util.ResizeNeeded(*vm, vmClass) {
+		return nil, nil
+	}
+
+	var (
+		drift   []vmopv1.VirtualMachineDriftStatus
+		isOff   = moVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOff
+		policy  = vm.Spec.DriftPolicy
+		correct = policy == vmopv1.VirtualMachineDriftPolicyAutoCorrect && isOff
+	)
+
+	//nolint:gosec // disable G115
+	if desired, actual := int32(vmClass.Spec.Hardware.Cpus), moVM.Config.Hardware.NumCPU; desired > 0 &&
+		configSpec.NumCPUs == 0 {
+
+		d := vmopv1.VirtualMachineDriftStatus{
+			Property: numCPUProperty,
+			Desired:  strconv.Itoa(int(desired)),
+			Actual:   strconv.Itoa(int(actual)),
+			Action:   action(policy, correct),
+		}
+
+		switch {
+		case desired == actual:
+			delete(last.Adopted, d.Property)
+		case !last.isAdopted(policy, d.Property, d.Desired, d.Actual):
+			if correct {
+				configSpec.NumCPUs = desired
+			}
+			drift = append(drift, d)
+		}
+	}
+
+	if desired, actual := virtualmachine.MemoryQuantityToMb(vmClass.Spec.Hardware.Memory), int64(moVM.Config.Hardware.MemoryMB); desired > 0 &&
+		configSpec.MemoryMB == 0 {
+
+		d := vmopv1.VirtualMachineDriftStatus{
+			Property: memoryMBProperty,
+			Desired:  strconv.FormatInt(desired, 10),
+			Actual:   strconv.FormatInt(actual, 10),
+			Action:   action(policy, correct),
+		}
+
+		switch {
+		case desired == actual:
+			delete(last.Adopted, d.Property)
+		case !last.isAdopted(policy, d.Property, d.Desired, d.Actual):
+			if correct {
+				configSpec.MemoryMB = desired
+			}
+			drift = append(drift, d)
+		}
+	}
+
+	return drift, nil
+}
+
+// reconcileExtraConfig compares the ExtraConfig values the ConfigSpec would
+// change with the values of the vSphere VM. A value is only drift if the value
+// on the vSphere VM differs from the value last applied to it, and the desired
+// value is the same as the value last applied. Otherwise the value is changed
+// because the VM's spec or class changed, and the ConfigSpec is not modified.
+// New keys and removed keys are always changes to the VM's desired state. An
+// adopted value is not reverted.
+func reconcileExtraConfig(
+	vm *vmopv1.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	last lastApplied) []vmopv1.VirtualMachineDriftStatus {
+
+	var (
+		drift  []vmopv1.VirtualMachineDriftStatus
+		outEC  []vimtypes.BaseOptionValue
+		curEC  = object.OptionValueList(moVM.Config.ExtraConfig)
+		policy = vm.Spec.DriftPolicy
+	)
+
+	// The values whose reconfigure completed are no longer pending.
+	for key, applied := range last.ExtraConfig {
+		if actual, _ := curEC.GetString(key); applied.Pending && actual == applied.Value {
+			last.ExtraConfig[key] = appliedValue{Value: applied.Value}
+		}
+	}
+
+	for _, bov := range configSpec.ExtraConfig {
+		ov := bov.GetOptionValue()
+		desired, _ := ov.Value.(string)
+		actual, _ := curEC.GetString(ov.Key)
+		property := fmt.Sprintf("config.extraConfig[%q]", ov.Key)
+
+		if strings.HasPrefix(ov.Key, guestInfoPrefix) {
+			outEC = append(outEC, bov)
+			continue
+		}
+
+		if desired == "" {
+			delete(last.ExtraConfig, ov.Key)
+			delete(last.Adopted, property)
+			outEC = append(outEC, bov)
+			continue
+		}
+
+		// A pending value is applied again since the reconfigure that applied
+		// it may have failed.
+		if applied, ok := last.ExtraConfig[ov.Key]; !ok || applied.Pending ||
+			applied.Value != desired || actual == "" || actual == desired {
+
+			last.ExtraConfig[ov.Key] = appliedValue{
+				Value:   desired,
+				Pending: actual != desired,
+			}
+			delete(last.Adopted, property)
+			outEC = append(outEC, bov)
+			continue
+		}
+
+		if last.isAdopted(policy, property, desired, actual) {
+			continue
+		}
+
+		correct := policy == vmopv1.VirtualMachineDriftPolicyAutoCorrect
+		if correct {
+			last.ExtraConfig[ov.Key] = appliedValue{
+				Value:   desired,
+				Pending: true,
+			}
+			outEC = append(outEC, bov)
+		}
+		drift = append(drift, vmopv1.VirtualMachineDriftStatus{
+			Property: property,
+			Desired:  desired,
+			Actual:   actual,
+			Action:   action(policy, correct),
+		})
+	}
+
+	if len(outEC) != len(configSpec.ExtraConfig) {
+		configSpec.ExtraConfig = outEC
+	}
+
+	return drift
+}
+
+// reconcileNetworkInterfaces compares the number of network interfaces of the
+// vSphere VM with the VM's spec. Only the network interfaces that were added
+// out of band are reported, and they are never removed, since removing a
+// network interface may disconnect the guest.
+func reconcileNetworkInterfaces(
+	vm *vmopv1.VirtualMachine,
+	moVM mo.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	last lastApplied) []vmopv1.VirtualMachineDriftStatus {
+
+	if vm.Spec.Network == nil || vm.Spec.Network.Disabled {
+		return nil
+	}
+
+	for _, bdc := range configSpec.DeviceChange {
+		if dc := bdc.GetVirtualDeviceConfigSpec(); dc != nil {
+			if _, ok := dc.Device.(vimtypes.BaseVirtualEthernetCard); ok {
+				// The network interfaces are being changed to match the
+				// VM's spec.
+				return nil
+			}
+		}
+	}
+
+	var (
+		desired = len(vm.Spec.Network.Interfaces)
+		actual  = len(object.VirtualDeviceList(moVM.Config.Hardware.Device).
+			SelectByType((*vimtypes.VirtualEthernetCard)(nil)))
+	)
+
+	if actual <= desired {
+		delete(last.Adopted, deviceProperty)
+		return nil
+	}
+
+	d := vmopv1.VirtualMachineDriftStatus{
+		Property: deviceProperty,
+		Desired:  fmt.Sprintf("%d network interfaces", desired),
+		Actual:   fmt.Sprintf("%d network interfaces", actual),
+		Action:   action(vm.Spec.DriftPolicy, false),
+	}
+	if last.isAdopted(vm.Spec.DriftPolicy, d.Property, d.Desired, d.Actual) {
+		return nil
+	}
+
+	return []vmopv1.VirtualMachineDriftStatus{d}
+}
+
+func action(
+	policy vmopv1.VirtualMachineDriftPolicy,
+	corrected bool) vmopv1.VirtualMachineDriftAction {
+
+	switch {
+	case corrected:
+		return vmopv1.VirtualMachineDriftActionCorrected
+	case policy == vmopv1.VirtualMachineDriftPolicyAdopt:
+		return vmopv1.VirtualMachineDriftActionAdopted
+	default:
+		return vmopv1.VirtualMachineDriftActionReported
+	}
+}
+
+// updateStatus sets the VM's drift status and DriftDetected condition, and
+// emits an event when the diverged properties or their values change.
+func updateStatus(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	drift []vmopv1.VirtualMachineDriftStatus) {
+
+	changed := !slices.EqualFunc(
+		vm.Status.Drift,
+		drift,
+		func(a, b vmopv1.VirtualMachineDriftStatus) bool {
+			return a.Property == b.Property && a.Actual == b.Actual
+		})
+
+	vm.Status.Drift = drift
+
+	if len(drift) == 0 {
+		conditions.Delete(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+		return
+	}
+
+	var (
+		reason = vmopv1.VirtualMachineDriftReportedReason
+		props  = make([]string, len(drift))
+	)
+	for i, d := range drift {
+		switch d.Action {
+		case vmopv1.VirtualMachineDriftActionCorrected:
+			reason = vmopv1.VirtualMachineDriftCorrectedReason
+		case vmopv1.VirtualMachineDriftActionAdopted:
+			if reason == vmopv1.VirtualMachineDriftReportedReason {
+				reason = vmopv1.VirtualMachineDriftAdoptedReason
+			}
+		}
+		props[i] = fmt.Sprintf("%s: desired=%q, actual=%q (%s)",
+			d.Property, d.Desired, d.Actual, d.Action)
+	}
+	msg := strings.Join(props, "; ")
+
+	conditions.Set(vm, &metav1.Condition{
+		Type:    vmopv1.VirtualMachineDriftDetectedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+
+	if changed {
+		if reason == vmopv1.VirtualMachineDriftAdoptedReason {
+			record.FromContext(ctx).Eventf(vm, DriftDetectedReason, "%s", msg)
+		} else {
+			record.FromContext(ctx).Warnf(vm, DriftDetectedReason, "%s", msg)
+		}
+	}
+}
@@ -0,0 +1,619 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apirecord "k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig/drift"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("New", func() {
+	It("should return a reconciler", func() {
+		Expect(drift.New()).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("Name", func() {
+	It("should return 'drift'", func() {
+		Expect(drift.New().Name()).To(Equal("drift"))
+	})
+})
+
+var _ = Describe("OnResult", func() {
+	It("should return nil", func() {
+		var ctx context.Context
+		Expect(drift.New().OnResult(ctx, nil, mo.VirtualMachine{}, nil)).To(Succeed())
+	})
+})
+
+var _ = Describe("Reconcile", func() {
+
+	var (
+		r          vmconfig.Reconciler
+		ctx        context.Context
+		recorder   *apirecord.FakeRecorder
+		k8sClient  ctrlclient.Client
+		vimClient  *vim25.Client
+		moVM       mo.VirtualMachine
+		vm         *vmopv1.VirtualMachine
+		vmClass    *vmopv1.VirtualMachineClass
+		withObjs   []ctrlclient.Object
+		configSpec *vimtypes.VirtualMachineConfigSpec
+		err        error
+	)
+
+	BeforeEach(func() {
+		r = drift.New()
+
+		recorder = apirecord.NewFakeRecorder(10)
+		ctx = pkgcfg.NewContextWithDefaultConfig()
+		ctx = record.WithContext(ctx, record.New(recorder))
+
+		vimClient = &vim25.Client{}
+
+		moVM = mo.VirtualMachine{
+			Config: &vimtypes.VirtualMachineConfigInfo{
+				Hardware: vimtypes.VirtualHardware{
+					NumCPU:   2,
+					MemoryMB: 4096,
+				},
+			},
+			Runtime: vimtypes.VirtualMachineRuntimeInfo{
+				PowerState: vimtypes.VirtualMachinePowerStatePoweredOff,
+			},
+		}
+
+		configSpec = &vimtypes.VirtualMachineConfigSpec{}
+
+		vmClass = &vmopv1.VirtualMachineClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-namespace",
+				Name:      "my-class",
+			},
+			Spec: vmopv1.VirtualMachineClassSpec{
+				Hardware: vmopv1.VirtualMachineClassHardware{
+					Cpus:   2,
+					Memory: resource.MustParse("4Gi"),
+				},
+			},
+		}
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-namespace",
+				Name:      "my-vm",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName:   vmClass.Name,
+				DriftPolicy: vmopv1.VirtualMachineDriftPolicyReportOnly,
+			},
+		}
+
+		withObjs = []ctrlclient.Object{vmClass}
+	})
+
+	JustBeforeEach(func() {
+		k8sClient = builder.NewFakeClient(withObjs...)
+		err = r.Reconcile(ctx, k8sClient, vimClient, vm, moVM, configSpec)
+	})
+
+	When("there is no drift", func() {
+		BeforeEach(func() {
+			vm.Status.Drift = []vmopv1.VirtualMachineDriftStatus{
+				{
+					Property: "config.hardware.numCPU",
+					Desired:  "2",
+					Actual:   "4",
+					Action:   vmopv1.VirtualMachineDriftActionReported,
+				},
+			}
+			conditions.MarkTrue(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+		})
+		It("should clear the drift status and condition", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Status.Drift).To(BeEmpty())
+			Expect(conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)).To(BeNil())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	When("the drift policy is not set", func() {
+		BeforeEach(func() {
+			vm.Spec.DriftPolicy = ""
+			moVM.Config.Hardware.NumCPU = 4
+			vm.Status.Drift = []vmopv1.VirtualMachineDriftStatus{
+				{
+					Property: "config.hardware.numCPU",
+				},
+			}
+			conditions.MarkTrue(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+		})
+		It("should not detect drift", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Status.Drift).To(BeNil())
+			Expect(conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)).To(BeNil())
+			Expect(configSpec.NumCPUs).To(BeZero())
+		})
+	})
+
+	When("the VM is paused by an admin", func() {
+		BeforeEach(func() {
+			moVM.Config.Hardware.NumCPU = 4
+			moVM.Config.ExtraConfig = []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{
+					Key:   vmopv1.PauseVMExtraConfigKey,
+					Value: "True",
+				},
+			}
+		})
+		It("should not detect drift", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Status.Drift).To(BeNil())
+			Expect(conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)).To(BeNil())
+		})
+	})
+
+	When("the hardware diverged", func() {
+		BeforeEach(func() {
+			moVM.Config.Hardware.NumCPU = 4
+			moVM.Config.Hardware.MemoryMB = 8192
+		})
+
+		When("the policy is ReportOnly", func() {
+			It("should report the drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.NumCPUs).To(BeZero())
+				Expect(configSpec.MemoryMB).To(BeZero())
+				Expect(vm.Status.Drift).To(Equal([]vmopv1.VirtualMachineDriftStatus{
+					{
+						Property: "config.hardware.memoryMB",
+						Desired:  "4096",
+						Actual:   "8192",
+						Action:   vmopv1.VirtualMachineDriftActionReported,
+					},
+					{
+						Property: "config.hardware.numCPU",
+						Desired:  "2",
+						Actual:   "4",
+						Action:   vmopv1.VirtualMachineDriftActionReported,
+					},
+				}))
+
+				c := conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineDriftReportedReason))
+				Expect(c.Message).To(Equal(
+					`config.hardware.memoryMB: desired="4096", actual="8192" (Reported); ` +
+						`config.hardware.numCPU: desired="2", actual="4" (Reported)`))
+
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(HavePrefix("Warning DriftDetected config.hardware.memoryMB"))
+			})
+
+			When("the drift was already reported", func() {
+				BeforeEach(func() {
+					vm.Status.Drift = []vmopv1.VirtualMachineDriftStatus{
+						{
+							Property: "config.hardware.memoryMB",
+							Actual:   "8192",
+						},
+						{
+							Property: "config.hardware.numCPU",
+							Actual:   "4",
+						},
+					}
+				})
+				It("should not emit another event", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Drift).To(HaveLen(2))
+					Expect(recorder.Events).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the policy is AutoCorrect", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAutoCorrect
+			})
+
+			When("the VM is powered off", func() {
+				It("should correct the drift", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(configSpec.NumCPUs).To(Equal(int32(2)))
+					Expect(configSpec.MemoryMB).To(Equal(int64(4096)))
+					Expect(vm.Status.Drift).To(HaveLen(2))
+					Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionCorrected))
+					Expect(vm.Status.Drift[1].Action).To(Equal(vmopv1.VirtualMachineDriftActionCorrected))
+
+					c := conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineDriftCorrectedReason))
+				})
+			})
+
+			When("the VM is powered on", func() {
+				BeforeEach(func() {
+					moVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOn
+				})
+				It("should only report the drift", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(configSpec.NumCPUs).To(BeZero())
+					Expect(configSpec.MemoryMB).To(BeZero())
+					Expect(vm.Status.Drift).To(HaveLen(2))
+					Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionReported))
+				})
+			})
+		})
+
+		When("the policy is Adopt", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAdopt
+			})
+			It("should adopt the drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.NumCPUs).To(BeZero())
+				Expect(vm.Status.Drift).To(HaveLen(2))
+				Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionAdopted))
+
+				c := conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineDriftAdoptedReason))
+
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(HavePrefix("Normal DriftDetected"))
+
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"adopted":{"config.hardware.memoryMB":{"desired":"4096","actual":"8192"},"config.hardware.numCPU":{"desired":"2","actual":"4"}}}`))
+			})
+
+			When("the values were already adopted", func() {
+				BeforeEach(func() {
+					vm.Annotations = map[string]string{
+						drift.LastAppliedAnnotationKey: `{"adopted":{"config.hardware.memoryMB":{"desired":"4096","actual":"8192"},"config.hardware.numCPU":{"desired":"2","actual":"4"}}}`,
+					}
+					conditions.MarkTrue(vm, vmopv1.VirtualMachineDriftDetectedCondition)
+				})
+				It("should neither report nor revert them", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(configSpec.NumCPUs).To(BeZero())
+					Expect(configSpec.MemoryMB).To(BeZero())
+					Expect(vm.Status.Drift).To(BeEmpty())
+					Expect(conditions.Get(vm, vmopv1.VirtualMachineDriftDetectedCondition)).To(BeNil())
+					Expect(recorder.Events).To(BeEmpty())
+					Expect(vm.Annotations).To(HaveKey(drift.LastAppliedAnnotationKey))
+				})
+
+				When("an adopted value changed again", func() {
+					BeforeEach(func() {
+						moVM.Config.Hardware.NumCPU = 8
+					})
+					It("should adopt the new value", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.Drift).To(Equal([]vmopv1.VirtualMachineDriftStatus{
+							{
+								Property: "config.hardware.numCPU",
+								Desired:  "2",
+								Actual:   "8",
+								Action:   vmopv1.VirtualMachineDriftActionAdopted,
+							},
+						}))
+						Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+							`{"adopted":{"config.hardware.memoryMB":{"desired":"4096","actual":"8192"},"config.hardware.numCPU":{"desired":"2","actual":"8"}}}`))
+					})
+				})
+
+				When("the policy is no longer Adopt", func() {
+					BeforeEach(func() {
+						vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyReportOnly
+					})
+					It("should report the drift", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.Drift).To(HaveLen(2))
+						Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionReported))
+						Expect(vm.Annotations).ToNot(HaveKey(drift.LastAppliedAnnotationKey))
+					})
+				})
+			})
+		})
+
+		When("the VM is being resized to another class", func() {
+			BeforeEach(func() {
+				vm.Annotations = map[string]string{
+					vmopv1.VirtualMachineSameVMClassResizeAnnotation: "",
+				}
+			})
+			It("should not detect drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Status.Drift).To(BeEmpty())
+			})
+		})
+
+		When("the class does not exist", func() {
+			BeforeEach(func() {
+				withObjs = nil
+			})
+			It("should not detect drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Status.Drift).To(BeEmpty())
+			})
+		})
+	})
+
+	When("the ExtraConfig diverged", func() {
+		BeforeEach(func() {
+			moVM.Config.ExtraConfig = []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{Key: "key1", Value: "changed"},
+				&vimtypes.OptionValue{Key: "guestinfo.key2", Value: "changed"},
+				&vimtypes.OptionValue{Key: "key4", Value: "val4"},
+			}
+			configSpec.ExtraConfig = []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{Key: "key1", Value: "val1"},
+				&vimtypes.OptionValue{Key: "guestinfo.key2", Value: "val2"},
+				&vimtypes.OptionValue{Key: "key3", Value: "val3"},
+			}
+			vm.Annotations = map[string]string{
+				drift.LastAppliedAnnotationKey: `{"extraConfig":{"key1":{"value":"val1"},"key4":{"value":"val4","pending":true}}}`,
+			}
+		})
+
+		When("the policy is ReportOnly", func() {
+			It("should report the drift and not change the value", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(ConsistOf(
+					&vimtypes.OptionValue{Key: "guestinfo.key2", Value: "val2"},
+					&vimtypes.OptionValue{Key: "key3", Value: "val3"},
+				))
+				Expect(vm.Status.Drift).To(Equal([]vmopv1.VirtualMachineDriftStatus{
+					{
+						Property: `config.extraConfig["key1"]`,
+						Desired:  "val1",
+						Actual:   "changed",
+						Action:   vmopv1.VirtualMachineDriftActionReported,
+					},
+				}))
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"extraConfig":{"key1":{"value":"val1"},"key3":{"value":"val3","pending":true},"key4":{"value":"val4"}}}`))
+			})
+		})
+
+		When("the policy is AutoCorrect", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAutoCorrect
+			})
+			It("should correct the drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Status.Drift).To(HaveLen(1))
+				Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionCorrected))
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"extraConfig":{"key1":{"value":"val1","pending":true},"key3":{"value":"val3","pending":true},"key4":{"value":"val4"}}}`))
+			})
+		})
+
+		When("the policy is Adopt", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAdopt
+			})
+			It("should adopt the drift and not change the value", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(2))
+				Expect(vm.Status.Drift).To(HaveLen(1))
+				Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionAdopted))
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"extraConfig":{"key1":{"value":"val1"},"key3":{"value":"val3","pending":true},"key4":{"value":"val4"}},`+
+						`"adopted":{"config.extraConfig[\"key1\"]":{"desired":"val1","actual":"changed"}}}`))
+			})
+
+			When("the value was already adopted", func() {
+				BeforeEach(func() {
+					vm.Annotations[drift.LastAppliedAnnotationKey] = `{"extraConfig":{"key1":{"value":"val1"}},` +
+						`"adopted":{"config.extraConfig[\"key1\"]":{"desired":"val1","actual":"changed"}}}`
+				})
+				It("should neither report nor revert it", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(configSpec.ExtraConfig).To(HaveLen(2))
+					Expect(vm.Status.Drift).To(BeEmpty())
+				})
+
+				When("the desired value changed", func() {
+					BeforeEach(func() {
+						configSpec.ExtraConfig[0] = &vimtypes.OptionValue{Key: "key1", Value: "new"}
+					})
+					It("should apply the value", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(configSpec.ExtraConfig).To(HaveLen(3))
+						Expect(vm.Status.Drift).To(BeEmpty())
+						Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+							`{"extraConfig":{"key1":{"value":"new","pending":true},"key3":{"value":"val3","pending":true}}}`))
+					})
+				})
+			})
+		})
+
+		When("the desired value changed since it was last applied", func() {
+			BeforeEach(func() {
+				vm.Annotations[drift.LastAppliedAnnotationKey] = `{"extraConfig":{"key1":{"value":"changed"}}}`
+			})
+			It("should apply the value and not report drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Status.Drift).To(BeEmpty())
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"extraConfig":{"key1":{"value":"val1","pending":true},"key3":{"value":"val3","pending":true}}}`))
+			})
+		})
+
+		When("the value was not applied before", func() {
+			BeforeEach(func() {
+				vm.Annotations = nil
+			})
+			It("should apply the value and not report drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Status.Drift).To(BeEmpty())
+			})
+		})
+
+		When("the last applied value is pending", func() {
+			BeforeEach(func() {
+				vm.Annotations[drift.LastAppliedAnnotationKey] = `{"extraConfig":{"key1":{"value":"val1","pending":true}}}`
+			})
+			It("should apply the value again and not report drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Status.Drift).To(BeEmpty())
+			})
+		})
+
+		When("the value is removed", func() {
+			BeforeEach(func() {
+				configSpec.ExtraConfig[0] = &vimtypes.OptionValue{Key: "key1", Value: ""}
+			})
+			It("should remove the value and not report drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Status.Drift).To(BeEmpty())
+				Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+					`{"extraConfig":{"key3":{"value":"val3","pending":true},"key4":{"value":"val4"}}}`))
+			})
+		})
+
+		When("the drift policy is not set", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = ""
+			})
+			It("should remove the annotation", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.ExtraConfig).To(HaveLen(3))
+				Expect(vm.Annotations).ToNot(HaveKey(drift.LastAppliedAnnotationKey))
+			})
+		})
+	})
+
+	When("network interfaces were added", func() {
+		BeforeEach(func() {
+			vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{
+						Name: "eth0",
+					},
+				},
+			}
+			moVM.Config.Hardware.Device = []vimtypes.BaseVirtualDevice{
+				&vimtypes.VirtualVmxnet3{},
+				&vimtypes.VirtualE1000{},
+			}
+		})
+
+		It("should report the drift", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Status.Drift).To(Equal([]vmopv1.VirtualMachineDriftStatus{
+				{
+					Property: "config.hardware.device",
+					Desired:  "1 network interfaces",
+					Actual:   "2 network interfaces",
+					Action:   vmopv1.VirtualMachineDriftActionReported,
+				},
+			}))
+		})
+
+		When("the policy is Adopt", func() {
+			BeforeEach(func() {
+				vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAdopt
+			})
+			It("should adopt the drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Status.Drift).To(HaveLen(1))
+				Expect(vm.Status.Drift[0].Action).To(Equal(vmopv1.VirtualMachineDriftActionAdopted))
+				Expect(vm.Annotations).To(HaveKey(drift.LastAppliedAnnotationKey))
+			})
+
+			When("the network interfaces were already adopted", func() {
+				BeforeEach(func() {
+					vm.Annotations = map[string]string{
+						drift.LastAppliedAnnotationKey: `{"adopted":{"config.hardware.device":{"desired":"1 network interfaces","actual":"2 network interfaces"}}}`,
+					}
+				})
+				It("should not report them", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Drift).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the network interfaces are being changed", func() {
+			BeforeEach(func() {
+				configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+					&vimtypes.VirtualDeviceConfigSpec{
+						Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+						Device:    &vimtypes.VirtualE1000{},
+					},
+				}
+			})
+			It("should not detect drift", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Status.Drift).To(BeEmpty())
+			})
+		})
+	})
+})
+
+var _ = Describe("SetLastAppliedExtraConfig", func() {
+
+	var (
+		vm          *vmopv1.VirtualMachine
+		extraConfig []vimtypes.BaseOptionValue
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				DriftPolicy: vmopv1.VirtualMachineDriftPolicyReportOnly,
+			},
+		}
+		extraConfig = []vimtypes.BaseOptionValue{
+			&vimtypes.OptionValue{Key: "key1", Value: "val1"},
+			&vimtypes.OptionValue{Key: "guestinfo.key2", Value: "val2"},
+			&vimtypes.OptionValue{Key: "key3", Value: ""},
+		}
+	})
+
+	JustBeforeEach(func() {
+		drift.SetLastAppliedExtraConfig(vm, extraConfig)
+	})
+
+	It("should record the values as pending", func() {
+		Expect(vm.Annotations).To(HaveKeyWithValue(drift.LastAppliedAnnotationKey,
+			`{"extraConfig":{"key1":{"value":"val1","pending":true}}}`))
+	})
+
+	When("the drift policy is not set", func() {
+		BeforeEach(func() {
+			vm.Spec.DriftPolicy = ""
+		})
+		It("should not record the values", func() {
+			Expect(vm.Annotations).To(BeEmpty())
+		})
+	})
+})
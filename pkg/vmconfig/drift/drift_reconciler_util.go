@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"encoding/json"
+	"strings"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+)
+
+// LastAppliedAnnotationKey is the annotation that records the ExtraConfig
+// values VM Operator last applied to the vSphere VM, and the values of the
+// vSphere VM that were adopted. A value on the vSphere VM is only drift if it
+// differs from the value last applied to it, so the changes to the VM's spec
+// and class are not mistaken for drift.
+const LastAppliedAnnotationKey = vmopv1.GroupName + "/drift-last-applied"
+
+// lastApplied is the value of the LastAppliedAnnotationKey annotation.
+type lastApplied struct {
+	// ExtraConfig are the ExtraConfig values last applied to the vSphere VM,
+	// keyed by their keys.
+	ExtraConfig map[string]appliedValue `json:"extraConfig,omitempty"`
+
+	// Adopted are the values of the vSphere VM that were adopted, keyed by
+	// the path of their property, ex. config.hardware.numCPU.
+	Adopted map[string]adoptedValue `json:"adopted,omitempty"`
+}
+
+// appliedValue is a value applied to the vSphere VM.
+type appliedValue struct {
+	// Value is the value applied to the vSphere VM.
+	Value string `json:"value"`
+
+	// Pending is true until Value is observed on the vSphere VM, ex. while
+	// the reconfigure that applies it has not completed.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// adoptedValue is a value of the vSphere VM that was adopted instead of the
+// desired value.
+type adoptedValue struct {
+	// Desired is the value derived from the VM's spec and class when the
+	// value was adopted.
+	Desired string `json:"desired"`
+
+	// Actual is the adopted value.
+	Actual string `json:"actual"`
+}
+
+// isAdopted returns true if the value of the property on the vSphere VM was
+// adopted for the same desired value, so it is neither reported nor reverted.
+func (la lastApplied) isAdopted(
+	policy vmopv1.VirtualMachineDriftPolicy,
+	property, desired, actual string) bool {
+
+	a, ok := la.Adopted[property]
+	return ok && policy == vmopv1.VirtualMachineDriftPolicyAdopt &&
+		a.Desired == desired && a.Actual == actual
+}
+
+// getLastApplied returns the values recorded in the VM's
+// LastAppliedAnnotationKey annotation. An invalid annotation is ignored.
+func getLastApplied(vm *vmopv1.VirtualMachine) lastApplied {
+	var la lastApplied
+	if val, ok := vm.Annotations[LastAppliedAnnotationKey]; ok {
+		_ = json.Unmarshal([]byte(val), &la)
+	}
+	if la.ExtraConfig == nil {
+		la.ExtraConfig = map[string]appliedValue{}
+	}
+	if la.Adopted == nil || vm.Spec.DriftPolicy != vmopv1.VirtualMachineDriftPolicyAdopt {
+		la.Adopted = map[string]adoptedValue{}
+	}
+	return la
+}
+
+// setLastApplied records the values in the VM's LastAppliedAnnotationKey
+// annotation, or removes the annotation if there are none.
+func setLastApplied(vm *vmopv1.VirtualMachine, la lastApplied) {
+	if len(la.ExtraConfig) == 0 && len(la.Adopted) == 0 {
+		delete(vm.Annotations, LastAppliedAnnotationKey)
+		return
+	}
+
+	b, err := json.Marshal(la)
+	if err != nil {
+		return
+	}
+
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[LastAppliedAnnotationKey] = string(b)
+}
+
+// SetLastAppliedExtraConfig records the ExtraConfig values the vSphere VM is
+// created with in the VM's LastAppliedAnnotationKey annotation when the VM has
+// a drift policy, so a value that is later changed out of band is detected as
+// drift.
+func SetLastAppliedExtraConfig(
+	vm *vmopv1.VirtualMachine,
+	extraConfig []vimtypes.BaseOptionValue) {
+
+	if vm.Spec.DriftPolicy == "" {
+		return
+	}
+
+	la := lastApplied{
+		ExtraConfig: map[string]appliedValue{},
+	}
+	for _, bov := range extraConfig {
+		ov := bov.GetOptionValue()
+		value, _ := ov.Value.(string)
+		if strings.HasPrefix(ov.Key, guestInfoPrefix) || value == "" {
+			continue
+		}
+		la.ExtraConfig[ov.Key] = appliedValue{
+			Value:   value,
+			Pending: true,
+		}
+	}
+
+	setLastApplied(vm, la)
+}
@@ -5,6 +5,7 @@
 package builder
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	clientgorecord "k8s.io/client-go/tools/record"
@@ -15,6 +16,7 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 	imgregv1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha2"
 	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
 	appv1a1 "github.com/vmware-tanzu/vm-operator/external/appplatform/api/v1alpha1"
@@ -57,13 +59,20 @@ func KnownObjectTypes() []client.Object {
 	return []client.Object{
 		&vmopv1.VirtualMachine{},
 		&vmopv1.VirtualMachineGroup{},
+		&vmopv1.VirtualMachineGuestOperation{},
 		&vmopv1.VirtualMachineService{},
 		&vmopv1.VirtualMachineClass{},
 		&vmopv1.VirtualMachineClassInstance{},
+		&vmopv1.VirtualMachinePlacementRequest{},
 		&vmopv1.VirtualMachinePublishRequest{},
 		&vmopv1.ClusterVirtualMachineImage{},
 		&vmopv1.VirtualMachineImage{},
 		&vmopv1.VirtualMachineImageCache{},
+		&vmopv1.VirtualMachineImageImport{},
+		&vmopv1.VirtualMachineImageStream{},
+		&vmopv1.VirtualMachineIPPool{},
+		&vmopv1.VirtualMachineNetworkPolicy{},
+		&vmopv1.VirtualMachinePowerSchedule{},
 		&vmopv1.VirtualMachineWebConsoleRequest{},
 		&vmopv1.VirtualMachineSnapshot{},
 		&vmopv1a1.WebConsoleRequest{},
@@ -77,12 +86,15 @@ func KnownObjectTypes() []client.Object {
 		&vpcv1alpha1.Subnet{},
 		&vpcv1alpha1.SubnetSet{},
 		&vpcv1alpha1.SubnetPort{},
+		&vpcv1alpha1.SecurityPolicy{},
+		&nsxv1alpha1.SecurityPolicy{},
 		&byokv1.EncryptionClass{},
 		&capv1.Capabilities{},
 		&appv1a1.SupervisorProperties{},
 		&vspherepolv1.ComputePolicy{},
 		&vspherepolv1.PolicyEvaluation{},
 		&vspherepolv1.TagPolicy{},
+		&apiextensionsv1.CustomResourceDefinition{},
 	}
 }
 
@@ -95,6 +107,7 @@ func NewFakeRecorder() (record.Recorder, chan string) {
 func NewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
 	_ = vmopapi.AddToScheme(scheme)
 	_ = capv1.AddToScheme(scheme)
 	_ = byokv1.AddToScheme(scheme)
@@ -107,6 +120,7 @@ func NewScheme() *runtime.Scheme {
 	_ = imgregv1a1.AddToScheme(scheme)
 	_ = imgregv1.AddToScheme(scheme)
 	_ = vpcv1alpha1.AddToScheme(scheme)
+	_ = nsxv1alpha1.AddToScheme(scheme)
 	_ = vspherepolv1.AddToScheme(scheme)
 	return scheme
 }
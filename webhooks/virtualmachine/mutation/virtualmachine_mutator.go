@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -32,6 +33,7 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
 	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/admissionpolicy"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
@@ -57,6 +59,8 @@ const (
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagestreams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineadmissionpolicies,verbs=get;list;watch
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -137,12 +141,14 @@ func NewMutator(client ctrlclient.Client) builder.Mutator {
 	return mutator{
 		client:    client,
 		converter: runtime.DefaultUnstructuredConverter,
+		policies:  admissionpolicy.NewEvaluator(client),
 	}
 }
 
 type mutator struct {
 	client    ctrlclient.Client
 	converter runtime.UnstructuredConverter
+	policies  *admissionpolicy.Evaluator
 }
 
 // ResolveClassAndClassName resolves the spec.class and
@@ -262,6 +268,11 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 	case admissionv1.Create:
 		// SetCreatedAtAnnotations always mutates the VM on create.
 		wasMutated = true
+		// The profiles are applied first so the values from the VM and its
+		// profiles take precedence over the built-in defaults.
+		if _, err := ApplyProfiles(ctx, m.client, modified, nil); err != nil {
+			return admission.Denied(err.Error())
+		}
 		AddDefaultNetworkInterface(ctx, m.client, modified)
 		SetDefaultPowerState(ctx, m.client, modified)
 		SetDefaultCdromImgKindOnCreate(ctx, modified)
@@ -272,6 +283,9 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 		if _, err := SetDefaultBiosUUID(ctx, m.client, modified); err != nil {
 			return admission.Denied(err.Error())
 		}
+		if _, err := ResolveImageStreamOnCreate(ctx, m.client, modified); err != nil {
+			return admission.Denied(err.Error())
+		}
 		if _, err := ResolveImageNameOnCreate(ctx, m.client, modified); err != nil {
 			return admission.Denied(err.Error())
 		}
@@ -312,6 +326,12 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 			return admission.Errored(http.StatusInternalServerError, err)
 		}
 
+		if ok, err := ApplyProfiles(ctx, m.client, modified, oldVM); err != nil {
+			return admission.Denied(err.Error())
+		} else if ok {
+			wasMutated = true
+		}
+
 		if pkgcfg.FromContext(ctx).Features.ImmutableClasses {
 			if _, err := ResolveClassAndClassName(ctx, m.client, modified); err != nil {
 				return admission.Denied(err.Error())
@@ -401,16 +421,62 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 		}
 	}
 
+	// The defaults from the admission policies are applied last so their
+	// expressions are evaluated against the otherwise mutated VM.
+	result, err := m.mutateAdmissionPolicies(ctx, modified)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(result.Denials) > 0 {
+		response := admission.Denied(strings.Join(result.Denials, ", "))
+		response.AuditAnnotations = result.AuditAnnotations
+		return response
+	}
+	if result.Mutated {
+		wasMutated = true
+	}
+
+	var response admission.Response
 	if !wasMutated {
-		return admission.Allowed("")
+		response = admission.Allowed("")
+	} else {
+		rawModified, err := json.Marshal(modified)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		response = admission.PatchResponseFromRaw(ctx.RawObj, rawModified)
 	}
 
-	rawModified, err := json.Marshal(modified)
-	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, err)
+	response.AuditAnnotations = result.AuditAnnotations
+	return response.WithWarnings(result.Warnings...)
+}
+
+// mutateAdmissionPolicies applies the defaults of the
+// VirtualMachineAdmissionPolicy resources to the VM. Requests from VM
+// Operator and for VMs that are being deleted are not evaluated so that the
+// policies cannot prevent VMs from being reconciled.
+func (m mutator) mutateAdmissionPolicies(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) (admissionpolicy.Result, error) {
+
+	if ctx.IsVMOperatorAccount || !vm.DeletionTimestamp.IsZero() {
+		return admissionpolicy.Result{}, nil
 	}
 
-	return admission.PatchResponseFromRaw(ctx.RawObj, rawModified)
+	req := admissionpolicy.Request{
+		Operation: ctx.Op,
+		UserInfo:  ctx.UserInfo,
+		VM:        vm,
+	}
+	if ctx.Op == admissionv1.Update {
+		oldVM, err := m.vmFromUnstructured(ctx.OldObj)
+		if err != nil {
+			return admissionpolicy.Result{}, err
+		}
+		req.OldVM = oldVM
+	}
+
+	return m.policies.Mutate(ctx, req)
 }
 
 func (m mutator) For() schema.GroupVersionKind {
@@ -704,8 +770,55 @@ const (
 	vmiKind            = "VirtualMachineImage"
 	cvmiKind           = "Cluster" + vmiKind
 	imgNameNotMatchRef = "must refer to the same resource as spec.image"
+	imgStreamNotReady  = "image stream %q has not resolved an image"
 )
 
+// ResolveImageStreamOnCreate replaces a reference to a
+// VirtualMachineImageStream in vm.spec.image with the stream's current image.
+// The stream is recorded in the VM's image-stream annotation. If
+// vm.spec.imageName refers to the stream, it is set to the name of the
+// resolved image as well.
+func ResolveImageStreamOnCreate(
+	ctx *pkgctx.WebhookRequestContext,
+	c ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) (bool, error) {
+
+	if vm.Spec.Image == nil || vm.Spec.Image.Kind != vmopv1.VirtualMachineImageStreamKind {
+		return false, nil
+	}
+
+	imagePath := field.NewPath("spec", "image")
+
+	var stream vmopv1.VirtualMachineImageStream
+	if err := c.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: vm.Spec.Image.Name},
+		&stream); err != nil {
+
+		if apierrors.IsNotFound(err) {
+			return false, field.NotFound(imagePath.Child("name"), vm.Spec.Image.Name)
+		}
+		return false, err
+	}
+
+	if stream.Status.Image == nil {
+		return false, field.Invalid(imagePath.Child("name"), vm.Spec.Image.Name,
+			fmt.Sprintf(imgStreamNotReady, stream.Name))
+	}
+
+	if vm.Spec.ImageName == stream.Name {
+		vm.Spec.ImageName = stream.Status.Image.Name
+	}
+	vm.Spec.Image = stream.Status.Image.DeepCopy()
+
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[vmopv1.VirtualMachineImageStreamAnnotation] = stream.Name
+
+	return true, nil
+}
+
 // ResolveImageNameOnCreate ensures vm.spec.image is set to a non-empty value if
 // vm.spec.imageName is also non-empty.
 func ResolveImageNameOnCreate(
@@ -0,0 +1,131 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func admissionPolicyTests() {
+	Describe(
+		"Admission Policy",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		admissionPolicyMutationTests,
+	)
+}
+
+func admissionPolicyMutationTests() {
+	var (
+		ctx      *unitMutationWebhookContext
+		policy   *vmopv1.VirtualMachineAdmissionPolicy
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForMutatingWebhook()
+		ctx.vm.Spec.StorageClass = ""
+		policy = &vmopv1.VirtualMachineAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default-storage-class",
+			},
+			Spec: vmopv1.VirtualMachineAdmissionPolicySpec{
+				Mode:   vmopv1.VirtualMachineAdmissionPolicyModeMutate,
+				Action: vmopv1.VirtualMachineAdmissionPolicyActionDeny,
+				Operations: []vmopv1.VirtualMachineAdmissionPolicyOperation{
+					vmopv1.VirtualMachineAdmissionPolicyOperationUpdate,
+				},
+				Defaults: []vmopv1.VirtualMachineAdmissionPolicyDefault{
+					{
+						Path:       "spec.storageClass",
+						Expression: "'gold'",
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		policy = nil
+	})
+
+	JustBeforeEach(func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+
+		var err error
+		ctx.WebhookRequestContext.Op = admissionv1.Update
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+		Expect(err).ToNot(HaveOccurred())
+		ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.vm)
+		Expect(err).ToNot(HaveOccurred())
+		ctx.WebhookRequestContext.RawObj, err = json.Marshal(ctx.WebhookRequestContext.Obj)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.Mutate(&ctx.WebhookRequestContext)
+	})
+
+	When("the policy sets a default", func() {
+		It("should patch the VM", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(ContainElement(HaveField("Path", "/spec/storageClass")))
+			Expect(response.AuditAnnotations).To(HaveKeyWithValue(
+				"default-storage-class", "deny: set spec.storageClass"))
+		})
+	})
+
+	When("the policy does not select the Update operation", func() {
+		BeforeEach(func() {
+			policy.Spec.Operations = nil
+		})
+
+		It("should not patch the VM", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).ToNot(ContainElement(HaveField("Path", "/spec/storageClass")))
+			Expect(response.AuditAnnotations).ToNot(HaveKey("default-storage-class"))
+		})
+	})
+
+	When("the policy only audits the default", func() {
+		BeforeEach(func() {
+			policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionAudit
+		})
+
+		It("should not patch the VM", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).ToNot(ContainElement(HaveField("Path", "/spec/storageClass")))
+			Expect(response.AuditAnnotations).To(HaveKeyWithValue(
+				"default-storage-class", "audit: would set spec.storageClass"))
+		})
+	})
+
+	When("the policy warns about the default", func() {
+		BeforeEach(func() {
+			policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionWarn
+		})
+
+		It("should patch the VM with a warning", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(ContainElement(HaveField("Path", "/spec/storageClass")))
+			Expect(response.Warnings).To(ConsistOf(
+				`VirtualMachineAdmissionPolicy "default-storage-class" set spec.storageClass`))
+		})
+	})
+}
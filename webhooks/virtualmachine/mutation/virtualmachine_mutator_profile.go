@@ -0,0 +1,241 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineprofiles,verbs=get;list;watch
+
+const (
+	profileFieldStorageClass   = "spec.storageClass"
+	profileFieldBootstrap      = "spec.bootstrap"
+	profileFieldCloudInitUsers = "spec.bootstrap.cloudInit.cloudConfig.users"
+	profileFieldCrypto         = "spec.crypto"
+	profileFieldReadinessProbe = "spec.readinessProbe"
+	profileFieldNameservers    = "spec.network.nameservers"
+	profileFieldSearchDomains  = "spec.network.searchDomains"
+)
+
+// ApplyProfiles sets the values of the VirtualMachineProfiles selected by the
+// VM on the VM's fields that are not already set. The profile the VM selects
+// by name with spec.profileName takes precedence over the profiles that
+// select the VM by its labels, which take precedence in order by their
+// priority and then their name.
+//
+// The profiles are only applied when the VM is created, so changing a
+// profile, or creating a profile that selects existing VMs, never changes the
+// existing VMs. Pass a nil oldVM when the VM is being created.
+//
+// The fields set from each profile are recorded in the VM's applied profiles
+// annotation.
+func ApplyProfiles(
+	ctx *pkgctx.WebhookRequestContext,
+	client ctrlclient.Client,
+	vm, oldVM *vmopv1.VirtualMachine) (bool, error) {
+
+	if !vm.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	if oldVM != nil {
+		// The applied profiles are restored from the old VM when the VM is
+		// updated so users cannot change the record of what was defaulted.
+		if ctx.IsVMOperatorAccount ||
+			vm.Annotations[vmopv1util.AppliedProfilesAnnotationKey] ==
+				oldVM.Annotations[vmopv1util.AppliedProfilesAnnotationKey] {
+
+			return false, nil
+		}
+		if err := vmopv1util.SetAppliedProfiles(vm, vmopv1util.AppliedProfiles(*oldVM)); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	profiles, err := selectProfiles(ctx, client, vm)
+	if err != nil {
+		return false, err
+	}
+
+	var applied []vmopv1.VirtualMachineAppliedProfile
+	for i := range profiles {
+		fields := applyProfile(vm, profiles[i].Spec)
+		if len(fields) == 0 {
+			continue
+		}
+		slices.Sort(fields)
+		applied = append(applied, vmopv1.VirtualMachineAppliedProfile{
+			Name:   profiles[i].Name,
+			Fields: fields,
+		})
+	}
+
+	if len(applied) == 0 {
+		return false, nil
+	}
+
+	if err := vmopv1util.SetAppliedProfiles(vm, applied); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// selectProfiles returns the profiles selected by the VM in order of their
+// precedence.
+func selectProfiles(
+	ctx *pkgctx.WebhookRequestContext,
+	client ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) ([]vmopv1.VirtualMachineProfile, error) {
+
+	var list vmopv1.VirtualMachineProfileList
+	if err := client.List(
+		ctx,
+		&list,
+		ctrlclient.InNamespace(vm.Namespace)); err != nil {
+
+		return nil, fmt.Errorf("failed to list VirtualMachineProfiles: %w", err)
+	}
+
+	var (
+		named    *vmopv1.VirtualMachineProfile
+		selected []vmopv1.VirtualMachineProfile
+		vmLabels = labels.Set(vm.Labels)
+	)
+
+	for i := range list.Items {
+		p := list.Items[i]
+
+		if p.Name == vm.Spec.ProfileName {
+			named = &p
+			continue
+		}
+
+		if p.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+		if err != nil {
+			// The profile's validation webhook prevents invalid selectors.
+			continue
+		}
+		if selector.Matches(vmLabels) {
+			selected = append(selected, p)
+		}
+	}
+
+	if vm.Spec.ProfileName != "" && named == nil {
+		return nil, fmt.Errorf(
+			"VirtualMachineProfile %q not found", vm.Spec.ProfileName)
+	}
+
+	slices.SortFunc(selected, func(a, b vmopv1.VirtualMachineProfile) int {
+		if c := cmp.Compare(b.Spec.Priority, a.Spec.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	if named != nil {
+		selected = append([]vmopv1.VirtualMachineProfile{*named}, selected...)
+	}
+
+	return selected, nil
+}
+
+// applyProfile sets the values of the profile on the VM's fields that are not
+// already set, and returns the paths of the fields that were set.
+func applyProfile(
+	vm *vmopv1.VirtualMachine,
+	spec vmopv1.VirtualMachineProfileSpec) []string {
+
+	var fields []string
+
+	if spec.StorageClass != "" && vm.Spec.StorageClass == "" {
+		vm.Spec.StorageClass = spec.StorageClass
+		fields = append(fields, profileFieldStorageClass)
+	}
+
+	if spec.Bootstrap != nil {
+		if f := applyProfileBootstrap(vm, *spec.Bootstrap); f != "" {
+			fields = append(fields, f)
+		}
+	}
+
+	if spec.Crypto != nil && vm.Spec.Crypto == nil {
+		vm.Spec.Crypto = spec.Crypto.DeepCopy()
+		fields = append(fields, profileFieldCrypto)
+	}
+
+	if spec.ReadinessProbe != nil && vm.Spec.ReadinessProbe == nil {
+		vm.Spec.ReadinessProbe = spec.ReadinessProbe.DeepCopy()
+		fields = append(fields, profileFieldReadinessProbe)
+	}
+
+	if spec.Network != nil && (vm.Spec.Network == nil || !vm.Spec.Network.Disabled) {
+		if len(spec.Network.Nameservers) > 0 &&
+			(vm.Spec.Network == nil || len(vm.Spec.Network.Nameservers) == 0) {
+
+			if vm.Spec.Network == nil {
+				vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{}
+			}
+			vm.Spec.Network.Nameservers = slices.Clone(spec.Network.Nameservers)
+			fields = append(fields, profileFieldNameservers)
+		}
+
+		if len(spec.Network.SearchDomains) > 0 &&
+			(vm.Spec.Network == nil || len(vm.Spec.Network.SearchDomains) == 0) {
+
+			if vm.Spec.Network == nil {
+				vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{}
+			}
+			vm.Spec.Network.SearchDomains = slices.Clone(spec.Network.SearchDomains)
+			fields = append(fields, profileFieldSearchDomains)
+		}
+	}
+
+	return fields
+}
+
+// applyProfileBootstrap sets the profile's bootstrap on the VM if the VM does
+// not specify one. Otherwise, if the VM specifies an inline cloud-config
+// without any users, the users from the profile's cloud-config are set. The
+// path of the field that was set is returned.
+func applyProfileBootstrap(
+	vm *vmopv1.VirtualMachine,
+	bootstrap vmopv1.VirtualMachineBootstrapSpec) string {
+
+	if vm.Spec.Bootstrap == nil {
+		vm.Spec.Bootstrap = bootstrap.DeepCopy()
+		return profileFieldBootstrap
+	}
+
+	if bootstrap.CloudInit == nil || bootstrap.CloudInit.CloudConfig == nil ||
+		len(bootstrap.CloudInit.CloudConfig.Users) == 0 {
+
+		return ""
+	}
+
+	if ci := vm.Spec.Bootstrap.CloudInit; ci != nil &&
+		ci.CloudConfig != nil && len(ci.CloudConfig.Users) == 0 {
+
+		ci.CloudConfig.Users = bootstrap.DeepCopy().CloudInit.CloudConfig.Users
+		return profileFieldCloudInitUsers
+	}
+
+	return ""
+}
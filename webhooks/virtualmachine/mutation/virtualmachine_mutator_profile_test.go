@@ -0,0 +1,325 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha5/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine/mutation"
+)
+
+func profileTests() {
+	Describe(
+		"ApplyProfiles",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		profileMutationTests,
+	)
+}
+
+func profileMutationTests() {
+	const (
+		namespace = "my-namespace"
+	)
+
+	var (
+		ctx      *unitMutationWebhookContext
+		profiles []*vmopv1.VirtualMachineProfile
+		oldVM    *vmopv1.VirtualMachine
+		mutated  bool
+		err      error
+	)
+
+	newProfile := func(name string) *vmopv1.VirtualMachineProfile {
+		return &vmopv1.VirtualMachineProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForMutatingWebhook()
+		ctx.vm.Namespace = namespace
+		ctx.vm.Labels = map[string]string{"team": "blue"}
+		profiles = nil
+		oldVM = nil
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		profiles = nil
+		oldVM = nil
+	})
+
+	JustBeforeEach(func() {
+		for _, p := range profiles {
+			Expect(ctx.Client.Create(ctx, p)).To(Succeed())
+		}
+		mutated, err = mutation.ApplyProfiles(
+			&ctx.WebhookRequestContext, ctx.Client, ctx.vm, oldVM)
+	})
+
+	When("there are no profiles", func() {
+		It("does not mutate the VM", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mutated).To(BeFalse())
+			Expect(ctx.vm.Annotations).ToNot(HaveKey(vmopv1util.AppliedProfilesAnnotationKey))
+		})
+	})
+
+	When("the VM selects a profile that does not exist by name", func() {
+		BeforeEach(func() {
+			ctx.vm.Spec.ProfileName = "missing"
+		})
+
+		It("returns an error on create", func() {
+			Expect(err).To(MatchError(`VirtualMachineProfile "missing" not found`))
+		})
+
+		When("the VM is updated", func() {
+			BeforeEach(func() {
+				oldVM = ctx.vm.DeepCopy()
+			})
+			It("does not return an error", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeFalse())
+			})
+		})
+	})
+
+	When("the VM is created", func() {
+		BeforeEach(func() {
+			named := newProfile("named")
+			named.Spec.StorageClass = "named-storage-class"
+			named.Spec.Network = &vmopv1.VirtualMachineProfileNetworkSpec{
+				Nameservers: []string{"10.0.0.1"},
+			}
+
+			high := newProfile("high")
+			high.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "blue"},
+			}
+			high.Spec.Priority = 10
+			high.Spec.StorageClass = "high-storage-class"
+			high.Spec.Network = &vmopv1.VirtualMachineProfileNetworkSpec{
+				Nameservers:   []string{"10.0.0.2"},
+				SearchDomains: []string{"high.local"},
+			}
+			high.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{
+				EncryptionClassName: "high-encryption-class",
+			}
+
+			low := newProfile("low")
+			low.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "blue"},
+			}
+			low.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+			}
+			low.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{
+				EncryptionClassName: "low-encryption-class",
+			}
+
+			other := newProfile("other")
+			other.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "red"},
+			}
+			other.Spec.StorageClass = "other-storage-class"
+
+			profiles = []*vmopv1.VirtualMachineProfile{named, high, low, other}
+			ctx.vm.Spec.ProfileName = "named"
+		})
+
+		It("applies the profiles in order of precedence", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mutated).To(BeTrue())
+
+			vm := ctx.vm
+			Expect(vm.Spec.StorageClass).To(Equal("named-storage-class"))
+			Expect(vm.Spec.Network.Nameservers).To(Equal([]string{"10.0.0.1"}))
+			Expect(vm.Spec.Network.SearchDomains).To(Equal([]string{"high.local"}))
+			Expect(vm.Spec.Crypto).ToNot(BeNil())
+			Expect(vm.Spec.Crypto.EncryptionClassName).To(Equal("high-encryption-class"))
+			Expect(vm.Spec.ReadinessProbe).ToNot(BeNil())
+			Expect(vm.Spec.ReadinessProbe.GuestHeartbeat).ToNot(BeNil())
+
+			Expect(vmopv1util.AppliedProfiles(*vm)).To(Equal([]vmopv1.VirtualMachineAppliedProfile{
+				{
+					Name:   "named",
+					Fields: []string{"spec.network.nameservers", "spec.storageClass"},
+				},
+				{
+					Name:   "high",
+					Fields: []string{"spec.crypto", "spec.network.searchDomains"},
+				},
+				{
+					Name:   "low",
+					Fields: []string{"spec.readinessProbe"},
+				},
+			}))
+		})
+
+		When("the VM specifies the values", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.StorageClass = "my-storage-class"
+				ctx.vm.Spec.Network.Nameservers = []string{"10.0.0.3"}
+			})
+
+			It("does not overwrite the VM's values", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ctx.vm.Spec.StorageClass).To(Equal("my-storage-class"))
+				Expect(ctx.vm.Spec.Network.Nameservers).To(Equal([]string{"10.0.0.3"}))
+				Expect(vmopv1util.AppliedProfiles(*ctx.vm)[0].Name).To(Equal("high"))
+			})
+		})
+
+		When("the VM's network is disabled", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Network.Disabled = true
+			})
+
+			It("does not set the network settings", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ctx.vm.Spec.Network.Nameservers).To(BeEmpty())
+				Expect(ctx.vm.Spec.Network.SearchDomains).To(BeEmpty())
+			})
+		})
+	})
+
+	When("a profile specifies bootstrap", func() {
+		users := []vmopv1cloudinit.User{{Name: "admin"}}
+
+		BeforeEach(func() {
+			p := newProfile("bootstrap")
+			p.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "blue"},
+			}
+			p.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+				CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+					CloudConfig: &vmopv1cloudinit.CloudConfig{
+						Users: users,
+					},
+				},
+			}
+			profiles = []*vmopv1.VirtualMachineProfile{p}
+		})
+
+		When("the VM does not specify bootstrap", func() {
+			It("sets the bootstrap", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ctx.vm.Spec.Bootstrap).ToNot(BeNil())
+				Expect(ctx.vm.Spec.Bootstrap.CloudInit.CloudConfig.Users).To(Equal(users))
+				Expect(vmopv1util.AppliedProfiles(*ctx.vm)[0].Fields).To(Equal([]string{"spec.bootstrap"}))
+			})
+		})
+
+		When("the VM specifies a cloud-config without users", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+						CloudConfig: &vmopv1cloudinit.CloudConfig{
+							Timezone: "UTC",
+						},
+					},
+				}
+			})
+
+			It("sets the users", func() {
+				Expect(err).ToNot(HaveOccurred())
+				cc := ctx.vm.Spec.Bootstrap.CloudInit.CloudConfig
+				Expect(cc.Timezone).To(Equal("UTC"))
+				Expect(cc.Users).To(Equal(users))
+				Expect(vmopv1util.AppliedProfiles(*ctx.vm)[0].Fields).To(
+					Equal([]string{"spec.bootstrap.cloudInit.cloudConfig.users"}))
+			})
+		})
+
+		When("the VM specifies Sysprep", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{},
+				}
+			})
+
+			It("does not change the bootstrap", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Bootstrap.CloudInit).To(BeNil())
+			})
+		})
+	})
+
+	When("the VM is updated", func() {
+		BeforeEach(func() {
+			p := newProfile("p")
+			p.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "blue"},
+			}
+			p.Spec.StorageClass = "new-storage-class"
+			p.Spec.Network = &vmopv1.VirtualMachineProfileNetworkSpec{
+				SearchDomains: []string{"new.local"},
+			}
+			profiles = []*vmopv1.VirtualMachineProfile{p}
+
+			ctx.vm.Spec.StorageClass = ""
+			Expect(vmopv1util.SetAppliedProfiles(ctx.vm, []vmopv1.VirtualMachineAppliedProfile{
+				{
+					Name:   "old",
+					Fields: []string{"spec.crypto"},
+				},
+			})).To(Succeed())
+			oldVM = ctx.vm.DeepCopy()
+		})
+
+		It("does not apply the profiles", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mutated).To(BeFalse())
+			Expect(ctx.vm.Spec.StorageClass).To(BeEmpty())
+			Expect(ctx.vm.Spec.Network.SearchDomains).To(BeEmpty())
+			Expect(ctx.vm.Annotations[vmopv1util.AppliedProfilesAnnotationKey]).To(
+				Equal(oldVM.Annotations[vmopv1util.AppliedProfilesAnnotationKey]))
+		})
+
+		When("the user changes the applied profiles annotation", func() {
+			BeforeEach(func() {
+				profiles = nil
+				ctx.vm.Annotations[vmopv1util.AppliedProfilesAnnotationKey] = "[]"
+			})
+
+			It("restores the annotation", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeTrue())
+				Expect(ctx.vm.Annotations[vmopv1util.AppliedProfilesAnnotationKey]).To(
+					Equal(oldVM.Annotations[vmopv1util.AppliedProfilesAnnotationKey]))
+			})
+		})
+
+		When("the request is from VM Operator", func() {
+			BeforeEach(func() {
+				ctx.IsVMOperatorAccount = true
+			})
+
+			It("does not mutate the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Network.SearchDomains).To(BeEmpty())
+			})
+		})
+	})
+}
@@ -46,6 +46,8 @@ func uniTests() {
 	)
 
 	controllerTests()
+	admissionPolicyTests()
+	profileTests()
 }
 
 type unitMutationWebhookContext struct {
@@ -1109,6 +1111,108 @@ func unitTestsMutating() {
 		})
 	})
 
+	Describe("ResolveImageStreamOnCreate", func() {
+		const streamName = "photon"
+
+		var (
+			mutatedErr  error
+			wasMutated  bool
+			initObjects []client.Object
+			stream      *vmopv1.VirtualMachineImageStream
+		)
+
+		BeforeEach(func() {
+			stream = &vmopv1.VirtualMachineImageStream{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      streamName,
+					Namespace: ctx.vm.Namespace,
+				},
+				Status: vmopv1.VirtualMachineImageStreamStatus{
+					Image: &vmopv1.VirtualMachineImageRef{
+						Kind: "ClusterVirtualMachineImage",
+						Name: "vmi-123",
+					},
+				},
+			}
+			initObjects = []client.Object{stream}
+
+			ctx.vm.Spec.ImageName = streamName
+			ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+				Kind: vmopv1.VirtualMachineImageStreamKind,
+				Name: streamName,
+			}
+		})
+
+		JustBeforeEach(func() {
+			ctx.Client = fake.NewClientBuilder().WithScheme(builder.NewScheme()).
+				WithObjects(initObjects...).
+				Build()
+			wasMutated, mutatedErr = mutation.ResolveImageStreamOnCreate(
+				&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+		})
+
+		It("Should replace the stream with its current image", func() {
+			Expect(mutatedErr).ToNot(HaveOccurred())
+			Expect(wasMutated).To(BeTrue())
+			Expect(ctx.vm.Spec.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+				Kind: "ClusterVirtualMachineImage",
+				Name: "vmi-123",
+			}))
+			Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-123"))
+			Expect(ctx.vm.Annotations).To(HaveKeyWithValue(
+				vmopv1.VirtualMachineImageStreamAnnotation, streamName))
+		})
+
+		When("spec.imageName does not refer to the stream", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.ImageName = ""
+			})
+			It("Should not mutate ImageName", func() {
+				Expect(mutatedErr).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeTrue())
+				Expect(ctx.vm.Spec.Image.Name).To(Equal("vmi-123"))
+				Expect(ctx.vm.Spec.ImageName).To(BeEmpty())
+			})
+		})
+
+		When("spec.image refers to an image", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-456",
+				}
+			})
+			It("Should not mutate anything", func() {
+				Expect(mutatedErr).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image.Name).To(Equal("vmi-456"))
+				Expect(ctx.vm.Annotations).ToNot(HaveKey(vmopv1.VirtualMachineImageStreamAnnotation))
+			})
+		})
+
+		When("the stream does not exist", func() {
+			BeforeEach(func() {
+				initObjects = nil
+			})
+			It("Should return an error", func() {
+				Expect(mutatedErr).To(MatchError(field.NotFound(
+					field.NewPath("spec", "image", "name"), streamName).Error()))
+				Expect(wasMutated).To(BeFalse())
+			})
+		})
+
+		When("the stream has not resolved an image", func() {
+			BeforeEach(func() {
+				stream.Status.Image = nil
+			})
+			It("Should return an error", func() {
+				Expect(mutatedErr).To(MatchError(ContainSubstring("has not resolved an image")))
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image.Kind).To(Equal(vmopv1.VirtualMachineImageStreamKind))
+			})
+		})
+	})
+
 	Describe("SetNextRestartTime", func() {
 
 		var (
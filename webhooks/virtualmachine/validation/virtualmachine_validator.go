@@ -17,6 +17,7 @@ import (
 
 	"github.com/google/uuid"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -37,7 +39,9 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/sysprep"
+	"github.com/vmware-tanzu/vm-operator/pkg/admissionpolicy"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -85,7 +89,7 @@ const (
 	invalidMinHardwareVersionDowngrade         = "cannot downgrade hardware version"
 	invalidMinHardwareVersionPowerState        = "cannot upgrade hardware version unless powered off"
 	invalidImageKind                           = "supported: " + vmiKind + "; " + cvmiKind
-	invalidZone                                = "cannot use zone that is being deleted"
+	invalidZone                                = "cannot use zone that is being deleted or is cordoned"
 	restrictedToPrivUsers                      = "restricted to privileged users"
 	controllerBusNumberRangeFmt                = "%s controllerBusNumber must be in the range of 0 to %d"
 	unitNumberRangeFmt                         = "%s unitNumber must be in the range of 0 to %d"
@@ -99,11 +103,13 @@ const (
 	labelSelectorCanNotContainVMOperatorLabels = "label selector can not contain VM Operator managed labels (vmoperator.vmware.com)"
 	guestCustomizationVCDParityNotEnabled      = "VC guest customization VCD parity capability is not enabled"
 	bootstrapProviderTypeCannotBeChanged       = "bootstrap provider type cannot be changed"
+	imageSignatureNotVerifiedFmt               = "namespace requires images with a verified signature: %s"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha5,name=default.validating.virtualmachine.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineadmissionpolicies,verbs=get;list;watch
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -122,12 +128,14 @@ func NewValidator(client ctrlclient.Client) builder.Validator {
 		client: client,
 		// TODO BMV Use the Context.scheme instead
 		converter: runtime.DefaultUnstructuredConverter,
+		policies:  admissionpolicy.NewEvaluator(client),
 	}
 }
 
 type validator struct {
 	client    ctrlclient.Client
 	converter runtime.UnstructuredConverter
+	policies  *admissionpolicy.Evaluator
 }
 
 // vmFromUnstructured returns the VirtualMachine from the unstructured object.
@@ -158,6 +166,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 
 	fieldErrs = append(fieldErrs, v.validateAvailabilityZone(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateImageOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateImageSignature(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateClassOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateStorageClass(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
@@ -187,7 +196,39 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 		validationErrs = append(validationErrs, fieldErr.Error())
 	}
 
-	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+	return v.buildResponse(ctx, vm, nil, validationErrs)
+}
+
+// buildResponse evaluates the VM against the VirtualMachineAdmissionPolicy
+// resources and returns the response for the request. Requests from VM
+// Operator and for VMs that are being deleted are not evaluated so that the
+// policies cannot prevent VMs from being reconciled.
+func (v validator) buildResponse(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine,
+	validationErrs []string) admission.Response {
+
+	if ctx.IsVMOperatorAccount || !vm.DeletionTimestamp.IsZero() {
+		return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+	}
+
+	op := admissionv1.Create
+	if oldVM != nil {
+		op = admissionv1.Update
+	}
+
+	result, err := v.policies.Validate(ctx, admissionpolicy.Request{
+		Operation: op,
+		UserInfo:  ctx.UserInfo,
+		VM:        vm,
+		OldVM:     oldVM,
+	})
+
+	response := common.BuildValidationResponse(
+		ctx, result.Warnings, validationErrs, err, result.Denials...)
+	response.AuditAnnotations = result.AuditAnnotations
+
+	return response
 }
 
 func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
@@ -289,7 +330,7 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 		validationErrs = append(validationErrs, fieldErr.Error())
 	}
 
-	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+	return v.buildResponse(ctx, vm, oldVM, validationErrs)
 }
 
 func (v validator) validateBootstrapProviderImmutable(
@@ -565,6 +606,51 @@ func (v validator) validateImageOnCreate(
 	return allErrs
 }
 
+// validateImageSignature rejects a VM whose image's signature has not been
+// verified when the VM's namespace requires verified images.
+func (v validator) validateImageSignature(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	if vm.Spec.Image == nil || vm.Spec.Image.Kind == "" {
+		return nil
+	}
+
+	var (
+		allErrs field.ErrorList
+		f       = field.NewPath("spec", "image")
+		ns      corev1.Namespace
+	)
+
+	if err := v.client.Get(ctx, ctrlclient.ObjectKey{Name: vm.Namespace}, &ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.InternalError(f, err))
+		}
+		return allErrs
+	}
+
+	if ns.Labels[vmopv1.RequireVerifiedImagesLabelKey] != "true" {
+		return nil
+	}
+
+	img, err := vmopv1util.GetImage(ctx, v.client, *vm.Spec.Image, vm.Namespace)
+	if err != nil {
+		return append(allErrs, field.Forbidden(f, fmt.Sprintf(imageSignatureNotVerifiedFmt, err)))
+	}
+
+	c := pkgcnd.Get(img, vmopv1.VirtualMachineImageSignatureVerifiedCondition)
+	switch {
+	case c == nil:
+		allErrs = append(allErrs, field.Forbidden(f,
+			fmt.Sprintf(imageSignatureNotVerifiedFmt, "image has not been verified")))
+	case c.Status != metav1.ConditionTrue:
+		allErrs = append(allErrs, field.Forbidden(f,
+			fmt.Sprintf(imageSignatureNotVerifiedFmt, c.Reason)))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateClassOnCreate(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -781,9 +867,12 @@ func (v validator) validateNetwork(
 		for i, interfaceSpec := range networkSpec.Interfaces {
 			allErrs = append(allErrs, v.validateNetworkInterfaceSpec(p.Index(i), interfaceSpec, vm.Name)...)
 			allErrs = append(allErrs, v.validateNetworkInterfaceSpecWithBootstrap(ctx, p.Index(i), interfaceSpec, vm)...)
+			allErrs = append(allErrs, v.validateNetworkInterfaceIPPool(ctx, p.Index(i), interfaceSpec)...)
 		}
 	}
 
+	allErrs = append(allErrs, v.validateNetworkGuestDevices(networkPath, vm)...)
+
 	if oldVM != nil {
 		if pkgcfg.FromContext(ctx).Features.MutableNetworks {
 			allErrs = append(allErrs, v.validateNetworkInterfaceMacAddressNotChanged(ctx, vm, oldVM)...)
@@ -841,6 +930,216 @@ func (v validator) validateNetworkInterfaceMacAddressNotChanged(
 	return allErrs
 }
 
+// ipPoolSupportNetworkProviders are the network providers that do not always
+// allocate addresses, and so support allocating addresses from IP pools.
+var ipPoolSupportNetworkProviders = []pkgcfg.NetworkProviderType{
+	pkgcfg.NetworkProviderTypeNamed,
+	pkgcfg.NetworkProviderTypeVDS,
+}
+
+func (v validator) validateNetworkInterfaceIPPool(
+	ctx *pkgctx.WebhookRequestContext,
+	interfacePath *field.Path,
+	interfaceSpec vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	if interfaceSpec.IPPoolName == "" {
+		return nil
+	}
+
+	var (
+		allErrs field.ErrorList
+		p       = interfacePath.Child("ipPoolName")
+	)
+
+	if !slices.Contains(ipPoolSupportNetworkProviders, pkgcfg.FromContext(ctx).NetworkProviderType) {
+		allErrs = append(allErrs, field.Forbidden(p,
+			fmt.Sprintf("ipPoolName is available only with the following network providers: %s,%s",
+				pkgcfg.NetworkProviderTypeNamed, pkgcfg.NetworkProviderTypeVDS)))
+	}
+
+	if len(interfaceSpec.Addresses) > 0 {
+		allErrs = append(allErrs, field.Forbidden(p, "ipPoolName is mutually exclusive with addresses"))
+	}
+	if interfaceSpec.DHCP4 {
+		allErrs = append(allErrs, field.Forbidden(p, "ipPoolName is mutually exclusive with dhcp4"))
+	}
+	if interfaceSpec.DHCP6 {
+		allErrs = append(allErrs, field.Forbidden(p, "ipPoolName is mutually exclusive with dhcp6"))
+	}
+
+	return allErrs
+}
+
+// validateNetworkGuestDevices validates the bonds, VLANs, and bridges composed
+// of the VM's network interfaces. These devices are only rendered to netplan,
+// so they are available only with CloudInit.
+//
+//nolint:gocyclo
+func (v validator) validateNetworkGuestDevices(
+	networkPath *field.Path,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var (
+		allErrs     field.ErrorList
+		networkSpec = vm.Spec.Network
+		bondsPath   = networkPath.Child("bonds")
+		vlansPath   = networkPath.Child("vlans")
+		bridgesPath = networkPath.Child("bridges")
+	)
+
+	if len(networkSpec.Bonds) == 0 && len(networkSpec.VLANs) == 0 && len(networkSpec.Bridges) == 0 {
+		return nil
+	}
+
+	if vm.Spec.Bootstrap == nil || vm.Spec.Bootstrap.CloudInit == nil {
+		for _, d := range []struct {
+			name string
+			n    int
+		}{
+			{"bonds", len(networkSpec.Bonds)},
+			{"vlans", len(networkSpec.VLANs)},
+			{"bridges", len(networkSpec.Bridges)},
+		} {
+			if d.n > 0 {
+				allErrs = append(allErrs, field.Forbidden(networkPath.Child(d.name),
+					fmt.Sprintf("%s is available only with the following bootstrap providers: CloudInit", d.name)))
+			}
+		}
+		return allErrs
+	}
+
+	// Collect the names of the interfaces and devices. The names are also the
+	// netplan IDs, so they must be unique across all of them.
+	const (
+		kindInterface = "interface"
+		kindBond      = "bond"
+		kindVLAN      = "vlan"
+		kindBridge    = "bridge"
+	)
+	kinds := map[string]string{}
+	for _, i := range networkSpec.Interfaces {
+		kinds[i.Name] = kindInterface
+	}
+	addName := func(p *field.Path, name, kind string) {
+		if _, ok := kinds[name]; ok {
+			allErrs = append(allErrs, field.Duplicate(p.Child("name"), name))
+			return
+		}
+		kinds[name] = kind
+	}
+	for i, b := range networkSpec.Bonds {
+		addName(bondsPath.Index(i), b.Name, kindBond)
+	}
+	for i, vl := range networkSpec.VLANs {
+		addName(vlansPath.Index(i), vl.Name, kindVLAN)
+	}
+	for i, b := range networkSpec.Bridges {
+		addName(bridgesPath.Index(i), b.Name, kindBridge)
+	}
+
+	// An interface, bond, or VLAN may be a member of only one bond or bridge.
+	members := map[string]struct{}{}
+	addMember := func(p *field.Path, name string) {
+		if _, ok := members[name]; ok {
+			allErrs = append(allErrs, field.Invalid(p, name, "is already a member of another bond or bridge"))
+			return
+		}
+		members[name] = struct{}{}
+	}
+
+	for i, b := range networkSpec.Bonds {
+		p := bondsPath.Index(i)
+		for j, m := range b.Interfaces {
+			if kinds[m] != kindInterface {
+				allErrs = append(allErrs, field.Invalid(p.Child("interfaces").Index(j), m,
+					"must be the name of a network interface"))
+				continue
+			}
+			addMember(p.Child("interfaces").Index(j), m)
+		}
+		if b.Primary != "" && !slices.Contains(b.Interfaces, b.Primary) {
+			allErrs = append(allErrs, field.Invalid(p.Child("primary"), b.Primary,
+				"must be one of the bond's interfaces"))
+		}
+		if b.LACPRate != "" && b.Mode != vmopv1.VirtualMachineNetworkBondMode8023AD {
+			allErrs = append(allErrs, field.Forbidden(p.Child("lacpRate"),
+				fmt.Sprintf("lacpRate is available only with mode %s", vmopv1.VirtualMachineNetworkBondMode8023AD)))
+		}
+		allErrs = append(allErrs, v.validateNetworkGuestDeviceIPSpec(p, b.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	vlanIDs := map[string]struct{}{}
+	for i, vl := range networkSpec.VLANs {
+		p := vlansPath.Index(i)
+		if k := kinds[vl.Link]; k != kindInterface && k != kindBond {
+			allErrs = append(allErrs, field.Invalid(p.Child("link"), vl.Link,
+				"must be the name of a network interface or bond"))
+		}
+		key := fmt.Sprintf("%s/%d", vl.Link, vl.ID)
+		if _, ok := vlanIDs[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(p.Child("id"), vl.ID))
+		}
+		vlanIDs[key] = struct{}{}
+		allErrs = append(allErrs, v.validateNetworkGuestDeviceIPSpec(p, vl.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	for i, b := range networkSpec.Bridges {
+		p := bridgesPath.Index(i)
+		for j, m := range b.Interfaces {
+			if k := kinds[m]; k != kindInterface && k != kindBond && k != kindVLAN {
+				allErrs = append(allErrs, field.Invalid(p.Child("interfaces").Index(j), m,
+					"must be the name of a network interface, bond, or VLAN"))
+				continue
+			}
+			addMember(p.Child("interfaces").Index(j), m)
+		}
+		allErrs = append(allErrs, v.validateNetworkGuestDeviceIPSpec(p, b.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	// The addresses of a bond or bridge belong to the bond or bridge, so the
+	// members may not be configured with their own addresses.
+	const memberIPMsg = "a member of a bond or bridge may not have addresses, dhcp4, dhcp6, or ipPoolName"
+	for i, iface := range networkSpec.Interfaces {
+		if _, ok := members[iface.Name]; !ok {
+			continue
+		}
+		if len(iface.Addresses) > 0 || iface.DHCP4 || iface.DHCP6 || iface.IPPoolName != "" {
+			allErrs = append(allErrs, field.Forbidden(networkPath.Child("interfaces").Index(i), memberIPMsg))
+		}
+	}
+	for i, b := range networkSpec.Bonds {
+		if _, ok := members[b.Name]; ok && hasGuestDeviceIP(b.VirtualMachineNetworkGuestDeviceIPSpec) {
+			allErrs = append(allErrs, field.Forbidden(bondsPath.Index(i), memberIPMsg))
+		}
+	}
+	for i, vl := range networkSpec.VLANs {
+		if _, ok := members[vl.Name]; ok && hasGuestDeviceIP(vl.VirtualMachineNetworkGuestDeviceIPSpec) {
+			allErrs = append(allErrs, field.Forbidden(vlansPath.Index(i), memberIPMsg))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateNetworkGuestDeviceIPSpec(
+	path *field.Path,
+	ipSpec vmopv1.VirtualMachineNetworkGuestDeviceIPSpec) field.ErrorList {
+
+	return v.validateNetworkIPSpec(
+		path,
+		ipSpec.Addresses,
+		ipSpec.DHCP4,
+		ipSpec.DHCP6,
+		ipSpec.Gateway4,
+		ipSpec.Gateway6,
+		ipSpec.Nameservers,
+		ipSpec.Routes)
+}
+
+func hasGuestDeviceIP(ipSpec vmopv1.VirtualMachineNetworkGuestDeviceIPSpec) bool {
+	return len(ipSpec.Addresses) > 0 || ipSpec.DHCP4 || ipSpec.DHCP6
+}
+
 // Note the code for VDS is basically done, but only support this for VPC right
 // now since that is what matters.
 var macAddressSupportNetworkGroups = []string{
@@ -893,12 +1192,37 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
+	allErrs = append(allErrs, v.validateNetworkIPSpec(
+		interfacePath,
+		interfaceSpec.Addresses,
+		interfaceSpec.DHCP4,
+		interfaceSpec.DHCP6,
+		interfaceSpec.Gateway4,
+		interfaceSpec.Gateway6,
+		interfaceSpec.Nameservers,
+		interfaceSpec.Routes)...)
+
+	return allErrs
+}
+
+// validateNetworkIPSpec validates the IP configuration of a network interface
+// or a guest network device.
+func (v validator) validateNetworkIPSpec(
+	path *field.Path,
+	addresses []string,
+	dhcp4, dhcp6 bool,
+	gateway4, gateway6 string,
+	nameservers []string,
+	routes []vmopv1.VirtualMachineNetworkRouteSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
 	var ipv4Addrs, ipv6Addrs []string
-	for i, ipCIDR := range interfaceSpec.Addresses {
+	for i, ipCIDR := range addresses {
 		// NOTE: VPC SubnetPort only takes the IP address so we might want to make this more flexible.
 		ip, _, err := net.ParseCIDR(ipCIDR)
 		if err != nil {
-			p := interfacePath.Child("addresses").Index(i)
+			p := path.Child("addresses").Index(i)
 			allErrs = append(allErrs, field.Invalid(p, ipCIDR, err.Error()))
 			continue
 		}
@@ -910,8 +1234,8 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if ipv4 := interfaceSpec.Gateway4; ipv4 != "" && ipv4 != "None" {
-		p := interfacePath.Child("gateway4")
+	if ipv4 := gateway4; ipv4 != "" && ipv4 != "None" {
+		p := path.Child("gateway4")
 
 		if len(ipv4Addrs) == 0 {
 			allErrs = append(allErrs, field.Invalid(p, ipv4, "gateway4 must have an IPv4 address in the addresses field"))
@@ -922,8 +1246,8 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if ipv6 := interfaceSpec.Gateway6; ipv6 != "" && ipv6 != "None" {
-		p := interfacePath.Child("gateway6")
+	if ipv6 := gateway6; ipv6 != "" && ipv6 != "None" {
+		p := path.Child("gateway6")
 
 		if len(ipv6Addrs) == 0 {
 			allErrs = append(allErrs, field.Invalid(p, ipv6, "gateway6 must have an IPv6 address in the addresses field"))
@@ -934,43 +1258,43 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if interfaceSpec.DHCP4 {
+	if dhcp4 {
 		if len(ipv4Addrs) > 0 {
-			p := interfacePath.Child("dhcp4")
+			p := path.Child("dhcp4")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv4Addrs, ","),
 				"dhcp4 cannot be used with IPv4 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway4; gw != "" {
-			p := interfacePath.Child("gateway4")
+		if gw := gateway4; gw != "" {
+			p := path.Child("gateway4")
 			allErrs = append(allErrs, field.Invalid(p, gw, "gateway4 is mutually exclusive with dhcp4"))
 		}
 	}
 
-	if interfaceSpec.DHCP6 {
+	if dhcp6 {
 		if len(ipv6Addrs) > 0 {
-			p := interfacePath.Child("dhcp6")
+			p := path.Child("dhcp6")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv6Addrs, ","),
 				"dhcp6 cannot be used with IPv6 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway6; gw != "" {
-			p := interfacePath.Child("gateway6")
+		if gw := gateway6; gw != "" {
+			p := path.Child("gateway6")
 			allErrs = append(allErrs, field.Invalid(p, gw, "gateway6 is mutually exclusive with dhcp6"))
 		}
 	}
 
-	for i, n := range interfaceSpec.Nameservers {
+	for i, n := range nameservers {
 		if net.ParseIP(n) == nil {
 			allErrs = append(allErrs,
-				field.Invalid(interfacePath.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
+				field.Invalid(path.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
 		}
 	}
 
-	if len(interfaceSpec.Routes) > 0 {
-		p := interfacePath.Child("routes")
+	if len(routes) > 0 {
+		p := path.Child("routes")
 
-		for i, r := range interfaceSpec.Routes {
+		for i, r := range routes {
 			var toIP net.IP
 			if r.To != "default" {
 				ip, _, err := net.ParseCIDR(r.To)
@@ -2069,8 +2393,8 @@ func (v validator) validateAvailabilityZone(
 			if err != nil {
 				return append(allErrs, field.Invalid(zoneLabelPath, zoneName, err.Error()))
 			}
-			// Prevent new VirtualMachines created on marked zone by SSO users.
-			if !zone.DeletionTimestamp.IsZero() {
+			// Prevent new VirtualMachines created on marked or cordoned zone by SSO users.
+			if topology.IsZoneDraining(zone) {
 				if !ctx.IsPrivilegedAccount && !isCAPVServiceAccount(ctx.UserInfo.Username) {
 					return append(allErrs, field.Invalid(zoneLabelPath, zoneName, invalidZone))
 				}
@@ -2775,12 +3099,44 @@ func (v validator) validateVMAffinity(
 
 	var allErrs field.ErrorList
 
-	if vm.Spec.GroupName == "" {
+	path := field.NewPath("spec", "affinity")
+
+	// The VM-to-VM affinity rules are realized by the VM's group, while the
+	// zone and host affinity rules are considered when placing the VM by
+	// itself.
+	hasVMAffinity := affinity.VMAffinity != nil || affinity.VMAntiAffinity != nil
+	hasPlacementAffinity := affinity.ZoneAffinity != nil || affinity.HostAffinity != nil
+
+	if vm.Spec.GroupName == "" && (hasVMAffinity || !hasPlacementAffinity) {
 		allErrs = append(allErrs, field.Required(
 			field.NewPath("spec", "groupName"), "when setting affinity"))
 	}
 
-	path := field.NewPath("spec", "affinity")
+	if a := affinity.ZoneAffinity; a != nil {
+		p := path.Child("zoneAffinity")
+		if vm.Spec.GroupName != "" {
+			allErrs = append(allErrs, field.Forbidden(p, "when groupName is set"))
+		}
+		allErrs = append(allErrs, validateZoneSelectorTerms(
+			p.Child("requiredDuringSchedulingIgnoredDuringExecution"),
+			a.RequiredDuringSchedulingIgnoredDuringExecution)...)
+		allErrs = append(allErrs, validateZoneSelectorTerms(
+			p.Child("preferredDuringSchedulingIgnoredDuringExecution"),
+			a.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
+
+	if a := affinity.HostAffinity; a != nil {
+		p := path.Child("hostAffinity")
+		if vm.Spec.GroupName != "" {
+			allErrs = append(allErrs, field.Forbidden(p, "when groupName is set"))
+		}
+		allErrs = append(allErrs, validateHostSelectorTerms(
+			p.Child("requiredDuringSchedulingIgnoredDuringExecution"),
+			a.RequiredDuringSchedulingIgnoredDuringExecution)...)
+		allErrs = append(allErrs, validateHostSelectorTerms(
+			p.Child("preferredDuringSchedulingIgnoredDuringExecution"),
+			a.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
 
 	if a := affinity.VMAffinity; a != nil {
 		p := path.Child("vmAffinity")
@@ -2937,6 +3293,62 @@ func (v validator) validateVMAffinity(
 	return allErrs
 }
 
+func validateZoneSelectorTerms(
+	path *field.Path,
+	terms []vmopv1.ZoneSelectorTerm) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	for idx, t := range terms {
+		p := path.Index(idx).Child("labelSelector")
+
+		if t.LabelSelector == nil {
+			allErrs = append(allErrs, field.Required(p, ""))
+			continue
+		}
+
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			t.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, p)...)
+	}
+
+	return allErrs
+}
+
+func validateHostSelectorTerms(
+	path *field.Path,
+	terms []vmopv1.HostSelectorTerm) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	for idx, t := range terms {
+		p := path.Index(idx)
+
+		if t.Category == "" {
+			allErrs = append(allErrs, field.Required(p.Child("category"), ""))
+		}
+
+		switch t.Operator {
+		case "", vmopv1.HostSelectorOpIn, vmopv1.HostSelectorOpNotIn:
+		default:
+			allErrs = append(allErrs, field.NotSupported(
+				p.Child("operator"),
+				t.Operator,
+				[]vmopv1.HostSelectorOperator{vmopv1.HostSelectorOpIn, vmopv1.HostSelectorOpNotIn}))
+		}
+
+		if len(t.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(p.Child("tags"), ""))
+		}
+		for tagIdx, tag := range t.Tags {
+			if tag == "" {
+				allErrs = append(allErrs, field.Invalid(p.Child("tags").Index(tagIdx), tag, "must not be empty"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateImmutableVMAffinity(
 	_ *pkgctx.WebhookRequestContext,
 	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
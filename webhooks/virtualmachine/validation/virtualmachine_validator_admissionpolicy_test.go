@@ -0,0 +1,108 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func admissionPolicyTests() {
+	Describe(
+		"Admission Policy",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		admissionPolicyValidationTests,
+	)
+}
+
+func admissionPolicyValidationTests() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		policy   *vmopv1.VirtualMachineAdmissionPolicy
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+		policy = &vmopv1.VirtualMachineAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "max-cpus",
+			},
+			Spec: vmopv1.VirtualMachineAdmissionPolicySpec{
+				Mode:   vmopv1.VirtualMachineAdmissionPolicyModeValidate,
+				Action: vmopv1.VirtualMachineAdmissionPolicyActionDeny,
+				Validations: []vmopv1.VirtualMachineAdmissionPolicyValidation{
+					{
+						Expression: "object.spec.className != 'best-effort-xlarge'",
+						Message:    "xlarge VMs are not allowed",
+					},
+				},
+			},
+		}
+		ctx.vm.Spec.ClassName = "best-effort-xlarge"
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		policy = nil
+	})
+
+	JustBeforeEach(func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateCreate(&ctx.WebhookRequestContext)
+	})
+
+	When("the policy denies the VM", func() {
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring(
+				`VirtualMachineAdmissionPolicy "max-cpus" denied the request: xlarge VMs are not allowed`))
+			Expect(response.AuditAnnotations).To(HaveKeyWithValue(
+				"max-cpus", "deny: xlarge VMs are not allowed"))
+		})
+	})
+
+	When("the policy warns about the VM", func() {
+		BeforeEach(func() {
+			policy.Spec.Action = vmopv1.VirtualMachineAdmissionPolicyActionWarn
+		})
+
+		It("should allow the request with a warning", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				`VirtualMachineAdmissionPolicy "max-cpus": xlarge VMs are not allowed`))
+			Expect(response.AuditAnnotations).To(HaveKeyWithValue(
+				"max-cpus", "warn: xlarge VMs are not allowed"))
+		})
+	})
+
+	When("the request is from VM Operator", func() {
+		BeforeEach(func() {
+			ctx.IsVMOperatorAccount = true
+		})
+
+		It("should not evaluate the policy", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.AuditAnnotations).To(BeEmpty())
+		})
+	})
+}
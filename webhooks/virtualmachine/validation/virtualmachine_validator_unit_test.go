@@ -35,10 +35,12 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha5/sysprep"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	pkgbuilder "github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -178,6 +180,7 @@ func unitTests() {
 		unitTestsValidateUpdate,
 	)
 	controllerTests()
+	admissionPolicyTests()
 	Describe(
 		"Delete",
 		Label(
@@ -599,6 +602,22 @@ func unitTestsValidateCreate() {
 					expectAllowed: false,
 				},
 			),
+			Entry("when WorkloadDomainIsolation capability enabled, should deny when VM created by SSO user that specifies a cordoned zone",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.WorkloadDomainIsolation = true
+						})
+						zoneName := builder.DummyZoneName
+						ctx.vm.Labels[corev1.LabelTopologyZone] = zoneName
+						zone := &topologyv1.Zone{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKey{Name: zoneName, Namespace: dummyNamespaceName}, zone)).To(Succeed())
+						zone.Annotations = map[string]string{topology.ZoneCordonedAnnotation: ""}
+						Expect(ctx.Client.Update(ctx, zone)).To(Succeed())
+					},
+					expectAllowed: false,
+				},
+			),
 			Entry("when WorkloadDomainIsolation capability enabled, should allow when VM created by admin that specifies a zone being deleted",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -2479,6 +2498,166 @@ func unitTestsValidateCreate() {
 				},
 			),
 
+			Entry("allow ip pool",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].IPPoolName = "my-pool"
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+						})
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow mixing ip pool with static and dhcp",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].IPPoolName = "my-pool"
+						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{"192.168.1.100/24"}
+						ctx.vm.Spec.Network.Interfaces[0].DHCP6 = true
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].ipPoolName: Forbidden: ipPoolName is mutually exclusive with addresses`,
+						`spec.network.interfaces[0].ipPoolName: Forbidden: ipPoolName is mutually exclusive with dhcp6`,
+					),
+				},
+			),
+
+			Entry("disallow ip pool with VPC network provider",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].IPPoolName = "my-pool"
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].ipPoolName: Forbidden: ipPoolName is available only with the following network providers: NAMED,VSPHERE_NETWORK`,
+					),
+				},
+			),
+
+			Entry("allow bond, vlan, and bridge when bootstrap is CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0"},
+								{Name: "eth1"},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0", "eth1"},
+									Mode:       vmopv1.VirtualMachineNetworkBondMode8023AD,
+									LACPRate:   vmopv1.VirtualMachineNetworkBondLACPRateFast,
+								},
+							},
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{
+									Name: "bond0.100",
+									ID:   100,
+									Link: "bond0",
+									VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+										Addresses: []string{"192.168.100.10/24"},
+										Gateway4:  "192.168.100.1",
+									},
+								},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"bond0"},
+									VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+										DHCP4: true,
+									},
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow bond, vlan, and bridge when bootstrap is not CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.Network.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+							{Name: "bond0", Interfaces: []string{"eth0"}},
+						}
+						ctx.vm.Spec.Network.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+							{Name: "eth0.100", ID: 100, Link: "eth0"},
+						}
+						ctx.vm.Spec.Network.Bridges = []vmopv1.VirtualMachineNetworkBridgeSpec{
+							{Name: "br0", Interfaces: []string{"eth0"}},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.bonds: Forbidden: bonds is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.vlans: Forbidden: vlans is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.bridges: Forbidden: bridges is available only with the following bootstrap providers: CloudInit`,
+					),
+				},
+			),
+
+			Entry("disallow invalid bond, vlan, and bridge references",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0", DHCP4: true},
+								{Name: "eth1"},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0", "eth2"},
+									Mode:       vmopv1.VirtualMachineNetworkBondModeActiveBackup,
+									LACPRate:   vmopv1.VirtualMachineNetworkBondLACPRateFast,
+									Primary:    "eth1",
+								},
+								{
+									Name:       "eth1",
+									Interfaces: []string{"eth0"},
+								},
+							},
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{Name: "vlan100", ID: 100, Link: "br0"},
+								{Name: "vlan101", ID: 101, Link: "eth1"},
+								{Name: "vlan101b", ID: 101, Link: "eth1"},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"vlan101", "eth3"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.bonds[1].name: Duplicate value: "eth1"`,
+						`spec.network.bonds[0].interfaces[1]: Invalid value: "eth2": must be the name of a network interface`,
+						`spec.network.bonds[0].primary: Invalid value: "eth1": must be one of the bond's interfaces`,
+						`spec.network.bonds[0].lacpRate: Forbidden: lacpRate is available only with mode 802.3ad`,
+						`spec.network.bonds[1].interfaces[0]: Invalid value: "eth0": is already a member of another bond or bridge`,
+						`spec.network.vlans[0].link: Invalid value: "br0": must be the name of a network interface or bond`,
+						`spec.network.vlans[2].id: Duplicate value: 101`,
+						`spec.network.bridges[0].interfaces[1]: Invalid value: "eth3": must be the name of a network interface, bond, or VLAN`,
+						`spec.network.interfaces[0]: Forbidden: a member of a bond or bridge may not have addresses, dhcp4, dhcp6, or ipPoolName`,
+					),
+				},
+			),
+
 			Entry("validate addresses",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -3935,6 +4114,192 @@ func unitTestsValidateCreate() {
 		)
 	})
 
+	Context("Zone and host affinity", func() {
+		BeforeEach(func() {
+			ctx.vm.Spec.Affinity = &vmopv1.AffinitySpec{
+				ZoneAffinity: &vmopv1.ZoneAffinitySpec{
+					RequiredDuringSchedulingIgnoredDuringExecution: []vmopv1.ZoneSelectorTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"gpu": "true"},
+							},
+						},
+					},
+				},
+				HostAffinity: &vmopv1.HostAffinitySpec{
+					RequiredDuringSchedulingIgnoredDuringExecution: []vmopv1.HostSelectorTerm{
+						{
+							Category: "licensing",
+							Operator: vmopv1.HostSelectorOpIn,
+							Tags:     []string{"oracle"},
+						},
+					},
+				},
+			}
+		})
+
+		DescribeTable("create", doTest,
+			Entry("allow zone and host affinity without GroupName",
+				testParams{
+					setup:         func(ctx *unitValidatingWebhookContext) {},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow zone and host affinity with GroupName",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.GroupName = dummyGroupName
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMGroups = true
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.affinity.zoneAffinity: Forbidden: when groupName is set`,
+						`spec.affinity.hostAffinity: Forbidden: when groupName is set`),
+				},
+			),
+
+			Entry("disallow VM affinity without GroupName",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Affinity.VMAffinity = &vmopv1.VMAffinitySpec{}
+					},
+					validate: doValidateWithMsg(`spec.groupName: Required value: when setting affinity`),
+				},
+			),
+
+			Entry("disallow zone affinity term without label selector",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Affinity.ZoneAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []vmopv1.ZoneSelectorTerm{{}}
+					},
+					validate: doValidateWithMsg(
+						`spec.affinity.zoneAffinity.preferredDuringSchedulingIgnoredDuringExecution[0].labelSelector: Required value`),
+				},
+			),
+
+			Entry("disallow zone affinity term with invalid label selector",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Affinity.ZoneAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+							{
+								Key:      "gpu",
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.affinity.zoneAffinity.requiredDuringSchedulingIgnoredDuringExecution[0].labelSelector.matchExpressions[0].values: Required value: must be specified when `),
+				},
+			),
+
+			Entry("disallow host affinity term without category or tags",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Affinity.HostAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Category = ""
+						ctx.vm.Spec.Affinity.HostAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Tags = nil
+					},
+					validate: doValidateWithMsg(
+						`spec.affinity.hostAffinity.requiredDuringSchedulingIgnoredDuringExecution[0].category: Required value`,
+						`spec.affinity.hostAffinity.requiredDuringSchedulingIgnoredDuringExecution[0].tags: Required value`),
+				},
+			),
+
+			Entry("disallow host affinity term with unsupported operator",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Affinity.HostAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []vmopv1.HostSelectorTerm{
+							{
+								Category: "hardware",
+								Operator: "Exists",
+								Tags:     []string{"fpga"},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.affinity.hostAffinity.preferredDuringSchedulingIgnoredDuringExecution[0].operator: Unsupported value: "Exists": supported values: "In", "NotIn"`),
+				},
+			),
+		)
+	})
+
+	Context("Image signature", func() {
+		var (
+			ns  *corev1.Namespace
+			vmi *vmopv1.VirtualMachineImage
+		)
+
+		BeforeEach(func() {
+			ns = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ctx.vm.Namespace,
+					Labels: map[string]string{
+						vmopv1.RequireVerifiedImagesLabelKey: "true",
+					},
+				},
+			}
+			vmi = builder.DummyVirtualMachineImage(ctx.vm.Spec.Image.Name)
+			vmi.Namespace = ctx.vm.Namespace
+		})
+
+		JustBeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, ns)).To(Succeed())
+			Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+		})
+
+		DescribeTable("create", doTest,
+			Entry("should allow when the image is verified",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcnd.MarkTrue(vmi, vmopv1.VirtualMachineImageSignatureVerifiedCondition)
+						Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow an unverified image when the namespace does not require verified images",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ns.Labels = nil
+						Expect(ctx.Client.Update(ctx, ns)).To(Succeed())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow an image that has not been verified",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {},
+					validate: doValidateWithMsg(
+						`spec.image: Forbidden: namespace requires images with a verified signature: image has not been verified`),
+				},
+			),
+			Entry("should disallow an image with an untrusted signature",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcnd.MarkFalse(
+							vmi,
+							vmopv1.VirtualMachineImageSignatureVerifiedCondition,
+							vmopv1.VirtualMachineImageSignatureUntrustedReason,
+							"")
+						Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						`spec.image: Forbidden: namespace requires images with a verified signature: SignatureUntrusted`),
+				},
+			),
+			Entry("should disallow an image that does not exist",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						Expect(ctx.Client.Delete(ctx, vmi)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						`spec.image: Forbidden: namespace requires images with a verified signature: `),
+				},
+			),
+		)
+	})
+
 	Context("spec.biosUUID", func() {
 		DescribeTable("create", doTest,
 			Entry("should allow when VM specifies valid UUID",
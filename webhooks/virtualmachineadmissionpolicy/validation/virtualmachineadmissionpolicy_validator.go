@@ -0,0 +1,141 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/admissionpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	validationsRequiredMsg = "must specify at least one validation in Validate mode"
+	defaultsRequiredMsg    = "must specify at least one default in Mutate mode"
+	validationsForbidMsg   = "may only be specified in Validate mode"
+	defaultsForbidMsg      = "may only be specified in Mutate mode"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineadmissionpolicy,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineadmissionpolicies,versions=v1alpha5,name=default.validating.virtualmachineadmissionpolicy.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineadmissionpolicies,verbs=get;list;watch
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineAdmissionPolicy{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.admissionPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(policy)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.admissionPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// All of the policy's fields may be changed.
+	fieldErrs := v.validateSpec(policy)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) validateSpec(policy *vmopv1.VirtualMachineAdmissionPolicy) field.ErrorList {
+	var (
+		fieldErrs field.ErrorList
+		specPath  = field.NewPath("spec")
+	)
+
+	if policy.Spec.NamespaceSelector != nil {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			policy.Spec.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{},
+			specPath.Child("namespaceSelector"))...)
+	}
+
+	validationsPath := specPath.Child("validations")
+	defaultsPath := specPath.Child("defaults")
+
+	switch policy.Spec.Mode {
+	case vmopv1.VirtualMachineAdmissionPolicyModeMutate:
+		if len(policy.Spec.Defaults) == 0 {
+			fieldErrs = append(fieldErrs, field.Required(defaultsPath, defaultsRequiredMsg))
+		}
+		if len(policy.Spec.Validations) > 0 {
+			fieldErrs = append(fieldErrs, field.Forbidden(validationsPath, validationsForbidMsg))
+		}
+	default:
+		if len(policy.Spec.Validations) == 0 {
+			fieldErrs = append(fieldErrs, field.Required(validationsPath, validationsRequiredMsg))
+		}
+		if len(policy.Spec.Defaults) > 0 {
+			fieldErrs = append(fieldErrs, field.Forbidden(defaultsPath, defaultsForbidMsg))
+		}
+	}
+
+	fieldErrs = append(fieldErrs, admissionpolicy.ValidateExpressions(policy)...)
+
+	return fieldErrs
+}
+
+// admissionPolicyFromUnstructured returns the VirtualMachineAdmissionPolicy
+// from the unstructured object.
+func (v validator) admissionPolicyFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineAdmissionPolicy, error) {
+
+	policy := &vmopv1.VirtualMachineAdmissionPolicy{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
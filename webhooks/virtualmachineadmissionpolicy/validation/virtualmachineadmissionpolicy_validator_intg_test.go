@@ -0,0 +1,66 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		policy *vmopv1.VirtualMachineAdmissionPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		policy = newAdmissionPolicy()
+		policy.Name = "policy-" + ctx.Namespace
+	})
+
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, policy)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+		policy = nil
+	})
+
+	It("should allow a valid policy to be created", func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+	})
+
+	It("should deny an update with an invalid expression", func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+
+		policy.Spec.Validations = []vmopv1.VirtualMachineAdmissionPolicyValidation{
+			{
+				Expression: "object.spec.className ==",
+			},
+		}
+		err := ctx.Client.Update(ctx, policy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.validations[0].expression: Invalid value"))
+	})
+}
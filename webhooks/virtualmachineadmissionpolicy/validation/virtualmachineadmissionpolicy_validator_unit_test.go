@@ -0,0 +1,250 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	policy *vmopv1.VirtualMachineAdmissionPolicy
+}
+
+func newAdmissionPolicy() *vmopv1.VirtualMachineAdmissionPolicy {
+	return &vmopv1.VirtualMachineAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dummy-admission-policy",
+		},
+		Spec: vmopv1.VirtualMachineAdmissionPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+			Mode:   vmopv1.VirtualMachineAdmissionPolicyModeValidate,
+			Action: vmopv1.VirtualMachineAdmissionPolicyActionDeny,
+			Validations: []vmopv1.VirtualMachineAdmissionPolicyValidation{
+				{
+					Expression: "object.spec.?crypto.encryptionClassName.orValue('') != ''",
+					Message:    "VMs in prod must use encryption",
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	policy := newAdmissionPolicy()
+	obj, err := builder.ToUnstructured(policy)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(policy.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			policy:                              policy,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		policy:                              policy,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(
+		mutateFn func(*vmopv1.VirtualMachineAdmissionPolicy),
+		expectedAllowed bool,
+		expectedReason string) {
+
+		if mutateFn != nil {
+			mutateFn(ctx.policy)
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	mutate := func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+		policy.Spec.Mode = vmopv1.VirtualMachineAdmissionPolicyModeMutate
+		policy.Spec.Validations = nil
+		policy.Spec.Defaults = []vmopv1.VirtualMachineAdmissionPolicyDefault{
+			{
+				Path:       "spec.storageClass",
+				Expression: "'gold'",
+			},
+		}
+	}
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid policy", nil, true, ""),
+		Entry("should allow policy without namespace selector", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.NamespaceSelector = nil
+		}, true, ""),
+		Entry("should allow valid mutating policy", mutate, true, ""),
+		Entry("should deny invalid namespace selector", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.NamespaceSelector.MatchLabels = map[string]string{"env": "prod!"}
+		}, false, `spec.namespaceSelector.matchLabels: Invalid value: "prod!"`),
+		Entry("should deny validating policy without validations", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.Validations = nil
+		}, false, "spec.validations: Required value: must specify at least one validation in Validate mode"),
+		Entry("should deny validating policy with defaults", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.Defaults = []vmopv1.VirtualMachineAdmissionPolicyDefault{
+				{Path: "spec.storageClass", Expression: "'gold'"},
+			}
+		}, false, "spec.defaults: Forbidden: may only be specified in Mutate mode"),
+		Entry("should deny mutating policy without defaults", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			mutate(policy)
+			policy.Spec.Defaults = nil
+		}, false, "spec.defaults: Required value: must specify at least one default in Mutate mode"),
+		Entry("should deny mutating policy with validations", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			mutate(policy)
+			policy.Spec.Validations = newAdmissionPolicy().Spec.Validations
+		}, false, "spec.validations: Forbidden: may only be specified in Validate mode"),
+		Entry("should deny validation that does not compile", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.Validations[0].Expression = "object.spec.className =="
+		}, false, "spec.validations[0].expression: Invalid value"),
+		Entry("should deny validation that is not a bool", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			policy.Spec.Validations[0].Expression = "size(object.spec.className)"
+		}, false, "must evaluate to bool, not int"),
+		Entry("should deny default that does not compile", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			mutate(policy)
+			policy.Spec.Defaults[0].Expression = "'gold"
+		}, false, "spec.defaults[0].expression: Invalid value"),
+		Entry("should deny default with path not in spec", func(policy *vmopv1.VirtualMachineAdmissionPolicy) {
+			mutate(policy)
+			policy.Spec.Defaults[0].Path = "status.storageClass"
+		}, false, `spec.defaults[0].path: Invalid value: "status.storageClass": must be a dot-separated path of a field in spec`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the validations are changed", func() {
+		BeforeEach(func() {
+			ctx.policy.Spec.Validations[0].Expression = "object.spec.className != 'best-effort-xlarge'"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the validations are changed to invalid expressions", func() {
+		BeforeEach(func() {
+			ctx.policy.Spec.Validations[0].Expression = "object.spec.className !="
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.validations[0].expression: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,279 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	operationRequired         = "one of copyIn, copyOut, or runProgram is required"
+	operationsExclusive       = "copyIn, copyOut, and runProgram are mutually exclusive"
+	sourceRequired            = "one of secret or configMap is required"
+	sourceMutuallyExclusive   = "secret and configMap are mutually exclusive"
+	copyOutSecretIsCredsMsg   = "must not be the credentials secret"
+	invalidResourceNameMsgFmt = "must be a valid resource name: %s"
+	invalidKeyMsgFmt          = "must be a valid secret key: %s"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineguestoperation,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,versions=v1alpha5,name=default.validating.virtualmachineguestoperation.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,verbs=get;list;watch
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineGuestOperation{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	guestOp, err := v.guestOperationFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(guestOp)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	guestOp, err := v.guestOperationFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+	oldGuestOp, err := v.guestOperationFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The operation is performed once, so only its TTL may be changed.
+	var (
+		fieldErrs field.ErrorList
+		spec      = guestOp.Spec
+		oldSpec   = oldGuestOp.Spec
+		specPath  = field.NewPath("spec")
+	)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		spec.VMName, oldSpec.VMName, specPath.Child("vmName"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		spec.CredentialsSecretName, oldSpec.CredentialsSecretName, specPath.Child("credentialsSecretName"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		spec.CopyIn, oldSpec.CopyIn, specPath.Child("copyIn"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		spec.CopyOut, oldSpec.CopyOut, specPath.Child("copyOut"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		spec.RunProgram, oldSpec.RunProgram, specPath.Child("runProgram"))...)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) validateSpec(guestOp *vmopv1.VirtualMachineGuestOperation) field.ErrorList {
+	var (
+		fieldErrs field.ErrorList
+		spec      = guestOp.Spec
+		specPath  = field.NewPath("spec")
+	)
+
+	if spec.VMName == "" {
+		fieldErrs = append(fieldErrs, field.Required(specPath.Child("vmName"), ""))
+	}
+	if spec.CredentialsSecretName == "" {
+		fieldErrs = append(fieldErrs, field.Required(specPath.Child("credentialsSecretName"), ""))
+	}
+
+	var numOps int
+	for _, set := range []bool{spec.CopyIn != nil, spec.CopyOut != nil, spec.RunProgram != nil} {
+		if set {
+			numOps++
+		}
+	}
+	switch {
+	case numOps == 0:
+		fieldErrs = append(fieldErrs, field.Required(specPath, operationRequired))
+	case numOps > 1:
+		fieldErrs = append(fieldErrs, field.Invalid(specPath, "", operationsExclusive))
+	}
+
+	if spec.CopyIn != nil {
+		fieldErrs = append(fieldErrs, v.validateCopyIn(spec.CopyIn, specPath.Child("copyIn"))...)
+	}
+	if spec.CopyOut != nil {
+		fieldErrs = append(fieldErrs, v.validateCopyOut(spec, specPath.Child("copyOut"))...)
+	}
+	if spec.RunProgram != nil {
+		fieldErrs = append(fieldErrs, v.validateRunProgram(spec.RunProgram, specPath.Child("runProgram"))...)
+	}
+
+	return fieldErrs
+}
+
+func (v validator) validateCopyIn(
+	copyIn *vmopv1.VirtualMachineGuestOperationCopyIn,
+	copyInPath *field.Path) field.ErrorList {
+
+	var (
+		fieldErrs  field.ErrorList
+		src        = copyIn.Source
+		sourcePath = copyInPath.Child("source")
+	)
+
+	switch {
+	case src.Secret != nil && src.ConfigMap != nil:
+		fieldErrs = append(fieldErrs, field.Invalid(sourcePath.Child("secret"), "secret", sourceMutuallyExclusive))
+	case src.Secret == nil && src.ConfigMap == nil:
+		fieldErrs = append(fieldErrs, field.Required(sourcePath, sourceRequired))
+	case src.Secret != nil:
+		fieldErrs = append(fieldErrs, validateKeySelector(
+			src.Secret.Name, src.Secret.Key, sourcePath.Child("secret"))...)
+	case src.ConfigMap != nil:
+		fieldErrs = append(fieldErrs, validateKeySelector(
+			src.ConfigMap.Name, src.ConfigMap.Key, sourcePath.Child("configMap"))...)
+	}
+
+	if copyIn.GuestPath == "" {
+		fieldErrs = append(fieldErrs, field.Required(copyInPath.Child("guestPath"), ""))
+	}
+
+	return fieldErrs
+}
+
+func (v validator) validateCopyOut(
+	spec vmopv1.VirtualMachineGuestOperationSpec,
+	copyOutPath *field.Path) field.ErrorList {
+
+	var (
+		fieldErrs field.ErrorList
+		copyOut   = spec.CopyOut
+	)
+
+	if copyOut.GuestPath == "" {
+		fieldErrs = append(fieldErrs, field.Required(copyOutPath.Child("guestPath"), ""))
+	}
+
+	secretNamePath := copyOutPath.Child("secretName")
+	switch {
+	case copyOut.SecretName == "":
+		fieldErrs = append(fieldErrs, field.Required(secretNamePath, ""))
+	case copyOut.SecretName == spec.CredentialsSecretName:
+		fieldErrs = append(fieldErrs, field.Invalid(secretNamePath, copyOut.SecretName, copyOutSecretIsCredsMsg))
+	default:
+		fieldErrs = append(fieldErrs, validateResourceName(copyOut.SecretName, secretNamePath)...)
+	}
+
+	if copyOut.Key != "" {
+		fieldErrs = append(fieldErrs, validateKey(copyOut.Key, copyOutPath.Child("key"))...)
+	}
+
+	return fieldErrs
+}
+
+func (v validator) validateRunProgram(
+	runProgram *vmopv1.VirtualMachineGuestOperationRunProgram,
+	runProgramPath *field.Path) field.ErrorList {
+
+	var fieldErrs field.ErrorList
+
+	if runProgram.Path == "" {
+		fieldErrs = append(fieldErrs, field.Required(runProgramPath.Child("path"), ""))
+	}
+
+	envPath := runProgramPath.Child("env")
+	for i, e := range runProgram.Env {
+		if e.Name == "" {
+			fieldErrs = append(fieldErrs, field.Required(envPath.Index(i).Child("name"), ""))
+		}
+	}
+
+	return fieldErrs
+}
+
+func validateKeySelector(name, key string, selectorPath *field.Path) field.ErrorList {
+	var fieldErrs field.ErrorList
+
+	if name == "" {
+		fieldErrs = append(fieldErrs, field.Required(selectorPath.Child("name"), ""))
+	} else {
+		fieldErrs = append(fieldErrs, validateResourceName(name, selectorPath.Child("name"))...)
+	}
+
+	if key == "" {
+		fieldErrs = append(fieldErrs, field.Required(selectorPath.Child("key"), ""))
+	} else {
+		fieldErrs = append(fieldErrs, validateKey(key, selectorPath.Child("key"))...)
+	}
+
+	return fieldErrs
+}
+
+func validateResourceName(name string, namePath *field.Path) field.ErrorList {
+	var fieldErrs field.ErrorList
+	for _, msg := range k8svalidation.IsDNS1123Subdomain(name) {
+		fieldErrs = append(fieldErrs, field.Invalid(namePath, name, fmt.Sprintf(invalidResourceNameMsgFmt, msg)))
+	}
+	return fieldErrs
+}
+
+func validateKey(key string, keyPath *field.Path) field.ErrorList {
+	var fieldErrs field.ErrorList
+	for _, msg := range k8svalidation.IsConfigMapKey(key) {
+		fieldErrs = append(fieldErrs, field.Invalid(keyPath, key, fmt.Sprintf(invalidKeyMsgFmt, msg)))
+	}
+	return fieldErrs
+}
+
+// guestOperationFromUnstructured returns the VirtualMachineGuestOperation from
+// the unstructured object.
+func (v validator) guestOperationFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineGuestOperation, error) {
+
+	guestOp := &vmopv1.VirtualMachineGuestOperation{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), guestOp); err != nil {
+		return nil, err
+	}
+	return guestOp, nil
+}
@@ -0,0 +1,66 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx     *builder.IntegrationTestContext
+		guestOp *vmopv1.VirtualMachineGuestOperation
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		guestOp = newGuestOperation()
+		guestOp.Namespace = ctx.Namespace
+	})
+
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, guestOp)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+		guestOp = nil
+	})
+
+	It("should allow the TTL to be updated", func() {
+		Expect(ctx.Client.Create(ctx, guestOp)).To(Succeed())
+
+		guestOp.Spec.TTLSecondsAfterFinished = ptr.To[int64](60)
+		Expect(ctx.Client.Update(ctx, guestOp)).To(Succeed())
+	})
+
+	It("should deny an update to the program", func() {
+		Expect(ctx.Client.Create(ctx, guestOp)).To(Succeed())
+
+		guestOp.Spec.RunProgram.Path = "/usr/bin/reboot"
+		err := ctx.Client.Update(ctx, guestOp)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field is immutable"))
+	})
+}
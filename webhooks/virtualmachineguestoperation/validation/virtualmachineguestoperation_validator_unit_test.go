@@ -0,0 +1,302 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	guestOp *vmopv1.VirtualMachineGuestOperation
+}
+
+func newGuestOperation() *vmopv1.VirtualMachineGuestOperation {
+	return &vmopv1.VirtualMachineGuestOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-guest-operation",
+			Namespace: "dummy-namespace",
+		},
+		Spec: vmopv1.VirtualMachineGuestOperationSpec{
+			VMName:                "dummy-vm",
+			CredentialsSecretName: "dummy-creds",
+			RunProgram: &vmopv1.VirtualMachineGuestOperationRunProgram{
+				Path: "/usr/bin/systemctl",
+				Args: []string{"restart", "nginx"},
+				Env: []vmopv1common.NameValuePair{
+					{Name: "SYSTEMD_PAGER", Value: ""},
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	guestOp := newGuestOperation()
+	obj, err := builder.ToUnstructured(guestOp)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(guestOp.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			guestOp:                             guestOp,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		guestOp:                             guestOp,
+	}
+}
+
+func copyIn() *vmopv1.VirtualMachineGuestOperationCopyIn {
+	return &vmopv1.VirtualMachineGuestOperationCopyIn{
+		Source: vmopv1.VirtualMachineGuestOperationFileSource{
+			Secret: &vmopv1common.SecretKeySelector{
+				Name: "my-secret",
+				Key:  "tls.crt",
+			},
+		},
+		GuestPath: "/etc/nginx/tls.crt",
+	}
+}
+
+func copyOut() *vmopv1.VirtualMachineGuestOperationCopyOut {
+	return &vmopv1.VirtualMachineGuestOperationCopyOut{
+		GuestPath:  "/var/log/nginx/error.log",
+		SecretName: "nginx-error-log",
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(
+		mutateFn func(*vmopv1.VirtualMachineGuestOperation),
+		expectedAllowed bool,
+		expectedReason string) {
+
+		if mutateFn != nil {
+			mutateFn(ctx.guestOp)
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.guestOp)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid run program", nil, true, ""),
+		Entry("should allow valid copy in from secret", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+		}, true, ""),
+		Entry("should allow valid copy in from config map", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+			guestOp.Spec.CopyIn.Source.Secret = nil
+			guestOp.Spec.CopyIn.Source.ConfigMap = &vmopv1.ConfigMapKeySelector{Name: "my-config", Key: "nginx.conf"}
+		}, true, ""),
+		Entry("should allow valid copy out", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyOut = copyOut()
+			guestOp.Spec.CopyOut.Key = "error.log"
+		}, true, ""),
+		Entry("should deny missing vm name", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.VMName = ""
+		}, false, "spec.vmName: Required value"),
+		Entry("should deny missing credentials secret name", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.CredentialsSecretName = ""
+		}, false, "spec.credentialsSecretName: Required value"),
+		Entry("should deny no operation", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+		}, false, "spec: Required value: one of copyIn, copyOut, or runProgram is required"),
+		Entry("should deny multiple operations", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.CopyOut = copyOut()
+		}, false, "copyIn, copyOut, and runProgram are mutually exclusive"),
+		Entry("should deny copy in without source", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+			guestOp.Spec.CopyIn.Source.Secret = nil
+		}, false, "spec.copyIn.source: Required value: one of secret or configMap is required"),
+		Entry("should deny copy in with secret and config map", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+			guestOp.Spec.CopyIn.Source.ConfigMap = &vmopv1.ConfigMapKeySelector{Name: "my-config", Key: "nginx.conf"}
+		}, false, `spec.copyIn.source.secret: Invalid value: "secret": secret and configMap are mutually exclusive`),
+		Entry("should deny copy in with invalid key", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+			guestOp.Spec.CopyIn.Source.Secret.Key = "tls/crt"
+		}, false, `spec.copyIn.source.secret.key: Invalid value: "tls/crt": must be a valid secret key`),
+		Entry("should deny copy in without guest path", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyIn = copyIn()
+			guestOp.Spec.CopyIn.GuestPath = ""
+		}, false, "spec.copyIn.guestPath: Required value"),
+		Entry("should deny copy out with invalid secret name", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyOut = copyOut()
+			guestOp.Spec.CopyOut.SecretName = "Error_Log"
+		}, false, `spec.copyOut.secretName: Invalid value: "Error_Log": must be a valid resource name`),
+		Entry("should deny copy out to the credentials secret", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyOut = copyOut()
+			guestOp.Spec.CopyOut.SecretName = guestOp.Spec.CredentialsSecretName
+		}, false, `spec.copyOut.secretName: Invalid value: "dummy-creds": must not be the credentials secret`),
+		Entry("should deny copy out with invalid key", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram = nil
+			guestOp.Spec.CopyOut = copyOut()
+			guestOp.Spec.CopyOut.Key = "error log"
+		}, false, `spec.copyOut.key: Invalid value: "error log": must be a valid secret key`),
+		Entry("should deny run program without path", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram.Path = ""
+		}, false, "spec.runProgram.path: Required value"),
+		Entry("should deny env without name", func(guestOp *vmopv1.VirtualMachineGuestOperation) {
+			guestOp.Spec.RunProgram.Env[0].Name = ""
+		}, false, "spec.runProgram.env[0].name: Required value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.guestOp)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the TTL is changed", func() {
+		BeforeEach(func() {
+			ctx.guestOp.Spec.TTLSecondsAfterFinished = ptr.To[int64](60)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the VM name is changed", func() {
+		BeforeEach(func() {
+			ctx.guestOp.Spec.VMName = "other-vm"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.vmName: Invalid value: \"other-vm\": field is immutable"))
+		})
+	})
+
+	When("the program is changed", func() {
+		BeforeEach(func() {
+			ctx.guestOp.Spec.RunProgram.Args = []string{"stop", "nginx"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.runProgram"))
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
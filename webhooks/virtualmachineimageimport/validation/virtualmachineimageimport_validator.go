@@ -0,0 +1,198 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	requiredOneSourceFmt    = "one of %s or %s must be set"
+	forbiddenBothSourcesFmt = "only one of %s or %s may be set"
+	unsupportedURLFmt       = "must be an http or https URL with one of the extensions: %s"
+	invalidChecksumLenFmt   = "must be %d hex characters for %s"
+)
+
+var (
+	supportedExtensions = []string{".ova", ".ovf", ".iso"}
+
+	checksumHexLen = map[vmopv1.VirtualMachineImageImportChecksumAlgorithm]int{
+		vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256: 64,
+		vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA512: 128,
+	}
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineimageimport,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimageimports,versions=v1alpha5,name=default.validating.virtualmachineimageimport.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageimports,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImageImport{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	imageImport, err := v.vmImageImportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSource(imageImport)...)
+	fieldErrs = append(fieldErrs, v.validateTarget(imageImport)...)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	imageImport, err := v.vmImageImportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+	oldImageImport, err := v.vmImageImportFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The image is imported once, so its source and target are immutable.
+	specPath := field.NewPath("spec")
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		imageImport.Spec.Source, oldImageImport.Spec.Source, specPath.Child("source"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		imageImport.Spec.Target, oldImageImport.Spec.Target, specPath.Child("target"))...)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+// validateSource ensures exactly one source is set, and that it describes an
+// image that can be imported.
+func (v validator) validateSource(imageImport *vmopv1.VirtualMachineImageImport) field.ErrorList {
+	var fieldErrs field.ErrorList
+	source := imageImport.Spec.Source
+	sourcePath := field.NewPath("spec", "source")
+	httpPath := sourcePath.Child("http")
+	ociPath := sourcePath.Child("oci")
+
+	switch {
+	case source.HTTP == nil && source.OCI == nil:
+		fieldErrs = append(fieldErrs, field.Required(sourcePath,
+			fmt.Sprintf(requiredOneSourceFmt, httpPath, ociPath)))
+	case source.HTTP != nil && source.OCI != nil:
+		fieldErrs = append(fieldErrs, field.Forbidden(sourcePath,
+			fmt.Sprintf(forbiddenBothSourcesFmt, httpPath, ociPath)))
+	}
+
+	if source.HTTP != nil {
+		urlPath := httpPath.Child("url")
+		u, err := url.Parse(source.HTTP.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			!isSupportedExtension(u.Path) {
+
+			fieldErrs = append(fieldErrs, field.Invalid(urlPath, source.HTTP.URL,
+				fmt.Sprintf(unsupportedURLFmt, strings.Join(supportedExtensions, ", "))))
+		}
+
+		if checksum := source.HTTP.Checksum; checksum != nil {
+			if n, ok := checksumHexLen[checksum.Algorithm]; ok && len(checksum.Value) != n {
+				fieldErrs = append(fieldErrs, field.Invalid(httpPath.Child("checksum", "value"), checksum.Value,
+					fmt.Sprintf(invalidChecksumLenFmt, n, checksum.Algorithm)))
+			}
+		}
+	}
+
+	if source.OCI != nil {
+		var opts []name.Option
+		if source.OCI.Insecure {
+			opts = append(opts, name.Insecure)
+		}
+		if _, err := name.ParseReference(source.OCI.Reference, opts...); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(ociPath.Child("reference"), source.OCI.Reference, err.Error()))
+		}
+		if secretName := source.OCI.PullSecretName; secretName != "" {
+			for _, msg := range validation.NameIsDNSSubdomain(secretName, false) {
+				fieldErrs = append(fieldErrs, field.Invalid(ociPath.Child("pullSecretName"), secretName, msg))
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+// validateTarget ensures the target library is a valid resource name.
+func (v validator) validateTarget(imageImport *vmopv1.VirtualMachineImageImport) field.ErrorList {
+	var fieldErrs field.ErrorList
+	libraryName := imageImport.Spec.Target.LibraryName
+	libraryNamePath := field.NewPath("spec", "target", "libraryName")
+
+	if libraryName == "" {
+		return append(fieldErrs, field.Required(libraryNamePath, ""))
+	}
+	for _, msg := range validation.NameIsDNSSubdomain(libraryName, false) {
+		fieldErrs = append(fieldErrs, field.Invalid(libraryNamePath, libraryName, msg))
+	}
+
+	return fieldErrs
+}
+
+func isSupportedExtension(p string) bool {
+	return slices.Contains(supportedExtensions, strings.ToLower(path.Ext(p)))
+}
+
+// vmImageImportFromUnstructured returns the VirtualMachineImageImport from the unstructured object.
+func (v validator) vmImageImportFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineImageImport, error) {
+	imageImport := &vmopv1.VirtualMachineImageImport{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), imageImport); err != nil {
+		return nil, err
+	}
+	return imageImport, nil
+}
@@ -0,0 +1,82 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx         *builder.IntegrationTestContext
+		imageImport *vmopv1.VirtualMachineImageImport
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		imageImport = &vmopv1.VirtualMachineImageImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-image-import",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineImageImportSpec{
+				Source: vmopv1.VirtualMachineImageImportSource{
+					OCI: &vmopv1.VirtualMachineImageImportOCISource{
+						Reference: "registry.example.com/images/photon:5.0",
+					},
+				},
+				Target: vmopv1.VirtualMachineImageImportTarget{
+					LibraryName: "dummy-cl",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		imageImport = nil
+	})
+
+	It("should allow a valid import to be created", func() {
+		Expect(ctx.Client.Create(ctx, imageImport)).To(Succeed())
+	})
+
+	It("should deny an import with no source", func() {
+		imageImport.Spec.Source.OCI = nil
+		err := ctx.Client.Create(ctx, imageImport)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("one of spec.source.http or spec.source.oci must be set"))
+	})
+
+	It("should deny an update to the source", func() {
+		Expect(ctx.Client.Create(ctx, imageImport)).To(Succeed())
+		imageImport.Spec.Source.OCI.Reference = "registry.example.com/images/photon:6.0"
+		err := ctx.Client.Update(ctx, imageImport)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field is immutable"))
+	})
+}
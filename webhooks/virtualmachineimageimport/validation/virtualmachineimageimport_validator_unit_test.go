@@ -0,0 +1,289 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	imageImport    *vmopv1.VirtualMachineImageImport
+	oldImageImport *vmopv1.VirtualMachineImageImport
+}
+
+func newImageImport() *vmopv1.VirtualMachineImageImport {
+	return &vmopv1.VirtualMachineImageImport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-image-import",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachineImageImportSpec{
+			Source: vmopv1.VirtualMachineImageImportSource{
+				HTTP: &vmopv1.VirtualMachineImageImportHTTPSource{
+					URL: "https://images.example.com/photon.ova",
+				},
+			},
+			Target: vmopv1.VirtualMachineImageImportTarget{
+				LibraryName: "dummy-cl",
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	imageImport := newImageImport()
+	obj, err := builder.ToUnstructured(imageImport)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldImageImport *vmopv1.VirtualMachineImageImport
+	if isUpdate {
+		oldImageImport = imageImport.DeepCopy()
+		oldObj, err := builder.ToUnstructured(oldImageImport)
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			imageImport:                         imageImport,
+			oldImageImport:                      oldImageImport,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		imageImport:                         imageImport,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noSource        bool
+		bothSources     bool
+		url             string
+		checksum        *vmopv1.VirtualMachineImageImportChecksum
+		ociReference    string
+		ociInsecure     bool
+		pullSecretName  string
+		noLibraryName   bool
+		invalidLibrary  bool
+		emptyHTTPSource bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		source := &ctx.imageImport.Spec.Source
+		if args.url != "" {
+			source.HTTP.URL = args.url
+		}
+		source.HTTP.Checksum = args.checksum
+		if args.ociReference != "" {
+			source.HTTP = nil
+			source.OCI = &vmopv1.VirtualMachineImageImportOCISource{
+				Reference:      args.ociReference,
+				Insecure:       args.ociInsecure,
+				PullSecretName: args.pullSecretName,
+			}
+		}
+		if args.noSource {
+			source.HTTP = nil
+			source.OCI = nil
+		}
+		if args.bothSources {
+			source.OCI = &vmopv1.VirtualMachineImageImportOCISource{
+				Reference: "registry.example.com/images/photon:5.0",
+			}
+		}
+		if args.noLibraryName {
+			ctx.imageImport.Spec.Target.LibraryName = ""
+		}
+		if args.invalidLibrary {
+			ctx.imageImport.Spec.Target.LibraryName = "Not_Valid"
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.imageImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid HTTP source", createArgs{}, true, ""),
+		Entry("should allow OVF URL", createArgs{url: "http://images.example.com/photon/photon.OVF"}, true, ""),
+		Entry("should allow ISO URL with query", createArgs{url: "https://images.example.com/photon.iso?sig=abc"}, true, ""),
+		Entry("should deny no source", createArgs{noSource: true}, false,
+			"spec.source: Required value: one of spec.source.http or spec.source.oci must be set"),
+		Entry("should deny both sources", createArgs{bothSources: true}, false,
+			"spec.source: Forbidden: only one of spec.source.http or spec.source.oci may be set"),
+		Entry("should deny unsupported URL scheme", createArgs{url: "ftp://images.example.com/photon.ova"}, false,
+			"spec.source.http.url: Invalid value"),
+		Entry("should deny unsupported extension", createArgs{url: "https://images.example.com/photon.vmdk"}, false,
+			"must be an http or https URL with one of the extensions: .ova, .ovf, .iso"),
+		Entry("should allow valid SHA256 checksum", createArgs{checksum: &vmopv1.VirtualMachineImageImportChecksum{
+			Algorithm: vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA256,
+			Value:     strings.Repeat("a", 64),
+		}}, true, ""),
+		Entry("should deny SHA512 checksum with wrong length", createArgs{checksum: &vmopv1.VirtualMachineImageImportChecksum{
+			Algorithm: vmopv1.VirtualMachineImageImportChecksumAlgorithmSHA512,
+			Value:     strings.Repeat("a", 64),
+		}}, false, "spec.source.http.checksum.value: Invalid value"),
+		Entry("should allow valid OCI source", createArgs{
+			ociReference:   "registry.example.com/images/photon:5.0",
+			pullSecretName: "my-secret",
+		}, true, ""),
+		Entry("should allow insecure OCI source", createArgs{
+			ociReference: "localhost:5000/images/photon@sha256:" + strings.Repeat("a", 64),
+			ociInsecure:  true,
+		}, true, ""),
+		Entry("should deny invalid OCI reference", createArgs{ociReference: "registry.example.com/Images:"}, false,
+			"spec.source.oci.reference: Invalid value"),
+		Entry("should deny invalid pull secret name", createArgs{
+			ociReference:   "registry.example.com/images/photon:5.0",
+			pullSecretName: "My_Secret",
+		}, false, "spec.source.oci.pullSecretName: Invalid value"),
+		Entry("should deny no library name", createArgs{noLibraryName: true}, false,
+			"spec.target.libraryName: Required value"),
+		Entry("should deny invalid library name", createArgs{invalidLibrary: true}, false,
+			"spec.target.libraryName: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.imageImport)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the TTL is changed", func() {
+		BeforeEach(func() {
+			ttl := int64(60)
+			ctx.imageImport.Spec.TTLSecondsAfterFinished = &ttl
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the source is changed", func() {
+		BeforeEach(func() {
+			ctx.imageImport.Spec.Source.HTTP.URL = "https://images.example.com/other.ova"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.source: Invalid value"))
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		})
+	})
+
+	When("the target is changed", func() {
+		BeforeEach(func() {
+			ctx.imageImport.Spec.Target.ItemName = "other"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.target: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,140 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/blang/semver/v4"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	requiredOneSelectorFmt = "at least one of %s, %s or %s must be set"
+	invalidVersionRangeFmt = "must be a semantic version range: %v"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineimagestream,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimagestreams,versions=v1alpha5,name=default.validating.virtualmachineimagestream.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagestreams,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImageStream{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	stream, err := v.vmImageStreamFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(stream)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	stream, err := v.vmImageStreamFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The selectors may be changed, ex. to move the stream to the next major
+	// version, so they are validated the same way as on create.
+	fieldErrs := v.validateSpec(stream)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+// validateSpec ensures the stream has at least one selector, and that its
+// version ranges and label selector are valid.
+func (v validator) validateSpec(stream *vmopv1.VirtualMachineImageStream) field.ErrorList {
+	var (
+		fieldErrs    field.ErrorList
+		spec         = stream.Spec
+		specPath     = field.NewPath("spec")
+		productPath  = specPath.Child("product")
+		osPath       = specPath.Child("os")
+		selectorPath = specPath.Child("selector")
+	)
+
+	if spec.Product == nil && spec.OS == nil && spec.Selector == nil {
+		fieldErrs = append(fieldErrs, field.Required(specPath,
+			fmt.Sprintf(requiredOneSelectorFmt, productPath, osPath, selectorPath)))
+	}
+
+	if spec.Product != nil {
+		fieldErrs = append(fieldErrs, validateVersionRange(spec.Product.Version, productPath.Child("version"))...)
+	}
+	if spec.OS != nil {
+		fieldErrs = append(fieldErrs, validateVersionRange(spec.OS.Version, osPath.Child("version"))...)
+	}
+	if spec.Selector != nil {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			spec.Selector, metav1validation.LabelSelectorValidationOptions{}, selectorPath)...)
+	}
+
+	return fieldErrs
+}
+
+func validateVersionRange(r string, p *field.Path) field.ErrorList {
+	if r == "" {
+		return nil
+	}
+	if _, err := semver.ParseRange(r); err != nil {
+		return field.ErrorList{field.Invalid(p, r, fmt.Sprintf(invalidVersionRangeFmt, err))}
+	}
+	return nil
+}
+
+// vmImageStreamFromUnstructured returns the VirtualMachineImageStream from the unstructured object.
+func (v validator) vmImageStreamFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineImageStream, error) {
+	stream := &vmopv1.VirtualMachineImageStream{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), stream); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
@@ -0,0 +1,78 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		stream *vmopv1.VirtualMachineImageStream
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		stream = &vmopv1.VirtualMachineImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-image-stream",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineImageStreamSpec{
+				Product: &vmopv1.VirtualMachineImageStreamProductSelector{
+					Product: "Photon OS",
+					Version: ">=5.0.0",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		stream = nil
+	})
+
+	It("should allow a valid stream to be created", func() {
+		Expect(ctx.Client.Create(ctx, stream)).To(Succeed())
+	})
+
+	It("should deny a stream with no selectors", func() {
+		stream.Spec.Product = nil
+		err := ctx.Client.Create(ctx, stream)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("at least one of spec.product, spec.os or spec.selector must be set"))
+	})
+
+	It("should deny an update to an invalid version range", func() {
+		Expect(ctx.Client.Create(ctx, stream)).To(Succeed())
+		stream.Spec.Product.Version = "latest"
+		err := ctx.Client.Update(ctx, stream)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.product.version: Invalid value"))
+	})
+}
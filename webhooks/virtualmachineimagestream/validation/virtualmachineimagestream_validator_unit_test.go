@@ -0,0 +1,237 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	stream *vmopv1.VirtualMachineImageStream
+}
+
+func newImageStream() *vmopv1.VirtualMachineImageStream {
+	return &vmopv1.VirtualMachineImageStream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-image-stream",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachineImageStreamSpec{
+			Product: &vmopv1.VirtualMachineImageStreamProductSelector{
+				Product: "Photon OS",
+				Vendor:  "VMware",
+				Version: ">=5.0.0 <6.0.0",
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	stream := newImageStream()
+	obj, err := builder.ToUnstructured(stream)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(stream.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			stream:                              stream,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		stream:                              stream,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noProduct      bool
+		productVersion string
+		os             *vmopv1.VirtualMachineImageStreamOSSelector
+		selector       *metav1.LabelSelector
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noProduct {
+			ctx.stream.Spec.Product = nil
+		}
+		if args.productVersion != "" {
+			ctx.stream.Spec.Product.Version = args.productVersion
+		}
+		ctx.stream.Spec.OS = args.os
+		ctx.stream.Spec.Selector = args.selector
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.stream)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid product selector", createArgs{}, true, ""),
+		Entry("should allow OS selector", createArgs{
+			noProduct: true,
+			os: &vmopv1.VirtualMachineImageStreamOSSelector{
+				Type:    "ubuntu64Guest",
+				Version: ">=22.4.0 || >=24.4.0",
+			},
+		}, true, ""),
+		Entry("should allow label selector", createArgs{
+			noProduct: true,
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"channel": "stable"},
+			},
+		}, true, ""),
+		Entry("should deny no selectors", createArgs{noProduct: true}, false,
+			"spec: Required value: at least one of spec.product, spec.os or spec.selector must be set"),
+		Entry("should deny invalid product version range", createArgs{productVersion: ">=five"}, false,
+			"spec.product.version: Invalid value"),
+		Entry("should deny invalid OS version range", createArgs{
+			os: &vmopv1.VirtualMachineImageStreamOSSelector{
+				Version: "latest",
+			},
+		}, false, "spec.os.version: Invalid value"),
+		Entry("should deny invalid label selector", createArgs{
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "channel",
+						Operator: metav1.LabelSelectorOpIn,
+					},
+				},
+			},
+		}, false, "spec.selector.matchExpressions[0].values: Required value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.stream)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the version range is changed", func() {
+		BeforeEach(func() {
+			ctx.stream.Spec.Product.Version = ">=6.0.0"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the version range is changed to an invalid range", func() {
+		BeforeEach(func() {
+			ctx.stream.Spec.Product.Version = "6.x.y"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.product.version: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
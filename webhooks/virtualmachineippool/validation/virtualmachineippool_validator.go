@@ -0,0 +1,183 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	mixedIPFamiliesMsg     = "must be of the same IP family as spec.addresses[0]"
+	invalidIP4PrefixMsg    = "must be less than or equal to 32 for IP4 addresses"
+	invalidGatewayMsg      = "must be an IP address"
+	mixedGatewayFamilyMsg  = "must be of the same IP family as spec.addresses"
+	allocatedAddressFmt    = "must contain the allocated address %s"
+	invalidAddressRangeFmt = "must be an IP address, an IP address range, or a network in CIDR notation: %v"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineippool,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineippools,versions=v1alpha5,name=default.validating.virtualmachineippool.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineippools,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineIPPool{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	pool, err := v.vmIPPoolFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs, _ := v.validateSpec(pool)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	pool, err := v.vmIPPoolFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The addresses may be changed, ex. to grow the pool, so they are
+	// validated the same way as on create. However, the addresses that are
+	// already allocated may not be removed from the pool.
+	fieldErrs, ranges := v.validateSpec(pool)
+	if len(fieldErrs) == 0 {
+		fieldErrs = append(fieldErrs, v.validateAllocations(pool, ranges)...)
+	}
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+// validateSpec ensures the pool's addresses and gateway are valid, and are of
+// the same IP family. The parsed address ranges are returned.
+func (v validator) validateSpec(pool *vmopv1.VirtualMachineIPPool) (field.ErrorList, []pkgutil.IPRange) {
+	var (
+		fieldErrs     field.ErrorList
+		ranges        []pkgutil.IPRange
+		specPath      = field.NewPath("spec")
+		addressesPath = specPath.Child("addresses")
+	)
+
+	for i, s := range pool.Spec.Addresses {
+		r, err := pkgutil.ParseIPRange(s)
+		if err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(addressesPath.Index(i), s,
+				fmt.Sprintf(invalidAddressRangeFmt, err)))
+			continue
+		}
+		if len(ranges) > 0 && ranges[0].First.Is4() != r.First.Is4() {
+			fieldErrs = append(fieldErrs, field.Invalid(addressesPath.Index(i), s, mixedIPFamiliesMsg))
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+
+	if len(ranges) == 0 {
+		return fieldErrs, nil
+	}
+	is4 := ranges[0].First.Is4()
+
+	if is4 && pool.Spec.Prefix > 32 {
+		fieldErrs = append(fieldErrs, field.Invalid(specPath.Child("prefix"), pool.Spec.Prefix, invalidIP4PrefixMsg))
+	}
+
+	if gw := pool.Spec.Gateway; gw != "" {
+		gatewayPath := specPath.Child("gateway")
+		if a, err := netip.ParseAddr(gw); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(gatewayPath, gw, invalidGatewayMsg))
+		} else if a.Unmap().Is4() != is4 {
+			fieldErrs = append(fieldErrs, field.Invalid(gatewayPath, gw, mixedGatewayFamilyMsg))
+		}
+	}
+
+	return fieldErrs, ranges
+}
+
+// validateAllocations ensures the pool's addresses still contain all of the
+// addresses that are allocated from the pool.
+func (v validator) validateAllocations(
+	pool *vmopv1.VirtualMachineIPPool,
+	ranges []pkgutil.IPRange) field.ErrorList {
+
+	var fieldErrs field.ErrorList
+
+	for _, a := range pool.Status.Allocations {
+		addr, err := netip.ParseAddr(a.Address)
+		if err != nil {
+			continue
+		}
+		addr = addr.Unmap()
+
+		contained := false
+		for _, r := range ranges {
+			if !addr.Less(r.First) && !r.Last.Less(addr) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			fieldErrs = append(fieldErrs, field.Forbidden(field.NewPath("spec", "addresses"),
+				fmt.Sprintf(allocatedAddressFmt, a.Address)))
+		}
+	}
+
+	return fieldErrs
+}
+
+// vmIPPoolFromUnstructured returns the VirtualMachineIPPool from the unstructured object.
+func (v validator) vmIPPoolFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineIPPool, error) {
+	pool := &vmopv1.VirtualMachineIPPool{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
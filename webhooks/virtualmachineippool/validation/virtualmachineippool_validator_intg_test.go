@@ -0,0 +1,87 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx  *builder.IntegrationTestContext
+		pool *vmopv1.VirtualMachineIPPool
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		pool = &vmopv1.VirtualMachineIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-ip-pool",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineIPPoolSpec{
+				Addresses: []string{"192.168.0.10-192.168.0.50"},
+				Prefix:    24,
+				Gateway:   "192.168.0.1",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		pool = nil
+	})
+
+	It("should allow a valid pool to be created", func() {
+		Expect(ctx.Client.Create(ctx, pool)).To(Succeed())
+	})
+
+	It("should deny a pool with an invalid gateway", func() {
+		pool.Spec.Gateway = "2001:db8::1"
+		err := ctx.Client.Create(ctx, pool)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.gateway: Invalid value"))
+	})
+
+	It("should deny an update that removes an allocated address", func() {
+		Expect(ctx.Client.Create(ctx, pool)).To(Succeed())
+		pool.Status.Allocations = []vmopv1.VirtualMachineIPPoolAllocation{
+			{
+				Address:            "192.168.0.10",
+				VirtualMachineName: "my-vm",
+				VirtualMachineUID:  "my-vm-uid",
+				InterfaceName:      "eth0",
+			},
+		}
+		Expect(ctx.Client.Status().Update(ctx, pool)).To(Succeed())
+
+		pool.Spec.Addresses = []string{"192.168.0.20-192.168.0.50"}
+		err := ctx.Client.Update(ctx, pool)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must contain the allocated address 192.168.0.10"))
+	})
+}
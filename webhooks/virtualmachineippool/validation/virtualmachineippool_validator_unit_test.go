@@ -0,0 +1,256 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	pool *vmopv1.VirtualMachineIPPool
+}
+
+func newIPPool() *vmopv1.VirtualMachineIPPool {
+	return &vmopv1.VirtualMachineIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-ip-pool",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachineIPPoolSpec{
+			Addresses: []string{
+				"192.168.0.10-192.168.0.50",
+				"192.168.0.100",
+			},
+			Prefix:  24,
+			Gateway: "192.168.0.1",
+		},
+		Status: vmopv1.VirtualMachineIPPoolStatus{
+			Allocations: []vmopv1.VirtualMachineIPPoolAllocation{
+				{
+					Address:            "192.168.0.10",
+					VirtualMachineName: "my-vm",
+					VirtualMachineUID:  "my-vm-uid",
+					InterfaceName:      "eth0",
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	pool := newIPPool()
+	obj, err := builder.ToUnstructured(pool)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(pool.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			pool:                                pool,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		pool:                                pool,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		addresses []string
+		prefix    int32
+		gateway   string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.addresses != nil {
+			ctx.pool.Spec.Addresses = args.addresses
+		}
+		if args.prefix != 0 {
+			ctx.pool.Spec.Prefix = args.prefix
+		}
+		if args.gateway != "" {
+			ctx.pool.Spec.Gateway = args.gateway
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.pool)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid IP4 pool", createArgs{}, true, ""),
+		Entry("should allow IP4 network", createArgs{addresses: []string{"192.168.0.0/25"}}, true, ""),
+		Entry("should allow IP6 pool", createArgs{
+			addresses: []string{"2001:db8::/120"},
+			prefix:    64,
+			gateway:   "2001:db8::1",
+		}, true, ""),
+		Entry("should deny invalid address", createArgs{addresses: []string{"192.168.0"}}, false,
+			`spec.addresses[0]: Invalid value: "192.168.0": must be an IP address, an IP address range, or a network in CIDR notation`),
+		Entry("should deny invalid range", createArgs{addresses: []string{"192.168.0.50-192.168.0.10"}}, false,
+			"ends before it starts"),
+		Entry("should deny mixed IP families", createArgs{addresses: []string{"192.168.0.10", "2001:db8::a"}}, false,
+			`spec.addresses[1]: Invalid value: "2001:db8::a": must be of the same IP family as spec.addresses[0]`),
+		Entry("should deny IP4 prefix greater than 32", createArgs{prefix: 64}, false,
+			"spec.prefix: Invalid value: 64: must be less than or equal to 32 for IP4 addresses"),
+		Entry("should deny invalid gateway", createArgs{gateway: "my-gateway"}, false,
+			`spec.gateway: Invalid value: "my-gateway": must be an IP address`),
+		Entry("should deny gateway of a different IP family", createArgs{gateway: "2001:db8::1"}, false,
+			`spec.gateway: Invalid value: "2001:db8::1": must be of the same IP family as spec.addresses`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.pool)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("addresses are added", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = append(ctx.pool.Spec.Addresses, "192.168.0.200-192.168.0.250")
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("an unallocated address is removed", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = ctx.pool.Spec.Addresses[:1]
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("an allocated address is removed", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = []string{"192.168.0.11-192.168.0.50"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring(
+				"spec.addresses: Forbidden: must contain the allocated address 192.168.0.10"))
+		})
+	})
+
+	When("the addresses are changed to invalid addresses", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = []string{"192.168.0.10-"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.addresses[0]: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
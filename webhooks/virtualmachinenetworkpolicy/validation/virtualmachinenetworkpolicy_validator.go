@@ -0,0 +1,210 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"strings"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	peerRequiredMsg        = "must specify ipBlock, vmSelector, or namespaceSelector"
+	ipBlockWithSelectorMsg = "may not be specified with vmSelector or namespaceSelector"
+	invalidCIDRFmt         = "must be a network in CIDR notation: %v"
+	invalidPortMsg         = "must be between 1 and 65535"
+	endPortRequiresPortMsg = "may only be specified when port is a number"
+	endPortLessThanPortMsg = "must be greater than or equal to port"
+	invalidPortNameMsg     = "must be a valid port name: "
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachinenetworkpolicy,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies,versions=v1alpha5,name=default.validating.virtualmachinenetworkpolicy.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineNetworkPolicy{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.networkPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(policy)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.networkPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// All of the policy's fields may be changed.
+	fieldErrs := v.validateSpec(policy)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) validateSpec(policy *vmopv1.VirtualMachineNetworkPolicy) field.ErrorList {
+	var (
+		fieldErrs field.ErrorList
+		specPath  = field.NewPath("spec")
+	)
+
+	fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+		&policy.Spec.VMSelector,
+		metav1validation.LabelSelectorValidationOptions{},
+		specPath.Child("vmSelector"))...)
+
+	for i, r := range policy.Spec.Ingress {
+		rulePath := specPath.Child("ingress").Index(i)
+		for j := range r.From {
+			fieldErrs = append(fieldErrs, validatePeer(r.From[j], rulePath.Child("from").Index(j))...)
+		}
+		for j := range r.Ports {
+			fieldErrs = append(fieldErrs, validatePort(r.Ports[j], rulePath.Child("ports").Index(j))...)
+		}
+	}
+
+	for i, r := range policy.Spec.Egress {
+		rulePath := specPath.Child("egress").Index(i)
+		for j := range r.To {
+			fieldErrs = append(fieldErrs, validatePeer(r.To[j], rulePath.Child("to").Index(j))...)
+		}
+		for j := range r.Ports {
+			fieldErrs = append(fieldErrs, validatePort(r.Ports[j], rulePath.Child("ports").Index(j))...)
+		}
+	}
+
+	return fieldErrs
+}
+
+// validatePeer ensures the peer specifies either an IP block, or VM and
+// namespace selectors.
+func validatePeer(peer vmopv1.VirtualMachineNetworkPolicyPeer, p *field.Path) field.ErrorList {
+	var (
+		fieldErrs    field.ErrorList
+		hasSelectors = peer.VMSelector != nil || peer.NamespaceSelector != nil
+	)
+
+	if peer.IPBlock == nil {
+		if !hasSelectors {
+			return append(fieldErrs, field.Required(p, peerRequiredMsg))
+		}
+	} else {
+		if hasSelectors {
+			fieldErrs = append(fieldErrs, field.Forbidden(p.Child("ipBlock"), ipBlockWithSelectorMsg))
+		}
+		if _, err := netip.ParsePrefix(peer.IPBlock.CIDR); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(p.Child("ipBlock", "cidr"), peer.IPBlock.CIDR,
+				fmt.Sprintf(invalidCIDRFmt, err)))
+		}
+	}
+
+	opts := metav1validation.LabelSelectorValidationOptions{}
+	if peer.VMSelector != nil {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			peer.VMSelector, opts, p.Child("vmSelector"))...)
+	}
+	if peer.NamespaceSelector != nil {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			peer.NamespaceSelector, opts, p.Child("namespaceSelector"))...)
+	}
+
+	return fieldErrs
+}
+
+// validatePort ensures the port is a valid number or name, and that the end
+// of a port range is not less than its start.
+func validatePort(port vmopv1.VirtualMachineNetworkPolicyPort, p *field.Path) field.ErrorList {
+	var fieldErrs field.ErrorList
+
+	if port.Port != nil {
+		portPath := p.Child("port")
+		if port.Port.Type == intstr.Int {
+			if port.Port.IntVal < 1 || port.Port.IntVal > 65535 {
+				fieldErrs = append(fieldErrs, field.Invalid(portPath, port.Port.IntVal, invalidPortMsg))
+			}
+		} else if errs := validation.IsValidPortName(port.Port.StrVal); len(errs) > 0 {
+			fieldErrs = append(fieldErrs, field.Invalid(portPath, port.Port.StrVal,
+				invalidPortNameMsg+strings.Join(errs, ", ")))
+		}
+	}
+
+	if port.EndPort != nil {
+		endPortPath := p.Child("endPort")
+		switch {
+		case port.Port == nil || port.Port.Type != intstr.Int:
+			fieldErrs = append(fieldErrs, field.Forbidden(endPortPath, endPortRequiresPortMsg))
+		case *port.EndPort > 65535:
+			fieldErrs = append(fieldErrs, field.Invalid(endPortPath, *port.EndPort, invalidPortMsg))
+		case *port.EndPort < port.Port.IntVal:
+			fieldErrs = append(fieldErrs, field.Invalid(endPortPath, *port.EndPort, endPortLessThanPortMsg))
+		}
+	}
+
+	return fieldErrs
+}
+
+// networkPolicyFromUnstructured returns the VirtualMachineNetworkPolicy from
+// the unstructured object.
+func (v validator) networkPolicyFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineNetworkPolicy, error) {
+
+	policy := &vmopv1.VirtualMachineNetworkPolicy{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
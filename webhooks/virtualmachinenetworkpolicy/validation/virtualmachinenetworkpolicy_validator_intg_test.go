@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		policy *vmopv1.VirtualMachineNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		policy = newNetworkPolicy()
+		policy.Namespace = ctx.Namespace
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		policy = nil
+	})
+
+	It("should allow a valid policy to be created", func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+	})
+
+	It("should deny a policy with an invalid peer", func() {
+		policy.Spec.Ingress[0].From = []vmopv1.VirtualMachineNetworkPolicyPeer{
+			{
+				IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0/8"},
+				VMSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "app"},
+				},
+			},
+		}
+		err := ctx.Client.Create(ctx, policy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.ingress[0].from[0].ipBlock: Forbidden"))
+	})
+
+	It("should deny an update with an invalid CIDR", func() {
+		Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+
+		policy.Spec.Ingress[0].From = []vmopv1.VirtualMachineNetworkPolicyPeer{
+			{
+				IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0"},
+			},
+		}
+		err := ctx.Client.Update(ctx, policy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.ingress[0].from[0].ipBlock.cidr: Invalid value"))
+	})
+}
@@ -0,0 +1,286 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	policy *vmopv1.VirtualMachineNetworkPolicy
+}
+
+func newNetworkPolicy() *vmopv1.VirtualMachineNetworkPolicy {
+	return &vmopv1.VirtualMachineNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-network-policy",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+			VMSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "db"},
+			},
+			Ingress: []vmopv1.VirtualMachineNetworkPolicyIngressRule{
+				{
+					From: []vmopv1.VirtualMachineNetworkPolicyPeer{
+						{
+							VMSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "app"},
+							},
+						},
+					},
+					Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+						{
+							Protocol: ptr.To(corev1.ProtocolTCP),
+							Port:     ptr.To(intstr.FromInt32(5432)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	policy := newNetworkPolicy()
+	obj, err := builder.ToUnstructured(policy)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(policy.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			policy:                              policy,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		policy:                              policy,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(
+		mutateFn func(*vmopv1.VirtualMachineNetworkPolicy),
+		expectedAllowed bool,
+		expectedReason string) {
+
+		if mutateFn != nil {
+			mutateFn(ctx.policy)
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	from := func(p vmopv1.VirtualMachineNetworkPolicyPeer) func(*vmopv1.VirtualMachineNetworkPolicy) {
+		return func(policy *vmopv1.VirtualMachineNetworkPolicy) {
+			policy.Spec.Ingress[0].From = []vmopv1.VirtualMachineNetworkPolicyPeer{p}
+		}
+	}
+	ports := func(p vmopv1.VirtualMachineNetworkPolicyPort) func(*vmopv1.VirtualMachineNetworkPolicy) {
+		return func(policy *vmopv1.VirtualMachineNetworkPolicy) {
+			policy.Spec.Ingress[0].Ports = []vmopv1.VirtualMachineNetworkPolicyPort{p}
+		}
+	}
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid policy", nil, true, ""),
+		Entry("should allow empty VM selector", func(policy *vmopv1.VirtualMachineNetworkPolicy) {
+			policy.Spec.VMSelector = metav1.LabelSelector{}
+		}, true, ""),
+		Entry("should allow namespace selector", from(vmopv1.VirtualMachineNetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		}), true, ""),
+		Entry("should allow IP block", from(vmopv1.VirtualMachineNetworkPolicyPeer{
+			IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "2001:db8::/64"},
+		}), true, ""),
+		Entry("should allow named port", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port: ptr.To(intstr.FromString("postgres")),
+		}), true, ""),
+		Entry("should allow port range", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port:    ptr.To(intstr.FromInt32(5000)),
+			EndPort: ptr.To[int32](5010),
+		}), true, ""),
+		Entry("should deny invalid VM selector", func(policy *vmopv1.VirtualMachineNetworkPolicy) {
+			policy.Spec.VMSelector.MatchLabels = map[string]string{"tier": "db!"}
+		}, false, `spec.vmSelector.matchLabels: Invalid value: "db!"`),
+		Entry("should deny empty peer", from(vmopv1.VirtualMachineNetworkPolicyPeer{}), false,
+			"spec.ingress[0].from[0]: Required value: must specify ipBlock, vmSelector, or namespaceSelector"),
+		Entry("should deny IP block with selector", from(vmopv1.VirtualMachineNetworkPolicyPeer{
+			VMSelector: &metav1.LabelSelector{},
+			IPBlock:    &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0/8"},
+		}), false, "spec.ingress[0].from[0].ipBlock: Forbidden: may not be specified with vmSelector or namespaceSelector"),
+		Entry("should deny invalid CIDR", from(vmopv1.VirtualMachineNetworkPolicyPeer{
+			IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0"},
+		}), false, `spec.ingress[0].from[0].ipBlock.cidr: Invalid value: "10.0.0.0": must be a network in CIDR notation`),
+		Entry("should deny invalid egress namespace selector", func(policy *vmopv1.VirtualMachineNetworkPolicy) {
+			policy.Spec.Egress = []vmopv1.VirtualMachineNetworkPolicyEgressRule{
+				{
+					To: []vmopv1.VirtualMachineNetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "env", Operator: metav1.LabelSelectorOpIn},
+								},
+							},
+						},
+					},
+				},
+			}
+		}, false, "spec.egress[0].to[0].namespaceSelector.matchExpressions[0].values: Required value"),
+		Entry("should deny port out of range", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port: ptr.To(intstr.FromInt32(70000)),
+		}), false, "spec.ingress[0].ports[0].port: Invalid value: 70000: must be between 1 and 65535"),
+		Entry("should deny invalid port name", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port: ptr.To(intstr.FromString("not_a_port")),
+		}), false, `spec.ingress[0].ports[0].port: Invalid value: "not_a_port": must be a valid port name`),
+		Entry("should deny end port without port", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			EndPort: ptr.To[int32](5010),
+		}), false, "spec.ingress[0].ports[0].endPort: Forbidden: may only be specified when port is a number"),
+		Entry("should deny end port with named port", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port:    ptr.To(intstr.FromString("postgres")),
+			EndPort: ptr.To[int32](5010),
+		}), false, "spec.ingress[0].ports[0].endPort: Forbidden: may only be specified when port is a number"),
+		Entry("should deny end port less than port", ports(vmopv1.VirtualMachineNetworkPolicyPort{
+			Port:    ptr.To(intstr.FromInt32(5000)),
+			EndPort: ptr.To[int32](4000),
+		}), false, "spec.ingress[0].ports[0].endPort: Invalid value: 4000: must be greater than or equal to port"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the rules are changed", func() {
+		BeforeEach(func() {
+			ctx.policy.Spec.Ingress[0].Ports[0].Port = ptr.To(intstr.FromInt32(3306))
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the rules are changed to invalid rules", func() {
+		BeforeEach(func() {
+			ctx.policy.Spec.Ingress[0].From[0] = vmopv1.VirtualMachineNetworkPolicyPeer{}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.ingress[0].from[0]: Required value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
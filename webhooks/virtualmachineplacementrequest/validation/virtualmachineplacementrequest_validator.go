@@ -0,0 +1,150 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	requiredVMsOrGroupFmt = "one of %s or %s must be set"
+	featureNotEnabledFmt  = "the %s feature is not enabled"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineplacementrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,versions=v1alpha5,name=default.validating.virtualmachineplacementrequest.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachinePlacementRequest{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	placementReq, err := v.vmPlacementRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return common.BuildValidationResponse(ctx, nil,
+		common.ConvertFieldErrorsToStrings(v.validateSpec(ctx, placementReq)), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	placementReq, err := v.vmPlacementRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+	oldPlacementReq, err := v.vmPlacementRequestFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The placement is determined once, so the spec is immutable.
+	fieldErrs := validation.ValidateImmutableField(
+		placementReq.Spec, oldPlacementReq.Spec, field.NewPath("spec"))
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+// validateSpec ensures the request has VMs or a group to place, and that the
+// names of the VMs are valid and unique.
+func (v validator) validateSpec(
+	ctx *pkgctx.WebhookRequestContext,
+	placementReq *vmopv1.VirtualMachinePlacementRequest) field.ErrorList {
+
+	var fieldErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	vmsPath := specPath.Child("virtualMachines")
+	groupNamePath := specPath.Child("groupName")
+
+	if len(placementReq.Spec.VirtualMachines) == 0 && placementReq.Spec.GroupName == "" {
+		fieldErrs = append(fieldErrs, field.Required(specPath,
+			fmt.Sprintf(requiredVMsOrGroupFmt, vmsPath, groupNamePath)))
+	}
+
+	if placementReq.Spec.GroupName != "" {
+		if !pkgcfg.FromContext(ctx).Features.VMGroups {
+			fieldErrs = append(fieldErrs, field.Forbidden(groupNamePath,
+				fmt.Sprintf(featureNotEnabledFmt, "VM Groups")))
+		}
+		for _, msg := range validation.NameIsDNSSubdomain(placementReq.Spec.GroupName, false) {
+			fieldErrs = append(fieldErrs, field.Invalid(groupNamePath, placementReq.Spec.GroupName, msg))
+		}
+	}
+
+	vmNames := sets.New[string]()
+	for i, reqVM := range placementReq.Spec.VirtualMachines {
+		namePath := vmsPath.Index(i).Child("name")
+
+		for _, name := range vmopv1util.PlacementRequestVMNames(reqVM) {
+			if vmNames.Has(name) {
+				fieldErrs = append(fieldErrs, field.Duplicate(namePath, name))
+				continue
+			}
+			vmNames.Insert(name)
+
+			for _, msg := range validation.NameIsDNSSubdomain(name, false) {
+				fieldErrs = append(fieldErrs, field.Invalid(namePath, name, msg))
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+// vmPlacementRequestFromUnstructured returns the VirtualMachinePlacementRequest from the unstructured object.
+func (v validator) vmPlacementRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachinePlacementRequest, error) {
+	placementReq := &vmopv1.VirtualMachinePlacementRequest{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), placementReq); err != nil {
+		return nil, err
+	}
+	return placementReq, nil
+}
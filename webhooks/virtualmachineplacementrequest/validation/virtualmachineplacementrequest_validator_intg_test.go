@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx          *builder.IntegrationTestContext
+		placementReq *vmopv1.VirtualMachinePlacementRequest
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		placementReq = &vmopv1.VirtualMachinePlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-placement-request",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachinePlacementRequestSpec{
+				VirtualMachines: []vmopv1.VirtualMachinePlacementRequestVM{
+					{
+						Name: "web",
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		placementReq = nil
+	})
+
+	It("should allow a valid request to be created", func() {
+		Expect(ctx.Client.Create(ctx, placementReq)).To(Succeed())
+	})
+
+	It("should deny a request with neither VMs nor group", func() {
+		placementReq.Spec.VirtualMachines = nil
+		err := ctx.Client.Create(ctx, placementReq)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("one of spec.virtualMachines or spec.groupName must be set"))
+	})
+
+	It("should deny an update to the spec", func() {
+		Expect(ctx.Client.Create(ctx, placementReq)).To(Succeed())
+		placementReq.Spec.VirtualMachines[0].Replicas = ptr.To[int32](3)
+		err := ctx.Client.Update(ctx, placementReq)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field is immutable"))
+	})
+}
@@ -0,0 +1,242 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	placementReq    *vmopv1.VirtualMachinePlacementRequest
+	oldPlacementReq *vmopv1.VirtualMachinePlacementRequest
+}
+
+func newPlacementRequest() *vmopv1.VirtualMachinePlacementRequest {
+	return &vmopv1.VirtualMachinePlacementRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-placement-request",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachinePlacementRequestSpec{
+			VirtualMachines: []vmopv1.VirtualMachinePlacementRequestVM{
+				{
+					Name:     "web",
+					Replicas: ptr.To[int32](2),
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	placementReq := newPlacementRequest()
+	obj, err := builder.ToUnstructured(placementReq)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldPlacementReq *vmopv1.VirtualMachinePlacementRequest
+	if isUpdate {
+		oldPlacementReq = placementReq.DeepCopy()
+		oldObj, err := builder.ToUnstructured(oldPlacementReq)
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			placementReq:                        placementReq,
+			oldPlacementReq:                     oldPlacementReq,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		placementReq:                        placementReq,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noVMs            bool
+		groupName        string
+		vmGroupsEnabled  bool
+		duplicateName    bool
+		replicaCollision bool
+		invalidName      bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noVMs {
+			ctx.placementReq.Spec.VirtualMachines = nil
+		}
+		ctx.placementReq.Spec.GroupName = args.groupName
+		if args.vmGroupsEnabled {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMGroups = true
+			})
+		}
+		if args.duplicateName {
+			ctx.placementReq.Spec.VirtualMachines = append(ctx.placementReq.Spec.VirtualMachines,
+				vmopv1.VirtualMachinePlacementRequestVM{Name: "web", Replicas: ptr.To[int32](3)})
+		}
+		if args.replicaCollision {
+			ctx.placementReq.Spec.VirtualMachines = append(ctx.placementReq.Spec.VirtualMachines,
+				vmopv1.VirtualMachinePlacementRequestVM{Name: "web-1"})
+		}
+		if args.invalidName {
+			ctx.placementReq.Spec.VirtualMachines[0].Name = "Not_Valid"
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.placementReq)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should deny neither VMs nor group", createArgs{noVMs: true}, false,
+			"spec: Required value: one of spec.virtualMachines or spec.groupName must be set"),
+		Entry("should deny group when VM Groups feature is disabled", createArgs{groupName: "my-group"}, false,
+			"spec.groupName: Forbidden: the VM Groups feature is not enabled"),
+		Entry("should allow group when VM Groups feature is enabled",
+			createArgs{groupName: "my-group", vmGroupsEnabled: true}, true, ""),
+		Entry("should allow only a group", createArgs{noVMs: true, groupName: "my-group", vmGroupsEnabled: true}, true, ""),
+		Entry("should deny invalid group name",
+			createArgs{groupName: "My_Group", vmGroupsEnabled: true}, false, "spec.groupName: Invalid value"),
+		Entry("should deny duplicate VM names", createArgs{duplicateName: true}, false,
+			`spec.virtualMachines[1].name: Duplicate value: "web-0"`),
+		Entry("should deny VM name that collides with a replica name", createArgs{replicaCollision: true}, false,
+			`spec.virtualMachines[1].name: Duplicate value: "web-1"`),
+		Entry("should deny invalid VM name", createArgs{invalidName: true}, false,
+			"spec.virtualMachines[0].name: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.placementReq)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the spec is unchanged", func() {
+		BeforeEach(func() {
+			ctx.placementReq.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the spec is changed", func() {
+		BeforeEach(func() {
+			ctx.placementReq.Spec.VirtualMachines[0].Replicas = ptr.To[int32](5)
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec: Invalid value"))
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,185 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	dateLayout = "2006-01-02"
+
+	selectorAndGroupMutuallyExclusive = "selector and groupName are mutually exclusive"
+	selectorOrGroupRequired           = "one of selector or groupName is required"
+	invalidTimeZoneFmt                = "invalid time zone: %v"
+	invalidScheduleFmt                = "invalid schedule: %v"
+	invalidDateMsg                    = "must be a valid date in the format YYYY-MM-DD"
+	endDateBeforeDateMsg              = "must not be before date"
+	powerOpModeNotAllowedMsg          = "may only be set when the action is PowerOff or Suspend"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachinepowerschedule,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinepowerschedules,versions=v1alpha5,name=default.validating.virtualmachinepowerschedule.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepowerschedules,verbs=get;list;watch
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachinePowerSchedule{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	sched, err := v.scheduleFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(sched)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	sched, err := v.scheduleFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// All of the schedule's fields may be changed. Changes take effect at the
+	// next scheduled window.
+	fieldErrs := v.validateSpec(sched)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) validateSpec(sched *vmopv1.VirtualMachinePowerSchedule) field.ErrorList {
+	var (
+		fieldErrs field.ErrorList
+		spec      = sched.Spec
+		specPath  = field.NewPath("spec")
+	)
+
+	switch {
+	case spec.Selector != nil && spec.GroupName != "":
+		fieldErrs = append(fieldErrs, field.Invalid(
+			specPath.Child("selector"),
+			"selector",
+			selectorAndGroupMutuallyExclusive))
+	case spec.Selector == nil && spec.GroupName == "":
+		fieldErrs = append(fieldErrs, field.Required(
+			specPath.Child("selector"),
+			selectorOrGroupRequired))
+	case spec.Selector != nil:
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			spec.Selector,
+			metav1validation.LabelSelectorValidationOptions{},
+			specPath.Child("selector"))...)
+	}
+
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(
+				specPath.Child("timeZone"),
+				spec.TimeZone,
+				fmt.Sprintf(invalidTimeZoneFmt, err)))
+		}
+	}
+
+	windowsPath := specPath.Child("windows")
+	for i, w := range spec.Windows {
+		if _, err := cron.Parse(w.Schedule); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(
+				windowsPath.Index(i).Child("schedule"),
+				w.Schedule,
+				fmt.Sprintf(invalidScheduleFmt, err)))
+		}
+		if w.PowerOpMode != "" && w.Action == vmopv1.VirtualMachinePowerScheduleActionPowerOn {
+			fieldErrs = append(fieldErrs, field.Forbidden(
+				windowsPath.Index(i).Child("powerOpMode"),
+				powerOpModeNotAllowedMsg))
+		}
+	}
+
+	exclusionsPath := specPath.Child("exclusions")
+	for i, e := range spec.Exclusions {
+		p := exclusionsPath.Index(i)
+
+		date, err := time.Parse(dateLayout, e.Date)
+		if err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(
+				p.Child("date"), e.Date, invalidDateMsg))
+		}
+
+		if e.EndDate == "" {
+			continue
+		}
+		endDate, endErr := time.Parse(dateLayout, e.EndDate)
+		switch {
+		case endErr != nil:
+			fieldErrs = append(fieldErrs, field.Invalid(
+				p.Child("endDate"), e.EndDate, invalidDateMsg))
+		case err == nil && endDate.Before(date):
+			fieldErrs = append(fieldErrs, field.Invalid(
+				p.Child("endDate"), e.EndDate, endDateBeforeDateMsg))
+		}
+	}
+
+	return fieldErrs
+}
+
+// scheduleFromUnstructured returns the VirtualMachinePowerSchedule from the
+// unstructured object.
+func (v validator) scheduleFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachinePowerSchedule, error) {
+
+	sched := &vmopv1.VirtualMachinePowerSchedule{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), sched); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
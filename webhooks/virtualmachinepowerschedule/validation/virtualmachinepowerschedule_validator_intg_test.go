@@ -0,0 +1,62 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx   *builder.IntegrationTestContext
+		sched *vmopv1.VirtualMachinePowerSchedule
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		sched = newPowerSchedule()
+		sched.Namespace = ctx.Namespace
+	})
+
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, sched)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+		sched = nil
+	})
+
+	It("should allow a valid schedule to be created", func() {
+		Expect(ctx.Client.Create(ctx, sched)).To(Succeed())
+	})
+
+	It("should deny an update with an invalid time zone", func() {
+		Expect(ctx.Client.Create(ctx, sched)).To(Succeed())
+
+		sched.Spec.TimeZone = "Mars/Olympus_Mons"
+		err := ctx.Client.Update(ctx, sched)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.timeZone: Invalid value"))
+	})
+}
@@ -0,0 +1,250 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	sched *vmopv1.VirtualMachinePowerSchedule
+}
+
+func newPowerSchedule() *vmopv1.VirtualMachinePowerSchedule {
+	return &vmopv1.VirtualMachinePowerSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-power-schedule",
+			Namespace: "dummy-namespace",
+		},
+		Spec: vmopv1.VirtualMachinePowerScheduleSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "dev"},
+			},
+			TimeZone: "America/Los_Angeles",
+			Windows: []vmopv1.VirtualMachinePowerScheduleWindow{
+				{
+					Name:        "evening",
+					Schedule:    "0 19 * * MON-FRI",
+					Action:      vmopv1.VirtualMachinePowerScheduleActionPowerOff,
+					PowerOpMode: vmopv1.VirtualMachinePowerOpModeTrySoft,
+				},
+				{
+					Name:     "morning",
+					Schedule: "0 7 * * MON-FRI",
+					Action:   vmopv1.VirtualMachinePowerScheduleActionPowerOn,
+				},
+			},
+			Exclusions: []vmopv1.VirtualMachinePowerScheduleExclusion{
+				{
+					Name:    "winter-break",
+					Date:    "2026-12-24",
+					EndDate: "2027-01-01",
+				},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	sched := newPowerSchedule()
+	obj, err := builder.ToUnstructured(sched)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(sched.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			sched:                               sched,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		sched:                               sched,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(
+		mutateFn func(*vmopv1.VirtualMachinePowerSchedule),
+		expectedAllowed bool,
+		expectedReason string) {
+
+		if mutateFn != nil {
+			mutateFn(ctx.sched)
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.sched)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid schedule", nil, true, ""),
+		Entry("should allow schedule with empty selector", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Selector = &metav1.LabelSelector{}
+		}, true, ""),
+		Entry("should allow schedule with group", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Selector = nil
+			sched.Spec.GroupName = "my-group"
+		}, true, ""),
+		Entry("should allow exclusion without end date", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Exclusions[0].EndDate = ""
+		}, true, ""),
+		Entry("should deny selector and group", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.GroupName = "my-group"
+		}, false, `spec.selector: Invalid value: "selector": selector and groupName are mutually exclusive`),
+		Entry("should deny schedule without selector or group", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Selector = nil
+		}, false, "spec.selector: Required value: one of selector or groupName is required"),
+		Entry("should deny invalid selector", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Selector.MatchLabels = map[string]string{"env": "dev!"}
+		}, false, `spec.selector.matchLabels: Invalid value: "dev!"`),
+		Entry("should deny invalid time zone", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.TimeZone = "Mars/Olympus_Mons"
+		}, false, `spec.timeZone: Invalid value: "Mars/Olympus_Mons": invalid time zone`),
+		Entry("should deny invalid schedule", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Windows[1].Schedule = "0 25 * * *"
+		}, false, `spec.windows[1].schedule: Invalid value: "0 25 * * *": invalid schedule: invalid hour`),
+		Entry("should deny power op mode with power on", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Windows[1].PowerOpMode = vmopv1.VirtualMachinePowerOpModeHard
+		}, false, "spec.windows[1].powerOpMode: Forbidden: may only be set when the action is PowerOff or Suspend"),
+		Entry("should deny invalid date", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Exclusions[0].Date = "2026-02-30"
+		}, false, `spec.exclusions[0].date: Invalid value: "2026-02-30": must be a valid date in the format YYYY-MM-DD`),
+		Entry("should deny end date before date", func(sched *vmopv1.VirtualMachinePowerSchedule) {
+			sched.Spec.Exclusions[0].EndDate = "2026-12-01"
+		}, false, `spec.exclusions[0].endDate: Invalid value: "2026-12-01": must not be before date`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.sched)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the schedule is paused", func() {
+		BeforeEach(func() {
+			ctx.sched.Spec.Paused = true
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("a window's schedule is changed to an invalid value", func() {
+		BeforeEach(func() {
+			ctx.sched.Spec.Windows[0].Schedule = "@daily"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.windows[0].schedule: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,137 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	invalidNameserverMsg         = "must be an IPv4 or IPv6 address"
+	cloudConfigMutuallyExclusive = "cloudConfig and rawCloudConfig are mutually exclusive"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachineprofile,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineprofiles,versions=v1alpha5,name=default.validating.virtualmachineprofile.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineprofiles,verbs=get;list;watch
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineProfile{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	profile, err := v.profileFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	fieldErrs := v.validateSpec(profile)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) ValidateDelete(_ *pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	profile, err := v.profileFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// All of the profile's fields may be changed since the values of a
+	// profile are only set on VMs when they are created or updated.
+	fieldErrs := v.validateSpec(profile)
+
+	return common.BuildValidationResponse(ctx, nil, common.ConvertFieldErrorsToStrings(fieldErrs), nil)
+}
+
+func (v validator) validateSpec(profile *vmopv1.VirtualMachineProfile) field.ErrorList {
+	var (
+		fieldErrs field.ErrorList
+		specPath  = field.NewPath("spec")
+	)
+
+	if profile.Spec.Selector != nil {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			profile.Spec.Selector,
+			metav1validation.LabelSelectorValidationOptions{},
+			specPath.Child("selector"))...)
+	}
+
+	if bs := profile.Spec.Bootstrap; bs != nil && bs.CloudInit != nil {
+		if bs.CloudInit.CloudConfig != nil && bs.CloudInit.RawCloudConfig != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(
+				specPath.Child("bootstrap", "cloudInit"),
+				"cloudConfig",
+				cloudConfigMutuallyExclusive))
+		}
+	}
+
+	if network := profile.Spec.Network; network != nil {
+		nameserversPath := specPath.Child("network", "nameservers")
+		for i, ns := range network.Nameservers {
+			if net.ParseIP(ns) == nil {
+				fieldErrs = append(fieldErrs, field.Invalid(
+					nameserversPath.Index(i), ns, invalidNameserverMsg))
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+// profileFromUnstructured returns the VirtualMachineProfile from the
+// unstructured object.
+func (v validator) profileFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineProfile, error) {
+
+	profile := &vmopv1.VirtualMachineProfile{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,62 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Validate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidate,
+	)
+}
+
+func intgTestsValidate() {
+	var (
+		ctx     *builder.IntegrationTestContext
+		profile *vmopv1.VirtualMachineProfile
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		profile = newProfile()
+		profile.Namespace = ctx.Namespace
+	})
+
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, profile)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+		profile = nil
+	})
+
+	It("should allow a valid profile to be created", func() {
+		Expect(ctx.Client.Create(ctx, profile)).To(Succeed())
+	})
+
+	It("should deny an update with an invalid nameserver", func() {
+		Expect(ctx.Client.Create(ctx, profile)).To(Succeed())
+
+		profile.Spec.Network.Nameservers = []string{"not-an-ip"}
+		err := ctx.Client.Update(ctx, profile)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.network.nameservers[0]: Invalid value"))
+	})
+}
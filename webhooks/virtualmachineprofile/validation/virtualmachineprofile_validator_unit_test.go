@@ -0,0 +1,232 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha5"
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha5/cloudinit"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha5/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	profile *vmopv1.VirtualMachineProfile
+}
+
+func newProfile() *vmopv1.VirtualMachineProfile {
+	return &vmopv1.VirtualMachineProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-profile",
+			Namespace: "dummy-namespace",
+		},
+		Spec: vmopv1.VirtualMachineProfileSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "blue"},
+			},
+			Priority:     10,
+			StorageClass: "gold",
+			Network: &vmopv1.VirtualMachineProfileNetworkSpec{
+				Nameservers:   []string{"10.0.0.1", "fd00::1"},
+				SearchDomains: []string{"example.local"},
+			},
+		},
+	}
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	profile := newProfile()
+	obj, err := builder.ToUnstructured(profile)
+	Expect(err).ToNot(HaveOccurred())
+
+	if isUpdate {
+		oldObj, err := builder.ToUnstructured(profile.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		return &unitValidatingWebhookContext{
+			UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+			profile:                             profile,
+		}
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, nil),
+		profile:                             profile,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(
+		mutateFn func(*vmopv1.VirtualMachineProfile),
+		expectedAllowed bool,
+		expectedReason string) {
+
+		if mutateFn != nil {
+			mutateFn(ctx.profile)
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.profile)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create", validateCreate,
+		Entry("should allow valid profile", nil, true, ""),
+		Entry("should allow profile without selector", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Selector = nil
+		}, true, ""),
+		Entry("should allow profile with empty selector", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Selector = &metav1.LabelSelector{}
+		}, true, ""),
+		Entry("should allow profile with cloud-config", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+				CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+					CloudConfig: &vmopv1cloudinit.CloudConfig{
+						Users: []vmopv1cloudinit.User{{Name: "admin"}},
+					},
+				},
+			}
+		}, true, ""),
+		Entry("should deny invalid selector", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Selector.MatchLabels = map[string]string{"team": "blue!"}
+		}, false, `spec.selector.matchLabels: Invalid value: "blue!"`),
+		Entry("should deny invalid nameserver", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Network.Nameservers[1] = "not-an-ip"
+		}, false, `spec.network.nameservers[1]: Invalid value: "not-an-ip": must be an IPv4 or IPv6 address`),
+		Entry("should deny cloud-config and raw cloud-config", func(profile *vmopv1.VirtualMachineProfile) {
+			profile.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+				CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+					CloudConfig:    &vmopv1cloudinit.CloudConfig{},
+					RawCloudConfig: &vmopv1common.SecretKeySelector{Name: "my-secret", Key: "user-data"},
+				},
+			}
+		}, false, "spec.bootstrap.cloudInit: Invalid value: \"cloudConfig\": cloudConfig and rawCloudConfig are mutually exclusive"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.profile)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the storage class is changed", func() {
+		BeforeEach(func() {
+			ctx.profile.Spec.StorageClass = "silver"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the nameservers are changed to invalid values", func() {
+		BeforeEach(func() {
+			ctx.profile.Spec.Network.Nameservers = []string{"10.0.0.256"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.network.nameservers[0]: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
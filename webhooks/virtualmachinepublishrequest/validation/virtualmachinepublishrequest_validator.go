@@ -20,6 +20,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/google/go-containerregistry/pkg/name"
+
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 	imgregv1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha2"
 
@@ -86,7 +88,11 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	var fieldErrs field.ErrorList
 
 	fieldErrs = append(fieldErrs, v.validateSource(ctx, vmpub)...)
-	fieldErrs = append(fieldErrs, v.validateTargetLocation(ctx, vmpub)...)
+	if vmpub.Spec.Target.OCI != nil || vmpub.Spec.Target.HTTP != nil {
+		fieldErrs = append(fieldErrs, v.validateArtifactTarget(ctx, vmpub)...)
+	} else {
+		fieldErrs = append(fieldErrs, v.validateTargetLocation(ctx, vmpub)...)
+	}
 	// Validate that users are not adding any quota related annotations.
 	fieldErrs = append(fieldErrs, v.validateAsyncQuotaAnnotations(ctx, vmpub, nil)...)
 
@@ -188,6 +194,42 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 
 // validateCreateVMGroupPublishRequestOwnership ensures a VirtualMachineGroupPublishRequest owner reference is set
 // when a VirtualMachinePublishRequest has a managed by VirtualMachineGroupPublishRequest label.
+// validateArtifactTarget validates a target that is an OCI registry or a
+// pre-signed HTTP URL instead of a content library.
+func (v validator) validateArtifactTarget(_ *pkgctx.WebhookRequestContext, vmpub *vmopv1.VirtualMachinePublishRequest) field.ErrorList {
+	var (
+		allErrs    field.ErrorList
+		target     = vmpub.Spec.Target
+		targetPath = field.NewPath("spec").Child("target")
+	)
+
+	if target.OCI != nil && target.HTTP != nil {
+		allErrs = append(allErrs, field.Forbidden(targetPath.Child("http"),
+			"only one of oci or http may be specified"))
+	}
+
+	if target.Location.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(targetPath.Child("location", "name"),
+			"may not be specified when oci or http is specified"))
+	}
+
+	if oci := target.OCI; oci != nil {
+		refPath := targetPath.Child("oci", "reference")
+		if oci.Reference == "" {
+			allErrs = append(allErrs, field.Required(refPath, ""))
+		} else if _, err := name.NewTag(oci.Reference, name.StrictValidation); err != nil {
+			allErrs = append(allErrs, field.Invalid(refPath, oci.Reference,
+				fmt.Sprintf("must be a reference with a tag: %v", err)))
+		}
+	}
+
+	if target.HTTP != nil && target.HTTP.URLSecretName == "" {
+		allErrs = append(allErrs, field.Required(targetPath.Child("http", "urlSecretName"), ""))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateCreateVMGroupPublishRequestOwnership(vmPub *vmopv1.VirtualMachinePublishRequest) error {
 	if !metav1.HasLabel(vmPub.ObjectMeta, vmopv1.VirtualMachinePublishRequestManagedByLabelKey) {
 		return nil
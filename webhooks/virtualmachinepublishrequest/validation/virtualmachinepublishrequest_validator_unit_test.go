@@ -113,6 +113,11 @@ func unitTestsValidateCreate() {
 		targetLocationNotFound          bool
 		targetItemAlreadyExists         bool
 		managedByLabelExists            bool
+		ociTarget                       bool
+		ociReference                    string
+		httpTarget                      bool
+		httpURLSecretName               string
+		targetLocationNameNotEmpty      bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -158,6 +163,22 @@ func unitTestsValidateCreate() {
 			Expect(ctx.Client.Delete(ctx, ctx.cl)).To(Succeed())
 		}
 
+		if args.ociTarget || args.httpTarget {
+			if !args.targetLocationNameNotEmpty {
+				ctx.vmPub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{}
+			}
+			if args.ociTarget {
+				ctx.vmPub.Spec.Target.OCI = &vmopv1.VirtualMachinePublishRequestOCITarget{
+					Reference: args.ociReference,
+				}
+			}
+			if args.httpTarget {
+				ctx.vmPub.Spec.Target.HTTP = &vmopv1.VirtualMachinePublishRequestHTTPTarget{
+					URLSecretName: args.httpURLSecretName,
+				}
+			}
+		}
+
 		if args.targetItemAlreadyExists {
 			clItem := utils.DummyContentLibraryItem("dummy-item", ctx.vmPub.Namespace)
 			Expect(ctx.Client.Create(ctx, clItem)).To(Succeed())
@@ -188,6 +209,8 @@ func unitTestsValidateCreate() {
 
 	sourcePath := field.NewPath("spec").Child("source")
 	targetLocationPath := field.NewPath("spec").Child("target", "location")
+	targetOCIPath := field.NewPath("spec").Child("target", "oci")
+	targetHTTPPath := field.NewPath("spec").Child("target", "http")
 	DescribeTable("create table", validateCreate,
 		Entry("should allow valid", createArgs{}, true, nil, nil),
 		Entry("should deny invalid source API version", createArgs{invalidSourceAPIVersion: true}, false,
@@ -207,6 +230,18 @@ func unitTestsValidateCreate() {
 		Entry("should deny if managed-by label is set by a non privileged user", createArgs{managedByLabelExists: true}, false,
 			"", fmt.Errorf("cannot add the %q label without a VirtualMachineGroupPublishRequest owner reference",
 				vmopv1.VirtualMachinePublishRequestManagedByLabelKey)),
+		Entry("should allow an oci target", createArgs{ociTarget: true, ociReference: "registry.example.com/images/my-vm:v1"}, true, nil, nil),
+		Entry("should allow an http target", createArgs{httpTarget: true, httpURLSecretName: "my-url"}, true, nil, nil),
+		Entry("should deny an oci target without a reference", createArgs{ociTarget: true}, false,
+			field.Required(targetOCIPath.Child("reference"), "").Error(), nil),
+		Entry("should deny an oci target without a tag", createArgs{ociTarget: true, ociReference: "registry.example.com/images/my-vm"}, false,
+			"spec.target.oci.reference: Invalid value: \"registry.example.com/images/my-vm\": must be a reference with a tag", nil),
+		Entry("should deny an http target without a url secret name", createArgs{httpTarget: true}, false,
+			field.Required(targetHTTPPath.Child("urlSecretName"), "").Error(), nil),
+		Entry("should deny both an oci and an http target", createArgs{ociTarget: true, ociReference: "registry.example.com/images/my-vm:v1", httpTarget: true, httpURLSecretName: "my-url"}, false,
+			field.Forbidden(targetHTTPPath, "only one of oci or http may be specified").Error(), nil),
+		Entry("should deny a target location name with an oci target", createArgs{ociTarget: true, ociReference: "registry.example.com/images/my-vm:v1", targetLocationNameNotEmpty: true}, false,
+			field.Forbidden(targetLocationPath.Child("name"), "may not be specified when oci or http is specified").Error(), nil),
 	)
 
 	type createArgsAnnotations struct {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +28,12 @@ import (
 
 const (
 	webHookName = "default"
+
+	maxHookTimeout = 10 * time.Minute
+
+	hookActionRequired  = "one of command or http is required"
+	hookActionExclusive = "command and http are mutually exclusive"
+	hookTimeoutFmt      = "must be greater than 0 and not more than %s"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha5-virtualmachinesnapshot,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,versions=v1alpha5,name=default.validating.virtualmachinesnapshot.v1alpha5.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -81,6 +88,12 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 		}
 	}
 
+	specPath := field.NewPath("spec")
+	fieldErrs = append(fieldErrs, v.validateHooks(
+		vmSnapshot.Spec.PreSnapshotHooks, specPath.Child("preSnapshotHooks"))...)
+	fieldErrs = append(fieldErrs, v.validateHooks(
+		vmSnapshot.Spec.PostSnapshotHooks, specPath.Child("postSnapshotHooks"))...)
+
 	validationErrs := make([]string, 0)
 	for _, fieldErr := range fieldErrs {
 		validationErrs = append(validationErrs, fieldErr.Error())
@@ -111,6 +124,8 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(vmSnapshot.Spec.Memory, oldVMSnapshot.Spec.Memory, field.NewPath("spec", "memory"))...)
 	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(vmSnapshot.Spec.Quiesce, oldVMSnapshot.Spec.Quiesce, field.NewPath("spec", "quiesce"))...)
 	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(vmSnapshot.Spec.VMName, oldVMSnapshot.Spec.VMName, field.NewPath("spec", "vmName"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(vmSnapshot.Spec.PreSnapshotHooks, oldVMSnapshot.Spec.PreSnapshotHooks, field.NewPath("spec", "preSnapshotHooks"))...)
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(vmSnapshot.Spec.PostSnapshotHooks, oldVMSnapshot.Spec.PostSnapshotHooks, field.NewPath("spec", "postSnapshotHooks"))...)
 	fieldErrs = append(fieldErrs, v.validateImmutableVMNameLabel(ctx, vmSnapshot, oldVMSnapshot)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
@@ -121,6 +136,59 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
 }
 
+// validateHooks validates the pre- or post-snapshot hooks.
+func (v validator) validateHooks(
+	hooks []vmopv1.VirtualMachineSnapshotHook,
+	hooksPath *field.Path) field.ErrorList {
+
+	var (
+		fieldErrs field.ErrorList
+		names     = map[string]struct{}{}
+	)
+
+	for i, hook := range hooks {
+		hookPath := hooksPath.Index(i)
+
+		if hook.Name == "" {
+			fieldErrs = append(fieldErrs, field.Required(hookPath.Child("name"), ""))
+		} else if _, ok := names[hook.Name]; ok {
+			fieldErrs = append(fieldErrs, field.Duplicate(hookPath.Child("name"), hook.Name))
+		}
+		names[hook.Name] = struct{}{}
+
+		switch {
+		case hook.Command == nil && hook.HTTP == nil:
+			fieldErrs = append(fieldErrs, field.Required(hookPath, hookActionRequired))
+		case hook.Command != nil && hook.HTTP != nil:
+			fieldErrs = append(fieldErrs, field.Invalid(hookPath.Child("command"), "command", hookActionExclusive))
+		case hook.Command != nil:
+			commandPath := hookPath.Child("command")
+			if hook.Command.Path == "" {
+				fieldErrs = append(fieldErrs, field.Required(commandPath.Child("path"), ""))
+			}
+			if hook.Command.CredentialsSecretName == "" {
+				fieldErrs = append(fieldErrs, field.Required(commandPath.Child("credentialsSecretName"), ""))
+			}
+			for j, e := range hook.Command.Env {
+				if e.Name == "" {
+					fieldErrs = append(fieldErrs, field.Required(commandPath.Child("env").Index(j).Child("name"), ""))
+				}
+			}
+		case hook.HTTP != nil:
+			if p := hook.HTTP.Port; p < 1 || p > 65535 {
+				fieldErrs = append(fieldErrs, field.Invalid(hookPath.Child("http", "port"), p, "must be between 1 and 65535"))
+			}
+		}
+
+		if t := hook.Timeout; t != nil && (t.Duration <= 0 || t.Duration > maxHookTimeout) {
+			fieldErrs = append(fieldErrs, field.Invalid(
+				hookPath.Child("timeout"), t.Duration.String(), fmt.Sprintf(hookTimeoutFmt, maxHookTimeout)))
+		}
+	}
+
+	return fieldErrs
+}
+
 // validateImmutableVMNameLabel validates that the label on the
 // VirtualMachineSnapshot resource pointing to the VM can never be
 // changed. This label is set by the mutation webhook during creation
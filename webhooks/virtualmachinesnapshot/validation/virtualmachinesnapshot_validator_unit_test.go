@@ -90,6 +90,8 @@ func unitTestsValidateCreate() {
 	type createArgs struct {
 		emptyVMName   bool
 		createVKSNode bool
+		preHooks      []vmopv1.VirtualMachineSnapshotHook
+		postHooks     []vmopv1.VirtualMachineSnapshotHook
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -97,6 +99,9 @@ func unitTestsValidateCreate() {
 			ctx.vmSnapshot.Spec.VMName = ""
 		}
 
+		ctx.vmSnapshot.Spec.PreSnapshotHooks = args.preHooks
+		ctx.vmSnapshot.Spec.PostSnapshotHooks = args.postHooks
+
 		if args.createVKSNode {
 			// Create a VM with CAPI labels to simulate a VKS/TKG node
 			vm := builder.DummyBasicVirtualMachine(ctx.vmSnapshot.Spec.VMName, ctx.vmSnapshot.Namespace)
@@ -128,6 +133,8 @@ func unitTestsValidateCreate() {
 	})
 
 	vmNameField := field.NewPath("spec", "vmName")
+	preHooksField := field.NewPath("spec", "preSnapshotHooks")
+	postHooksField := field.NewPath("spec", "postSnapshotHooks")
 
 	DescribeTable("create table", validateCreate,
 		Entry("should allow valid",
@@ -148,9 +155,111 @@ func unitTestsValidateCreate() {
 			field.Forbidden(vmNameField, "snapshots are not allowed for VKS/TKG nodes").Error(),
 			nil,
 		),
+		Entry("should allow valid command and http hooks",
+			createArgs{
+				preHooks:  []vmopv1.VirtualMachineSnapshotHook{commandHook("freeze")},
+				postHooks: []vmopv1.VirtualMachineSnapshotHook{httpHook("thaw")},
+			},
+			true,
+			nil,
+			nil,
+		),
+		Entry("should deny hook without command or http",
+			createArgs{
+				preHooks: []vmopv1.VirtualMachineSnapshotHook{{Name: "freeze"}},
+			},
+			false,
+			field.Required(preHooksField.Index(0), "one of command or http is required").Error(),
+			nil,
+		),
+		Entry("should deny hook with command and http",
+			createArgs{
+				preHooks: []vmopv1.VirtualMachineSnapshotHook{
+					func() vmopv1.VirtualMachineSnapshotHook {
+						h := commandHook("freeze")
+						h.HTTP = httpHook("freeze").HTTP
+						return h
+					}(),
+				},
+			},
+			false,
+			field.Invalid(preHooksField.Index(0).Child("command"), "command", "command and http are mutually exclusive").Error(),
+			nil,
+		),
+		Entry("should deny duplicate hook names",
+			createArgs{
+				postHooks: []vmopv1.VirtualMachineSnapshotHook{httpHook("thaw"), commandHook("thaw")},
+			},
+			false,
+			field.Duplicate(postHooksField.Index(1).Child("name"), "thaw").Error(),
+			nil,
+		),
+		Entry("should deny command hook without credentials",
+			createArgs{
+				preHooks: []vmopv1.VirtualMachineSnapshotHook{
+					func() vmopv1.VirtualMachineSnapshotHook {
+						h := commandHook("freeze")
+						h.Command.CredentialsSecretName = ""
+						return h
+					}(),
+				},
+			},
+			false,
+			field.Required(preHooksField.Index(0).Child("command", "credentialsSecretName"), "").Error(),
+			nil,
+		),
+		Entry("should deny http hook with invalid port",
+			createArgs{
+				postHooks: []vmopv1.VirtualMachineSnapshotHook{
+					func() vmopv1.VirtualMachineSnapshotHook {
+						h := httpHook("thaw")
+						h.HTTP.Port = 0
+						return h
+					}(),
+				},
+			},
+			false,
+			field.Invalid(postHooksField.Index(0).Child("http", "port"), int32(0), "must be between 1 and 65535").Error(),
+			nil,
+		),
+		Entry("should deny hook timeout greater than 10 minutes",
+			createArgs{
+				preHooks: []vmopv1.VirtualMachineSnapshotHook{
+					func() vmopv1.VirtualMachineSnapshotHook {
+						h := commandHook("freeze")
+						h.Timeout = &metav1.Duration{Duration: 11 * time.Minute}
+						return h
+					}(),
+				},
+			},
+			false,
+			field.Invalid(preHooksField.Index(0).Child("timeout"), "11m0s", "must be greater than 0 and not more than 10m0s").Error(),
+			nil,
+		),
 	)
 }
 
+func commandHook(name string) vmopv1.VirtualMachineSnapshotHook {
+	return vmopv1.VirtualMachineSnapshotHook{
+		Name: name,
+		Command: &vmopv1.VirtualMachineSnapshotHookCommand{
+			Path:                  "/usr/sbin/fsfreeze",
+			Args:                  []string{"--freeze", "/data"},
+			CredentialsSecretName: "guest-creds",
+		},
+	}
+}
+
+func httpHook(name string) vmopv1.VirtualMachineSnapshotHook {
+	return vmopv1.VirtualMachineSnapshotHook{
+		Name: name,
+		HTTP: &vmopv1.VirtualMachineSnapshotHookHTTP{
+			Port: 8080,
+			Path: "/hooks/" + name,
+		},
+	}
+}
+
 func unitTestsValidateUpdate() {
 	var (
 		ctx     *unitValidatingWebhookContext
@@ -162,6 +271,7 @@ func unitTestsValidateUpdate() {
 		updateQuiesce     bool
 		updateVMRef       bool
 		updateVMNameLabel bool
+		updateHooks       bool
 	}
 
 	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -179,6 +289,10 @@ func unitTestsValidateUpdate() {
 			ctx.vmSnapshot.Spec.VMName = "another-vm"
 		}
 
+		if args.updateHooks {
+			ctx.vmSnapshot.Spec.PreSnapshotHooks = []vmopv1.VirtualMachineSnapshotHook{commandHook("freeze")}
+		}
+
 		if args.updateVMNameLabel {
 			// Try to change the VM name label to a different value
 			metav1.SetMetaDataLabel(&ctx.vmSnapshot.ObjectMeta, vmopv1.VMNameForSnapshotLabel, "different-vm-name")
@@ -240,6 +354,12 @@ func unitTestsValidateUpdate() {
 			"field is immutable",
 			nil,
 		),
+		Entry("should not allow updating hooks",
+			updateArgs{updateHooks: true},
+			false,
+			"field is immutable",
+			nil,
+		),
 	)
 }
 
@@ -15,9 +15,18 @@ import (
 	"github.com/vmware-tanzu/vm-operator/webhooks/persistentvolumeclaim"
 	"github.com/vmware-tanzu/vm-operator/webhooks/unifiedstoragequota"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineadmissionpolicy"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclass"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinegroup"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinegrouppublishrequest"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestoperation"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimageimport"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimagestream"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineippool"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinenetworkpolicy"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineplacementrequest"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepowerschedule"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineprofile"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinereplicaset"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineservice"
@@ -37,9 +46,36 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 	if err := virtualmachine.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachine webhooks: %w", err)
 	}
+	if err := virtualmachineadmissionpolicy.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineAdmissionPolicy webhooks: %w", err)
+	}
 	if err := virtualmachineclass.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachineClass webhooks: %w", err)
 	}
+	if err := virtualmachineguestoperation.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineGuestOperation webhooks: %w", err)
+	}
+	if err := virtualmachineimageimport.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageImport webhooks: %w", err)
+	}
+	if err := virtualmachineimagestream.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageStream webhooks: %w", err)
+	}
+	if err := virtualmachineippool.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineIPPool webhooks: %w", err)
+	}
+	if err := virtualmachinenetworkpolicy.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineNetworkPolicy webhooks: %w", err)
+	}
+	if err := virtualmachineplacementrequest.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachinePlacementRequest webhooks: %w", err)
+	}
+	if err := virtualmachinepowerschedule.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachinePowerSchedule webhooks: %w", err)
+	}
+	if err := virtualmachineprofile.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineProfile webhooks: %w", err)
+	}
 	if err := virtualmachinepublishrequest.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachinePublishRequest webhooks: %w", err)
 	}